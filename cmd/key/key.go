@@ -21,7 +21,8 @@ const (
 )
 
 type Config struct {
-	Database database.Config
+	Database    database.Config
+	KeyProvider *ProviderConfig
 }
 
 func New() *cobra.Command {
@@ -31,6 +32,7 @@ func New() *cobra.Command {
 	}
 	AddMasterKeyFlag(cmd)
 	cmd.AddCommand(newKey())
+	cmd.AddCommand(newRewrap())
 	return cmd
 }
 
@@ -66,11 +68,11 @@ new -f keys.yaml key2=anotherkey`,
 			if err := viper.Unmarshal(config); err != nil {
 				return err
 			}
-			masterKey, err := MasterKey(cmd)
+			masterKey, err := MasterKeyIfRequired(cmd, config.KeyProvider)
 			if err != nil {
 				return err
 			}
-			storage, err := keyStorage(config.Database, masterKey)
+			storage, err := keyStorage(config.Database, masterKey, config.KeyProvider)
 			if err != nil {
 				return err
 			}
@@ -81,6 +83,69 @@ new -f keys.yaml key2=anotherkey`,
 	return cmd
 }
 
+func newRewrap() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewrap",
+		Short: "wrap the encryption keys by the key provider",
+		Long: `wrap the encryption keys stored in the database by the key (KeyProvider.WrappingKeyID) of the key provider instead of the masterkey.
+Afterwards ZITADEL can be started without masterkey.
+Requirements:
+- cockroachdb
+- key provider with the wrapping key`,
+		Example: `rewrap --masterkeyFromEnv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := new(Config)
+			if err := viper.Unmarshal(config); err != nil {
+				return err
+			}
+			if !config.KeyProvider.wrapsKeys() {
+				return caos_errs.ThrowPreconditionFailed(nil, "KEY-oo4Ei", "no wrapping key of the key provider configured")
+			}
+			masterKey, err := MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			db, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			provider, err := NewProvider(config.KeyProvider)
+			if err != nil {
+				return err
+			}
+			source, err := cryptoDB.NewKeyStorage(db.DB, masterKey)
+			if err != nil {
+				return err
+			}
+			target, err := cryptoDB.NewProviderKeyStorage(db.DB, provider, config.KeyProvider.WrappingKeyID)
+			if err != nil {
+				return err
+			}
+			return rewrap(source, target)
+		},
+	}
+}
+
+type keyUpdater interface {
+	UpdateKeys(...*crypto.Key) error
+}
+
+// rewrap reads all keys of the source storage and replaces their stored values by the target storage
+func rewrap(source crypto.KeyStorage, target keyUpdater) error {
+	keys, err := source.ReadKeys()
+	if err != nil {
+		return err
+	}
+	rewrapped := make([]*crypto.Key, 0, len(keys))
+	for id, value := range keys {
+		rewrapped = append(rewrapped, &crypto.Key{
+			ID:    id,
+			Value: value,
+		})
+	}
+	return target.UpdateKeys(rewrapped...)
+}
+
 func keysFromArgs(args []string) ([]*crypto.Key, error) {
 	keys := make([]*crypto.Key, len(args))
 	for i, arg := range args {
@@ -123,10 +188,14 @@ func openFile(fileName string) (io.Reader, error) {
 	return file, nil
 }
 
-func keyStorage(config database.Config, masterKey string) (crypto.KeyStorage, error) {
+func keyStorage(config database.Config, masterKey string, providerConfig *ProviderConfig) (crypto.KeyStorage, error) {
 	db, err := database.Connect(config, false)
 	if err != nil {
 		return nil, err
 	}
-	return cryptoDB.NewKeyStorage(db.DB, masterKey)
+	provider, err := NewProvider(providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyStorage(db.DB, masterKey, provider, providerConfig)
 }
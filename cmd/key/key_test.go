@@ -159,3 +159,26 @@ func Test_keysFromYAML(t *testing.T) {
 		})
 	}
 }
+
+type testStorage struct {
+	crypto.KeyStorage
+	keys    crypto.Keys
+	updated []*crypto.Key
+}
+
+func (s *testStorage) ReadKeys() (crypto.Keys, error) {
+	return s.keys, nil
+}
+
+func (s *testStorage) UpdateKeys(keys ...*crypto.Key) error {
+	s.updated = keys
+	return nil
+}
+
+func Test_rewrap(t *testing.T) {
+	source := &testStorage{keys: crypto.Keys{"id1": "key1"}}
+	target := new(testStorage)
+	err := rewrap(source, target)
+	assert.NoError(t, err)
+	assert.Equal(t, []*crypto.Key{{ID: "id1", Value: "key1"}}, target.updated)
+}
@@ -0,0 +1,74 @@
+package key
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/pkcs11"
+	"github.com/zitadel/zitadel/internal/crypto/transit"
+)
+
+const (
+	ProviderTypeNone    = ""
+	ProviderTypePKCS11  = "pkcs11"
+	ProviderTypeTransit = "transit"
+)
+
+// ProviderConfig configures the key provider (HSM / KMS) holding keys outside of ZITADEL
+type ProviderConfig struct {
+	Type string
+	// WrappingKeyID references the key of the provider, which wraps the encryption keys stored in the database.
+	// If set, no masterkey is required.
+	WrappingKeyID string
+	PKCS11        *pkcs11.Config
+	Transit       *transit.Config
+}
+
+func (c *ProviderConfig) wrapsKeys() bool {
+	return c != nil && c.Type != ProviderTypeNone && c.WrappingKeyID != ""
+}
+
+// NewProvider returns the configured key provider or nil if none is configured
+func NewProvider(config *ProviderConfig) (crypto.KeyProvider, error) {
+	if config == nil {
+		return nil, nil
+	}
+	switch config.Type {
+	case ProviderTypeNone:
+		return nil, nil
+	case ProviderTypePKCS11:
+		return pkcs11.New(config.PKCS11)
+	case ProviderTypeTransit:
+		return transit.New(config.Transit)
+	default:
+		return nil, fmt.Errorf("unknown key provider type %q", config.Type)
+	}
+}
+
+// NewKeyStorage returns the storage of the encryption keys in the database.
+// The keys are wrapped by the key provider if a wrapping key is configured, otherwise by the masterkey.
+func NewKeyStorage(client *sql.DB, masterKey string, provider crypto.KeyProvider, config *ProviderConfig) (crypto.KeyStorage, error) {
+	if config.wrapsKeys() {
+		return cryptoDB.NewProviderKeyStorage(client, provider, config.WrappingKeyID)
+	}
+	return cryptoDB.NewKeyStorage(client, masterKey)
+}
+
+// MasterKeyIfRequired returns the masterkey provided by flag.
+// It's only required if the encryption keys are not wrapped by the key provider.
+func MasterKeyIfRequired(cmd *cobra.Command, config *ProviderConfig) (string, error) {
+	if config.wrapsKeys() && !masterKeyFlagSet(cmd) {
+		return "", nil
+	}
+	return MasterKey(cmd)
+}
+
+func masterKeyFlagSet(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed(flagMasterKey) ||
+		cmd.Flags().Changed(flagMasterKeyArg) ||
+		cmd.Flags().Changed(flagMasterKeyEnv)
+}
@@ -0,0 +1,61 @@
+package key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto/transit"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *ProviderConfig
+		wantProvider bool
+		wantErr      bool
+	}{
+		{
+			name:   "no config",
+			config: nil,
+		},
+		{
+			name:   "no type",
+			config: &ProviderConfig{},
+		},
+		{
+			name: "transit",
+			config: &ProviderConfig{
+				Type:    ProviderTypeTransit,
+				Transit: &transit.Config{Address: "http://localhost:8200"},
+			},
+			wantProvider: true,
+		},
+		{
+			name: "transit without address",
+			config: &ProviderConfig{
+				Type:    ProviderTypeTransit,
+				Transit: &transit.Config{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown type",
+			config: &ProviderConfig{
+				Type: "unknown",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewProvider(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantProvider, provider != nil)
+		})
+	}
+}
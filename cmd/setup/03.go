@@ -9,11 +9,11 @@ import (
 
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
-	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
@@ -30,6 +30,7 @@ type FirstInstance struct {
 	smtpEncryptionKey *crypto.KeyConfig
 	oidcEncryptionKey *crypto.KeyConfig
 	masterKey         string
+	keyProvider       *key.ProviderConfig
 	db                *sql.DB
 	es                *eventstore.Eventstore
 	defaults          systemdefaults.SystemDefaults
@@ -41,14 +42,18 @@ type FirstInstance struct {
 }
 
 func (mig *FirstInstance) Execute(ctx context.Context) error {
-	keyStorage, err := crypto_db.NewKeyStorage(mig.db, mig.masterKey)
+	keyProvider, err := key.NewProvider(mig.keyProvider)
+	if err != nil {
+		return fmt.Errorf("cannot start key provider: %w", err)
+	}
+	keyStorage, err := key.NewKeyStorage(mig.db, mig.masterKey, keyProvider, mig.keyProvider)
 	if err != nil {
 		return fmt.Errorf("cannot start key storage: %w", err)
 	}
 	if err = verifyKey(mig.userEncryptionKey, keyStorage); err != nil {
 		return err
 	}
-	userAlg, err := crypto.NewAESCrypto(mig.userEncryptionKey, keyStorage)
+	userAlg, err := crypto.NewEncryption(mig.userEncryptionKey, keyStorage, keyProvider)
 	if err != nil {
 		return err
 	}
@@ -56,7 +61,7 @@ func (mig *FirstInstance) Execute(ctx context.Context) error {
 	if err = verifyKey(mig.smtpEncryptionKey, keyStorage); err != nil {
 		return err
 	}
-	smtpEncryption, err := crypto.NewAESCrypto(mig.smtpEncryptionKey, keyStorage)
+	smtpEncryption, err := crypto.NewEncryption(mig.smtpEncryptionKey, keyStorage, keyProvider)
 	if err != nil {
 		return err
 	}
@@ -64,7 +69,7 @@ func (mig *FirstInstance) Execute(ctx context.Context) error {
 	if err = verifyKey(mig.oidcEncryptionKey, keyStorage); err != nil {
 		return err
 	}
-	oidcEncryption, err := crypto.NewAESCrypto(mig.oidcEncryptionKey, keyStorage)
+	oidcEncryption, err := crypto.NewEncryption(mig.oidcEncryptionKey, keyStorage, keyProvider)
 	if err != nil {
 		return err
 	}
@@ -88,6 +93,8 @@ func (mig *FirstInstance) Execute(ctx context.Context) error {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 	)
 	if err != nil {
 		return err
@@ -155,6 +162,9 @@ func (mig *FirstInstance) String() string {
 }
 
 func verifyKey(key *crypto.KeyConfig, storage crypto.KeyStorage) (err error) {
+	if key.EncryptionKeyID == "" && key.ProviderKeyID != "" {
+		return nil
+	}
 	_, err = crypto.LoadKey(key.EncryptionKeyID, storage)
 	if err == nil {
 		return nil
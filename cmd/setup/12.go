@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 12/12_add_token_confirmation.sql
+	addTokenConfirmation12 string
+)
+
+type AuthTokenConfirmation struct {
+	dbClient *database.DB
+}
+
+func (mig *AuthTokenConfirmation) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addTokenConfirmation12)
+	return err
+}
+
+func (mig *AuthTokenConfirmation) String() string {
+	return "12_auth_token_confirmation"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS cnf_jkt TEXT NULL;
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS cnf_x5t_s256 TEXT NULL;
@@ -0,0 +1,31 @@
+package setup
+
+import (
+	"context"
+	"embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 13/cockroach/13.sql
+	//go:embed 13/postgres/13.sql
+	executionLogIndexes13 embed.FS
+)
+
+type ExecutionLogIndexes struct {
+	dbClient *database.DB
+}
+
+func (mig *ExecutionLogIndexes) Execute(ctx context.Context) error {
+	stmt, err := readStmt(executionLogIndexes13, "13", mig.dbClient.Type(), "13.sql")
+	if err != nil {
+		return err
+	}
+	_, err = mig.dbClient.ExecContext(ctx, stmt)
+	return err
+}
+
+func (mig *ExecutionLogIndexes) String() string {
+	return "13_execution_log_indexes"
+}
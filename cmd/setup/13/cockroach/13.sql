@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS action_id_log_date_desc ON logstore.execution (instance_id, action_id, log_date DESC) STORING (took);
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 14/14_add_magic_link_verification.sql
+	addMagicLinkVerification14 string
+)
+
+type UserSessionMagicLink struct {
+	dbClient *database.DB
+}
+
+func (mig *UserSessionMagicLink) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addMagicLinkVerification14)
+	return err
+}
+
+func (mig *UserSessionMagicLink) String() string {
+	return "14_user_session_magic_link"
+}
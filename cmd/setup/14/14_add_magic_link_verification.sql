@@ -0,0 +1 @@
+ALTER TABLE auth.user_sessions ADD COLUMN IF NOT EXISTS magic_link_verification TIMESTAMPTZ NULL;
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 15/15_add_recovery_codes_state.sql
+	addRecoveryCodesState15 string
+)
+
+type UserRecoveryCodes struct {
+	dbClient *database.DB
+}
+
+func (mig *UserRecoveryCodes) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addRecoveryCodesState15)
+	return err
+}
+
+func (mig *UserRecoveryCodes) String() string {
+	return "15_user_recovery_codes"
+}
@@ -0,0 +1,134 @@
+package setup
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/crypto"
+	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+var (
+	//go:embed 16/16_create_table.sql
+	createUserDataKeys16 string
+	//go:embed 16/16_fetch_events.sql
+	fetchUserDataEvents16 string
+	//go:embed 16/16_update_event.sql
+	updateUserDataEvent16 string
+)
+
+// UserDataEncryption creates the table of the data encryption keys of the users
+// and encrypts the personal data of the existing user events.
+// The personal data of removed users is emptied instead, as their keys must not exist.
+type UserDataEncryption struct {
+	BulkAmount int
+
+	dbClient    *database.DB
+	keyConfig   *crypto.KeyConfig
+	keysConfig  crypto_db.UserDataKeysConfig
+	masterKey   string
+	keyProvider *key.ProviderConfig
+}
+
+type userDataEvent struct {
+	id          string
+	instanceID  string
+	aggregateID string
+	eventType   string
+	data        []byte
+	sequence    uint64
+	removed     bool
+}
+
+func (mig *UserDataEncryption) Execute(ctx context.Context) error {
+	keyProvider, err := key.NewProvider(mig.keyProvider)
+	if err != nil {
+		return fmt.Errorf("cannot start key provider: %w", err)
+	}
+	keyStorage, err := key.NewKeyStorage(mig.dbClient.DB, mig.masterKey, keyProvider, mig.keyProvider)
+	if err != nil {
+		return fmt.Errorf("cannot start key storage: %w", err)
+	}
+	if err = verifyKey(mig.keyConfig, keyStorage); err != nil {
+		return err
+	}
+	userDataAlg, err := crypto.NewEncryption(mig.keyConfig, keyStorage, keyProvider)
+	if err != nil {
+		return err
+	}
+	if _, err = mig.dbClient.ExecContext(ctx, createUserDataKeys16); err != nil {
+		return err
+	}
+	keys := crypto_db.NewUserDataKeys(mig.dbClient.DB, userDataAlg, mig.keysConfig)
+
+	eventTypes := user.PIIEventTypes()
+	types := make(database.StringArray, len(eventTypes))
+	for i, typ := range eventTypes {
+		types[i] = string(typ)
+	}
+
+	var (
+		instanceID string
+		sequence   uint64
+	)
+	for {
+		events, err := mig.fetchEvents(ctx, types, instanceID, sequence)
+		if err != nil {
+			return err
+		}
+		var count int
+		for _, event := range events {
+			var data []byte
+			if event.removed {
+				data, err = user.RemovePIIPayload(eventstore.EventType(event.eventType), event.data)
+			} else {
+				data, err = user.EncryptPIIPayload(ctx, keys, event.instanceID, event.aggregateID, eventstore.EventType(event.eventType), event.data)
+			}
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(data, event.data) {
+				continue
+			}
+			if _, err = mig.dbClient.ExecContext(ctx, updateUserDataEvent16, data, event.id); err != nil {
+				return err
+			}
+			count++
+		}
+		logging.WithFields("count", count).Info("user data encrypted")
+		if len(events) == 0 || len(events) < mig.BulkAmount {
+			return nil
+		}
+		instanceID, sequence = events[len(events)-1].instanceID, events[len(events)-1].sequence
+	}
+}
+
+func (mig *UserDataEncryption) fetchEvents(ctx context.Context, types database.StringArray, instanceID string, sequence uint64) ([]*userDataEvent, error) {
+	rows, err := mig.dbClient.QueryContext(ctx, fetchUserDataEvents16, types, instanceID, sequence, mig.BulkAmount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*userDataEvent, 0, mig.BulkAmount)
+	for rows.Next() {
+		event := new(userDataEvent)
+		if err = rows.Scan(&event.id, &event.instanceID, &event.aggregateID, &event.eventType, &event.data, &event.sequence, &event.removed); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (mig *UserDataEncryption) String() string {
+	return "16_user_data_encryption"
+}
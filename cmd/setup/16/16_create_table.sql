@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS eventstore.user_data_keys (
+    instance_id TEXT NOT NULL
+    , user_id TEXT NOT NULL
+    , key JSONB NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, user_id)
+);
@@ -0,0 +1,25 @@
+SELECT
+    e.id
+    , e.instance_id
+    , e.aggregate_id
+    , e.event_type
+    , e.event_data
+    , e.event_sequence
+    , EXISTS (
+        SELECT 1 FROM eventstore.events r
+        WHERE
+            r.instance_id = e.instance_id
+            AND r.aggregate_type = 'user'
+            AND r.aggregate_id = e.aggregate_id
+            AND r.event_type = 'user.removed'
+    ) AS removed
+FROM
+    eventstore.events e
+WHERE
+    e.aggregate_type = 'user'
+    AND e.event_type = ANY($1)
+    AND (e.instance_id, e.event_sequence) > ($2, $3)
+ORDER BY
+    e.instance_id
+    , e.event_sequence
+LIMIT $4
@@ -0,0 +1 @@
+UPDATE eventstore.events SET event_data = $1 WHERE id = $2
\ No newline at end of file
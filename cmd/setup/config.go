@@ -9,11 +9,13 @@ import (
 	"github.com/spf13/viper"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -28,9 +30,11 @@ type Config struct {
 	ExternalSecure  bool
 	Log             *logging.Config
 	EncryptionKeys  *encryptionKeyConfig
+	KeyProvider     *key.ProviderConfig
 	DefaultInstance command.InstanceSetup
 	Machine         *id.Config
 	Projections     projection.Config
+	UserDataKeys    crypto_db.UserDataKeysConfig
 }
 
 func MustNewConfig(v *viper.Viper) *Config {
@@ -56,23 +60,29 @@ func MustNewConfig(v *viper.Viper) *Config {
 }
 
 type Steps struct {
-	s1ProjectionTable    *ProjectionTable
-	s2AssetsTable        *AssetTable
-	FirstInstance        *FirstInstance
-	s4EventstoreIndexes  *EventstoreIndexesNew
-	s5LastFailed         *LastFailed
-	s6OwnerRemoveColumns *OwnerRemoveColumns
-	s7LogstoreTables     *LogstoreTables
-	s8AuthTokens         *AuthTokenIndexes
-	s9EventstoreIndexes2 *EventstoreIndexesNew
-	CorrectCreationDate  *CorrectCreationDate
-	AddEventCreatedAt    *AddEventCreatedAt
+	s1ProjectionTable       *ProjectionTable
+	s2AssetsTable           *AssetTable
+	FirstInstance           *FirstInstance
+	s4EventstoreIndexes     *EventstoreIndexesNew
+	s5LastFailed            *LastFailed
+	s6OwnerRemoveColumns    *OwnerRemoveColumns
+	s7LogstoreTables        *LogstoreTables
+	s8AuthTokens            *AuthTokenIndexes
+	s9EventstoreIndexes2    *EventstoreIndexesNew
+	CorrectCreationDate     *CorrectCreationDate
+	AddEventCreatedAt       *AddEventCreatedAt
+	s12AuthTokenCnf         *AuthTokenConfirmation
+	s13ExecutionLogIndexes  *ExecutionLogIndexes
+	s14UserSessionMagicLink *UserSessionMagicLink
+	s15UserRecoveryCodes    *UserRecoveryCodes
+	UserDataEncryption      *UserDataEncryption
 }
 
 type encryptionKeyConfig struct {
-	User *crypto.KeyConfig
-	SMTP *crypto.KeyConfig
-	OIDC *crypto.KeyConfig
+	User     *crypto.KeyConfig
+	SMTP     *crypto.KeyConfig
+	OIDC     *crypto.KeyConfig
+	UserData *crypto.KeyConfig
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
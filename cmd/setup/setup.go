@@ -38,7 +38,7 @@ Requirements:
 			config := MustNewConfig(viper.GetViper())
 			steps := MustNewSteps(viper.New())
 
-			masterKey, err := key.MasterKey(cmd)
+			masterKey, err := key.MasterKeyIfRequired(cmd, config.KeyProvider)
 			logging.OnError(err).Panic("No master key provided")
 
 			Setup(config, steps, masterKey)
@@ -77,6 +77,7 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.FirstInstance.smtpEncryptionKey = config.EncryptionKeys.SMTP
 	steps.FirstInstance.oidcEncryptionKey = config.EncryptionKeys.OIDC
 	steps.FirstInstance.masterKey = masterKey
+	steps.FirstInstance.keyProvider = config.KeyProvider
 	steps.FirstInstance.db = dbClient.DB
 	steps.FirstInstance.es = eventstoreClient
 	steps.FirstInstance.defaults = config.SystemDefaults
@@ -94,6 +95,15 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.CorrectCreationDate.dbClient = dbClient
 	steps.AddEventCreatedAt.dbClient = dbClient
 	steps.AddEventCreatedAt.step10 = steps.CorrectCreationDate
+	steps.s12AuthTokenCnf = &AuthTokenConfirmation{dbClient: dbClient}
+	steps.s13ExecutionLogIndexes = &ExecutionLogIndexes{dbClient: dbClient}
+	steps.s14UserSessionMagicLink = &UserSessionMagicLink{dbClient: dbClient}
+	steps.s15UserRecoveryCodes = &UserRecoveryCodes{dbClient: dbClient}
+	steps.UserDataEncryption.dbClient = dbClient
+	steps.UserDataEncryption.keyConfig = config.EncryptionKeys.UserData
+	steps.UserDataEncryption.keysConfig = config.UserDataKeys
+	steps.UserDataEncryption.masterKey = masterKey
+	steps.UserDataEncryption.keyProvider = config.KeyProvider
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -133,6 +143,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 10")
 	err = migration.Migrate(ctx, eventstoreClient, steps.AddEventCreatedAt)
 	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12AuthTokenCnf)
+	logging.OnError(err).Fatal("unable to migrate step 12")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s13ExecutionLogIndexes)
+	logging.OnError(err).Fatal("unable to migrate step 13")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s14UserSessionMagicLink)
+	logging.OnError(err).Fatal("unable to migrate step 14")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s15UserRecoveryCodes)
+	logging.OnError(err).Fatal("unable to migrate step 15")
+	err = migration.Migrate(ctx, eventstoreClient, steps.UserDataEncryption)
+	logging.OnError(err).Fatal("unable to migrate step 16")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
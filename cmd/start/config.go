@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/internal/actions"
 	admin_es "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
@@ -21,6 +22,7 @@ import (
 	"github.com/zitadel/zitadel/internal/config/network"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
@@ -29,6 +31,8 @@ import (
 	static_config "github.com/zitadel/zitadel/internal/static/config"
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
+	"github.com/zitadel/zitadel/internal/userbulk"
+	"github.com/zitadel/zitadel/internal/userlifecycle"
 )
 
 type Config struct {
@@ -56,6 +60,7 @@ type Config struct {
 	InternalAuthZ     internal_authz.Config
 	SystemDefaults    systemdefaults.SystemDefaults
 	EncryptionKeys    *encryptionKeyConfig
+	KeyProvider       *key.ProviderConfig
 	DefaultInstance   command.InstanceSetup
 	AuditLogRetention time.Duration
 	SystemAPIUsers    map[string]*internal_authz.SystemAPIUser
@@ -65,6 +70,9 @@ type Config struct {
 	Eventstore        *eventstore.Config
 	LogStore          *logstore.Configs
 	Quotas            *QuotasConfig
+	UserLifecycle     userlifecycle.Config
+	UserBulkJobs      userbulk.Config
+	UserDataKeys      cryptoDB.UserDataKeysConfig
 }
 
 type QuotasConfig struct {
@@ -96,8 +104,13 @@ func MustNewConfig(v *viper.Viper) *Config {
 	err = config.Metrics.NewMeter()
 	logging.OnError(err).Fatal("unable to set meter")
 
+	err = config.InternalAuthZ.TokenBinding.Init()
+	logging.OnError(err).Fatal("unable to initialize token binding")
+
 	id.Configure(config.Machine)
 	actions.SetHTTPConfig(&config.Actions.HTTP)
+	actions.SetQueryConfig(&config.Actions.Query)
+	actions.SetWASMConfig(&config.Actions.WASM)
 
 	return config
 }
@@ -111,6 +124,8 @@ type encryptionKeyConfig struct {
 	SMS                  *crypto.KeyConfig
 	SMTP                 *crypto.KeyConfig
 	User                 *crypto.KeyConfig
+	UserData             *crypto.KeyConfig
+	ActionSecret         *crypto.KeyConfig
 	CSRFCookieKeyID      string
 	UserAgentCookieKeyID string
 }
@@ -15,6 +15,8 @@ var (
 		"smsKey",
 		"smtpKey",
 		"userKey",
+		"userDataKey",
+		"actionSecretKey",
 		"csrfCookieKey",
 		"userAgentCookieKey",
 	}
@@ -29,29 +31,32 @@ type encryptionKeys struct {
 	SMS                crypto.EncryptionAlgorithm
 	SMTP               crypto.EncryptionAlgorithm
 	User               crypto.EncryptionAlgorithm
+	UserData           crypto.EncryptionAlgorithm
+	ActionSecret       crypto.EncryptionAlgorithm
 	CSRFCookieKey      []byte
 	UserAgentCookieKey []byte
 	OIDCKey            []byte
+	KeyProvider        crypto.KeyProvider
 }
 
-func ensureEncryptionKeys(keyConfig *encryptionKeyConfig, keyStorage crypto.KeyStorage) (keys *encryptionKeys, err error) {
+func ensureEncryptionKeys(keyConfig *encryptionKeyConfig, keyStorage crypto.KeyStorage, keyProvider crypto.KeyProvider) (keys *encryptionKeys, err error) {
 	if err := verifyDefaultKeys(keyStorage); err != nil {
 		return nil, err
 	}
 	keys = new(encryptionKeys)
-	keys.DomainVerification, err = crypto.NewAESCrypto(keyConfig.DomainVerification, keyStorage)
+	keys.DomainVerification, err = crypto.NewEncryption(keyConfig.DomainVerification, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
-	keys.IDPConfig, err = crypto.NewAESCrypto(keyConfig.IDPConfig, keyStorage)
+	keys.IDPConfig, err = crypto.NewEncryption(keyConfig.IDPConfig, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
-	keys.OIDC, err = crypto.NewAESCrypto(keyConfig.OIDC, keyStorage)
+	keys.OIDC, err = crypto.NewEncryption(keyConfig.OIDC, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
-	keys.SAML, err = crypto.NewAESCrypto(keyConfig.SAML, keyStorage)
+	keys.SAML, err = crypto.NewEncryption(keyConfig.SAML, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -60,19 +65,27 @@ func ensureEncryptionKeys(keyConfig *encryptionKeyConfig, keyStorage crypto.KeyS
 		return nil, err
 	}
 	keys.OIDCKey = []byte(key)
-	keys.OTP, err = crypto.NewAESCrypto(keyConfig.OTP, keyStorage)
+	keys.OTP, err = crypto.NewEncryption(keyConfig.OTP, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
-	keys.SMS, err = crypto.NewAESCrypto(keyConfig.SMS, keyStorage)
+	keys.SMS, err = crypto.NewEncryption(keyConfig.SMS, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
-	keys.SMTP, err = crypto.NewAESCrypto(keyConfig.SMTP, keyStorage)
+	keys.SMTP, err = crypto.NewEncryption(keyConfig.SMTP, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
-	keys.User, err = crypto.NewAESCrypto(keyConfig.User, keyStorage)
+	keys.User, err = crypto.NewEncryption(keyConfig.User, keyStorage, keyProvider)
+	if err != nil {
+		return nil, err
+	}
+	keys.UserData, err = crypto.NewEncryption(keyConfig.UserData, keyStorage, keyProvider)
+	if err != nil {
+		return nil, err
+	}
+	keys.ActionSecret, err = crypto.NewEncryption(keyConfig.ActionSecret, keyStorage, keyProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +99,7 @@ func ensureEncryptionKeys(keyConfig *encryptionKeyConfig, keyStorage crypto.KeyS
 		return nil, err
 	}
 	keys.UserAgentCookieKey = []byte(key)
+	keys.KeyProvider = keyProvider
 	return keys, nil
 }
 
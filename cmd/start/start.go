@@ -29,6 +29,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/delivery"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
 	"github.com/zitadel/zitadel/internal/api/grpc/management"
@@ -62,6 +63,8 @@ import (
 	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/userbulk"
+	"github.com/zitadel/zitadel/internal/userlifecycle"
 	"github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/openapi"
 )
@@ -79,7 +82,7 @@ Requirements:
 				return err
 			}
 			config := MustNewConfig(viper.GetViper())
-			masterKey, err := key.MasterKey(cmd)
+			masterKey, err := key.MasterKeyIfRequired(cmd, config.KeyProvider)
 			if err != nil {
 				return err
 			}
@@ -117,16 +120,20 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		return fmt.Errorf("cannot start client for projection: %w", err)
 	}
 
-	keyStorage, err := cryptoDB.NewKeyStorage(dbClient.DB, masterKey)
+	keyProvider, err := key.NewProvider(config.KeyProvider)
+	if err != nil {
+		return fmt.Errorf("cannot start key provider: %w", err)
+	}
+	keyStorage, err := key.NewKeyStorage(dbClient.DB, masterKey, keyProvider, config.KeyProvider)
 	if err != nil {
 		return fmt.Errorf("cannot start key storage: %w", err)
 	}
-	keys, err := ensureEncryptionKeys(config.EncryptionKeys, keyStorage)
+	keys, err := ensureEncryptionKeys(config.EncryptionKeys, keyStorage, keyProvider)
 	if err != nil {
 		return err
 	}
-
 	config.Eventstore.Client = dbClient
+	config.Eventstore.PersonalDataKeys = cryptoDB.NewUserDataKeys(dbClient.DB, keys.UserData, config.UserDataKeys)
 	eventstoreClient, err := eventstore.Start(config.Eventstore)
 	if err != nil {
 		return fmt.Errorf("cannot start eventstore for queries: %w", err)
@@ -156,7 +163,7 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		return fmt.Errorf("cannot start queries: %w", err)
 	}
 
-	authZRepo, err := authz.Start(queries, dbClient, keys.OIDC, config.ExternalSecure, config.Eventstore.AllowOrderByCreationDate)
+	authZRepo, err := authz.Start(queries, dbClient, eventstoreClient, keys.OIDC, config.ExternalSecure, config.Eventstore.AllowOrderByCreationDate)
 	if err != nil {
 		return fmt.Errorf("error starting authz repo: %w", err)
 	}
@@ -189,9 +196,11 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.ActionSecret,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
+		queries.GetActiveActionsByFlowAndTriggerType,
 	)
 	if err != nil {
 		return fmt.Errorf("cannot start commands: %w", err)
@@ -213,8 +222,12 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		logging.Warn("execution logs are currently in beta")
 	}
 	actions.SetLogstoreService(actionsLogstoreSvc)
+	actions.SetQueries(queries)
+	actions.SetStorage(commands)
 
 	notification.Start(ctx, config.Projections.Customizations["notifications"], config.Projections.Customizations["notificationsquotas"], config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, keys.User, keys.SMTP, keys.SMS)
+	userlifecycle.Start(ctx, config.UserLifecycle, dbClient, eventstoreClient, commands, queries)
+	userbulk.Start(ctx, config.UserBulkJobs, dbClient, eventstoreClient, commands, queries)
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
@@ -314,7 +327,7 @@ func startAPIs(
 		http_util.WithMaxAge(int(math.Floor(config.Quotas.Access.ExhaustedCookieMaxAge.Seconds()))),
 	)
 	limitingAccessInterceptor := middleware.NewAccessInterceptor(accessSvc, exhaustedCookieHandler, config.Quotas.Access)
-	apis, err := api.New(ctx, config.Port, router, queries, verifier, config.InternalAuthZ, tlsConfig, config.HTTP2HostHeader, config.HTTP1HostHeader, limitingAccessInterceptor)
+	apis, err := api.New(ctx, config.Port, router, queries, verifier, config.InternalAuthZ, tlsConfig, config.HTTP2HostHeader, config.HTTP1HostHeader, limitingAccessInterceptor, config.ExternalSecure)
 	if err != nil {
 		return fmt.Errorf("error creating api %w", err)
 	}
@@ -352,6 +365,7 @@ func startAPIs(
 	apis.RegisterHandlerOnPrefix(assets.HandlerPrefix, assets.NewHandler(commands, verifier, config.InternalAuthZ, id.SonyFlakeGenerator(), store, queries, middleware.CallDurationHandler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle))
 
 	apis.RegisterHandlerOnPrefix(idp.HandlerPrefix, idp.NewHandler(commands, queries, keys.IDPConfig, config.ExternalSecure, instanceInterceptor.Handler))
+	apis.RegisterHandlerOnPrefix(delivery.HandlerPrefix, delivery.NewHandler(commands, queries, keys.SMS, config.ExternalSecure, config.SystemDefaults.Notifications.DeliveryCallbackToken, instanceInterceptor.Handler))
 
 	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources)
 	if err != nil {
@@ -372,7 +386,10 @@ func startAPIs(
 	}
 	apis.RegisterHandlerOnPrefix(openapi.HandlerPrefix, openAPIHandler)
 
-	oidcProvider, err := oidc.NewProvider(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor.Handle)
+	// the registration handler must be registered before the provider, which handles all other requests on /oauth/v2
+	apis.RegisterHandlerPrefixes(oidc.NewClientRegistrationHandler(commands, queries, crypto.NewBCrypt(config.SystemDefaults.SecretGenerators.PasswordSaltCost), config.ExternalSecure, instanceInterceptor.Handler, limitingAccessInterceptor.Handle), oidc.ClientRegistrationPath)
+
+	oidcProvider, err := oidc.NewProvider(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, config.InternalAuthZ.TokenBinding, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, keys.KeyProvider, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor.Handle)
 	if err != nil {
 		return fmt.Errorf("unable to start oidc provider: %w", err)
 	}
@@ -26,12 +26,13 @@ Requirements:
 			err := tls.ModeFromFlag(cmd)
 			logging.OnError(err).Fatal("invalid tlsMode")
 
-			masterKey, err := key.MasterKey(cmd)
+			setupConfig := setup.MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKeyIfRequired(cmd, setupConfig.KeyProvider)
 			logging.OnError(err).Panic("No master key provided")
 
 			initialise.InitAll(initialise.MustNewConfig(viper.GetViper()))
 
-			setupConfig := setup.MustNewConfig(viper.GetViper())
 			setupSteps := setup.MustNewSteps(viper.New())
 			setup.Setup(setupConfig, setupSteps, masterKey)
 
@@ -26,10 +26,11 @@ Requirements:
 			err := tls.ModeFromFlag(cmd)
 			logging.OnError(err).Fatal("invalid tlsMode")
 
-			masterKey, err := key.MasterKey(cmd)
+			setupConfig := setup.MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKeyIfRequired(cmd, setupConfig.KeyProvider)
 			logging.OnError(err).Panic("No master key provided")
 
-			setupConfig := setup.MustNewConfig(viper.GetViper())
 			setupSteps := setup.MustNewSteps(viper.New())
 			setup.Setup(setupConfig, setupSteps, masterKey)
 
@@ -502,6 +502,9 @@ module.exports = {
         "apis/actions/internal-authentication",
         "apis/actions/external-authentication",
         "apis/actions/complement-token",
+        "apis/actions/customize-saml-response",
+        "apis/actions/user-management",
+        "apis/actions/notification",
         "apis/actions/objects",
       ]
     },
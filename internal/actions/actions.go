@@ -8,12 +8,15 @@ import (
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/sirupsen/logrus"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	z_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
 type Config struct {
-	HTTP HTTPConfig
+	HTTP  HTTPConfig
+	Query QueryConfig
+	WASM  WASMConfig
 }
 
 var ErrHalt = errors.New("interrupt")
@@ -30,7 +33,7 @@ func actionFailedMessage(err error) string {
 }
 
 func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script, name string, opts ...Option) (err error) {
-	config := newRunConfig(ctx, append(opts, withLogger(ctx))...)
+	config := newRunConfig(ctx, append(opts, withLogger(ctx), withQuery(ctx), withStorage(ctx))...)
 	if config.functionTimeout == 0 {
 		return z_errs.ThrowInternal(nil, "ACTIO-uCpCx", "Errrors.Internal")
 	}
@@ -54,6 +57,10 @@ func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script
 		}
 	}()
 
+	if config.runtime == domain.ActionRuntimeWASM {
+		return wasmRun(ctx, config, ctxParam, apiParam, script, name)
+	}
+
 	if err := executeScript(config, ctxParam, apiParam, script); err != nil {
 		return err
 	}
@@ -108,6 +115,13 @@ func executeScript(config *runConfig, ctxParam contextFields, apiParam apiFields
 }
 
 func executeFn(config *runConfig, fn jsAction) (err error) {
+	return recoverFn(func() error {
+		return fn(config.ctxParam.fields, config.apiParam.fields)
+	})
+}
+
+// recoverFn converts panics of the function into errors
+func recoverFn(fn func() error) (err error) {
 	defer func() {
 		r := recover()
 		if r == nil {
@@ -126,14 +140,18 @@ func executeFn(config *runConfig, fn jsAction) (err error) {
 		err = fmt.Errorf("unknown error occurred: %v", r)
 	}()
 
-	if err = fn(config.ctxParam.fields, config.apiParam.fields); err != nil {
-		return err
+	return fn()
+}
+
+func withActionID(actionID string) Option {
+	return func(c *runConfig) {
+		c.actionID = actionID
 	}
-	return nil
 }
 
 func ActionToOptions(a *query.Action) []Option {
-	opts := make([]Option, 0, 1)
+	opts := make([]Option, 0, 4)
+	opts = append(opts, withOrgID(a.ResourceOwner), withActionID(a.ID), withRuntime(a.Runtime))
 	if a.AllowedToFail {
 		opts = append(opts, WithAllowedToFail())
 	}
@@ -7,6 +7,8 @@ import (
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
 )
 
 const (
@@ -28,6 +30,10 @@ type runConfig struct {
 	modules    map[string]require.ModuleLoader
 	logger     *logger
 	instanceID string
+	orgID      string
+	actionID   string
+	dryRun     *DryRunResult
+	runtime    domain.ActionRuntime
 	vm         *goja.Runtime
 	ctxParam   *ctxConfig
 	apiParam   *apiConfig
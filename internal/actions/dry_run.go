@@ -0,0 +1,214 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/sirupsen/logrus"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// maxDryRunTimeout matches the maximum timeout of an action
+const maxDryRunTimeout = 20 * time.Second
+
+// DryRunResult is the outcome of an action executed by DryRun
+type DryRunResult struct {
+	Mutations []*DryRunMutation
+	Logs      []*DryRunLog
+	Took      time.Duration
+	// Err is the error the action returned or threw
+	Err error
+}
+
+// DryRunMutation is a call of the action which would have had an effect in a real run,
+// e.g. a call of a function of the api parameter or a write to the key value store
+type DryRunMutation struct {
+	Path string
+	Args []interface{}
+}
+
+type DryRunLog struct {
+	LogDate  time.Time
+	Message  string
+	LogLevel logrus.Level
+}
+
+type dryRunAction func(fields, goja.Value) error
+
+func withDryRun(result *DryRunResult) Option {
+	return func(c *runConfig) {
+		c.dryRun = result
+	}
+}
+
+// DryRun executes the function of the script without any side effects.
+// The ctx parameter of the function is built from ctxFields.
+// Calls on the api parameter, writes to the key value store and http requests are recorded as mutations instead of being executed,
+// secrets are replaced by placeholders and the logs are captured instead of being stored in the logstore.
+// A timeout of 0 uses the maximum timeout of an action.
+// The returned error is only set if the action could not be executed, errors of the action itself are part of the result.
+func DryRun(ctx context.Context, orgID string, ctxFields map[string]interface{}, runtime domain.ActionRuntime, script, name string, timeout time.Duration) (*DryRunResult, error) {
+	if timeout <= 0 || timeout > maxDryRunTimeout {
+		timeout = maxDryRunTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := new(DryRunResult)
+	config := newRunConfig(ctx, withOrgID(orgID), withRuntime(runtime), withDryRun(result), WithHTTP(ctx), withLogger(ctx), withQuery(ctx), withStorage(ctx))
+	if config.functionTimeout == 0 {
+		return nil, z_errs.ThrowInternal(nil, "ACTIO-Hoh3e", "Errors.Internal")
+	}
+
+	remaining := logstoreService.Limit(ctx, config.instanceID)
+	config.cutTimeouts(remaining)
+	if remaining != nil && *remaining == 0 {
+		return nil, z_errs.ThrowResourceExhausted(nil, "ACTIO-eeK4o", "Errors.Quota.Execution.Exhausted")
+	}
+
+	started := time.Now()
+	config.logger.Log(actionStartedMessage)
+	err := dryRun(ctx, config, ctxFields, script, name)
+	result.Took = time.Since(started)
+	if err != nil {
+		config.logger.log(actionFailedMessage(err), logrus.ErrorLevel, true)
+		result.Err = err
+		return result, nil
+	}
+	config.logger.log(actionSucceededMessage, logrus.InfoLevel, true)
+	return result, nil
+}
+
+func dryRun(ctx context.Context, config *runConfig, ctxFields map[string]interface{}, script, name string) error {
+	ctxParam := func(c *ctxConfig) {
+		for key, value := range ctxFields {
+			c.set(key, value)
+		}
+	}
+	if config.runtime == domain.ActionRuntimeWASM {
+		return wasmRun(ctx, config, ctxParam, nil, script, name)
+	}
+	if err := executeScript(config, ctxParam, nil, script); err != nil {
+		return err
+	}
+
+	var fn dryRunAction
+	jsFn := config.vm.Get(name)
+	if jsFn == nil {
+		return errors.New("function not found")
+	}
+	if err := config.vm.ExportTo(jsFn, &fn); err != nil {
+		return err
+	}
+
+	t := config.StartFunction()
+	defer func() {
+		t.Stop()
+	}()
+
+	return recoverFn(func() error {
+		return fn(config.ctxParam.fields, config.dryRun.recorder(config.vm, "api"))
+	})
+}
+
+// recorder returns an object which accepts any property access and records all calls as mutations
+func (r *DryRunResult) recorder(runtime *goja.Runtime, path string) goja.Value {
+	target := runtime.ToValue(func(goja.FunctionCall) goja.Value { return goja.Undefined() }).ToObject(runtime)
+	return runtime.ToValue(runtime.NewProxy(target, &goja.ProxyTrapConfig{
+		Get: func(_ *goja.Object, property string, _ goja.Value) goja.Value {
+			return r.recorder(runtime, path+"."+property)
+		},
+		Apply: func(_ *goja.Object, _ goja.Value, args []goja.Value) goja.Value {
+			exported := make([]interface{}, len(args))
+			for i, arg := range args {
+				exported[i] = arg.Export()
+			}
+			r.record(path, exported...)
+			return goja.Undefined()
+		},
+	}))
+}
+
+func (r *DryRunResult) record(path string, args ...interface{}) {
+	r.Mutations = append(r.Mutations, &DryRunMutation{
+		Path: path,
+		Args: args,
+	})
+}
+
+// dryRunStorage reads from the storage but only records the writes to the key value store.
+// Subsequent reads of the same run return the recorded values.
+type dryRunStorage struct {
+	Storage
+	result *DryRunResult
+	// entries contains the written values, removed keys are nil
+	entries map[string][]byte
+}
+
+// wrapStorage prevents writes to the storage on dry runs
+func (c *runConfig) wrapStorage(s Storage) Storage {
+	if c.dryRun == nil {
+		return s
+	}
+	return newDryRunStorage(s, c.dryRun)
+}
+
+func newDryRunStorage(storage Storage, result *DryRunResult) *dryRunStorage {
+	return &dryRunStorage{
+		Storage: storage,
+		result:  result,
+		entries: make(map[string][]byte),
+	}
+}
+
+// GetActionSecretValue returns a placeholder, secrets are never exposed by dry runs
+func (s *dryRunStorage) GetActionSecretValue(ctx context.Context, orgID, name string) (string, error) {
+	if _, err := s.Storage.GetActionSecretValue(ctx, orgID, name); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("[secret %s]", name), nil
+}
+
+func (s *dryRunStorage) GetActionKeyValue(ctx context.Context, orgID, key string) ([]byte, error) {
+	if value, ok := s.entries[key]; ok {
+		return value, nil
+	}
+	return s.Storage.GetActionKeyValue(ctx, orgID, key)
+}
+
+func (s *dryRunStorage) SetActionKeyValue(_ context.Context, _, key string, value []byte) (*domain.ObjectDetails, error) {
+	var v interface{}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return nil, z_errs.ThrowInvalidArgument(err, "ACTIO-Sho5a", "Errors.Action.Storage.InvalidValue")
+	}
+	s.entries[key] = value
+	s.result.record("zitadel/kv.set", key, v)
+	return new(domain.ObjectDetails), nil
+}
+
+func (s *dryRunStorage) RemoveActionKeyValue(_ context.Context, _, key string) (*domain.ObjectDetails, error) {
+	s.entries[key] = nil
+	s.result.record("zitadel/kv.remove", key)
+	return new(domain.ObjectDetails), nil
+}
+
+// requireDryRunHTTP registers a fetch which records the requests instead of sending them.
+// The response of the fetch has status 0 and an empty json object as body.
+func requireDryRunHTTP(result *DryRunResult, runtime *goja.Runtime, module *goja.Object) {
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("fetch", func(call goja.FunctionCall) goja.Value {
+		args := make([]interface{}, len(call.Arguments))
+		for i, arg := range call.Arguments {
+			args[i] = arg.Export()
+		}
+		result.record("zitadel/http.fetch", args...)
+		return runtime.ToValue(&response{Body: "{}", runtime: runtime})
+	})).Warn("unable to set module")
+}
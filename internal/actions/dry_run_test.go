@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestDryRun(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	defer SetStorage(nil)
+	type want struct {
+		mutations []*DryRunMutation
+		logs      []string
+		err       bool
+		entries   map[string][]byte
+	}
+	tests := []struct {
+		name      string
+		ctxFields map[string]interface{}
+		script    string
+		want      want
+	}{
+		{
+			name: "api calls recorded",
+			ctxFields: map[string]interface{}{
+				"v1": map[string]interface{}{"user": map[string]interface{}{"firstName": "Gigi"}},
+			},
+			script: `function test(ctx, api) {
+	api.v1.user.appendMetadata("name", ctx.v1.user.firstName);
+	api.setFirstName("Gigi");
+}`,
+			want: want{
+				mutations: []*DryRunMutation{
+					{Path: "api.v1.user.appendMetadata", Args: []interface{}{"name", "Gigi"}},
+					{Path: "api.setFirstName", Args: []interface{}{"Gigi"}},
+				},
+				logs:    []string{actionStartedMessage, actionSucceededMessage},
+				entries: map[string][]byte{"counter": []byte("1")},
+			},
+		},
+		{
+			name: "logs, kv and http recorded",
+			script: `function test(ctx, api) {
+	let logger = require("zitadel/log");
+	let kv = require("zitadel/kv");
+	let secrets = require("zitadel/secrets");
+	kv.set("counter", kv.get("counter") + 1);
+	logger.log(kv.get("counter") + " " + secrets.get("apiKey"));
+	require("zitadel/http").fetch("https://zitadel.cloud", {method: "POST"}).json();
+}`,
+			want: want{
+				mutations: []*DryRunMutation{
+					{Path: "zitadel/kv.set", Args: []interface{}{"counter", float64(2)}},
+					{Path: "zitadel/http.fetch", Args: []interface{}{"https://zitadel.cloud", map[string]interface{}{"method": "POST"}}},
+				},
+				logs:    []string{actionStartedMessage, "2 [secret apiKey]", actionSucceededMessage},
+				entries: map[string][]byte{"counter": []byte("1")},
+			},
+		},
+		{
+			name:   "error returned in result",
+			script: `function test(ctx, api) { throw "some error" }`,
+			want: want{
+				logs:    []string{actionStartedMessage, "action run failed: some error"},
+				err:     true,
+				entries: map[string][]byte{"counter": []byte("1")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &mockStorage{
+				secrets: map[string]string{"apiKey": "secret"},
+				entries: map[string][]byte{"counter": []byte("1")},
+			}
+			SetStorage(storage)
+			got, err := DryRun(context.Background(), "org", tt.ctxFields, domain.ActionRuntimeJavaScript, tt.script, "test", 10*time.Second)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want.err, got.Err != nil)
+			assert.Equal(t, tt.want.mutations, got.Mutations)
+			if assert.Len(t, got.Logs, len(tt.want.logs)) {
+				for i, log := range got.Logs {
+					assert.True(t, strings.HasPrefix(log.Message, tt.want.logs[i]), "log %q should start with %q", log.Message, tt.want.logs[i])
+				}
+			}
+			assert.Equal(t, tt.want.entries, storage.entries)
+		})
+	}
+}
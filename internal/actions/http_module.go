@@ -13,13 +13,22 @@ import (
 	"github.com/dop251/goja"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	z_errs "github.com/zitadel/zitadel/internal/errors"
 )
 
 func WithHTTP(ctx context.Context) Option {
 	return func(c *runConfig) {
 		c.modules["zitadel/http"] = func(runtime *goja.Runtime, module *goja.Object) {
-			requireHTTP(ctx, &http.Client{Transport: new(transport)}, runtime, module)
+			if c.dryRun != nil {
+				requireDryRunHTTP(c.dryRun, runtime, module)
+				return
+			}
+			client := &http.Client{
+				Transport:     new(transport),
+				CheckRedirect: checkRedirect,
+			}
+			requireHTTP(ctx, client, c.wrapStorage(storage), c.orgID, runtime, module)
 		}
 	}
 }
@@ -27,12 +36,17 @@ func WithHTTP(ctx context.Context) Option {
 type HTTP struct {
 	runtime *goja.Runtime
 	client  *http.Client
+	// storage provides the secrets to sign requests
+	storage Storage
+	orgID   string
 }
 
-func requireHTTP(ctx context.Context, client *http.Client, runtime *goja.Runtime, module *goja.Object) {
+func requireHTTP(ctx context.Context, client *http.Client, storage Storage, orgID string, runtime *goja.Runtime, module *goja.Object) {
 	c := &HTTP{
 		client:  client,
 		runtime: runtime,
+		storage: storage,
+		orgID:   orgID,
 	}
 	o := module.Get("exports").(*goja.Object)
 	logging.OnError(o.Set("fetch", c.fetch(ctx))).Warn("unable to set module")
@@ -42,6 +56,9 @@ type fetchConfig struct {
 	Method  string
 	Headers http.Header
 	Body    io.Reader
+	// Certificate is the name of the client certificate used for mTLS
+	Certificate string
+	Signing     *signingConfig
 }
 
 var defaultFetchConfig = fetchConfig{
@@ -65,6 +82,12 @@ func (c *HTTP) fetchConfigFromArg(arg *goja.Object, config *fetchConfig) (err er
 				return err
 			}
 			config.Body = bytes.NewReader(body)
+		case "certificate":
+			config.Certificate = arg.Get(key).String()
+		case "signing":
+			if config.Signing, err = c.signingConfigFromArg(arg.Get(key).ToObject(c.runtime)); err != nil {
+				return err
+			}
 		default:
 			return z_errs.ThrowInvalidArgument(nil, "ACTIO-OfUeA", "key is invalid")
 		}
@@ -107,20 +130,44 @@ func (c *HTTP) fetch(ctx context.Context) func(call goja.FunctionCall) goja.Valu
 		}
 		defer res.Body.Close()
 
-		body, err := io.ReadAll(res.Body)
+		body, err := readBody(res)
 		if err != nil {
 			logging.WithError(err).Warn("unable to parse body")
-			panic("unable to read response body")
+			panic(err)
 		}
 		return c.runtime.ToValue(&response{Status: res.StatusCode, Body: string(body), runtime: c.runtime})
 	}
 }
 
+// readBody reads the body of the response up to the configured maximum size
+func readBody(res *http.Response) ([]byte, error) {
+	if httpConfig == nil || httpConfig.MaxResponseSize <= 0 {
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, z_errs.ThrowInternal(err, "ACTIO-ohG0a", "unable to read response body")
+		}
+		return body, nil
+	}
+	if res.ContentLength > httpConfig.MaxResponseSize {
+		return nil, z_errs.ThrowResourceExhausted(nil, "ACTIO-eiL4d", "Errors.Action.HTTP.ResponseTooLarge")
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, httpConfig.MaxResponseSize+1))
+	if err != nil {
+		return nil, z_errs.ThrowInternal(err, "ACTIO-Gei4x", "unable to read response body")
+	}
+	if int64(len(body)) > httpConfig.MaxResponseSize {
+		return nil, z_errs.ThrowResourceExhausted(nil, "ACTIO-Oof8o", "Errors.Action.HTTP.ResponseTooLarge")
+	}
+	return body, nil
+}
+
 // the first argument has to be a string and is required
 // the second agrument is optional and an object with the following fields possible:
 // - `Headers`: map with string key and value of type string or string array
 // - `Body`: json body of the request
 // - `Method`: http method type
+// - `Certificate`: name of the configured client certificate
+// - `Signing`: object with the `type` (hmac or jwt) and the name of the `secret` used to sign the request
 func (c *HTTP) buildHTTPRequest(ctx context.Context, args []goja.Value) (req *http.Request) {
 	if len(args) > 2 {
 		logging.WithFields("count", len(args)).Debug("more than 2 args provided")
@@ -143,11 +190,33 @@ func (c *HTTP) buildHTTPRequest(ctx context.Context, args []goja.Value) (req *ht
 	if err != nil {
 		panic(err)
 	}
-	req.Header = config.Headers
+	req.Header = config.Headers.Clone()
+
+	if config.Certificate != "" {
+		cert, err := clientCertificate(ctx, config.Certificate)
+		if err != nil {
+			panic(err)
+		}
+		req = req.WithContext(context.WithValue(req.Context(), certificateKey{}, cert))
+	}
+	if config.Signing != nil {
+		if err = c.sign(ctx, req, config.Signing); err != nil {
+			panic(err)
+		}
+	}
 
 	return req
 }
 
+type certificateKey struct{}
+
+func clientCertificate(ctx context.Context, name string) (*ClientCertificate, error) {
+	if httpConfig == nil {
+		return nil, z_errs.ThrowNotFound(nil, "ACTIO-Ohg2a", "Errors.Action.HTTP.CertificateNotFound")
+	}
+	return httpConfig.certificate(authz.GetInstance(ctx).InstanceID(), name)
+}
+
 func parseHeaders(headers *goja.Object) http.Header {
 	h := make(http.Header, len(headers.Keys()))
 	for _, k := range headers.Keys() {
@@ -176,15 +245,60 @@ func (*transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if httpConfig == nil {
 		return http.DefaultTransport.RoundTrip(req)
 	}
-	if isHostBlocked(httpConfig.DenyList, req.URL) {
-		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-N72d0", "host is denied")
+	if err := checkHost(req.Context(), req.URL); err != nil {
+		return nil, err
+	}
+	if cert, ok := req.Context().Value(certificateKey{}).(*ClientCertificate); ok {
+		return cert.transport.RoundTrip(req)
 	}
 	return http.DefaultTransport.RoundTrip(req)
 }
 
+func checkHost(ctx context.Context, address *url.URL) error {
+	if isHostBlocked(httpConfig.DenyList, address) {
+		return z_errs.ThrowInvalidArgument(nil, "ACTIO-N72d0", "host is denied")
+	}
+	allowList := httpConfig.allowList(authz.GetInstance(ctx).InstanceID())
+	if len(allowList) > 0 && !isHostListed(allowList, address) {
+		return z_errs.ThrowInvalidArgument(nil, "ACTIO-ahGh0", "Errors.Action.HTTP.HostNotAllowed")
+	}
+	return nil
+}
+
+// checkRedirect verifies the redirect target before it is requested.
+// If the redirect leaves the host of the original request the signature is removed
+// and the client certificate is not presented to the new host.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	maxRedirects := defaultMaxRedirects
+	if httpConfig != nil {
+		maxRedirects = httpConfig.MaxRedirects
+	}
+	if len(via) > maxRedirects {
+		return z_errs.ThrowInvalidArgument(nil, "ACTIO-Ahf5o", "Errors.Action.HTTP.TooManyRedirects")
+	}
+	if httpConfig != nil {
+		if err := checkHost(req.Context(), req.URL); err != nil {
+			return err
+		}
+	}
+	if req.URL.Host != via[0].URL.Host {
+		req.Header.Del(SignatureHeader)
+		req.Header.Del("Authorization")
+		*req = *req.WithContext(context.WithValue(req.Context(), certificateKey{}, nil))
+	}
+	return nil
+}
+
+// defaultMaxRedirects matches the default of the http client
+const defaultMaxRedirects = 10
+
 func isHostBlocked(denyList []AddressChecker, address *url.URL) bool {
-	for _, blocked := range denyList {
-		if blocked.Matches(address.Hostname()) {
+	return isHostListed(denyList, address)
+}
+
+func isHostListed(list []AddressChecker, address *url.URL) bool {
+	for _, checker := range list {
+		if checker.Matches(address.Hostname()) {
 			return true
 		}
 	}
@@ -1,8 +1,11 @@
 package actions
 
 import (
+	"crypto/tls"
 	"net"
+	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	z_errs "github.com/zitadel/zitadel/internal/errors"
@@ -16,6 +19,50 @@ var httpConfig *HTTPConfig
 
 type HTTPConfig struct {
 	DenyList []AddressChecker
+	// AllowList restricts the requests to the listed hosts if it's not empty
+	AllowList []AddressChecker
+	// InstanceAllowLists replace the AllowList for the instance with the id of the key
+	InstanceAllowLists map[string][]AddressChecker
+	// ClientCertificates are used for mTLS if the fetch references the name of the certificate
+	ClientCertificates map[string]*ClientCertificate
+	// MaxResponseSize is the maximum size of a response body in bytes, 0 means unlimited
+	MaxResponseSize int64
+	// MaxRedirects is the maximum amount of redirects followed by a fetch, 0 disables redirects
+	MaxRedirects int
+}
+
+type ClientCertificate struct {
+	Certificate tls.Certificate
+	// Instances restrict the usage of the certificate to the listed instances, empty means all instances
+	Instances []string
+
+	transport http.RoundTripper
+}
+
+// allowList returns the allow list of the instance or the default one
+func (c *HTTPConfig) allowList(instanceID string) []AddressChecker {
+	if allowList, ok := c.InstanceAllowLists[instanceID]; ok {
+		return allowList
+	}
+	return c.AllowList
+}
+
+// certificate returns the client certificate if the instance is allowed to use it
+func (c *HTTPConfig) certificate(instanceID, name string) (*ClientCertificate, error) {
+	// the keys of the configuration are lowercased
+	cert, ok := c.ClientCertificates[strings.ToLower(name)]
+	if !ok {
+		return nil, z_errs.ThrowNotFound(nil, "ACTIO-Tho4a", "Errors.Action.HTTP.CertificateNotFound")
+	}
+	if len(cert.Instances) == 0 {
+		return cert, nil
+	}
+	for _, id := range cert.Instances {
+		if id == instanceID {
+			return cert, nil
+		}
+	}
+	return nil, z_errs.ThrowNotFound(nil, "ACTIO-ieG8u", "Errors.Action.HTTP.CertificateNotFound")
 }
 
 func HTTPConfigDecodeHook(from, to reflect.Value) (interface{}, error) {
@@ -24,7 +71,16 @@ func HTTPConfigDecodeHook(from, to reflect.Value) (interface{}, error) {
 	}
 
 	config := struct {
-		DenyList []string
+		DenyList           []string
+		AllowList          []string
+		InstanceAllowLists map[string][]string
+		ClientCertificates map[string]struct {
+			CertificatePath string
+			KeyPath         string
+			Instances       []string
+		}
+		MaxResponseSize int64
+		MaxRedirects    int
 	}{}
 
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
@@ -41,18 +97,58 @@ func HTTPConfigDecodeHook(from, to reflect.Value) (interface{}, error) {
 	}
 
 	c := HTTPConfig{
-		DenyList: make([]AddressChecker, len(config.DenyList)),
+		InstanceAllowLists: make(map[string][]AddressChecker, len(config.InstanceAllowLists)),
+		ClientCertificates: make(map[string]*ClientCertificate, len(config.ClientCertificates)),
+		MaxResponseSize:    config.MaxResponseSize,
+		MaxRedirects:       config.MaxRedirects,
 	}
 
-	for i, entry := range config.DenyList {
-		if c.DenyList[i], err = parseDenyListEntry(entry); err != nil {
+	if c.DenyList, err = parseAddressList(config.DenyList); err != nil {
+		return nil, err
+	}
+	if c.AllowList, err = parseAddressList(config.AllowList); err != nil {
+		return nil, err
+	}
+	for instanceID, entries := range config.InstanceAllowLists {
+		if c.InstanceAllowLists[instanceID], err = parseAddressList(entries); err != nil {
 			return nil, err
 		}
 	}
+	for name, certConfig := range config.ClientCertificates {
+		cert, err := tls.LoadX509KeyPair(certConfig.CertificatePath, certConfig.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+		c.ClientCertificates[strings.ToLower(name)] = NewClientCertificate(cert, certConfig.Instances...)
+	}
 
 	return c, nil
 }
 
+// NewClientCertificate prepares the transport which authenticates with the certificate
+func NewClientCertificate(cert tls.Certificate, instances ...string) *ClientCertificate {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	return &ClientCertificate{
+		Certificate: cert,
+		Instances:   instances,
+		transport:   transport,
+	}
+}
+
+func parseAddressList(entries []string) (_ []AddressChecker, err error) {
+	list := make([]AddressChecker, len(entries))
+	for i, entry := range entries {
+		if list[i], err = parseDenyListEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
 func parseDenyListEntry(entry string) (AddressChecker, error) {
 	if checker, err := NewIPChecker(entry); err == nil {
 		return checker, nil
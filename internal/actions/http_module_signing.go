@@ -0,0 +1,128 @@
+package actions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dop251/goja"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	signingTypeHMAC = "hmac"
+	signingTypeJWT  = "jwt"
+
+	// SignatureHeader contains the hmac signature of the request in the format `t=<unix timestamp>,v1=<hex encoded signature>`
+	SignatureHeader = "ZITADEL-Signature"
+	// signedJWTLifetime is the validity of the jwt which signs the request
+	signedJWTLifetime = time.Minute
+)
+
+// signingConfig signs the request with the action secret called Secret
+type signingConfig struct {
+	Type   string
+	Secret string
+}
+
+func (c *HTTP) signingConfigFromArg(arg *goja.Object) (*signingConfig, error) {
+	config := new(signingConfig)
+	for _, key := range arg.Keys() {
+		switch key {
+		case "type":
+			config.Type = arg.Get(key).String()
+		case "secret":
+			config.Secret = arg.Get(key).String()
+		default:
+			return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Xae3u", "Errors.Action.HTTP.SigningInvalid")
+		}
+	}
+	if config.Secret == "" || (config.Type != signingTypeHMAC && config.Type != signingTypeJWT) {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-chie0", "Errors.Action.HTTP.SigningInvalid")
+	}
+	return config, nil
+}
+
+// sign adds the signature to the request
+// - hmac: the `ZITADEL-Signature` header contains the HMAC-SHA256 of `<unix timestamp>.<body>`
+// - jwt: the `Authorization` header contains a HS256 signed jwt as bearer token, the jwt contains the method and url of the request
+func (c *HTTP) sign(ctx context.Context, req *http.Request, config *signingConfig) error {
+	if c.storage == nil || c.orgID == "" {
+		return z_errs.ThrowPreconditionFailed(nil, "ACTIO-ooj4E", "Errors.Action.Storage.NoOrganisation")
+	}
+	secret, err := c.storage.GetActionSecretValue(ctx, c.orgID, config.Secret)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	switch config.Type {
+	case signingTypeHMAC:
+		body, err := requestBody(req)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(SignatureHeader, computeSignature(secret, now, body))
+	case signingTypeJWT:
+		token, err := signedJWT(ctx, secret, req, now)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+func computeSignature(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp.Unix())))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp.Unix(), hex.EncodeToString(mac.Sum(nil)))
+}
+
+type requestClaims struct {
+	jwt.Claims
+	Method string `json:"htm"`
+	URL    string `json:"htu"`
+}
+
+func signedJWT(ctx context.Context, secret string, req *http.Request, now time.Time) (string, error) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(secret)}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", z_errs.ThrowInternal(err, "ACTIO-aeG7o", "Errors.Internal")
+	}
+	// the url is used without query and fragment
+	target := *req.URL
+	target.RawQuery = ""
+	target.Fragment = ""
+	return jwt.Signed(signer).Claims(&requestClaims{
+		Claims: jwt.Claims{
+			Issuer:   authz.GetInstance(ctx).RequestedDomain(),
+			Audience: jwt.Audience{target.String()},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(signedJWTLifetime)),
+		},
+		Method: req.Method,
+		URL:    target.String(),
+	}).CompactSerialize()
+}
+
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		return nil, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
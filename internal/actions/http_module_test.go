@@ -3,13 +3,21 @@ package actions
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/logstore"
 )
@@ -433,3 +441,292 @@ func TestHTTP_buildHTTPRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkHost(t *testing.T) {
+	defer SetHTTPConfig(nil)
+	SetHTTPConfig(&HTTPConfig{
+		DenyList:  []AddressChecker{&DomainChecker{Domain: "denied.com"}},
+		AllowList: []AddressChecker{&DomainChecker{Domain: "allowed.com"}, &DomainChecker{Domain: "denied.com"}},
+		InstanceAllowLists: map[string][]AddressChecker{
+			"instance": {&DomainChecker{Domain: "internal.com"}},
+			"open":     {},
+		},
+	})
+	tests := []struct {
+		name       string
+		instanceID string
+		address    *url.URL
+		wantErr    bool
+	}{
+		{
+			name:    "allowed",
+			address: mustNewURL(t, "https://allowed.com/hodor"),
+		},
+		{
+			name:    "not in allow list",
+			address: mustNewURL(t, "https://other.com/hodor"),
+			wantErr: true,
+		},
+		{
+			name:    "denied before allowed",
+			address: mustNewURL(t, "https://denied.com/hodor"),
+			wantErr: true,
+		},
+		{
+			name:       "instance allow list",
+			instanceID: "instance",
+			address:    mustNewURL(t, "https://internal.com/hodor"),
+		},
+		{
+			name:       "instance allow list replaces default",
+			instanceID: "instance",
+			address:    mustNewURL(t, "https://allowed.com/hodor"),
+			wantErr:    true,
+		},
+		{
+			name:       "empty instance allow list",
+			instanceID: "open",
+			address:    mustNewURL(t, "https://other.com/hodor"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHost(authz.WithInstanceID(context.Background(), tt.instanceID), tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkHost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_checkRedirect(t *testing.T) {
+	defer SetHTTPConfig(nil)
+	SetHTTPConfig(&HTTPConfig{MaxRedirects: 1})
+	cert := new(ClientCertificate)
+	tests := []struct {
+		name       string
+		target     string
+		wantSigned bool
+		wantCert   bool
+	}{
+		{
+			name:       "same host",
+			target:     "https://origin.com/redirected",
+			wantSigned: true,
+			wantCert:   true,
+		},
+		{
+			name:   "other host",
+			target: "https://other.com/redirected",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), certificateKey{}, cert)
+			origin, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://origin.com/hodor", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, tt.target, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set(SignatureHeader, "signature")
+			req.Header.Set("Authorization", "Bearer token")
+
+			assert.NoError(t, checkRedirect(req, []*http.Request{origin}))
+			assert.Equal(t, tt.wantSigned, req.Header.Get(SignatureHeader) != "")
+			assert.Equal(t, tt.wantSigned, req.Header.Get("Authorization") != "")
+			_, hasCert := req.Context().Value(certificateKey{}).(*ClientCertificate)
+			assert.Equal(t, tt.wantCert, hasCert)
+		})
+	}
+}
+
+func TestHTTP_sign(t *testing.T) {
+	runtime := goja.New()
+	runtime.SetFieldNameMapper(goja.UncapFieldNameMapper())
+	storage := &mockStorage{secrets: map[string]string{"key": "secret"}}
+
+	tests := []struct {
+		name      string
+		signing   interface{}
+		orgID     string
+		check     func(t *testing.T, req *http.Request)
+		wantPanic bool
+	}{
+		{
+			name:    "hmac",
+			signing: map[string]interface{}{"type": "hmac", "secret": "key"},
+			orgID:   "org",
+			check: func(t *testing.T, req *http.Request) {
+				var timestamp int64
+				if _, err := fmt.Sscanf(req.Header.Get(SignatureHeader), "t=%d,", &timestamp); err != nil {
+					t.Fatalf("unable to parse signature %q: %v", req.Header.Get(SignatureHeader), err)
+				}
+				want := computeSignature("secret", time.Unix(timestamp, 0), []byte(`{"id":"1"}`))
+				assert.Equal(t, want, req.Header.Get(SignatureHeader))
+			},
+		},
+		{
+			name:    "jwt",
+			signing: map[string]interface{}{"type": "jwt", "secret": "key"},
+			orgID:   "org",
+			check: func(t *testing.T, req *http.Request) {
+				token, err := jwt.ParseSigned(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+				if err != nil {
+					t.Fatal(err)
+				}
+				claims := new(requestClaims)
+				if err = token.Claims([]byte("secret"), claims); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, http.MethodPost, claims.Method)
+				assert.Equal(t, "https://zitadel.cloud/hook", claims.URL)
+				assert.NoError(t, claims.Validate(jwt.Expected{Audience: jwt.Audience{"https://zitadel.cloud/hook"}, Time: time.Now()}))
+			},
+		},
+		{
+			name:      "unknown type",
+			signing:   map[string]interface{}{"type": "rsa", "secret": "key"},
+			orgID:     "org",
+			wantPanic: true,
+		},
+		{
+			name:      "secret not found",
+			signing:   map[string]interface{}{"type": "hmac", "secret": "unknown"},
+			orgID:     "org",
+			wantPanic: true,
+		},
+		{
+			name:      "no organisation",
+			signing:   map[string]interface{}{"type": "hmac", "secret": "key"},
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &HTTP{
+				runtime: runtime,
+				storage: storage,
+				orgID:   tt.orgID,
+			}
+			defer func() {
+				r := recover()
+				if (r != nil) != tt.wantPanic {
+					t.Errorf("wanted panic: %v got %v", tt.wantPanic, r)
+				}
+			}()
+			req := c.buildHTTPRequest(context.Background(), []goja.Value{
+				runtime.ToValue("https://zitadel.cloud/hook?query=1"),
+				runtime.ToValue(map[string]interface{}{
+					"method":  http.MethodPost,
+					"body":    map[string]interface{}{"id": "1"},
+					"signing": tt.signing,
+				}),
+			})
+			tt.check(t, req)
+		})
+	}
+}
+
+func TestHTTP_fetch(t *testing.T) {
+	defer SetHTTPConfig(nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/small":
+			w.Write([]byte(`{"ok":true}`))
+		case "/large":
+			w.Write(bytes.Repeat([]byte("a"), 100))
+		case "/redirect":
+			http.Redirect(w, r, "/small", http.StatusFound)
+		case "/external":
+			http.Redirect(w, r, "https://denied.com/small", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		config   *HTTPConfig
+		wantBody string
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "within limit",
+			path:     "/small",
+			config:   &HTTPConfig{MaxResponseSize: 20},
+			wantBody: `{"ok":true}`,
+		},
+		{
+			name:    "response too large",
+			path:    "/large",
+			config:  &HTTPConfig{MaxResponseSize: 20},
+			wantErr: errors.IsResourceExhausted,
+		},
+		{
+			name:     "unlimited",
+			path:     "/large",
+			config:   &HTTPConfig{},
+			wantBody: strings.Repeat("a", 100),
+		},
+		{
+			name:     "redirect followed",
+			path:     "/redirect",
+			config:   &HTTPConfig{MaxRedirects: 1},
+			wantBody: `{"ok":true}`,
+		},
+		{
+			name:    "too many redirects",
+			path:    "/redirect",
+			config:  &HTTPConfig{MaxRedirects: 0},
+			wantErr: func(err error) bool { return err != nil },
+		},
+		{
+			name: "redirect to denied host",
+			path: "/external",
+			config: &HTTPConfig{
+				DenyList:     []AddressChecker{&DomainChecker{Domain: "denied.com"}},
+				MaxRedirects: 1,
+			},
+			wantErr: func(err error) bool { return err != nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetHTTPConfig(tt.config)
+			runtime := goja.New()
+			runtime.SetFieldNameMapper(goja.UncapFieldNameMapper())
+			c := &HTTP{
+				runtime: runtime,
+				client: &http.Client{
+					Transport:     new(transport),
+					CheckRedirect: checkRedirect,
+				},
+			}
+			var (
+				res *response
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, _ = r.(error)
+						if err == nil {
+							err = fmt.Errorf("%v", r)
+						}
+					}
+				}()
+				res = c.fetch(context.Background())(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue(server.URL + tt.path)}}).Export().(*response)
+			}()
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantBody, res.Body)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@ package actions
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/console"
 	"github.com/sirupsen/logrus"
+	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/logstore"
@@ -22,10 +25,18 @@ func SetLogstoreService(svc *logstore.Service) {
 	logstoreService = svc
 }
 
+// runIDMetadataKey is the key of the metadata of the execution log records
+// which groups the records of a single action run
+const runIDMetadataKey = "runId"
+
 type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	actionID   string
+	runID      string
+	// dryRun captures the records instead of emitting them to the logstore
+	dryRun *DryRunResult
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
@@ -56,12 +67,25 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 		l.started = ts
 	}
 
+	if l.dryRun != nil {
+		l.dryRun.Logs = append(l.dryRun.Logs, &DryRunLog{
+			LogDate:  ts,
+			Message:  msg,
+			LogLevel: level,
+		})
+		return
+	}
+
 	record := &execution.Record{
 		LogDate:    ts,
 		InstanceID: l.instanceID,
+		ActionID:   l.actionID,
 		Message:    msg,
 		LogLevel:   level,
 	}
+	if l.runID != "" {
+		record.Metadata = map[string]interface{}{runIDMetadataKey: l.runID}
+	}
 
 	if last {
 		record.Took = ts.Sub(l.started)
@@ -70,11 +94,22 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 	logstoreService.Handle(l.ctx, record)
 }
 
+// newRunID returns a random id to group the records of an action run
+func newRunID() string {
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	logging.OnError(err).Warn("unable to generate run id of action")
+	return hex.EncodeToString(id)
+}
+
 func withLogger(ctx context.Context) Option {
 	instance := authz.GetInstance(ctx)
 	instanceID := instance.InstanceID()
 	return func(c *runConfig) {
 		c.logger = newLogger(ctx, instanceID)
+		c.logger.actionID = c.actionID
+		c.logger.runID = newRunID()
+		c.logger.dryRun = c.dryRun
 		c.instanceID = instanceID
 		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
@@ -0,0 +1,321 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// Queries are the read models available in the zitadel/query module.
+// All lookups are scoped to the instance of the context.
+type Queries interface {
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, withOwnerRemoved bool, queries ...query.SearchQuery) (*query.User, error)
+	SearchOrgMetadata(ctx context.Context, shouldTriggerBulk bool, orgID string, queries *query.OrgMetadataSearchQueries, withOwnerRemoved bool) (*query.OrgMetadataList, error)
+	SearchProjectRoles(ctx context.Context, shouldTriggerBulk bool, queries *query.ProjectRoleSearchQueries, withOwnerRemoved bool) (*query.ProjectRoles, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk, withOwnerRemoved bool) (*query.UserGrants, error)
+}
+
+type QueryConfig struct {
+	// MaxCalls is the maximum amount of lookups a single action run is allowed to execute
+	MaxCalls uint
+}
+
+var (
+	queryModuleQueries Queries
+	queryConfig        *QueryConfig
+)
+
+func SetQueryConfig(config *QueryConfig) {
+	queryConfig = config
+}
+
+// SetQueries enables the zitadel/query module
+func SetQueries(queries Queries) {
+	queryModuleQueries = queries
+}
+
+// withQuery registers the zitadel/query module if the queries are set.
+// The lookups are executed during the function execution,
+// so they are limited by the timeout of the action and counted against the execution quota.
+func withQuery(ctx context.Context) Option {
+	return func(c *runConfig) {
+		if queryModuleQueries == nil {
+			return
+		}
+		c.modules["zitadel/query"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireQuery(ctx, queryModuleQueries, maxQueryCalls(), runtime, module)
+		}
+	}
+}
+
+func maxQueryCalls() uint {
+	if queryConfig == nil {
+		return 0
+	}
+	return queryConfig.MaxCalls
+}
+
+type queryModule struct {
+	ctx      context.Context
+	queries  Queries
+	runtime  *goja.Runtime
+	calls    uint
+	maxCalls uint
+}
+
+func requireQuery(ctx context.Context, queries Queries, maxCalls uint, runtime *goja.Runtime, module *goja.Object) {
+	q := &queryModule{
+		ctx:      ctx,
+		queries:  queries,
+		runtime:  runtime,
+		maxCalls: maxCalls,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("getUserByID", q.getUserByID)).Warn("unable to set module")
+	logging.OnError(o.Set("getOrgMetadata", q.getOrgMetadata)).Warn("unable to set module")
+	logging.OnError(o.Set("getProjectRoles", q.getProjectRoles)).Warn("unable to set module")
+	logging.OnError(o.Set("getUserGrants", q.getUserGrants)).Warn("unable to set module")
+}
+
+// countCall panics if the maximum amount of lookups of the run is reached
+func (q *queryModule) countCall() {
+	if q.maxCalls > 0 && q.calls >= q.maxCalls {
+		panic(z_errs.ThrowResourceExhausted(nil, "ACTIO-Ohph6", "Errors.Action.QueryLimitExceeded"))
+	}
+	q.calls++
+}
+
+func (q *queryModule) getUserByID(userID string) goja.Value {
+	q.countCall()
+	user, err := q.queries.GetUserByID(q.ctx, false, userID, false)
+	if z_errs.IsNotFound(err) {
+		return goja.Null()
+	}
+	if err != nil {
+		panic(err)
+	}
+	if user.Human != nil {
+		return q.runtime.ToValue(&queryHumanUser{
+			QueryUser: queryUserFromQuery(user),
+			Human: queryHuman{
+				FirstName:         user.Human.FirstName,
+				LastName:          user.Human.LastName,
+				NickName:          user.Human.NickName,
+				DisplayName:       user.Human.DisplayName,
+				AvatarKey:         user.Human.AvatarKey,
+				PreferredLanguage: user.Human.PreferredLanguage.String(),
+				Gender:            user.Human.Gender,
+				Email:             user.Human.Email,
+				IsEmailVerified:   user.Human.IsEmailVerified,
+				Phone:             user.Human.Phone,
+				IsPhoneVerified:   user.Human.IsPhoneVerified,
+			},
+		})
+	}
+	return q.runtime.ToValue(&queryMachineUser{
+		QueryUser: queryUserFromQuery(user),
+		Machine: queryMachine{
+			Name:        user.Machine.Name,
+			Description: user.Machine.Description,
+		},
+	})
+}
+
+func (q *queryModule) getOrgMetadata(orgID string) goja.Value {
+	q.countCall()
+	metadata, err := q.queries.SearchOrgMetadata(q.ctx, false, orgID, &query.OrgMetadataSearchQueries{}, false)
+	if err != nil {
+		panic(err)
+	}
+	result := &queryMetadataList{
+		Count:    metadata.Count,
+		Metadata: make([]*queryMetadata, len(metadata.Metadata)),
+	}
+	for i, md := range metadata.Metadata {
+		result.Metadata[i] = &queryMetadata{
+			CreationDate:  md.CreationDate,
+			ChangeDate:    md.ChangeDate,
+			ResourceOwner: md.ResourceOwner,
+			Sequence:      md.Sequence,
+			Key:           md.Key,
+			Value:         metadataValue(md.Value),
+		}
+	}
+	return q.runtime.ToValue(result)
+}
+
+func (q *queryModule) getProjectRoles(projectID string) goja.Value {
+	q.countCall()
+	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		panic(err)
+	}
+	roles, err := q.queries.SearchProjectRoles(q.ctx, false, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{projectIDQuery}}, false)
+	if err != nil {
+		panic(err)
+	}
+	result := &queryProjectRoleList{
+		Count: roles.Count,
+		Roles: make([]*queryProjectRole, len(roles.ProjectRoles)),
+	}
+	for i, role := range roles.ProjectRoles {
+		result.Roles[i] = &queryProjectRole{
+			ProjectId:     role.ProjectID,
+			ResourceOwner: role.ResourceOwner,
+			Key:           role.Key,
+			DisplayName:   role.DisplayName,
+			Group:         role.Group,
+		}
+	}
+	return q.runtime.ToValue(result)
+}
+
+func (q *queryModule) getUserGrants(userID string) goja.Value {
+	q.countCall()
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		panic(err)
+	}
+	grants, err := q.queries.UserGrants(q.ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, false, false)
+	if err != nil {
+		panic(err)
+	}
+	result := &queryUserGrantList{
+		Count:  grants.Count,
+		Grants: make([]*queryUserGrant, len(grants.UserGrants)),
+	}
+	for i, grant := range grants.UserGrants {
+		result.Grants[i] = &queryUserGrant{
+			Id:                     grant.ID,
+			ProjectGrantId:         grant.GrantID,
+			State:                  grant.State,
+			UserGrantResourceOwner: grant.ResourceOwner,
+			UserId:                 grant.UserID,
+			UserResourceOwner:      grant.UserResourceOwner,
+			Roles:                  grant.Roles,
+			ProjectId:              grant.ProjectID,
+			ProjectName:            grant.ProjectName,
+		}
+	}
+	return q.runtime.ToValue(result)
+}
+
+// metadataValue returns the json value of the metadata or the string if it's no valid json
+func metadataValue(value []byte) interface{} {
+	var v interface{}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return string(value)
+	}
+	return v
+}
+
+func queryUserFromQuery(user *query.User) QueryUser {
+	return QueryUser{
+		Id:                 user.ID,
+		CreationDate:       user.CreationDate,
+		ChangeDate:         user.ChangeDate,
+		ResourceOwner:      user.ResourceOwner,
+		Sequence:           user.Sequence,
+		State:              user.State,
+		Username:           user.Username,
+		LoginNames:         user.LoginNames,
+		PreferredLoginName: user.PreferredLoginName,
+	}
+}
+
+// the types below match the objects passed to the actions in the object package,
+// which can't be used here because of the import cycle
+
+// QueryUser is exported because the runtime only maps the fields of exported embedded structs
+type QueryUser struct {
+	Id                 string
+	CreationDate       time.Time
+	ChangeDate         time.Time
+	ResourceOwner      string
+	Sequence           uint64
+	State              domain.UserState
+	Username           string
+	LoginNames         database.StringArray
+	PreferredLoginName string
+}
+
+type queryHumanUser struct {
+	QueryUser
+	Human queryHuman
+}
+
+type queryHuman struct {
+	FirstName         string
+	LastName          string
+	NickName          string
+	DisplayName       string
+	AvatarKey         string
+	PreferredLanguage string
+	Gender            domain.Gender
+	Email             domain.EmailAddress
+	IsEmailVerified   bool
+	Phone             domain.PhoneNumber
+	IsPhoneVerified   bool
+}
+
+type queryMachineUser struct {
+	QueryUser
+	Machine queryMachine
+}
+
+type queryMachine struct {
+	Name        string
+	Description string
+}
+
+type queryMetadataList struct {
+	Count    uint64
+	Metadata []*queryMetadata
+}
+
+type queryMetadata struct {
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	Key           string
+	Value         interface{}
+}
+
+type queryProjectRoleList struct {
+	Count uint64
+	Roles []*queryProjectRole
+}
+
+type queryProjectRole struct {
+	ProjectId     string
+	ResourceOwner string
+	Key           string
+	DisplayName   string
+	Group         string
+}
+
+type queryUserGrantList struct {
+	Count  uint64
+	Grants []*queryUserGrant
+}
+
+type queryUserGrant struct {
+	Id                     string
+	ProjectGrantId         string
+	State                  domain.UserGrantState
+	UserGrantResourceOwner string
+	UserId                 string
+	UserResourceOwner      string
+	Roles                  []string
+	ProjectId              string
+	ProjectName            string
+}
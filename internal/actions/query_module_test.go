@@ -0,0 +1,131 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockQueries struct {
+	Queries
+	users map[string]*query.User
+	roles []*query.ProjectRole
+}
+
+func (q *mockQueries) GetUserByID(_ context.Context, _ bool, userID string, _ bool, _ ...query.SearchQuery) (*query.User, error) {
+	user, ok := q.users[userID]
+	if !ok {
+		return nil, z_errs.ThrowNotFound(nil, "ACTIO-TEST", "Errors.User.NotFound")
+	}
+	return user, nil
+}
+
+func (q *mockQueries) SearchProjectRoles(_ context.Context, _ bool, _ *query.ProjectRoleSearchQueries, _ bool) (*query.ProjectRoles, error) {
+	return &query.ProjectRoles{
+		SearchResponse: query.SearchResponse{Count: uint64(len(q.roles))},
+		ProjectRoles:   q.roles,
+	}, nil
+}
+
+func Test_queryModule(t *testing.T) {
+	queries := &mockQueries{
+		users: map[string]*query.User{
+			"human": {
+				ID:       "human",
+				Username: "username",
+				State:    domain.UserStateActive,
+				Human:    &query.Human{FirstName: "first", LastName: "last"},
+			},
+			"machine": {
+				ID:       "machine",
+				Username: "machine",
+				Machine:  &query.Machine{Name: "name"},
+			},
+		},
+		roles: []*query.ProjectRole{
+			{ProjectID: "project", Key: "role"},
+		},
+	}
+	tests := []struct {
+		name     string
+		script   string
+		maxCalls uint
+		want     interface{}
+		wantErr  bool
+	}{
+		{
+			name:   "human user",
+			script: `query.getUserByID("human").human.firstName`,
+			want:   "first",
+		},
+		{
+			name:   "machine user",
+			script: `query.getUserByID("machine").machine.name`,
+			want:   "name",
+		},
+		{
+			name:   "user not found",
+			script: `query.getUserByID("unknown") === null`,
+			want:   true,
+		},
+		{
+			name:   "project roles",
+			script: `query.getProjectRoles("project").roles[0].key`,
+			want:   "role",
+		},
+		{
+			name:     "calls within limit",
+			script:   `query.getUserByID("human"); query.getUserByID("machine").id`,
+			maxCalls: 2,
+			want:     "machine",
+		},
+		{
+			name:     "calls exceed limit",
+			script:   `query.getUserByID("human"); query.getUserByID("machine")`,
+			maxCalls: 1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := goja.New()
+			vm.SetFieldNameMapper(goja.UncapFieldNameMapper())
+			registry := new(require.Registry)
+			registry.RegisterNativeModule("zitadel/query", func(runtime *goja.Runtime, module *goja.Object) {
+				requireQuery(context.Background(), queries, tt.maxCalls, runtime, module)
+			})
+			registry.Enable(vm)
+			if _, err := vm.RunString(`const query = require("zitadel/query")`); err != nil {
+				t.Fatalf("unable to require module: %v", err)
+			}
+
+			got, err := runScript(vm, tt.script)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("script error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Export() != tt.want {
+				t.Errorf("got %v, want %v", got.Export(), tt.want)
+			}
+		})
+	}
+}
+
+// runScript converts panics of the modules into errors like Run
+func runScript(vm *goja.Runtime, script string) (value goja.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return vm.RunString(script)
+}
@@ -0,0 +1,140 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// Storage provides the secrets and the key value store of the actions of an organisation
+type Storage interface {
+	// GetActionSecretValue returns the decrypted value of the secret
+	GetActionSecretValue(ctx context.Context, orgID, name string) (string, error)
+	// GetActionKeyValue returns nil if the key does not exist
+	GetActionKeyValue(ctx context.Context, orgID, key string) ([]byte, error)
+	SetActionKeyValue(ctx context.Context, orgID, key string, value []byte) (*domain.ObjectDetails, error)
+	RemoveActionKeyValue(ctx context.Context, orgID, key string) (*domain.ObjectDetails, error)
+}
+
+var storage Storage
+
+// SetStorage enables the zitadel/secrets and zitadel/kv modules
+func SetStorage(s Storage) {
+	storage = s
+}
+
+func withOrgID(orgID string) Option {
+	return func(c *runConfig) {
+		c.orgID = orgID
+	}
+}
+
+// withStorage registers the zitadel/secrets and zitadel/kv modules if the storage is set.
+// The modules are scoped to the organisation of the action.
+func withStorage(ctx context.Context) Option {
+	return func(c *runConfig) {
+		if storage == nil {
+			return
+		}
+		c.modules["zitadel/secrets"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireSecrets(ctx, c.wrapStorage(storage), c.orgID, module)
+		}
+		c.modules["zitadel/kv"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireKeyValue(ctx, c.wrapStorage(storage), c.orgID, runtime, module)
+		}
+	}
+}
+
+type secretsModule struct {
+	ctx     context.Context
+	storage Storage
+	orgID   string
+}
+
+func requireSecrets(ctx context.Context, storage Storage, orgID string, module *goja.Object) {
+	s := &secretsModule{
+		ctx:     ctx,
+		storage: storage,
+		orgID:   orgID,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("get", s.get)).Warn("unable to set module")
+}
+
+func (s *secretsModule) get(name string) string {
+	if s.orgID == "" {
+		panic(z_errs.ThrowPreconditionFailed(nil, "ACTIO-Eesh3", "Errors.Action.Storage.NoOrganisation"))
+	}
+	value, err := s.storage.GetActionSecretValue(s.ctx, s.orgID, name)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+type keyValueModule struct {
+	ctx     context.Context
+	storage Storage
+	orgID   string
+	runtime *goja.Runtime
+}
+
+func requireKeyValue(ctx context.Context, storage Storage, orgID string, runtime *goja.Runtime, module *goja.Object) {
+	kv := &keyValueModule{
+		ctx:     ctx,
+		storage: storage,
+		orgID:   orgID,
+		runtime: runtime,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("get", kv.get)).Warn("unable to set module")
+	logging.OnError(o.Set("set", kv.set)).Warn("unable to set module")
+	logging.OnError(o.Set("remove", kv.remove)).Warn("unable to set module")
+}
+
+func (kv *keyValueModule) checkOrg() {
+	if kv.orgID == "" {
+		panic(z_errs.ThrowPreconditionFailed(nil, "ACTIO-ooL4a", "Errors.Action.Storage.NoOrganisation"))
+	}
+}
+
+// get returns the parsed value of the key or null if it does not exist
+func (kv *keyValueModule) get(key string) goja.Value {
+	kv.checkOrg()
+	value, err := kv.storage.GetActionKeyValue(kv.ctx, kv.orgID, key)
+	if err != nil {
+		panic(err)
+	}
+	if value == nil {
+		return goja.Null()
+	}
+	var v interface{}
+	if err = json.Unmarshal(value, &v); err != nil {
+		panic(z_errs.ThrowInternal(err, "ACTIO-Iex9o", "Errors.Internal"))
+	}
+	return kv.runtime.ToValue(v)
+}
+
+// set stores the value json encoded
+func (kv *keyValueModule) set(key string, value goja.Value) {
+	kv.checkOrg()
+	data, err := json.Marshal(value.Export())
+	if err != nil {
+		panic(z_errs.ThrowInvalidArgument(err, "ACTIO-Ahc7i", "Errors.Action.Storage.InvalidValue"))
+	}
+	if _, err = kv.storage.SetActionKeyValue(kv.ctx, kv.orgID, key, data); err != nil {
+		panic(err)
+	}
+}
+
+func (kv *keyValueModule) remove(key string) {
+	kv.checkOrg()
+	if _, err := kv.storage.RemoveActionKeyValue(kv.ctx, kv.orgID, key); err != nil {
+		panic(err)
+	}
+}
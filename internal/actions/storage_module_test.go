@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type mockStorage struct {
+	secrets map[string]string
+	entries map[string][]byte
+}
+
+func (s *mockStorage) GetActionSecretValue(_ context.Context, _, name string) (string, error) {
+	secret, ok := s.secrets[name]
+	if !ok {
+		return "", z_errs.ThrowNotFound(nil, "ACTIO-TEST", "Errors.Action.Storage.SecretNotFound")
+	}
+	return secret, nil
+}
+
+func (s *mockStorage) GetActionKeyValue(_ context.Context, _, key string) ([]byte, error) {
+	return s.entries[key], nil
+}
+
+func (s *mockStorage) SetActionKeyValue(_ context.Context, _, key string, value []byte) (*domain.ObjectDetails, error) {
+	s.entries[key] = value
+	return &domain.ObjectDetails{}, nil
+}
+
+func (s *mockStorage) RemoveActionKeyValue(_ context.Context, _, key string) (*domain.ObjectDetails, error) {
+	delete(s.entries, key)
+	return &domain.ObjectDetails{}, nil
+}
+
+func Test_storageModules(t *testing.T) {
+	tests := []struct {
+		name    string
+		orgID   string
+		script  string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "get secret",
+			orgID:  "org",
+			script: `secrets.get("apiKey")`,
+			want:   "secret",
+		},
+		{
+			name:    "secret not found",
+			orgID:   "org",
+			script:  `secrets.get("unknown")`,
+			wantErr: true,
+		},
+		{
+			name:    "no organisation",
+			script:  `secrets.get("apiKey")`,
+			wantErr: true,
+		},
+		{
+			name:   "get not existing entry",
+			orgID:  "org",
+			script: `kv.get("unknown") === null`,
+			want:   true,
+		},
+		{
+			name:   "set and get entry",
+			orgID:  "org",
+			script: `kv.set("counter", {count: 1}); kv.get("counter").count`,
+			want:   int64(1),
+		},
+		{
+			name:   "remove entry",
+			orgID:  "org",
+			script: `kv.set("counter", 1); kv.remove("counter"); kv.get("counter") === null`,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &mockStorage{
+				secrets: map[string]string{"apiKey": "secret"},
+				entries: map[string][]byte{},
+			}
+			vm := goja.New()
+			vm.SetFieldNameMapper(goja.UncapFieldNameMapper())
+			registry := new(require.Registry)
+			registry.RegisterNativeModule("zitadel/secrets", func(runtime *goja.Runtime, module *goja.Object) {
+				requireSecrets(context.Background(), storage, tt.orgID, module)
+			})
+			registry.RegisterNativeModule("zitadel/kv", func(runtime *goja.Runtime, module *goja.Object) {
+				requireKeyValue(context.Background(), storage, tt.orgID, runtime, module)
+			})
+			registry.Enable(vm)
+			if _, err := vm.RunString(`const secrets = require("zitadel/secrets"); const kv = require("zitadel/kv")`); err != nil {
+				t.Fatalf("unable to require modules: %v", err)
+			}
+
+			got, err := runScript(vm, tt.script)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("script error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Export() != tt.want {
+				t.Errorf("got %v (%T), want %v", got.Export(), got.Export(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// ActiveActionsQuery returns the active actions of the trigger of a flow of an organisation
+type ActiveActionsQuery func(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string, withOwnerRemoved bool) ([]*query.Action, error)
+
+// RunTrigger runs the active actions of the trigger one after another.
+// Every action is executed with its own timeout, quota and allowedToFail are handled by [Run].
+// If no query is passed, no action is executed.
+func RunTrigger(ctx context.Context, activeActions ActiveActionsQuery, flowType domain.FlowType, triggerType domain.TriggerType, orgID string, ctxParam contextFields, apiParam apiFields) error {
+	if activeActions == nil {
+		return nil
+	}
+	triggerActions, err := activeActions(ctx, flowType, triggerType, orgID, false)
+	if err != nil {
+		return err
+	}
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+		err = Run(
+			actionCtx,
+			ctxParam,
+			apiParam,
+			a.Script,
+			a.Name,
+			append(ActionToOptions(a), WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func TestRunTrigger(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	type args struct {
+		activeActions ActiveActionsQuery
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "no query",
+			args:      args{},
+			wantCalls: 0,
+		},
+		{
+			name: "query fails",
+			args: args{
+				activeActions: func(context.Context, domain.FlowType, domain.TriggerType, string, bool) ([]*query.Action, error) {
+					return nil, errors.New("query failed")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "all actions executed",
+			args: args{
+				activeActions: func(context.Context, domain.FlowType, domain.TriggerType, string, bool) ([]*query.Action, error) {
+					return []*query.Action{
+						{Name: "first", Script: "function first(ctx, api) { api.count() }"},
+						{Name: "second", Script: "function second(ctx, api) { api.count() }"},
+					}, nil
+				},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "failing action stops execution",
+			args: args{
+				activeActions: func(context.Context, domain.FlowType, domain.TriggerType, string, bool) ([]*query.Action, error) {
+					return []*query.Action{
+						{Name: "first", Script: "function first(ctx, api) { throw 'failed' }"},
+						{Name: "second", Script: "function second(ctx, api) { api.count() }"},
+					}, nil
+				},
+			},
+			wantCalls: 0,
+			wantErr:   true,
+		},
+		{
+			name: "failing action allowed to fail",
+			args: args{
+				activeActions: func(context.Context, domain.FlowType, domain.TriggerType, string, bool) ([]*query.Action, error) {
+					return []*query.Action{
+						{Name: "first", Script: "function first(ctx, api) { throw 'failed' }", AllowedToFail: true},
+						{Name: "second", Script: "function second(ctx, api) { api.count() }"},
+					}, nil
+				},
+			},
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			api := WithAPIFields(SetFields("count", func() { calls++ }))
+			err := RunTrigger(context.Background(), tt.args.activeActions, domain.FlowTypeUserManagement, domain.TriggerTypePostChange, "org", nil, api)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RunTrigger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("RunTrigger() calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package actions
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/require"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// wasmHostModule is the name of the module which provides the host functions to the actions
+	wasmHostModule = "zitadel"
+	// wasmAllocFunction has to be exported by the module, the host writes the results of the host functions to the allocated memory
+	wasmAllocFunction = "alloc"
+	wasmPageSize      = 64 * 1024
+)
+
+type WASMConfig struct {
+	// MaxMemory is the maximum linear memory of a module in bytes, it's rounded up to pages of 64KiB
+	MaxMemory uint64
+}
+
+var (
+	wasmConfig *WASMConfig
+	// wasmCompilationCache prevents the compilation of the same module on each run
+	wasmCompilationCache = wazero.NewCompilationCache()
+)
+
+func SetWASMConfig(config *WASMConfig) {
+	wasmConfig = config
+}
+
+func withRuntime(runtime domain.ActionRuntime) Option {
+	return func(c *runConfig) {
+		c.runtime = runtime
+	}
+}
+
+func wasmMemoryLimitPages() uint32 {
+	// the maximum of wazero is 4GiB
+	if wasmConfig == nil || wasmConfig.MaxMemory == 0 {
+		return 65536
+	}
+	pages := (wasmConfig.MaxMemory + wasmPageSize - 1) / wasmPageSize
+	if pages > 65536 {
+		return 65536
+	}
+	return uint32(pages)
+}
+
+// wasmRun executes the exported function of the base64 encoded WebAssembly module.
+//
+// The function is called without arguments and returns an i32, everything but 0 is a failure.
+// Instead of the parameters of a javascript action, the module imports the following functions from the `zitadel` module.
+// The strings are passed as pointer and length, the results are returned as i64 with the pointer in the upper and the length in the lower 32 bits.
+// A result is a json object containing either the `result` or the `error` of the call.
+//   - ctx_get(path) i64: value of the ctx field at the dot separated path, e.g. `v1.user.username`, an empty path returns the whole ctx
+//   - ctx_call(path, args) i64: calls the function of the ctx at the path with the json array of arguments
+//   - api_call(path, args) i64: calls the function of the api at the path with the json array of arguments
+//   - require_call(module, function, args) i64: calls the function of a module, e.g. `fetch` of `zitadel/http`
+//   - set_error(message): sets the error message of a failed run
+//
+// The module has to export its memory and an `alloc(size i32) i32` function which returns a pointer to memory of the requested size.
+// The memory of the module is limited by [WASMConfig] and the execution by the timeout of the action.
+// Modules targeting WASI can write to stdout and stderr which are logged as info and warning.
+func wasmRun(ctx context.Context, config *runConfig, ctxParam contextFields, apiParam apiFields, script, name string) (err error) {
+	module, err := base64.StdEncoding.DecodeString(script)
+	if err != nil {
+		return z_errs.ThrowInvalidArgument(err, "ACTIO-iePh7", "Errors.Action.WASM.Invalid")
+	}
+	if ctxParam != nil {
+		ctxParam(config.ctxParam)
+	}
+	if apiParam != nil {
+		apiParam(config.apiParam)
+	}
+
+	t := config.StartFunction()
+	defer t.Stop()
+	ctx, cancel := context.WithTimeout(ctx, config.functionTimeout)
+	defer cancel()
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(wasmMemoryLimitPages()).
+		WithCompilationCache(wasmCompilationCache),
+	)
+	defer runtime.Close(ctx)
+
+	registry := new(require.Registry)
+	registry.Enable(config.vm)
+	for name, loader := range config.modules {
+		registry.RegisterNativeModule(name, loader)
+	}
+
+	host := &wasmHost{config: config}
+	if err = host.instantiate(ctx, runtime); err != nil {
+		return err
+	}
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return z_errs.ThrowInternal(err, "ACTIO-ieN3i", "Errors.Internal")
+	}
+
+	compiled, err := runtime.CompileModule(ctx, module)
+	if err != nil {
+		return z_errs.ThrowInvalidArgument(err, "ACTIO-Ohx4e", "Errors.Action.WASM.Invalid")
+	}
+	instance, err := runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().
+		WithStartFunctions("_initialize").
+		WithStdout(&wasmLogWriter{log: config.logger.Log}).
+		WithStderr(&wasmLogWriter{log: config.logger.Warn}).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader),
+	)
+	if err != nil {
+		return err
+	}
+	fn := instance.ExportedFunction(name)
+	if fn == nil {
+		return z_errs.ThrowInvalidArgument(nil, "ACTIO-Aix5s", "function not found")
+	}
+
+	return recoverFn(func() error {
+		results, err := fn.Call(ctx)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 || api.DecodeI32(results[0]) == 0 {
+			return nil
+		}
+		if host.err != "" {
+			return z_errs.ThrowInternal(nil, "ACTIO-Eiw5a", host.err)
+		}
+		return z_errs.ThrowInternal(nil, "ACTIO-xi6Ie", "action returned an error")
+	})
+}
+
+// wasmHost provides the fields and modules of the action to the WebAssembly module.
+// The goja runtime is used to call the functions of the fields and modules,
+// so they behave the same as in javascript actions.
+type wasmHost struct {
+	config *runConfig
+	err    string
+}
+
+func (h *wasmHost) instantiate(ctx context.Context, runtime wazero.Runtime) error {
+	_, err := runtime.NewHostModuleBuilder(wasmHostModule).
+		NewFunctionBuilder().
+		WithFunc(func(ctx context.Context, m api.Module, pathPtr, pathLen uint32) uint64 {
+			return h.respond(ctx, m, func() (goja.Value, error) {
+				return h.get(h.config.ctxParam.fields, readString(m, pathPtr, pathLen))
+			})
+		}).
+		WithParameterNames("path_ptr", "path_len").
+		Export("ctx_get").
+		NewFunctionBuilder().
+		WithFunc(func(ctx context.Context, m api.Module, pathPtr, pathLen, argsPtr, argsLen uint32) uint64 {
+			return h.respond(ctx, m, func() (goja.Value, error) {
+				return h.callField(h.config.ctxParam.fields, "ctx", readString(m, pathPtr, pathLen), readString(m, argsPtr, argsLen))
+			})
+		}).
+		WithParameterNames("path_ptr", "path_len", "args_ptr", "args_len").
+		Export("ctx_call").
+		NewFunctionBuilder().
+		WithFunc(func(ctx context.Context, m api.Module, pathPtr, pathLen, argsPtr, argsLen uint32) uint64 {
+			return h.respond(ctx, m, func() (goja.Value, error) {
+				return h.callField(h.config.apiParam.fields, "api", readString(m, pathPtr, pathLen), readString(m, argsPtr, argsLen))
+			})
+		}).
+		WithParameterNames("path_ptr", "path_len", "args_ptr", "args_len").
+		Export("api_call").
+		NewFunctionBuilder().
+		WithFunc(func(ctx context.Context, m api.Module, modulePtr, moduleLen, fnPtr, fnLen, argsPtr, argsLen uint32) uint64 {
+			return h.respond(ctx, m, func() (goja.Value, error) {
+				return h.callModule(readString(m, modulePtr, moduleLen), readString(m, fnPtr, fnLen), readString(m, argsPtr, argsLen))
+			})
+		}).
+		WithParameterNames("module_ptr", "module_len", "function_ptr", "function_len", "args_ptr", "args_len").
+		Export("require_call").
+		NewFunctionBuilder().
+		WithFunc(func(ctx context.Context, m api.Module, messagePtr, messageLen uint32) {
+			h.err = readString(m, messagePtr, messageLen)
+		}).
+		WithParameterNames("message_ptr", "message_len").
+		Export("set_error").
+		Instantiate(ctx)
+	if err != nil {
+		return z_errs.ThrowInternal(err, "ACTIO-Ahp0o", "Errors.Internal")
+	}
+	return nil
+}
+
+func (h *wasmHost) get(f fields, path string) (goja.Value, error) {
+	value, ok := lookupField(f, path)
+	if !ok {
+		return goja.Undefined(), nil
+	}
+	return h.config.vm.ToValue(value), nil
+}
+
+// callField calls the function at the path of the fields, dry runs record the calls of the api instead
+func (h *wasmHost) callField(f fields, root, path, args string) (goja.Value, error) {
+	if h.config.dryRun != nil && root == "api" {
+		arguments, err := parseArguments(args)
+		if err != nil {
+			return nil, err
+		}
+		h.config.dryRun.record(root+"."+path, arguments...)
+		return goja.Undefined(), nil
+	}
+	value, ok := lookupField(f, path)
+	if !ok {
+		return nil, z_errs.ThrowNotFoundf(nil, "ACTIO-eiM0a", "%s.%s not found", root, path)
+	}
+	return h.call(h.config.vm.ToValue(value), args)
+}
+
+func (h *wasmHost) callModule(name, function, args string) (goja.Value, error) {
+	exports, err := h.require(name)
+	if err != nil {
+		return nil, err
+	}
+	return h.call(exports.Get(function), args)
+}
+
+// require loads the module once per run, so the state of the module, e.g. the count of the queries, is kept between calls
+func (h *wasmHost) require(name string) (*goja.Object, error) {
+	if _, ok := h.config.modules[name]; !ok {
+		return nil, z_errs.ThrowNotFoundf(nil, "ACTIO-Je4ae", "module %s not found", name)
+	}
+	return require.Require(h.config.vm, name).ToObject(h.config.vm), nil
+}
+
+func (h *wasmHost) call(value goja.Value, args string) (goja.Value, error) {
+	fn, ok := goja.AssertFunction(value)
+	if !ok {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-ooG4e", "not a function")
+	}
+	arguments, err := parseArguments(args)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]goja.Value, len(arguments))
+	for i, argument := range arguments {
+		values[i] = h.config.vm.ToValue(argument)
+	}
+	return fn(goja.Undefined(), values...)
+}
+
+type wasmResponse struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// respond writes the json response of the host function into the memory of the module
+func (h *wasmHost) respond(ctx context.Context, m api.Module, fn func() (goja.Value, error)) uint64 {
+	response := new(wasmResponse)
+	var value goja.Value
+	err := recoverFn(func() (err error) {
+		value, err = fn()
+		return err
+	})
+	if err == nil {
+		response.Result, err = h.stringify(value)
+	}
+	if err != nil {
+		response.Error = err.Error()
+		response.Result = nil
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+
+	alloc := m.ExportedFunction(wasmAllocFunction)
+	if alloc == nil {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Ku2ei", "Errors.Action.WASM.AllocMissing"))
+	}
+	results, err := alloc.Call(ctx, uint64(len(body)))
+	if err != nil {
+		panic(err)
+	}
+	ptr := api.DecodeU32(results[0])
+	if !m.Memory().Write(ptr, body) {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-Ie8ao", "Errors.Action.WASM.AllocMissing"))
+	}
+	return uint64(ptr)<<32 | uint64(len(body))
+}
+
+// stringify converts the value the same way as JSON.stringify in javascript actions
+func (h *wasmHost) stringify(value goja.Value) (json.RawMessage, error) {
+	if value == nil || goja.IsUndefined(value) {
+		return json.RawMessage("null"), nil
+	}
+	stringify, ok := goja.AssertFunction(h.config.vm.Get("JSON").ToObject(h.config.vm).Get("stringify"))
+	if !ok {
+		return nil, z_errs.ThrowInternal(nil, "ACTIO-Uo3ae", "Errors.Internal")
+	}
+	result, err := stringify(goja.Undefined(), value)
+	if err != nil {
+		return nil, err
+	}
+	if goja.IsUndefined(result) {
+		return json.RawMessage("null"), nil
+	}
+	return json.RawMessage(result.String()), nil
+}
+
+func lookupField(f fields, path string) (interface{}, bool) {
+	if path == "" {
+		return f, true
+	}
+	var value interface{} = f
+	for _, segment := range strings.Split(path, ".") {
+		object, ok := value.(fields)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[segment]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func parseArguments(args string) ([]interface{}, error) {
+	if args == "" {
+		return nil, nil
+	}
+	var arguments []interface{}
+	if err := json.Unmarshal([]byte(args), &arguments); err != nil {
+		return nil, z_errs.ThrowInvalidArgument(err, "ACTIO-Thae9", "arguments must be a json array")
+	}
+	return arguments, nil
+}
+
+func readString(m api.Module, ptr, length uint32) string {
+	value, ok := m.Memory().Read(ptr, length)
+	if !ok {
+		panic(z_errs.ThrowInvalidArgument(nil, "ACTIO-ooS1e", "memory out of range"))
+	}
+	return string(value)
+}
+
+// wasmLogWriter logs the output of the module
+type wasmLogWriter struct {
+	log func(string)
+}
+
+func (w *wasmLogWriter) Write(p []byte) (int, error) {
+	if msg := strings.TrimSpace(string(p)); msg != "" {
+		w.log(msg)
+	}
+	return len(p), nil
+}
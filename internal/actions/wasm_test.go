@@ -0,0 +1,266 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestRun_wasm(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	SetWASMConfig(&WASMConfig{MaxMemory: 1 << 20})
+	defer SetWASMConfig(nil)
+	module := base64.StdEncoding.EncodeToString(testWASMModule())
+
+	tests := []struct {
+		name          string
+		function      string
+		timeout       time.Duration
+		wantFirstName string
+		wantErr       func(error) bool
+	}{
+		{
+			name:          "api called",
+			function:      "succeed",
+			wantFirstName: "Gigi",
+		},
+		{
+			name:     "error set",
+			function: "fail",
+			wantErr: func(err error) bool {
+				return err != nil && strings.Contains(err.Error(), "some error")
+			},
+		},
+		{
+			name:     "ctx read",
+			function: "ctx",
+			wantErr: func(err error) bool {
+				return err != nil && strings.Contains(err.Error(), `{"result":"Gigi"}`)
+			},
+		},
+		{
+			name:     "timeout",
+			function: "loop",
+			timeout:  100 * time.Millisecond,
+			wantErr: func(err error) bool {
+				return err != nil
+			},
+		},
+		{
+			name:     "memory limit",
+			function: "grow",
+			wantErr: func(err error) bool {
+				return err != nil
+			},
+		},
+		{
+			name:     "function not found",
+			function: "unknown",
+			wantErr: func(err error) bool {
+				return err != nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.timeout == 0 {
+				tt.timeout = 10 * time.Second
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			var firstName string
+			err := Run(ctx,
+				SetContextFields(SetFields("v1", SetFields("user", SetFields("firstName", "Gigi")))),
+				WithAPIFields(SetFields("setFirstName", func(name string) { firstName = name })),
+				module,
+				tt.function,
+				withRuntime(domain.ActionRuntimeWASM),
+			)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			}
+			assert.Equal(t, tt.wantFirstName, firstName)
+		})
+	}
+}
+
+func TestDryRun_wasm(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	module := base64.StdEncoding.EncodeToString(testWASMModule())
+
+	got, err := DryRun(context.Background(), "org", nil, domain.ActionRuntimeWASM, module, "succeed", 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, got.Err)
+	assert.Equal(t, []*DryRunMutation{{Path: "api.setFirstName", Args: []interface{}{"Gigi"}}}, got.Mutations)
+
+	got, err = DryRun(context.Background(), "org", nil, domain.ActionRuntimeWASM, module, "log", 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, got.Err)
+	if assert.Len(t, got.Logs, 3) {
+		assert.Equal(t, "hello", got.Logs[1].Message)
+	}
+}
+
+// testWASMModule assembles a WebAssembly module which exports the following functions:
+//   - succeed: calls api.setFirstName("Gigi")
+//   - fail: sets the error "some error" and returns 1
+//   - ctx: reads ctx.v1.user.firstName and sets the response as error
+//   - loop: runs forever
+//   - grow: grows the memory by 1000 pages and traps if it fails
+//   - log: logs "hello" using the zitadel/log module
+func testWASMModule() []byte {
+	const (
+		i32 = 0x7f
+		i64 = 0x7e
+	)
+	// strings of the data segment and their offsets
+	data := make([]byte, 128)
+	copy(data[0:], "setFirstName")
+	copy(data[16:], `["Gigi"]`)
+	copy(data[32:], "some error")
+	copy(data[48:], "v1.user.firstName")
+	copy(data[80:], "zitadel/log")
+	copy(data[96:], "log")
+	copy(data[112:], `["hello"]`)
+
+	types := [][]byte{
+		funcType([]byte{i32}, []byte{i32}),                          // alloc
+		funcType(nil, []byte{i32}),                                  // exported functions
+		funcType([]byte{i32, i32, i32, i32}, []byte{i64}),           // api_call
+		funcType([]byte{i32, i32}, nil),                             // set_error
+		funcType([]byte{i32, i32}, []byte{i64}),                     // ctx_get
+		funcType([]byte{i32, i32, i32, i32, i32, i32}, []byte{i64}), // require_call
+	}
+	imports := [][]byte{
+		wasmImport("api_call", 2),
+		wasmImport("set_error", 3),
+		wasmImport("ctx_get", 4),
+		wasmImport("require_call", 5),
+	}
+	const firstFunction = 4
+	exports := []string{"alloc", "succeed", "fail", "loop", "grow", "ctx", "log"}
+	codes := [][]byte{
+		// alloc: returns the current heap pointer and moves it by the size
+		code(nil, 0x23, 0, 0x23, 0, 0x20, 0, 0x6a, 0x24, 0),
+		// succeed
+		code(nil, i32Const(0), i32Const(12), i32Const(16), i32Const(8), 0x10, 0, 0x1a, i32Const(0)),
+		// fail
+		code(nil, i32Const(32), i32Const(10), 0x10, 1, i32Const(1)),
+		// loop
+		code(nil, 0x03, 0x40, 0x0c, 0, 0x0b, i32Const(0)),
+		// grow
+		code(nil, i32Const(1000), 0x40, 0, i32Const(-1), 0x46, 0x04, 0x40, 0x00, 0x0b, i32Const(0)),
+		// ctx: the result of ctx_get is split into pointer and length
+		code([]byte{1, 1, i64}, i32Const(48), i32Const(17), 0x10, 2, 0x21, 0,
+			0x20, 0, 0x42, 32, 0x88, 0xa7,
+			0x20, 0, 0xa7,
+			0x10, 1, i32Const(1)),
+		// log
+		code(nil, i32Const(80), i32Const(11), i32Const(96), i32Const(3), i32Const(112), i32Const(9), 0x10, 3, 0x1a, i32Const(0)),
+	}
+
+	functions := make([][]byte, len(codes))
+	for i := range functions {
+		functions[i] = []byte{1}
+	}
+	functions[0] = []byte{0}
+	exportEntries := [][]byte{append(wasmName("memory"), 0x02, 0)}
+	for i, name := range exports {
+		exportEntries = append(exportEntries, append(wasmName(name), 0x00, byte(firstFunction+i)))
+	}
+
+	module := []byte{0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00}
+	module = append(module, section(1, types...)...)
+	module = append(module, section(2, imports...)...)
+	module = append(module, section(3, functions...)...)
+	module = append(module, section(5, []byte{0x00, 1})...)
+	module = append(module, section(6, concat([]byte{i32, 0x01}, i32Const(1024), []byte{0x0b}))...)
+	module = append(module, section(7, exportEntries...)...)
+	module = append(module, section(10, codes...)...)
+	module = append(module, section(11, concat([]byte{0x00}, i32Const(0), []byte{0x0b}, uleb(uint32(len(data))), data))...)
+	return module
+}
+
+func funcType(params, results []byte) []byte {
+	return concat([]byte{0x60}, uleb(uint32(len(params))), params, uleb(uint32(len(results))), results)
+}
+
+func wasmImport(name string, typeIndex byte) []byte {
+	return concat(wasmName(wasmHostModule), wasmName(name), []byte{0x00, typeIndex})
+}
+
+func wasmName(name string) []byte {
+	return concat(uleb(uint32(len(name))), []byte(name))
+}
+
+// code encodes the body of a function, the instructions are either single bytes or byte slices
+func code(locals []byte, instructions ...interface{}) []byte {
+	if locals == nil {
+		locals = []byte{0}
+	}
+	body := bytes.NewBuffer(locals)
+	for _, instruction := range instructions {
+		switch i := instruction.(type) {
+		case int:
+			body.WriteByte(byte(i))
+		case byte:
+			body.WriteByte(i)
+		case []byte:
+			body.Write(i)
+		}
+	}
+	body.WriteByte(0x0b)
+	return concat(uleb(uint32(body.Len())), body.Bytes())
+}
+
+func section(id byte, entries ...[]byte) []byte {
+	content := concat(uleb(uint32(len(entries))), concat(entries...))
+	return concat([]byte{id}, uleb(uint32(len(content))), content)
+}
+
+func i32Const(value int32) []byte {
+	return append([]byte{0x41}, sleb(value)...)
+}
+
+func uleb(value uint32) []byte {
+	var encoded []byte
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value == 0 {
+			return append(encoded, b)
+		}
+		encoded = append(encoded, b|0x80)
+	}
+}
+
+func sleb(value int32) []byte {
+	var encoded []byte
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if (value == 0 && b&0x40 == 0) || (value == -1 && b&0x40 != 0) {
+			return append(encoded, b)
+		}
+		encoded = append(encoded, b|0x80)
+	}
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
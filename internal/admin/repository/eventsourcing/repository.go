@@ -24,7 +24,7 @@ type EsRepository struct {
 }
 
 func Start(ctx context.Context, conf Config, static static.Storage, dbClient *database.DB, esV2 *eventstore2.Eventstore, allowOrderByCreationDate bool) (*EsRepository, error) {
-	es, err := v1.Start(dbClient, allowOrderByCreationDate)
+	es, err := v1.Start(dbClient, allowOrderByCreationDate, esV2.InterceptV1Filter)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +36,7 @@ type API struct {
 	healthServer      *health.Server
 	accessInterceptor *http_mw.AccessInterceptor
 	queries           *query.Queries
+	forwardedHeaders  []string
 }
 
 type healthCheck interface {
@@ -51,6 +52,7 @@ func New(
 	authZ internal_authz.Config,
 	tlsConfig *tls.Config, http2HostName, http1HostName string,
 	accessInterceptor *http_mw.AccessInterceptor,
+	externalSecure bool,
 ) (_ *API, err error) {
 	api := &API{
 		port:              port,
@@ -61,9 +63,12 @@ func New(
 		queries:           queries,
 		accessInterceptor: accessInterceptor,
 	}
+	if authZ.TokenBinding.ClientCertificateHeader != "" {
+		api.forwardedHeaders = append(api.forwardedHeaders, authZ.TokenBinding.ClientCertificateHeader)
+	}
 
-	api.grpcServer = server.CreateServer(api.verifier, authZ, queries, http2HostName, tlsConfig, accessInterceptor.AccessService())
-	api.grpcGateway, err = server.CreateGateway(ctx, port, http1HostName, accessInterceptor)
+	api.grpcServer = server.CreateServer(api.verifier, authZ, queries, http2HostName, tlsConfig, accessInterceptor.AccessService(), externalSecure)
+	api.grpcGateway, err = server.CreateGateway(ctx, port, http1HostName, accessInterceptor, api.forwardedHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +94,7 @@ func (a *API) RegisterServer(ctx context.Context, grpcServer server.WithGatewayP
 		a.http1HostName,
 		a.accessInterceptor,
 		a.queries,
+		a.forwardedHeaders,
 	)
 	if err != nil {
 		return err
@@ -171,6 +177,7 @@ func (a *API) routeGRPCWeb() {
 				http_util.Accept,
 				http_util.AcceptLanguage,
 				http_util.Authorization,
+				http_util.DPoP,
 				http_util.ZitadelOrgID,
 				http_util.XUserAgent,
 				http_util.XGrpcWeb,
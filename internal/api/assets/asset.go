@@ -208,6 +208,11 @@ func GetAsset(w http.ResponseWriter, r *http.Request, resourceOwner, objectName
 	if err != nil {
 		return fmt.Errorf("download failed: %v", err)
 	}
+	// other objects (e.g. files of user bulk jobs) contain personal data and are only readable through the API of their resource
+	if !info.ObjectType.Public() {
+		http.Error(w, "file not found: "+objectName, http.StatusNotFound)
+		return nil
+	}
 	if info.Hash == strings.Trim(r.Header.Get(http_util.IfNoneMatch), "\"") {
 		w.Header().Set(http_util.LastModified, info.LastModified.Format(time.RFC1123))
 		w.Header().Set(http_util.Etag, "\""+info.Hash+"\"")
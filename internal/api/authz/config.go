@@ -2,6 +2,7 @@ package authz
 
 type Config struct {
 	RolePermissionMappings []RoleMapping
+	TokenBinding           TokenBindingConfig
 }
 
 type RoleMapping struct {
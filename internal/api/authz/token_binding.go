@@ -0,0 +1,370 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	stderrors "errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	DPoPPrefix    = "DPoP "
+	dpopProofType = "dpop+jwt"
+
+	tokenConfirmationKey key = 5
+
+	dpopNonceRequiredID = "AUTH-Neoh4"
+)
+
+// TokenBindingConfig configures sender-constrained access tokens,
+// either bound to a DPoP key (RFC 9449) or a TLS client certificate (RFC 8705).
+type TokenBindingConfig struct {
+	// DPoPProofMaxAge defines how long a DPoP proof is accepted after it was issued (iat)
+	DPoPProofMaxAge time.Duration
+	// ClientCertificateHeader is the name of the header in which a reverse proxy forwards the verified TLS client certificate.
+	// Certificate-bound tokens are disabled if empty.
+	// The proxy must always overwrite the header, so that clients are not able to set it themselves.
+	ClientCertificateHeader string
+	// DPoPNonce configures nonces provided by the server, which clients must include in their DPoP proofs
+	DPoPNonce DPoPNonceConfig
+
+	replays  *dpopReplayCache
+	nonceKey []byte
+}
+
+// DPoPNonceConfig configures server provided nonces of DPoP proofs (RFC 9449, section 8 and 9)
+type DPoPNonceConfig struct {
+	// Required enforces clients to include a nonce provided by the server in their DPoP proofs
+	Required bool
+	// Lifetime defines how long a provided nonce is accepted
+	Lifetime time.Duration
+	// Key signs the nonces and must be shared by all instances of a deployment.
+	// A random key is generated on startup if empty.
+	Key string
+}
+
+// Init prepares the replay protection and the nonces of DPoP proofs.
+// It must be called before the config is passed to the apis.
+func (c *TokenBindingConfig) Init() error {
+	c.replays = newDPoPReplayCache()
+	if c.DPoPNonce.Key != "" {
+		c.nonceKey = []byte(c.DPoPNonce.Key)
+		return nil
+	}
+	c.nonceKey = make([]byte, 32)
+	if _, err := rand.Read(c.nonceKey); err != nil {
+		return errors.ThrowInternal(err, "AUTH-ooL7e", "unable to generate DPoP nonce key")
+	}
+	return nil
+}
+
+// TokenConfirmation contains the thumbprints of the keys a token is bound to (cnf claim of RFC 7800).
+type TokenConfirmation struct {
+	// JKT is the JWK SHA-256 thumbprint of the DPoP key
+	JKT string `json:"jkt,omitempty"`
+	// X5TS256 is the SHA-256 thumbprint of the TLS client certificate
+	X5TS256 string `json:"x5t#S256,omitempty"`
+}
+
+func (c *TokenConfirmation) IsZero() bool {
+	return c == nil || (c.JKT == "" && c.X5TS256 == "")
+}
+
+func (c *TokenConfirmation) GetJKT() string {
+	if c == nil {
+		return ""
+	}
+	return c.JKT
+}
+
+func (c *TokenConfirmation) GetX5TS256() string {
+	if c == nil {
+		return ""
+	}
+	return c.X5TS256
+}
+
+// Verify checks that the keys presented by the client (proven in the current request)
+// match the keys the token is bound to.
+// Unbound tokens can be used with or without proof.
+func (c *TokenConfirmation) Verify(presented *TokenConfirmation) error {
+	if c.IsZero() {
+		return nil
+	}
+	if c.JKT != "" && c.JKT != presented.GetJKT() {
+		return errors.ThrowUnauthenticated(nil, "AUTH-Eish5", "token is bound to a DPoP key, which was not proven")
+	}
+	if c.X5TS256 != "" && c.X5TS256 != presented.GetX5TS256() {
+		return errors.ThrowUnauthenticated(nil, "AUTH-ahP8u", "token is bound to a client certificate, which was not presented")
+	}
+	return nil
+}
+
+// SetTokenConfirmation stores the keys proven by the client in the current request
+func SetTokenConfirmation(ctx context.Context, confirmation *TokenConfirmation) context.Context {
+	return context.WithValue(ctx, tokenConfirmationKey, confirmation)
+}
+
+// TokenConfirmationFromCtx returns the keys proven by the client in the current request (might be nil)
+func TokenConfirmationFromCtx(ctx context.Context) *TokenConfirmation {
+	confirmation, _ := ctx.Value(tokenConfirmationKey).(*TokenConfirmation)
+	return confirmation
+}
+
+// RequestConfirmation verifies the DPoP proof and the client certificate presented in a request
+// with the provided method and uri and returns the proven keys.
+// If the proof is sent along an access token, the token must be provided as well.
+// It returns nil if the client did not present any key.
+func (c *TokenBindingConfig) RequestConfirmation(dpopProofs []string, clientCertificate, method, uri, accessToken string) (*TokenConfirmation, error) {
+	confirmation := new(TokenConfirmation)
+	switch len(dpopProofs) {
+	case 0:
+	case 1:
+		jkt, err := c.VerifyDPoPProof(dpopProofs[0], method, uri, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		confirmation.JKT = jkt
+	default:
+		return nil, errors.ThrowUnauthenticated(nil, "AUTH-ooM4a", "multiple DPoP proofs")
+	}
+	if c.ClientCertificateHeader != "" && clientCertificate != "" {
+		x5t, err := ClientCertificateThumbprint(clientCertificate)
+		if err != nil {
+			return nil, err
+		}
+		confirmation.X5TS256 = x5t
+	}
+	if confirmation.IsZero() {
+		return nil, nil
+	}
+	return confirmation, nil
+}
+
+type dpopProofClaims struct {
+	JTI   string `json:"jti"`
+	HTM   string `json:"htm"`
+	HTU   string `json:"htu"`
+	IAT   int64  `json:"iat"`
+	ATH   string `json:"ath,omitempty"`
+	Nonce string `json:"nonce,omitempty"`
+}
+
+var dpopProofAlgorithms = map[string]bool{
+	string(jose.RS256): true,
+	string(jose.RS384): true,
+	string(jose.RS512): true,
+	string(jose.PS256): true,
+	string(jose.PS384): true,
+	string(jose.PS512): true,
+	string(jose.ES256): true,
+	string(jose.ES384): true,
+	string(jose.ES512): true,
+	string(jose.EdDSA): true,
+}
+
+// VerifyDPoPProof checks the DPoP proof (RFC 9449, section 4.3) for the request with the provided method and uri.
+// If the proof is sent along an access token, the token must be provided to check its hash (ath).
+// Every proof is only accepted once and, if required, must contain a valid nonce provided by the server.
+// It returns the JWK SHA-256 thumbprint of the proof key.
+func (c *TokenBindingConfig) VerifyDPoPProof(proof, method, uri, accessToken string) (jkt string, err error) {
+	if c.replays == nil || c.nonceKey == nil {
+		return "", errors.ThrowInternal(nil, "AUTH-Ais4u", "token binding not initialized")
+	}
+	signature, err := jose.ParseSigned(proof)
+	if err != nil {
+		return "", errors.ThrowUnauthenticated(err, "AUTH-Ieth7", "invalid DPoP proof")
+	}
+	if len(signature.Signatures) != 1 {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-xoo4E", "invalid DPoP proof")
+	}
+	header := signature.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != dpopProofType {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-Ahc2o", "invalid DPoP proof type")
+	}
+	if !dpopProofAlgorithms[header.Algorithm] {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-Yoo0a", "unsupported DPoP proof algorithm")
+	}
+	if header.JSONWebKey == nil || !header.JSONWebKey.Valid() || !header.JSONWebKey.IsPublic() {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-Ohd4i", "invalid DPoP proof key")
+	}
+	payload, err := signature.Verify(header.JSONWebKey)
+	if err != nil {
+		return "", errors.ThrowUnauthenticated(err, "AUTH-eeV2o", "invalid DPoP proof signature")
+	}
+	claims := new(dpopProofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", errors.ThrowUnauthenticated(err, "AUTH-Ro4ee", "invalid DPoP proof")
+	}
+	if claims.JTI == "" {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-bai6U", "DPoP proof jti missing")
+	}
+	if claims.HTM != method || !dpopURIMatches(claims.HTU, uri) {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-Eiv3z", "DPoP proof was not issued for this request")
+	}
+	issuedAt := time.Unix(claims.IAT, 0)
+	now := time.Now()
+	// the proof might be issued slightly in the future because of clock skew
+	if issuedAt.Before(now.Add(-c.DPoPProofMaxAge)) || issuedAt.After(now.Add(c.DPoPProofMaxAge)) {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-Sho8e", "DPoP proof expired")
+	}
+	if c.DPoPNonce.Required && !c.validDPoPNonce(claims.Nonce, now) {
+		return "", errors.ThrowUnauthenticated(nil, dpopNonceRequiredID, "DPoP proof nonce missing or expired")
+	}
+	if accessToken != "" {
+		hash := sha256.Sum256([]byte(accessToken))
+		if claims.ATH != base64.RawURLEncoding.EncodeToString(hash[:]) {
+			return "", errors.ThrowUnauthenticated(nil, "AUTH-aeT4i", "DPoP proof was not issued for this token")
+		}
+	}
+	thumbprint, err := header.JSONWebKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", errors.ThrowUnauthenticated(err, "AUTH-Uz3ai", "invalid DPoP proof key")
+	}
+	jkt = base64.RawURLEncoding.EncodeToString(thumbprint)
+	// the proof is accepted until it expires, so it must be remembered until then
+	if !c.replays.use(jkt, claims.JTI, issuedAt.Add(c.DPoPProofMaxAge), now) {
+		return "", errors.ThrowUnauthenticated(nil, "AUTH-Ceo9k", "DPoP proof was already used")
+	}
+	return jkt, nil
+}
+
+// IsDPoPNonceRequired checks if the DPoP proof was rejected because of a missing or expired nonce.
+// The client must then retry with the nonce returned by [TokenBindingConfig.DPoPNonceValue].
+func IsDPoPNonceRequired(err error) bool {
+	caosErr := new(errors.CaosError)
+	return stderrors.As(err, &caosErr) && caosErr.GetID() == dpopNonceRequiredID
+}
+
+// DPoPNonceValue returns the nonce, which clients must currently include in their DPoP proofs.
+// The nonce consists of the current time slot and its signature, so it can be verified by all instances without storing it.
+func (c *TokenBindingConfig) DPoPNonceValue() string {
+	return c.dpopNonce(c.dpopNonceSlot(time.Now()))
+}
+
+func (c *TokenBindingConfig) dpopNonceSlot(t time.Time) uint64 {
+	lifetime := int64(c.DPoPNonce.Lifetime / time.Second)
+	if lifetime <= 0 {
+		lifetime = 1
+	}
+	return uint64(t.Unix() / lifetime)
+}
+
+func (c *TokenBindingConfig) dpopNonce(slot uint64) string {
+	value := binary.BigEndian.AppendUint64(nil, slot)
+	mac := hmac.New(sha256.New, c.nonceKey)
+	mac.Write(value)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(value))
+}
+
+// validDPoPNonce accepts the nonce of the current and the previous time slot,
+// so that a nonce provided at the end of a slot can still be used
+func (c *TokenBindingConfig) validDPoPNonce(nonce string, now time.Time) bool {
+	if nonce == "" {
+		return false
+	}
+	slot := c.dpopNonceSlot(now)
+	for _, valid := range []uint64{slot, slot - 1} {
+		if subtle.ConstantTimeCompare([]byte(nonce), []byte(c.dpopNonce(valid))) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// dpopReplayCache remembers the used DPoP proofs (jti per key) until they expire
+type dpopReplayCache struct {
+	mu          sync.Mutex
+	used        map[string]time.Time
+	nextCleanup time.Time
+}
+
+func newDPoPReplayCache() *dpopReplayCache {
+	return &dpopReplayCache{
+		used: make(map[string]time.Time),
+	}
+}
+
+// use marks the proof as used and returns false if it was already used before
+func (c *dpopReplayCache) use(jkt, jti string, expiration, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.After(c.nextCleanup) {
+		for id, exp := range c.used {
+			if now.After(exp) {
+				delete(c.used, id)
+			}
+		}
+		c.nextCleanup = now.Add(time.Minute)
+	}
+	id := jkt + ":" + jti
+	if exp, ok := c.used[id]; ok && !now.After(exp) {
+		return false
+	}
+	c.used[id] = expiration
+	return true
+}
+
+// dpopURIMatches compares the htu claim with the uri of the request, ignoring query and fragment
+func dpopURIMatches(htu, uri string) bool {
+	claimed, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	requested, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(claimed.Scheme, requested.Scheme) &&
+		strings.EqualFold(claimed.Host, requested.Host) &&
+		claimed.EscapedPath() == requested.EscapedPath()
+}
+
+// ClientCertificateThumbprint parses the client certificate forwarded by a reverse proxy
+// and returns its SHA-256 thumbprint (x5t#S256).
+// The certificate can either be an url encoded PEM or a base64 encoded DER.
+func ClientCertificateThumbprint(header string) (string, error) {
+	var der []byte
+	if strings.Contains(header, "BEGIN") {
+		value, err := url.QueryUnescape(header)
+		if err != nil {
+			return "", errors.ThrowInvalidArgument(err, "AUTH-Aesh6", "invalid client certificate")
+		}
+		block, _ := pem.Decode([]byte(value))
+		if block == nil {
+			return "", errors.ThrowInvalidArgument(nil, "AUTH-Ohz0e", "invalid client certificate")
+		}
+		der = block.Bytes
+	} else {
+		value, err := url.PathUnescape(header)
+		if err != nil {
+			return "", errors.ThrowInvalidArgument(err, "AUTH-Ozah3", "invalid client certificate")
+		}
+		der, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+		if err != nil {
+			return "", errors.ThrowInvalidArgument(err, "AUTH-Ied5a", "invalid client certificate")
+		}
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", errors.ThrowInvalidArgument(err, "AUTH-Xae2u", "invalid client certificate")
+	}
+	thumbprint := sha256.Sum256(certificate.Raw)
+	return base64.RawURLEncoding.EncodeToString(thumbprint[:]), nil
+}
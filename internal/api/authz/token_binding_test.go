@@ -0,0 +1,297 @@
+package authz
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func newDPoPProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims *dpopProofClaims) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ)),
+	)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := signed.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func TestVerifyDPoPProof(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+	tokenHash := sha256.Sum256([]byte("accessToken"))
+	ath := base64.RawURLEncoding.EncodeToString(tokenHash[:])
+
+	type args struct {
+		proof       string
+		method      string
+		uri         string
+		accessToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"invalid proof",
+			args{
+				proof:  "invalid",
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			"",
+			true,
+		},
+		{
+			"wrong type",
+			args{
+				proof:  newDPoPProof(t, key, "JWT", &dpopProofClaims{JTI: "id", HTM: "POST", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			"",
+			true,
+		},
+		{
+			"jti missing",
+			args{
+				proof:  newDPoPProof(t, key, dpopProofType, &dpopProofClaims{HTM: "POST", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			"",
+			true,
+		},
+		{
+			"wrong method",
+			args{
+				proof:  newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "id", HTM: "GET", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			"",
+			true,
+		},
+		{
+			"wrong uri",
+			args{
+				proof:  newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "id", HTM: "POST", HTU: "https://issuer.com/oauth/v2/introspect", IAT: time.Now().Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			"",
+			true,
+		},
+		{
+			"expired",
+			args{
+				proof:  newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "id", HTM: "POST", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Add(-time.Hour).Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			"",
+			true,
+		},
+		{
+			"wrong access token hash",
+			args{
+				proof:       newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "id", HTM: "GET", HTU: "https://issuer.com/oidc/v1/userinfo", IAT: time.Now().Unix(), ATH: "hash"}),
+				method:      "GET",
+				uri:         "https://issuer.com/oidc/v1/userinfo",
+				accessToken: "accessToken",
+			},
+			"",
+			true,
+		},
+		{
+			"valid, query ignored",
+			args{
+				proof:  newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "id", HTM: "POST", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token?foo=bar",
+			},
+			jkt,
+			false,
+		},
+		{
+			"valid with access token",
+			args{
+				proof:       newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "id", HTM: "GET", HTU: "https://issuer.com/oidc/v1/userinfo", IAT: time.Now().Unix(), ATH: ath}),
+				method:      "GET",
+				uri:         "https://issuer.com/oidc/v1/userinfo",
+				accessToken: "accessToken",
+			},
+			jkt,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TokenBindingConfig{DPoPProofMaxAge: time.Minute}
+			require.NoError(t, config.Init())
+			got, err := config.VerifyDPoPProof(tt.args.proof, tt.args.method, tt.args.uri, tt.args.accessToken)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTokenConfirmation_Verify(t *testing.T) {
+	tests := []struct {
+		name      string
+		bound     *TokenConfirmation
+		presented *TokenConfirmation
+		wantErr   bool
+	}{
+		{
+			"unbound",
+			nil,
+			nil,
+			false,
+		},
+		{
+			"unbound, proof presented",
+			nil,
+			&TokenConfirmation{JKT: "jkt"},
+			false,
+		},
+		{
+			"dpop bound, no proof",
+			&TokenConfirmation{JKT: "jkt"},
+			nil,
+			true,
+		},
+		{
+			"dpop bound, other key",
+			&TokenConfirmation{JKT: "jkt"},
+			&TokenConfirmation{JKT: "other"},
+			true,
+		},
+		{
+			"dpop bound, ok",
+			&TokenConfirmation{JKT: "jkt"},
+			&TokenConfirmation{JKT: "jkt"},
+			false,
+		},
+		{
+			"certificate bound, no certificate",
+			&TokenConfirmation{X5TS256: "x5t"},
+			&TokenConfirmation{JKT: "jkt"},
+			true,
+		},
+		{
+			"certificate bound, ok",
+			&TokenConfirmation{X5TS256: "x5t"},
+			&TokenConfirmation{X5TS256: "x5t"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bound.Verify(tt.presented)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestVerifyDPoPProof_replay(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	config := &TokenBindingConfig{DPoPProofMaxAge: time.Minute}
+	require.NoError(t, config.Init())
+
+	proof := newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "id", HTM: "POST", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Unix()})
+	_, err = config.VerifyDPoPProof(proof, "POST", "https://issuer.com/oauth/v2/token", "")
+	require.NoError(t, err)
+	_, err = config.VerifyDPoPProof(proof, "POST", "https://issuer.com/oauth/v2/token", "")
+	assert.Error(t, err)
+
+	otherProof := newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: "other", HTM: "POST", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Unix()})
+	_, err = config.VerifyDPoPProof(otherProof, "POST", "https://issuer.com/oauth/v2/token", "")
+	assert.NoError(t, err)
+}
+
+func TestVerifyDPoPProof_nonce(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	config := &TokenBindingConfig{
+		DPoPProofMaxAge: time.Minute,
+		DPoPNonce: DPoPNonceConfig{
+			Required: true,
+			Lifetime: 5 * time.Minute,
+		},
+	}
+	require.NoError(t, config.Init())
+	otherConfig := &TokenBindingConfig{DPoPProofMaxAge: time.Minute}
+	require.NoError(t, otherConfig.Init())
+
+	tests := []struct {
+		name              string
+		nonce             string
+		wantNonceRequired bool
+	}{
+		{
+			"nonce missing",
+			"",
+			true,
+		},
+		{
+			"nonce invalid",
+			otherConfig.DPoPNonceValue(),
+			true,
+		},
+		{
+			"nonce expired",
+			config.dpopNonce(config.dpopNonceSlot(time.Now()) - 2),
+			true,
+		},
+		{
+			"nonce of previous slot",
+			config.dpopNonce(config.dpopNonceSlot(time.Now()) - 1),
+			false,
+		},
+		{
+			"valid nonce",
+			config.DPoPNonceValue(),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := newDPoPProof(t, key, dpopProofType, &dpopProofClaims{JTI: tt.name, HTM: "POST", HTU: "https://issuer.com/oauth/v2/token", IAT: time.Now().Unix(), Nonce: tt.nonce})
+			_, err := config.VerifyDPoPProof(proof, "POST", "https://issuer.com/oauth/v2/token", "")
+			assert.Equal(t, tt.wantNonceRequired, IsDPoPNonceRequired(err))
+			if !tt.wantNonceRequired {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package delivery
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/kevinburke/twilio-go"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	HandlerPrefix = "/notifications/delivery"
+	twilioPath    = "/twilio"
+	emailPath     = "/email"
+
+	twilioSignatureHeader = "X-Twilio-Signature"
+	bearerPrefix          = "Bearer "
+)
+
+// Handler receives the delivery status callbacks of the notification providers
+type Handler struct {
+	commands            *command.Commands
+	queries             *query.Queries
+	encryptionAlgorithm crypto.EncryptionAlgorithm
+	externalSecure      bool
+	emailCallbackToken  string
+}
+
+// emailStatus is the payload of the email delivery status callback
+type emailStatus struct {
+	MessageID string `json:"messageId"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+}
+
+// TwilioStatusCallbackURL returns the URL Twilio reports the delivery status of sent SMS to
+func TwilioStatusCallbackURL(origin string) string {
+	return origin + HandlerPrefix + twilioPath
+}
+
+// NewHandler creates the handler for the delivery status callbacks.
+// Twilio callbacks are verified by their signature,
+// email callbacks must provide the emailCallbackToken as bearer token and are disabled if it's empty.
+func NewHandler(
+	commands *command.Commands,
+	queries *query.Queries,
+	encryptionAlgorithm crypto.EncryptionAlgorithm,
+	externalSecure bool,
+	emailCallbackToken string,
+	instanceInterceptor func(next http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		commands:            commands,
+		queries:             queries,
+		encryptionAlgorithm: encryptionAlgorithm,
+		externalSecure:      externalSecure,
+		emailCallbackToken:  emailCallbackToken,
+	}
+
+	router := mux.NewRouter()
+	router.Use(instanceInterceptor)
+	router.HandleFunc(twilioPath, h.handleTwilioStatus).Methods(http.MethodPost)
+	router.HandleFunc(emailPath, h.handleEmailStatus).Methods(http.MethodPost)
+	return router
+}
+
+func (h *Handler) handleTwilioStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	token, err := h.twilioToken(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	origin := http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), h.externalSecure)
+	expected := twilio.GetExpectedTwilioSignature("", token, TwilioStatusCallbackURL(origin), r.PostForm)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(r.Header.Get(twilioSignatureHeader))) != 1 {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	status, ok := twilioStatusToDomain(r.PostForm.Get("MessageStatus"))
+	if !ok {
+		// intermediate states (e.g. queued, sent) are not recorded
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	reason := r.PostForm.Get("ErrorCode")
+	if reason != "" {
+		reason = "twilio error code " + reason
+	}
+	h.changeStatus(w, r, r.PostForm.Get("MessageSid"), status, reason)
+}
+
+func (h *Handler) handleEmailStatus(w http.ResponseWriter, r *http.Request) {
+	if h.emailCallbackToken == "" {
+		http.NotFound(w, r)
+		return
+	}
+	token := strings.TrimPrefix(http_utils.GetAuthorization(r), bearerPrefix)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.emailCallbackToken)) != 1 {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	data := new(emailStatus)
+	if err := json.NewDecoder(r.Body).Decode(data); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	status, ok := emailStatusToDomain(data.Status)
+	if !ok {
+		http.Error(w, "unknown status", http.StatusBadRequest)
+		return
+	}
+	h.changeStatus(w, r, strings.Trim(data.MessageID, "<>"), status, data.Reason)
+}
+
+func (h *Handler) changeStatus(w http.ResponseWriter, r *http.Request, providerMessageID string, status domain.NotificationDeliveryStatus, reason string) {
+	ctx := r.Context()
+	delivery, err := h.queries.NotificationDeliveryByProviderMessageID(ctx, providerMessageID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	_, err = h.commands.ChangeNotificationDeliveryStatus(ctx, delivery.ResourceOwner, delivery.ID, status, reason)
+	// providers might report the same status multiple times
+	if err != nil && !z_errs.IsPreconditionFailed(err) {
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "delivery", delivery.ID).WithError(err).Error("failed to change status of notification delivery")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) twilioToken(ctx context.Context) (string, error) {
+	active, err := query.NewSMSProviderStateQuery(domain.SMSConfigStateActive)
+	if err != nil {
+		return "", err
+	}
+	config, err := h.queries.SMSProviderConfig(ctx, active)
+	if err != nil {
+		return "", err
+	}
+	if config.TwilioConfig == nil {
+		return "", z_errs.ThrowNotFound(nil, "DELIV-Mb3xq", "Errors.SMS.Twilio.NotFound")
+	}
+	return crypto.DecryptString(config.TwilioConfig.Token, h.encryptionAlgorithm)
+}
+
+func twilioStatusToDomain(status string) (domain.NotificationDeliveryStatus, bool) {
+	switch status {
+	case "delivered":
+		return domain.NotificationDeliveryStatusDelivered, true
+	case "undelivered":
+		return domain.NotificationDeliveryStatusBounced, true
+	case "failed":
+		return domain.NotificationDeliveryStatusFailed, true
+	default:
+		return domain.NotificationDeliveryStatusUnspecified, false
+	}
+}
+
+func emailStatusToDomain(status string) (domain.NotificationDeliveryStatus, bool) {
+	switch strings.ToLower(status) {
+	case "delivered":
+		return domain.NotificationDeliveryStatusDelivered, true
+	case "bounced":
+		return domain.NotificationDeliveryStatusBounced, true
+	case "failed":
+		return domain.NotificationDeliveryStatusFailed, true
+	default:
+		return domain.NotificationDeliveryStatusUnspecified, false
+	}
+}
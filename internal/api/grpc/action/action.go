@@ -1,10 +1,20 @@
 package action
 
 import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zitadel/logging"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 	action_pb "github.com/zitadel/zitadel/pkg/grpc/action"
 	message_pb "github.com/zitadel/zitadel/pkg/grpc/message"
@@ -19,6 +29,12 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeCustomiseToken
 	case domain.FlowTypeInternalAuthentication.ID():
 		return domain.FlowTypeInternalAuthentication
+	case domain.FlowTypeCustomizeSAMLResponse.ID():
+		return domain.FlowTypeCustomizeSAMLResponse
+	case domain.FlowTypeUserManagement.ID():
+		return domain.FlowTypeUserManagement
+	case domain.FlowTypeNotification.ID():
+		return domain.FlowTypeNotification
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -47,6 +63,18 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreAccessTokenCreation
 	case domain.TriggerTypePreUserinfoCreation.ID():
 		return domain.TriggerTypePreUserinfoCreation
+	case domain.TriggerTypePreSAMLResponseCreation.ID():
+		return domain.TriggerTypePreSAMLResponseCreation
+	case domain.TriggerTypePostChange.ID():
+		return domain.TriggerTypePostChange
+	case domain.TriggerTypePostDeactivation.ID():
+		return domain.TriggerTypePostDeactivation
+	case domain.TriggerTypePostPasswordChange.ID():
+		return domain.TriggerTypePostPasswordChange
+	case domain.TriggerTypePreNotificationSend.ID():
+		return domain.TriggerTypePreNotificationSend
+	case domain.TriggerTypePostRiskEvaluation.ID():
+		return domain.TriggerTypePostRiskEvaluation
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -102,14 +130,53 @@ func ActionsToPb(actions []*query.Action) []*action_pb.Action {
 }
 
 func ActionToPb(action *query.Action) *action_pb.Action {
+	script, module := ActionScriptToPb(action.Runtime, action.Script)
 	return &action_pb.Action{
 		Id:            action.ID,
 		Details:       object_grpc.ChangeToDetailsPb(action.Sequence, action.ChangeDate, action.ResourceOwner),
 		State:         ActionStateToPb(action.State),
 		Name:          action.Name,
-		Script:        action.Script,
+		Script:        script,
 		Timeout:       durationpb.New(action.Timeout()),
 		AllowedToFail: action.AllowedToFail,
+		Runtime:       ActionRuntimeToPb(action.Runtime),
+		Module:        module,
+	}
+}
+
+// ActionScriptToPb returns the decoded module instead of the script for WebAssembly actions
+func ActionScriptToPb(runtime domain.ActionRuntime, script string) (string, []byte) {
+	if runtime != domain.ActionRuntimeWASM {
+		return script, nil
+	}
+	module, err := base64.StdEncoding.DecodeString(script)
+	logging.OnError(err).Warn("unable to decode module of action")
+	return "", module
+}
+
+// ActionScriptToDomain returns the script of the action, WebAssembly modules are stored base64 encoded
+func ActionScriptToDomain(runtime domain.ActionRuntime, script string, module []byte) string {
+	if runtime != domain.ActionRuntimeWASM {
+		return script
+	}
+	return base64.StdEncoding.EncodeToString(module)
+}
+
+func ActionRuntimeToPb(runtime domain.ActionRuntime) action_pb.ActionRuntime {
+	switch runtime {
+	case domain.ActionRuntimeWASM:
+		return action_pb.ActionRuntime_ACTION_RUNTIME_WASM
+	default:
+		return action_pb.ActionRuntime_ACTION_RUNTIME_JAVASCRIPT
+	}
+}
+
+func ActionRuntimeToDomain(runtime action_pb.ActionRuntime) domain.ActionRuntime {
+	switch runtime {
+	case action_pb.ActionRuntime_ACTION_RUNTIME_WASM:
+		return domain.ActionRuntimeWASM
+	default:
+		return domain.ActionRuntimeJavaScript
 	}
 }
 
@@ -145,3 +212,137 @@ func ActionStateToDomain(state action_pb.ActionState) domain.ActionState {
 		return domain.ActionStateUnspecified
 	}
 }
+
+func ActionSecretsToPb(secrets []*query.ActionSecret) []*action_pb.ActionSecret {
+	list := make([]*action_pb.ActionSecret, len(secrets))
+	for i, secret := range secrets {
+		list[i] = ActionSecretToPb(secret)
+	}
+	return list
+}
+
+func ActionSecretToPb(secret *query.ActionSecret) *action_pb.ActionSecret {
+	return &action_pb.ActionSecret{
+		Details: object_grpc.ChangeToDetailsPb(secret.Sequence, secret.ChangeDate, secret.ResourceOwner),
+		Name:    secret.Name,
+	}
+}
+
+func ActionKeyValuesToPb(entries []*query.ActionKeyValue) []*action_pb.ActionKeyValue {
+	list := make([]*action_pb.ActionKeyValue, len(entries))
+	for i, entry := range entries {
+		list[i] = ActionKeyValueToPb(entry)
+	}
+	return list
+}
+
+func ActionKeyValueToPb(entry *query.ActionKeyValue) *action_pb.ActionKeyValue {
+	return &action_pb.ActionKeyValue{
+		Details: object_grpc.ChangeToDetailsPb(entry.Sequence, entry.ChangeDate, entry.ResourceOwner),
+		Key:     entry.Key,
+		Value:   entry.Value,
+	}
+}
+
+func ActionRevisionsToPb(revisions []*query.ActionRevision) []*action_pb.ActionRevision {
+	list := make([]*action_pb.ActionRevision, len(revisions))
+	for i, revision := range revisions {
+		list[i] = ActionRevisionToPb(revision)
+	}
+	return list
+}
+
+func ActionRevisionToPb(revision *query.ActionRevision) *action_pb.ActionRevision {
+	script, module := ActionScriptToPb(revision.Runtime, revision.Script)
+	return &action_pb.ActionRevision{
+		Revision:      revision.Revision,
+		Details:       object_grpc.ChangeToDetailsPb(revision.Sequence, revision.ChangeDate, revision.ResourceOwner),
+		EditorUserId:  revision.EditorUserID,
+		Name:          revision.Name,
+		Script:        script,
+		Timeout:       durationpb.New(revision.Timeout),
+		AllowedToFail: revision.AllowedToFail,
+		Runtime:       ActionRuntimeToPb(revision.Runtime),
+		Module:        module,
+	}
+}
+
+func ActionMutationsToPb(mutations []*actions.DryRunMutation) ([]*action_pb.ActionMutation, error) {
+	list := make([]*action_pb.ActionMutation, len(mutations))
+	for i, mutation := range mutations {
+		args, err := argsToPb(mutation.Args)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = &action_pb.ActionMutation{
+			Path: mutation.Path,
+			Args: args,
+		}
+	}
+	return list, nil
+}
+
+// argsToPb converts the arguments using their json representation,
+// because the exported values of the runtime are not always supported by structpb
+func argsToPb(args []interface{}) (*structpb.ListValue, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACTIO-Ov3ie", "Errors.Internal")
+	}
+	list := new(structpb.ListValue)
+	if err = protojson.Unmarshal(data, list); err != nil {
+		return nil, errors.ThrowInternal(err, "ACTIO-aeP4u", "Errors.Internal")
+	}
+	return list, nil
+}
+
+func ActionDryRunLogsToPb(logs []*actions.DryRunLog) []*action_pb.ActionLog {
+	list := make([]*action_pb.ActionLog, len(logs))
+	for i, log := range logs {
+		list[i] = &action_pb.ActionLog{
+			LogDate: timestamppb.New(log.LogDate),
+			Message: log.Message,
+			Level:   ActionLogLevelToPb(log.LogLevel),
+		}
+	}
+	return list
+}
+
+func ActionExecutionsToPb(executions []*query.ActionExecution) []*action_pb.ActionExecution {
+	list := make([]*action_pb.ActionExecution, len(executions))
+	for i, execution := range executions {
+		list[i] = ActionExecutionToPb(execution)
+	}
+	return list
+}
+
+func ActionExecutionToPb(execution *query.ActionExecution) *action_pb.ActionExecution {
+	logs := make([]*action_pb.ActionLog, len(execution.Logs))
+	for i, log := range execution.Logs {
+		logs[i] = &action_pb.ActionLog{
+			LogDate: timestamppb.New(log.LogDate),
+			Message: log.Message,
+			Level:   ActionLogLevelToPb(log.LogLevel),
+		}
+	}
+	return &action_pb.ActionExecution{
+		RunId:     execution.RunID,
+		Finished:  timestamppb.New(execution.Finished),
+		Took:      durationpb.New(execution.Took),
+		Succeeded: execution.Succeeded,
+		Logs:      logs,
+	}
+}
+
+func ActionLogLevelToPb(level logrus.Level) action_pb.ActionLogLevel {
+	switch level {
+	case logrus.InfoLevel:
+		return action_pb.ActionLogLevel_ACTION_LOG_LEVEL_INFO
+	case logrus.WarnLevel:
+		return action_pb.ActionLogLevel_ACTION_LOG_LEVEL_WARN
+	case logrus.ErrorLevel:
+		return action_pb.ActionLogLevel_ACTION_LOG_LEVEL_ERROR
+	default:
+		return action_pb.ActionLogLevel_ACTION_LOG_LEVEL_UNSPECIFIED
+	}
+}
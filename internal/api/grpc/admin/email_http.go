@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListEmailHTTPProviders(ctx context.Context, req *admin_pb.ListEmailHTTPProvidersRequest) (*admin_pb.ListEmailHTTPProvidersResponse, error) {
+	queries := listEmailHTTPConfigsToModel(req)
+	result, err := s.query.SearchEmailHTTPConfigs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListEmailHTTPProvidersResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  EmailHTTPConfigsToPb(result.Configs),
+	}, nil
+}
+
+func (s *Server) GetEmailHTTPProvider(ctx context.Context, req *admin_pb.GetEmailHTTPProviderRequest) (*admin_pb.GetEmailHTTPProviderResponse, error) {
+	result, err := s.query.EmailHTTPConfigByID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetEmailHTTPProviderResponse{
+		Provider: EmailHTTPConfigToPb(result),
+	}, nil
+}
+
+func (s *Server) AddEmailHTTPProvider(ctx context.Context, req *admin_pb.AddEmailHTTPProviderRequest) (*admin_pb.AddEmailHTTPProviderResponse, error) {
+	id, result, err := s.command.AddEmailHTTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), AddEmailHTTPProviderToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddEmailHTTPProviderResponse{
+		Details: object.DomainToAddDetailsPb(result),
+		Id:      id,
+	}, nil
+}
+
+func (s *Server) UpdateEmailHTTPProvider(ctx context.Context, req *admin_pb.UpdateEmailHTTPProviderRequest) (*admin_pb.UpdateEmailHTTPProviderResponse, error) {
+	result, err := s.command.ChangeEmailHTTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, UpdateEmailHTTPProviderToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateEmailHTTPProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) UpdateEmailHTTPProviderAPIKey(ctx context.Context, req *admin_pb.UpdateEmailHTTPProviderAPIKeyRequest) (*admin_pb.UpdateEmailHTTPProviderAPIKeyResponse, error) {
+	result, err := s.command.ChangeEmailHTTPConfigAPIKey(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateEmailHTTPProviderAPIKeyResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) ActivateEmailHTTPProvider(ctx context.Context, req *admin_pb.ActivateEmailHTTPProviderRequest) (*admin_pb.ActivateEmailHTTPProviderResponse, error) {
+	result, err := s.command.ActivateEmailHTTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ActivateEmailHTTPProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) DeactivateEmailHTTPProvider(ctx context.Context, req *admin_pb.DeactivateEmailHTTPProviderRequest) (*admin_pb.DeactivateEmailHTTPProviderResponse, error) {
+	result, err := s.command.DeactivateEmailHTTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.DeactivateEmailHTTPProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) RemoveEmailHTTPProvider(ctx context.Context, req *admin_pb.RemoveEmailHTTPProviderRequest) (*admin_pb.RemoveEmailHTTPProviderResponse, error) {
+	result, err := s.command.RemoveEmailHTTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveEmailHTTPProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
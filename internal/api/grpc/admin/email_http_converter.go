@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpmail"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
+)
+
+func listEmailHTTPConfigsToModel(req *admin_pb.ListEmailHTTPProvidersRequest) *query.EmailHTTPConfigsSearchQueries {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.EmailHTTPConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}
+}
+
+func EmailHTTPConfigsToPb(configs []*query.EmailHTTPConfig) []*settings_pb.EmailHTTPProvider {
+	c := make([]*settings_pb.EmailHTTPProvider, len(configs))
+	for i, config := range configs {
+		c[i] = EmailHTTPConfigToPb(config)
+	}
+	return c
+}
+
+func EmailHTTPConfigToPb(config *query.EmailHTTPConfig) *settings_pb.EmailHTTPProvider {
+	return &settings_pb.EmailHTTPProvider{
+		Details:             object.ToViewDetailsPb(config.Sequence, config.CreationDate, config.ChangeDate, config.ResourceOwner),
+		Id:                  config.ID,
+		State:               emailHTTPStateToPb(config.State),
+		Preset:              emailHTTPPresetToPb(config.Preset),
+		Endpoint:            config.Endpoint,
+		ContentType:         config.ContentType,
+		BodyTemplate:        config.BodyTemplate,
+		AuthHeader:          config.AuthHeader,
+		SuccessStatusCodes:  config.SuccessStatusCodes,
+		SuccessBodyContains: config.SuccessBodyContains,
+		SenderAddress:       config.SenderAddress,
+		SenderName:          config.SenderName,
+	}
+}
+
+func emailHTTPStateToPb(state domain.EmailHTTPConfigState) settings_pb.EmailHTTPProviderState {
+	switch state {
+	case domain.EmailHTTPConfigStateActive:
+		return settings_pb.EmailHTTPProviderState_EMAIL_HTTP_PROVIDER_STATE_ACTIVE
+	case domain.EmailHTTPConfigStateInactive:
+		return settings_pb.EmailHTTPProviderState_EMAIL_HTTP_PROVIDER_STATE_INACTIVE
+	default:
+		return settings_pb.EmailHTTPProviderState_EMAIL_HTTP_PROVIDER_STATE_UNSPECIFIED
+	}
+}
+
+func emailHTTPPresetToPb(preset domain.EmailHTTPPreset) settings_pb.EmailHTTPProviderPreset {
+	switch preset {
+	case domain.EmailHTTPPresetSendGrid:
+		return settings_pb.EmailHTTPProviderPreset_EMAIL_HTTP_PROVIDER_PRESET_SENDGRID
+	case domain.EmailHTTPPresetMailgun:
+		return settings_pb.EmailHTTPProviderPreset_EMAIL_HTTP_PROVIDER_PRESET_MAILGUN
+	case domain.EmailHTTPPresetSES:
+		return settings_pb.EmailHTTPProviderPreset_EMAIL_HTTP_PROVIDER_PRESET_SES
+	default:
+		return settings_pb.EmailHTTPProviderPreset_EMAIL_HTTP_PROVIDER_PRESET_CUSTOM
+	}
+}
+
+func emailHTTPPresetToDomain(preset settings_pb.EmailHTTPProviderPreset) domain.EmailHTTPPreset {
+	switch preset {
+	case settings_pb.EmailHTTPProviderPreset_EMAIL_HTTP_PROVIDER_PRESET_SENDGRID:
+		return domain.EmailHTTPPresetSendGrid
+	case settings_pb.EmailHTTPProviderPreset_EMAIL_HTTP_PROVIDER_PRESET_MAILGUN:
+		return domain.EmailHTTPPresetMailgun
+	case settings_pb.EmailHTTPProviderPreset_EMAIL_HTTP_PROVIDER_PRESET_SES:
+		return domain.EmailHTTPPresetSES
+	default:
+		return domain.EmailHTTPPresetCustom
+	}
+}
+
+func AddEmailHTTPProviderToConfig(req *admin_pb.AddEmailHTTPProviderRequest) *httpmail.Config {
+	return &httpmail.Config{
+		Preset:              emailHTTPPresetToDomain(req.Preset),
+		Endpoint:            req.Endpoint,
+		ContentType:         req.ContentType,
+		BodyTemplate:        req.BodyTemplate,
+		AuthHeader:          req.AuthHeader,
+		APIKey:              req.ApiKey,
+		SuccessStatusCodes:  req.SuccessStatusCodes,
+		SuccessBodyContains: req.SuccessBodyContains,
+		From:                req.SenderAddress,
+		FromName:            req.SenderName,
+	}
+}
+
+func UpdateEmailHTTPProviderToConfig(req *admin_pb.UpdateEmailHTTPProviderRequest) *httpmail.Config {
+	return &httpmail.Config{
+		Preset:              emailHTTPPresetToDomain(req.Preset),
+		Endpoint:            req.Endpoint,
+		ContentType:         req.ContentType,
+		BodyTemplate:        req.BodyTemplate,
+		AuthHeader:          req.AuthHeader,
+		SuccessStatusCodes:  req.SuccessStatusCodes,
+		SuccessBodyContains: req.SuccessBodyContains,
+		From:                req.SenderAddress,
+		FromName:            req.SenderName,
+	}
+}
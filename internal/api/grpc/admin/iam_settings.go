@@ -5,7 +5,11 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object"
 )
 
 func (s *Server) ListSecretGenerators(ctx context.Context, req *admin_pb.ListSecretGeneratorsRequest) (*admin_pb.ListSecretGeneratorsResponse, error) {
@@ -46,17 +50,42 @@ func (s *Server) UpdateSecretGenerator(ctx context.Context, req *admin_pb.Update
 }
 
 func (s *Server) GetSMTPConfig(ctx context.Context, req *admin_pb.GetSMTPConfigRequest) (*admin_pb.GetSMTPConfigResponse, error) {
-	smtp, err := s.query.SMTPConfigByAggregateID(ctx, authz.GetInstance(ctx).InstanceID())
+	smtp, err := s.prioritizedSMTPConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &admin_pb.GetSMTPConfigResponse{
-		SmtpConfig: SMTPConfigToPb(smtp),
+		SmtpConfig: settings.SMTPConfigToPb(smtp),
+	}, nil
+}
+
+func (s *Server) GetSMTPConfigById(ctx context.Context, req *admin_pb.GetSMTPConfigByIdRequest) (*admin_pb.GetSMTPConfigByIdResponse, error) {
+	smtp, err := s.query.SMTPConfigByID(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetSMTPConfigByIdResponse{
+		SmtpConfig: settings.SMTPConfigToPb(smtp),
+	}, nil
+}
+
+func (s *Server) ListSMTPConfigs(ctx context.Context, req *admin_pb.ListSMTPConfigsRequest) (*admin_pb.ListSMTPConfigsResponse, error) {
+	queries, err := listSMTPConfigsToModel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchSMTPConfigs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListSMTPConfigsResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  settings.SMTPConfigsToPb(result.SMTPConfigs),
 	}, nil
 }
 
 func (s *Server) AddSMTPConfig(ctx context.Context, req *admin_pb.AddSMTPConfigRequest) (*admin_pb.AddSMTPConfigResponse, error) {
-	details, err := s.command.AddSMTPConfig(ctx, AddSMTPToConfig(req))
+	id, details, err := s.command.AddSMTPConfig(ctx, req.Priority, AddSMTPToConfig(req))
 	if err != nil {
 		return nil, err
 	}
@@ -65,11 +94,16 @@ func (s *Server) AddSMTPConfig(ctx context.Context, req *admin_pb.AddSMTPConfigR
 			details.Sequence,
 			details.EventDate,
 			details.ResourceOwner),
+		Id: id,
 	}, nil
 }
 
 func (s *Server) UpdateSMTPConfig(ctx context.Context, req *admin_pb.UpdateSMTPConfigRequest) (*admin_pb.UpdateSMTPConfigResponse, error) {
-	details, err := s.command.ChangeSMTPConfig(ctx, UpdateSMTPToConfig(req))
+	id, err := s.smtpConfigIDOrPrioritized(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ChangeSMTPConfig(ctx, id, req.Priority, UpdateSMTPToConfig(req))
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +115,12 @@ func (s *Server) UpdateSMTPConfig(ctx context.Context, req *admin_pb.UpdateSMTPC
 	}, nil
 }
 
-func (s *Server) RemoveSMTPConfig(ctx context.Context, _ *admin_pb.RemoveSMTPConfigRequest) (*admin_pb.RemoveSMTPConfigResponse, error) {
-	details, err := s.command.RemoveSMTPConfig(ctx)
+func (s *Server) RemoveSMTPConfig(ctx context.Context, req *admin_pb.RemoveSMTPConfigRequest) (*admin_pb.RemoveSMTPConfigResponse, error) {
+	id, err := s.smtpConfigIDOrPrioritized(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.RemoveSMTPConfig(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +133,11 @@ func (s *Server) RemoveSMTPConfig(ctx context.Context, _ *admin_pb.RemoveSMTPCon
 }
 
 func (s *Server) UpdateSMTPConfigPassword(ctx context.Context, req *admin_pb.UpdateSMTPConfigPasswordRequest) (*admin_pb.UpdateSMTPConfigPasswordResponse, error) {
-	details, err := s.command.ChangeSMTPConfigPassword(ctx, req.Password)
+	id, err := s.smtpConfigIDOrPrioritized(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ChangeSMTPConfigPassword(ctx, id, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +149,34 @@ func (s *Server) UpdateSMTPConfigPassword(ctx context.Context, req *admin_pb.Upd
 	}, nil
 }
 
+// smtpConfigIDOrPrioritized returns the id of the configuration with the highest priority if no id is provided,
+// so the requests of the single SMTP configuration API keep working
+func (s *Server) smtpConfigIDOrPrioritized(ctx context.Context, id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	config, err := s.prioritizedSMTPConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	return config.ID, nil
+}
+
+func (s *Server) prioritizedSMTPConfig(ctx context.Context) (*query.SMTPConfig, error) {
+	queries, err := listSMTPConfigsToModel(ctx, &admin_pb.ListSMTPConfigsRequest{Query: &object_pb.ListQuery{Limit: 1}})
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchSMTPConfigs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.SMTPConfigs) == 0 {
+		return nil, errors.ThrowNotFound(nil, "ADMIN-Ke8sv", "Errors.SMTPConfig.NotFound")
+	}
+	return result.SMTPConfigs[0], nil
+}
+
 func (s *Server) GetSecurityPolicy(ctx context.Context, req *admin_pb.GetSecurityPolicyRequest) (*admin_pb.GetSecurityPolicyResponse, error) {
 	policy, err := s.query.SecurityPolicy(ctx)
 	if err != nil {
@@ -1,10 +1,14 @@
 package admin
 
 import (
+	"context"
+
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -89,6 +93,8 @@ func SecretGeneratorTypeToPb(generatorType domain.SecretGeneratorType) settings_
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_PASSWORDLESS_INIT_CODE
 	case domain.SecretGeneratorTypeAppSecret:
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_APP_SECRET
+	case domain.SecretGeneratorTypeMagicLinkCode:
+		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_MAGIC_LINK_CODE
 	default:
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_UNSPECIFIED
 	}
@@ -108,6 +114,8 @@ func SecretGeneratorTypeToDomain(generatorType settings_pb.SecretGeneratorType)
 		return domain.SecretGeneratorTypePasswordlessInitCode
 	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_APP_SECRET:
 		return domain.SecretGeneratorTypeAppSecret
+	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_MAGIC_LINK_CODE:
+		return domain.SecretGeneratorTypeMagicLinkCode
 	default:
 		return domain.SecretGeneratorTypeUnspecified
 	}
@@ -122,6 +130,7 @@ func AddSMTPToConfig(req *admin_pb.AddSMTPConfigRequest) *smtp.Config {
 			Host:     req.Host,
 			User:     req.User,
 			Password: req.Password,
+			XOAuth2:  settings.SMTPXOAuth2ToConfig(req.Xoauth2),
 		},
 	}
 }
@@ -132,22 +141,27 @@ func UpdateSMTPToConfig(req *admin_pb.UpdateSMTPConfigRequest) *smtp.Config {
 		From:     req.SenderAddress,
 		FromName: req.SenderName,
 		SMTP: smtp.SMTP{
-			Host: req.Host,
-			User: req.User,
+			Host:    req.Host,
+			User:    req.User,
+			XOAuth2: settings.SMTPXOAuth2ToConfig(req.Xoauth2),
 		},
 	}
 }
 
-func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
-	mapped := &settings_pb.SMTPConfig{
-		Tls:           smtp.TLS,
-		SenderAddress: smtp.SenderAddress,
-		SenderName:    smtp.SenderName,
-		Host:          smtp.Host,
-		User:          smtp.User,
-		Details:       obj_grpc.ToViewDetailsPb(smtp.Sequence, smtp.CreationDate, smtp.ChangeDate, smtp.AggregateID),
+func listSMTPConfigsToModel(ctx context.Context, req *admin_pb.ListSMTPConfigsRequest) (*query.SMTPConfigSearchQueries, error) {
+	offset, limit, asc := obj_grpc.ListQueryToModel(req.Query)
+	resourceOwnerQuery, err := query.NewSMTPConfigResourceOwnerSearchQuery(authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
 	}
-	return mapped
+	return &query.SMTPConfigSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{resourceOwnerQuery},
+	}, nil
 }
 
 func SecurityPolicyToPb(policy *query.SecurityPolicy) *settings_pb.SecurityPolicy {
@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetKeyRotationPolicy(ctx context.Context, _ *admin_pb.GetKeyRotationPolicyRequest) (*admin_pb.GetKeyRotationPolicyResponse, error) {
+	policy, err := s.query.KeyRotationPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetKeyRotationPolicyResponse{
+		Policy: KeyRotationPolicyToPb(policy),
+	}, nil
+}
+
+func (s *Server) SetKeyRotationPolicy(ctx context.Context, req *admin_pb.SetKeyRotationPolicyRequest) (*admin_pb.SetKeyRotationPolicyResponse, error) {
+	details, err := s.command.SetKeyRotationPolicy(ctx, SetKeyRotationPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetKeyRotationPolicyResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListKeys(ctx context.Context, _ *admin_pb.ListKeysRequest) (*admin_pb.ListKeysResponse, error) {
+	keys, err := s.query.ActivePublicKeys(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	details := object.ToListDetails(keys.Count, 0, time.Now())
+	if keys.LatestSequence != nil {
+		details = object.ToListDetails(keys.Count, keys.Sequence, keys.Timestamp)
+	}
+	return &admin_pb.ListKeysResponse{
+		Details: details,
+		Result:  KeysToPb(keys.Keys),
+	}, nil
+}
+
+func (s *Server) RotateKeys(ctx context.Context, req *admin_pb.RotateKeysRequest) (*admin_pb.RotateKeysResponse, error) {
+	details, err := s.command.RotateKeyPairs(ctx, KeyUsageToDomain(req.Usage))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RotateKeysResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RevokeKey(ctx context.Context, req *admin_pb.RevokeKeyRequest) (*admin_pb.RevokeKeyResponse, error) {
+	details, err := s.command.RevokeKeyPair(ctx, req.KeyId)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RevokeKeyResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
+)
+
+func KeyRotationPolicyToPb(policy *query.KeyRotationPolicy) *settings_pb.KeyRotationPolicy {
+	return &settings_pb.KeyRotationPolicy{
+		Details:               obj_grpc.ToViewDetailsPb(policy.Sequence, policy.CreationDate, policy.ChangeDate, policy.AggregateID),
+		SigningKeyAlgorithm:   policy.SigningKeyAlgorithm,
+		SigningKeyLifetime:    durationpb.New(policy.SigningKeyLifetime),
+		CertificateLifetime:   durationpb.New(policy.CertificateLifetime),
+		PrePublicationPeriod:  durationpb.New(policy.PrePublicationPeriod),
+		RetirementGracePeriod: durationpb.New(policy.RetirementGracePeriod),
+	}
+}
+
+func SetKeyRotationPolicyToDomain(req *admin_pb.SetKeyRotationPolicyRequest) *domain.KeyRotationPolicy {
+	return &domain.KeyRotationPolicy{
+		SigningKeyAlgorithm:   req.SigningKeyAlgorithm,
+		SigningKeyLifetime:    req.SigningKeyLifetime.AsDuration(),
+		CertificateLifetime:   req.CertificateLifetime.AsDuration(),
+		PrePublicationPeriod:  req.PrePublicationPeriod.AsDuration(),
+		RetirementGracePeriod: req.RetirementGracePeriod.AsDuration(),
+	}
+}
+
+func KeysToPb(keys []query.PublicKey) []*settings_pb.Key {
+	result := make([]*settings_pb.Key, len(keys))
+	for i, key := range keys {
+		result[i] = &settings_pb.Key{
+			Id:             key.ID(),
+			Usage:          KeyUsageToPb(key.Use()),
+			Algorithm:      key.Algorithm(),
+			CreationDate:   timestamppb.New(key.CreationDate()),
+			ExpirationDate: timestamppb.New(key.Expiry()),
+		}
+	}
+	return result
+}
+
+func KeyUsageToPb(usage domain.KeyUsage) settings_pb.KeyUsage {
+	switch usage {
+	case domain.KeyUsageSAMLMetadataSigning:
+		return settings_pb.KeyUsage_KEY_USAGE_SAML_METADATA_SIGNING
+	case domain.KeyUsageSAMLResponseSinging:
+		return settings_pb.KeyUsage_KEY_USAGE_SAML_RESPONSE_SIGNING
+	case domain.KeyUsageSAMLCA:
+		return settings_pb.KeyUsage_KEY_USAGE_SAML_CA
+	default:
+		return settings_pb.KeyUsage_KEY_USAGE_SIGNING
+	}
+}
+
+func KeyUsageToDomain(usage settings_pb.KeyUsage) domain.KeyUsage {
+	switch usage {
+	case settings_pb.KeyUsage_KEY_USAGE_SAML_METADATA_SIGNING:
+		return domain.KeyUsageSAMLMetadataSigning
+	case settings_pb.KeyUsage_KEY_USAGE_SAML_RESPONSE_SIGNING:
+		return domain.KeyUsageSAMLResponseSinging
+	case settings_pb.KeyUsage_KEY_USAGE_SAML_CA:
+		return domain.KeyUsageSAMLCA
+	default:
+		return domain.KeyUsageSigning
+	}
+}
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetDefaultMailMessageTemplate(ctx context.Context, req *admin_pb.GetDefaultMailMessageTemplateRequest) (*admin_pb.GetDefaultMailMessageTemplateResponse, error) {
+	mailTemplate, err := s.query.DefaultMailTemplate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	template := mailTemplate.MessageTemplate(req.MessageType)
+	if template == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "ADMIN-Ld9wq", "Errors.IAM.MailTemplate.MessageNotFound")
+	}
+	return &admin_pb.GetDefaultMailMessageTemplateResponse{
+		Template: policy_grpc.ModelMailMessageTemplateToPb(template),
+	}, nil
+}
+
+func (s *Server) SetDefaultMailMessageTemplate(ctx context.Context, req *admin_pb.SetDefaultMailMessageTemplateRequest) (*admin_pb.SetDefaultMailMessageTemplateResponse, error) {
+	details, err := s.command.SetDefaultMailTemplateMessage(ctx, setDefaultMailMessageTemplateToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMailMessageTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveDefaultMailMessageTemplate(ctx context.Context, req *admin_pb.RemoveDefaultMailMessageTemplateRequest) (*admin_pb.RemoveDefaultMailMessageTemplateResponse, error) {
+	details, err := s.command.RemoveDefaultMailTemplateMessage(ctx, req.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveDefaultMailMessageTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewDefaultMailTemplate(ctx context.Context, req *admin_pb.PreviewDefaultMailTemplateRequest) (*admin_pb.PreviewDefaultMailTemplateResponse, error) {
+	email, err := notification.PreviewEmail(ctx, s.query, s.assetsAPIDomain(ctx), authz.GetInstance(ctx).InstanceID(), req.MessageType, req.Language, req.PreviewBranding)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.PreviewDefaultMailTemplateResponse{
+		Subject: email.Subject,
+		Html:    email.HTML,
+		Text:    email.Text,
+	}, nil
+}
+
+func setDefaultMailMessageTemplateToDomain(req *admin_pb.SetDefaultMailMessageTemplateRequest) *domain.MailMessageTemplate {
+	return &domain.MailMessageTemplate{
+		MessageType: req.MessageType,
+		Subject:     req.Subject,
+		HTML:        req.Html,
+		Text:        req.Text,
+	}
+}
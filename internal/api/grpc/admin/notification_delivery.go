@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"context"
+
+	notification_grpc "github.com/zitadel/zitadel/internal/api/grpc/notification"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListNotificationDeliveries(ctx context.Context, req *admin_pb.ListNotificationDeliveriesRequest) (*admin_pb.ListNotificationDeliveriesResponse, error) {
+	queries, err := notification_grpc.ListDeliveriesRequestToQuery(req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchNotificationDeliveries(ctx, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListNotificationDeliveriesResponse{
+		Result:  notification_grpc.DeliveriesToPb(res.Deliveries),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) ResendNotificationDelivery(ctx context.Context, req *admin_pb.ResendNotificationDeliveryRequest) (*admin_pb.ResendNotificationDeliveryResponse, error) {
+	delivery, err := s.query.NotificationDeliveryByID(ctx, req.Id, "", false)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ResendNotificationDelivery(ctx, delivery.ResourceOwner, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResendNotificationDeliveryResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
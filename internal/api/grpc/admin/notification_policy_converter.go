@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/command"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func addNotificationPolicyToCommand(req *admin_pb.AddNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceLogin:           req.GetNewDeviceLogin(),
+		MFAChange:                req.GetMfaChange(),
+		EmailChange:              req.GetEmailChange(),
+		PhoneChange:              req.GetPhoneChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
+
+func updateNotificationPolicyToCommand(req *admin_pb.UpdateNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceLogin:           req.GetNewDeviceLogin(),
+		MFAChange:                req.GetMfaChange(),
+		EmailChange:              req.GetEmailChange(),
+		PhoneChange:              req.GetPhoneChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) AddUserLifecyclePolicy(ctx context.Context, req *admin_pb.AddUserLifecyclePolicyRequest) (*admin_pb.AddUserLifecyclePolicyResponse, error) {
+	result, err := s.command.AddDefaultUserLifecyclePolicy(ctx, authz.GetInstance(ctx).InstanceID(), addUserLifecyclePolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddUserLifecyclePolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetUserLifecyclePolicy(ctx context.Context, _ *admin_pb.GetUserLifecyclePolicyRequest) (*admin_pb.GetUserLifecyclePolicyResponse, error) {
+	policy, err := s.query.DefaultUserLifecyclePolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetUserLifecyclePolicyResponse{Policy: policy_grpc.ModelUserLifecyclePolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateUserLifecyclePolicy(ctx context.Context, req *admin_pb.UpdateUserLifecyclePolicyRequest) (*admin_pb.UpdateUserLifecyclePolicyResponse, error) {
+	result, err := s.command.ChangeDefaultUserLifecyclePolicy(ctx, authz.GetInstance(ctx).InstanceID(), updateUserLifecyclePolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateUserLifecyclePolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func addUserLifecyclePolicyToDomain(req *admin_pb.AddUserLifecyclePolicyRequest) *domain.UserLifecyclePolicy {
+	return &domain.UserLifecyclePolicy{
+		DeactivateAfterDays: uint64(req.GetDeactivateAfterDays()),
+		DeleteAfterDays:     uint64(req.GetDeleteAfterDays()),
+		WarnDaysBefore:      uint64(req.GetWarnDaysBefore()),
+		ExcludeMachineUsers: req.GetExcludeMachineUsers(),
+		ExcludedUserIDs:     req.GetExcludedUserIds(),
+		DryRun:              req.GetDryRun(),
+	}
+}
+
+func updateUserLifecyclePolicyToDomain(req *admin_pb.UpdateUserLifecyclePolicyRequest) *domain.UserLifecyclePolicy {
+	return &domain.UserLifecyclePolicy{
+		DeactivateAfterDays: uint64(req.GetDeactivateAfterDays()),
+		DeleteAfterDays:     uint64(req.GetDeleteAfterDays()),
+		WarnDaysBefore:      uint64(req.GetWarnDaysBefore()),
+		ExcludeMachineUsers: req.GetExcludeMachineUsers(),
+		ExcludedUserIDs:     req.GetExcludedUserIds(),
+		DryRun:              req.GetDryRun(),
+	}
+}
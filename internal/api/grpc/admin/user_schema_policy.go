@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) AddUserSchemaPolicy(ctx context.Context, req *admin_pb.AddUserSchemaPolicyRequest) (*admin_pb.AddUserSchemaPolicyResponse, error) {
+	result, err := s.command.AddDefaultUserSchemaPolicy(ctx, authz.GetInstance(ctx).InstanceID(), addUserSchemaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddUserSchemaPolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetUserSchemaPolicy(ctx context.Context, _ *admin_pb.GetUserSchemaPolicyRequest) (*admin_pb.GetUserSchemaPolicyResponse, error) {
+	policy, err := s.query.DefaultUserSchemaPolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetUserSchemaPolicyResponse{Policy: policy_grpc.ModelUserSchemaPolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateUserSchemaPolicy(ctx context.Context, req *admin_pb.UpdateUserSchemaPolicyRequest) (*admin_pb.UpdateUserSchemaPolicyResponse, error) {
+	result, err := s.command.ChangeDefaultUserSchemaPolicy(ctx, authz.GetInstance(ctx).InstanceID(), updateUserSchemaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateUserSchemaPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
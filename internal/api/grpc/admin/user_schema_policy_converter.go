@@ -0,0 +1,19 @@
+package admin
+
+import (
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func addUserSchemaPolicyToDomain(req *admin_pb.AddUserSchemaPolicyRequest) *domain.UserSchemaPolicy {
+	return &domain.UserSchemaPolicy{
+		Attributes: policy_grpc.UserSchemaAttributesToDomain(req.GetAttributes()),
+	}
+}
+
+func updateUserSchemaPolicyToDomain(req *admin_pb.UpdateUserSchemaPolicyRequest) *domain.UserSchemaPolicy {
+	return &domain.UserSchemaPolicy{
+		Attributes: policy_grpc.UserSchemaAttributesToDomain(req.GetAttributes()),
+	}
+}
@@ -11,6 +11,7 @@ import (
 	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
@@ -26,6 +27,27 @@ func (s *Server) GetMyUser(ctx context.Context, _ *auth_pb.GetMyUserRequest) (*a
 	return &auth_pb.GetMyUserResponse{User: user_grpc.UserToPb(user, s.assetsAPIDomain(ctx))}, nil
 }
 
+func (s *Server) ExportMyUserData(ctx context.Context, req *auth_pb.ExportMyUserDataRequest) (*auth_pb.ExportMyUserDataResponse, error) {
+	export, err := s.query.UserDataExport(ctx, authz.GetCtxData(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+	hidden, err := s.adminUserSchemaAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	export.RemoveMetadata(hidden)
+	data, fileName, contentType, err := user_grpc.UserDataExportToPb(export, req.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.ExportMyUserDataResponse{
+		Data:        data,
+		FileName:    fileName,
+		ContentType: contentType,
+	}, nil
+}
+
 func (s *Server) RemoveMyUser(ctx context.Context, _ *auth_pb.RemoveMyUserRequest) (*auth_pb.RemoveMyUserResponse, error) {
 	ctxData := authz.GetCtxData(ctx)
 	userGrantUserID, err := query.NewUserGrantUserIDSearchQuery(ctxData.UserID)
@@ -102,13 +124,30 @@ func (s *Server) ListMyMetadata(ctx context.Context, req *auth_pb.ListMyMetadata
 	if err != nil {
 		return nil, err
 	}
+	hidden, err := s.adminUserSchemaAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]*query.UserMetadata, 0, len(res.Metadata))
+	for _, data := range res.Metadata {
+		if _, ok := hidden[data.Key]; !ok {
+			visible = append(visible, data)
+		}
+	}
 	return &auth_pb.ListMyMetadataResponse{
-		Result:  metadata.UserMetadataListToPb(res.Metadata),
-		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+		Result:  metadata.UserMetadataListToPb(visible),
+		Details: obj_grpc.ToListDetails(res.Count-uint64(len(res.Metadata)-len(visible)), res.Sequence, res.Timestamp),
 	}, nil
 }
 
 func (s *Server) GetMyMetadata(ctx context.Context, req *auth_pb.GetMyMetadataRequest) (*auth_pb.GetMyMetadataResponse, error) {
+	hidden, err := s.adminUserSchemaAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := hidden[req.Key]; ok {
+		return nil, caos_errs.ThrowNotFound(nil, "AUTH-Usc6m", "Errors.Metadata.NotFound")
+	}
 	data, err := s.query.GetUserMetadataByKey(ctx, true, authz.GetCtxData(ctx).UserID, req.Key, false)
 	if err != nil {
 		return nil, err
@@ -118,6 +157,24 @@ func (s *Server) GetMyMetadata(ctx context.Context, req *auth_pb.GetMyMetadataRe
 	}, nil
 }
 
+// adminUserSchemaAttributes returns the names of the attributes of the user schema, which are only visible to administrators
+func (s *Server) adminUserSchemaAttributes(ctx context.Context) (map[string]struct{}, error) {
+	schema, err := s.query.UserSchemaPolicyByOrg(ctx, false, authz.GetCtxData(ctx).ResourceOwner, false)
+	if caos_errs.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]struct{})
+	for _, attribute := range schema.Attributes {
+		if attribute.Visibility == domain.UserSchemaAttributeVisibilityAdmin {
+			attributes[attribute.Name] = struct{}{}
+		}
+	}
+	return attributes, nil
+}
+
 func (s *Server) ListMyUserSessions(ctx context.Context, req *auth_pb.ListMyUserSessionsRequest) (*auth_pb.ListMyUserSessionsResponse, error) {
 	userSessions, err := s.repo.GetMyUserSessions(ctx)
 	if err != nil {
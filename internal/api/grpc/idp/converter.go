@@ -308,6 +308,30 @@ func OptionsToCommand(options *idp_pb.Options) idp.Options {
 		IsLinkingAllowed:  options.IsLinkingAllowed,
 		IsAutoCreation:    options.IsAutoCreation,
 		IsAutoUpdate:      options.IsAutoUpdate,
+		AutoLinking:       autoLinkingOptionToCommand(options.AutoLinking),
+		AutoLinkingMode:   autoLinkingModeToCommand(options.AutoLinkingMode),
+	}
+}
+
+func autoLinkingOptionToCommand(option idp_pb.AutoLinkingOption) domain.AutoLinkingOption {
+	switch option {
+	case idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_EMAIL:
+		return domain.AutoLinkingOptionEmail
+	case idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_UNSPECIFIED:
+		fallthrough
+	default:
+		return domain.AutoLinkingOptionUnspecified
+	}
+}
+
+func autoLinkingModeToCommand(mode idp_pb.AutoLinkingMode) domain.AutoLinkingMode {
+	switch mode {
+	case idp_pb.AutoLinkingMode_AUTO_LINKING_MODE_AUTOMATIC:
+		return domain.AutoLinkingModeAutomatic
+	case idp_pb.AutoLinkingMode_AUTO_LINKING_MODE_PROMPT:
+		fallthrough
+	default:
+		return domain.AutoLinkingModePrompt
 	}
 }
 
@@ -428,6 +452,8 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 			IsCreationAllowed: config.IsCreationAllowed,
 			IsAutoCreation:    config.IsAutoCreation,
 			IsAutoUpdate:      config.IsAutoUpdate,
+			AutoLinking:       autoLinkingOptionToPb(config.AutoLinking),
+			AutoLinkingMode:   autoLinkingModeToPb(config.AutoLinkingMode),
 		},
 	}
 	if config.OAuthIDPTemplate != nil {
@@ -473,6 +499,24 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 	return providerConfig
 }
 
+func autoLinkingOptionToPb(option domain.AutoLinkingOption) idp_pb.AutoLinkingOption {
+	switch option {
+	case domain.AutoLinkingOptionEmail:
+		return idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_EMAIL
+	default:
+		return idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_UNSPECIFIED
+	}
+}
+
+func autoLinkingModeToPb(mode domain.AutoLinkingMode) idp_pb.AutoLinkingMode {
+	switch mode {
+	case domain.AutoLinkingModeAutomatic:
+		return idp_pb.AutoLinkingMode_AUTO_LINKING_MODE_AUTOMATIC
+	default:
+		return idp_pb.AutoLinkingMode_AUTO_LINKING_MODE_PROMPT
+	}
+}
+
 func oauthConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.OAuthIDPTemplate) {
 	providerConfig.Config = &idp_pb.ProviderConfig_Oauth{
 		Oauth: &idp_pb.OAuthConfig{
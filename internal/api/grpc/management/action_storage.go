@@ -0,0 +1,62 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListActionSecrets(ctx context.Context, req *mgmt_pb.ListActionSecretsRequest) (*mgmt_pb.ListActionSecretsResponse, error) {
+	res, err := s.query.SearchActionSecrets(ctx, true, authz.GetCtxData(ctx).OrgID, listActionSecretsToQuery(req), false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionSecretsResponse{
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+		Result:  action_grpc.ActionSecretsToPb(res.Secrets),
+	}, nil
+}
+
+func (s *Server) SetActionSecret(ctx context.Context, req *mgmt_pb.SetActionSecretRequest) (*mgmt_pb.SetActionSecretResponse, error) {
+	details, err := s.command.SetActionSecret(ctx, authz.GetCtxData(ctx).OrgID, req.Name, req.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetActionSecretResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveActionSecret(ctx context.Context, req *mgmt_pb.RemoveActionSecretRequest) (*mgmt_pb.RemoveActionSecretResponse, error) {
+	details, err := s.command.RemoveActionSecret(ctx, authz.GetCtxData(ctx).OrgID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionSecretResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListActionKeyValues(ctx context.Context, req *mgmt_pb.ListActionKeyValuesRequest) (*mgmt_pb.ListActionKeyValuesResponse, error) {
+	res, err := s.query.SearchActionKeyValues(ctx, true, authz.GetCtxData(ctx).OrgID, listActionKeyValuesToQuery(req), false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionKeyValuesResponse{
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+		Result:  action_grpc.ActionKeyValuesToPb(res.Entries),
+	}, nil
+}
+
+func (s *Server) RemoveActionKeyValue(ctx context.Context, req *mgmt_pb.RemoveActionKeyValueRequest) (*mgmt_pb.RemoveActionKeyValueResponse, error) {
+	details, err := s.command.RemoveActionKeyValue(ctx, authz.GetCtxData(ctx).OrgID, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionKeyValueResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func listActionSecretsToQuery(req *mgmt_pb.ListActionSecretsRequest) *query.ActionSecretSearchQueries {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.ActionSecretSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}
+}
+
+func listActionKeyValuesToQuery(req *mgmt_pb.ListActionKeyValuesRequest) *query.ActionKeyValueSearchQueries {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.ActionKeyValueSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}
+}
@@ -3,6 +3,7 @@ package management
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -96,3 +97,47 @@ func (s *Server) DeleteAction(ctx context.Context, req *mgmt_pb.DeleteActionRequ
 	_, err = s.command.DeleteAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID, flowTypes...)
 	return &mgmt_pb.DeleteActionResponse{}, err
 }
+
+func (s *Server) ListActionRevisions(ctx context.Context, req *mgmt_pb.ListActionRevisionsRequest) (*mgmt_pb.ListActionRevisionsResponse, error) {
+	revisions, err := s.query.ActionRevisions(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionRevisionsResponse{
+		Result: action_grpc.ActionRevisionsToPb(revisions),
+	}, nil
+}
+
+func (s *Server) RollbackAction(ctx context.Context, req *mgmt_pb.RollbackActionRequest) (*mgmt_pb.RollbackActionResponse, error) {
+	details, err := s.command.RollbackAction(ctx, req.Id, req.Revision, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RollbackActionResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) TestAction(ctx context.Context, req *mgmt_pb.TestActionRequest) (*mgmt_pb.TestActionResponse, error) {
+	runtime := action_grpc.ActionRuntimeToDomain(req.Runtime)
+	script := action_grpc.ActionScriptToDomain(runtime, req.Script, req.Module)
+	result, err := actions.DryRun(ctx, authz.GetCtxData(ctx).OrgID, req.Context.AsMap(), runtime, script, req.Name, req.Timeout.AsDuration())
+	if err != nil {
+		return nil, err
+	}
+	return dryRunResultToPb(result)
+}
+
+func (s *Server) ListActionExecutions(ctx context.Context, req *mgmt_pb.ListActionExecutionsRequest) (*mgmt_pb.ListActionExecutionsResponse, error) {
+	// ensures the action belongs to the organization
+	if _, err := s.query.GetActionByID(ctx, req.Id, authz.GetCtxData(ctx).OrgID, false); err != nil {
+		return nil, err
+	}
+	executions, err := s.query.SearchActionExecutions(ctx, req.Id, uint64(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionExecutionsResponse{
+		Result: action_grpc.ActionExecutionsToPb(executions.Executions),
+	}, nil
+}
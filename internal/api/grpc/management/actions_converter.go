@@ -1,6 +1,9 @@
 package management
 
 import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/actions"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -11,23 +14,27 @@ import (
 )
 
 func CreateActionRequestToDomain(req *mgmt_pb.CreateActionRequest) *domain.Action {
+	runtime := action_grpc.ActionRuntimeToDomain(req.Runtime)
 	return &domain.Action{
 		Name:          req.Name,
-		Script:        req.Script,
+		Script:        action_grpc.ActionScriptToDomain(runtime, req.Script, req.Module),
 		Timeout:       req.Timeout.AsDuration(),
 		AllowedToFail: req.AllowedToFail,
+		Runtime:       runtime,
 	}
 }
 
 func updateActionRequestToDomain(req *mgmt_pb.UpdateActionRequest) *domain.Action {
+	runtime := action_grpc.ActionRuntimeToDomain(req.Runtime)
 	return &domain.Action{
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.Id,
 		},
 		Name:          req.Name,
-		Script:        req.Script,
+		Script:        action_grpc.ActionScriptToDomain(runtime, req.Script, req.Module),
 		Timeout:       req.Timeout.AsDuration(),
 		AllowedToFail: req.AllowedToFail,
+		Runtime:       runtime,
 	}
 }
 
@@ -65,3 +72,19 @@ func ActionQueryToQuery(query interface{}) (query.SearchQuery, error) {
 	}
 	return nil, errors.ThrowInvalidArgument(nil, "MGMT-dsg3z", "Errors.Query.InvalidRequest")
 }
+
+func dryRunResultToPb(result *actions.DryRunResult) (*mgmt_pb.TestActionResponse, error) {
+	mutations, err := action_grpc.ActionMutationsToPb(result.Mutations)
+	if err != nil {
+		return nil, err
+	}
+	res := &mgmt_pb.TestActionResponse{
+		Mutations: mutations,
+		Logs:      action_grpc.ActionDryRunLogsToPb(result.Logs),
+		Took:      durationpb.New(result.Took),
+	}
+	if result.Err != nil {
+		res.Error = result.Err.Error()
+	}
+	return res, nil
+}
@@ -18,6 +18,9 @@ func (s *Server) ListFlowTypes(ctx context.Context, _ *mgmt_pb.ListFlowTypesRequ
 			action_grpc.FlowTypeToPb(domain.FlowTypeExternalAuthentication),
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseToken),
 			action_grpc.FlowTypeToPb(domain.FlowTypeInternalAuthentication),
+			action_grpc.FlowTypeToPb(domain.FlowTypeCustomizeSAMLResponse),
+			action_grpc.FlowTypeToPb(domain.FlowTypeUserManagement),
+			action_grpc.FlowTypeToPb(domain.FlowTypeNotification),
 		},
 	}, nil
 }
@@ -0,0 +1,68 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetMailMessageTemplate(ctx context.Context, req *mgmt_pb.GetMailMessageTemplateRequest) (*mgmt_pb.GetMailMessageTemplateResponse, error) {
+	mailTemplate, err := s.query.MailTemplateByOrg(ctx, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	template := mailTemplate.MessageTemplate(req.MessageType)
+	if template == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "MANAG-Ue7cz", "Errors.Org.MailTemplate.MessageNotFound")
+	}
+	return &mgmt_pb.GetMailMessageTemplateResponse{
+		Template: policy_grpc.ModelMailMessageTemplateToPb(template),
+	}, nil
+}
+
+func (s *Server) SetCustomMailMessageTemplate(ctx context.Context, req *mgmt_pb.SetCustomMailMessageTemplateRequest) (*mgmt_pb.SetCustomMailMessageTemplateResponse, error) {
+	details, err := s.command.SetOrgMailTemplateMessage(ctx, authz.GetCtxData(ctx).OrgID, setCustomMailMessageTemplateToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMailMessageTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ResetMailMessageTemplateToDefault(ctx context.Context, req *mgmt_pb.ResetMailMessageTemplateToDefaultRequest) (*mgmt_pb.ResetMailMessageTemplateToDefaultResponse, error) {
+	details, err := s.command.RemoveOrgMailTemplateMessage(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetMailMessageTemplateToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewMailTemplate(ctx context.Context, req *mgmt_pb.PreviewMailTemplateRequest) (*mgmt_pb.PreviewMailTemplateResponse, error) {
+	email, err := notification.PreviewEmail(ctx, s.query, s.assetAPIPrefix(ctx), authz.GetCtxData(ctx).OrgID, req.MessageType, req.Language, req.PreviewBranding)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.PreviewMailTemplateResponse{
+		Subject: email.Subject,
+		Html:    email.HTML,
+		Text:    email.Text,
+	}, nil
+}
+
+func setCustomMailMessageTemplateToDomain(req *mgmt_pb.SetCustomMailMessageTemplateRequest) *domain.MailMessageTemplate {
+	return &domain.MailMessageTemplate{
+		MessageType: req.MessageType,
+		Subject:     req.Subject,
+		HTML:        req.Html,
+		Text:        req.Text,
+	}
+}
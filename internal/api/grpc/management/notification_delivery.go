@@ -0,0 +1,41 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	notification_grpc "github.com/zitadel/zitadel/internal/api/grpc/notification"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListNotificationDeliveries(ctx context.Context, req *mgmt_pb.ListNotificationDeliveriesRequest) (*mgmt_pb.ListNotificationDeliveriesResponse, error) {
+	queries, err := notification_grpc.ListDeliveriesRequestToQuery(req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewNotificationDeliveryResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	queries.Queries = append(queries.Queries, ownerQuery)
+	res, err := s.query.SearchNotificationDeliveries(ctx, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListNotificationDeliveriesResponse{
+		Result:  notification_grpc.DeliveriesToPb(res.Deliveries),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) ResendNotificationDelivery(ctx context.Context, req *mgmt_pb.ResendNotificationDeliveryRequest) (*mgmt_pb.ResendNotificationDeliveryResponse, error) {
+	details, err := s.command.ResendNotificationDelivery(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResendNotificationDeliveryResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
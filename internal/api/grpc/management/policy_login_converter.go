@@ -30,6 +30,9 @@ func AddLoginPolicyToCommand(p *mgmt_pb.AddCustomLoginPolicyRequest) *command.Ad
 		IDPProviders:               addLoginPolicyIDPsToCommand(p.Idps),
 		DisableLoginWithEmail:      p.DisableLoginWithEmail,
 		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		AllowEmailMagicLink:        p.AllowEmailMagicLink,
+		RiskStepUpThreshold:        p.RiskStepUpThreshold,
+		RiskBlockThreshold:         p.RiskBlockThreshold,
 	}
 }
 func addLoginPolicyIDPsToCommand(idps []*mgmt_pb.AddCustomLoginPolicyRequest_IDP) []*command.AddLoginPolicyIDP {
@@ -55,6 +58,9 @@ func updateLoginPolicyToCommand(p *mgmt_pb.UpdateCustomLoginPolicyRequest) *comm
 		AllowDomainDiscovery:       p.AllowDomainDiscovery,
 		DisableLoginWithEmail:      p.DisableLoginWithEmail,
 		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		AllowEmailMagicLink:        p.AllowEmailMagicLink,
+		RiskStepUpThreshold:        p.RiskStepUpThreshold,
+		RiskBlockThreshold:         p.RiskBlockThreshold,
 		DefaultRedirectURI:         p.DefaultRedirectUri,
 		PasswordCheckLifetime:      p.PasswordCheckLifetime.AsDuration(),
 		ExternalLoginCheckLifetime: p.ExternalLoginCheckLifetime.AsDuration(),
@@ -0,0 +1,30 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/command"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func addNotificationPolicyToCommand(req *mgmt_pb.AddCustomNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceLogin:           req.GetNewDeviceLogin(),
+		MFAChange:                req.GetMfaChange(),
+		EmailChange:              req.GetEmailChange(),
+		PhoneChange:              req.GetPhoneChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
+
+func updateNotificationPolicyToCommand(req *mgmt_pb.UpdateCustomNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceLogin:           req.GetNewDeviceLogin(),
+		MFAChange:                req.GetMfaChange(),
+		EmailChange:              req.GetEmailChange(),
+		PhoneChange:              req.GetPhoneChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
@@ -0,0 +1,68 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetUserLifecyclePolicy(ctx context.Context, _ *mgmt_pb.GetUserLifecyclePolicyRequest) (*mgmt_pb.GetUserLifecyclePolicyResponse, error) {
+	policy, err := s.query.UserLifecyclePolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetUserLifecyclePolicyResponse{Policy: policy_grpc.ModelUserLifecyclePolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultUserLifecyclePolicy(ctx context.Context, _ *mgmt_pb.GetDefaultUserLifecyclePolicyRequest) (*mgmt_pb.GetDefaultUserLifecyclePolicyResponse, error) {
+	policy, err := s.query.DefaultUserLifecyclePolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultUserLifecyclePolicyResponse{Policy: policy_grpc.ModelUserLifecyclePolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCustomUserLifecyclePolicy(ctx context.Context, req *mgmt_pb.AddCustomUserLifecyclePolicyRequest) (*mgmt_pb.AddCustomUserLifecyclePolicyResponse, error) {
+	result, err := s.command.AddUserLifecyclePolicy(ctx, authz.GetCtxData(ctx).OrgID, addUserLifecyclePolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddCustomUserLifecyclePolicyResponse{
+		Details: object.DomainToAddDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) UpdateCustomUserLifecyclePolicy(ctx context.Context, req *mgmt_pb.UpdateCustomUserLifecyclePolicyRequest) (*mgmt_pb.UpdateCustomUserLifecyclePolicyResponse, error) {
+	result, err := s.command.ChangeUserLifecyclePolicy(ctx, authz.GetCtxData(ctx).OrgID, updateUserLifecyclePolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomUserLifecyclePolicyResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) ResetUserLifecyclePolicyToDefault(ctx context.Context, _ *mgmt_pb.ResetUserLifecyclePolicyToDefaultRequest) (*mgmt_pb.ResetUserLifecyclePolicyToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveUserLifecyclePolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetUserLifecyclePolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
+func (s *Server) ListUserLifecycleCandidates(ctx context.Context, _ *mgmt_pb.ListUserLifecycleCandidatesRequest) (*mgmt_pb.ListUserLifecycleCandidatesResponse, error) {
+	report, err := s.query.UserLifecycleReport(ctx, authz.GetCtxData(ctx).OrgID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserLifecycleCandidatesResponse{
+		Result:  policy_grpc.UserLifecycleCandidatesToPb(report.Candidates),
+		Details: object.ToListDetails(uint64(len(report.Candidates)), 0, time.Time{}),
+	}, nil
+}
@@ -0,0 +1,28 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func addUserLifecyclePolicyToDomain(req *mgmt_pb.AddCustomUserLifecyclePolicyRequest) *domain.UserLifecyclePolicy {
+	return &domain.UserLifecyclePolicy{
+		DeactivateAfterDays: uint64(req.GetDeactivateAfterDays()),
+		DeleteAfterDays:     uint64(req.GetDeleteAfterDays()),
+		WarnDaysBefore:      uint64(req.GetWarnDaysBefore()),
+		ExcludeMachineUsers: req.GetExcludeMachineUsers(),
+		ExcludedUserIDs:     req.GetExcludedUserIds(),
+		DryRun:              req.GetDryRun(),
+	}
+}
+
+func updateUserLifecyclePolicyToDomain(req *mgmt_pb.UpdateCustomUserLifecyclePolicyRequest) *domain.UserLifecyclePolicy {
+	return &domain.UserLifecyclePolicy{
+		DeactivateAfterDays: uint64(req.GetDeactivateAfterDays()),
+		DeleteAfterDays:     uint64(req.GetDeleteAfterDays()),
+		WarnDaysBefore:      uint64(req.GetWarnDaysBefore()),
+		ExcludeMachineUsers: req.GetExcludeMachineUsers(),
+		ExcludedUserIDs:     req.GetExcludedUserIds(),
+		DryRun:              req.GetDryRun(),
+	}
+}
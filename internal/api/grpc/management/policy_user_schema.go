@@ -0,0 +1,56 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetUserSchemaPolicy(ctx context.Context, _ *mgmt_pb.GetUserSchemaPolicyRequest) (*mgmt_pb.GetUserSchemaPolicyResponse, error) {
+	policy, err := s.query.UserSchemaPolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetUserSchemaPolicyResponse{Policy: policy_grpc.ModelUserSchemaPolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultUserSchemaPolicy(ctx context.Context, _ *mgmt_pb.GetDefaultUserSchemaPolicyRequest) (*mgmt_pb.GetDefaultUserSchemaPolicyResponse, error) {
+	policy, err := s.query.DefaultUserSchemaPolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultUserSchemaPolicyResponse{Policy: policy_grpc.ModelUserSchemaPolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCustomUserSchemaPolicy(ctx context.Context, req *mgmt_pb.AddCustomUserSchemaPolicyRequest) (*mgmt_pb.AddCustomUserSchemaPolicyResponse, error) {
+	result, err := s.command.AddUserSchemaPolicy(ctx, authz.GetCtxData(ctx).OrgID, addUserSchemaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddCustomUserSchemaPolicyResponse{
+		Details: object.DomainToAddDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) UpdateCustomUserSchemaPolicy(ctx context.Context, req *mgmt_pb.UpdateCustomUserSchemaPolicyRequest) (*mgmt_pb.UpdateCustomUserSchemaPolicyResponse, error) {
+	result, err := s.command.ChangeUserSchemaPolicy(ctx, authz.GetCtxData(ctx).OrgID, updateUserSchemaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomUserSchemaPolicyResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) ResetUserSchemaPolicyToDefault(ctx context.Context, _ *mgmt_pb.ResetUserSchemaPolicyToDefaultRequest) (*mgmt_pb.ResetUserSchemaPolicyToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveUserSchemaPolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetUserSchemaPolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
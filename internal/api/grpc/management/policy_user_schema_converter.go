@@ -0,0 +1,19 @@
+package management
+
+import (
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func addUserSchemaPolicyToDomain(req *mgmt_pb.AddCustomUserSchemaPolicyRequest) *domain.UserSchemaPolicy {
+	return &domain.UserSchemaPolicy{
+		Attributes: policy_grpc.UserSchemaAttributesToDomain(req.GetAttributes()),
+	}
+}
+
+func updateUserSchemaPolicyToDomain(req *mgmt_pb.UpdateCustomUserSchemaPolicyRequest) *domain.UserSchemaPolicy {
+	return &domain.UserSchemaPolicy{
+		Attributes: policy_grpc.UserSchemaAttributesToDomain(req.GetAttributes()),
+	}
+}
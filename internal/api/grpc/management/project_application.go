@@ -298,6 +298,44 @@ func (s *Server) AddAppKey(ctx context.Context, req *mgmt_pb.AddAppKeyRequest) (
 	}, nil
 }
 
+func (s *Server) ListOIDCInitialAccessTokens(ctx context.Context, req *mgmt_pb.ListOIDCInitialAccessTokensRequest) (*mgmt_pb.ListOIDCInitialAccessTokensResponse, error) {
+	queries, err := ListOIDCInitialAccessTokensRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.query.SearchOIDCInitialAccessTokens(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOIDCInitialAccessTokensResponse{
+		Result:  project_grpc.OIDCInitialAccessTokensToPb(tokens.OIDCInitialAccessTokens),
+		Details: object_grpc.ToListDetails(tokens.Count, tokens.Sequence, tokens.Timestamp),
+	}, nil
+}
+
+func (s *Server) AddOIDCInitialAccessToken(ctx context.Context, req *mgmt_pb.AddOIDCInitialAccessTokenRequest) (*mgmt_pb.AddOIDCInitialAccessTokenResponse, error) {
+	token := AddOIDCInitialAccessTokenRequestToCommand(req, authz.GetCtxData(ctx).OrgID)
+	details, err := s.command.AddOIDCInitialAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddOIDCInitialAccessTokenResponse{
+		TokenId: token.TokenID,
+		Token:   token.Token,
+		Details: object_grpc.AddToDetailsPb(details.Sequence, details.EventDate, details.ResourceOwner),
+	}, nil
+}
+
+func (s *Server) RemoveOIDCInitialAccessToken(ctx context.Context, req *mgmt_pb.RemoveOIDCInitialAccessTokenRequest) (*mgmt_pb.RemoveOIDCInitialAccessTokenResponse, error) {
+	details, err := s.command.RemoveOIDCInitialAccessToken(ctx, req.ProjectId, req.TokenId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOIDCInitialAccessTokenResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) RemoveAppKey(ctx context.Context, req *mgmt_pb.RemoveAppKeyRequest) (*mgmt_pb.RemoveAppKeyResponse, error) {
 	details, err := s.command.RemoveApplicationKey(ctx, req.ProjectId, req.AppId, req.KeyId, authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	authn_grpc "github.com/zitadel/zitadel/internal/api/grpc/authn"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	app_grpc "github.com/zitadel/zitadel/internal/api/grpc/project"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
@@ -41,22 +42,24 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		AppName:                  req.Name,
-		OIDCVersion:              app_grpc.OIDCVersionToDomain(req.Version),
-		RedirectUris:             req.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(req.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
-		PostLogoutRedirectUris:   req.PostLogoutRedirectUris,
-		DevMode:                  req.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
-		AccessTokenRoleAssertion: req.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     req.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: req.IdTokenUserinfoAssertion,
-		ClockSkew:                req.ClockSkew.AsDuration(),
-		AdditionalOrigins:        req.AdditionalOrigins,
-		SkipNativeAppSuccessPage: req.SkipNativeAppSuccessPage,
+		AppName:                   req.Name,
+		OIDCVersion:               app_grpc.OIDCVersionToDomain(req.Version),
+		RedirectUris:              req.RedirectUris,
+		ResponseTypes:             app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
+		GrantTypes:                app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
+		ApplicationType:           app_grpc.OIDCApplicationTypeToDomain(req.AppType),
+		AuthMethodType:            app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
+		PostLogoutRedirectUris:    req.PostLogoutRedirectUris,
+		DevMode:                   req.DevMode,
+		AccessTokenType:           app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
+		AccessTokenRoleAssertion:  req.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:      req.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:  req.IdTokenUserinfoAssertion,
+		ClockSkew:                 req.ClockSkew.AsDuration(),
+		AdditionalOrigins:         req.AdditionalOrigins,
+		SkipNativeAppSuccessPage:  req.SkipNativeAppSuccessPage,
+		RefreshTokenRotation:      app_grpc.OIDCRefreshTokenRotationToDomain(req.RefreshTokenRotation),
+		RefreshTokenReuseInterval: req.RefreshTokenReuseInterval.AsDuration(),
 	}
 }
 
@@ -93,21 +96,23 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppID:                    app.AppId,
-		RedirectUris:             app.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(app.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
-		PostLogoutRedirectUris:   app.PostLogoutRedirectUris,
-		DevMode:                  app.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
-		AccessTokenRoleAssertion: app.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     app.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: app.IdTokenUserinfoAssertion,
-		ClockSkew:                app.ClockSkew.AsDuration(),
-		AdditionalOrigins:        app.AdditionalOrigins,
-		SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+		AppID:                     app.AppId,
+		RedirectUris:              app.RedirectUris,
+		ResponseTypes:             app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
+		GrantTypes:                app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
+		ApplicationType:           app_grpc.OIDCApplicationTypeToDomain(app.AppType),
+		AuthMethodType:            app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
+		PostLogoutRedirectUris:    app.PostLogoutRedirectUris,
+		DevMode:                   app.DevMode,
+		AccessTokenType:           app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
+		AccessTokenRoleAssertion:  app.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:      app.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:  app.IdTokenUserinfoAssertion,
+		ClockSkew:                 app.ClockSkew.AsDuration(),
+		AdditionalOrigins:         app.AdditionalOrigins,
+		SkipNativeAppSuccessPage:  app.SkipNativeAppSuccessPage,
+		RefreshTokenRotation:      app_grpc.OIDCRefreshTokenRotationToDomain(app.RefreshTokenRotation),
+		RefreshTokenReuseInterval: app.RefreshTokenReuseInterval.AsDuration(),
 	}
 }
 
@@ -148,6 +153,54 @@ func AddAPIClientKeyRequestToDomain(key *mgmt_pb.AddAppKeyRequest) *domain.Appli
 	}
 }
 
+func AddOIDCInitialAccessTokenRequestToCommand(req *mgmt_pb.AddOIDCInitialAccessTokenRequest, resourceOwner string) *command.OIDCInitialAccessToken {
+	expirationDate := time.Time{}
+	if req.ExpirationDate != nil {
+		expirationDate = req.ExpirationDate.AsTime()
+	}
+	policy := domain.OIDCClientRegistrationPolicy{
+		AllowedRedirectURIPrefixes: req.AllowedRedirectUriPrefixes,
+	}
+	if len(req.AllowedGrantTypes) > 0 {
+		policy.AllowedGrantTypes = app_grpc.OIDCGrantTypesToDomain(req.AllowedGrantTypes)
+	}
+	for _, authMethod := range req.AllowedAuthMethodTypes {
+		policy.AllowedAuthMethodTypes = append(policy.AllowedAuthMethodTypes, app_grpc.OIDCAuthMethodTypeToDomain(authMethod))
+	}
+	return &command.OIDCInitialAccessToken{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   req.ProjectId,
+			ResourceOwner: resourceOwner,
+		},
+		ExpirationDate: expirationDate,
+		Policy:         policy,
+	}
+}
+
+func ListOIDCInitialAccessTokensRequestToQuery(ctx context.Context, req *mgmt_pb.ListOIDCInitialAccessTokensRequest) (*query.OIDCInitialAccessTokenSearchQueries, error) {
+	resourceOwner, err := query.NewOIDCInitialAccessTokenResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	projectID, err := query.NewOIDCInitialAccessTokenProjectIDSearchQuery(req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.OIDCInitialAccessTokenSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.OIDCInitialAccessTokenColumnCreationDate,
+		},
+		Queries: []query.SearchQuery{
+			resourceOwner,
+			projectID,
+		},
+	}, nil
+}
+
 func ListAPIClientKeysRequestToQuery(ctx context.Context, req *mgmt_pb.ListAppKeysRequest) (*query.AuthNKeySearchQueries, error) {
 	resourcOwner, err := query.NewAuthNKeyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -0,0 +1,89 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetOrgSMTPConfig(ctx context.Context, _ *mgmt_pb.GetOrgSMTPConfigRequest) (*mgmt_pb.GetOrgSMTPConfigResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	config, err := s.query.SMTPConfigByID(ctx, orgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetOrgSMTPConfigResponse{
+		SmtpConfig: settings.SMTPConfigToPb(config),
+	}, nil
+}
+
+func (s *Server) AddOrgSMTPConfig(ctx context.Context, req *mgmt_pb.AddOrgSMTPConfigRequest) (*mgmt_pb.AddOrgSMTPConfigResponse, error) {
+	details, err := s.command.AddOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID, addOrgSMTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddOrgSMTPConfigResponse{
+		Details: object.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateOrgSMTPConfig(ctx context.Context, req *mgmt_pb.UpdateOrgSMTPConfigRequest) (*mgmt_pb.UpdateOrgSMTPConfigResponse, error) {
+	details, err := s.command.ChangeOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID, updateOrgSMTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateOrgSMTPConfigResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateOrgSMTPConfigPassword(ctx context.Context, req *mgmt_pb.UpdateOrgSMTPConfigPasswordRequest) (*mgmt_pb.UpdateOrgSMTPConfigPasswordResponse, error) {
+	details, err := s.command.ChangeOrgSMTPConfigPassword(ctx, authz.GetCtxData(ctx).OrgID, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateOrgSMTPConfigPasswordResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrgSMTPConfig(ctx context.Context, _ *mgmt_pb.RemoveOrgSMTPConfigRequest) (*mgmt_pb.RemoveOrgSMTPConfigResponse, error) {
+	details, err := s.command.RemoveOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgSMTPConfigResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func addOrgSMTPToConfig(req *mgmt_pb.AddOrgSMTPConfigRequest) *smtp.Config {
+	return &smtp.Config{
+		Tls:      req.Tls,
+		From:     req.SenderAddress,
+		FromName: req.SenderName,
+		SMTP: smtp.SMTP{
+			Host:     req.Host,
+			User:     req.User,
+			Password: req.Password,
+			XOAuth2:  settings.SMTPXOAuth2ToConfig(req.Xoauth2),
+		},
+	}
+}
+
+func updateOrgSMTPToConfig(req *mgmt_pb.UpdateOrgSMTPConfigRequest) *smtp.Config {
+	return &smtp.Config{
+		Tls:      req.Tls,
+		From:     req.SenderAddress,
+		FromName: req.SenderName,
+		SMTP: smtp.SMTP{
+			Host:    req.Host,
+			User:    req.User,
+			XOAuth2: settings.SMTPXOAuth2ToConfig(req.Xoauth2),
+		},
+	}
+}
@@ -48,6 +48,26 @@ func (s *Server) GetUserByID(ctx context.Context, req *mgmt_pb.GetUserByIDReques
 	}, nil
 }
 
+func (s *Server) ExportUserData(ctx context.Context, req *mgmt_pb.ExportUserDataRequest) (*mgmt_pb.ExportUserDataResponse, error) {
+	owner, err := query.NewUserResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	export, err := s.query.UserDataExport(ctx, req.UserId, owner)
+	if err != nil {
+		return nil, err
+	}
+	data, fileName, contentType, err := user_grpc.UserDataExportToPb(export, req.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ExportUserDataResponse{
+		Data:        data,
+		FileName:    fileName,
+		ContentType: contentType,
+	}, nil
+}
+
 func (s *Server) GetUserByLoginNameGlobal(ctx context.Context, req *mgmt_pb.GetUserByLoginNameGlobalRequest) (*mgmt_pb.GetUserByLoginNameGlobalResponse, error) {
 	loginName, err := query.NewUserPreferredLoginNameSearchQuery(req.LoginName, query.TextEquals)
 	if err != nil {
@@ -877,6 +897,31 @@ func (s *Server) RemovePersonalAccessToken(ctx context.Context, req *mgmt_pb.Rem
 	}, nil
 }
 
+func (s *Server) ListHumanRefreshTokenFamilies(ctx context.Context, req *mgmt_pb.ListHumanRefreshTokenFamiliesRequest) (*mgmt_pb.ListHumanRefreshTokenFamiliesResponse, error) {
+	queries, err := ListHumanRefreshTokenFamiliesRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchRefreshTokenFamilies(ctx, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListHumanRefreshTokenFamiliesResponse{
+		Result:  user_grpc.RefreshTokenFamiliesToPb(result.RefreshTokenFamilies),
+		Details: obj_grpc.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+	}, nil
+}
+
+func (s *Server) RevokeHumanRefreshTokenFamily(ctx context.Context, req *mgmt_pb.RevokeHumanRefreshTokenFamilyRequest) (*mgmt_pb.RevokeHumanRefreshTokenFamilyResponse, error) {
+	objectDetails, err := s.command.RevokeRefreshToken(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, req.FamilyId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RevokeHumanRefreshTokenFamilyResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) ListHumanLinkedIDPs(ctx context.Context, req *mgmt_pb.ListHumanLinkedIDPsRequest) (*mgmt_pb.ListHumanLinkedIDPsResponse, error) {
 	queries, err := ListHumanLinkedIDPsRequestToQuery(ctx, req)
 	if err != nil {
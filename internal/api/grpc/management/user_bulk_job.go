@@ -0,0 +1,111 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ImportUsers(ctx context.Context, req *mgmt_pb.ImportUsersRequest) (*mgmt_pb.ImportUsersResponse, error) {
+	jobID, details, err := s.command.AddUserBulkImportJob(ctx, authz.GetCtxData(ctx).OrgID, user_grpc.UserBulkJobFormatToDomain(req.Format), req.File)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ImportUsersResponse{
+		JobId:   jobID,
+		Details: object.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ExportUsers(ctx context.Context, req *mgmt_pb.ExportUsersRequest) (*mgmt_pb.ExportUsersResponse, error) {
+	jobID, details, err := s.command.AddUserBulkExportJob(ctx, authz.GetCtxData(ctx).OrgID, user_grpc.UserBulkJobFormatToDomain(req.Format), user_grpc.UserBulkExportFilterToDomain(req.Filter))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ExportUsersResponse{
+		JobId:   jobID,
+		Details: object.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetUserBulkJob(ctx context.Context, req *mgmt_pb.GetUserBulkJobRequest) (*mgmt_pb.GetUserBulkJobResponse, error) {
+	job, err := s.query.UserBulkJobByID(ctx, true, req.JobId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetUserBulkJobResponse{
+		Job: user_grpc.UserBulkJobToPb(job),
+	}, nil
+}
+
+func (s *Server) ListUserBulkJobs(ctx context.Context, req *mgmt_pb.ListUserBulkJobsRequest) (*mgmt_pb.ListUserBulkJobsResponse, error) {
+	queries, err := ListUserBulkJobsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = queries.AppendMyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID); err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUserBulkJobs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserBulkJobsResponse{
+		Result:  user_grpc.UserBulkJobsToPb(res.Jobs),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) ListUserBulkJobRows(ctx context.Context, req *mgmt_pb.ListUserBulkJobRowsRequest) (*mgmt_pb.ListUserBulkJobRowsResponse, error) {
+	// ensures the job belongs to the organization
+	if _, err := s.query.UserBulkJobByID(ctx, true, req.JobId, authz.GetCtxData(ctx).OrgID); err != nil {
+		return nil, err
+	}
+	queries, err := ListUserBulkJobRowsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUserBulkJobRows(ctx, req.JobId, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserBulkJobRowsResponse{
+		Result:  user_grpc.UserBulkJobRowsToPb(res.Rows),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) GetUserBulkJobFile(ctx context.Context, req *mgmt_pb.GetUserBulkJobFileRequest) (*mgmt_pb.GetUserBulkJobFileResponse, error) {
+	data, format, err := s.command.UserBulkJobFile(ctx, req.JobId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetUserBulkJobFileResponse{
+		Data:        data,
+		FileName:    "users_" + req.JobId + format.FileExtension(),
+		ContentType: format.ContentType(),
+	}, nil
+}
+
+func (s *Server) CancelUserBulkJob(ctx context.Context, req *mgmt_pb.CancelUserBulkJobRequest) (*mgmt_pb.CancelUserBulkJobResponse, error) {
+	details, err := s.command.CancelUserBulkJob(ctx, req.JobId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.CancelUserBulkJobResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveUserBulkJob(ctx context.Context, req *mgmt_pb.RemoveUserBulkJobRequest) (*mgmt_pb.RemoveUserBulkJobResponse, error) {
+	details, err := s.command.RemoveUserBulkJob(ctx, req.JobId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveUserBulkJobResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func ListUserBulkJobsRequestToQuery(req *mgmt_pb.ListUserBulkJobsRequest) (*query.UserBulkJobSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := user_grpc.UserBulkJobQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserBulkJobSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.UserBulkJobColCreationDate,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func ListUserBulkJobRowsRequestToQuery(req *mgmt_pb.ListUserBulkJobRowsRequest) (*query.UserBulkJobRowSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, 0, 1)
+	if req.OnlyFailed {
+		failedQuery, err := query.NewUserBulkJobRowFailedSearchQuery()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, failedQuery)
+	}
+	return &query.UserBulkJobRowSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.UserBulkJobRowColRow,
+		},
+		Queries: queries,
+	}, nil
+}
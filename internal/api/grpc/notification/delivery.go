@@ -0,0 +1,134 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	notification_pb "github.com/zitadel/zitadel/pkg/grpc/notification"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object"
+)
+
+func DeliveriesToPb(deliveries []*query.NotificationDelivery) []*notification_pb.NotificationDelivery {
+	d := make([]*notification_pb.NotificationDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		d[i] = DeliveryToPb(delivery)
+	}
+	return d
+}
+
+func DeliveryToPb(delivery *query.NotificationDelivery) *notification_pb.NotificationDelivery {
+	return &notification_pb.NotificationDelivery{
+		Id: delivery.ID,
+		Details: object.ToViewDetailsPb(
+			delivery.Sequence,
+			delivery.CreationDate,
+			delivery.ChangeDate,
+			delivery.ResourceOwner,
+		),
+		UserId:            delivery.UserID,
+		Channel:           ChannelToPb(delivery.Channel),
+		Recipient:         delivery.Recipient,
+		MessageType:       delivery.MessageType,
+		Status:            DeliveryStatusToPb(delivery.Status),
+		ProviderMessageId: delivery.ProviderMessageID,
+		Error:             delivery.Error,
+	}
+}
+
+func ChannelToPb(channel domain.NotificationType) notification_pb.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeEmail:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	case domain.NotificationTypeSms:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	default:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED
+	}
+}
+
+func ChannelToDomain(channel notification_pb.NotificationChannel) domain.NotificationType {
+	switch channel {
+	case notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS:
+		return domain.NotificationTypeSms
+	default:
+		return domain.NotificationTypeEmail
+	}
+}
+
+func DeliveryStatusToPb(status domain.NotificationDeliveryStatus) notification_pb.DeliveryStatus {
+	switch status {
+	case domain.NotificationDeliveryStatusSent:
+		return notification_pb.DeliveryStatus_DELIVERY_STATUS_SENT
+	case domain.NotificationDeliveryStatusFailed:
+		return notification_pb.DeliveryStatus_DELIVERY_STATUS_FAILED
+	case domain.NotificationDeliveryStatusDelivered:
+		return notification_pb.DeliveryStatus_DELIVERY_STATUS_DELIVERED
+	case domain.NotificationDeliveryStatusBounced:
+		return notification_pb.DeliveryStatus_DELIVERY_STATUS_BOUNCED
+	case domain.NotificationDeliveryStatusResent:
+		return notification_pb.DeliveryStatus_DELIVERY_STATUS_RESENT
+	default:
+		return notification_pb.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
+
+func DeliveryStatusToDomain(status notification_pb.DeliveryStatus) domain.NotificationDeliveryStatus {
+	switch status {
+	case notification_pb.DeliveryStatus_DELIVERY_STATUS_SENT:
+		return domain.NotificationDeliveryStatusSent
+	case notification_pb.DeliveryStatus_DELIVERY_STATUS_FAILED:
+		return domain.NotificationDeliveryStatusFailed
+	case notification_pb.DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+		return domain.NotificationDeliveryStatusDelivered
+	case notification_pb.DeliveryStatus_DELIVERY_STATUS_BOUNCED:
+		return domain.NotificationDeliveryStatusBounced
+	case notification_pb.DeliveryStatus_DELIVERY_STATUS_RESENT:
+		return domain.NotificationDeliveryStatusResent
+	default:
+		return domain.NotificationDeliveryStatusUnspecified
+	}
+}
+
+func ListDeliveriesRequestToQuery(listQuery *object_pb.ListQuery, queries []*notification_pb.NotificationDeliveryQuery) (*query.NotificationDeliverySearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(listQuery)
+	q, err := DeliveryQueriesToQuery(queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.NotificationDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.NotificationDeliveryColumnCreationDate,
+		},
+		Queries: q,
+	}, nil
+}
+
+func DeliveryQueriesToQuery(queries []*notification_pb.NotificationDeliveryQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = DeliveryQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func DeliveryQueryToQuery(q *notification_pb.NotificationDeliveryQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *notification_pb.NotificationDeliveryQuery_UserIdQuery:
+		return query.NewNotificationDeliveryUserIDSearchQuery(q.UserIdQuery.UserId)
+	case *notification_pb.NotificationDeliveryQuery_StatusQuery:
+		return query.NewNotificationDeliveryStatusSearchQuery(DeliveryStatusToDomain(q.StatusQuery.Status))
+	case *notification_pb.NotificationDeliveryQuery_ChannelQuery:
+		return query.NewNotificationDeliveryChannelSearchQuery(ChannelToDomain(q.ChannelQuery.Channel))
+	case *notification_pb.NotificationDeliveryQuery_MessageTypeQuery:
+		return query.NewNotificationDeliveryMessageTypeSearchQuery(q.MessageTypeQuery.MessageType)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "NOTIF-Wm4pe", "List.Query.Invalid")
+	}
+}
@@ -24,6 +24,9 @@ func ModelLoginPolicyToPb(policy *query.LoginPolicy) *policy_pb.LoginPolicy {
 		AllowDomainDiscovery:       policy.AllowDomainDiscovery,
 		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
 		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		AllowEmailMagicLink:        policy.AllowEmailMagicLink,
+		RiskStepUpThreshold:        policy.RiskStepUpThreshold,
+		RiskBlockThreshold:         policy.RiskBlockThreshold,
 		DefaultRedirectUri:         policy.DefaultRedirectURI,
 		PasswordCheckLifetime:      durationpb.New(policy.PasswordCheckLifetime),
 		ExternalLoginCheckLifetime: durationpb.New(policy.ExternalLoginCheckLifetime),
@@ -0,0 +1,23 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelMailMessageTemplateToPb(template *query.MailMessageTemplate) *policy_pb.MailMessageTemplate {
+	return &policy_pb.MailMessageTemplate{
+		IsDefault:   template.IsDefault,
+		MessageType: template.MessageType,
+		Subject:     template.Subject,
+		Html:        template.HTML,
+		Text:        template.Text,
+		Details: object.ToViewDetailsPb(
+			template.Sequence,
+			template.CreationDate,
+			template.ChangeDate,
+			template.AggregateID,
+		),
+	}
+}
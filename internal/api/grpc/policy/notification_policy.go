@@ -8,8 +8,14 @@ import (
 
 func ModelNotificationPolicyToPb(policy *query.NotificationPolicy) *policy_pb.NotificationPolicy {
 	return &policy_pb.NotificationPolicy{
-		IsDefault:      policy.IsDefault,
-		PasswordChange: policy.PasswordChange,
+		IsDefault:                policy.IsDefault,
+		PasswordChange:           policy.PasswordChange,
+		NewDeviceLogin:           policy.NewDeviceLogin,
+		MfaChange:                policy.MFAChange,
+		EmailChange:              policy.EmailChange,
+		PhoneChange:              policy.PhoneChange,
+		AccountLocked:            policy.AccountLocked,
+		PersonalAccessTokenAdded: policy.PersonalAccessTokenAdded,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
@@ -0,0 +1,55 @@
+package policy
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelUserLifecyclePolicyToPb(policy *query.UserLifecyclePolicy) *policy_pb.UserLifecyclePolicy {
+	return &policy_pb.UserLifecyclePolicy{
+		IsDefault:           policy.IsDefault,
+		DeactivateAfterDays: uint32(policy.DeactivateAfterDays),
+		DeleteAfterDays:     uint32(policy.DeleteAfterDays),
+		WarnDaysBefore:      uint32(policy.WarnDaysBefore),
+		ExcludeMachineUsers: policy.ExcludeMachineUsers,
+		ExcludedUserIds:     policy.ExcludedUserIDs,
+		DryRun:              policy.DryRun,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
+
+func UserLifecycleCandidatesToPb(candidates []*query.UserLifecycleCandidate) []*policy_pb.UserLifecycleCandidate {
+	c := make([]*policy_pb.UserLifecycleCandidate, len(candidates))
+	for i, candidate := range candidates {
+		c[i] = &policy_pb.UserLifecycleCandidate{
+			UserId:  candidate.UserID,
+			Action:  userLifecycleActionToPb(candidate.Action),
+			DueDate: timestamppb.New(candidate.DueDate),
+		}
+	}
+	return c
+}
+
+func userLifecycleActionToPb(action domain.UserLifecycleAction) policy_pb.UserLifecycleAction {
+	switch action {
+	case domain.UserLifecycleActionWarnDeactivation:
+		return policy_pb.UserLifecycleAction_USER_LIFECYCLE_ACTION_WARN_DEACTIVATION
+	case domain.UserLifecycleActionDeactivate:
+		return policy_pb.UserLifecycleAction_USER_LIFECYCLE_ACTION_DEACTIVATE
+	case domain.UserLifecycleActionWarnDeletion:
+		return policy_pb.UserLifecycleAction_USER_LIFECYCLE_ACTION_WARN_DELETION
+	case domain.UserLifecycleActionDelete:
+		return policy_pb.UserLifecycleAction_USER_LIFECYCLE_ACTION_DELETE
+	default:
+		return policy_pb.UserLifecycleAction_USER_LIFECYCLE_ACTION_UNSPECIFIED
+	}
+}
@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelUserSchemaPolicyToPb(policy *query.UserSchemaPolicy) *policy_pb.UserSchemaPolicy {
+	return &policy_pb.UserSchemaPolicy{
+		IsDefault:  policy.IsDefault,
+		Attributes: UserSchemaAttributesToPb(policy.Attributes),
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
+
+func UserSchemaAttributesToPb(attributes []*domain.UserSchemaAttribute) []*policy_pb.UserSchemaAttribute {
+	a := make([]*policy_pb.UserSchemaAttribute, len(attributes))
+	for i, attribute := range attributes {
+		a[i] = &policy_pb.UserSchemaAttribute{
+			Name:          attribute.Name,
+			DisplayName:   attribute.DisplayName,
+			Type:          userSchemaAttributeTypeToPb(attribute.Type),
+			Required:      attribute.Required,
+			Pattern:       attribute.Pattern,
+			Unique:        attribute.Unique,
+			Visibility:    userSchemaAttributeVisibilityToPb(attribute.Visibility),
+			InTokenClaims: attribute.InTokenClaims,
+		}
+	}
+	return a
+}
+
+func UserSchemaAttributesToDomain(attributes []*policy_pb.UserSchemaAttribute) []*domain.UserSchemaAttribute {
+	a := make([]*domain.UserSchemaAttribute, len(attributes))
+	for i, attribute := range attributes {
+		a[i] = &domain.UserSchemaAttribute{
+			Name:          attribute.GetName(),
+			DisplayName:   attribute.GetDisplayName(),
+			Type:          userSchemaAttributeTypeToDomain(attribute.GetType()),
+			Required:      attribute.GetRequired(),
+			Pattern:       attribute.GetPattern(),
+			Unique:        attribute.GetUnique(),
+			Visibility:    userSchemaAttributeVisibilityToDomain(attribute.GetVisibility()),
+			InTokenClaims: attribute.GetInTokenClaims(),
+		}
+	}
+	return a
+}
+
+func userSchemaAttributeTypeToPb(attributeType domain.UserSchemaAttributeType) policy_pb.UserSchemaAttributeType {
+	switch attributeType {
+	case domain.UserSchemaAttributeTypeNumber:
+		return policy_pb.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_NUMBER
+	case domain.UserSchemaAttributeTypeBoolean:
+		return policy_pb.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_BOOLEAN
+	case domain.UserSchemaAttributeTypeDate:
+		return policy_pb.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_DATE
+	default:
+		return policy_pb.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_STRING
+	}
+}
+
+func userSchemaAttributeTypeToDomain(attributeType policy_pb.UserSchemaAttributeType) domain.UserSchemaAttributeType {
+	switch attributeType {
+	case policy_pb.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_NUMBER:
+		return domain.UserSchemaAttributeTypeNumber
+	case policy_pb.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_BOOLEAN:
+		return domain.UserSchemaAttributeTypeBoolean
+	case policy_pb.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_DATE:
+		return domain.UserSchemaAttributeTypeDate
+	default:
+		return domain.UserSchemaAttributeTypeString
+	}
+}
+
+func userSchemaAttributeVisibilityToPb(visibility domain.UserSchemaAttributeVisibility) policy_pb.UserSchemaAttributeVisibility {
+	switch visibility {
+	case domain.UserSchemaAttributeVisibilitySelf:
+		return policy_pb.UserSchemaAttributeVisibility_USER_SCHEMA_ATTRIBUTE_VISIBILITY_SELF
+	default:
+		return policy_pb.UserSchemaAttributeVisibility_USER_SCHEMA_ATTRIBUTE_VISIBILITY_ADMIN
+	}
+}
+
+func userSchemaAttributeVisibilityToDomain(visibility policy_pb.UserSchemaAttributeVisibility) domain.UserSchemaAttributeVisibility {
+	switch visibility {
+	case policy_pb.UserSchemaAttributeVisibility_USER_SCHEMA_ATTRIBUTE_VISIBILITY_SELF:
+		return domain.UserSchemaAttributeVisibilitySelf
+	default:
+		return domain.UserSchemaAttributeVisibilityAdmin
+	}
+}
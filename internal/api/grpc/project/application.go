@@ -2,6 +2,7 @@ package project
 
 import (
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -29,6 +30,29 @@ func AppToPb(app *query.App) *app_pb.App {
 	}
 }
 
+func OIDCInitialAccessTokensToPb(tokens []*query.OIDCInitialAccessToken) []*app_pb.OIDCInitialAccessToken {
+	t := make([]*app_pb.OIDCInitialAccessToken, len(tokens))
+	for i, token := range tokens {
+		t[i] = OIDCInitialAccessTokenToPb(token)
+	}
+	return t
+}
+
+func OIDCInitialAccessTokenToPb(token *query.OIDCInitialAccessToken) *app_pb.OIDCInitialAccessToken {
+	authMethodTypes := make([]app_pb.OIDCAuthMethodType, len(token.AllowedAuthMethodTypes))
+	for i, authMethodType := range token.AllowedAuthMethodTypes {
+		authMethodTypes[i] = OIDCAuthMethodTypeToPb(authMethodType)
+	}
+	return &app_pb.OIDCInitialAccessToken{
+		Id:                         token.ID,
+		Details:                    object_grpc.ToViewDetailsPb(token.Sequence, token.CreationDate, token.ChangeDate, token.ResourceOwner),
+		ExpirationDate:             timestamppb.New(token.Expiration),
+		AllowedRedirectUriPrefixes: token.AllowedRedirectURIPrefixes,
+		AllowedGrantTypes:          OIDCGrantTypesFromModel(token.AllowedGrantTypes),
+		AllowedAuthMethodTypes:     authMethodTypes,
+	}
+}
+
 func AppConfigToPb(app *query.App) app_pb.AppConfig {
 	if app.OIDCConfig != nil {
 		return AppOIDCConfigToPb(app.OIDCConfig)
@@ -42,25 +66,27 @@ func AppConfigToPb(app *query.App) app_pb.AppConfig {
 func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 	return &app_pb.App_OidcConfig{
 		OidcConfig: &app_pb.OIDCConfig{
-			RedirectUris:             app.RedirectURIs,
-			ResponseTypes:            OIDCResponseTypesFromModel(app.ResponseTypes),
-			GrantTypes:               OIDCGrantTypesFromModel(app.GrantTypes),
-			AppType:                  OIDCApplicationTypeToPb(app.AppType),
-			ClientId:                 app.ClientID,
-			AuthMethodType:           OIDCAuthMethodTypeToPb(app.AuthMethodType),
-			PostLogoutRedirectUris:   app.PostLogoutRedirectURIs,
-			Version:                  OIDCVersionToPb(domain.OIDCVersion(app.Version)),
-			NoneCompliant:            len(app.ComplianceProblems) != 0,
-			ComplianceProblems:       ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
-			DevMode:                  app.IsDevMode,
-			AccessTokenType:          oidcTokenTypeToPb(app.AccessTokenType),
-			AccessTokenRoleAssertion: app.AssertAccessTokenRole,
-			IdTokenRoleAssertion:     app.AssertIDTokenRole,
-			IdTokenUserinfoAssertion: app.AssertIDTokenUserinfo,
-			ClockSkew:                durationpb.New(app.ClockSkew),
-			AdditionalOrigins:        app.AdditionalOrigins,
-			AllowedOrigins:           app.AllowedOrigins,
-			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+			RedirectUris:              app.RedirectURIs,
+			ResponseTypes:             OIDCResponseTypesFromModel(app.ResponseTypes),
+			GrantTypes:                OIDCGrantTypesFromModel(app.GrantTypes),
+			AppType:                   OIDCApplicationTypeToPb(app.AppType),
+			ClientId:                  app.ClientID,
+			AuthMethodType:            OIDCAuthMethodTypeToPb(app.AuthMethodType),
+			PostLogoutRedirectUris:    app.PostLogoutRedirectURIs,
+			Version:                   OIDCVersionToPb(domain.OIDCVersion(app.Version)),
+			NoneCompliant:             len(app.ComplianceProblems) != 0,
+			ComplianceProblems:        ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
+			DevMode:                   app.IsDevMode,
+			AccessTokenType:           oidcTokenTypeToPb(app.AccessTokenType),
+			AccessTokenRoleAssertion:  app.AssertAccessTokenRole,
+			IdTokenRoleAssertion:      app.AssertIDTokenRole,
+			IdTokenUserinfoAssertion:  app.AssertIDTokenUserinfo,
+			ClockSkew:                 durationpb.New(app.ClockSkew),
+			AdditionalOrigins:         app.AdditionalOrigins,
+			AllowedOrigins:            app.AllowedOrigins,
+			SkipNativeAppSuccessPage:  app.SkipNativeAppSuccessPage,
+			RefreshTokenRotation:      oidcRefreshTokenRotationToPb(app.RefreshTokenRotation),
+			RefreshTokenReuseInterval: durationpb.New(app.RefreshTokenReuseInterval),
 		},
 	}
 }
@@ -256,6 +282,28 @@ func OIDCTokenTypeToDomain(tokenType app_pb.OIDCTokenType) domain.OIDCTokenType
 	}
 }
 
+func oidcRefreshTokenRotationToPb(rotation domain.OIDCRefreshTokenRotation) app_pb.OIDCRefreshTokenRotation {
+	switch rotation {
+	case domain.OIDCRefreshTokenRotationEnabled:
+		return app_pb.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ENABLED
+	case domain.OIDCRefreshTokenRotationDisabled:
+		return app_pb.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_DISABLED
+	default:
+		return app_pb.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ENABLED
+	}
+}
+
+func OIDCRefreshTokenRotationToDomain(rotation app_pb.OIDCRefreshTokenRotation) domain.OIDCRefreshTokenRotation {
+	switch rotation {
+	case app_pb.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ENABLED:
+		return domain.OIDCRefreshTokenRotationEnabled
+	case app_pb.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_DISABLED:
+		return domain.OIDCRefreshTokenRotationDisabled
+	default:
+		return domain.OIDCRefreshTokenRotationEnabled
+	}
+}
+
 func ComplianceProblemsToLocalizedMessages(problems []string) []*message_pb.LocalizedMessage {
 	converted := make([]*message_pb.LocalizedMessage, len(problems))
 	for i, p := range problems {
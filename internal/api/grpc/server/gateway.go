@@ -16,6 +16,7 @@ import (
 
 	client_middleware "github.com/zitadel/zitadel/internal/api/grpc/client/middleware"
 	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -27,6 +28,7 @@ const (
 var (
 	customHeaders = []string{
 		"x-zitadel-",
+		http_util.DPoP,
 	}
 	jsonMarshaler = &runtime.JSONPb{
 		UnmarshalOptions: protojson.UnmarshalOptions{
@@ -38,22 +40,10 @@ var (
 		runtime.WithMarshalerOption(jsonMarshaler.ContentType(nil), jsonMarshaler),
 		runtime.WithMarshalerOption(mimeWildcard, jsonMarshaler),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, jsonMarshaler),
-		runtime.WithIncomingHeaderMatcher(headerMatcher),
-		runtime.WithOutgoingHeaderMatcher(runtime.DefaultHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
 		runtime.WithForwardResponseOption(responseForwarder),
 	}
 
-	headerMatcher = runtime.HeaderMatcherFunc(
-		func(header string) (string, bool) {
-			for _, customHeader := range customHeaders {
-				if strings.HasPrefix(strings.ToLower(header), customHeader) {
-					return header, true
-				}
-			}
-			return runtime.DefaultHeaderMatcher(header)
-		},
-	)
-
 	responseForwarder = func(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
 		t, ok := resp.(CustomHTTPResponse)
 		if ok {
@@ -64,6 +54,31 @@ var (
 	}
 )
 
+// headerMatcher forwards the custom headers and the additionally provided headers (e.g. from config) to the grpc server
+func headerMatcher(forwardedHeaders []string) runtime.HeaderMatcherFunc {
+	return func(header string) (string, bool) {
+		for _, customHeader := range customHeaders {
+			if strings.HasPrefix(strings.ToLower(header), customHeader) {
+				return header, true
+			}
+		}
+		for _, forwardedHeader := range forwardedHeaders {
+			if strings.EqualFold(header, forwardedHeader) {
+				return header, true
+			}
+		}
+		return runtime.DefaultHeaderMatcher(header)
+	}
+}
+
+// outgoingHeaderMatcher returns the DPoP nonce as defined in RFC 9449
+func outgoingHeaderMatcher(header string) (string, bool) {
+	if strings.EqualFold(header, http_util.DPoPNonce) {
+		return http_util.DPoPNonce, true
+	}
+	return runtime.DefaultHeaderMatcher(header)
+}
+
 type Gateway struct {
 	mux               *runtime.ServeMux
 	http1HostName     string
@@ -89,8 +104,9 @@ func CreateGatewayWithPrefix(
 	http1HostName string,
 	accessInterceptor *http_mw.AccessInterceptor,
 	queries *query.Queries,
+	forwardedHeaders []string,
 ) (http.Handler, string, error) {
-	runtimeMux := runtime.NewServeMux(serveMuxOptions...)
+	runtimeMux := runtime.NewServeMux(append(serveMuxOptions, runtime.WithIncomingHeaderMatcher(headerMatcher(forwardedHeaders)))...)
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithUnaryInterceptor(client_middleware.DefaultTracingClient()),
@@ -106,7 +122,7 @@ func CreateGatewayWithPrefix(
 	return addInterceptors(runtimeMux, http1HostName, accessInterceptor, queries), g.GatewayPathPrefix(), nil
 }
 
-func CreateGateway(ctx context.Context, port uint16, http1HostName string, accessInterceptor *http_mw.AccessInterceptor) (*Gateway, error) {
+func CreateGateway(ctx context.Context, port uint16, http1HostName string, accessInterceptor *http_mw.AccessInterceptor, forwardedHeaders []string) (*Gateway, error) {
 	connection, err := dial(ctx,
 		port,
 		[]grpc.DialOption{
@@ -116,7 +132,10 @@ func CreateGateway(ctx context.Context, port uint16, http1HostName string, acces
 	if err != nil {
 		return nil, err
 	}
-	runtimeMux := runtime.NewServeMux(append(serveMuxOptions, runtime.WithHealthzEndpoint(healthpb.NewHealthClient(connection)))...)
+	runtimeMux := runtime.NewServeMux(append(serveMuxOptions,
+		runtime.WithIncomingHeaderMatcher(headerMatcher(forwardedHeaders)),
+		runtime.WithHealthzEndpoint(healthpb.NewHealthClient(connection)),
+	)...)
 	return &Gateway{
 		mux:               runtimeMux,
 		http1HostName:     http1HostName,
@@ -164,6 +183,7 @@ func addInterceptors(
 ) http.Handler {
 	handler = http_mw.CallDurationHandler(handler)
 	handler = http1Host(handler, http1HostName)
+	handler = http1Request(handler)
 	handler = http_mw.CORSInterceptor(handler)
 	handler = http_mw.RobotsTagHandler(handler)
 	handler = http_mw.DefaultTelemetryHandler(handler)
@@ -186,6 +206,20 @@ func http1Host(next http.Handler, http1HostName string) http.Handler {
 	})
 }
 
+// http1Request passes the method and path of the original http request to the grpc server,
+// e.g. to verify DPoP proofs.
+// They are signed, so the grpc server can distinguish them from values sent by other clients.
+func http1Request(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the RequestURI still contains the prefix of the gateway
+		path := strings.SplitN(r.RequestURI, "?", 2)[0]
+		r.Header.Set(middleware.HTTP1Method, r.Method)
+		r.Header.Set(middleware.HTTP1Path, path)
+		r.Header.Set(middleware.HTTP1Signature, middleware.SignHTTP1Request(r.Method, path))
+		next.ServeHTTP(w, r)
+	})
+}
+
 func exhaustedCookieInterceptor(
 	next http.Handler,
 	accessInterceptor *http_mw.AccessInterceptor,
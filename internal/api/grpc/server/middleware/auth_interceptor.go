@@ -2,9 +2,16 @@ package middleware
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
 
+	"github.com/zitadel/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -14,13 +21,14 @@ import (
 	object "github.com/zitadel/zitadel/pkg/grpc/object/v2alpha"
 )
 
-func AuthorizationInterceptor(verifier *authz.TokenVerifier, authConfig authz.Config) grpc.UnaryServerInterceptor {
+func AuthorizationInterceptor(verifier *authz.TokenVerifier, authConfig authz.Config, externalSecure bool) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		return authorize(ctx, req, info, handler, verifier, authConfig)
+		return authorize(ctx, req, info, handler, verifier, authConfig, externalSecure)
 	}
 }
 
-func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, verifier *authz.TokenVerifier, authConfig authz.Config) (_ interface{}, err error) {
+func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, verifier *authz.TokenVerifier, authConfig authz.Config, externalSecure bool) (_ interface{}, err error) {
+	ctx = removeUnsignedHTTP1Request(ctx)
 	authOpt, needsToken := verifier.CheckAuthMethod(info.FullMethod)
 	if !needsToken {
 		return handler(ctx, req)
@@ -33,6 +41,18 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	if authToken == "" {
 		return nil, status.Error(codes.Unauthenticated, "auth header missing")
 	}
+	if strings.HasPrefix(authToken, authz.DPoPPrefix) {
+		authToken = authz.BearerPrefix + strings.TrimPrefix(authToken, authz.DPoPPrefix)
+	}
+	confirmation, err := requestConfirmation(authCtx, info.FullMethod, strings.TrimPrefix(authToken, authz.BearerPrefix), externalSecure, authConfig.TokenBinding)
+	if authz.IsDPoPNonceRequired(err) {
+		headerErr := grpc.SetHeader(ctx, metadata.Pairs(http.DPoPNonce, authConfig.TokenBinding.DPoPNonceValue()))
+		logging.OnError(headerErr).Debug("unable to set dpop nonce header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	authCtx = authz.SetTokenConfirmation(authCtx, confirmation)
 
 	var orgDomain string
 	orgID := grpc_util.GetHeader(authCtx, http.ZitadelOrgID)
@@ -46,7 +66,66 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 		return nil, err
 	}
 	span.End()
-	return handler(ctxSetter(ctx), req)
+	return handler(ctxSetter(authz.SetTokenConfirmation(ctx, confirmation)), req)
+}
+
+// gatewayKey signs the method and path of the original http request set by the grpc gateway,
+// which runs in the same process, so that they cannot be set by other clients
+var gatewayKey = func() []byte {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	logging.OnError(err).Fatal("unable to generate gateway key")
+	return key
+}()
+
+// SignHTTP1Request returns the signature of the method and path of the original http request,
+// which the grpc gateway passes in the [HTTP1Signature] header
+func SignHTTP1Request(method, path string) string {
+	mac := hmac.New(sha256.New, gatewayKey)
+	mac.Write([]byte(method + " " + path))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// removeUnsignedHTTP1Request removes the method and path of the original http request from the metadata,
+// if they were not set by the grpc gateway
+func removeUnsignedHTTP1Request(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	method, path, signature := md.Get(HTTP1Method), md.Get(HTTP1Path), md.Get(HTTP1Signature)
+	if len(method) == 0 && len(path) == 0 && len(signature) == 0 {
+		return ctx
+	}
+	if len(method) == 1 && len(path) == 1 && len(signature) == 1 &&
+		hmac.Equal([]byte(signature[0]), []byte(SignHTTP1Request(method[0], path[0]))) {
+		return ctx
+	}
+	md = md.Copy()
+	md.Delete(HTTP1Method)
+	md.Delete(HTTP1Path)
+	md.Delete(HTTP1Signature)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// requestConfirmation verifies the DPoP proof and the client certificate presented with the request.
+// Requests passed by the grpc gateway are verified against the method and path of the original http request.
+func requestConfirmation(ctx context.Context, fullMethod, accessToken string, externalSecure bool, config authz.TokenBindingConfig) (*authz.TokenConfirmation, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	// grpc and grpc-web requests are always sent as POST
+	method, path := "POST", fullMethod
+	if httpMethod := md.Get(HTTP1Method); len(httpMethod) == 1 {
+		method = httpMethod[0]
+	}
+	if httpPath := md.Get(HTTP1Path); len(httpPath) == 1 {
+		path = httpPath[0]
+	}
+	var clientCertificate string
+	if config.ClientCertificateHeader != "" {
+		clientCertificate = grpc_util.GetHeader(ctx, config.ClientCertificateHeader)
+	}
+	uri := http.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + path
+	return config.RequestConfirmation(md.Get(http.DPoP), clientCertificate, method, uri, accessToken)
 }
 
 type OrganisationFromRequest interface {
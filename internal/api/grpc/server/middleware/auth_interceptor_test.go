@@ -139,7 +139,7 @@ func Test_authorize(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := authorize(tt.args.ctx, tt.args.req, tt.args.info, tt.args.handler, tt.args.verifier, tt.args.authConfig)
+			got, err := authorize(tt.args.ctx, tt.args.req, tt.args.info, tt.args.handler, tt.args.verifier, tt.args.authConfig, false)
 			if (err != nil) != tt.res.wantErr {
 				t.Errorf("authorize() error = %v, wantErr %v", err, tt.res.wantErr)
 				return
@@ -150,3 +150,40 @@ func Test_authorize(t *testing.T) {
 		})
 	}
 }
+
+func Test_removeUnsignedHTTP1Request(t *testing.T) {
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want metadata.MD
+	}{
+		{
+			"no http1 request",
+			metadata.Pairs("authorization", "Bearer token"),
+			metadata.Pairs("authorization", "Bearer token"),
+		},
+		{
+			"signed by gateway",
+			metadata.Pairs(HTTP1Method, "GET", HTTP1Path, "/management/v1/users/me", HTTP1Signature, SignHTTP1Request("GET", "/management/v1/users/me")),
+			metadata.Pairs(HTTP1Method, "GET", HTTP1Path, "/management/v1/users/me", HTTP1Signature, SignHTTP1Request("GET", "/management/v1/users/me")),
+		},
+		{
+			"signature missing",
+			metadata.Pairs("authorization", "Bearer token", HTTP1Method, "GET", HTTP1Path, "/management/v1/users/me"),
+			metadata.Pairs("authorization", "Bearer token"),
+		},
+		{
+			"signature of other request",
+			metadata.Pairs(HTTP1Method, "GET", HTTP1Path, "/management/v1/users/me", HTTP1Signature, SignHTTP1Request("POST", "/management/v1/users/me")),
+			metadata.MD{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := metadata.FromIncomingContext(removeUnsignedHTTP1Request(metadata.NewIncomingContext(context.Background(), tt.md)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("removeUnsignedHTTP1Request() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -116,9 +116,11 @@ func factorsToPb(s *query.Session) *session.Factors {
 		return nil
 	}
 	return &session.Factors{
-		User:     user,
-		Password: passwordFactorToPb(s.PasswordFactor),
-		Passkey:  passkeyFactorToPb(s.PasskeyFactor),
+		User:         user,
+		Password:     passwordFactorToPb(s.PasswordFactor),
+		Passkey:      passkeyFactorToPb(s.PasskeyFactor),
+		MagicLink:    magicLinkFactorToPb(s.MagicLinkFactor),
+		RecoveryCode: recoveryCodeFactorToPb(s.RecoveryCodeFactor),
 	}
 }
 
@@ -140,6 +142,24 @@ func passkeyFactorToPb(factor query.SessionPasskeyFactor) *session.PasskeyFactor
 	}
 }
 
+func magicLinkFactorToPb(factor query.SessionMagicLinkFactor) *session.MagicLinkFactor {
+	if factor.MagicLinkCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.MagicLinkFactor{
+		VerifiedAt: timestamppb.New(factor.MagicLinkCheckedAt),
+	}
+}
+
+func recoveryCodeFactorToPb(factor query.SessionRecoveryCodeFactor) *session.RecoveryCodeFactor {
+	if factor.RecoveryCodeCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.RecoveryCodeFactor{
+		VerifiedAt: timestamppb.New(factor.RecoveryCodeCheckedAt),
+	}
+}
+
 func userFactorToPb(factor query.SessionUserFactor) *session.UserFactor {
 	if factor.UserID == "" || factor.UserCheckedAt.IsZero() {
 		return nil
@@ -218,7 +238,7 @@ func (s *Server) checksToCommand(ctx context.Context, checks *session.Checks) ([
 	if err != nil {
 		return nil, err
 	}
-	sessionChecks := make([]command.SessionCommand, 0, 3)
+	sessionChecks := make([]command.SessionCommand, 0, 5)
 	if checkUser != nil {
 		user, err := checkUser.search(ctx, s.query)
 		if err != nil {
@@ -232,6 +252,12 @@ func (s *Server) checksToCommand(ctx context.Context, checks *session.Checks) ([
 	if passkey := checks.GetPasskey(); passkey != nil {
 		sessionChecks = append(sessionChecks, s.command.CheckPasskey(passkey.GetCredentialAssertionData()))
 	}
+	if magicLink := checks.GetMagicLink(); magicLink != nil {
+		sessionChecks = append(sessionChecks, command.CheckMagicLink(magicLink.GetCodeId(), magicLink.GetCode()))
+	}
+	if recoveryCode := checks.GetRecoveryCode(); recoveryCode != nil {
+		sessionChecks = append(sessionChecks, command.CheckRecoveryCode(recoveryCode.GetCode()))
+	}
 
 	return sessionChecks, nil
 }
@@ -86,6 +86,42 @@ func Test_sessionsToPb(t *testing.T) {
 				PasskeyCheckedAt: past,
 			},
 			Metadata: map[string][]byte{"hello": []byte("world")},
+		}, { // magic link factor
+			ID:            "999",
+			CreationDate:  now,
+			ChangeDate:    now,
+			Sequence:      123,
+			State:         domain.SessionStateActive,
+			ResourceOwner: "me",
+			Creator:       "he",
+			UserFactor: query.SessionUserFactor{
+				UserID:        "345",
+				UserCheckedAt: past,
+				LoginName:     "donald",
+				DisplayName:   "donald duck",
+			},
+			MagicLinkFactor: query.SessionMagicLinkFactor{
+				MagicLinkCheckedAt: past,
+			},
+			Metadata: map[string][]byte{"hello": []byte("world")},
+		}, { // recovery code factor
+			ID:            "999",
+			CreationDate:  now,
+			ChangeDate:    now,
+			Sequence:      123,
+			State:         domain.SessionStateActive,
+			ResourceOwner: "me",
+			Creator:       "he",
+			UserFactor: query.SessionUserFactor{
+				UserID:        "345",
+				UserCheckedAt: past,
+				LoginName:     "donald",
+				DisplayName:   "donald duck",
+			},
+			RecoveryCodeFactor: query.SessionRecoveryCodeFactor{
+				RecoveryCodeCheckedAt: past,
+			},
+			Metadata: map[string][]byte{"hello": []byte("world")},
 		},
 	}
 
@@ -148,6 +184,40 @@ func Test_sessionsToPb(t *testing.T) {
 				},
 			},
 			Metadata: map[string][]byte{"hello": []byte("world")},
+		}, { // magic link factor
+			Id:           "999",
+			CreationDate: timestamppb.New(now),
+			ChangeDate:   timestamppb.New(now),
+			Sequence:     123,
+			Factors: &session.Factors{
+				User: &session.UserFactor{
+					VerifiedAt:  timestamppb.New(past),
+					Id:          "345",
+					LoginName:   "donald",
+					DisplayName: "donald duck",
+				},
+				MagicLink: &session.MagicLinkFactor{
+					VerifiedAt: timestamppb.New(past),
+				},
+			},
+			Metadata: map[string][]byte{"hello": []byte("world")},
+		}, { // recovery code factor
+			Id:           "999",
+			CreationDate: timestamppb.New(now),
+			ChangeDate:   timestamppb.New(now),
+			Sequence:     123,
+			Factors: &session.Factors{
+				User: &session.UserFactor{
+					VerifiedAt:  timestamppb.New(past),
+					Id:          "345",
+					LoginName:   "donald",
+					DisplayName: "donald duck",
+				},
+				RecoveryCode: &session.RecoveryCodeFactor{
+					VerifiedAt: timestamppb.New(past),
+				},
+			},
+			Metadata: map[string][]byte{"hello": []byte("world")},
 		},
 	}
 
@@ -2,6 +2,7 @@ package settings
 
 import (
 	obj_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/query"
 	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
 )
@@ -13,3 +14,43 @@ func NotificationProviderToPb(provider *query.DebugNotificationProvider) *settin
 	}
 	return mapped
 }
+
+func SMTPConfigsToPb(configs []*query.SMTPConfig) []*settings_pb.SMTPConfig {
+	c := make([]*settings_pb.SMTPConfig, len(configs))
+	for i, config := range configs {
+		c[i] = SMTPConfigToPb(config)
+	}
+	return c
+}
+
+func SMTPConfigToPb(config *query.SMTPConfig) *settings_pb.SMTPConfig {
+	mapped := &settings_pb.SMTPConfig{
+		Id:            config.ID,
+		Priority:      config.Priority,
+		Tls:           config.TLS,
+		SenderAddress: config.SenderAddress,
+		SenderName:    config.SenderName,
+		Host:          config.Host,
+		User:          config.User,
+		Details:       obj_pb.ToViewDetailsPb(config.Sequence, config.CreationDate, config.ChangeDate, config.ResourceOwner),
+	}
+	if config.XOAuth2 != nil {
+		mapped.Xoauth2 = &settings_pb.SMTPXOAuth2{
+			TokenEndpoint: config.XOAuth2.TokenEndpoint,
+			ClientId:      config.XOAuth2.ClientID,
+			Scopes:        config.XOAuth2.Scopes,
+		}
+	}
+	return mapped
+}
+
+func SMTPXOAuth2ToConfig(xoauth2 *settings_pb.SMTPXOAuth2) *smtp.XOAuth2 {
+	if xoauth2 == nil {
+		return nil
+	}
+	return &smtp.XOAuth2{
+		TokenEndpoint: xoauth2.TokenEndpoint,
+		ClientID:      xoauth2.ClientId,
+		Scopes:        xoauth2.Scopes,
+	}
+}
@@ -30,6 +30,9 @@ func loginSettingsToPb(current *query.LoginPolicy) *settings.LoginSettings {
 		AllowDomainDiscovery:       current.AllowDomainDiscovery,
 		DisableLoginWithEmail:      current.DisableLoginWithEmail,
 		DisableLoginWithPhone:      current.DisableLoginWithPhone,
+		AllowEmailMagicLink:        current.AllowEmailMagicLink,
+		RiskStepUpThreshold:        current.RiskStepUpThreshold,
+		RiskBlockThreshold:         current.RiskBlockThreshold,
 		DefaultRedirectUri:         current.DefaultRedirectURI,
 		PasswordCheckLifetime:      durationpb.New(current.PasswordCheckLifetime),
 		ExternalLoginCheckLifetime: durationpb.New(current.ExternalLoginCheckLifetime),
@@ -31,6 +31,9 @@ func Test_loginSettingsToPb(t *testing.T) {
 		AllowDomainDiscovery:       true,
 		DisableLoginWithEmail:      true,
 		DisableLoginWithPhone:      true,
+		AllowEmailMagicLink:        true,
+		RiskStepUpThreshold:        50,
+		RiskBlockThreshold:         80,
 		DefaultRedirectURI:         "example.com",
 		PasswordCheckLifetime:      time.Hour,
 		ExternalLoginCheckLifetime: time.Minute,
@@ -58,6 +61,9 @@ func Test_loginSettingsToPb(t *testing.T) {
 		AllowDomainDiscovery:       true,
 		DisableLoginWithEmail:      true,
 		DisableLoginWithPhone:      true,
+		AllowEmailMagicLink:        true,
+		RiskStepUpThreshold:        50,
+		RiskBlockThreshold:         80,
 		DefaultRedirectUri:         "example.com",
 		PasswordCheckLifetime:      durationpb.New(time.Hour),
 		ExternalLoginCheckLifetime: durationpb.New(time.Minute),
@@ -0,0 +1,169 @@
+package user
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	user_pb "github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+func UserBulkJobsToPb(jobs []*query.UserBulkJob) []*user_pb.UserBulkJob {
+	j := make([]*user_pb.UserBulkJob, len(jobs))
+	for i, job := range jobs {
+		j[i] = UserBulkJobToPb(job)
+	}
+	return j
+}
+
+func UserBulkJobToPb(job *query.UserBulkJob) *user_pb.UserBulkJob {
+	return &user_pb.UserBulkJob{
+		Id:        job.ID,
+		Details:   object.ToViewDetailsPb(job.Sequence, job.CreationDate, job.ChangeDate, job.ResourceOwner),
+		Type:      UserBulkJobTypeToPb(job.Type),
+		Format:    UserBulkJobFormatToPb(job.Format),
+		State:     UserBulkJobStateToPb(job.State),
+		Filter:    UserBulkExportFilterToPb(job.Filter),
+		Total:     job.Total,
+		Processed: job.Processed,
+		Failed:    job.Failed,
+		Error:     job.Error,
+	}
+}
+
+func UserBulkJobRowsToPb(rows []*query.UserBulkJobRow) []*user_pb.UserBulkJobRow {
+	r := make([]*user_pb.UserBulkJobRow, len(rows))
+	for i, row := range rows {
+		r[i] = &user_pb.UserBulkJobRow{
+			Row:     row.Row,
+			UserId:  row.UserID,
+			ErrorId: row.ErrorID,
+			Error:   row.Error,
+		}
+	}
+	return r
+}
+
+func UserBulkExportFilterToPb(filter *domain.UserBulkExportFilter) *user_pb.UserBulkExportFilter {
+	if filter == nil {
+		return nil
+	}
+	return &user_pb.UserBulkExportFilter{
+		Type:     TypeToPb(filter.Type),
+		State:    UserStateToPb(filter.State),
+		UserName: filter.UserName,
+		Email:    filter.Email,
+	}
+}
+
+func UserBulkExportFilterToDomain(filter *user_pb.UserBulkExportFilter) *domain.UserBulkExportFilter {
+	if filter == nil {
+		return nil
+	}
+	return &domain.UserBulkExportFilter{
+		Type:     domain.UserType(filter.Type),
+		State:    domain.UserState(filter.State),
+		UserName: filter.UserName,
+		Email:    filter.Email,
+	}
+}
+
+func UserBulkJobTypeToPb(jobType domain.UserBulkJobType) user_pb.UserBulkJobType {
+	switch jobType {
+	case domain.UserBulkJobTypeImport:
+		return user_pb.UserBulkJobType_USER_BULK_JOB_TYPE_IMPORT
+	case domain.UserBulkJobTypeExport:
+		return user_pb.UserBulkJobType_USER_BULK_JOB_TYPE_EXPORT
+	default:
+		return user_pb.UserBulkJobType_USER_BULK_JOB_TYPE_UNSPECIFIED
+	}
+}
+
+func UserBulkJobTypeToDomain(jobType user_pb.UserBulkJobType) domain.UserBulkJobType {
+	switch jobType {
+	case user_pb.UserBulkJobType_USER_BULK_JOB_TYPE_IMPORT:
+		return domain.UserBulkJobTypeImport
+	case user_pb.UserBulkJobType_USER_BULK_JOB_TYPE_EXPORT:
+		return domain.UserBulkJobTypeExport
+	default:
+		return domain.UserBulkJobTypeUnspecified
+	}
+}
+
+func UserBulkJobFormatToPb(format domain.UserBulkJobFormat) user_pb.UserBulkJobFormat {
+	switch format {
+	case domain.UserBulkJobFormatCSV:
+		return user_pb.UserBulkJobFormat_USER_BULK_JOB_FORMAT_CSV
+	case domain.UserBulkJobFormatJSON:
+		return user_pb.UserBulkJobFormat_USER_BULK_JOB_FORMAT_JSON
+	default:
+		return user_pb.UserBulkJobFormat_USER_BULK_JOB_FORMAT_UNSPECIFIED
+	}
+}
+
+func UserBulkJobFormatToDomain(format user_pb.UserBulkJobFormat) domain.UserBulkJobFormat {
+	switch format {
+	case user_pb.UserBulkJobFormat_USER_BULK_JOB_FORMAT_CSV:
+		return domain.UserBulkJobFormatCSV
+	case user_pb.UserBulkJobFormat_USER_BULK_JOB_FORMAT_JSON:
+		return domain.UserBulkJobFormatJSON
+	default:
+		return domain.UserBulkJobFormatUnspecified
+	}
+}
+
+func UserBulkJobStateToPb(state domain.UserBulkJobState) user_pb.UserBulkJobState {
+	switch state {
+	case domain.UserBulkJobStateQueued:
+		return user_pb.UserBulkJobState_USER_BULK_JOB_STATE_QUEUED
+	case domain.UserBulkJobStateRunning:
+		return user_pb.UserBulkJobState_USER_BULK_JOB_STATE_RUNNING
+	case domain.UserBulkJobStateDone:
+		return user_pb.UserBulkJobState_USER_BULK_JOB_STATE_DONE
+	case domain.UserBulkJobStateFailed:
+		return user_pb.UserBulkJobState_USER_BULK_JOB_STATE_FAILED
+	case domain.UserBulkJobStateCancelled:
+		return user_pb.UserBulkJobState_USER_BULK_JOB_STATE_CANCELLED
+	default:
+		return user_pb.UserBulkJobState_USER_BULK_JOB_STATE_UNSPECIFIED
+	}
+}
+
+func UserBulkJobStateToDomain(state user_pb.UserBulkJobState) domain.UserBulkJobState {
+	switch state {
+	case user_pb.UserBulkJobState_USER_BULK_JOB_STATE_QUEUED:
+		return domain.UserBulkJobStateQueued
+	case user_pb.UserBulkJobState_USER_BULK_JOB_STATE_RUNNING:
+		return domain.UserBulkJobStateRunning
+	case user_pb.UserBulkJobState_USER_BULK_JOB_STATE_DONE:
+		return domain.UserBulkJobStateDone
+	case user_pb.UserBulkJobState_USER_BULK_JOB_STATE_FAILED:
+		return domain.UserBulkJobStateFailed
+	case user_pb.UserBulkJobState_USER_BULK_JOB_STATE_CANCELLED:
+		return domain.UserBulkJobStateCancelled
+	default:
+		return domain.UserBulkJobStateUnspecified
+	}
+}
+
+func UserBulkJobQueriesToQuery(queries []*user_pb.UserBulkJobQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = UserBulkJobQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func UserBulkJobQueryToQuery(q *user_pb.UserBulkJobQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *user_pb.UserBulkJobQuery_TypeQuery:
+		return query.NewUserBulkJobTypeSearchQuery(UserBulkJobTypeToDomain(q.TypeQuery.Type))
+	case *user_pb.UserBulkJobQuery_StateQuery:
+		return query.NewUserBulkJobStateSearchQuery(UserBulkJobStateToDomain(q.StateQuery.State))
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "GRPC-Ubj1q", "List.Query.Invalid")
+	}
+}
@@ -0,0 +1,20 @@
+package user
+
+import (
+	"github.com/zitadel/zitadel/internal/query"
+	user_pb "github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+// UserDataExportToPb renders the export in the requested format
+// and returns the data including the file name and content type of the download
+func UserDataExportToPb(export *query.UserDataExport, format user_pb.UserDataExportFormat) (data []byte, fileName, contentType string, err error) {
+	fileName = "user_data_" + export.User.ID
+	switch format {
+	case user_pb.UserDataExportFormat_USER_DATA_EXPORT_FORMAT_ZIP:
+		data, err = export.ZIP()
+		return data, fileName + ".zip", "application/zip", err
+	default:
+		data, err = export.JSON()
+		return data, fileName + ".json", "application/json", err
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/user/model"
 	"github.com/zitadel/zitadel/pkg/grpc/user"
 )
@@ -28,3 +29,24 @@ func RefreshTokenToPb(token *model.RefreshTokenView) *user.RefreshToken {
 		Audience:       token.Audience,
 	}
 }
+
+func RefreshTokenFamiliesToPb(families []*query.RefreshTokenFamily) []*user.RefreshToken {
+	tokens := make([]*user.RefreshToken, len(families))
+	for i, family := range families {
+		tokens[i] = RefreshTokenFamilyToPb(family)
+	}
+	return tokens
+}
+
+func RefreshTokenFamilyToPb(family *query.RefreshTokenFamily) *user.RefreshToken {
+	return &user.RefreshToken{
+		Id:             family.ID,
+		Details:        object.ToViewDetailsPb(family.Sequence, family.CreationDate, family.ChangeDate, family.ResourceOwner),
+		ClientId:       family.ClientID,
+		AuthTime:       timestamppb.New(family.AuthTime),
+		IdleExpiration: timestamppb.New(family.IdleExpiration),
+		Expiration:     timestamppb.New(family.Expiration),
+		Scopes:         family.Scopes,
+		Audience:       family.Audience,
+	}
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) CreateMagicLink(ctx context.Context, req *user.CreateMagicLinkRequest) (resp *user.CreateMagicLinkResponse, err error) {
+	resourceOwner := authz.GetCtxData(ctx).ResourceOwner
+
+	switch medium := req.Medium.(type) {
+	case nil:
+		return magicLinkDetailsToPb(
+			s.command.AddUserMagicLinkCode(ctx, req.GetUserId(), resourceOwner, s.userCodeAlg),
+		)
+	case *user.CreateMagicLinkRequest_SendLink:
+		return magicLinkDetailsToPb(
+			s.command.AddUserMagicLinkCodeURLTemplate(ctx, req.GetUserId(), resourceOwner, s.userCodeAlg, medium.SendLink.GetUrlTemplate()),
+		)
+	case *user.CreateMagicLinkRequest_ReturnCode:
+		return magicLinkCodeDetailsToPb(
+			s.command.AddUserMagicLinkCodeReturn(ctx, req.GetUserId(), resourceOwner, s.userCodeAlg),
+		)
+	default:
+		return nil, caos_errs.ThrowUnimplementedf(nil, "USERv2-Quai8", "verification oneOf %T in method CreateMagicLink not implemented", medium)
+	}
+}
+
+func magicLinkDetailsToPb(details *domain.ObjectDetails, err error) (*user.CreateMagicLinkResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &user.CreateMagicLinkResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func magicLinkCodeDetailsToPb(details *domain.MagicLinkCodeDetails, err error) (*user.CreateMagicLinkResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &user.CreateMagicLinkResponse{
+		Details: object.DomainToDetailsPb(details.ObjectDetails),
+		Code: &user.MagicLinkCode{
+			Id:   details.CodeID,
+			Code: details.Code,
+		},
+	}, nil
+}
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	object "github.com/zitadel/zitadel/pkg/grpc/object/v2alpha"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func Test_recoveryCodesToPb(t *testing.T) {
+	type args struct {
+		codes *domain.RecoveryCodes
+		err   error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *user.GenerateRecoveryCodesResponse
+		wantErr error
+	}{
+		{
+			name: "error",
+			args: args{
+				err: io.ErrClosedPipe,
+			},
+			wantErr: io.ErrClosedPipe,
+		},
+		{
+			name: "success",
+			args: args{
+				codes: &domain.RecoveryCodes{
+					ObjectDetails: &domain.ObjectDetails{
+						Sequence:      123,
+						EventDate:     time.Unix(456, 789),
+						ResourceOwner: "me",
+					},
+					Codes: []string{"abcde-fghjk", "mnpqr-stuvw"},
+				},
+			},
+			want: &user.GenerateRecoveryCodesResponse{
+				Details: &object.Details{
+					Sequence: 123,
+					ChangeDate: &timestamppb.Timestamp{
+						Seconds: 456,
+						Nanos:   789,
+					},
+					ResourceOwner: "me",
+				},
+				Codes: []string{"abcde-fghjk", "mnpqr-stuvw"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := recoveryCodesToPb(tt.args.codes, tt.args.err)
+			require.ErrorIs(t, err, tt.wantErr)
+			if !proto.Equal(tt.want, got) {
+				t.Errorf("GenerateRecoveryCodesResponse =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2alpha"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) GetUserSchema(ctx context.Context, req *user.GetUserSchemaRequest) (*user.GetUserSchemaResponse, error) {
+	resourceOwner := object.ResourceOwnerFromReq(ctx, req.GetCtx())
+	policy, err := s.query.UserSchemaPolicyByOrg(ctx, true, resourceOwner, false)
+	if errors.IsNotFound(err) {
+		return &user.GetUserSchemaResponse{
+			Details: &object_pb.Details{
+				ResourceOwner: resourceOwner,
+			},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user.GetUserSchemaResponse{
+		Attributes: userSchemaAttributesToPb(policy.Attributes),
+		Details: &object_pb.Details{
+			Sequence:      policy.Sequence,
+			ChangeDate:    timestamppb.New(policy.ChangeDate),
+			ResourceOwner: policy.ResourceOwner,
+		},
+	}, nil
+}
+
+func userSchemaAttributesToPb(attributes []*domain.UserSchemaAttribute) []*user.UserSchemaAttribute {
+	a := make([]*user.UserSchemaAttribute, len(attributes))
+	for i, attribute := range attributes {
+		a[i] = &user.UserSchemaAttribute{
+			Name:          attribute.Name,
+			DisplayName:   attribute.DisplayName,
+			Type:          userSchemaAttributeTypeToPb(attribute.Type),
+			Required:      attribute.Required,
+			Pattern:       attribute.Pattern,
+			Unique:        attribute.Unique,
+			Visibility:    userSchemaAttributeVisibilityToPb(attribute.Visibility),
+			InTokenClaims: attribute.InTokenClaims,
+		}
+	}
+	return a
+}
+
+func userSchemaAttributeTypeToPb(attributeType domain.UserSchemaAttributeType) user.UserSchemaAttributeType {
+	switch attributeType {
+	case domain.UserSchemaAttributeTypeNumber:
+		return user.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_NUMBER
+	case domain.UserSchemaAttributeTypeBoolean:
+		return user.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_BOOLEAN
+	case domain.UserSchemaAttributeTypeDate:
+		return user.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_DATE
+	default:
+		return user.UserSchemaAttributeType_USER_SCHEMA_ATTRIBUTE_TYPE_STRING
+	}
+}
+
+func userSchemaAttributeVisibilityToPb(visibility domain.UserSchemaAttributeVisibility) user.UserSchemaAttributeVisibility {
+	switch visibility {
+	case domain.UserSchemaAttributeVisibilitySelf:
+		return user.UserSchemaAttributeVisibility_USER_SCHEMA_ATTRIBUTE_VISIBILITY_SELF
+	default:
+		return user.UserSchemaAttributeVisibility_USER_SCHEMA_ATTRIBUTE_VISIBILITY_ADMIN
+	}
+}
@@ -183,6 +183,10 @@ func intentToIDPInformationPb(intent *command.IDPIntentWriteModel, alg crypto.En
 		return nil, err
 	}
 
+	var linkingUserID *string
+	if intent.LinkingUserID != "" {
+		linkingUserID = &intent.LinkingUserID
+	}
 	return &user.RetrieveIdentityProviderInformationResponse{
 		Details: &object_pb.Details{
 			Sequence:      intent.ProcessedSequence,
@@ -201,6 +205,7 @@ func intentToIDPInformationPb(intent *command.IDPIntentWriteModel, alg crypto.En
 			UserName:       intent.IDPUserName,
 			RawInformation: rawInformation,
 		},
+		LinkingUserId: linkingUserID,
 	}, nil
 }
 
@@ -100,7 +100,7 @@ func createSuccessfulIntent(t *testing.T, idpID string) (string, string, time.Ti
 			IDToken: "idToken",
 		},
 	}
-	token, err := Tester.Commands.SucceedIDPIntent(ctx, writeModel, idpUser, idpSession, "")
+	token, err := Tester.Commands.SucceedIDPIntent(ctx, writeModel, idpUser, idpSession, "", "")
 	require.NoError(t, err)
 	return intentID, token, writeModel.ChangeDate, writeModel.ProcessedSequence
 }
@@ -174,9 +174,10 @@ func Test_intentToIDPInformationPb(t *testing.T) {
 						KeyID:      "id",
 						Crypted:    []byte("accessToken"),
 					},
-					IDPIDToken: "idToken",
-					UserID:     "userID",
-					State:      domain.IDPIntentStateSucceeded,
+					IDPIDToken:    "idToken",
+					UserID:        "userID",
+					LinkingUserID: "linkingUserID",
+					State:         domain.IDPIntentStateSucceeded,
 				},
 				alg: decryption(nil),
 			},
@@ -206,6 +207,7 @@ func Test_intentToIDPInformationPb(t *testing.T) {
 							return s
 						}(),
 					},
+					LinkingUserId: gu.Ptr("linkingUserID"),
 				},
 				err: nil,
 			},
@@ -27,6 +27,8 @@ const (
 	IfNoneMatch     = "If-None-Match"
 	LastModified    = "Last-Modified"
 	Etag            = "Etag"
+	DPoP            = "dpop"
+	DPoPNonce       = "dpop-nonce"
 
 	ContentSecurityPolicy   = "content-security-policy"
 	XXSSProtection          = "x-xss-protection"
@@ -17,6 +17,7 @@ var (
 			http_utils.Accept,
 			http_utils.AcceptLanguage,
 			http_utils.Authorization,
+			http_utils.DPoP,
 			http_utils.ZitadelOrgID,
 			http_utils.XUserAgent,
 			http_utils.XGrpcWeb,
@@ -34,6 +35,7 @@ var (
 		ExposedHeaders: []string{
 			http_utils.Location,
 			http_utils.ContentLength,
+			http_utils.DPoPNonce,
 		},
 		AllowOriginFunc: func(_ string) bool {
 			return true
@@ -119,8 +119,13 @@ func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 	}
 	userID, err := h.checkExternalUser(ctx, intent.IDPID, idpUser.GetID())
 	logging.WithFields("intent", intent.AggregateID).OnError(err).Error("could not check if idp user already exists")
+	var linkingUserID string
+	if userID == "" {
+		linkingUserID, err = h.autoLinkingUser(ctx, intent.IDPID, idpUser)
+		logging.WithFields("intent", intent.AggregateID).OnError(err).Error("could not search auto linking user")
+	}
 
-	token, err := h.commands.SucceedIDPIntent(ctx, intent, idpUser, idpSession, userID)
+	token, err := h.commands.SucceedIDPIntent(ctx, intent, idpUser, idpSession, userID, linkingUserID)
 	if err != nil {
 		redirectToFailureURLErr(w, r, intent, z_errs.ThrowInternal(err, "IDP-JdD3g", "Errors.Intent.TokenCreationFailed"))
 		return
@@ -238,6 +243,26 @@ func (h *Handler) checkExternalUser(ctx context.Context, idpID, externalUserID s
 	return links.Links[0].UserID, nil
 }
 
+// autoLinkingUser searches an existing user matching the idp user based on the auto linking option of the provider.
+// The found user is only returned as hint, the idp user must be linked (AddIDPLink) after the user authenticated as the found user.
+func (h *Handler) autoLinkingUser(ctx context.Context, idpID string, idpUser idp.User) (linkingUserID string, err error) {
+	provider, err := h.queries.IDPTemplateByID(ctx, false, idpID, false)
+	if err != nil {
+		return "", err
+	}
+	if provider.AutoLinking == domain.AutoLinkingOptionUnspecified {
+		return "", nil
+	}
+	user, err := h.queries.UserForAutoLinking(ctx, idpID, idpUser.GetEmail(), idpUser.IsEmailVerified(), "")
+	if z_errs.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
 func reason(err, description string) string {
 	if description == "" {
 		return err
@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -94,7 +95,7 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		return "", time.Time{}, err
 	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime, authz.TokenConfirmationFromCtx(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -118,9 +119,14 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 		return "", "", time.Time{}, err
 	}
 
+	rotation, err := o.refreshTokenRotationPolicy(ctx, applicationID, refreshToken)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
 		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
-		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		refreshTokenIdleExpiration, refreshTokenExpiration, authTime, authz.TokenConfirmationFromCtx(ctx), rotation) //PLANNED: lifetime from client
 	if err != nil {
 		if errors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
@@ -130,6 +136,21 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	return resp.TokenID, token, resp.Expiration, nil
 }
 
+// refreshTokenRotationPolicy returns the rotation settings of the client, which are only needed on renewal
+func (o *OPStorage) refreshTokenRotationPolicy(ctx context.Context, clientID, refreshToken string) (domain.RefreshTokenRotationPolicy, error) {
+	if refreshToken == "" {
+		return domain.RefreshTokenRotationPolicy{}, nil
+	}
+	app, err := o.query.AppByOIDCClientID(ctx, clientID, false)
+	if err != nil {
+		return domain.RefreshTokenRotationPolicy{}, err
+	}
+	return domain.RefreshTokenRotationPolicy{
+		Rotation:      app.OIDCConfig.RefreshTokenRotation,
+		ReuseInterval: app.OIDCConfig.RefreshTokenReuseInterval,
+	}, nil
+}
+
 func getInfoFromRequest(req op.TokenRequest) (string, string, string, time.Time, []string) {
 	authReq, ok := req.(*AuthRequest)
 	if ok {
@@ -143,7 +164,8 @@ func getInfoFromRequest(req op.TokenRequest) (string, string, string, time.Time,
 }
 
 func (o *OPStorage) TokenRequestByRefreshToken(ctx context.Context, refreshToken string) (op.RefreshTokenRequest, error) {
-	tokenView, err := o.repo.RefreshTokenByToken(ctx, refreshToken)
+	// rotated tokens are checked (and their reuse detected) when the token is renewed
+	tokenView, err := o.repo.RefreshTokenFamilyByToken(ctx, refreshToken)
 	if err != nil {
 		return nil, err
 	}
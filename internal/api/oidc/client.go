@@ -31,6 +31,7 @@ const (
 	ClaimProjectRolesFormat = "urn:zitadel:iam:org:project:%s:roles"
 	ScopeUserMetaData       = "urn:zitadel:iam:user:metadata"
 	ClaimUserMetaData       = ScopeUserMetaData
+	ClaimUserAttributes     = "urn:zitadel:iam:user:attributes"
 	ScopeResourceOwner      = "urn:zitadel:iam:user:resourceowner"
 	ClaimResourceOwner      = ScopeResourceOwner + ":"
 	ClaimActionLogFormat    = "urn:zitadel:iam:action:%s:log"
@@ -123,6 +124,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 	if err != nil {
 		return errors.ThrowPermissionDenied(nil, "OIDC-Dsfb2", "token is not valid or has expired")
 	}
+	if err = token.Confirmation().Verify(authz.TokenConfirmationFromCtx(ctx)); err != nil {
+		return errors.ThrowPermissionDenied(err, "OIDC-Ohl0s", "token is not valid or has expired")
+	}
 	if token.ApplicationID != "" {
 		app, err := o.query.AppByOIDCClientID(ctx, token.ApplicationID, false)
 		if err != nil {
@@ -179,6 +183,12 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			introspection.Scope = token.Scopes
 			introspection.ClientID = token.ApplicationID
 			introspection.TokenType = oidc.BearerToken
+			if confirmation := token.Confirmation(); !confirmation.IsZero() {
+				if confirmation.JKT != "" {
+					introspection.TokenType = tokenTypeDPoP
+				}
+				introspection.Claims = appendClaim(introspection.Claims, ClaimConfirmation, confirmation)
+			}
 			introspection.Expiration = oidc.FromTime(token.Expiration)
 			introspection.IssuedAt = oidc.FromTime(token.CreationDate)
 			introspection.NotBefore = oidc.FromTime(token.CreationDate)
@@ -303,6 +313,10 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo *oidc.UserInfo, us
 		}
 	}
 
+	if err := o.setUserInfoAttributes(ctx, userInfo, user); err != nil {
+		return err
+	}
+
 	// if all roles are requested take the audience for those from the scopes
 	if allRoles && len(roleAudience) == 0 {
 		roleAudience = domain.AddAudScopeToAudience(ctx, roleAudience, scopes)
@@ -363,6 +377,17 @@ func (o *OPStorage) setUserInfoMetadata(ctx context.Context, userInfo *oidc.User
 	return nil
 }
 
+func (o *OPStorage) setUserInfoAttributes(ctx context.Context, userInfo *oidc.UserInfo, user *query.User) error {
+	attributes, err := o.assertUserAttributes(ctx, user.ID, user.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if len(attributes) > 0 {
+		userInfo.AppendClaims(ClaimUserAttributes, attributes)
+	}
+	return nil
+}
+
 func (o *OPStorage) setUserInfoResourceOwner(ctx context.Context, userInfo *oidc.UserInfo, userID string) error {
 	resourceOwnerClaims, err := o.assertUserResourceOwner(ctx, userID)
 	if err != nil {
@@ -572,6 +597,10 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 			claims = appendClaim(claims, fmt.Sprintf(ClaimProjectRolesFormat, projectID), roles)
 		}
 	}
+	// the private claims are only requested for JWT access tokens, which are bound to the keys proven in the token request
+	if confirmation := authz.TokenConfirmationFromCtx(ctx); !confirmation.IsZero() {
+		claims = appendClaim(claims, ClaimConfirmation, confirmation)
+	}
 
 	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
 }
@@ -581,6 +610,13 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 	if err != nil {
 		return nil, err
 	}
+	attributes, err := o.assertUserAttributes(ctx, user.ID, user.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if len(attributes) > 0 {
+		claims = appendClaim(claims, ClaimUserAttributes, attributes)
+	}
 	queriedActions, err := o.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, user.ResourceOwner, false)
 	if err != nil {
 		return nil, err
@@ -757,6 +793,39 @@ func (o *OPStorage) assertUserMetaData(ctx context.Context, userID string) (map[
 	return userMetaData, nil
 }
 
+// assertUserAttributes returns the typed values of the attributes of the user schema, which are configured for the token claims
+func (o *OPStorage) assertUserAttributes(ctx context.Context, userID, resourceOwner string) (map[string]interface{}, error) {
+	schema, err := o.query.UserSchemaPolicyByOrg(ctx, false, resourceOwner, false)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	claimAttributes := make(map[string]*domain.UserSchemaAttribute)
+	for _, attribute := range schema.Attributes {
+		// attributes only visible to administrators are never part of the user's own tokens,
+		// this also applies to policies stored before the combination was rejected
+		if attribute.InTokenClaims && attribute.Visibility == domain.UserSchemaAttributeVisibilitySelf {
+			claimAttributes[attribute.Name] = attribute
+		}
+	}
+	if len(claimAttributes) == 0 {
+		return nil, nil
+	}
+	metaData, err := o.query.SearchUserMetadata(ctx, true, userID, &query.UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]interface{})
+	for _, md := range metaData.Metadata {
+		if attribute, ok := claimAttributes[md.Key]; ok {
+			attributes[md.Key] = attribute.ClaimValue(md.Value)
+		}
+	}
+	return attributes, nil
+}
+
 func (o *OPStorage) assertUserResourceOwner(ctx context.Context, userID string) (map[string]string, error) {
 	user, err := o.query.GetUserByID(ctx, true, userID, false)
 	if err != nil {
@@ -0,0 +1,437 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// ClientRegistrationPath is the endpoint for Dynamic Client Registration (RFC 7591).
+	// The client configuration endpoint (RFC 7592) is located at ClientRegistrationPath/{client_id}.
+	ClientRegistrationPath = "/oauth/v2/register"
+
+	clientRegistrationClientIDParam = "client_id"
+	// maxClientMetadataSize limits the body of the registration requests
+	maxClientMetadataSize = 64 << 10
+
+	registrationErrorInvalidRedirectURI    = "invalid_redirect_uri"
+	registrationErrorInvalidClientMetadata = "invalid_client_metadata"
+	registrationErrorInvalidToken          = "invalid_token"
+	registrationErrorServerError           = "server_error"
+
+	applicationTypeWeb    = "web"
+	applicationTypeNative = "native"
+)
+
+// ClientMetadata represents the client metadata of RFC 7591 which are supported by ZITADEL.
+type ClientMetadata struct {
+	ClientName              string   `json:"client_name,omitempty"`
+	RedirectURIs            []string `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs  []string `json:"post_logout_redirect_uris,omitempty"`
+	ResponseTypes           []string `json:"response_types,omitempty"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	ApplicationType         string   `json:"application_type,omitempty"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+}
+
+// ClientInformationResponse is the response of a successful registration (RFC 7591)
+// and of the client read and update requests (RFC 7592).
+type ClientInformationResponse struct {
+	ClientMetadata
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at,omitempty"`
+	ClientSecretExpiresAt   *int64 `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+}
+
+type clientRegistrationError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+type clientRegistrationHandler struct {
+	command         *command.Commands
+	query           *query.Queries
+	passwordHashAlg crypto.HashAlgorithm
+	externalSecure  bool
+}
+
+// NewClientRegistrationHandler returns the handler for the Dynamic Client Registration endpoints.
+// It must be registered on [ClientRegistrationPath] before the handler of the OpenID Provider.
+func NewClientRegistrationHandler(
+	command *command.Commands,
+	query *query.Queries,
+	passwordHashAlg crypto.HashAlgorithm,
+	externalSecure bool,
+	instanceHandler,
+	accessHandler func(http.Handler) http.Handler,
+) http.Handler {
+	h := &clientRegistrationHandler{
+		command:         command,
+		query:           query,
+		passwordHashAlg: passwordHashAlg,
+		externalSecure:  externalSecure,
+	}
+	router := mux.NewRouter()
+	router.Use(instanceHandler, accessHandler)
+	router.HandleFunc(ClientRegistrationPath, h.registerClient).Methods(http.MethodPost)
+	clientPath := ClientRegistrationPath + "/{" + clientRegistrationClientIDParam + "}"
+	router.HandleFunc(clientPath, h.readClient).Methods(http.MethodGet)
+	router.HandleFunc(clientPath, h.updateClient).Methods(http.MethodPut)
+	router.HandleFunc(clientPath, h.deleteClient).Methods(http.MethodDelete)
+	return router
+}
+
+func (h *clientRegistrationHandler) registerClient(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	token, ok := bearerToken(r)
+	if !ok {
+		writeClientRegistrationError(w, caos_errs.ThrowUnauthenticated(nil, "OIDC-ieR5a", "Errors.Project.App.Registration.TokenInvalid"))
+		return
+	}
+	metadata, err := parseClientMetadata(w, r)
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	app, err := metadata.toOIDCApp()
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	registered, registrationAccessToken, err := h.command.RegisterOIDCClient(ctx, token, app, h.passwordHashAlg)
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	response := h.clientInformationResponse(ctx, registered)
+	response.ClientSecret = registered.ClientSecretString
+	response.ClientIDIssuedAt = registered.ChangeDate.Unix()
+	response.RegistrationAccessToken = registrationAccessToken
+	writeClientRegistrationResponse(w, http.StatusCreated, response)
+}
+
+func (h *clientRegistrationHandler) readClient(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	registration, err := h.checkRegistrationAccessToken(ctx, r)
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	app, err := h.query.AppByProjectAndAppID(ctx, true, registration.AggregateID, registration.AppID, false)
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	if app.OIDCConfig == nil {
+		writeClientRegistrationError(w, caos_errs.ThrowUnauthenticated(nil, "OIDC-Ahng4", "Errors.Project.App.Registration.TokenInvalid"))
+		return
+	}
+	writeClientRegistrationResponse(w, http.StatusOK, h.clientInformationResponse(ctx, queryAppToOIDCApp(app)))
+}
+
+func (h *clientRegistrationHandler) updateClient(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	if _, err = h.checkRegistrationAccessToken(ctx, r); err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	metadata, err := parseClientMetadata(w, r)
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	app, err := metadata.toOIDCApp()
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	token, _ := bearerToken(r)
+	changed, err := h.command.ChangeRegisteredOIDCClient(ctx, token, app)
+	if err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	writeClientRegistrationResponse(w, http.StatusOK, h.clientInformationResponse(ctx, changed))
+}
+
+func (h *clientRegistrationHandler) deleteClient(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	if _, err = h.checkRegistrationAccessToken(ctx, r); err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	token, _ := bearerToken(r)
+	if _, err = h.command.RemoveRegisteredOIDCClient(ctx, token); err != nil {
+		writeClientRegistrationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkRegistrationAccessToken verifies the bearer token of the request
+// and ensures it was issued for the client of the request path.
+func (h *clientRegistrationHandler) checkRegistrationAccessToken(ctx context.Context, r *http.Request) (*command.OIDCRegistrationAccessTokenWriteModel, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "OIDC-eeT6o", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	registration, err := h.command.CheckOIDCRegistrationAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	app, err := h.query.AppByOIDCClientID(ctx, mux.Vars(r)[clientRegistrationClientIDParam], false)
+	if err != nil || app.ProjectID != registration.AggregateID || app.ID != registration.AppID {
+		return nil, caos_errs.ThrowUnauthenticated(err, "OIDC-Oe5ai", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	return registration, nil
+}
+
+func (h *clientRegistrationHandler) clientInformationResponse(ctx context.Context, app *domain.OIDCApp) *ClientInformationResponse {
+	var secretExpiresAt *int64
+	if app.AuthMethodType == domain.OIDCAuthMethodTypeBasic || app.AuthMethodType == domain.OIDCAuthMethodTypePost {
+		// client secrets do not expire
+		secretExpiresAt = new(int64)
+	}
+	return &ClientInformationResponse{
+		ClientMetadata: ClientMetadata{
+			ClientName:              app.AppName,
+			RedirectURIs:            app.RedirectUris,
+			PostLogoutRedirectURIs:  app.PostLogoutRedirectUris,
+			ResponseTypes:           responseTypesToString(app.ResponseTypes),
+			GrantTypes:              grantTypesToString(app.GrantTypes),
+			ApplicationType:         applicationTypeToString(app.ApplicationType),
+			TokenEndpointAuthMethod: string(authMethodToOIDC(app.AuthMethodType)),
+		},
+		ClientID:              app.ClientID,
+		ClientSecretExpiresAt: secretExpiresAt,
+		RegistrationClientURI: http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), h.externalSecure) + ClientRegistrationPath + "/" + app.ClientID,
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	auth := r.Header.Get(http_utils.Authorization)
+	if !strings.HasPrefix(auth, oidc.PrefixBearer) {
+		return "", false
+	}
+	token := strings.TrimPrefix(auth, oidc.PrefixBearer)
+	return token, token != ""
+}
+
+// parseClientMetadata decodes the client metadata of the request,
+// the body is limited as the registration is possible without an authenticated user
+func parseClientMetadata(w http.ResponseWriter, r *http.Request) (*ClientMetadata, error) {
+	metadata := new(ClientMetadata)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxClientMetadataSize)).Decode(metadata); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "OIDC-Ceix0", "Errors.Project.App.OIDCConfigInvalid")
+	}
+	return metadata, nil
+}
+
+// toOIDCApp maps the client metadata to an [domain.OIDCApp]
+// using the defaults defined in RFC 7591 and OpenID Connect Dynamic Client Registration 1.0
+// for omitted values.
+func (m *ClientMetadata) toOIDCApp() (*domain.OIDCApp, error) {
+	if len(m.RedirectURIs) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "OIDC-Wai0z", "Errors.Project.App.Registration.RedirectURIMissing")
+	}
+	app := &domain.OIDCApp{
+		AppName:                m.ClientName,
+		RedirectUris:           m.RedirectURIs,
+		PostLogoutRedirectUris: m.PostLogoutRedirectURIs,
+		ResponseTypes:          []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+		GrantTypes:             []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+		AuthMethodType:         domain.OIDCAuthMethodTypeBasic,
+		OIDCVersion:            domain.OIDCVersionV1,
+		AccessTokenType:        domain.OIDCTokenTypeBearer,
+	}
+	var err error
+	if len(m.ResponseTypes) > 0 {
+		if app.ResponseTypes, err = responseTypesFromString(m.ResponseTypes); err != nil {
+			return nil, err
+		}
+	}
+	if len(m.GrantTypes) > 0 {
+		if app.GrantTypes, err = grantTypesFromString(m.GrantTypes); err != nil {
+			return nil, err
+		}
+	}
+	if m.TokenEndpointAuthMethod != "" {
+		if app.AuthMethodType, err = authMethodFromString(m.TokenEndpointAuthMethod); err != nil {
+			return nil, err
+		}
+	}
+	switch m.ApplicationType {
+	case "", applicationTypeWeb:
+		app.ApplicationType = domain.OIDCApplicationTypeWeb
+		if app.AuthMethodType == domain.OIDCAuthMethodTypeNone {
+			app.ApplicationType = domain.OIDCApplicationTypeUserAgent
+		}
+	case applicationTypeNative:
+		app.ApplicationType = domain.OIDCApplicationTypeNative
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "OIDC-aiL5o", "Errors.Project.App.OIDCConfigInvalid")
+	}
+	if !app.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "OIDC-Pho1a", "Errors.Project.App.OIDCConfigInvalid")
+	}
+	return app, nil
+}
+
+func queryAppToOIDCApp(app *query.App) *domain.OIDCApp {
+	return &domain.OIDCApp{
+		AppID:                  app.ID,
+		AppName:                app.Name,
+		ClientID:               app.OIDCConfig.ClientID,
+		RedirectUris:           app.OIDCConfig.RedirectURIs,
+		PostLogoutRedirectUris: app.OIDCConfig.PostLogoutRedirectURIs,
+		ResponseTypes:          app.OIDCConfig.ResponseTypes,
+		GrantTypes:             app.OIDCConfig.GrantTypes,
+		ApplicationType:        app.OIDCConfig.AppType,
+		AuthMethodType:         app.OIDCConfig.AuthMethodType,
+	}
+}
+
+func responseTypesFromString(responseTypes []string) ([]domain.OIDCResponseType, error) {
+	types := make([]domain.OIDCResponseType, len(responseTypes))
+	for i, responseType := range responseTypes {
+		switch oidc.ResponseType(responseType) {
+		case oidc.ResponseTypeCode:
+			types[i] = domain.OIDCResponseTypeCode
+		case oidc.ResponseTypeIDToken:
+			types[i] = domain.OIDCResponseTypeIDTokenToken
+		case oidc.ResponseTypeIDTokenOnly:
+			types[i] = domain.OIDCResponseTypeIDToken
+		default:
+			return nil, caos_errs.ThrowInvalidArgument(nil, "OIDC-ou8Ee", "Errors.Project.App.OIDCConfigInvalid")
+		}
+	}
+	return types, nil
+}
+
+func responseTypesToString(responseTypes []domain.OIDCResponseType) []string {
+	types := make([]string, len(responseTypes))
+	for i, responseType := range responseTypes {
+		types[i] = string(responseTypeToOIDC(responseType))
+	}
+	return types
+}
+
+func grantTypesFromString(grantTypes []string) ([]domain.OIDCGrantType, error) {
+	types := make([]domain.OIDCGrantType, len(grantTypes))
+	for i, grantType := range grantTypes {
+		switch oidc.GrantType(grantType) {
+		case oidc.GrantTypeCode:
+			types[i] = domain.OIDCGrantTypeAuthorizationCode
+		case oidc.GrantTypeImplicit:
+			types[i] = domain.OIDCGrantTypeImplicit
+		case oidc.GrantTypeRefreshToken:
+			types[i] = domain.OIDCGrantTypeRefreshToken
+		case oidc.GrantTypeDeviceCode:
+			types[i] = domain.OIDCGrantTypeDeviceCode
+		default:
+			return nil, caos_errs.ThrowInvalidArgument(nil, "OIDC-Hie0a", "Errors.Project.App.OIDCConfigInvalid")
+		}
+	}
+	return types, nil
+}
+
+func grantTypesToString(grantTypes []domain.OIDCGrantType) []string {
+	types := make([]string, len(grantTypes))
+	for i, grantType := range grantTypes {
+		types[i] = string(grantTypeToOIDC(grantType))
+	}
+	return types
+}
+
+func authMethodFromString(authMethod string) (domain.OIDCAuthMethodType, error) {
+	switch oidc.AuthMethod(authMethod) {
+	case oidc.AuthMethodBasic:
+		return domain.OIDCAuthMethodTypeBasic, nil
+	case oidc.AuthMethodPost:
+		return domain.OIDCAuthMethodTypePost, nil
+	case oidc.AuthMethodNone:
+		return domain.OIDCAuthMethodTypeNone, nil
+	case oidc.AuthMethodPrivateKeyJWT:
+		return domain.OIDCAuthMethodTypePrivateKeyJWT, nil
+	default:
+		return 0, caos_errs.ThrowInvalidArgument(nil, "OIDC-ahT4e", "Errors.Project.App.OIDCConfigInvalid")
+	}
+}
+
+func applicationTypeToString(appType domain.OIDCApplicationType) string {
+	if appType == domain.OIDCApplicationTypeNative {
+		return applicationTypeNative
+	}
+	return applicationTypeWeb
+}
+
+func writeClientRegistrationResponse(w http.ResponseWriter, status int, response *ClientInformationResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(response)
+	logging.OnError(err).Error("unable to write client registration response")
+}
+
+func writeClientRegistrationError(w http.ResponseWriter, err error) {
+	status, registrationErr := clientRegistrationErrorFromError(err)
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer error="`+registrationErr.Error+`"`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encodeErr := json.NewEncoder(w).Encode(registrationErr)
+	logging.OnError(encodeErr).Error("unable to write client registration error")
+}
+
+func clientRegistrationErrorFromError(err error) (int, *clientRegistrationError) {
+	description := err.Error()
+	caosErr := new(caos_errs.CaosError)
+	if errors.As(err, &caosErr) {
+		description = caosErr.GetMessage()
+	}
+	switch {
+	case caos_errs.IsUnauthenticated(err), caos_errs.IsNotFound(err):
+		return http.StatusUnauthorized, &clientRegistrationError{Error: registrationErrorInvalidToken, ErrorDescription: description}
+	case caos_errs.IsPermissionDenied(err) && strings.HasSuffix(description, "RedirectURINotAllowed"),
+		strings.HasSuffix(description, "RedirectURIMissing"):
+		return http.StatusBadRequest, &clientRegistrationError{Error: registrationErrorInvalidRedirectURI, ErrorDescription: description}
+	case caos_errs.IsPermissionDenied(err), caos_errs.IsErrorInvalidArgument(err), caos_errs.IsPreconditionFailed(err):
+		return http.StatusBadRequest, &clientRegistrationError{Error: registrationErrorInvalidClientMetadata, ErrorDescription: description}
+	default:
+		logging.WithError(err).Error("client registration failed")
+		return http.StatusInternalServerError, &clientRegistrationError{Error: registrationErrorServerError}
+	}
+}
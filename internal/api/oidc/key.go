@@ -24,10 +24,9 @@ const (
 	signingKey = "signing_key"
 	oidcUser   = "OIDC"
 
-	retryBackoff   = 500 * time.Millisecond
-	retryCount     = 3
-	lockDuration   = retryCount * retryBackoff * 5
-	gracefulPeriod = 10 * time.Minute
+	retryBackoff = 500 * time.Millisecond
+	retryCount   = 3
+	lockDuration = retryCount * retryBackoff * 5
 )
 
 // SigningKey wraps the query.PrivateKey to implement the op.SigningKey interface
@@ -79,10 +78,13 @@ func (o *OPStorage) KeySet(ctx context.Context) (keys []op.Key, err error) {
 		if err != nil {
 			return err
 		}
-		keys = make([]op.Key, len(publicKeys.Keys))
+		keys = make([]op.Key, len(publicKeys.Keys), len(publicKeys.Keys)+1)
 		for i, key := range publicKeys.Keys {
 			keys[i] = &PublicKey{key}
 		}
+		if o.providerSigningKey != nil {
+			keys = append(keys, o.providerSigningKey.publicKey())
+		}
 		return nil
 	})
 	return keys, err
@@ -114,18 +116,32 @@ func (o *OPStorage) SigningKey(ctx context.Context) (key op.SigningKey, err erro
 }
 
 func (o *OPStorage) getSigningKey(ctx context.Context) (op.SigningKey, error) {
-	keys, err := o.query.ActivePrivateSigningKey(ctx, time.Now().Add(gracefulPeriod))
+	if o.providerSigningKey != nil {
+		return o.providerSigningKey, nil
+	}
+	policy, err := o.query.KeyRotationPolicy(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if len(keys.Keys) > 0 {
-		return o.privateKeyToSigningKey(selectSigningKey(keys.Keys))
+	now := time.Now()
+	keys, err := o.query.ActivePrivateSigningKey(ctx, now)
+	if err != nil {
+		return nil, err
 	}
+	prePublicationPeriod := policy.KeyPrePublicationPeriod()
 	var sequence uint64
 	if keys.LatestSequence != nil {
 		sequence = keys.LatestSequence.Sequence
 	}
-	return nil, o.refreshSigningKey(ctx, o.signingKeyAlgorithm, sequence)
+	if len(keys.Keys) == 0 {
+		return nil, o.refreshSigningKey(ctx, o.signingKeyAlgorithm, sequence)
+	}
+	if !hasSuccessor(keys.Keys, now.Add(prePublicationPeriod)) {
+		// generate the successor while the current key is still valid,
+		// so it's published in the key set long enough before it's used for signing
+		o.generateSuccessorSigningKey(ctx, o.signingKeyAlgorithm, sequence)
+	}
+	return o.privateKeyToSigningKey(selectSigningKey(keys.Keys, prePublicationPeriod, now))
 }
 
 func (o *OPStorage) refreshSigningKey(ctx context.Context, algorithm string, sequence uint64) error {
@@ -140,6 +156,16 @@ func (o *OPStorage) refreshSigningKey(ctx context.Context, algorithm string, seq
 	return errors.ThrowInternal(nil, "OIDC-Df1bh", "")
 }
 
+func (o *OPStorage) generateSuccessorSigningKey(ctx context.Context, algorithm string, sequence uint64) {
+	ok, err := o.ensureIsLatestKey(ctx, sequence)
+	if err != nil || !ok {
+		logging.OnError(err).Warn("cannot ensure that projection is up to date")
+		return
+	}
+	err = o.lockAndGenerateSigningKeyPair(ctx, algorithm)
+	logging.OnError(err).Warn("could not create successor signing key")
+}
+
 func (o *OPStorage) ensureIsLatestKey(ctx context.Context, sequence uint64) (bool, error) {
 	maxSequence, err := o.getMaxKeySequence(ctx)
 	if err != nil {
@@ -153,7 +179,7 @@ func (o *OPStorage) privateKeyToSigningKey(key query.PrivateKey) (_ op.SigningKe
 	if err != nil {
 		return nil, err
 	}
-	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	privateKey, err := crypto.BytesToSigningPrivateKey(keyData)
 	if err != nil {
 		return nil, err
 	}
@@ -194,8 +220,35 @@ func (o *OPStorage) getMaxKeySequence(ctx context.Context) (uint64, error) {
 	)
 }
 
-func selectSigningKey(keys []query.PrivateKey) query.PrivateKey {
-	return keys[len(keys)-1]
+// selectSigningKey returns the newest key, which has been published for the pre-publication period.
+// If there is none (e.g. after the first key was generated or all others were revoked), the newest key is used.
+func selectSigningKey(keys []query.PrivateKey, prePublicationPeriod time.Duration, now time.Time) query.PrivateKey {
+	var newest, published query.PrivateKey
+	for _, key := range keys {
+		if newest == nil || key.CreationDate().After(newest.CreationDate()) {
+			newest = key
+		}
+		if key.CreationDate().Add(prePublicationPeriod).After(now) {
+			continue
+		}
+		if published == nil || key.CreationDate().After(published.CreationDate()) {
+			published = key
+		}
+	}
+	if published != nil {
+		return published
+	}
+	return newest
+}
+
+// hasSuccessor checks if any key can still be used for signing after the provided time
+func hasSuccessor(keys []query.PrivateKey, t time.Time) bool {
+	for _, key := range keys {
+		if key.Expiry().After(t) {
+			return true
+		}
+	}
+	return false
 }
 
 func setOIDCCtx(ctx context.Context) context.Context {
@@ -0,0 +1,106 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+// providerSigningKey signs the tokens with a key of the key provider (HSM / KMS),
+// so the private key is never loaded into ZITADEL.
+// The key is used for all instances, its rotation is managed by the key provider.
+type providerSigningKey struct {
+	signer *crypto.KeyProviderSigner
+}
+
+func newProviderSigningKey(provider crypto.KeyProvider, keyID string) (*providerSigningKey, error) {
+	signer, err := crypto.NewKeyProviderSigner(provider, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &providerSigningKey{signer: signer}, nil
+}
+
+// SignatureAlgorithm implements the op.SigningKey interface
+func (k *providerSigningKey) SignatureAlgorithm() jose.SignatureAlgorithm {
+	return jose.SignatureAlgorithm(k.signer.Algorithm())
+}
+
+// Key implements the op.SigningKey interface
+func (k *providerSigningKey) Key() interface{} {
+	return k
+}
+
+// ID implements the op.SigningKey and op.Key interface
+func (k *providerSigningKey) ID() string {
+	return k.signer.KeyID()
+}
+
+// publicKey returns the op.Key published in the key set
+func (k *providerSigningKey) publicKey() *providerPublicKey {
+	return &providerPublicKey{k}
+}
+
+// Public implements the jose.OpaqueSigner interface
+func (k *providerSigningKey) Public() *jose.JSONWebKey {
+	return &jose.JSONWebKey{
+		Key:       k.signer.Public(),
+		KeyID:     k.signer.KeyID(),
+		Algorithm: k.signer.Algorithm(),
+		Use:       "sig",
+	}
+}
+
+// Algs implements the jose.OpaqueSigner interface
+func (k *providerSigningKey) Algs() []jose.SignatureAlgorithm {
+	return []jose.SignatureAlgorithm{k.SignatureAlgorithm()}
+}
+
+// SignPayload implements the jose.OpaqueSigner interface
+func (k *providerSigningKey) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	hash, err := crypto.SigningAlgorithmHash(string(alg))
+	if err != nil {
+		return nil, err
+	}
+	digest := payload
+	if hash != 0 {
+		hasher := hash.New()
+		hasher.Write(payload)
+		digest = hasher.Sum(nil)
+	}
+	signature, err := k.signer.Sign(rand.Reader, digest, hash)
+	if err != nil {
+		return nil, err
+	}
+	// JWS requires the concatenated r and s values instead of the ASN.1 encoding
+	if ecKey, ok := k.signer.Public().(*ecdsa.PublicKey); ok {
+		return crypto.ECDSASignatureFromASN1(signature, ecKey.Curve)
+	}
+	return signature, nil
+}
+
+// providerPublicKey implements the op.Key interface for the public key of the providerSigningKey
+type providerPublicKey struct {
+	key *providerSigningKey
+}
+
+func (k *providerPublicKey) ID() string {
+	return k.key.ID()
+}
+
+func (k *providerPublicKey) Algorithm() jose.SignatureAlgorithm {
+	return k.key.SignatureAlgorithm()
+}
+
+func (k *providerPublicKey) Use() string {
+	return "sig"
+}
+
+func (k *providerPublicKey) Key() interface{} {
+	return k.key.signer.Public()
+}
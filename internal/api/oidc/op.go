@@ -11,6 +11,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/api/assets"
+	"github.com/zitadel/zitadel/internal/api/authz"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
@@ -33,6 +34,7 @@ type Config struct {
 	GrantTypeRefreshToken             bool
 	RequestObjectSupported            bool
 	SigningKeyAlgorithm               string
+	SigningKeyProviderKeyID           string
 	DefaultAccessTokenLifetime        time.Duration
 	DefaultIdTokenLifetime            time.Duration
 	DefaultRefreshTokenIdleExpiration time.Duration
@@ -71,17 +73,27 @@ type OPStorage struct {
 	defaultRefreshTokenIdleExpiration time.Duration
 	defaultRefreshTokenExpiration     time.Duration
 	encAlg                            crypto.EncryptionAlgorithm
+	providerSigningKey                *providerSigningKey
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
 }
 
-func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *database.DB, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
+func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure bool, tokenBinding authz.TokenBindingConfig, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, keyProvider crypto.KeyProvider, es *eventstore.Eventstore, projections *database.DB, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
 	opConfig, err := createOPConfig(config, defaultLogoutRedirectURI, cryptoKey)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
 	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
-	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, accessHandler)
+	if config.SigningKeyProviderKeyID != "" {
+		if keyProvider == nil {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "OIDC-Eeh4a", "signing key of the key provider configured, but no key provider")
+		}
+		storage.providerSigningKey, err = newProviderSigningKey(keyProvider, config.SigningKeyProviderKeyID)
+		if err != nil {
+			return nil, caos_errs.ThrowInternal(err, "OIDC-uaX5o", "cannot load signing key of key provider")
+		}
+	}
+	options, err := createOptions(config, externalSecure, tokenBinding, userAgentCookie, instanceHandler, accessHandler)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-D3gq1", "cannot create options: %w")
 	}
@@ -119,7 +131,7 @@ func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []
 	return opConfig, nil
 }
 
-func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) ([]op.Option, error) {
+func createOptions(config Config, externalSecure bool, tokenBinding authz.TokenBindingConfig, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) ([]op.Option, error) {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	options := []op.Option{
 		op.WithHttpInterceptors(
@@ -127,6 +139,7 @@ func createOptions(config Config, externalSecure bool, userAgentCookie, instance
 			middleware.TelemetryHandler(),
 			middleware.NoCacheInterceptor().Handler,
 			instanceHandler,
+			tokenBindingInterceptor(tokenBinding, externalSecure),
 			userAgentCookie,
 			http_utils.CopyHeadersToContext,
 			accessHandler,
@@ -0,0 +1,78 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+const (
+	// ClaimConfirmation is the cnf claim of RFC 7800 containing the thumbprints of the keys a token is bound to
+	ClaimConfirmation = "cnf"
+
+	tokenTypeDPoP           = "DPoP"
+	errorInvalidDPoPProof   = "invalid_dpop_proof"
+	errorUseDPoPNonce       = "use_dpop_nonce"
+	headerWWWAuthenticate   = "WWW-Authenticate"
+	invalidDPoPProofMessage = "the DPoP proof or client certificate of the request is invalid"
+	useDPoPNonceMessage     = "the DPoP proof must contain the nonce provided in the DPoP-Nonce header"
+)
+
+// tokenBindingInterceptor verifies the DPoP proof and the client certificate presented with the request
+// and stores the proven keys in the context,
+// so that issued tokens are bound to them and the binding of presented tokens can be checked.
+// As the oidc library only handles bearer tokens, the DPoP authorization scheme is rewritten.
+func tokenBindingInterceptor(config authz.TokenBindingConfig, externalSecure bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var accessToken string
+			authorization := r.Header.Get(http_utils.Authorization)
+			switch {
+			case strings.HasPrefix(authorization, authz.DPoPPrefix):
+				accessToken = strings.TrimPrefix(authorization, authz.DPoPPrefix)
+				r.Header.Set(http_utils.Authorization, authz.BearerPrefix+accessToken)
+			case strings.HasPrefix(authorization, authz.BearerPrefix):
+				accessToken = strings.TrimPrefix(authorization, authz.BearerPrefix)
+			}
+			var clientCertificate string
+			if config.ClientCertificateHeader != "" {
+				clientCertificate = r.Header.Get(config.ClientCertificateHeader)
+			}
+			uri := http_utils.BuildOrigin(authz.GetInstance(r.Context()).RequestedHost(), externalSecure) + r.URL.EscapedPath()
+			confirmation, err := config.RequestConfirmation(r.Header.Values(http_utils.DPoP), clientCertificate, r.Method, uri, accessToken)
+			if authz.IsDPoPNonceRequired(err) {
+				w.Header().Set(http_utils.DPoPNonce, config.DPoPNonceValue())
+				writeDPoPError(w, accessToken != "", errorUseDPoPNonce, useDPoPNonceMessage)
+				return
+			}
+			if err != nil {
+				logging.WithError(err).Debug("invalid token binding")
+				writeDPoPError(w, accessToken != "", errorInvalidDPoPProof, invalidDPoPProofMessage)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(authz.SetTokenConfirmation(r.Context(), confirmation)))
+		})
+	}
+}
+
+// writeDPoPError responds as defined in RFC 9449 section 7.1 and 9 for protected resources
+// or section 5 and 8 for the token endpoint
+func writeDPoPError(w http.ResponseWriter, protectedResource bool, errorCode, description string) {
+	if protectedResource {
+		w.Header().Set(headerWWWAuthenticate, tokenTypeDPoP+` error="`+errorCode+`"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set(http_utils.ContentType, "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	err := json.NewEncoder(w).Encode(map[string]string{
+		"error":             errorCode,
+		"error_description": description,
+	})
+	logging.OnError(err).Debug("unable to write dpop error response")
+}
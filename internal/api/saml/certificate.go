@@ -24,10 +24,9 @@ const (
 	signingKey = "signing_key"
 	samlUser   = "SAML"
 
-	retryBackoff   = 500 * time.Millisecond
-	retryCount     = 3
-	lockDuration   = retryCount * retryBackoff * 5
-	gracefulPeriod = 10 * time.Minute
+	retryBackoff = 500 * time.Millisecond
+	retryCount   = 3
+	lockDuration = retryCount * retryBackoff * 5
 )
 
 type CertificateAndKey struct {
@@ -68,13 +67,14 @@ func (p *Storage) GetCertificateAndKey(ctx context.Context, usage domain.KeyUsag
 }
 
 func (p *Storage) getCertificateAndKey(ctx context.Context, usage domain.KeyUsage) (*key.CertificateAndKey, error) {
-	certs, err := p.query.ActiveCertificates(ctx, time.Now().Add(gracefulPeriod), usage)
+	policy, err := p.query.KeyRotationPolicy(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(certs.Certificates) > 0 {
-		return p.certificateToCertificateAndKey(selectCertificate(certs.Certificates))
+	now := time.Now()
+	certs, err := p.query.ActiveCertificates(ctx, now, usage)
+	if err != nil {
+		return nil, err
 	}
 
 	var sequence uint64
@@ -82,7 +82,17 @@ func (p *Storage) getCertificateAndKey(ctx context.Context, usage domain.KeyUsag
 		sequence = certs.LatestSequence.Sequence
 	}
 
-	return nil, p.refreshCertificate(ctx, usage, sequence)
+	if len(certs.Certificates) == 0 {
+		return nil, p.refreshCertificate(ctx, usage, sequence)
+	}
+
+	prePublicationPeriod := policy.KeyPrePublicationPeriod()
+	if !hasSuccessor(certs.Certificates, now.Add(prePublicationPeriod)) {
+		// generate the successor while the current certificate is still valid
+		err = p.refreshCertificate(ctx, usage, sequence)
+		logging.OnError(err).Warn("could not create successor certificate")
+	}
+	return p.certificateToCertificateAndKey(selectCertificate(certs.Certificates, prePublicationPeriod, now))
 }
 
 func (p *Storage) refreshCertificate(
@@ -167,6 +177,7 @@ func (p *Storage) certificateToCertificateAndKey(certificate query.Certificate)
 	if err != nil {
 		return nil, err
 	}
+	// SAML certificates are always RSA keys, as the SAML library does not support other signature methods
 	privateKey, err := crypto.BytesToPrivateKey(keyData)
 	if err != nil {
 		return nil, err
@@ -183,8 +194,35 @@ func (p *Storage) certificateToCertificateAndKey(certificate query.Certificate)
 	}, nil
 }
 
-func selectCertificate(certs []query.Certificate) query.Certificate {
-	return certs[len(certs)-1]
+// selectCertificate returns the newest certificate, which has been published for the pre-publication period.
+// If there is none (e.g. after the first certificate was generated or all others were revoked), the newest one is used.
+func selectCertificate(certs []query.Certificate, prePublicationPeriod time.Duration, now time.Time) query.Certificate {
+	var newest, published query.Certificate
+	for _, cert := range certs {
+		if newest == nil || cert.CreationDate().After(newest.CreationDate()) {
+			newest = cert
+		}
+		if cert.CreationDate().Add(prePublicationPeriod).After(now) {
+			continue
+		}
+		if published == nil || cert.CreationDate().After(published.CreationDate()) {
+			published = cert
+		}
+	}
+	if published != nil {
+		return published
+	}
+	return newest
+}
+
+// hasSuccessor checks if the private key of any certificate can still be used for signing after the provided time
+func hasSuccessor(certs []query.Certificate, t time.Time) bool {
+	for _, cert := range certs {
+		if cert.PrivateKeyExpiry().After(t) {
+			return true
+		}
+	}
+	return false
 }
 
 func setSAMLCtx(ctx context.Context) context.Context {
@@ -0,0 +1,63 @@
+package saml
+
+import (
+	"context"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider/models"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// runPreSAMLResponseActions runs the actions of the pre SAML response creation trigger,
+// which are able to overwrite the attributes of the user in the response
+func (p *Storage) runPreSAMLResponseActions(ctx context.Context, user *query.User, userinfo models.AttributeSetter) error {
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
+					return func(goja.FunctionCall) goja.Value {
+						resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
+						if err != nil {
+							logging.WithError(err).Debug("unable to create search query")
+							panic(err)
+						}
+						metadata, err := p.query.SearchUserMetadata(
+							ctx,
+							true,
+							user.ID,
+							&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
+							false,
+						)
+						if err != nil {
+							logging.WithError(err).Info("unable to get md in action")
+							panic(err)
+						}
+						return object.UserMetadataListFromQuery(c, metadata)
+					}
+				}),
+			),
+		),
+	)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("attributes",
+				actions.SetFields("setEmail", userinfo.SetEmail),
+				actions.SetFields("setFullName", userinfo.SetFullName),
+				actions.SetFields("setGivenName", userinfo.SetGivenName),
+				actions.SetFields("setSurname", userinfo.SetSurname),
+				actions.SetFields("setUsername", userinfo.SetUsername),
+			),
+		),
+	)
+	return actions.RunTrigger(ctx, p.query.GetActiveActionsByFlowAndTriggerType, domain.FlowTypeCustomizeSAMLResponse, domain.TriggerTypePreSAMLResponseCreation, user.ResourceOwner, ctxFields, apiFields)
+}
@@ -133,7 +133,7 @@ func (p *Storage) SetUserinfoWithUserID(ctx context.Context, userinfo models.Att
 	}
 
 	setUserinfo(user, userinfo, attributes)
-	return nil
+	return p.runPreSAMLResponseActions(ctx, user, userinfo)
 }
 
 func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.AttributeSetter, loginName string, attributes []int) (err error) {
@@ -150,7 +150,7 @@ func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.
 	}
 
 	setUserinfo(user, userinfo, attributes)
-	return nil
+	return p.runPreSAMLResponseActions(ctx, user, userinfo)
 }
 
 func setUserinfo(user *query.User, userinfo models.AttributeSetter, attributes []int) {
@@ -151,6 +151,8 @@ const (
 	authMethodOTP          authMethod = "OTP"
 	authMethodU2F          authMethod = "U2F"
 	authMethodPasswordless authMethod = "passwordless"
+	authMethodMagicLink    authMethod = "magicLink"
+	authMethodRecoveryCode authMethod = "recoveryCode"
 )
 
 func (l *Login) runPostInternalAuthenticationActions(
@@ -0,0 +1,100 @@
+package login
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	tmplExternalLinkPrompt = "externallinkprompt"
+)
+
+type externalLinkPromptData struct {
+	baseData
+	IDPName            string
+	LinkingLoginName   string
+	LinkingDisplayName string
+}
+
+type externalLinkPromptFormData struct {
+	Link bool `schema:"linkbutton"`
+}
+
+// autoLinkingUser searches the existing user the external user can be linked to,
+// based on the auto linking option of the provider.
+// It returns nil if the option is not set or no unique user was found.
+func (l *Login) autoLinkingUser(ctx context.Context, authReq *domain.AuthRequest, provider *query.IDPTemplate, externalUser *domain.ExternalUser) (*query.User, error) {
+	if provider.AutoLinking == domain.AutoLinkingOptionUnspecified {
+		return nil, nil
+	}
+	user, err := l.query.UserForAutoLinking(ctx, provider.ID, externalUser.Email, externalUser.IsEmailVerified, authReq.RequestedOrgID)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// renderExternalLinkPrompt renders a page, where the user can confirm to link the external user to the existing user
+// (by authenticating with its credentials) or choose the other options (link manually or register)
+func (l *Login) renderExternalLinkPrompt(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, provider *query.IDPTemplate, linkingUser *query.User, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := externalLinkPromptData{
+		baseData:         l.getBaseData(r, authReq, "ExternalLinkPrompt.Title", "ExternalLinkPrompt.Description", errID, errMessage),
+		IDPName:          domain.IDPName(provider.Name, provider.Type),
+		LinkingLoginName: linkingUser.PreferredLoginName,
+	}
+	if linkingUser.Human != nil {
+		data.LinkingDisplayName = linkingUser.Human.DisplayName
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplExternalLinkPrompt], data, nil)
+}
+
+// handleExternalLinkPromptCheck takes the decision of the user on the external link prompt page
+// and either selects the existing user for the linking or shows the external not found options
+func (l *Login) handleExternalLinkPromptCheck(w http.ResponseWriter, r *http.Request) {
+	data := new(externalLinkPromptFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil || len(authReq.LinkingUsers) == 0 {
+		l.renderError(w, r, authReq, errors.ThrowPreconditionFailed(nil, "LOGIN-Hx7bq", "Errors.ExternalIDP.NoExternalUserData"))
+		return
+	}
+	if !data.Link {
+		l.renderExternalNotFoundOption(w, r, authReq, nil, nil, nil, nil)
+		return
+	}
+	provider, err := l.getIDPByID(r, authReq.SelectedIDPConfigID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	// the user is searched again, so it cannot be changed by the user agent
+	linkingUser, err := l.autoLinkingUser(r.Context(), authReq, provider, authReq.LinkingUsers[len(authReq.LinkingUsers)-1])
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if linkingUser == nil {
+		l.renderExternalNotFoundOption(w, r, authReq, nil, nil, nil, nil)
+		return
+	}
+	err = l.authRepo.SelectUser(r.Context(), authReq.ID, linkingUser.ID, authReq.AgentID)
+	if err != nil {
+		l.renderExternalLinkPrompt(w, r, authReq, provider, linkingUser, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
@@ -306,7 +306,31 @@ func (l *Login) handleExternalUserAuthenticated(
 	}
 	// if action is done and no user linked then link or register
 	if errors.IsNotFound(externalErr) {
-		l.externalUserNotExisting(w, r, authReq, provider, externalUser, externalUserChange)
+		linkingUser, err := l.autoLinkingUser(r.Context(), authReq, provider, externalUser)
+		if err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+		if linkingUser == nil {
+			l.externalUserNotExisting(w, r, authReq, provider, externalUser, externalUserChange)
+			return
+		}
+		if externalUserChange {
+			if err = l.authRepo.SetLinkingUser(r.Context(), authReq, externalUser); err != nil {
+				l.renderError(w, r, authReq, err)
+				return
+			}
+		}
+		if provider.AutoLinkingMode != domain.AutoLinkingModeAutomatic {
+			l.renderExternalLinkPrompt(w, r, authReq, provider, linkingUser, nil)
+			return
+		}
+		// the external user is linked after the user authenticated as the selected user
+		if err = l.authRepo.SelectUser(r.Context(), authReq.ID, linkingUser.ID, authReq.AgentID); err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+		l.renderNextStep(w, r, authReq)
 		return
 	}
 	if provider.IsAutoUpdate || externalUserChange {
@@ -327,6 +351,7 @@ func (l *Login) handleExternalUserAuthenticated(
 }
 
 // externalUserNotExisting is called if an externalAuthentication couldn't find a corresponding externalID
+// and no existing user was found by the auto linking option of the provider
 // possible solutions are:
 //
 // * auto creation
@@ -0,0 +1,72 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplMagicLinkSent = "magiclinksent"
+)
+
+type magicLinkFormData struct {
+	Code   string `schema:"code"`
+	CodeID string `schema:"codeID"`
+	UserID string `schema:"userID"`
+	OrgID  string `schema:"orgID"`
+}
+
+// handleMagicLinkSend sends a magic link to the verified email address of the user of the auth request.
+func (l *Login) handleMagicLinkSend(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderError(w, r, nil, caos_errs.ThrowInvalidArgument(nil, "LOGIN-oox4A", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	err = l.authRepo.SendMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, authReq.AgentID)
+	l.renderMagicLinkSent(w, r, authReq, err)
+}
+
+func (l *Login) renderMagicLinkSent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := l.getUserData(r, authReq, "MagicLinkSent.Title", "MagicLinkSent.Description", errID, errMessage)
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMagicLinkSent], data, nil)
+}
+
+// handleMagicLink verifies the code of a magic link opened by the user.
+// The link can only be used in the browser, which requested it during the login.
+func (l *Login) handleMagicLink(w http.ResponseWriter, r *http.Request) {
+	data := new(magicLinkFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderError(w, r, nil, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Eis9u", "Errors.User.MagicLink.NoAuthRequest"))
+		return
+	}
+	err = l.authRepo.VerifyMagicLink(setContext(r.Context(), data.OrgID), data.UserID, data.OrgID, authReq.ID, authReq.AgentID, data.CodeID, data.Code, domain.BrowserInfoFromRequest(r))
+
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodMagicLink, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
+
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
@@ -3,7 +3,10 @@ package login
 import (
 	"net/http"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
 )
 
 const (
@@ -18,5 +21,20 @@ func (l *Login) renderMFAInitDone(w http.ResponseWriter, r *http.Request, authRe
 	translator := l.getTranslator(r.Context(), authReq)
 	data.baseData = l.getBaseData(r, authReq, "InitMFADone.Title", "InitMFADone.Description", errType, errMessage)
 	data.profileData = l.getProfileData(authReq)
+	data.RecoveryCodes = l.addRecoveryCodes(r, authReq)
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAInitDone], data, nil)
 }
+
+// addRecoveryCodes generates the recovery codes with the first second factor of the user,
+// so they can be displayed once
+func (l *Login) addRecoveryCodes(r *http.Request, authReq *domain.AuthRequest) []string {
+	codes, err := l.command.AddHumanRecoveryCodes(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
+	if errors.IsErrorAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		logging.WithFields("authReq", authReq.ID, "user", authReq.UserID).WithError(err).Error("unable to generate recovery codes")
+		return nil
+	}
+	return codes.Codes
+}
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	tmplMFAVerify = "mfaverify"
+	tmplMFAVerify             = "mfaverify"
+	tmplMFAVerifyRecoveryCode = "mfaverifyrecoverycode"
 )
 
 type mfaVerifyFormData struct {
@@ -33,31 +34,43 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, nil)
 		return
 	}
-	if data.MFAType == domain.MFATypeOTP {
+	switch data.MFAType {
+	case domain.MFATypeOTP:
 		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 		err = l.authRepo.VerifyMFAOTP(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
-
-		metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodOTP, err)
-		if err == nil && actionErr == nil && len(metadata) > 0 {
-			_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
-		} else if actionErr != nil && err == nil {
-			err = actionErr
-		}
-
+		err = l.handleMFAVerifyActions(r, authReq, authMethodOTP, err)
 		if err != nil {
 			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeOTP, err)
 			return
 		}
+	case domain.MFATypeRecoveryCode:
+		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+		err = l.authRepo.VerifyMFARecoveryCode(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+		err = l.handleMFAVerifyActions(r, authReq, authMethodRecoveryCode, err)
+		if err != nil {
+			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeRecoveryCode, err)
+			return
+		}
 	}
 	l.renderNextStep(w, r, authReq)
 }
 
+func (l *Login) handleMFAVerifyActions(r *http.Request, authReq *domain.AuthRequest, method authMethod, err error) error {
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, method, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
+	return err
+}
+
 func (l *Login) renderMFAVerify(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, verificationStep *domain.MFAVerificationStep, err error) {
 	if verificationStep == nil {
 		l.renderError(w, r, authReq, err)
 		return
 	}
-	provider := verificationStep.MFAProviders[len(verificationStep.MFAProviders)-1]
+	provider := defaultMFAProvider(verificationStep.MFAProviders)
 	l.renderMFAVerifySelected(w, r, authReq, verificationStep, provider, err)
 }
 
@@ -84,6 +97,13 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 		data.SelectedMFAProvider = domain.MFATypeOTP
 		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTP.Title")
 		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTP.Description")
+	case domain.MFATypeRecoveryCode:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeRecoveryCode)
+		data.SelectedMFAProvider = domain.MFATypeRecoveryCode
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Description")
+		l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerifyRecoveryCode], data, nil)
+		return
 	default:
 		l.renderError(w, r, authReq, err)
 		return
@@ -91,6 +111,17 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerify], data, nil)
 }
 
+// defaultMFAProvider returns the last provider of the list,
+// recovery codes are only used if explicitly selected by the user
+func defaultMFAProvider(providers []domain.MFAType) domain.MFAType {
+	for i := len(providers) - 1; i >= 0; i-- {
+		if providers[i] != domain.MFATypeRecoveryCode {
+			return providers[i]
+		}
+	}
+	return providers[len(providers)-1]
+}
+
 func removeSelectedProviderFromList(providers []domain.MFAType, selected domain.MFAType) []domain.MFAType {
 	for i := len(providers) - 1; i >= 0; i-- {
 		if providers[i] == selected {
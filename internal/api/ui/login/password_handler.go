@@ -27,6 +27,9 @@ func (l *Login) renderPassword(w http.ResponseWriter, r *http.Request, authReq *
 			}
 			return true
 		},
+		"showMagicLink": func() bool {
+			return authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowEmailMagicLink
+		},
 	}
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplPassword], data, funcs)
 }
@@ -3,6 +3,8 @@ package login
 import (
 	"net/http"
 
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
@@ -34,3 +36,21 @@ func (l *Login) getLabelPolicy(r *http.Request, orgID string) (*query.LabelPolic
 	}
 	return l.query.ActiveLabelPolicyByOrg(r.Context(), orgID, false)
 }
+
+// getUserSchemaPolicy returns the user schema of the organisation or nil if neither the organisation nor the instance defines one
+func (l *Login) getUserSchemaPolicy(r *http.Request, orgID string) (*domain.UserSchemaPolicy, error) {
+	var policy *query.UserSchemaPolicy
+	var err error
+	if orgID == "" {
+		policy, err = l.query.DefaultUserSchemaPolicy(r.Context(), false)
+	} else {
+		policy, err = l.query.UserSchemaPolicyByOrg(r.Context(), false, orgID, false)
+	}
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy.ToDomain(), nil
+}
@@ -2,6 +2,8 @@ package login
 
 import (
 	"net/http"
+	"sort"
+	"strings"
 
 	"golang.org/x/text/language"
 
@@ -13,6 +15,8 @@ import (
 
 const (
 	tmplRegister = "register"
+
+	registerAttributePrefix = "attribute-"
 )
 
 type registerFormData struct {
@@ -24,6 +28,16 @@ type registerFormData struct {
 	Password     string              `schema:"register-password"`
 	Password2    string              `schema:"register-password-confirmation"`
 	TermsConfirm bool                `schema:"terms-confirm"`
+	// Attributes are the values of the user schema attributes, they're parsed by parseRegisterAttributes
+	Attributes map[string]string `schema:"-"`
+}
+
+type registerAttribute struct {
+	Name      string
+	Label     string
+	InputType string
+	Required  bool
+	Value     string
 }
 
 type registerData struct {
@@ -37,6 +51,7 @@ type registerData struct {
 	ShowUsername       bool
 	ShowUsernameSuffix bool
 	OrgRegister        bool
+	Attributes         []*registerAttribute
 }
 
 func (l *Login) handleRegister(w http.ResponseWriter, r *http.Request) {
@@ -67,6 +82,12 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 	if authRequest != nil && authRequest.RequestedOrgID != "" && authRequest.RequestedOrgID != resourceOwner {
 		resourceOwner = authRequest.RequestedOrgID
 	}
+	schema, err := l.getUserSchemaPolicy(r, resourceOwner)
+	if err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
+	data.parseRegisterAttributes(r, schema)
 	initCodeGenerator, err := l.query.InitEncryptionGenerator(r.Context(), domain.SecretGeneratorTypeInitCode, l.userCodeAlg)
 	if err != nil {
 		l.renderRegister(w, r, authRequest, data, err)
@@ -97,7 +118,7 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err = l.command.RegisterHuman(setContext(r.Context(), resourceOwner), resourceOwner, user, nil, nil, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator)
+	user, err = l.command.RegisterHuman(setContext(r.Context(), resourceOwner), resourceOwner, user, nil, nil, data.attributesToMetadata(), initCodeGenerator, emailCodeGenerator, phoneCodeGenerator)
 	if err != nil {
 		l.renderRegister(w, r, authRequest, data, err)
 		return
@@ -196,6 +217,13 @@ func (l *Login) renderRegister(w http.ResponseWriter, r *http.Request, authReque
 	}
 	data.ShowUsernameSuffix = !labelPolicy.HideLoginNameSuffix
 
+	schema, err := l.getUserSchemaPolicy(r, resourceOwner)
+	if err != nil {
+		l.renderRegister(w, r, authRequest, formData, err)
+		return
+	}
+	data.Attributes = registerAttributes(schema, formData.Attributes)
+
 	funcs := map[string]interface{}{
 		"selectedLanguage": func(l string) bool {
 			if formData == nil {
@@ -226,3 +254,72 @@ func (d registerFormData) toHumanDomain() *domain.Human {
 		},
 	}
 }
+
+// parseRegisterAttributes reads the values of the attributes of the user schema, which are visible to the user
+func (d *registerFormData) parseRegisterAttributes(r *http.Request, schema *domain.UserSchemaPolicy) {
+	if schema == nil {
+		return
+	}
+	d.Attributes = make(map[string]string, len(schema.Attributes))
+	for _, attribute := range schema.Attributes {
+		if attribute.Visibility != domain.UserSchemaAttributeVisibilitySelf {
+			continue
+		}
+		value := strings.TrimSpace(r.PostFormValue(registerAttributePrefix + attribute.Name))
+		if value == "" {
+			continue
+		}
+		d.Attributes[attribute.Name] = value
+	}
+}
+
+func (d registerFormData) attributesToMetadata() []*domain.Metadata {
+	metadata := make([]*domain.Metadata, 0, len(d.Attributes))
+	for name, value := range d.Attributes {
+		metadata = append(metadata, &domain.Metadata{
+			Key:   name,
+			Value: []byte(value),
+		})
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].Key < metadata[j].Key
+	})
+	return metadata
+}
+
+func registerAttributes(schema *domain.UserSchemaPolicy, values map[string]string) []*registerAttribute {
+	if schema == nil {
+		return nil
+	}
+	attributes := make([]*registerAttribute, 0, len(schema.Attributes))
+	for _, attribute := range schema.Attributes {
+		if attribute.Visibility != domain.UserSchemaAttributeVisibilitySelf {
+			continue
+		}
+		label := attribute.DisplayName
+		if label == "" {
+			label = attribute.Name
+		}
+		attributes = append(attributes, &registerAttribute{
+			Name:      registerAttributePrefix + attribute.Name,
+			Label:     label,
+			InputType: registerAttributeInputType(attribute.Type),
+			Required:  attribute.Required,
+			Value:     values[attribute.Name],
+		})
+	}
+	return attributes
+}
+
+func registerAttributeInputType(attributeType domain.UserSchemaAttributeType) string {
+	switch attributeType {
+	case domain.UserSchemaAttributeTypeNumber:
+		return "number"
+	case domain.UserSchemaAttributeTypeBoolean:
+		return "checkbox"
+	case domain.UserSchemaAttributeTypeDate:
+		return "date"
+	default:
+		return "text"
+	}
+}
@@ -55,6 +55,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplPasswordlessRegistrationDone: "passwordless_registration_done.html",
 		tmplPasswordlessPrompt:           "passwordless_prompt.html",
 		tmplMFAVerify:                    "mfa_verify_otp.html",
+		tmplMFAVerifyRecoveryCode:        "mfa_verify_recovery_code.html",
 		tmplMFAPrompt:                    "mfa_prompt.html",
 		tmplMFAInitVerify:                "mfa_init_otp.html",
 		tmplMFAU2FInit:                   "mfa_init_u2f.html",
@@ -67,6 +68,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplInitUser:                     "init_user.html",
 		tmplInitUserDone:                 "init_user_done.html",
 		tmplPasswordResetDone:            "password_reset_done.html",
+		tmplMagicLinkSent:                "magic_link_sent.html",
 		tmplChangePassword:               "change_password.html",
 		tmplChangePasswordDone:           "change_password_done.html",
 		tmplRegisterOption:               "register_option.html",
@@ -77,6 +79,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplChangeUsernameDone:           "change_username_done.html",
 		tmplLinkUsersDone:                "link_users_done.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
+		tmplExternalLinkPrompt:           "external_link_prompt.html",
 		tmplLoginSuccess:                 "login_success.html",
 		tmplLDAPLogin:                    "ldap_login.html",
 		tmplDeviceAuthUserCode:           "device_usercode.html",
@@ -155,6 +158,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"passwordResetUrl": func(id string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointPasswordReset, QueryAuthRequestID, id))
 		},
+		"magicLinkSendUrl": func(id string) string {
+			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointMagicLinkSend, QueryAuthRequestID, id))
+		},
 		"passwordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPassword)
 		},
@@ -203,6 +209,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"externalNotFoundOptionUrl": func(action string) string {
 			return path.Join(r.pathPrefix, EndpointExternalNotFoundOption+"?"+action+"=true")
 		},
+		"externalLinkPromptUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointExternalLinkPrompt)
+		},
 		"selectedLanguage": func(l string) bool {
 			return false
 		},
@@ -679,7 +688,8 @@ type mfaVerifyData struct {
 type mfaDoneData struct {
 	baseData
 	profileData
-	MFAType domain.MFAType
+	MFAType       domain.MFAType
+	RecoveryCodes []string
 }
 
 type otpData struct {
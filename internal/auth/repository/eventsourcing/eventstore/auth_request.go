@@ -51,6 +51,9 @@ type AuthRequestRepo struct {
 	ProjectProvider           projectProvider
 	ApplicationProvider       applicationProvider
 
+	// RiskEvaluator assesses the risk of logins, if the login policy requires it (disabled if nil)
+	RiskEvaluator *RiskEvaluator
+
 	IdGenerator id.Generator
 }
 
@@ -371,6 +374,16 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
 }
 
+func (repo *AuthRequestRepo) VerifyMFARecoveryCode(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckRecoveryCode(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -446,6 +459,27 @@ func (repo *AuthRequestRepo) VerifyPasswordless(ctx context.Context, userID, res
 	return repo.Command.HumanFinishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, request)
 }
 
+func (repo *AuthRequestRepo) SendMagicLink(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	_, err = repo.Command.AddUserMagicLinkCodeForAuthRequest(ctx, userID, resourceOwner, request.ID, repo.UserCodeAlg)
+	return err
+}
+
+func (repo *AuthRequestRepo) VerifyMagicLink(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, codeID, code string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.CheckUserMagicLinkCode(ctx, userID, resourceOwner, codeID, code, request.WithCurrentInfo(info), repo.UserCodeAlg)
+}
+
 func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -503,7 +537,7 @@ func (repo *AuthRequestRepo) AutoRegisterExternalUser(ctx context.Context, regis
 	if err != nil {
 		return err
 	}
-	human, err := repo.Command.RegisterHuman(ctx, resourceOwner, registerUser, externalIDP, orgMemberRoles, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator)
+	human, err := repo.Command.RegisterHuman(ctx, resourceOwner, registerUser, externalIDP, orgMemberRoles, nil, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator)
 	if err != nil {
 		return err
 	}
@@ -855,6 +889,9 @@ func queryLoginPolicyToDomain(policy *query.LoginPolicy) *domain.LoginPolicy {
 		MultiFactorCheckLifetime:   policy.MultiFactorCheckLifetime,
 		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
 		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		AllowEmailMagicLink:        policy.AllowEmailMagicLink,
+		RiskStepUpThreshold:        policy.RiskStepUpThreshold,
+		RiskBlockThreshold:         policy.RiskBlockThreshold,
 	}
 }
 
@@ -974,7 +1011,12 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		}
 	}
 
-	step, ok, err := repo.mfaChecked(userSession, request, user)
+	stepUp, err := repo.riskChecked(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok, err := repo.mfaChecked(userSession, request, user, stepUp)
 	if err != nil {
 		return nil, err
 	}
@@ -1087,19 +1129,53 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 		request.AuthTime = userSession.PasswordVerification
 		return nil
 	}
+	if request.LoginPolicy.AllowEmailMagicLink && checkVerificationTimeMaxAge(userSession.MagicLinkVerification, request.LoginPolicy.PasswordCheckLifetime, request) {
+		request.AuthTime = userSession.MagicLinkVerification
+		return nil
+	}
 	if step != nil {
 		return step
 	}
 	return &domain.PasswordStep{}
 }
 
-func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView) (domain.NextStep, bool, error) {
+// riskChecked evaluates the risk of the login (once per user of the auth request), if the login policy requires it.
+// It returns an error if the login is blocked and whether a second factor is required (step-up).
+func (repo *AuthRequestRepo) riskChecked(ctx context.Context, request *domain.AuthRequest) (bool, error) {
+	if repo.RiskEvaluator == nil || !request.LoginPolicy.RiskEvaluationEnabled() {
+		return false, nil
+	}
+	if request.RiskAssessment == nil || request.RiskAssessment.UserID != request.UserID {
+		assessment, err := repo.RiskEvaluator.Evaluate(ctx, request)
+		if err != nil {
+			return false, err
+		}
+		request.RiskAssessment = assessment
+		if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+			return false, err
+		}
+	}
+	switch request.LoginPolicy.RiskDecision(request.RiskAssessment.Score) {
+	case domain.RiskDecisionBlock:
+		return false, errors.ThrowPermissionDenied(nil, "EVENT-Gx3pR", "Errors.User.RiskTooHigh")
+	case domain.RiskDecisionStepUp:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView, stepUp bool) (domain.NextStep, bool, error) {
 	mfaLevel := request.MFALevel()
 	allowedProviders, required := user.MFATypesAllowed(mfaLevel, request.LoginPolicy)
+	required = required || stepUp
 	promptRequired := (user.MFAMaxSetUp < mfaLevel) || (len(allowedProviders) == 0 && required)
 	if promptRequired || !repo.mfaSkippedOrSetUp(user, request) {
 		types := user.MFATypesSetupPossible(mfaLevel, request.LoginPolicy)
 		if promptRequired && len(types) == 0 {
+			if stepUp {
+				return nil, false, errors.ThrowPermissionDenied(nil, "LOGIN-Wk4vd", "Errors.User.RiskTooHigh")
+			}
 			return nil, false, errors.ThrowPreconditionFailed(nil, "LOGIN-5Hm8s", "Errors.Login.LoginPolicy.MFA.ForceAndNotConfigured")
 		}
 		if len(types) == 0 {
@@ -1119,14 +1195,16 @@ func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView,
 		}
 		fallthrough
 	case domain.MFALevelSecondFactor:
-		if checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) {
+		if checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) &&
+			stepUpVerified(userSession.SecondFactorVerification, request, stepUp) {
 			request.MFAsVerified = append(request.MFAsVerified, userSession.SecondFactorVerificationType)
 			request.AuthTime = userSession.SecondFactorVerification
 			return nil, true, nil
 		}
 		fallthrough
 	case domain.MFALevelMultiFactor:
-		if checkVerificationTimeMaxAge(userSession.MultiFactorVerification, request.LoginPolicy.MultiFactorCheckLifetime, request) {
+		if checkVerificationTimeMaxAge(userSession.MultiFactorVerification, request.LoginPolicy.MultiFactorCheckLifetime, request) &&
+			stepUpVerified(userSession.MultiFactorVerification, request, stepUp) {
 			request.MFAsVerified = append(request.MFAsVerified, userSession.MultiFactorVerificationType)
 			request.AuthTime = userSession.MultiFactorVerification
 			return nil, true, nil
@@ -1137,6 +1215,12 @@ func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView,
 	}, false, nil
 }
 
+// stepUpVerified checks that a required step-up was verified during the current auth request,
+// so a factor verified in an earlier login does not count
+func stepUpVerified(verification time.Time, request *domain.AuthRequest, stepUp bool) bool {
+	return !stepUp || verification.After(request.CreationDate)
+}
+
 func (repo *AuthRequestRepo) mfaSkippedOrSetUp(user *user_model.UserView, request *domain.AuthRequest) bool {
 	if user.MFAMaxSetUp > domain.MFALevelNotSetUp {
 		return true
@@ -1352,7 +1436,9 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 			user_repo.HumanPasswordlessTokenCheckSucceededType,
 			user_repo.HumanPasswordlessTokenCheckFailedType,
 			user_repo.HumanU2FTokenCheckSucceededType,
-			user_repo.HumanU2FTokenCheckFailedType:
+			user_repo.HumanU2FTokenCheckFailedType,
+			user_repo.HumanMagicLinkCodeCheckSucceededType,
+			user_repo.HumanMagicLinkCodeCheckFailedType:
 			eventData, err := user_view_model.UserSessionFromEvent(event)
 			if err != nil {
 				logging.WithFields("traceID", tracing.TraceIDFromCtx(ctx)).WithError(err).Debug("error getting event data")
@@ -0,0 +1,277 @@
+package eventstore
+
+import (
+	"context"
+	"math"
+	"net"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// travels shorter than minTravelDistance (km) are ignored,
+	// because the location of networks is not precise
+	minTravelDistance = 100
+	earthRadius       = 6371
+)
+
+// RiskConfig configures the risk evaluation of auth requests.
+// The evaluation is only done if the login policy defines a step-up or block threshold.
+type RiskConfig struct {
+	// HistoryLifetime defines how long previous authentications are considered to recognise known user agents and networks
+	HistoryLifetime time.Duration
+	// FailedAttemptsLifetime defines how long failed authentication attempts are considered
+	FailedAttemptsLifetime time.Duration
+	NewUserAgentScore      uint32
+	NewNetworkScore        uint32
+	ImpossibleTravelScore  uint32
+	// FailedAttemptScore is added for every failed attempt, but at most MaxFailedAttemptsScore
+	FailedAttemptScore     uint32
+	MaxFailedAttemptsScore uint32
+	// MaxTravelSpeed (km/h) between the locations of two authentications, faster travels are considered impossible
+	MaxTravelSpeed float64
+	// NetworkDatabases are CSV files mapping networks to their location and autonomous system (e.g. GeoLite2 City and ASN blocks)
+	NetworkDatabases []string
+	// AccessLogs enables counting the failed requests of the remote ip in the access logs,
+	// which requires the access logs to be stored in the database
+	AccessLogs bool
+}
+
+type userAuthenticationChecksProvider interface {
+	UserAuthenticationChecks(ctx context.Context, userID string, since time.Time) ([]*query.UserAuthenticationCheck, error)
+}
+
+type failedRequestsProvider interface {
+	QueryFailedRequests(ctx context.Context, instanceID, remoteIP string, start time.Time) (uint64, error)
+}
+
+// RiskEvaluator assesses the risk of the authentication of a user
+// based on the previous authentications and the failed attempts
+type RiskEvaluator struct {
+	config        RiskConfig
+	networks      *NetworkDatabase
+	checks        userAuthenticationChecksProvider
+	accessLogs    failedRequestsProvider
+	activeActions actions.ActiveActionsQuery
+}
+
+func NewRiskEvaluator(config RiskConfig, checks userAuthenticationChecksProvider, dbClient *database.DB, activeActions actions.ActiveActionsQuery) (*RiskEvaluator, error) {
+	networks, err := LoadNetworkDatabases(config.NetworkDatabases...)
+	if err != nil {
+		return nil, err
+	}
+	evaluator := &RiskEvaluator{
+		config:        config,
+		networks:      networks,
+		checks:        checks,
+		activeActions: activeActions,
+	}
+	if config.AccessLogs {
+		evaluator.accessLogs = access.NewDatabaseLogStorage(dbClient)
+	}
+	return evaluator, nil
+}
+
+// Evaluate assesses the risk of the authentication of the user of the auth request
+// and runs the post risk evaluation actions, which are able to adjust the score
+func (e *RiskEvaluator) Evaluate(ctx context.Context, request *domain.AuthRequest) (_ *domain.RiskAssessment, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	now := time.Now()
+	var since time.Time
+	if e.config.HistoryLifetime > 0 {
+		since = now.Add(-e.config.HistoryLifetime)
+	}
+	checks, err := e.checks.UserAuthenticationChecks(ctx, request.UserID, since)
+	if err != nil {
+		return nil, err
+	}
+	remoteIP := requestRemoteIP(request)
+	var failedRequests uint64
+	if e.accessLogs != nil && remoteIP != nil {
+		failedRequests, err = e.accessLogs.QueryFailedRequests(ctx, request.InstanceID, remoteIP.String(), now.Add(-e.config.FailedAttemptsLifetime))
+		if err != nil {
+			return nil, err
+		}
+	}
+	assessment := e.assess(request, checks, failedRequests, now)
+	if err = e.runPostRiskEvaluationActions(ctx, request, assessment); err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+func (e *RiskEvaluator) assess(request *domain.AuthRequest, checks []*query.UserAuthenticationCheck, failedRequests uint64, now time.Time) *domain.RiskAssessment {
+	assessment := &domain.RiskAssessment{
+		UserID:         request.UserID,
+		EvaluationDate: now,
+	}
+	failedAttempts := failedRequests
+	known := make([]*query.UserAuthenticationCheck, 0, len(checks))
+	for _, check := range checks {
+		if !check.Succeeded {
+			if check.CreationDate.After(now.Add(-e.config.FailedAttemptsLifetime)) {
+				failedAttempts++
+			}
+			continue
+		}
+		// the checks of the current request are no history to compare with
+		if check.AuthRequestID != "" && check.AuthRequestID == request.ID {
+			continue
+		}
+		known = append(known, check)
+	}
+	if failedAttempts > 0 {
+		score := failedAttempts * uint64(e.config.FailedAttemptScore)
+		if e.config.MaxFailedAttemptsScore > 0 && score > uint64(e.config.MaxFailedAttemptsScore) {
+			score = uint64(e.config.MaxFailedAttemptsScore)
+		}
+		assessment.AddScore(int64(score), domain.RiskSignalFailedAttempts)
+	}
+	// without any previous authentication, there's nothing the current one could differ from
+	if len(known) == 0 {
+		return assessment
+	}
+	if !knownUserAgent(known, request.AgentID) {
+		assessment.AddScore(int64(e.config.NewUserAgentScore), domain.RiskSignalNewUserAgent)
+	}
+	remoteIP := requestRemoteIP(request)
+	if remoteIP == nil {
+		return assessment
+	}
+	network := e.networks.Lookup(remoteIP)
+	if !e.knownNetwork(known, remoteIP, network) {
+		assessment.AddScore(int64(e.config.NewNetworkScore), domain.RiskSignalNewNetwork)
+	}
+	if e.impossibleTravel(known, network, now) {
+		assessment.AddScore(int64(e.config.ImpossibleTravelScore), domain.RiskSignalImpossibleTravel)
+	}
+	return assessment
+}
+
+func knownUserAgent(checks []*query.UserAuthenticationCheck, agentID string) bool {
+	if agentID == "" {
+		return false
+	}
+	for _, check := range checks {
+		if check.UserAgentID == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// knownNetwork checks if the user authenticated from the same autonomous system (if known)
+// or the same network (/24 for IPv4 and /48 for IPv6) before
+func (e *RiskEvaluator) knownNetwork(checks []*query.UserAuthenticationCheck, ip net.IP, network *NetworkInfo) bool {
+	for _, check := range checks {
+		if check.RemoteIP == nil {
+			continue
+		}
+		if sameNetwork(ip, check.RemoteIP) {
+			return true
+		}
+		if network.ASN != 0 && e.networks.Lookup(check.RemoteIP).ASN == network.ASN {
+			return true
+		}
+	}
+	return false
+}
+
+func sameNetwork(a, b net.IP) bool {
+	if a4, b4 := a.To4(), b.To4(); a4 != nil || b4 != nil {
+		if a4 == nil || b4 == nil {
+			return false
+		}
+		mask := net.CIDRMask(24, net.IPv4len*8)
+		return a4.Mask(mask).Equal(b4.Mask(mask))
+	}
+	mask := net.CIDRMask(48, net.IPv6len*8)
+	return a.Mask(mask).Equal(b.Mask(mask))
+}
+
+// impossibleTravel checks if the distance between the location of the last located authentication
+// and the current location could have been travelled in the elapsed time
+func (e *RiskEvaluator) impossibleTravel(checks []*query.UserAuthenticationCheck, network *NetworkInfo, now time.Time) bool {
+	if !network.Located || e.config.MaxTravelSpeed <= 0 {
+		return false
+	}
+	for i := len(checks) - 1; i >= 0; i-- {
+		if checks[i].RemoteIP == nil {
+			continue
+		}
+		previous := e.networks.Lookup(checks[i].RemoteIP)
+		if !previous.Located {
+			continue
+		}
+		distance := haversineDistance(previous.Latitude, previous.Longitude, network.Latitude, network.Longitude)
+		if distance < minTravelDistance {
+			return false
+		}
+		elapsed := now.Sub(checks[i].CreationDate)
+		if elapsed < time.Minute {
+			elapsed = time.Minute
+		}
+		return distance/elapsed.Hours() > e.config.MaxTravelSpeed
+	}
+	return false
+}
+
+// haversineDistance returns the distance in km between the two coordinates
+func haversineDistance(lat1, long1, lat2, long2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	deltaLat := toRadians(lat2 - lat1)
+	deltaLong := toRadians(long2 - long1)
+	a := math.Pow(math.Sin(deltaLat/2), 2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Pow(math.Sin(deltaLong/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func requestRemoteIP(request *domain.AuthRequest) net.IP {
+	if request.BrowserInfo == nil {
+		return nil
+	}
+	return request.BrowserInfo.RemoteIP
+}
+
+func (e *RiskEvaluator) runPostRiskEvaluationActions(ctx context.Context, request *domain.AuthRequest, assessment *domain.RiskAssessment) error {
+	resourceOwner := request.RequestedOrgID
+	if resourceOwner == "" {
+		resourceOwner = request.UserOrgID
+	}
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("risk",
+				actions.SetFields("score", func(c *actions.FieldConfig) interface{} {
+					return assessment.Score
+				}),
+				actions.SetFields("signals", func(c *actions.FieldConfig) interface{} {
+					signals := make([]string, len(assessment.Signals))
+					for i, signal := range assessment.Signals {
+						signals[i] = string(signal)
+					}
+					return signals
+				}),
+			),
+			actions.SetFields("authRequest", object.AuthRequestField(request)),
+		),
+	)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("risk",
+				actions.SetFields("addScore", func(score int64, signal string) {
+					assessment.AddScore(score, domain.RiskSignal(signal))
+				}),
+			),
+		),
+	)
+	return actions.RunTrigger(ctx, e.activeActions, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostRiskEvaluation, resourceOwner, ctxFields, apiFields)
+}
@@ -0,0 +1,193 @@
+package eventstore
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const testNetworks = `network,latitude,longitude,autonomous_system_number
+10.0.0.0/8,47.37,8.54,64500
+10.1.0.0/16,40.71,-74.00,64501
+192.168.0.0/16,47.50,8.70,64500
+2001:db8::/32,52.52,13.40,64502
+`
+
+func testNetworkDatabase(t *testing.T) *NetworkDatabase {
+	table, err := readNetworkTable(strings.NewReader(testNetworks))
+	require.NoError(t, err)
+	return &NetworkDatabase{tables: []*networkTable{table}}
+}
+
+func TestNetworkDatabase_Lookup(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   net.IP
+		want *NetworkInfo
+	}{
+		{
+			name: "unknown",
+			ip:   net.ParseIP("172.16.0.1"),
+			want: &NetworkInfo{},
+		},
+		{
+			name: "ipv4",
+			ip:   net.ParseIP("10.2.3.4"),
+			want: &NetworkInfo{Located: true, Latitude: 47.37, Longitude: 8.54, ASN: 64500},
+		},
+		{
+			name: "most specific network",
+			ip:   net.ParseIP("10.1.3.4"),
+			want: &NetworkInfo{Located: true, Latitude: 40.71, Longitude: -74.00, ASN: 64501},
+		},
+		{
+			name: "ipv6",
+			ip:   net.ParseIP("2001:db8::1"),
+			want: &NetworkInfo{Located: true, Latitude: 52.52, Longitude: 13.40, ASN: 64502},
+		},
+	}
+	db := testNetworkDatabase(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, db.Lookup(tt.ip))
+		})
+	}
+}
+
+func TestRiskEvaluator_assess(t *testing.T) {
+	now := time.Now()
+	config := RiskConfig{
+		FailedAttemptsLifetime: 24 * time.Hour,
+		NewUserAgentScore:      20,
+		NewNetworkScore:        20,
+		ImpossibleTravelScore:  50,
+		FailedAttemptScore:     10,
+		MaxFailedAttemptsScore: 30,
+		MaxTravelSpeed:         1000,
+	}
+	request := func(agentID, ip string) *domain.AuthRequest {
+		return &domain.AuthRequest{
+			ID:          "request",
+			AgentID:     agentID,
+			UserID:      "user",
+			BrowserInfo: &domain.BrowserInfo{RemoteIP: net.ParseIP(ip)},
+		}
+	}
+	type args struct {
+		request        *domain.AuthRequest
+		checks         []*query.UserAuthenticationCheck
+		failedRequests uint64
+	}
+	tests := []struct {
+		name string
+		args args
+		want *domain.RiskAssessment
+	}{
+		{
+			name: "no history",
+			args: args{
+				request: request("agent", "10.2.3.4"),
+			},
+			want: &domain.RiskAssessment{UserID: "user", EvaluationDate: now},
+		},
+		{
+			name: "only checks of current request",
+			args: args{
+				request: request("agent", "10.2.3.4"),
+				checks: []*query.UserAuthenticationCheck{
+					{CreationDate: now, Succeeded: true, AuthRequestID: "request", UserAgentID: "agent", RemoteIP: net.ParseIP("10.2.3.4")},
+				},
+			},
+			want: &domain.RiskAssessment{UserID: "user", EvaluationDate: now},
+		},
+		{
+			name: "known user agent and network",
+			args: args{
+				request: request("agent", "10.2.3.4"),
+				checks: []*query.UserAuthenticationCheck{
+					{CreationDate: now.Add(-time.Hour), Succeeded: true, UserAgentID: "agent", RemoteIP: net.ParseIP("10.2.3.5")},
+				},
+			},
+			want: &domain.RiskAssessment{UserID: "user", EvaluationDate: now},
+		},
+		{
+			name: "new user agent, same autonomous system",
+			args: args{
+				request: request("new", "192.168.1.1"),
+				checks: []*query.UserAuthenticationCheck{
+					{CreationDate: now.Add(-time.Hour), Succeeded: true, UserAgentID: "agent", RemoteIP: net.ParseIP("10.2.3.5")},
+				},
+			},
+			want: &domain.RiskAssessment{
+				UserID:         "user",
+				Score:          20,
+				Signals:        []domain.RiskSignal{domain.RiskSignalNewUserAgent},
+				EvaluationDate: now,
+			},
+		},
+		{
+			name: "new network, impossible travel",
+			args: args{
+				request: request("agent", "10.1.3.4"),
+				checks: []*query.UserAuthenticationCheck{
+					{CreationDate: now.Add(-time.Hour), Succeeded: true, UserAgentID: "agent", RemoteIP: net.ParseIP("10.2.3.5")},
+				},
+			},
+			want: &domain.RiskAssessment{
+				UserID:         "user",
+				Score:          70,
+				Signals:        []domain.RiskSignal{domain.RiskSignalNewNetwork, domain.RiskSignalImpossibleTravel},
+				EvaluationDate: now,
+			},
+		},
+		{
+			name: "new network, possible travel",
+			args: args{
+				request: request("agent", "10.1.3.4"),
+				checks: []*query.UserAuthenticationCheck{
+					{CreationDate: now.Add(-24 * time.Hour), Succeeded: true, UserAgentID: "agent", RemoteIP: net.ParseIP("10.2.3.5")},
+				},
+			},
+			want: &domain.RiskAssessment{
+				UserID:         "user",
+				Score:          20,
+				Signals:        []domain.RiskSignal{domain.RiskSignalNewNetwork},
+				EvaluationDate: now,
+			},
+		},
+		{
+			name: "failed attempts, limited",
+			args: args{
+				request: request("agent", "10.2.3.4"),
+				checks: []*query.UserAuthenticationCheck{
+					{CreationDate: now.Add(-48 * time.Hour), Succeeded: false},
+					{CreationDate: now.Add(-time.Hour), Succeeded: false},
+					{CreationDate: now.Add(-time.Minute), Succeeded: false, AuthRequestID: "request"},
+				},
+				failedRequests: 2,
+			},
+			want: &domain.RiskAssessment{
+				UserID:         "user",
+				Score:          30,
+				Signals:        []domain.RiskSignal{domain.RiskSignalFailedAttempts},
+				EvaluationDate: now,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &RiskEvaluator{
+				config:   config,
+				networks: testNetworkDatabase(t),
+			}
+			assert.Equal(t, tt.want, e.assess(tt.args.request, tt.args.checks, tt.args.failedRequests, now))
+		})
+	}
+}
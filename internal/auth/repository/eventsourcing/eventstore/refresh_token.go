@@ -41,6 +41,17 @@ func (r *RefreshTokenRepo) RefreshTokenByToken(ctx context.Context, refreshToken
 	return tokenView, nil
 }
 
+// RefreshTokenFamilyByToken returns the refresh token the provided token belongs to,
+// even if the provided token was already rotated.
+// The token itself is checked on renewal, where the reuse of a rotated token revokes the whole family.
+func (r *RefreshTokenRepo) RefreshTokenFamilyByToken(ctx context.Context, refreshToken string) (*usr_model.RefreshTokenView, error) {
+	userID, tokenID, _, err := domain.FromRefreshToken(refreshToken, r.KeyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	return r.RefreshTokenByID(ctx, tokenID, userID)
+}
+
 func (r *RefreshTokenRepo) RefreshTokenByID(ctx context.Context, tokenID, userID string) (*usr_model.RefreshTokenView, error) {
 	instanceID := authz.GetInstance(ctx).InstanceID()
 	tokenView, viewErr := r.View.RefreshTokenByID(tokenID, instanceID)
@@ -0,0 +1,170 @@
+package eventstore
+
+import (
+	"encoding/csv"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	networkColumn          = "network"
+	latitudeColumn         = "latitude"
+	longitudeColumn        = "longitude"
+	autonomousSystemColumn = "autonomous_system_number"
+)
+
+// NetworkInfo is the known location and autonomous system of a network
+type NetworkInfo struct {
+	Located   bool
+	Latitude  float64
+	Longitude float64
+	ASN       uint32
+}
+
+// NetworkDatabase maps ip addresses to the information about their networks.
+// The information of all loaded tables is merged, so a location table (e.g. GeoLite2 City blocks)
+// can be combined with an autonomous system table (e.g. GeoLite2 ASN blocks)
+type NetworkDatabase struct {
+	tables []*networkTable
+}
+
+type networkTable struct {
+	prefixesV4 []int
+	prefixesV6 []int
+	networks   map[string]*NetworkInfo
+}
+
+// LoadNetworkDatabases reads the CSV files of the paths.
+// Each file requires a header row with a `network` column (CIDR notation)
+// and optional `latitude`, `longitude` and `autonomous_system_number` columns.
+func LoadNetworkDatabases(paths ...string) (*NetworkDatabase, error) {
+	db := &NetworkDatabase{tables: make([]*networkTable, 0, len(paths))}
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "EVENT-p8Zsn", "unable to open network database")
+		}
+		table, err := readNetworkTable(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		db.tables = append(db.tables, table)
+	}
+	return db, nil
+}
+
+func readNetworkTable(r io.Reader) (*networkTable, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "EVENT-Vq4xe", "unable to read header of network database")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+	networkIdx, ok := columns[networkColumn]
+	if !ok {
+		return nil, errors.ThrowInternal(nil, "EVENT-bS7ka", "network database has no network column")
+	}
+	latitudeIdx, hasLatitude := columns[latitudeColumn]
+	longitudeIdx, hasLongitude := columns[longitudeColumn]
+	asnIdx, hasASN := columns[autonomousSystemColumn]
+
+	table := &networkTable{networks: make(map[string]*NetworkInfo)}
+	prefixesV4 := make(map[int]struct{})
+	prefixesV6 := make(map[int]struct{})
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "EVENT-n2Lrw", "unable to read network database")
+		}
+		_, network, err := net.ParseCIDR(record[networkIdx])
+		if err != nil {
+			continue
+		}
+		info := new(NetworkInfo)
+		if hasLatitude && hasLongitude {
+			latitude, latErr := strconv.ParseFloat(record[latitudeIdx], 64)
+			longitude, longErr := strconv.ParseFloat(record[longitudeIdx], 64)
+			if latErr == nil && longErr == nil {
+				info.Located = true
+				info.Latitude = latitude
+				info.Longitude = longitude
+			}
+		}
+		if hasASN {
+			if asn, err := strconv.ParseUint(record[asnIdx], 10, 32); err == nil {
+				info.ASN = uint32(asn)
+			}
+		}
+		ones, bits := network.Mask.Size()
+		if bits == net.IPv4len*8 {
+			prefixesV4[ones] = struct{}{}
+		} else {
+			prefixesV6[ones] = struct{}{}
+		}
+		table.networks[network.String()] = info
+	}
+	table.prefixesV4 = sortedPrefixes(prefixesV4)
+	table.prefixesV6 = sortedPrefixes(prefixesV6)
+	return table, nil
+}
+
+// sortedPrefixes returns the prefix lengths from the longest to the shortest
+func sortedPrefixes(prefixes map[int]struct{}) []int {
+	sorted := make([]int, 0, len(prefixes))
+	for prefix := range prefixes {
+		sorted = append(sorted, prefix)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	return sorted
+}
+
+// Lookup returns the merged information of the most specific networks containing the ip
+func (db *NetworkDatabase) Lookup(ip net.IP) *NetworkInfo {
+	info := new(NetworkInfo)
+	if db == nil || ip == nil {
+		return info
+	}
+	for _, table := range db.tables {
+		found := table.lookup(ip)
+		if found == nil {
+			continue
+		}
+		if !info.Located && found.Located {
+			info.Located = true
+			info.Latitude = found.Latitude
+			info.Longitude = found.Longitude
+		}
+		if info.ASN == 0 {
+			info.ASN = found.ASN
+		}
+	}
+	return info
+}
+
+func (t *networkTable) lookup(ip net.IP) *NetworkInfo {
+	prefixes, bits := t.prefixesV6, net.IPv6len*8
+	if ipV4 := ip.To4(); ipV4 != nil {
+		ip, prefixes, bits = ipV4, t.prefixesV4, net.IPv4len*8
+	}
+	for _, prefix := range prefixes {
+		mask := net.CIDRMask(prefix, bits)
+		network := &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+		if info, ok := t.networks[network.String()]; ok {
+			return info
+		}
+	}
+	return nil
+}
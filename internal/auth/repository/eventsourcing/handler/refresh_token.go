@@ -102,7 +102,8 @@ func (t *RefreshToken) Reduce(event *es_models.Event) (err error) {
 			return err
 		}
 		return t.view.PutRefreshToken(token, event)
-	case user.HumanRefreshTokenRemovedType:
+	case user.HumanRefreshTokenRemovedType,
+		user.HumanRefreshTokenReuseDetectedType:
 		e := new(user.HumanRefreshTokenRemovedEvent)
 		if err := json.Unmarshal(event.Data, e); err != nil {
 			logging.WithError(err).Error("could not unmarshal event data")
@@ -126,7 +126,8 @@ func (t *Token) Reduce(event *es_models.Event) (err error) {
 			return err
 		}
 		return t.view.DeleteToken(id, event.InstanceID, event)
-	case user_repo.HumanRefreshTokenRemovedType:
+	case user_repo.HumanRefreshTokenRemovedType,
+		user_repo.HumanRefreshTokenReuseDetectedType:
 		id, err := refreshTokenIDFromRemovedEvent(event)
 		if err != nil {
 			return err
@@ -84,6 +84,19 @@ func (u *UserSession) EventQuery(ctx context.Context, instanceIDs []string) (*mo
 func (u *UserSession) Reduce(event *models.Event) (err error) {
 	var session *view_model.UserSessionView
 	switch eventstore.EventType(event.Type) {
+	case user.HumanMagicLinkCodeCheckSucceededType,
+		user.HumanMagicLinkCodeCheckFailedType,
+		user.HumanRecoveryCodeCheckSucceededType,
+		user.HumanRecoveryCodeCheckFailedType:
+		// magic links and recovery codes checked through the session API are not bound to a user agent
+		eventData, err := view_model.UserSessionFromEvent(event)
+		if err != nil {
+			return err
+		}
+		if eventData.UserAgentID == "" {
+			return u.view.ProcessedUserSessionSequence(event)
+		}
+		fallthrough
 	case user.UserV1PasswordCheckSucceededType,
 		user.UserV1PasswordCheckFailedType,
 		user.UserV1MFAOTPCheckSucceededType,
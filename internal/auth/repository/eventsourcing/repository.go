@@ -21,6 +21,7 @@ import (
 type Config struct {
 	SearchLimit uint64
 	Spooler     spooler.SpoolerConfig
+	Risk        eventstore.RiskConfig
 }
 
 type EsRepository struct {
@@ -35,7 +36,7 @@ type EsRepository struct {
 }
 
 func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, command *command.Commands, queries *query.Queries, dbClient *database.DB, esV2 *eventstore2.Eventstore, oidcEncryption crypto.EncryptionAlgorithm, userEncryption crypto.EncryptionAlgorithm, allowOrderByCreationDate bool) (*EsRepository, error) {
-	es, err := v1.Start(dbClient, allowOrderByCreationDate)
+	es, err := v1.Start(dbClient, allowOrderByCreationDate, esV2.InterceptV1Filter)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +58,10 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 		Query:          queries,
 		SystemDefaults: systemDefaults,
 	}
+	riskEvaluator, err := eventstore.NewRiskEvaluator(conf.Risk, queries, dbClient, queries.GetActiveActionsByFlowAndTriggerType)
+	if err != nil {
+		return nil, err
+	}
 	//TODO: remove as soon as possible
 	queryView := queryViewWrapper{
 		queries,
@@ -87,6 +92,7 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
 			ApplicationProvider:       queries,
+			RiskEvaluator:             riskEvaluator,
 			IdGenerator:               idGenerator,
 		},
 		eventstore.TokenRepo{
@@ -9,5 +9,6 @@ import (
 type RefreshTokenRepository interface {
 	RefreshTokenByID(ctx context.Context, tokenID, userID string) (*model.RefreshTokenView, error)
 	RefreshTokenByToken(ctx context.Context, refreshToken string) (*model.RefreshTokenView, error)
+	RefreshTokenFamilyByToken(ctx context.Context, refreshToken string) (*model.RefreshTokenView, error)
 	SearchMyRefreshTokens(ctx context.Context, userID string, request *model.RefreshTokenSearchRequest) (*model.RefreshTokenSearchResponse, error)
 }
@@ -101,6 +101,10 @@ func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenStrin
 	if !token.Expiration.After(time.Now().UTC()) {
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-k9KS0", "invalid token")
 	}
+	// sender-constrained tokens can only be used with a proof of the bound key
+	if err = token.Confirmation().Verify(authz.TokenConfirmationFromCtx(ctx)); err != nil {
+		return "", "", "", "", "", err
+	}
 	if token.IsPAT {
 		return token.UserID, "", "", "", token.ResourceOwner, nil
 	}
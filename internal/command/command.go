@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	api_http "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/command/preparation"
@@ -18,11 +19,13 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/userbulkjob"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/static"
 	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
@@ -46,6 +49,7 @@ type Commands struct {
 	smtpEncryption              crypto.EncryptionAlgorithm
 	smsEncryption               crypto.EncryptionAlgorithm
 	userEncryption              crypto.EncryptionAlgorithm
+	actionSecretEncryption      crypto.EncryptionAlgorithm
 	userPasswordAlg             crypto.HashAlgorithm
 	machineKeySize              int
 	applicationKeySize          int
@@ -64,6 +68,9 @@ type Commands struct {
 	privateKeyLifetime   time.Duration
 	publicKeyLifetime    time.Duration
 	certificateLifetime  time.Duration
+
+	activeActions actions.ActiveActionsQuery
+	actionStorage sd.ActionStorage
 }
 
 func StartCommands(
@@ -75,10 +82,11 @@ func StartCommands(
 	externalDomain string,
 	externalSecure bool,
 	externalPort uint16,
-	idpConfigEncryption, otpEncryption, smtpEncryption, smsEncryption, userEncryption, domainVerificationEncryption, oidcEncryption, samlEncryption crypto.EncryptionAlgorithm,
+	idpConfigEncryption, otpEncryption, smtpEncryption, smsEncryption, userEncryption, domainVerificationEncryption, oidcEncryption, samlEncryption, actionSecretEncryption crypto.EncryptionAlgorithm,
 	httpClient *http.Client,
 	permissionCheck domain.PermissionCheck,
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error),
+	activeActions actions.ActiveActionsQuery,
 ) (repo *Commands, err error) {
 	if externalDomain == "" {
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Df21s", "no external domain specified")
@@ -87,31 +95,34 @@ func StartCommands(
 	// reuse the oidcEncryption to be able to handle both tokens in the interceptor later on
 	sessionAlg := oidcEncryption
 	repo = &Commands{
-		eventstore:            es,
-		static:                staticStore,
-		idGenerator:           idGenerator,
-		zitadelRoles:          zitadelRoles,
-		externalDomain:        externalDomain,
-		externalSecure:        externalSecure,
-		externalPort:          externalPort,
-		keySize:               defaults.KeyConfig.Size,
-		certKeySize:           defaults.KeyConfig.CertificateSize,
-		privateKeyLifetime:    defaults.KeyConfig.PrivateKeyLifetime,
-		publicKeyLifetime:     defaults.KeyConfig.PublicKeyLifetime,
-		certificateLifetime:   defaults.KeyConfig.CertificateLifetime,
-		idpConfigEncryption:   idpConfigEncryption,
-		smtpEncryption:        smtpEncryption,
-		smsEncryption:         smsEncryption,
-		userEncryption:        userEncryption,
-		domainVerificationAlg: domainVerificationEncryption,
-		keyAlgorithm:          oidcEncryption,
-		certificateAlgorithm:  samlEncryption,
-		webauthnConfig:        webAuthN,
-		httpClient:            httpClient,
-		checkPermission:       permissionCheck,
-		newCode:               newCryptoCodeWithExpiry,
-		sessionTokenCreator:   sessionTokenCreator(idGenerator, sessionAlg),
-		sessionTokenVerifier:  sessionTokenVerifier,
+		eventstore:             es,
+		static:                 staticStore,
+		idGenerator:            idGenerator,
+		zitadelRoles:           zitadelRoles,
+		externalDomain:         externalDomain,
+		externalSecure:         externalSecure,
+		externalPort:           externalPort,
+		keySize:                defaults.KeyConfig.Size,
+		certKeySize:            defaults.KeyConfig.CertificateSize,
+		privateKeyLifetime:     defaults.KeyConfig.PrivateKeyLifetime,
+		publicKeyLifetime:      defaults.KeyConfig.PublicKeyLifetime,
+		certificateLifetime:    defaults.KeyConfig.CertificateLifetime,
+		idpConfigEncryption:    idpConfigEncryption,
+		smtpEncryption:         smtpEncryption,
+		smsEncryption:          smsEncryption,
+		userEncryption:         userEncryption,
+		actionSecretEncryption: actionSecretEncryption,
+		domainVerificationAlg:  domainVerificationEncryption,
+		keyAlgorithm:           oidcEncryption,
+		certificateAlgorithm:   samlEncryption,
+		webauthnConfig:         webAuthN,
+		httpClient:             httpClient,
+		checkPermission:        permissionCheck,
+		newCode:                newCryptoCodeWithExpiry,
+		sessionTokenCreator:    sessionTokenCreator(idGenerator, sessionAlg),
+		sessionTokenVerifier:   sessionTokenVerifier,
+		activeActions:          activeActions,
+		actionStorage:          defaults.ActionStorage,
 	}
 
 	instance_repo.RegisterEventMappers(repo.eventstore)
@@ -122,8 +133,10 @@ func StartCommands(
 	keypair.RegisterEventMappers(repo.eventstore)
 	action.RegisterEventMappers(repo.eventstore)
 	quota.RegisterEventMappers(repo.eventstore)
+	notification.RegisterEventMappers(repo.eventstore)
 	session.RegisterEventMappers(repo.eventstore)
 	idpintent.RegisterEventMappers(repo.eventstore)
+	userbulkjob.RegisterEventMappers(repo.eventstore)
 
 	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
@@ -10,6 +10,18 @@ import (
 	"github.com/zitadel/zitadel/internal/errors"
 )
 
+// defaultSecretGeneratorConfigs are used for generator types,
+// which were introduced after the instance was set up and are therefore not configured yet
+var defaultSecretGeneratorConfigs = map[domain.SecretGeneratorType]crypto.GeneratorConfig{
+	domain.SecretGeneratorTypeMagicLinkCode: {
+		Length:              32,
+		Expiry:              10 * time.Minute,
+		IncludeLowerLetters: true,
+		IncludeUpperLetters: true,
+		IncludeDigits:       true,
+	},
+}
+
 type cryptoCodeFunc func(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.Crypto) (*CryptoCodeWithExpiry, error)
 
 type CryptoCodeWithExpiry struct {
@@ -75,6 +87,11 @@ func secretGeneratorConfig(ctx context.Context, filter preparation.FilterToQuery
 	if err := wm.Reduce(); err != nil {
 		return nil, err
 	}
+	if wm.State != domain.SecretGeneratorStateActive {
+		if config, ok := defaultSecretGeneratorConfigs[typ]; ok {
+			return &config, nil
+		}
+	}
 	return &crypto.GeneratorConfig{
 		Length:              wm.Length,
 		Expiry:              wm.Expiry,
@@ -0,0 +1,237 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpmail"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+// AddEmailHTTPConfig adds an inactive transactional email API of the instance,
+// the api key is encrypted with the same key as the SMTP passwords
+func (c *Commands) AddEmailHTTPConfig(ctx context.Context, instanceID string, config *httpmail.Config) (string, *domain.ObjectDetails, error) {
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel, err := c.getEmailHTTPConfig(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiKey *crypto.CryptoValue
+	if config.APIKey != "" {
+		apiKey, err = crypto.Encrypt([]byte(config.APIKey), c.smtpEncryption)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailHTTPConfigAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Preset,
+		config.Endpoint,
+		config.ContentType,
+		config.BodyTemplate,
+		config.AuthHeader,
+		apiKey,
+		config.SuccessStatusCodes,
+		config.SuccessBodyContains,
+		config.From,
+		config.FromName,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ChangeEmailHTTPConfig changes all settings of the transactional email API except the api key
+func (c *Commands) ChangeEmailHTTPConfig(ctx context.Context, instanceID, id string, config *httpmail.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "EMAIL-Hc3nf", "Errors.IDMissing")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.getEmailHTTPConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hn4fq", "Errors.EmailHTTPConfig.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+
+	changedEvent, hasChanged, err := writeModel.NewChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Preset,
+		config.Endpoint,
+		config.ContentType,
+		config.BodyTemplate,
+		config.AuthHeader,
+		config.SuccessStatusCodes,
+		config.SuccessBodyContains,
+		config.From,
+		config.FromName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hp9ch", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeEmailHTTPConfigAPIKey(ctx context.Context, instanceID, id, apiKey string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "EMAIL-Hk2ay", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailHTTPConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hk8nf", "Errors.EmailHTTPConfig.NotFound")
+	}
+	var encryptedKey *crypto.CryptoValue
+	if apiKey != "" {
+		encryptedKey, err = crypto.Encrypt([]byte(apiKey), c.smtpEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailHTTPConfigAPIKeyChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		encryptedKey))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ActivateEmailHTTPConfig activates the transactional email API,
+// the active APIs are used for sending the emails before the SMTP configurations
+func (c *Commands) ActivateEmailHTTPConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "EMAIL-Ha5vt", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailHTTPConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ha7nf", "Errors.EmailHTTPConfig.NotFound")
+	}
+	if writeModel.State == domain.EmailHTTPConfigStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ha2ac", "Errors.EmailHTTPConfig.AlreadyActive")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailHTTPConfigActivatedEvent(
+		ctx,
+		iamAgg,
+		id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) DeactivateEmailHTTPConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "EMAIL-Hd3vt", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailHTTPConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hd8nf", "Errors.EmailHTTPConfig.NotFound")
+	}
+	if writeModel.State == domain.EmailHTTPConfigStateInactive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hd4ia", "Errors.EmailHTTPConfig.AlreadyDeactivated")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailHTTPConfigDeactivatedEvent(
+		ctx,
+		iamAgg,
+		id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveEmailHTTPConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "EMAIL-Hr6mv", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailHTTPConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hr2nf", "Errors.EmailHTTPConfig.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailHTTPConfigRemovedEvent(
+		ctx,
+		iamAgg,
+		id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) getEmailHTTPConfig(ctx context.Context, instanceID, id string) (_ *InstanceEmailHTTPConfigWriteModel, err error) {
+	writeModel := NewInstanceEmailHTTPConfigWriteModel(instanceID, id)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceEmailHTTPConfigWriteModel struct {
+	eventstore.WriteModel
+
+	ID                  string
+	Preset              domain.EmailHTTPPreset
+	Endpoint            string
+	ContentType         string
+	BodyTemplate        string
+	AuthHeader          string
+	APIKey              *crypto.CryptoValue
+	SuccessStatusCodes  []int32
+	SuccessBodyContains string
+	SenderAddress       string
+	SenderName          string
+	State               domain.EmailHTTPConfigState
+}
+
+func NewInstanceEmailHTTPConfigWriteModel(instanceID, id string) *InstanceEmailHTTPConfigWriteModel {
+	return &InstanceEmailHTTPConfigWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   instanceID,
+			ResourceOwner: instanceID,
+		},
+		ID: id,
+	}
+}
+
+func (wm *InstanceEmailHTTPConfigWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.EmailHTTPConfigAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Preset = e.Preset
+			wm.Endpoint = e.Endpoint
+			wm.ContentType = e.ContentType
+			wm.BodyTemplate = e.BodyTemplate
+			wm.AuthHeader = e.AuthHeader
+			wm.APIKey = e.APIKey
+			wm.SuccessStatusCodes = e.SuccessStatusCodes
+			wm.SuccessBodyContains = e.SuccessBodyContains
+			wm.SenderAddress = e.SenderAddress
+			wm.SenderName = e.SenderName
+			wm.State = domain.EmailHTTPConfigStateInactive
+		case *instance.EmailHTTPConfigChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.Preset != nil {
+				wm.Preset = *e.Preset
+			}
+			if e.Endpoint != nil {
+				wm.Endpoint = *e.Endpoint
+			}
+			if e.ContentType != nil {
+				wm.ContentType = *e.ContentType
+			}
+			if e.BodyTemplate != nil {
+				wm.BodyTemplate = *e.BodyTemplate
+			}
+			if e.AuthHeader != nil {
+				wm.AuthHeader = *e.AuthHeader
+			}
+			if e.SuccessStatusCodes != nil {
+				wm.SuccessStatusCodes = *e.SuccessStatusCodes
+			}
+			if e.SuccessBodyContains != nil {
+				wm.SuccessBodyContains = *e.SuccessBodyContains
+			}
+			if e.SenderAddress != nil {
+				wm.SenderAddress = *e.SenderAddress
+			}
+			if e.SenderName != nil {
+				wm.SenderName = *e.SenderName
+			}
+		case *instance.EmailHTTPConfigAPIKeyChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.APIKey = e.APIKey
+		case *instance.EmailHTTPConfigActivatedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.State = domain.EmailHTTPConfigStateActive
+		case *instance.EmailHTTPConfigDeactivatedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.State = domain.EmailHTTPConfigStateInactive
+		case *instance.EmailHTTPConfigRemovedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.APIKey = nil
+			wm.State = domain.EmailHTTPConfigStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceEmailHTTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.EmailHTTPConfigAddedEventType,
+			instance.EmailHTTPConfigChangedEventType,
+			instance.EmailHTTPConfigAPIKeyChangedEventType,
+			instance.EmailHTTPConfigActivatedEventType,
+			instance.EmailHTTPConfigDeactivatedEventType,
+			instance.EmailHTTPConfigRemovedEventType).
+		Builder()
+}
+
+func (wm *InstanceEmailHTTPConfigWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	preset domain.EmailHTTPPreset,
+	endpoint,
+	contentType,
+	bodyTemplate,
+	authHeader string,
+	successStatusCodes []int32,
+	successBodyContains,
+	senderAddress,
+	senderName string,
+) (*instance.EmailHTTPConfigChangedEvent, bool, error) {
+	changes := make([]instance.EmailHTTPConfigChanges, 0)
+	var err error
+
+	if wm.Preset != preset {
+		changes = append(changes, instance.ChangeEmailHTTPConfigPreset(preset))
+	}
+	if wm.Endpoint != endpoint {
+		changes = append(changes, instance.ChangeEmailHTTPConfigEndpoint(endpoint))
+	}
+	if wm.ContentType != contentType {
+		changes = append(changes, instance.ChangeEmailHTTPConfigContentType(contentType))
+	}
+	if wm.BodyTemplate != bodyTemplate {
+		changes = append(changes, instance.ChangeEmailHTTPConfigBodyTemplate(bodyTemplate))
+	}
+	if wm.AuthHeader != authHeader {
+		changes = append(changes, instance.ChangeEmailHTTPConfigAuthHeader(authHeader))
+	}
+	if (len(wm.SuccessStatusCodes) > 0 || len(successStatusCodes) > 0) && !reflect.DeepEqual(wm.SuccessStatusCodes, successStatusCodes) {
+		changes = append(changes, instance.ChangeEmailHTTPConfigSuccessStatusCodes(successStatusCodes))
+	}
+	if wm.SuccessBodyContains != successBodyContains {
+		changes = append(changes, instance.ChangeEmailHTTPConfigSuccessBodyContains(successBodyContains))
+	}
+	if wm.SenderAddress != senderAddress {
+		changes = append(changes, instance.ChangeEmailHTTPConfigSenderAddress(senderAddress))
+	}
+	if wm.SenderName != senderName {
+		changes = append(changes, instance.ChangeEmailHTTPConfigSenderName(senderName))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewEmailHTTPConfigChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
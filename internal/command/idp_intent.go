@@ -106,7 +106,7 @@ func getIDPIntentWriteModel(ctx context.Context, writeModel *IDPIntentWriteModel
 	return writeModel.Reduce()
 }
 
-func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWriteModel, idpUser idp.User, idpSession idp.Session, userID string) (string, error) {
+func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWriteModel, idpUser idp.User, idpSession idp.Session, userID, linkingUserID string) (string, error) {
 	token, err := c.idpConfigEncryption.Encrypt([]byte(writeModel.AggregateID))
 	if err != nil {
 		return "", err
@@ -126,6 +126,7 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 		idpUser.GetID(),
 		idpUser.GetPreferredUsername(),
 		userID,
+		linkingUserID,
 		accessToken,
 		idToken,
 	)
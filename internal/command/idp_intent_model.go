@@ -21,6 +21,7 @@ type IDPIntentWriteModel struct {
 	IDPAccessToken *crypto.CryptoValue
 	IDPIDToken     string
 	UserID         string
+	LinkingUserID  string
 
 	State     domain.IDPIntentState
 	aggregate *eventstore.Aggregate
@@ -73,6 +74,7 @@ func (wm *IDPIntentWriteModel) reduceStartedEvent(e *idpintent.StartedEvent) {
 
 func (wm *IDPIntentWriteModel) reduceSucceededEvent(e *idpintent.SucceededEvent) {
 	wm.UserID = e.UserID
+	wm.LinkingUserID = e.LinkingUserID
 	wm.IDPUser = e.IDPUser
 	wm.IDPUserID = e.IDPUserID
 	wm.IDPUserName = e.IDPUserName
@@ -368,11 +368,12 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 		idpConfigEncryption crypto.EncryptionAlgorithm
 	}
 	type args struct {
-		ctx        context.Context
-		writeModel *IDPIntentWriteModel
-		idpUser    idp.User
-		idpSession idp.Session
-		userID     string
+		ctx           context.Context
+		writeModel    *IDPIntentWriteModel
+		idpUser       idp.User
+		idpSession    idp.Session
+		userID        string
+		linkingUserID string
 	}
 	type res struct {
 		token string
@@ -443,6 +444,7 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 									"id",
 									"username",
 									"",
+									"",
 									&crypto.CryptoValue{
 										CryptoType: crypto.TypeEncryption,
 										Algorithm:  "enc",
@@ -486,7 +488,7 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 				eventstore:          tt.fields.eventstore,
 				idpConfigEncryption: tt.fields.idpConfigEncryption,
 			}
-			got, err := c.SucceedIDPIntent(tt.args.ctx, tt.args.writeModel, tt.args.idpUser, tt.args.idpSession, tt.args.userID)
+			got, err := c.SucceedIDPIntent(tt.args.ctx, tt.args.writeModel, tt.args.idpUser, tt.args.idpSession, tt.args.userID, tt.args.linkingUserID)
 			require.ErrorIs(t, err, tt.res.err)
 			assert.Equal(t, tt.res.token, got)
 		})
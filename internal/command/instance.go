@@ -47,6 +47,7 @@ type InstanceSetup struct {
 		PhoneVerificationCode    *crypto.GeneratorConfig
 		PasswordVerificationCode *crypto.GeneratorConfig
 		PasswordlessInitCode     *crypto.GeneratorConfig
+		MagicLinkCode            *crypto.GeneratorConfig
 		DomainVerification       *crypto.GeneratorConfig
 	}
 	PasswordComplexityPolicy struct {
@@ -75,6 +76,9 @@ type InstanceSetup struct {
 		AllowDomainDiscovery       bool
 		DisableLoginWithEmail      bool
 		DisableLoginWithPhone      bool
+		AllowEmailMagicLink        bool
+		RiskStepUpThreshold        uint32
+		RiskBlockThreshold         uint32
 		PasswordlessType           domain.PasswordlessType
 		DefaultRedirectURI         string
 		PasswordCheckLifetime      time.Duration
@@ -83,10 +87,8 @@ type InstanceSetup struct {
 		SecondFactorCheckLifetime  time.Duration
 		MultiFactorCheckLifetime   time.Duration
 	}
-	NotificationPolicy struct {
-		PasswordChange bool
-	}
-	PrivacyPolicy struct {
+	NotificationPolicy NotificationPolicy
+	PrivacyPolicy      struct {
 		TOSLink      string
 		PrivacyLink  string
 		HelpLink     string
@@ -199,6 +201,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeVerifyPhoneCode, setup.SecretGenerators.PhoneVerificationCode),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypePasswordResetCode, setup.SecretGenerators.PasswordVerificationCode),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypePasswordlessInitCode, setup.SecretGenerators.PasswordlessInitCode),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeMagicLinkCode, setup.SecretGenerators.MagicLinkCode),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeVerifyDomain, setup.SecretGenerators.DomainVerification),
 
 		prepareAddDefaultPasswordComplexityPolicy(
@@ -231,6 +234,9 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.LoginPolicy.AllowDomainDiscovery,
 			setup.LoginPolicy.DisableLoginWithEmail,
 			setup.LoginPolicy.DisableLoginWithPhone,
+			setup.LoginPolicy.AllowEmailMagicLink,
+			setup.LoginPolicy.RiskStepUpThreshold,
+			setup.LoginPolicy.RiskBlockThreshold,
 			setup.LoginPolicy.PasswordlessType,
 			setup.LoginPolicy.DefaultRedirectURI,
 			setup.LoginPolicy.PasswordCheckLifetime,
@@ -244,7 +250,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddMultiFactorToDefaultLoginPolicy(instanceAgg, domain.MultiFactorTypeU2FWithPIN),
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
-		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
+		prepareAddDefaultNotificationPolicy(instanceAgg, &setup.NotificationPolicy),
 		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
 
 		prepareAddDefaultLabelPolicy(
@@ -398,15 +404,22 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 	}
 
 	if setup.SMTPConfiguration != nil {
+		smtpConfigID, err := c.idGenerator.Next()
+		if err != nil {
+			return "", "", nil, nil, err
+		}
 		validations = append(validations,
 			c.prepareAddSMTPConfig(
 				instanceAgg,
+				smtpConfigID,
+				0,
 				setup.SMTPConfiguration.From,
 				setup.SMTPConfiguration.FromName,
 				setup.SMTPConfiguration.SMTP.Host,
 				setup.SMTPConfiguration.SMTP.User,
 				[]byte(setup.SMTPConfiguration.SMTP.Password),
 				setup.SMTPConfiguration.Tls,
+				setup.SMTPConfiguration.SMTP.XOAuth2,
 			),
 		)
 	}
@@ -41,6 +41,11 @@ func writeModelToLoginPolicy(wm *LoginPolicyWriteModel) *domain.LoginPolicy {
 		MFAInitSkipLifetime:        wm.MFAInitSkipLifetime,
 		SecondFactorCheckLifetime:  wm.SecondFactorCheckLifetime,
 		MultiFactorCheckLifetime:   wm.MultiFactorCheckLifetime,
+		DisableLoginWithEmail:      wm.DisableLoginWithEmail,
+		DisableLoginWithPhone:      wm.DisableLoginWithPhone,
+		AllowEmailMagicLink:        wm.AllowEmailMagicLink,
+		RiskStepUpThreshold:        wm.RiskStepUpThreshold,
+		RiskBlockThreshold:         wm.RiskBlockThreshold,
 	}
 }
 
@@ -163,6 +163,8 @@ func TestCommandSide_AddInstanceDomain(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								domain.OIDCRefreshTokenRotationEnabled,
+								0,
 							),
 						),
 					),
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func (c *Commands) SetKeyRotationPolicy(ctx context.Context, policy *domain.KeyRotationPolicy) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	validation := c.prepareSetKeyRotationPolicy(instanceAgg, policy)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      events[len(events)-1].Sequence(),
+		EventDate:     events[len(events)-1].CreationDate(),
+		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
+	}, nil
+}
+
+func (c *Commands) prepareSetKeyRotationPolicy(a *instance.Aggregate, policy *domain.KeyRotationPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if policy.SigningKeyAlgorithm != "" && !crypto.IsSupportedSigningAlgorithm(policy.SigningKeyAlgorithm) {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "INST-Ua6sh", "Errors.Key.AlgorithmNotSupported")
+		}
+		if policy.SigningKeyLifetime < 0 ||
+			policy.CertificateLifetime < 0 ||
+			policy.PrePublicationPeriod < 0 ||
+			policy.RetirementGracePeriod < 0 {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "INST-Phu3e", "Errors.Key.RotationPolicy.Invalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := c.getKeyRotationPolicyWriteModel(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			cmd, err := writeModel.NewSetEvent(ctx, &a.Aggregate, policy)
+			if err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{cmd}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) getKeyRotationPolicyWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer) (_ *InstanceKeyRotationPolicyWriteModel, err error) {
+	writeModel := NewInstanceKeyRotationPolicyWriteModel(ctx)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return writeModel, nil
+	}
+	writeModel.AppendEvents(events...)
+	err = writeModel.Reduce()
+	return writeModel, err
+}
+
+// keyRotationPolicy returns the key rotation policy of the instance, where unset values are taken from the system defaults
+func (c *Commands) keyRotationPolicy(ctx context.Context) (*domain.KeyRotationPolicy, error) {
+	writeModel, err := c.getKeyRotationPolicyWriteModel(ctx, c.eventstore.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel.policy().WithDefaults(c.defaultKeyRotationPolicy()), nil
+}
+
+func (c *Commands) defaultKeyRotationPolicy() *domain.KeyRotationPolicy {
+	policy := &domain.KeyRotationPolicy{
+		SigningKeyLifetime:   c.privateKeyLifetime,
+		CertificateLifetime:  c.certificateLifetime,
+		PrePublicationPeriod: domain.DefaultKeyPrePublicationPeriod,
+	}
+	// the public key lifetime of the system defaults includes the lifetime of the private key
+	if c.publicKeyLifetime > c.privateKeyLifetime {
+		policy.RetirementGracePeriod = c.publicKeyLifetime - c.privateKeyLifetime
+	}
+	return policy
+}
@@ -0,0 +1,98 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceKeyRotationPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	SigningKeyAlgorithm   string
+	SigningKeyLifetime    time.Duration
+	CertificateLifetime   time.Duration
+	PrePublicationPeriod  time.Duration
+	RetirementGracePeriod time.Duration
+}
+
+func NewInstanceKeyRotationPolicyWriteModel(ctx context.Context) *InstanceKeyRotationPolicyWriteModel {
+	return &InstanceKeyRotationPolicyWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+	}
+}
+
+func (wm *InstanceKeyRotationPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*instance.KeyRotationPolicySetEvent); ok {
+			if e.SigningKeyAlgorithm != nil {
+				wm.SigningKeyAlgorithm = *e.SigningKeyAlgorithm
+			}
+			if e.SigningKeyLifetime != nil {
+				wm.SigningKeyLifetime = *e.SigningKeyLifetime
+			}
+			if e.CertificateLifetime != nil {
+				wm.CertificateLifetime = *e.CertificateLifetime
+			}
+			if e.PrePublicationPeriod != nil {
+				wm.PrePublicationPeriod = *e.PrePublicationPeriod
+			}
+			if e.RetirementGracePeriod != nil {
+				wm.RetirementGracePeriod = *e.RetirementGracePeriod
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceKeyRotationPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.KeyRotationPolicySetEventType).
+		Builder()
+}
+
+func (wm *InstanceKeyRotationPolicyWriteModel) NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	policy *domain.KeyRotationPolicy,
+) (*instance.KeyRotationPolicySetEvent, error) {
+	changes := make([]instance.KeyRotationPolicyChanges, 0, 5)
+	if wm.SigningKeyAlgorithm != policy.SigningKeyAlgorithm {
+		changes = append(changes, instance.ChangeKeyRotationPolicySigningKeyAlgorithm(policy.SigningKeyAlgorithm))
+	}
+	if wm.SigningKeyLifetime != policy.SigningKeyLifetime {
+		changes = append(changes, instance.ChangeKeyRotationPolicySigningKeyLifetime(policy.SigningKeyLifetime))
+	}
+	if wm.CertificateLifetime != policy.CertificateLifetime {
+		changes = append(changes, instance.ChangeKeyRotationPolicyCertificateLifetime(policy.CertificateLifetime))
+	}
+	if wm.PrePublicationPeriod != policy.PrePublicationPeriod {
+		changes = append(changes, instance.ChangeKeyRotationPolicyPrePublicationPeriod(policy.PrePublicationPeriod))
+	}
+	if wm.RetirementGracePeriod != policy.RetirementGracePeriod {
+		changes = append(changes, instance.ChangeKeyRotationPolicyRetirementGracePeriod(policy.RetirementGracePeriod))
+	}
+	return instance.NewKeyRotationPolicySetEvent(ctx, aggregate, changes)
+}
+
+func (wm *InstanceKeyRotationPolicyWriteModel) policy() *domain.KeyRotationPolicy {
+	return &domain.KeyRotationPolicy{
+		SigningKeyAlgorithm:   wm.SigningKeyAlgorithm,
+		SigningKeyLifetime:    wm.SigningKeyLifetime,
+		CertificateLifetime:   wm.CertificateLifetime,
+		PrePublicationPeriod:  wm.PrePublicationPeriod,
+		RetirementGracePeriod: wm.RetirementGracePeriod,
+	}
+}
@@ -221,6 +221,9 @@ func prepareChangeDefaultLoginPolicy(a *instance.Aggregate, policy *ChangeLoginP
 		if ok := domain.ValidateDefaultRedirectURI(policy.DefaultRedirectURI); !ok {
 			return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-SFdqd", "Errors.IAM.LoginPolicy.RedirectURIInvalid")
 		}
+		if !domain.ValidateRiskThresholds(policy.RiskStepUpThreshold, policy.RiskBlockThreshold) {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-m3Rq9", "Errors.IAM.LoginPolicy.RiskThresholdsInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			wm := NewInstanceLoginPolicyWriteModel(ctx)
 			if err := queryAndReduce(ctx, filter, wm); err != nil {
@@ -239,6 +242,9 @@ func prepareChangeDefaultLoginPolicy(a *instance.Aggregate, policy *ChangeLoginP
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowEmailMagicLink,
+				policy.RiskStepUpThreshold,
+				policy.RiskBlockThreshold,
 				policy.PasswordlessType,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
@@ -265,6 +271,9 @@ func prepareAddDefaultLoginPolicy(
 	allowDomainDiscovery bool,
 	disableLoginWithEmail bool,
 	disableLoginWithPhone bool,
+	allowEmailMagicLink bool,
+	riskStepUpThreshold uint32,
+	riskBlockThreshold uint32,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime time.Duration,
@@ -274,6 +283,9 @@ func prepareAddDefaultLoginPolicy(
 	multiFactorCheckLifetime time.Duration,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
+		if !domain.ValidateRiskThresholds(riskStepUpThreshold, riskBlockThreshold) {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Wq0vL", "Errors.IAM.LoginPolicy.RiskThresholdsInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel := NewInstanceLoginPolicyWriteModel(ctx)
 			events, err := filter(ctx, writeModel.Query())
@@ -298,6 +310,9 @@ func prepareAddDefaultLoginPolicy(
 					allowDomainDiscovery,
 					disableLoginWithEmail,
 					disableLoginWithPhone,
+					allowEmailMagicLink,
+					riskStepUpThreshold,
+					riskBlockThreshold,
 					passwordlessType,
 					defaultRedirectURI,
 					passwordCheckLifetime,
@@ -73,6 +73,9 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -129,6 +132,9 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -281,6 +287,9 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -322,6 +331,9 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -383,6 +395,9 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -526,6 +541,9 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -567,6 +585,9 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -621,6 +642,9 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -680,6 +704,9 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -747,6 +774,9 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+// SetDefaultMailTemplateMessage sets the template of the default mail template for a specific message type
+func (c *Commands) SetDefaultMailTemplateMessage(ctx context.Context, template *domain.MailMessageTemplate) (*domain.ObjectDetails, error) {
+	if !template.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Kx8fd", "Errors.IAM.MailTemplate.MessageInvalid")
+	}
+	existing := NewInstanceMailTemplateMessageWriteModel(ctx, template.MessageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.hasChanged(template) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Vb2pe", "Errors.IAM.MailTemplate.MessageNotChanged")
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&existing.MailTemplateMessageWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewMailTemplateMessageSetEvent(ctx, instanceAgg, template.MessageType, template.Subject, template.HTML, template.Text))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.MailTemplateMessageWriteModel.WriteModel), nil
+}
+
+// RemoveDefaultMailTemplateMessage removes the template of a specific message type,
+// so the default mail template will be used again
+func (c *Commands) RemoveDefaultMailTemplateMessage(ctx context.Context, messageType string) (*domain.ObjectDetails, error) {
+	if !domain.IsMailMessageType(messageType) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-p0Qsf", "Errors.IAM.MailTemplate.MessageInvalid")
+	}
+	existing := NewInstanceMailTemplateMessageWriteModel(ctx, messageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Zw6xr", "Errors.IAM.MailTemplate.MessageNotFound")
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&existing.MailTemplateMessageWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewMailTemplateMessageRemovedEvent(ctx, instanceAgg, messageType))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.MailTemplateMessageWriteModel.WriteModel), nil
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceMailTemplateMessageWriteModel struct {
+	MailTemplateMessageWriteModel
+}
+
+func NewInstanceMailTemplateMessageWriteModel(ctx context.Context, messageType string) *InstanceMailTemplateMessageWriteModel {
+	return &InstanceMailTemplateMessageWriteModel{
+		MailTemplateMessageWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+			MessageType: messageType,
+		},
+	}
+}
+
+func (wm *InstanceMailTemplateMessageWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.MailTemplateMessageSetEvent:
+			wm.MailTemplateMessageWriteModel.AppendEvents(&e.MailTemplateMessageSetEvent)
+		case *instance.MailTemplateMessageRemovedEvent:
+			wm.MailTemplateMessageWriteModel.AppendEvents(&e.MailTemplateMessageRemovedEvent)
+		}
+	}
+}
+
+func (wm *InstanceMailTemplateMessageWriteModel) Reduce() error {
+	return wm.MailTemplateMessageWriteModel.Reduce()
+}
+
+func (wm *InstanceMailTemplateMessageWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.MailTemplateMessageWriteModel.AggregateID).
+		EventTypes(
+			instance.MailTemplateMessageSetEventType,
+			instance.MailTemplateMessageRemovedEventType).
+		Builder()
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/instance"
-	"github.com/zitadel/zitadel/internal/repository/policy"
 )
 
 type InstanceNotificationPolicyWriteModel struct {
@@ -54,13 +53,9 @@ func (wm *InstanceNotificationPolicyWriteModel) Query() *eventstore.SearchQueryB
 func (wm *InstanceNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	notificationPolicy *NotificationPolicy,
 ) (*instance.NotificationPolicyChangedEvent, bool) {
-
-	changes := make([]policy.NotificationPolicyChanges, 0)
-	if wm.PasswordChange != passwordChange {
-		changes = append(changes, policy.ChangePasswordChange(passwordChange))
-	}
+	changes := wm.NotificationPolicyWriteModel.changes(notificationPolicy)
 	if len(changes) == 0 {
 		return nil, false
 	}
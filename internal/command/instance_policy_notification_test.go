@@ -19,9 +19,9 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx                context.Context
+		resourceOwner      string
+		notificationPolicy *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -43,15 +43,21 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsErrorAlreadyExists,
@@ -69,6 +75,12 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 								instance.NewNotificationPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									true,
+									false,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -76,9 +88,9 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -98,6 +110,12 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 								instance.NewNotificationPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									true,
+									false,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -105,9 +123,9 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -121,7 +139,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -140,9 +158,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx                context.Context
+		resourceOwner      string
+		notificationPolicy *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -163,9 +181,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsNotFound,
@@ -181,15 +199,21 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsPreconditionFailed,
@@ -205,6 +229,12 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -219,9 +249,63 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "change security notifications, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewNotificationPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *instance.NotificationPolicyChangedEvent {
+									event, _ := instance.NewNotificationPolicyChangedEvent(context.Background(),
+										&instance.NewAggregate("INSTANCE").Aggregate,
+										[]policy.NotificationPolicyChanges{
+											policy.ChangeNewDeviceLogin(true),
+											policy.ChangeMFAChange(true),
+											policy.ChangeAccountLocked(true),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				notificationPolicy: &NotificationPolicy{
+					PasswordChange: true,
+					NewDeviceLogin: true,
+					MFAChange:      true,
+					AccountLocked:  true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -235,7 +319,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func (c *Commands) AddDefaultUserLifecyclePolicy(ctx context.Context, resourceOwner string, lifecyclePolicy *domain.UserLifecyclePolicy) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultUserLifecyclePolicy(instanceAgg, lifecyclePolicy))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) ChangeDefaultUserLifecyclePolicy(ctx context.Context, resourceOwner string, lifecyclePolicy *domain.UserLifecyclePolicy) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultUserLifecyclePolicy(instanceAgg, lifecyclePolicy))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareAddDefaultUserLifecyclePolicy(
+	a *instance.Aggregate,
+	lifecyclePolicy *domain.UserLifecyclePolicy,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := lifecyclePolicy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstanceUserLifecyclePolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if writeModel.State == domain.PolicyStateActive {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Ulc8a", "Errors.IAM.UserLifecyclePolicy.AlreadyExists")
+			}
+			return []eventstore.Command{
+				instance.NewUserLifecyclePolicyAddedEvent(
+					ctx,
+					&a.Aggregate,
+					lifecyclePolicy.DeactivateAfterDays,
+					lifecyclePolicy.DeleteAfterDays,
+					lifecyclePolicy.WarnDaysBefore,
+					lifecyclePolicy.ExcludeMachineUsers,
+					lifecyclePolicy.ExcludedUserIDs,
+					lifecyclePolicy.DryRun,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareChangeDefaultUserLifecyclePolicy(
+	a *instance.Aggregate,
+	lifecyclePolicy *domain.UserLifecyclePolicy,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := lifecyclePolicy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstanceUserLifecyclePolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+
+			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
+				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Ulc2n", "Errors.IAM.UserLifecyclePolicy.NotFound")
+			}
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, lifecyclePolicy)
+			if !hasChanged {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Ulc7c", "Errors.IAM.UserLifecyclePolicy.NotChanged")
+			}
+			return []eventstore.Command{
+				change,
+			}, nil
+		}, nil
+	}
+}
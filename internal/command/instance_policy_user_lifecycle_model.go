@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceUserLifecyclePolicyWriteModel struct {
+	UserLifecyclePolicyWriteModel
+}
+
+func NewInstanceUserLifecyclePolicyWriteModel(ctx context.Context) *InstanceUserLifecyclePolicyWriteModel {
+	return &InstanceUserLifecyclePolicyWriteModel{
+		UserLifecyclePolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceUserLifecyclePolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.UserLifecyclePolicyAddedEvent:
+			wm.UserLifecyclePolicyWriteModel.AppendEvents(&e.UserLifecyclePolicyAddedEvent)
+		case *instance.UserLifecyclePolicyChangedEvent:
+			wm.UserLifecyclePolicyWriteModel.AppendEvents(&e.UserLifecyclePolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceUserLifecyclePolicyWriteModel) Reduce() error {
+	return wm.UserLifecyclePolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceUserLifecyclePolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.UserLifecyclePolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.UserLifecyclePolicyAddedEventType,
+			instance.UserLifecyclePolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceUserLifecyclePolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	lifecyclePolicy *domain.UserLifecyclePolicy,
+) (*instance.UserLifecyclePolicyChangedEvent, bool) {
+	changes := wm.UserLifecyclePolicyWriteModel.changes(lifecyclePolicy)
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewUserLifecyclePolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -0,0 +1,276 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+func TestCommandSide_AddDefaultUserLifecyclePolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx             context.Context
+		resourceOwner   string
+		lifecyclePolicy *domain.UserLifecyclePolicy
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "empty excluded user id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:             context.Background(),
+				resourceOwner:   "INSTANCE",
+				lifecyclePolicy: &domain.UserLifecyclePolicy{ExcludedUserIDs: []string{""}},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user lifecycle policy already existing, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewUserLifecyclePolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								90,
+								30,
+								7,
+								false,
+								nil,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:             context.Background(),
+				resourceOwner:   "INSTANCE",
+				lifecyclePolicy: &domain.UserLifecyclePolicy{DeactivateAfterDays: 90},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add policy, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								instance.NewUserLifecyclePolicyAddedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									90,
+									30,
+									7,
+									true,
+									nil,
+									false,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				lifecyclePolicy: &domain.UserLifecyclePolicy{
+					DeactivateAfterDays: 90,
+					DeleteAfterDays:     30,
+					WarnDaysBefore:      7,
+					ExcludeMachineUsers: true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.AddDefaultUserLifecyclePolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.lifecyclePolicy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeDefaultUserLifecyclePolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx             context.Context
+		resourceOwner   string
+		lifecyclePolicy *domain.UserLifecyclePolicy
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "user lifecycle policy not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:             context.Background(),
+				resourceOwner:   "INSTANCE",
+				lifecyclePolicy: &domain.UserLifecyclePolicy{DeactivateAfterDays: 90},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewUserLifecyclePolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								90,
+								0,
+								0,
+								false,
+								nil,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:             context.Background(),
+				resourceOwner:   "INSTANCE",
+				lifecyclePolicy: &domain.UserLifecyclePolicy{DeactivateAfterDays: 90},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewUserLifecyclePolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								90,
+								0,
+								0,
+								false,
+								nil,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newDefaultUserLifecyclePolicyChangedEvent(context.Background(),
+									policy.ChangeWarnDaysBefore(7),
+									policy.ChangeDryRun(true),
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				lifecyclePolicy: &domain.UserLifecyclePolicy{
+					DeactivateAfterDays: 90,
+					WarnDaysBefore:      7,
+					DryRun:              true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ChangeDefaultUserLifecyclePolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.lifecyclePolicy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newDefaultUserLifecyclePolicyChangedEvent(ctx context.Context, changes ...policy.UserLifecyclePolicyChanges) *instance.UserLifecyclePolicyChangedEvent {
+	event, _ := instance.NewUserLifecyclePolicyChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		changes,
+	)
+	return event
+}
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+func (c *Commands) AddDefaultUserSchemaPolicy(ctx context.Context, resourceOwner string, schemaPolicy *domain.UserSchemaPolicy) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultUserSchemaPolicy(instanceAgg, schemaPolicy))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) ChangeDefaultUserSchemaPolicy(ctx context.Context, resourceOwner string, schemaPolicy *domain.UserSchemaPolicy) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultUserSchemaPolicy(instanceAgg, schemaPolicy))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareAddDefaultUserSchemaPolicy(
+	a *instance.Aggregate,
+	schemaPolicy *domain.UserSchemaPolicy,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := schemaPolicy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstanceUserSchemaPolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if writeModel.State == domain.PolicyStateActive {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Usc9a", "Errors.IAM.UserSchemaPolicy.AlreadyExists")
+			}
+			return []eventstore.Command{
+				instance.NewUserSchemaPolicyAddedEvent(
+					ctx,
+					&a.Aggregate,
+					policy.UserSchemaAttributesFromDomain(schemaPolicy.Attributes),
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareChangeDefaultUserSchemaPolicy(
+	a *instance.Aggregate,
+	schemaPolicy *domain.UserSchemaPolicy,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := schemaPolicy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstanceUserSchemaPolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+
+			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
+				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Usc3n", "Errors.IAM.UserSchemaPolicy.NotFound")
+			}
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, schemaPolicy)
+			if !hasChanged {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Usc8c", "Errors.IAM.UserSchemaPolicy.NotChanged")
+			}
+			return []eventstore.Command{
+				change,
+			}, nil
+		}, nil
+	}
+}
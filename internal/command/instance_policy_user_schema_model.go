@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceUserSchemaPolicyWriteModel struct {
+	UserSchemaPolicyWriteModel
+}
+
+func NewInstanceUserSchemaPolicyWriteModel(ctx context.Context) *InstanceUserSchemaPolicyWriteModel {
+	return &InstanceUserSchemaPolicyWriteModel{
+		UserSchemaPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceUserSchemaPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.UserSchemaPolicyAddedEvent:
+			wm.UserSchemaPolicyWriteModel.AppendEvents(&e.UserSchemaPolicyAddedEvent)
+		case *instance.UserSchemaPolicyChangedEvent:
+			wm.UserSchemaPolicyWriteModel.AppendEvents(&e.UserSchemaPolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceUserSchemaPolicyWriteModel) Reduce() error {
+	return wm.UserSchemaPolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceUserSchemaPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.UserSchemaPolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.UserSchemaPolicyAddedEventType,
+			instance.UserSchemaPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceUserSchemaPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	schemaPolicy *domain.UserSchemaPolicy,
+) (*instance.UserSchemaPolicyChangedEvent, bool) {
+	changes := wm.UserSchemaPolicyWriteModel.changes(schemaPolicy)
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewUserSchemaPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -12,12 +13,16 @@ import (
 type InstanceSMTPConfigWriteModel struct {
 	eventstore.WriteModel
 
+	ID            string
+	Priority      uint32
 	SenderAddress string
 	SenderName    string
 	TLS           bool
 	Host          string
 	User          string
 	Password      *crypto.CryptoValue
+	AuthType      domain.SMTPAuthType
+	XOAuth2       *domain.SMTPXOAuth2
 	State         domain.SMTPConfigState
 
 	domain                                 string
@@ -25,12 +30,13 @@ type InstanceSMTPConfigWriteModel struct {
 	smtpSenderAddressMatchesInstanceDomain bool
 }
 
-func NewInstanceSMTPConfigWriteModel(instanceID, domain string) *InstanceSMTPConfigWriteModel {
+func NewInstanceSMTPConfigWriteModel(instanceID, id, domain string) *InstanceSMTPConfigWriteModel {
 	return &InstanceSMTPConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
 			AggregateID:   instanceID,
 			ResourceOwner: instanceID,
 		},
+		ID:     id,
 		domain: domain,
 	}
 }
@@ -48,6 +54,11 @@ func (wm *InstanceSMTPConfigWriteModel) AppendEvents(events ...eventstore.Event)
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case smtpConfigEvent:
+			if e.ConfigID() != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		default:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -59,6 +70,9 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
 		case *instance.SMTPConfigAddedEvent:
+			wm.Priority = e.Priority
+			wm.AuthType = e.AuthType
+			wm.XOAuth2 = e.XOAuth2
 			wm.TLS = e.TLS
 			wm.SenderAddress = e.SenderAddress
 			wm.SenderName = e.SenderName
@@ -67,6 +81,15 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			wm.Password = e.Password
 			wm.State = domain.SMTPConfigStateActive
 		case *instance.SMTPConfigChangedEvent:
+			if e.Priority != nil {
+				wm.Priority = *e.Priority
+			}
+			if e.AuthType != nil {
+				wm.AuthType = *e.AuthType
+			}
+			if e.XOAuth2 != nil {
+				wm.XOAuth2 = e.XOAuth2
+			}
 			if e.TLS != nil {
 				wm.TLS = *e.TLS
 			}
@@ -82,8 +105,13 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			if e.User != nil {
 				wm.User = *e.User
 			}
+		case *instance.SMTPConfigPasswordChangedEvent:
+			wm.Password = e.Password
 		case *instance.SMTPConfigRemovedEvent:
 			wm.State = domain.SMTPConfigStateRemoved
+			wm.Priority = 0
+			wm.AuthType = domain.SMTPAuthTypePlain
+			wm.XOAuth2 = nil
 			wm.TLS = false
 			wm.SenderName = ""
 			wm.SenderAddress = ""
@@ -115,6 +143,7 @@ func (wm *InstanceSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMTPConfigAddedEventType,
 			instance.SMTPConfigChangedEventType,
 			instance.SMTPConfigPasswordChangedEventType,
+			instance.SMTPConfigRemovedEventType,
 			instance.InstanceDomainAddedEventType,
 			instance.InstanceDomainRemovedEventType,
 			instance.DomainPolicyAddedEventType,
@@ -122,10 +151,19 @@ func (wm *InstanceSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
-func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, tls bool, fromAddress, fromName, smtpHost, smtpUser string) (*instance.SMTPConfigChangedEvent, bool, error) {
+func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, priority uint32, tls bool, fromAddress, fromName, smtpHost, smtpUser string, xoauth2 *domain.SMTPXOAuth2) (*instance.SMTPConfigChangedEvent, bool, error) {
 	changes := make([]instance.SMTPConfigChanges, 0)
 	var err error
 
+	if wm.Priority != priority {
+		changes = append(changes, instance.ChangeSMTPConfigPriority(priority))
+	}
+	if authType := smtpAuthType(xoauth2); wm.AuthType != authType {
+		changes = append(changes, instance.ChangeSMTPConfigAuthType(authType))
+	}
+	if xoauth2 != nil && !reflect.DeepEqual(wm.XOAuth2, xoauth2) {
+		changes = append(changes, instance.ChangeSMTPConfigXOAuth2(xoauth2))
+	}
 	if wm.TLS != tls {
 		changes = append(changes, instance.ChangeSMTPConfigTLS(tls))
 	}
@@ -145,9 +183,22 @@ func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, agg
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
-	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, changes)
+	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, wm.ID, changes)
 	if err != nil {
 		return nil, false, err
 	}
 	return changeEvent, true, nil
 }
+
+// smtpConfigEvent is implemented by all events of an SMTP configuration of the instance
+type smtpConfigEvent interface {
+	eventstore.Event
+	ConfigID() string
+}
+
+func smtpAuthType(xoauth2 *domain.SMTPXOAuth2) domain.SMTPAuthType {
+	if xoauth2 != nil {
+		return domain.SMTPAuthTypeXOAuth2
+	}
+	return domain.SMTPAuthTypePlain
+}
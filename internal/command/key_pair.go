@@ -11,11 +11,22 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 )
 
+// GenerateSigningKeyPair generates a new OIDC signing key.
+// The algorithm is only used if the key rotation policy of the instance does not define one.
 func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string) error {
-	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedKeyPair(c.keySize, c.keyAlgorithm)
+	policy, err := c.keyRotationPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	if policy.SigningKeyAlgorithm != "" {
+		algorithm = policy.SigningKeyAlgorithm
+	}
+	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedSigningKeyPair(algorithm, c.keySize, c.keyAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -24,8 +35,7 @@ func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string)
 		return err
 	}
 
-	privateKeyExp := time.Now().UTC().Add(c.privateKeyLifetime)
-	publicKeyExp := time.Now().UTC().Add(c.publicKeyLifetime)
+	privateKeyExp, publicKeyExp := policy.SigningKeyExpiration(time.Now().UTC())
 
 	keyPairWriteModel := NewKeyPairWriteModel(keyID, authz.GetInstance(ctx).InstanceID())
 	keyAgg := KeyPairAggregateFromWriteModel(&keyPairWriteModel.WriteModel)
@@ -40,8 +50,12 @@ func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string)
 }
 
 func (c *Commands) GenerateSAMLCACertificate(ctx context.Context, algorithm string) error {
+	policy, err := c.keyRotationPolicy(ctx)
+	if err != nil {
+		return err
+	}
 	now := time.Now().UTC()
-	after := now.Add(c.certificateLifetime)
+	privateKeyExp, after := policy.CertificateExpiration(now)
 	randInt, err := rand.Int(rand.Reader, big.NewInt(1000))
 	if err != nil {
 		return err
@@ -72,7 +86,7 @@ func (c *Commands) GenerateSAMLCACertificate(ctx context.Context, algorithm stri
 			domain.KeyUsageSAMLCA,
 			algorithm,
 			privateCrypto, publicCrypto,
-			after, after,
+			privateKeyExp, after,
 		),
 		keypair.NewAddedCertificateEvent(
 			ctx,
@@ -85,8 +99,12 @@ func (c *Commands) GenerateSAMLCACertificate(ctx context.Context, algorithm stri
 }
 
 func (c *Commands) GenerateSAMLResponseCertificate(ctx context.Context, algorithm string, caPrivateKey *rsa.PrivateKey, caCertificate []byte) error {
+	policy, err := c.keyRotationPolicy(ctx)
+	if err != nil {
+		return err
+	}
 	now := time.Now().UTC()
-	after := now.Add(c.certificateLifetime)
+	privateKeyExp, after := policy.CertificateExpiration(now)
 	randInt, err := rand.Int(rand.Reader, big.NewInt(1000))
 	if err != nil {
 		return err
@@ -118,7 +136,7 @@ func (c *Commands) GenerateSAMLResponseCertificate(ctx context.Context, algorith
 			domain.KeyUsageSAMLResponseSinging,
 			algorithm,
 			privateCrypto, publicCrypto,
-			after, after,
+			privateKeyExp, after,
 		),
 		keypair.NewAddedCertificateEvent(
 			ctx,
@@ -131,8 +149,12 @@ func (c *Commands) GenerateSAMLResponseCertificate(ctx context.Context, algorith
 }
 
 func (c *Commands) GenerateSAMLMetadataCertificate(ctx context.Context, algorithm string, caPrivateKey *rsa.PrivateKey, caCertificate []byte) error {
+	policy, err := c.keyRotationPolicy(ctx)
+	if err != nil {
+		return err
+	}
 	now := time.Now().UTC()
-	after := now.Add(c.certificateLifetime)
+	privateKeyExp, after := policy.CertificateExpiration(now)
 	randInt, err := rand.Int(rand.Reader, big.NewInt(1000))
 	if err != nil {
 		return err
@@ -163,7 +185,7 @@ func (c *Commands) GenerateSAMLMetadataCertificate(ctx context.Context, algorith
 			domain.KeyUsageSAMLMetadataSigning,
 			algorithm,
 			privateCrypto, publicCrypto,
-			after, after),
+			privateKeyExp, after),
 		keypair.NewAddedCertificateEvent(
 			ctx,
 			keyAgg,
@@ -173,3 +195,76 @@ func (c *Commands) GenerateSAMLMetadataCertificate(ctx context.Context, algorith
 	)
 	return err
 }
+
+// RotateKeyPairs retires all active key pairs of the instance with the provided usage.
+// They are still used for signing until the pre-publication period of the key rotation policy has passed,
+// which allows the successor (generated on the next use) to be published beforehand.
+func (c *Commands) RotateKeyPairs(ctx context.Context, usage domain.KeyUsage) (*domain.ObjectDetails, error) {
+	policy, err := c.keyRotationPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewInstanceKeyPairsWriteModel(authz.GetInstance(ctx).InstanceID(), usage)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	privateKeyExp := now.Add(policy.PrePublicationPeriod)
+	publicKeyExp := privateKeyExp.Add(policy.RetirementGracePeriod)
+	events := make([]eventstore.Command, 0, len(writeModel.KeyPairs))
+	for _, keyPair := range writeModel.KeyPairs {
+		if keyPair.State != domain.KeyPairStateActive || !keyPair.PrivateKey.Expiry.After(privateKeyExp) {
+			continue
+		}
+		certificateExp := time.Time{}
+		if keyPair.Certificate != nil {
+			certificateExp = minTime(keyPair.Certificate.Expiry, publicKeyExp)
+		}
+		events = append(events, keypair.NewRetiredEvent(
+			ctx,
+			KeyPairAggregateFromWriteModel(&keyPair.WriteModel),
+			privateKeyExp,
+			minTime(keyPair.PublicKey.Expiry, publicKeyExp),
+			certificateExp,
+		))
+	}
+	if len(events) == 0 {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      pushedEvents[len(pushedEvents)-1].Sequence(),
+		EventDate:     pushedEvents[len(pushedEvents)-1].CreationDate(),
+		ResourceOwner: pushedEvents[len(pushedEvents)-1].Aggregate().InstanceID,
+	}, nil
+}
+
+// RevokeKeyPair removes a (compromised) key pair immediately.
+// It will neither be used for signing nor be published for verification anymore.
+func (c *Commands) RevokeKeyPair(ctx context.Context, keyID string) (*domain.ObjectDetails, error) {
+	if keyID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Bie3u", "Errors.IDMissing")
+	}
+	writeModel := NewKeyPairWriteModel(keyID, authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.KeyPairStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-ahG3o", "Errors.Key.NotFound")
+	}
+	err := c.pushAppendAndReduce(ctx, writeModel, keypair.NewRevokedEvent(ctx, KeyPairAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -9,6 +9,7 @@ import (
 type KeyPairWriteModel struct {
 	eventstore.WriteModel
 
+	State       domain.KeyPairState
 	Usage       domain.KeyUsage
 	Algorithm   string
 	PrivateKey  *domain.Key
@@ -33,6 +34,7 @@ func (wm *KeyPairWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
 		case *keypair.AddedEvent:
+			wm.State = domain.KeyPairStateActive
 			wm.Usage = e.Usage
 			wm.Algorithm = e.Algorithm
 			wm.PrivateKey = &domain.Key{
@@ -48,6 +50,14 @@ func (wm *KeyPairWriteModel) Reduce() error {
 				Key:    e.Certificate.Key,
 				Expiry: e.Certificate.Expiry,
 			}
+		case *keypair.RetiredEvent:
+			wm.PrivateKey.Expiry = e.PrivateKeyExpiry
+			wm.PublicKey.Expiry = e.PublicKeyExpiry
+			if wm.Certificate != nil {
+				wm.Certificate.Expiry = e.CertificateExpiry
+			}
+		case *keypair.RevokedEvent:
+			wm.State = domain.KeyPairStateRevoked
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -59,7 +69,70 @@ func (wm *KeyPairWriteModel) Query() *eventstore.SearchQueryBuilder {
 		AddQuery().
 		AggregateTypes(keypair.AggregateType).
 		AggregateIDs(wm.AggregateID).
-		EventTypes(keypair.AddedEventType, keypair.AddedCertificateEventType).
+		EventTypes(
+			keypair.AddedEventType,
+			keypair.AddedCertificateEventType,
+			keypair.RetiredEventType,
+			keypair.RevokedEventType,
+		).
+		Builder()
+}
+
+// InstanceKeyPairsWriteModel contains the state and expiration of all key pairs of an instance with a specific usage
+type InstanceKeyPairsWriteModel struct {
+	eventstore.WriteModel
+
+	usage    domain.KeyUsage
+	KeyPairs map[string]*KeyPairWriteModel
+}
+
+func NewInstanceKeyPairsWriteModel(instanceID string, usage domain.KeyUsage) *InstanceKeyPairsWriteModel {
+	return &InstanceKeyPairsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: instanceID,
+		},
+		usage:    usage,
+		KeyPairs: make(map[string]*KeyPairWriteModel),
+	}
+}
+
+func (wm *InstanceKeyPairsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if e, ok := event.(*keypair.AddedEvent); ok {
+			if e.Usage != wm.usage {
+				continue
+			}
+			wm.KeyPairs[e.Aggregate().ID] = NewKeyPairWriteModel(e.Aggregate().ID, wm.ResourceOwner)
+		}
+		keyPair, ok := wm.KeyPairs[event.Aggregate().ID]
+		if !ok {
+			continue
+		}
+		keyPair.AppendEvents(event)
+	}
+	wm.WriteModel.AppendEvents(events...)
+}
+
+func (wm *InstanceKeyPairsWriteModel) Reduce() error {
+	for _, keyPair := range wm.KeyPairs {
+		if err := keyPair.Reduce(); err != nil {
+			return err
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceKeyPairsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(keypair.AggregateType).
+		EventTypes(
+			keypair.AddedEventType,
+			keypair.AddedCertificateEventType,
+			keypair.RetiredEventType,
+			keypair.RevokedEventType,
+		).
 		Builder()
 }
 
@@ -0,0 +1,210 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/keypair"
+)
+
+func TestCommandSide_RevokeKeyPair(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		keyID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "INSTANCE"),
+				keyID: "key1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "already revoked, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(newKeyPairAddedEvent("key1", domain.KeyUsageSigning, time.Now().Add(time.Hour))),
+						eventFromEventPusher(keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key1"))),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "INSTANCE"),
+				keyID: "key1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "revoke, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(newKeyPairAddedEvent("key1", domain.KeyUsageSigning, time.Now().Add(time.Hour))),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key1")),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "INSTANCE"),
+				keyID: "key1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.RevokeKeyPair(tt.args.ctx, tt.args.keyID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RotateKeyPairs(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		usage domain.KeyUsage
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no active key of usage, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(newKeyPairAddedEvent("key1", domain.KeyUsageSAMLCA, time.Now().Add(time.Hour))),
+						eventFromEventPusher(newKeyPairAddedEvent("key2", domain.KeyUsageSigning, time.Now().Add(time.Hour))),
+						eventFromEventPusher(keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key2"))),
+						eventFromEventPusher(newKeyPairAddedEvent("key3", domain.KeyUsageSigning, time.Now().Add(time.Minute))),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "INSTANCE"),
+				usage: domain.KeyUsageSigning,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.RotateKeyPairs(tt.args.ctx, tt.args.usage)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func keyPairAggregate(id string) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            id,
+		Type:          keypair.AggregateType,
+		ResourceOwner: "INSTANCE",
+		InstanceID:    "INSTANCE",
+		Version:       keypair.AggregateVersion,
+	}
+}
+
+func newKeyPairAddedEvent(id string, usage domain.KeyUsage, expiry time.Time) *keypair.AddedEvent {
+	return keypair.NewAddedEvent(context.Background(),
+		keyPairAggregate(id),
+		usage,
+		"RS256",
+		&crypto.CryptoValue{},
+		&crypto.CryptoValue{},
+		expiry,
+		expiry,
+	)
+}
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+// AddNotificationDelivery records the outcome of a notification handed to a provider.
+// A delivery with an error is recorded as failed.
+func (c *Commands) AddNotificationDelivery(ctx context.Context, resourceOwner string, delivery *domain.NotificationDelivery) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ne3bx", "Errors.ResourceOwnerMissing")
+	}
+	if delivery.UserID == "" || delivery.MessageType == "" || !delivery.Channel.Valid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hq9wd", "Errors.Notification.Delivery.Invalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewNotificationDeliveryWriteModel(id, resourceOwner)
+	aggregate := &notification.NewAggregate(id, resourceOwner).Aggregate
+	var cmd eventstore.Command = notification.NewSentEvent(ctx, aggregate, delivery)
+	if delivery.Error != "" {
+		cmd = notification.NewFailedEvent(ctx, aggregate, delivery)
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ChangeNotificationDeliveryStatus sets the status reported by the provider of a sent notification
+func (c *Commands) ChangeNotificationDeliveryStatus(ctx context.Context, resourceOwner, deliveryID string, status domain.NotificationDeliveryStatus, reason string) (*domain.ObjectDetails, error) {
+	if deliveryID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rk2mv", "Errors.IDMissing")
+	}
+	if !status.IsReported() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-x7Uqs", "Errors.Notification.Delivery.StatusInvalid")
+	}
+	writeModel, err := c.getNotificationDeliveryWriteModel(ctx, resourceOwner, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Bv4pz", "Errors.Notification.Delivery.NotFound")
+	}
+	if writeModel.State == status {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tf8ku", "Errors.Notification.Delivery.StatusNotChanged")
+	}
+	if writeModel.State != domain.NotificationDeliveryStatusSent && writeModel.State != domain.NotificationDeliveryStatusDelivered {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Dw5ic", "Errors.Notification.Delivery.StatusInvalid")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewStatusChangedEvent(ctx, notificationDeliveryAggregate(writeModel), status, reason))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ResendNotificationDelivery sends a failed notification again.
+// The user event which caused the notification is handled again by the notification handler,
+// which results in a new delivery.
+func (c *Commands) ResendNotificationDelivery(ctx context.Context, resourceOwner, deliveryID string) (*domain.ObjectDetails, error) {
+	if deliveryID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zu6ba", "Errors.IDMissing")
+	}
+	writeModel, err := c.getNotificationDeliveryWriteModel(ctx, resourceOwner, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ag9xe", "Errors.Notification.Delivery.NotFound")
+	}
+	if writeModel.State != domain.NotificationDeliveryStatusFailed {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lm2cq", "Errors.Notification.Delivery.NotFailed")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewResendRequestedEvent(
+		ctx,
+		notificationDeliveryAggregate(writeModel),
+		writeModel.TriggeringAggregateID,
+		writeModel.TriggeringSequence,
+		writeModel.TriggeringEventType,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) getNotificationDeliveryWriteModel(ctx context.Context, resourceOwner, deliveryID string) (*NotificationDeliveryWriteModel, error) {
+	writeModel := NewNotificationDeliveryWriteModel(deliveryID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func notificationDeliveryAggregate(writeModel *NotificationDeliveryWriteModel) *eventstore.Aggregate {
+	return &notification.NewAggregate(writeModel.AggregateID, writeModel.ResourceOwner).Aggregate
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type NotificationDeliveryWriteModel struct {
+	eventstore.WriteModel
+
+	UserID                string
+	Channel               domain.NotificationType
+	MessageType           string
+	TriggeringAggregateID string
+	TriggeringSequence    uint64
+	TriggeringEventType   string
+
+	State domain.NotificationDeliveryStatus
+}
+
+func NewNotificationDeliveryWriteModel(id, resourceOwner string) *NotificationDeliveryWriteModel {
+	return &NotificationDeliveryWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *NotificationDeliveryWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.SentEvent:
+			wm.reduceDelivery(&e.Delivery)
+			wm.State = domain.NotificationDeliveryStatusSent
+		case *notification.FailedEvent:
+			wm.reduceDelivery(&e.Delivery)
+			wm.State = domain.NotificationDeliveryStatusFailed
+		case *notification.StatusChangedEvent:
+			wm.State = e.Status
+		case *notification.ResendRequestedEvent:
+			wm.State = domain.NotificationDeliveryStatusResent
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationDeliveryWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			notification.SentEventType,
+			notification.FailedEventType,
+			notification.StatusChangedEventType,
+			notification.ResendRequestedEventType,
+		).
+		Builder()
+}
+
+func (wm *NotificationDeliveryWriteModel) reduceDelivery(delivery *notification.Delivery) {
+	wm.UserID = delivery.UserID
+	wm.Channel = delivery.Channel
+	wm.MessageType = delivery.MessageType
+	wm.TriggeringAggregateID = delivery.TriggeringAggregateID
+	wm.TriggeringSequence = delivery.TriggeringSequence
+	wm.TriggeringEventType = delivery.TriggeringEventType
+}
@@ -48,6 +48,7 @@ func (c *Commands) addActionWithID(ctx context.Context, addAction *domain.Action
 		addAction.Script,
 		addAction.Timeout,
 		addAction.AllowedToFail,
+		addAction.Runtime,
 	))
 	if err != nil {
 		return "", nil, err
@@ -79,7 +80,8 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 		actionChange.Name,
 		actionChange.Script,
 		actionChange.Timeout,
-		actionChange.AllowedToFail)
+		actionChange.AllowedToFail,
+		actionChange.Runtime)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +96,46 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 	return writeModelToObjectDetails(&existingAction.WriteModel), nil
 }
 
+// RollbackAction sets the name, script, timeout, allowed to fail and runtime of the action to the state of a previous revision.
+// The rollback itself creates a new revision.
+func (c *Commands) RollbackAction(ctx context.Context, actionID string, revision uint64, resourceOwner string) (*domain.ObjectDetails, error) {
+	if actionID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ooc1a", "Errors.IDMissing")
+	}
+	if revision == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ahs5E", "Errors.Action.RevisionInvalid")
+	}
+
+	existingAction := NewActionRevisionWriteModel(actionID, resourceOwner, revision)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingAction)
+	if err != nil {
+		return nil, err
+	}
+	if !existingAction.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Iem2e", "Errors.Action.NotFound")
+	}
+	if existingAction.Revision == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Xoo8i", "Errors.Action.RevisionNotFound")
+	}
+
+	actionAgg := ActionAggregateFromWriteModel(&existingAction.WriteModel)
+	changedEvent, err := existingAction.NewChangedEvent(
+		ctx,
+		actionAgg,
+		existingAction.Revision.Name,
+		existingAction.Revision.Script,
+		existingAction.Revision.Timeout,
+		existingAction.Revision.AllowedToFail,
+		existingAction.Revision.Runtime)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.pushAppendAndReduce(ctx, existingAction, changedEvent); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingAction.WriteModel), nil
+}
+
 func (c *Commands) DeactivateAction(ctx context.Context, actionID string, resourceOwner string) (*domain.ObjectDetails, error) {
 	if actionID == "" || resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-DAhk5", "Errors.IDMissing")
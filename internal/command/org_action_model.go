@@ -17,6 +17,7 @@ type ActionWriteModel struct {
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         domain.ActionState
+	Runtime       domain.ActionRuntime
 }
 
 func NewActionWriteModel(actionID string, resourceOwner string) *ActionWriteModel {
@@ -30,37 +31,45 @@ func NewActionWriteModel(actionID string, resourceOwner string) *ActionWriteMode
 
 func (wm *ActionWriteModel) Reduce() error {
 	for _, event := range wm.Events {
-		switch e := event.(type) {
-		case *action.AddedEvent:
-			wm.Name = e.Name
-			wm.Script = e.Script
-			wm.Timeout = e.Timeout
-			wm.AllowedToFail = e.AllowedToFail
-			wm.State = domain.ActionStateActive
-		case *action.ChangedEvent:
-			if e.Name != nil {
-				wm.Name = *e.Name
-			}
-			if e.Script != nil {
-				wm.Script = *e.Script
-			}
-			if e.Timeout != nil {
-				wm.Timeout = *e.Timeout
-			}
-			if e.AllowedToFail != nil {
-				wm.AllowedToFail = *e.AllowedToFail
-			}
-		case *action.DeactivatedEvent:
-			wm.State = domain.ActionStateInactive
-		case *action.ReactivatedEvent:
-			wm.State = domain.ActionStateActive
-		case *action.RemovedEvent:
-			wm.State = domain.ActionStateRemoved
-		}
+		wm.reduceEvent(event)
 	}
 	return wm.WriteModel.Reduce()
 }
 
+func (wm *ActionWriteModel) reduceEvent(event eventstore.Event) {
+	switch e := event.(type) {
+	case *action.AddedEvent:
+		wm.Name = e.Name
+		wm.Script = e.Script
+		wm.Timeout = e.Timeout
+		wm.AllowedToFail = e.AllowedToFail
+		wm.Runtime = e.Runtime
+		wm.State = domain.ActionStateActive
+	case *action.ChangedEvent:
+		if e.Name != nil {
+			wm.Name = *e.Name
+		}
+		if e.Script != nil {
+			wm.Script = *e.Script
+		}
+		if e.Timeout != nil {
+			wm.Timeout = *e.Timeout
+		}
+		if e.AllowedToFail != nil {
+			wm.AllowedToFail = *e.AllowedToFail
+		}
+		if e.Runtime != nil {
+			wm.Runtime = *e.Runtime
+		}
+	case *action.DeactivatedEvent:
+		wm.State = domain.ActionStateInactive
+	case *action.ReactivatedEvent:
+		wm.State = domain.ActionStateActive
+	case *action.RemovedEvent:
+		wm.State = domain.ActionStateRemoved
+	}
+}
+
 func (wm *ActionWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
@@ -82,6 +91,7 @@ func (wm *ActionWriteModel) NewChangedEvent(
 	script string,
 	timeout time.Duration,
 	allowedToFail bool,
+	runtime domain.ActionRuntime,
 ) (*action.ChangedEvent, error) {
 	changes := make([]action.ActionChanges, 0)
 	if wm.Name != name {
@@ -96,9 +106,50 @@ func (wm *ActionWriteModel) NewChangedEvent(
 	if wm.AllowedToFail != allowedToFail {
 		changes = append(changes, action.ChangeAllowedToFail(allowedToFail))
 	}
+	if wm.Runtime != runtime {
+		changes = append(changes, action.ChangeRuntime(runtime))
+	}
 	return action.NewChangedEvent(ctx, agg, changes)
 }
 
+// ActionRevisionWriteModel contains the current state of the action and the state at the requested revision.
+// Every added and changed event creates a new revision, starting with 1.
+type ActionRevisionWriteModel struct {
+	ActionWriteModel
+
+	revision  uint64
+	revisions uint64
+	// Revision is nil if the revision does not exist
+	Revision *domain.Action
+}
+
+func NewActionRevisionWriteModel(actionID, resourceOwner string, revision uint64) *ActionRevisionWriteModel {
+	return &ActionRevisionWriteModel{
+		ActionWriteModel: *NewActionWriteModel(actionID, resourceOwner),
+		revision:         revision,
+	}
+}
+
+func (wm *ActionRevisionWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		wm.reduceEvent(event)
+		switch event.(type) {
+		case *action.AddedEvent, *action.ChangedEvent:
+			wm.revisions++
+			if wm.revisions == wm.revision {
+				wm.Revision = &domain.Action{
+					Name:          wm.Name,
+					Script:        wm.Script,
+					Timeout:       wm.Timeout,
+					AllowedToFail: wm.AllowedToFail,
+					Runtime:       wm.Runtime,
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
 func ActionAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, action.AggregateType, action.AggregateVersion)
 }
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// SetActionSecret adds or overwrites a secret of the actions of the organisation.
+// The value is stored encrypted and can only be read by the actions.
+func (c *Commands) SetActionSecret(ctx context.Context, orgID, name, value string) (*domain.ObjectDetails, error) {
+	if name == "" || value == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Uo9ie", "Errors.Action.Storage.SecretInvalid")
+	}
+	if c.actionStorage.MaxSecretSize > 0 && len(value) > c.actionStorage.MaxSecretSize {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-aeX3u", "Errors.Action.Storage.SecretTooLarge")
+	}
+	if err := c.checkOrgExists(ctx, orgID); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.getOrgActionStorageWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := writeModel.Secrets[name]; !ok && c.actionStorage.MaxSecrets > 0 && len(writeModel.Secrets) >= c.actionStorage.MaxSecrets {
+		return nil, caos_errs.ThrowResourceExhausted(nil, "COMMAND-Ieph4", "Errors.Action.Storage.SecretsExhausted")
+	}
+	encryptedValue, err := crypto.Encrypt([]byte(value), c.actionSecretEncryption)
+	if err != nil {
+		return nil, err
+	}
+	orgAgg := OrgAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, org.NewActionSecretSetEvent(ctx, orgAgg, name, encryptedValue)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveActionSecret(ctx context.Context, orgID, name string) (*domain.ObjectDetails, error) {
+	if name == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-eiT6o", "Errors.Action.Storage.SecretInvalid")
+	}
+	writeModel, err := c.getOrgActionStorageWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := writeModel.Secrets[name]; !ok {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Wai0e", "Errors.Action.Storage.SecretNotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, org.NewActionSecretRemovedEvent(ctx, orgAgg, name)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// GetActionSecretValue returns the decrypted secret for the execution of the actions of the organisation
+func (c *Commands) GetActionSecretValue(ctx context.Context, orgID, name string) (string, error) {
+	writeModel, err := c.getOrgActionStorageWriteModel(ctx, orgID)
+	if err != nil {
+		return "", err
+	}
+	secret, ok := writeModel.Secrets[name]
+	if !ok {
+		return "", caos_errs.ThrowNotFound(nil, "COMMAND-Ohb4x", "Errors.Action.Storage.SecretNotFound")
+	}
+	return crypto.DecryptString(secret, c.actionSecretEncryption)
+}
+
+// SetActionKeyValue adds or overwrites an entry of the key value store of the actions of the organisation
+func (c *Commands) SetActionKeyValue(ctx context.Context, orgID, key string, value []byte) (*domain.ObjectDetails, error) {
+	if key == "" || len(value) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ahK8a", "Errors.Action.Storage.EntryInvalid")
+	}
+	if c.actionStorage.MaxEntrySize > 0 && len(key)+len(value) > c.actionStorage.MaxEntrySize {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wu7ai", "Errors.Action.Storage.EntryTooLarge")
+	}
+	writeModel, err := c.getOrgActionStorageWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	existing, ok := writeModel.Entries[key]
+	if ok && bytes.Equal(existing, value) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	if !ok && c.actionStorage.MaxEntries > 0 && len(writeModel.Entries) >= c.actionStorage.MaxEntries {
+		return nil, caos_errs.ThrowResourceExhausted(nil, "COMMAND-Ohn5e", "Errors.Action.Storage.EntriesExhausted")
+	}
+	size := writeModel.storageSize() - len(existing) + len(value)
+	if !ok {
+		size += len(key)
+	}
+	if c.actionStorage.MaxStorageSize > 0 && size > c.actionStorage.MaxStorageSize {
+		return nil, caos_errs.ThrowResourceExhausted(nil, "COMMAND-uu2Ae", "Errors.Action.Storage.StorageExhausted")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, org.NewActionKeyValueSetEvent(ctx, orgAgg, key, value)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveActionKeyValue removes the entry of the key value store, it is a no-op if the key does not exist
+func (c *Commands) RemoveActionKeyValue(ctx context.Context, orgID, key string) (*domain.ObjectDetails, error) {
+	if key == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Jae1u", "Errors.Action.Storage.EntryInvalid")
+	}
+	writeModel, err := c.getOrgActionStorageWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := writeModel.Entries[key]; !ok {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	orgAgg := OrgAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, org.NewActionKeyValueRemovedEvent(ctx, orgAgg, key)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// GetActionKeyValue returns the value of the entry of the key value store or nil if it does not exist
+func (c *Commands) GetActionKeyValue(ctx context.Context, orgID, key string) ([]byte, error) {
+	writeModel, err := c.getOrgActionStorageWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel.Entries[key], nil
+}
+
+func (c *Commands) getOrgActionStorageWriteModel(ctx context.Context, orgID string) (*OrgActionStorageWriteModel, error) {
+	writeModel := NewOrgActionStorageWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// OrgActionStorageWriteModel contains all secrets and entries of the key value store of the actions of an organisation,
+// which is required to check the quotas
+type OrgActionStorageWriteModel struct {
+	eventstore.WriteModel
+
+	Secrets map[string]*crypto.CryptoValue
+	Entries map[string][]byte
+}
+
+func NewOrgActionStorageWriteModel(orgID string) *OrgActionStorageWriteModel {
+	return &OrgActionStorageWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		Secrets: make(map[string]*crypto.CryptoValue),
+		Entries: make(map[string][]byte),
+	}
+}
+
+func (wm *OrgActionStorageWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.ActionSecretSetEvent:
+			wm.Secrets[e.Name] = e.Value
+		case *org.ActionSecretRemovedEvent:
+			delete(wm.Secrets, e.Name)
+		case *org.ActionKeyValueSetEvent:
+			wm.Entries[e.Key] = e.Value
+		case *org.ActionKeyValueRemovedEvent:
+			delete(wm.Entries, e.Key)
+		case *org.OrgRemovedEvent:
+			wm.Secrets = make(map[string]*crypto.CryptoValue)
+			wm.Entries = make(map[string][]byte)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgActionStorageWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.ActionSecretSetEventType,
+			org.ActionSecretRemovedEventType,
+			org.ActionKeyValueSetEventType,
+			org.ActionKeyValueRemovedEventType,
+			org.OrgRemovedEventType,
+		).
+		Builder()
+}
+
+// storageSize returns the size of all entries including the keys
+func (wm *OrgActionStorageWriteModel) storageSize() int {
+	size := 0
+	for key, value := range wm.Entries {
+		size += len(key) + len(value)
+	}
+	return size
+}
@@ -0,0 +1,390 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestCommandSide_SetActionSecret(t *testing.T) {
+	type fields struct {
+		eventstore    *eventstore.Eventstore
+		actionStorage sd.ActionStorage
+	}
+	type args struct {
+		ctx   context.Context
+		orgID string
+		name  string
+		value string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing value, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				name:  "apiKey",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "value too large, invalid argument error",
+			fields: fields{
+				eventstore:    eventstoreExpect(t),
+				actionStorage: sd.ActionStorage{MaxSecretSize: 3},
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				name:  "apiKey",
+				value: "secret",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org not existing, pre condition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				name:  "apiKey",
+				value: "secret",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "max secrets reached, resource exhausted error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"ZITADEL",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionSecretSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"other",
+								&crypto.CryptoValue{},
+							),
+						),
+					),
+				),
+				actionStorage: sd.ActionStorage{MaxSecrets: 1},
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				name:  "apiKey",
+				value: "secret",
+			},
+			res: res{
+				err: caos_errs.IsResourceExhausted,
+			},
+		},
+		{
+			name: "overwrite secret with max secrets reached, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"ZITADEL",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionSecretSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"apiKey",
+								&crypto.CryptoValue{},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewActionSecretSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									"apiKey",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("secret"),
+									},
+								),
+							),
+						},
+					),
+				),
+				actionStorage: sd.ActionStorage{MaxSecrets: 1},
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				name:  "apiKey",
+				value: "secret",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:             tt.fields.eventstore,
+				actionSecretEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				actionStorage:          tt.fields.actionStorage,
+			}
+			got, err := r.SetActionSecret(tt.args.ctx, tt.args.orgID, tt.args.name, tt.args.value)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetActionKeyValue(t *testing.T) {
+	type fields struct {
+		eventstore    *eventstore.Eventstore
+		actionStorage sd.ActionStorage
+	}
+	type args struct {
+		ctx   context.Context
+		orgID string
+		key   string
+		value []byte
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing key, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				value: []byte("1"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "entry too large, invalid argument error",
+			fields: fields{
+				eventstore:    eventstoreExpect(t),
+				actionStorage: sd.ActionStorage{MaxEntrySize: 4},
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				key:   "counter",
+				value: []byte("1"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "max entries reached, resource exhausted error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionKeyValueSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"other",
+								[]byte("1"),
+							),
+						),
+					),
+				),
+				actionStorage: sd.ActionStorage{MaxEntries: 1},
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				key:   "counter",
+				value: []byte("1"),
+			},
+			res: res{
+				err: caos_errs.IsResourceExhausted,
+			},
+		},
+		{
+			name: "storage size exceeded, resource exhausted error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionKeyValueSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"other",
+								[]byte("1"),
+							),
+						),
+					),
+				),
+				actionStorage: sd.ActionStorage{MaxStorageSize: 10},
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				key:   "counter",
+				value: []byte("1"),
+			},
+			res: res{
+				err: caos_errs.IsResourceExhausted,
+			},
+		},
+		{
+			name: "unchanged value, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionKeyValueSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"counter",
+								[]byte("1"),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				key:   "counter",
+				value: []byte("1"),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "set entry, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionKeyValueSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"counter",
+								[]byte("1"),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewActionKeyValueSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									"counter",
+									[]byte("2"),
+								),
+							),
+						},
+					),
+				),
+				actionStorage: sd.ActionStorage{MaxEntries: 1, MaxStorageSize: 8},
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				key:   "counter",
+				value: []byte("2"),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				actionStorage: tt.fields.actionStorage,
+			}
+			got, err := r.SetActionKeyValue(tt.args.ctx, tt.args.orgID, tt.args.key, tt.args.value)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
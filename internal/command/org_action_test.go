@@ -68,6 +68,7 @@ func TestCommands_AddAction(t *testing.T) {
 									"name() {};",
 									0,
 									false,
+									domain.ActionRuntimeJavaScript,
 								),
 							),
 						},
@@ -101,6 +102,7 @@ func TestCommands_AddAction(t *testing.T) {
 									"name2() {};",
 									0,
 									false,
+									domain.ActionRuntimeJavaScript,
 								),
 							),
 						},
@@ -216,6 +218,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
@@ -248,6 +251,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
@@ -299,6 +303,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
@@ -359,6 +364,183 @@ func TestCommands_ChangeAction(t *testing.T) {
 	}
 }
 
+func TestCommands_RollbackAction(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		actionID      string
+		revision      uint64
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	addedEvent := func() *repository.Event {
+		return eventFromEventPusher(
+			action.NewAddedEvent(context.Background(),
+				&action.NewAggregate("id1", "org1").Aggregate,
+				"name",
+				"name() {};",
+				0,
+				false,
+				domain.ActionRuntimeJavaScript,
+			),
+		)
+	}
+	changedEvent := func() *repository.Event {
+		return eventFromEventPusher(
+			func() *action.ChangedEvent {
+				event, _ := action.NewChangedEvent(context.Background(),
+					&action.NewAggregate("id1", "org1").Aggregate,
+					[]action.ActionChanges{
+						action.ChangeName("name2", "name"),
+						action.ChangeScript("name2() {};"),
+					},
+				)
+				return event
+			}(),
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"revision missing, invalid argument error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				revision:      1,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"revision not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						addedEvent(),
+						changedEvent(),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				revision:      3,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"current revision, precondition error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						addedEvent(),
+						changedEvent(),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				revision:      2,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						addedEvent(),
+						changedEvent(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *action.ChangedEvent {
+									event, _ := action.NewChangedEvent(context.Background(),
+										&action.NewAggregate("id1", "org1").Aggregate,
+										[]action.ActionChanges{
+											action.ChangeName("name", "name2"),
+											action.ChangeScript("name() {};"),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(action.NewRemoveActionNameUniqueConstraint("name2", "org1")),
+						uniqueConstraintsFromEventConstraint(action.NewAddActionNameUniqueConstraint("name", "org1")),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				revision:      1,
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RollbackAction(tt.args.ctx, tt.args.actionID, tt.args.revision, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
 func TestCommands_DeactivateAction(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -420,6 +602,7 @@ func TestCommands_DeactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 						eventFromEventPusher(
@@ -451,6 +634,7 @@ func TestCommands_DeactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
@@ -557,6 +741,7 @@ func TestCommands_ReactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
@@ -583,6 +768,7 @@ func TestCommands_ReactivateAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 						eventFromEventPusher(
@@ -695,6 +881,7 @@ func TestCommands_DeleteAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
@@ -734,6 +921,7 @@ func TestCommands_DeleteAction(t *testing.T) {
 								"name() {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
@@ -237,6 +237,7 @@ func TestCommands_SetTriggerActions(t *testing.T) {
 								"function(ctx, api) action {};",
 								0,
 								false,
+								domain.ActionRuntimeJavaScript,
 							),
 						),
 					),
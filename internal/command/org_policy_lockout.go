@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
@@ -106,3 +107,21 @@ func (c *Commands) orgLockoutPolicyWriteModelByID(ctx context.Context, orgID str
 	}
 	return policy, nil
 }
+
+// lockoutPolicyByOrg returns the lockout policy of the organisation or the default policy of the instance
+func lockoutPolicyByOrg(ctx context.Context, es *eventstore.Eventstore, orgID string) (*domain.LockoutPolicy, error) {
+	orgPolicy := NewOrgLockoutPolicyWriteModel(orgID)
+	if err := es.FilterToQueryReducer(ctx, orgPolicy); err != nil {
+		return nil, err
+	}
+	if orgPolicy.State == domain.PolicyStateActive {
+		return writeModelToLockoutPolicy(&orgPolicy.LockoutPolicyWriteModel), nil
+	}
+	instancePolicy := NewInstanceLockoutPolicyWriteModel(ctx)
+	if err := es.FilterToQueryReducer(ctx, instancePolicy); err != nil {
+		return nil, err
+	}
+	policy := writeModelToLockoutPolicy(&instancePolicy.LockoutPolicyWriteModel)
+	policy.Default = true
+	return policy, nil
+}
@@ -35,6 +35,9 @@ type AddLoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowEmailMagicLink        bool
+	RiskStepUpThreshold        uint32
+	RiskBlockThreshold         uint32
 }
 
 type AddLoginPolicyIDP struct {
@@ -59,6 +62,9 @@ type ChangeLoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowEmailMagicLink        bool
+	RiskStepUpThreshold        uint32
+	RiskBlockThreshold         uint32
 }
 
 func (c *Commands) AddLoginPolicy(ctx context.Context, resourceOwner string, policy *AddLoginPolicy) (*domain.ObjectDetails, error) {
@@ -399,6 +405,9 @@ func prepareAddLoginPolicy(a *org.Aggregate, policy *AddLoginPolicy) preparation
 		if ok := domain.ValidateDefaultRedirectURI(policy.DefaultRedirectURI); !ok {
 			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-WSfdq", "Errors.Org.LoginPolicy.RedirectURIInvalid")
 		}
+		if !domain.ValidateRiskThresholds(policy.RiskStepUpThreshold, policy.RiskBlockThreshold) {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Tz8pe", "Errors.Org.LoginPolicy.RiskThresholdsInvalid")
+		}
 		for _, factor := range policy.SecondFactors {
 			if !factor.Valid() {
 				return nil, caos_errs.ThrowInvalidArgument(nil, "Org-SFeea", "Errors.Org.LoginPolicy.MFA.Unspecified")
@@ -430,6 +439,9 @@ func prepareAddLoginPolicy(a *org.Aggregate, policy *AddLoginPolicy) preparation
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowEmailMagicLink,
+				policy.RiskStepUpThreshold,
+				policy.RiskBlockThreshold,
 				policy.PasswordlessType,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
@@ -457,6 +469,9 @@ func prepareChangeLoginPolicy(a *org.Aggregate, policy *ChangeLoginPolicy) prepa
 		if ok := domain.ValidateDefaultRedirectURI(policy.DefaultRedirectURI); !ok {
 			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Sfd21", "Errors.Org.LoginPolicy.RedirectURIInvalid")
 		}
+		if !domain.ValidateRiskThresholds(policy.RiskStepUpThreshold, policy.RiskBlockThreshold) {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Kd2xn", "Errors.Org.LoginPolicy.RiskThresholdsInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			wm := NewOrgLoginPolicyWriteModel(a.ID)
 			if err := queryAndReduce(ctx, filter, wm); err != nil {
@@ -475,6 +490,9 @@ func prepareChangeLoginPolicy(a *org.Aggregate, policy *ChangeLoginPolicy) prepa
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowEmailMagicLink,
+				policy.RiskStepUpThreshold,
+				policy.RiskBlockThreshold,
 				policy.PasswordlessType,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
@@ -71,7 +71,10 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowEmailMagicLink bool,
+	riskStepUpThreshold,
+	riskBlockThreshold uint32,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -130,6 +133,15 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	if wm.DisableLoginWithPhone != disableLoginWithPhone {
 		changes = append(changes, policy.ChangeDisableLoginWithPhone(disableLoginWithPhone))
 	}
+	if wm.AllowEmailMagicLink != allowEmailMagicLink {
+		changes = append(changes, policy.ChangeAllowEmailMagicLink(allowEmailMagicLink))
+	}
+	if wm.RiskStepUpThreshold != riskStepUpThreshold {
+		changes = append(changes, policy.ChangeRiskStepUpThreshold(riskStepUpThreshold))
+	}
+	if wm.RiskBlockThreshold != riskBlockThreshold {
+		changes = append(changes, policy.ChangeRiskBlockThreshold(riskBlockThreshold))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
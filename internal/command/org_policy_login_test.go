@@ -63,6 +63,9 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 								true,
 								false,
 								false,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -118,6 +121,9 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
+									0,
+									0,
 									domain.PasswordlessTypeAllowed,
 									"https://example.com/redirect",
 									time.Hour*1,
@@ -213,6 +219,9 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
+									0,
+									0,
 									domain.PasswordlessTypeAllowed,
 									"https://example.com/redirect",
 									time.Hour*1,
@@ -341,6 +350,9 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
+									0,
+									0,
 									domain.PasswordlessTypeAllowed,
 									"https://example.com/redirect",
 									time.Hour*1,
@@ -480,6 +492,9 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -536,6 +551,9 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -686,6 +704,9 @@ func TestCommandSide_RemoveLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -829,6 +850,9 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -873,6 +897,9 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -937,6 +964,9 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1104,6 +1134,9 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1148,6 +1181,9 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1204,6 +1240,9 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1267,6 +1306,9 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1338,6 +1380,9 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// SetOrgMailTemplateMessage sets the template of the organization for a specific message type,
+// which takes precedence over the message template of the instance and the mail templates
+func (c *Commands) SetOrgMailTemplateMessage(ctx context.Context, resourceOwner string, template *domain.MailMessageTemplate) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Jd0sw", "Errors.ResourceOwnerMissing")
+	}
+	if !template.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-r4Fnq", "Errors.Org.MailTemplate.MessageInvalid")
+	}
+	existing := NewOrgMailTemplateMessageWriteModel(resourceOwner, template.MessageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.hasChanged(template) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-eK1ub", "Errors.Org.MailTemplate.MessageNotChanged")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.MailTemplateMessageWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMailTemplateMessageSetEvent(ctx, orgAgg, template.MessageType, template.Subject, template.HTML, template.Text))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.MailTemplateMessageWriteModel.WriteModel), nil
+}
+
+// RemoveOrgMailTemplateMessage removes the template of the organization for a specific message type
+func (c *Commands) RemoveOrgMailTemplateMessage(ctx context.Context, resourceOwner, messageType string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Gq3vd", "Errors.ResourceOwnerMissing")
+	}
+	if !domain.IsMailMessageType(messageType) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-u7Wnc", "Errors.Org.MailTemplate.MessageInvalid")
+	}
+	existing := NewOrgMailTemplateMessageWriteModel(resourceOwner, messageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Lb5sk", "Errors.Org.MailTemplate.MessageNotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.MailTemplateMessageWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMailTemplateMessageRemovedEvent(ctx, orgAgg, messageType))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.MailTemplateMessageWriteModel.WriteModel), nil
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgMailTemplateMessageWriteModel struct {
+	MailTemplateMessageWriteModel
+}
+
+func NewOrgMailTemplateMessageWriteModel(orgID, messageType string) *OrgMailTemplateMessageWriteModel {
+	return &OrgMailTemplateMessageWriteModel{
+		MailTemplateMessageWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			MessageType: messageType,
+		},
+	}
+}
+
+func (wm *OrgMailTemplateMessageWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.MailTemplateMessageSetEvent:
+			wm.MailTemplateMessageWriteModel.AppendEvents(&e.MailTemplateMessageSetEvent)
+		case *org.MailTemplateMessageRemovedEvent:
+			wm.MailTemplateMessageWriteModel.AppendEvents(&e.MailTemplateMessageRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgMailTemplateMessageWriteModel) Reduce() error {
+	return wm.MailTemplateMessageWriteModel.Reduce()
+}
+
+func (wm *OrgMailTemplateMessageWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.MailTemplateMessageWriteModel.AggregateID).
+		EventTypes(
+			org.MailTemplateMessageSetEventType,
+			org.MailTemplateMessageRemovedEventType).
+		Builder()
+}
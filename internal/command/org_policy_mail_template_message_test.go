@@ -0,0 +1,306 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestCommandSide_SetOrgMailTemplateMessage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		orgID    string
+		template *domain.MailMessageTemplate
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					HTML:        []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "sms message type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.VerifyPhoneMessageType,
+					Text:        []byte("{{.Text}}"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unparsable template, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					HTML:        []byte("<p>{{.Text</p>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								"",
+								[]byte("<p>{{.Text}}</p>"),
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					HTML:        []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.PasswordResetMessageType,
+								"",
+								[]byte("<p>{{.Text}}</p>"),
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewMailTemplateMessageSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									domain.InitCodeMessageType,
+									"{{.Subject}} - ACME",
+									[]byte("<p>{{.Text}}</p>"),
+									[]byte("{{.Text}}"),
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Subject:     "{{.Subject}} - ACME",
+					HTML:        []byte("<p>{{.Text}}</p>"),
+					Text:        []byte("{{.Text}}"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetOrgMailTemplateMessage(tt.args.ctx, tt.args.orgID, tt.args.template)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgMailTemplateMessage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		orgID       string
+		messageType string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.PasswordResetMessageType,
+								"",
+								[]byte("<p>{{.Text}}</p>"),
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								"",
+								[]byte("<p>{{.Text}}</p>"),
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewMailTemplateMessageRemovedEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									domain.InitCodeMessageType,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveOrgMailTemplateMessage(tt.args.ctx, tt.args.orgID, tt.args.messageType)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -10,12 +10,23 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
-func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+// NotificationPolicy defines which notifications are sent to the users
+type NotificationPolicy struct {
+	PasswordChange           bool
+	NewDeviceLogin           bool
+	MFAChange                bool
+	EmailChange              bool
+	PhoneChange              bool
+	AccountLocked            bool
+	PersonalAccessTokenAdded bool
+}
+
+func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner string, notificationPolicy *NotificationPolicy) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-x801sk2i", "Errors.ResourceOwnerMissing")
 	}
 	orgAgg := org.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddNotificationPolicy(orgAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddNotificationPolicy(orgAgg, notificationPolicy))
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +39,7 @@ func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner stri
 
 func prepareAddNotificationPolicy(
 	a *org.Aggregate,
-	passwordChange bool,
+	notificationPolicy *NotificationPolicy,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -45,18 +56,28 @@ func prepareAddNotificationPolicy(
 				return nil, caos_errs.ThrowAlreadyExists(nil, "Org-xa08n2", "Errors.Org.NotificationPolicy.AlreadyExists")
 			}
 			return []eventstore.Command{
-				org.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, passwordChange),
+				org.NewNotificationPolicyAddedEvent(
+					ctx,
+					&a.Aggregate,
+					notificationPolicy.PasswordChange,
+					notificationPolicy.NewDeviceLogin,
+					notificationPolicy.MFAChange,
+					notificationPolicy.EmailChange,
+					notificationPolicy.PhoneChange,
+					notificationPolicy.AccountLocked,
+					notificationPolicy.PersonalAccessTokenAdded,
+				),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner string, notificationPolicy *NotificationPolicy) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-x091n1g", "Errors.ResourceOwnerMissing")
 	}
 	orgAgg := org.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeNotificationPolicy(orgAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeNotificationPolicy(orgAgg, notificationPolicy))
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +90,7 @@ func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner s
 
 func prepareChangeNotificationPolicy(
 	a *org.Aggregate,
-	passwordChange bool,
+	notificationPolicy *NotificationPolicy,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -86,7 +107,7 @@ func prepareChangeNotificationPolicy(
 			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
 				return nil, caos_errs.ThrowNotFound(nil, "ORG-x029n3", "Errors.Org.NotificationPolicy.NotFound")
 			}
-			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, passwordChange)
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, notificationPolicy)
 			if !hasChanged {
 				return nil, caos_errs.ThrowPreconditionFailed(nil, "Org-ioqnxz", "Errors.Org.NotificationPolicy.NotChanged")
 			}
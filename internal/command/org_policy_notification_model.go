@@ -5,7 +5,6 @@ import (
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/org"
-	"github.com/zitadel/zitadel/internal/repository/policy"
 )
 
 type OrgNotificationPolicyWriteModel struct {
@@ -55,13 +54,9 @@ func (wm *OrgNotificationPolicyWriteModel) Query() *eventstore.SearchQueryBuilde
 func (wm *OrgNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	notificationPolicy *NotificationPolicy,
 ) (*org.NotificationPolicyChangedEvent, bool) {
-
-	changes := make([]policy.NotificationPolicyChanges, 0)
-	if wm.PasswordChange != passwordChange {
-		changes = append(changes, policy.ChangePasswordChange(passwordChange))
-	}
+	changes := wm.NotificationPolicyWriteModel.changes(notificationPolicy)
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -19,9 +19,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx                context.Context
+		orgID              string
+		notificationPolicy *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -41,9 +41,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsErrorInvalidArgument,
@@ -59,15 +59,21 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsErrorAlreadyExists,
@@ -85,6 +91,12 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 								org.NewNotificationPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									true,
+									false,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -92,9 +104,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -114,6 +126,12 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 								org.NewNotificationPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									false,
+									false,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -121,9 +139,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &NotificationPolicy{PasswordChange: false},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -137,7 +155,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -156,9 +174,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx                context.Context
+		orgID              string
+		notificationPolicy *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -178,8 +196,8 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				passwordChange: true,
+				ctx:                context.Background(),
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsErrorInvalidArgument,
@@ -194,9 +212,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsNotFound,
@@ -212,15 +230,21 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: caos_errs.IsPreconditionFailed,
@@ -236,6 +260,12 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -249,9 +279,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &NotificationPolicy{PasswordChange: false},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -265,7 +295,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -337,6 +367,12 @@ func TestCommandSide_RemoveNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
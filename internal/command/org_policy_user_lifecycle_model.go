@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgUserLifecyclePolicyWriteModel struct {
+	UserLifecyclePolicyWriteModel
+}
+
+func NewOrgUserLifecyclePolicyWriteModel(orgID string) *OrgUserLifecyclePolicyWriteModel {
+	return &OrgUserLifecyclePolicyWriteModel{
+		UserLifecyclePolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgUserLifecyclePolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.UserLifecyclePolicyAddedEvent:
+			wm.UserLifecyclePolicyWriteModel.AppendEvents(&e.UserLifecyclePolicyAddedEvent)
+		case *org.UserLifecyclePolicyChangedEvent:
+			wm.UserLifecyclePolicyWriteModel.AppendEvents(&e.UserLifecyclePolicyChangedEvent)
+		case *org.UserLifecyclePolicyRemovedEvent:
+			wm.UserLifecyclePolicyWriteModel.AppendEvents(&e.UserLifecyclePolicyRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgUserLifecyclePolicyWriteModel) Reduce() error {
+	return wm.UserLifecyclePolicyWriteModel.Reduce()
+}
+
+func (wm *OrgUserLifecyclePolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.UserLifecyclePolicyWriteModel.AggregateID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.UserLifecyclePolicyAddedEventType,
+			org.UserLifecyclePolicyChangedEventType,
+			org.UserLifecyclePolicyRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgUserLifecyclePolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	lifecyclePolicy *domain.UserLifecyclePolicy,
+) (*org.UserLifecyclePolicyChangedEvent, bool) {
+	changes := wm.UserLifecyclePolicyWriteModel.changes(lifecyclePolicy)
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := org.NewUserLifecyclePolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgUserSchemaPolicyWriteModel struct {
+	UserSchemaPolicyWriteModel
+}
+
+func NewOrgUserSchemaPolicyWriteModel(orgID string) *OrgUserSchemaPolicyWriteModel {
+	return &OrgUserSchemaPolicyWriteModel{
+		UserSchemaPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgUserSchemaPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.UserSchemaPolicyAddedEvent:
+			wm.UserSchemaPolicyWriteModel.AppendEvents(&e.UserSchemaPolicyAddedEvent)
+		case *org.UserSchemaPolicyChangedEvent:
+			wm.UserSchemaPolicyWriteModel.AppendEvents(&e.UserSchemaPolicyChangedEvent)
+		case *org.UserSchemaPolicyRemovedEvent:
+			wm.UserSchemaPolicyWriteModel.AppendEvents(&e.UserSchemaPolicyRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgUserSchemaPolicyWriteModel) Reduce() error {
+	return wm.UserSchemaPolicyWriteModel.Reduce()
+}
+
+func (wm *OrgUserSchemaPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.UserSchemaPolicyWriteModel.AggregateID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.UserSchemaPolicyAddedEventType,
+			org.UserSchemaPolicyChangedEventType,
+			org.UserSchemaPolicyRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgUserSchemaPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	schemaPolicy *domain.UserSchemaPolicy,
+) (*org.UserSchemaPolicyChangedEvent, bool) {
+	changes := wm.UserSchemaPolicyWriteModel.changes(schemaPolicy)
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := org.NewUserSchemaPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -0,0 +1,452 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+func TestCommandSide_AddUserSchemaPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx          context.Context
+		orgID        string
+		schemaPolicy *domain.UserSchemaPolicy
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:          context.Background(),
+				orgID:        "",
+				schemaPolicy: &domain.UserSchemaPolicy{},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid attribute name, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{{Name: "1 department"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "duplicate attribute name, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{{Name: "department"}, {Name: "department"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "policy already existing, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{{Name: "department"}},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{{Name: "department"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add policy, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewUserSchemaPolicyAddedEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									[]*policy.UserSchemaAttribute{
+										{
+											Name:          "employee_id",
+											DisplayName:   "Employee ID",
+											Type:          domain.UserSchemaAttributeTypeNumber,
+											Required:      true,
+											Unique:        true,
+											Visibility:    domain.UserSchemaAttributeVisibilitySelf,
+											InTokenClaims: true,
+										},
+									},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{
+						{
+							Name:          "employee_id",
+							DisplayName:   "Employee ID",
+							Type:          domain.UserSchemaAttributeTypeNumber,
+							Required:      true,
+							Unique:        true,
+							Visibility:    domain.UserSchemaAttributeVisibilitySelf,
+							InTokenClaims: true,
+						},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.AddUserSchemaPolicy(tt.args.ctx, tt.args.orgID, tt.args.schemaPolicy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeUserSchemaPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx          context.Context
+		orgID        string
+		schemaPolicy *domain.UserSchemaPolicy
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:          context.Background(),
+				schemaPolicy: &domain.UserSchemaPolicy{},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid pattern, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{{Name: "department", Pattern: "[a-z"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "policy not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{{Name: "department"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{{Name: "department"}},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{{Name: "department"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{{Name: "department"}},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newUserSchemaPolicyChangedEvent(context.Background(), "org1",
+									policy.ChangeUserSchemaAttributes([]*policy.UserSchemaAttribute{
+										{Name: "department", Required: true},
+										{Name: "birthday", Type: domain.UserSchemaAttributeTypeDate},
+									}),
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				schemaPolicy: &domain.UserSchemaPolicy{
+					Attributes: []*domain.UserSchemaAttribute{
+						{Name: "department", Required: true},
+						{Name: "birthday", Type: domain.UserSchemaAttributeTypeDate},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ChangeUserSchemaPolicy(tt.args.ctx, tt.args.orgID, tt.args.schemaPolicy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveUserSchemaPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		orgID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "policy not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{{Name: "department"}},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewUserSchemaPolicyRemovedEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveUserSchemaPolicy(tt.args.ctx, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newUserSchemaPolicyChangedEvent(ctx context.Context, orgID string, changes ...policy.UserSchemaPolicyChanges) *org.UserSchemaPolicyChangedEvent {
+	event, _ := org.NewUserSchemaPolicyChangedEvent(ctx,
+		&org.NewAggregate(orgID).Aggregate,
+		changes,
+	)
+	return event
+}
@@ -0,0 +1,182 @@
+package command
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// AddOrgSMTPConfig adds the SMTP configuration of the organization,
+// which is used for sending the emails to its users before the configurations of the instance.
+// The domain of the sender address must be a verified domain of the organization.
+func (c *Commands) AddOrgSMTPConfig(ctx context.Context, resourceOwner string, config *smtp.Config) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Sm3ta", "Errors.ResourceOwnerMissing")
+	}
+	from, hostAndPort, xoauth2, err := validateOrgSMTPConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := c.orgSMTPConfigWriteModel(ctx, resourceOwner, domain.SMTPSenderDomain(from))
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == domain.SMTPConfigStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "ORG-Fq8vz", "Errors.Org.SMTPConfig.AlreadyExists")
+	}
+	if !existing.domainVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Bv2ky", "Errors.Org.SMTPConfig.SenderDomainNotVerified")
+	}
+	var password *crypto.CryptoValue
+	if config.SMTP.Password != "" {
+		password, err = crypto.Encrypt([]byte(config.SMTP.Password), c.smtpEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigAddedEvent(
+		ctx,
+		orgAgg,
+		config.Tls,
+		from,
+		config.FromName,
+		hostAndPort,
+		config.SMTP.User,
+		password,
+		smtpAuthType(xoauth2),
+		xoauth2,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) ChangeOrgSMTPConfig(ctx context.Context, resourceOwner string, config *smtp.Config) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Jr6cw", "Errors.ResourceOwnerMissing")
+	}
+	from, hostAndPort, xoauth2, err := validateOrgSMTPConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := c.orgSMTPConfigWriteModel(ctx, resourceOwner, domain.SMTPSenderDomain(from))
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.SMTPConfigStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Zt4mn", "Errors.Org.SMTPConfig.NotFound")
+	}
+	if !existing.domainVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Lc9dx", "Errors.Org.SMTPConfig.SenderDomainNotVerified")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	changedEvent, hasChanged, err := existing.NewChangedEvent(ctx, orgAgg, config.Tls, from, config.FromName, hostAndPort, config.SMTP.User, xoauth2)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Ne5hp", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// ChangeOrgSMTPConfigPassword changes the password of the SMTP configuration of the organization,
+// which is used as client secret if the configuration authenticates with XOAUTH2
+func (c *Commands) ChangeOrgSMTPConfigPassword(ctx context.Context, resourceOwner, password string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Dp5rk", "Errors.ResourceOwnerMissing")
+	}
+	existing, err := c.orgSMTPConfigWriteModel(ctx, resourceOwner, "")
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.SMTPConfigStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Ug7wb", "Errors.Org.SMTPConfig.NotFound")
+	}
+	var smtpPassword *crypto.CryptoValue
+	if password != "" {
+		smtpPassword, err = crypto.Encrypt([]byte(password), c.smtpEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigPasswordChangedEvent(ctx, orgAgg, smtpPassword))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// RemoveOrgSMTPConfig removes the SMTP configuration of the organization,
+// the emails are then sent over the configurations of the instance
+func (c *Commands) RemoveOrgSMTPConfig(ctx context.Context, resourceOwner string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Hs8ea", "Errors.ResourceOwnerMissing")
+	}
+	existing, err := c.orgSMTPConfigWriteModel(ctx, resourceOwner, "")
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.SMTPConfigStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Qm1fy", "Errors.Org.SMTPConfig.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigRemovedEvent(ctx, orgAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) orgSMTPConfigWriteModel(ctx context.Context, resourceOwner, senderDomain string) (*OrgSMTPConfigWriteModel, error) {
+	writeModel := NewOrgSMTPConfigWriteModel(resourceOwner, senderDomain)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func validateOrgSMTPConfig(config *smtp.Config) (from, hostAndPort string, xoauth2 *domain.SMTPXOAuth2, err error) {
+	if from = strings.TrimSpace(config.From); from == "" {
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Tn6qe", "Errors.Invalid.Argument")
+	}
+	hostAndPort = strings.TrimSpace(config.SMTP.Host)
+	if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Yk3ws", "Errors.Invalid.Argument")
+	}
+	xoauth2 = smtpXOAuth2ToDomain(config.SMTP.XOAuth2)
+	if err := validateSMTPXOAuth2(config.SMTP.User, xoauth2); err != nil {
+		return "", "", nil, err
+	}
+	return from, hostAndPort, xoauth2, nil
+}
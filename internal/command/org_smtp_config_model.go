@@ -0,0 +1,168 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgSMTPConfigWriteModel struct {
+	eventstore.WriteModel
+
+	SenderAddress string
+	SenderName    string
+	TLS           bool
+	Host          string
+	User          string
+	Password      *crypto.CryptoValue
+	AuthType      domain.SMTPAuthType
+	XOAuth2       *domain.SMTPXOAuth2
+	State         domain.SMTPConfigState
+
+	domain         string
+	domainVerified bool
+}
+
+func NewOrgSMTPConfigWriteModel(orgID, domain string) *OrgSMTPConfigWriteModel {
+	return &OrgSMTPConfigWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		domain: domain,
+	}
+}
+
+func (wm *OrgSMTPConfigWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.DomainVerifiedEvent:
+			if e.Domain != wm.domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.DomainRemovedEvent:
+			if e.Domain != wm.domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		default:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgSMTPConfigWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.SMTPConfigAddedEvent:
+			wm.TLS = e.TLS
+			wm.SenderAddress = e.SenderAddress
+			wm.SenderName = e.SenderName
+			wm.Host = e.Host
+			wm.User = e.User
+			wm.Password = e.Password
+			wm.AuthType = e.AuthType
+			wm.XOAuth2 = e.XOAuth2
+			wm.State = domain.SMTPConfigStateActive
+		case *org.SMTPConfigChangedEvent:
+			if e.TLS != nil {
+				wm.TLS = *e.TLS
+			}
+			if e.FromAddress != nil {
+				wm.SenderAddress = *e.FromAddress
+			}
+			if e.FromName != nil {
+				wm.SenderName = *e.FromName
+			}
+			if e.Host != nil {
+				wm.Host = *e.Host
+			}
+			if e.User != nil {
+				wm.User = *e.User
+			}
+			if e.AuthType != nil {
+				wm.AuthType = *e.AuthType
+			}
+			if e.XOAuth2 != nil {
+				wm.XOAuth2 = e.XOAuth2
+			}
+		case *org.SMTPConfigPasswordChangedEvent:
+			wm.Password = e.Password
+		case *org.SMTPConfigRemovedEvent:
+			wm.State = domain.SMTPConfigStateRemoved
+			wm.TLS = false
+			wm.SenderName = ""
+			wm.SenderAddress = ""
+			wm.Host = ""
+			wm.User = ""
+			wm.Password = nil
+			wm.AuthType = domain.SMTPAuthTypePlain
+			wm.XOAuth2 = nil
+		case *org.OrgRemovedEvent:
+			wm.State = domain.SMTPConfigStateRemoved
+		case *org.DomainVerifiedEvent:
+			wm.domainVerified = true
+		case *org.DomainRemovedEvent:
+			wm.domainVerified = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.SMTPConfigAddedEventType,
+			org.SMTPConfigChangedEventType,
+			org.SMTPConfigPasswordChangedEventType,
+			org.SMTPConfigRemovedEventType,
+			org.OrgRemovedEventType,
+			org.OrgDomainVerifiedEventType,
+			org.OrgDomainRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, tls bool, fromAddress, fromName, smtpHost, smtpUser string, xoauth2 *domain.SMTPXOAuth2) (*org.SMTPConfigChangedEvent, bool, error) {
+	changes := make([]org.SMTPConfigChanges, 0)
+	var err error
+
+	if authType := smtpAuthType(xoauth2); wm.AuthType != authType {
+		changes = append(changes, org.ChangeSMTPConfigAuthType(authType))
+	}
+	if xoauth2 != nil && !reflect.DeepEqual(wm.XOAuth2, xoauth2) {
+		changes = append(changes, org.ChangeSMTPConfigXOAuth2(xoauth2))
+	}
+	if wm.TLS != tls {
+		changes = append(changes, org.ChangeSMTPConfigTLS(tls))
+	}
+	if wm.SenderAddress != fromAddress {
+		changes = append(changes, org.ChangeSMTPConfigFromAddress(fromAddress))
+	}
+	if wm.SenderName != fromName {
+		changes = append(changes, org.ChangeSMTPConfigFromName(fromName))
+	}
+	if wm.Host != smtpHost {
+		changes = append(changes, org.ChangeSMTPConfigSMTPHost(smtpHost))
+	}
+	if wm.User != smtpUser {
+		changes = append(changes, org.ChangeSMTPConfigSMTPUser(smtpUser))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := org.NewSMTPConfigChangeEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
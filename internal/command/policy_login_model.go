@@ -20,6 +20,9 @@ type LoginPolicyWriteModel struct {
 	AllowDomainDiscovery       bool
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowEmailMagicLink        bool
+	RiskStepUpThreshold        uint32
+	RiskBlockThreshold         uint32
 	PasswordlessType           domain.PasswordlessType
 	DefaultRedirectURI         string
 	PasswordCheckLifetime      time.Duration
@@ -44,6 +47,9 @@ func (wm *LoginPolicyWriteModel) Reduce() error {
 			wm.AllowDomainDiscovery = e.AllowDomainDiscovery
 			wm.DisableLoginWithEmail = e.DisableLoginWithEmail
 			wm.DisableLoginWithPhone = e.DisableLoginWithPhone
+			wm.AllowEmailMagicLink = e.AllowEmailMagicLink
+			wm.RiskStepUpThreshold = e.RiskStepUpThreshold
+			wm.RiskBlockThreshold = e.RiskBlockThreshold
 			wm.DefaultRedirectURI = e.DefaultRedirectURI
 			wm.PasswordCheckLifetime = e.PasswordCheckLifetime
 			wm.ExternalLoginCheckLifetime = e.ExternalLoginCheckLifetime
@@ -100,6 +106,15 @@ func (wm *LoginPolicyWriteModel) Reduce() error {
 			if e.DisableLoginWithPhone != nil {
 				wm.DisableLoginWithPhone = *e.DisableLoginWithPhone
 			}
+			if e.AllowEmailMagicLink != nil {
+				wm.AllowEmailMagicLink = *e.AllowEmailMagicLink
+			}
+			if e.RiskStepUpThreshold != nil {
+				wm.RiskStepUpThreshold = *e.RiskStepUpThreshold
+			}
+			if e.RiskBlockThreshold != nil {
+				wm.RiskBlockThreshold = *e.RiskBlockThreshold
+			}
 		case *policy.LoginPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type MailTemplateMessageWriteModel struct {
+	eventstore.WriteModel
+
+	MessageType string
+	Subject     string
+	HTML        []byte
+	Text        []byte
+
+	State domain.PolicyState
+}
+
+func (wm *MailTemplateMessageWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.MailTemplateMessageSetEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.Subject = e.Subject
+			wm.HTML = e.HTML
+			wm.Text = e.Text
+			wm.State = domain.PolicyStateActive
+		case *policy.MailTemplateMessageRemovedEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.Subject = ""
+			wm.HTML = nil
+			wm.Text = nil
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *MailTemplateMessageWriteModel) hasChanged(template *domain.MailMessageTemplate) bool {
+	return wm.State != domain.PolicyStateActive ||
+		wm.Subject != template.Subject ||
+		string(wm.HTML) != string(template.HTML) ||
+		string(wm.Text) != string(template.Text)
+}
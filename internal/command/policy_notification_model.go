@@ -9,8 +9,14 @@ import (
 type NotificationPolicyWriteModel struct {
 	eventstore.WriteModel
 
-	PasswordChange bool
-	State          domain.PolicyState
+	PasswordChange           bool
+	NewDeviceLogin           bool
+	MFAChange                bool
+	EmailChange              bool
+	PhoneChange              bool
+	AccountLocked            bool
+	PersonalAccessTokenAdded bool
+	State                    domain.PolicyState
 }
 
 func (wm *NotificationPolicyWriteModel) Reduce() error {
@@ -18,14 +24,64 @@ func (wm *NotificationPolicyWriteModel) Reduce() error {
 		switch e := event.(type) {
 		case *policy.NotificationPolicyAddedEvent:
 			wm.PasswordChange = e.PasswordChange
+			wm.NewDeviceLogin = e.NewDeviceLogin
+			wm.MFAChange = e.MFAChange
+			wm.EmailChange = e.EmailChange
+			wm.PhoneChange = e.PhoneChange
+			wm.AccountLocked = e.AccountLocked
+			wm.PersonalAccessTokenAdded = e.PersonalAccessTokenAdded
 			wm.State = domain.PolicyStateActive
 		case *policy.NotificationPolicyChangedEvent:
 			if e.PasswordChange != nil {
 				wm.PasswordChange = *e.PasswordChange
 			}
+			if e.NewDeviceLogin != nil {
+				wm.NewDeviceLogin = *e.NewDeviceLogin
+			}
+			if e.MFAChange != nil {
+				wm.MFAChange = *e.MFAChange
+			}
+			if e.EmailChange != nil {
+				wm.EmailChange = *e.EmailChange
+			}
+			if e.PhoneChange != nil {
+				wm.PhoneChange = *e.PhoneChange
+			}
+			if e.AccountLocked != nil {
+				wm.AccountLocked = *e.AccountLocked
+			}
+			if e.PersonalAccessTokenAdded != nil {
+				wm.PersonalAccessTokenAdded = *e.PersonalAccessTokenAdded
+			}
 		case *policy.NotificationPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
+
+func (wm *NotificationPolicyWriteModel) changes(notificationPolicy *NotificationPolicy) []policy.NotificationPolicyChanges {
+	changes := make([]policy.NotificationPolicyChanges, 0)
+	if wm.PasswordChange != notificationPolicy.PasswordChange {
+		changes = append(changes, policy.ChangePasswordChange(notificationPolicy.PasswordChange))
+	}
+	if wm.NewDeviceLogin != notificationPolicy.NewDeviceLogin {
+		changes = append(changes, policy.ChangeNewDeviceLogin(notificationPolicy.NewDeviceLogin))
+	}
+	if wm.MFAChange != notificationPolicy.MFAChange {
+		changes = append(changes, policy.ChangeMFAChange(notificationPolicy.MFAChange))
+	}
+	if wm.EmailChange != notificationPolicy.EmailChange {
+		changes = append(changes, policy.ChangeEmailChange(notificationPolicy.EmailChange))
+	}
+	if wm.PhoneChange != notificationPolicy.PhoneChange {
+		changes = append(changes, policy.ChangePhoneChange(notificationPolicy.PhoneChange))
+	}
+	if wm.AccountLocked != notificationPolicy.AccountLocked {
+		changes = append(changes, policy.ChangeAccountLocked(notificationPolicy.AccountLocked))
+	}
+	if wm.PersonalAccessTokenAdded != notificationPolicy.PersonalAccessTokenAdded {
+		changes = append(changes, policy.ChangePersonalAccessTokenAdded(notificationPolicy.PersonalAccessTokenAdded))
+	}
+	return changes
+}
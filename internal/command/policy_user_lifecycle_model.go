@@ -0,0 +1,81 @@
+package command
+
+import (
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type UserLifecyclePolicyWriteModel struct {
+	eventstore.WriteModel
+
+	DeactivateAfterDays uint64
+	DeleteAfterDays     uint64
+	WarnDaysBefore      uint64
+	ExcludeMachineUsers bool
+	ExcludedUserIDs     []string
+	DryRun              bool
+	State               domain.PolicyState
+}
+
+func (wm *UserLifecyclePolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.UserLifecyclePolicyAddedEvent:
+			wm.DeactivateAfterDays = e.DeactivateAfterDays
+			wm.DeleteAfterDays = e.DeleteAfterDays
+			wm.WarnDaysBefore = e.WarnDaysBefore
+			wm.ExcludeMachineUsers = e.ExcludeMachineUsers
+			wm.ExcludedUserIDs = e.ExcludedUserIDs
+			wm.DryRun = e.DryRun
+			wm.State = domain.PolicyStateActive
+		case *policy.UserLifecyclePolicyChangedEvent:
+			if e.DeactivateAfterDays != nil {
+				wm.DeactivateAfterDays = *e.DeactivateAfterDays
+			}
+			if e.DeleteAfterDays != nil {
+				wm.DeleteAfterDays = *e.DeleteAfterDays
+			}
+			if e.WarnDaysBefore != nil {
+				wm.WarnDaysBefore = *e.WarnDaysBefore
+			}
+			if e.ExcludeMachineUsers != nil {
+				wm.ExcludeMachineUsers = *e.ExcludeMachineUsers
+			}
+			if e.ExcludedUserIDs != nil {
+				wm.ExcludedUserIDs = *e.ExcludedUserIDs
+			}
+			if e.DryRun != nil {
+				wm.DryRun = *e.DryRun
+			}
+		case *policy.UserLifecyclePolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserLifecyclePolicyWriteModel) changes(lifecyclePolicy *domain.UserLifecyclePolicy) []policy.UserLifecyclePolicyChanges {
+	changes := make([]policy.UserLifecyclePolicyChanges, 0)
+	if wm.DeactivateAfterDays != lifecyclePolicy.DeactivateAfterDays {
+		changes = append(changes, policy.ChangeDeactivateAfterDays(lifecyclePolicy.DeactivateAfterDays))
+	}
+	if wm.DeleteAfterDays != lifecyclePolicy.DeleteAfterDays {
+		changes = append(changes, policy.ChangeDeleteAfterDays(lifecyclePolicy.DeleteAfterDays))
+	}
+	if wm.WarnDaysBefore != lifecyclePolicy.WarnDaysBefore {
+		changes = append(changes, policy.ChangeWarnDaysBefore(lifecyclePolicy.WarnDaysBefore))
+	}
+	if wm.ExcludeMachineUsers != lifecyclePolicy.ExcludeMachineUsers {
+		changes = append(changes, policy.ChangeExcludeMachineUsers(lifecyclePolicy.ExcludeMachineUsers))
+	}
+	if (len(wm.ExcludedUserIDs) > 0 || len(lifecyclePolicy.ExcludedUserIDs) > 0) && !reflect.DeepEqual(wm.ExcludedUserIDs, lifecyclePolicy.ExcludedUserIDs) {
+		changes = append(changes, policy.ChangeExcludedUserIDs(lifecyclePolicy.ExcludedUserIDs))
+	}
+	if wm.DryRun != lifecyclePolicy.DryRun {
+		changes = append(changes, policy.ChangeDryRun(lifecyclePolicy.DryRun))
+	}
+	return changes
+}
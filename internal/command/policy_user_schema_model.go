@@ -0,0 +1,43 @@
+package command
+
+import (
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type UserSchemaPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	Attributes []*policy.UserSchemaAttribute
+	State      domain.PolicyState
+}
+
+func (wm *UserSchemaPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.UserSchemaPolicyAddedEvent:
+			wm.Attributes = e.Attributes
+			wm.State = domain.PolicyStateActive
+		case *policy.UserSchemaPolicyChangedEvent:
+			if e.Attributes != nil {
+				wm.Attributes = *e.Attributes
+			}
+		case *policy.UserSchemaPolicyRemovedEvent:
+			wm.Attributes = nil
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserSchemaPolicyWriteModel) changes(schemaPolicy *domain.UserSchemaPolicy) []policy.UserSchemaPolicyChanges {
+	changes := make([]policy.UserSchemaPolicyChanges, 0)
+	attributes := policy.UserSchemaAttributesFromDomain(schemaPolicy.Attributes)
+	if (len(wm.Attributes) > 0 || len(attributes) > 0) && !reflect.DeepEqual(wm.Attributes, attributes) {
+		changes = append(changes, policy.ChangeUserSchemaAttributes(attributes))
+	}
+	return changes
+}
@@ -111,6 +111,8 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.ClockSkew,
 					app.AdditionalOrigins,
 					app.SkipSuccessPageForNativeApp,
+					domain.OIDCRefreshTokenRotationEnabled,
+					0,
 				),
 			}, nil
 		}, nil
@@ -195,6 +197,8 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		oidcApp.AdditionalOrigins,
 		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.RefreshTokenRotation,
+		oidcApp.RefreshTokenReuseInterval,
 	))
 
 	addedApplication.AppID = oidcApp.AppID
@@ -247,6 +251,8 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		oidc.AdditionalOrigins,
 		oidc.SkipNativeAppSuccessPage,
+		oidc.RefreshTokenRotation,
+		oidc.RefreshTokenReuseInterval,
 	)
 	if err != nil {
 		return nil, err
@@ -14,29 +14,31 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
-	oidc                     bool
+	AppID                     string
+	AppName                   string
+	ClientID                  string
+	ClientSecret              *crypto.CryptoValue
+	ClientSecretString        string
+	RedirectUris              []string
+	ResponseTypes             []domain.OIDCResponseType
+	GrantTypes                []domain.OIDCGrantType
+	ApplicationType           domain.OIDCApplicationType
+	AuthMethodType            domain.OIDCAuthMethodType
+	PostLogoutRedirectUris    []string
+	OIDCVersion               domain.OIDCVersion
+	Compliance                *domain.Compliance
+	DevMode                   bool
+	AccessTokenType           domain.OIDCTokenType
+	AccessTokenRoleAssertion  bool
+	IDTokenRoleAssertion      bool
+	IDTokenUserinfoAssertion  bool
+	ClockSkew                 time.Duration
+	State                     domain.AppState
+	AdditionalOrigins         []string
+	SkipNativeAppSuccessPage  bool
+	RefreshTokenRotation      domain.OIDCRefreshTokenRotation
+	RefreshTokenReuseInterval time.Duration
+	oidc                      bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -158,6 +160,8 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.RefreshTokenRotation = e.RefreshTokenRotation
+	wm.RefreshTokenReuseInterval = e.RefreshTokenReuseInterval
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -206,6 +210,12 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.RefreshTokenRotation != nil {
+		wm.RefreshTokenRotation = *e.RefreshTokenRotation
+	}
+	if e.RefreshTokenReuseInterval != nil {
+		wm.RefreshTokenReuseInterval = *e.RefreshTokenReuseInterval
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -246,6 +256,8 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	refreshTokenRotation domain.OIDCRefreshTokenRotation,
+	refreshTokenReuseInterval time.Duration,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -295,6 +307,12 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.RefreshTokenRotation != refreshTokenRotation {
+		changes = append(changes, project.ChangeRefreshTokenRotation(refreshTokenRotation))
+	}
+	if wm.RefreshTokenReuseInterval != refreshTokenReuseInterval {
+		changes = append(changes, project.ChangeRefreshTokenReuseInterval(refreshTokenReuseInterval))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -170,6 +170,8 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						domain.OIDCRefreshTokenRotationEnabled,
+						0,
 					),
 				},
 			},
@@ -328,6 +330,8 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 									time.Second*1,
 									[]string{"https://sub.test.ch"},
 									true,
+									domain.OIDCRefreshTokenRotationEnabled,
+									0,
 								),
 							),
 						},
@@ -565,6 +569,8 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								domain.OIDCRefreshTokenRotationEnabled,
+								0,
 							),
 						),
 					),
@@ -639,6 +645,8 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								domain.OIDCRefreshTokenRotationEnabled,
+								0,
 							),
 						),
 					),
@@ -660,23 +668,25 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					ObjectRoot: models.ObjectRoot{
 						AggregateID: "project1",
 					},
-					AppID:                    "app1",
-					AppName:                  "app",
-					AuthMethodType:           domain.OIDCAuthMethodTypePost,
-					OIDCVersion:              domain.OIDCVersionV1,
-					RedirectUris:             []string{"https://test-change.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          domain.OIDCApplicationTypeWeb,
-					PostLogoutRedirectUris:   []string{"https://test-change.ch/logout"},
-					DevMode:                  true,
-					AccessTokenType:          domain.OIDCTokenTypeJWT,
-					AccessTokenRoleAssertion: false,
-					IDTokenRoleAssertion:     false,
-					IDTokenUserinfoAssertion: false,
-					ClockSkew:                time.Second * 2,
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: true,
+					AppID:                     "app1",
+					AppName:                   "app",
+					AuthMethodType:            domain.OIDCAuthMethodTypePost,
+					OIDCVersion:               domain.OIDCVersionV1,
+					RedirectUris:              []string{"https://test-change.ch"},
+					ResponseTypes:             []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:           domain.OIDCApplicationTypeWeb,
+					PostLogoutRedirectUris:    []string{"https://test-change.ch/logout"},
+					DevMode:                   true,
+					AccessTokenType:           domain.OIDCTokenTypeJWT,
+					AccessTokenRoleAssertion:  false,
+					IDTokenRoleAssertion:      false,
+					IDTokenUserinfoAssertion:  false,
+					ClockSkew:                 time.Second * 2,
+					AdditionalOrigins:         []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:  true,
+					RefreshTokenRotation:      domain.OIDCRefreshTokenRotationDisabled,
+					RefreshTokenReuseInterval: time.Minute,
 				},
 				resourceOwner: "org1",
 			},
@@ -686,26 +696,28 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:                    "app1",
-					ClientID:                 "client1@project",
-					AppName:                  "app",
-					AuthMethodType:           domain.OIDCAuthMethodTypePost,
-					OIDCVersion:              domain.OIDCVersionV1,
-					RedirectUris:             []string{"https://test-change.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          domain.OIDCApplicationTypeWeb,
-					PostLogoutRedirectUris:   []string{"https://test-change.ch/logout"},
-					DevMode:                  true,
-					AccessTokenType:          domain.OIDCTokenTypeJWT,
-					AccessTokenRoleAssertion: false,
-					IDTokenRoleAssertion:     false,
-					IDTokenUserinfoAssertion: false,
-					ClockSkew:                time.Second * 2,
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: true,
-					Compliance:               &domain.Compliance{},
-					State:                    domain.AppStateActive,
+					AppID:                     "app1",
+					ClientID:                  "client1@project",
+					AppName:                   "app",
+					AuthMethodType:            domain.OIDCAuthMethodTypePost,
+					OIDCVersion:               domain.OIDCVersionV1,
+					RedirectUris:              []string{"https://test-change.ch"},
+					ResponseTypes:             []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:           domain.OIDCApplicationTypeWeb,
+					PostLogoutRedirectUris:    []string{"https://test-change.ch/logout"},
+					DevMode:                   true,
+					AccessTokenType:           domain.OIDCTokenTypeJWT,
+					AccessTokenRoleAssertion:  false,
+					IDTokenRoleAssertion:      false,
+					IDTokenUserinfoAssertion:  false,
+					ClockSkew:                 time.Second * 2,
+					AdditionalOrigins:         []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:  true,
+					RefreshTokenRotation:      domain.OIDCRefreshTokenRotationDisabled,
+					RefreshTokenReuseInterval: time.Minute,
+					Compliance:                &domain.Compliance{},
+					State:                     domain.AppStateActive,
 				},
 			},
 		},
@@ -840,6 +852,8 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								domain.OIDCRefreshTokenRotationEnabled,
+								0,
 							),
 						),
 					),
@@ -926,6 +940,8 @@ func newOIDCAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner
 		project.ChangeIDTokenRoleAssertion(false),
 		project.ChangeIDTokenUserinfoAssertion(false),
 		project.ChangeClockSkew(time.Second * 2),
+		project.ChangeRefreshTokenRotation(domain.OIDCRefreshTokenRotationDisabled),
+		project.ChangeRefreshTokenReuseInterval(time.Minute),
 	}
 	event, _ := project.NewOIDCConfigChangedEvent(ctx,
 		&project.NewAggregate(projectID, resourceOwner).Aggregate,
@@ -0,0 +1,273 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+// OIDCInitialAccessToken allows the registration of OIDC clients
+// in a project through Dynamic Client Registration (RFC 7591).
+type OIDCInitialAccessToken struct {
+	models.ObjectRoot
+
+	ExpirationDate time.Time
+	Policy         domain.OIDCClientRegistrationPolicy
+
+	TokenID string
+	Token   string
+}
+
+func (c *Commands) AddOIDCInitialAccessToken(ctx context.Context, token *OIDCInitialAccessToken) (_ *domain.ObjectDetails, err error) {
+	if token.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Thoo9", "Errors.Project.ProjectIDMissing")
+	}
+	token.ExpirationDate, err = domain.ValidateExpirationDate(token.ExpirationDate)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.getProjectByID(ctx, token.AggregateID, token.ResourceOwner); err != nil {
+		return nil, err
+	}
+	token.TokenID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewOIDCInitialAccessTokenWriteModel(token.AggregateID, token.TokenID, token.ResourceOwner)
+	var tokenHash *crypto.CryptoValue
+	token.Token, tokenHash, err = domain.NewOIDCRegistrationToken(domain.OIDCRegistrationTokenTypeInitialAccess, token.AggregateID, token.TokenID, c.keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewOIDCInitialAccessTokenAddedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		token.TokenID,
+		tokenHash,
+		token.ExpirationDate,
+		token.Policy.AllowedRedirectURIPrefixes,
+		token.Policy.AllowedGrantTypes,
+		token.Policy.AllowedAuthMethodTypes,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveOIDCInitialAccessToken(ctx context.Context, projectID, tokenID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || tokenID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ue3Ae", "Errors.IDMissing")
+	}
+	writeModel, err := c.getOIDCInitialAccessTokenWriteModel(ctx, projectID, tokenID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Phie4", "Errors.Project.App.Registration.TokenNotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewOIDCInitialAccessTokenRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		tokenID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RegisterOIDCClient creates a new OIDC application in the project of the provided initial access token,
+// if the application satisfies the restrictions of the token.
+// It returns the created application and a registration access token for managing the client (RFC 7592).
+// The application and the registration access token are pushed together, so there is never a client without a token.
+func (c *Commands) RegisterOIDCClient(ctx context.Context, initialAccessToken string, app *domain.OIDCApp, clientSecretAlg crypto.HashAlgorithm) (_ *domain.OIDCApp, registrationAccessToken string, err error) {
+	projectID, tokenID, secret, err := domain.FromOIDCRegistrationToken(initialAccessToken, domain.OIDCRegistrationTokenTypeInitialAccess, c.keyAlgorithm)
+	if err != nil {
+		return nil, "", err
+	}
+	initialToken, err := c.getOIDCInitialAccessTokenWriteModel(ctx, projectID, tokenID, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if !initialToken.Usable(time.Now()) {
+		return nil, "", errors.ThrowUnauthenticated(nil, "COMMAND-aiF5u", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	if err = domain.VerifyOIDCRegistrationTokenSecret(secret, initialToken.TokenHash); err != nil {
+		return nil, "", err
+	}
+	if err = initialToken.Policy.Check(app); err != nil {
+		return nil, "", err
+	}
+	registrationTokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	if app.AppName == "" {
+		app.AppName = "dynamic-client-" + registrationTokenID
+	}
+	if !app.IsValid() {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Oow4a", "Errors.Project.App.Invalid")
+	}
+	appID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	registrationAccessToken, registrationTokenHash, err := domain.NewOIDCRegistrationToken(domain.OIDCRegistrationTokenTypeRegistrationAccess, projectID, registrationTokenID, c.keyAlgorithm)
+	if err != nil {
+		return nil, "", err
+	}
+	projectAgg := project.NewAggregate(projectID, initialToken.ResourceOwner)
+	addApp := &addOIDCApp{
+		AddApp: AddApp{
+			Aggregate: *projectAgg,
+			ID:        appID,
+			Name:      app.AppName,
+		},
+		Version:                     app.OIDCVersion,
+		RedirectUris:                app.RedirectUris,
+		ResponseTypes:               app.ResponseTypes,
+		GrantTypes:                  app.GrantTypes,
+		ApplicationType:             app.ApplicationType,
+		AuthMethodType:              app.AuthMethodType,
+		PostLogoutRedirectUris:      app.PostLogoutRedirectUris,
+		DevMode:                     app.DevMode,
+		AccessTokenType:             app.AccessTokenType,
+		AccessTokenRoleAssertion:    app.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:        app.IDTokenRoleAssertion,
+		IDTokenUserinfoAssertion:    app.IDTokenUserinfoAssertion,
+		ClockSkew:                   app.ClockSkew,
+		AdditionalOrigins:           app.AdditionalOrigins,
+		SkipSuccessPageForNativeApp: app.SkipNativeAppSuccessPage,
+	}
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter,
+		c.AddOIDCAppCommand(addApp, clientSecretAlg),
+		prepareAddOIDCRegistrationAccessToken(&projectAgg.Aggregate, appID, registrationTokenID, registrationTokenHash, tokenID),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, "", err
+	}
+	writeModel := NewOIDCApplicationWriteModelWithAppID(projectID, appID, initialToken.ResourceOwner)
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, "", err
+	}
+	result := oidcWriteModelToOIDCConfig(writeModel)
+	result.ClientSecretString = addApp.ClientSecretPlain
+	result.FillCompliance()
+	return result, registrationAccessToken, nil
+}
+
+func prepareAddOIDCRegistrationAccessToken(agg *eventstore.Aggregate, appID, tokenID string, tokenHash *crypto.CryptoValue, initialAccessTokenID string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			return []eventstore.Command{
+				project.NewOIDCRegistrationAccessTokenAddedEvent(ctx, agg, appID, tokenID, tokenHash, initialAccessTokenID),
+			}, nil
+		}, nil
+	}
+}
+
+// CheckOIDCRegistrationAccessToken verifies the registration access token and returns
+// the write model containing the project (AggregateID) and the app the token was issued for.
+func (c *Commands) CheckOIDCRegistrationAccessToken(ctx context.Context, registrationAccessToken string) (*OIDCRegistrationAccessTokenWriteModel, error) {
+	projectID, tokenID, secret, err := domain.FromOIDCRegistrationToken(registrationAccessToken, domain.OIDCRegistrationTokenTypeRegistrationAccess, c.keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewOIDCRegistrationAccessTokenWriteModel(projectID, tokenID, "")
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() {
+		return nil, errors.ThrowUnauthenticated(nil, "COMMAND-ooX7e", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	if err = domain.VerifyOIDCRegistrationTokenSecret(secret, writeModel.TokenHash); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// ChangeRegisteredOIDCClient updates the client metadata of a client registered by [Commands.RegisterOIDCClient].
+// Only the fields which can be registered are taken from the provided app, all other settings remain.
+// The changes must still satisfy the restrictions of the initial access token used for the registration.
+func (c *Commands) ChangeRegisteredOIDCClient(ctx context.Context, registrationAccessToken string, app *domain.OIDCApp) (*domain.OIDCApp, error) {
+	registration, err := c.CheckOIDCRegistrationAccessToken(ctx, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := c.getOIDCAppWriteModel(ctx, registration.AggregateID, registration.AppID, registration.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() || !existing.IsOIDC() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Mae0k", "Errors.Project.App.NotExisting")
+	}
+	changed := oidcWriteModelToOIDCConfig(existing)
+	changed.RedirectUris = app.RedirectUris
+	changed.PostLogoutRedirectUris = app.PostLogoutRedirectUris
+	changed.ResponseTypes = app.ResponseTypes
+	changed.GrantTypes = app.GrantTypes
+	changed.ApplicationType = app.ApplicationType
+	changed.AuthMethodType = app.AuthMethodType
+
+	initialToken, err := c.getOIDCInitialAccessTokenWriteModel(ctx, registration.AggregateID, registration.InitialAccessTokenID, registration.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = initialToken.Policy.Check(changed); err != nil {
+		return nil, err
+	}
+	if !registrationMetadataChanged(existing, changed) {
+		changed.FillCompliance()
+		return changed, nil
+	}
+	return c.ChangeOIDCApplication(ctx, changed, registration.ResourceOwner)
+}
+
+// RemoveRegisteredOIDCClient removes a client registered by [Commands.RegisterOIDCClient].
+func (c *Commands) RemoveRegisteredOIDCClient(ctx context.Context, registrationAccessToken string) (*domain.ObjectDetails, error) {
+	registration, err := c.CheckOIDCRegistrationAccessToken(ctx, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.RemoveApplication(ctx, registration.AggregateID, registration.AppID, registration.ResourceOwner)
+}
+
+func registrationMetadataChanged(existing *OIDCApplicationWriteModel, app *domain.OIDCApp) bool {
+	return !reflect.DeepEqual(existing.RedirectUris, app.RedirectUris) ||
+		!reflect.DeepEqual(existing.PostLogoutRedirectUris, app.PostLogoutRedirectUris) ||
+		!reflect.DeepEqual(existing.ResponseTypes, app.ResponseTypes) ||
+		!reflect.DeepEqual(existing.GrantTypes, app.GrantTypes) ||
+		existing.ApplicationType != app.ApplicationType ||
+		existing.AuthMethodType != app.AuthMethodType
+}
+
+func (c *Commands) getOIDCInitialAccessTokenWriteModel(ctx context.Context, projectID, tokenID, resourceOwner string) (*OIDCInitialAccessTokenWriteModel, error) {
+	writeModel := NewOIDCInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
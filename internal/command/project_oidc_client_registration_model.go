@@ -0,0 +1,160 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type OIDCInitialAccessTokenWriteModel struct {
+	eventstore.WriteModel
+
+	TokenID        string
+	TokenHash      *crypto.CryptoValue
+	ExpirationDate time.Time
+	Policy         domain.OIDCClientRegistrationPolicy
+
+	State domain.OIDCInitialAccessTokenState
+}
+
+func NewOIDCInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner string) *OIDCInitialAccessTokenWriteModel {
+	return &OIDCInitialAccessTokenWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		TokenID: tokenID,
+	}
+}
+
+func (wm *OIDCInitialAccessTokenWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.OIDCInitialAccessTokenAddedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCInitialAccessTokenRemovedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OIDCInitialAccessTokenWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.OIDCInitialAccessTokenAddedEvent:
+			wm.TokenHash = e.TokenHash
+			wm.ExpirationDate = e.Expiration
+			wm.Policy = domain.OIDCClientRegistrationPolicy{
+				AllowedRedirectURIPrefixes: e.AllowedRedirectURIPrefixes,
+				AllowedGrantTypes:          e.AllowedGrantTypes,
+				AllowedAuthMethodTypes:     e.AllowedAuthMethodTypes,
+			}
+			wm.State = domain.OIDCInitialAccessTokenStateActive
+		case *project.OIDCInitialAccessTokenRemovedEvent:
+			wm.State = domain.OIDCInitialAccessTokenStateRemoved
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.OIDCInitialAccessTokenStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OIDCInitialAccessTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.OIDCInitialAccessTokenAddedType,
+			project.OIDCInitialAccessTokenRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *OIDCInitialAccessTokenWriteModel) Exists() bool {
+	return wm.State == domain.OIDCInitialAccessTokenStateActive
+}
+
+// Usable returns true if the token exists and is not expired (at the given time).
+func (wm *OIDCInitialAccessTokenWriteModel) Usable(now time.Time) bool {
+	return wm.Exists() && now.Before(wm.ExpirationDate)
+}
+
+// OIDCRegistrationAccessTokenWriteModel resolves a registration access token
+// to the app it was issued for.
+// The token is only valid as long as it was not replaced by a newer token
+// for the same app and the app and project were not removed.
+type OIDCRegistrationAccessTokenWriteModel struct {
+	eventstore.WriteModel
+
+	TokenID              string
+	TokenHash            *crypto.CryptoValue
+	AppID                string
+	InitialAccessTokenID string
+
+	active bool
+}
+
+func NewOIDCRegistrationAccessTokenWriteModel(projectID, tokenID, resourceOwner string) *OIDCRegistrationAccessTokenWriteModel {
+	return &OIDCRegistrationAccessTokenWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		TokenID: tokenID,
+	}
+}
+
+func (wm *OIDCRegistrationAccessTokenWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.OIDCRegistrationAccessTokenAddedEvent:
+			if e.TokenID == wm.TokenID {
+				wm.TokenHash = e.TokenHash
+				wm.AppID = e.AppID
+				wm.InitialAccessTokenID = e.InitialAccessTokenID
+				wm.active = true
+				continue
+			}
+			if wm.AppID != "" && e.AppID == wm.AppID {
+				wm.active = false
+			}
+		case *project.ApplicationRemovedEvent:
+			if wm.AppID != "" && e.AppID == wm.AppID {
+				wm.active = false
+			}
+		case *project.ProjectRemovedEvent:
+			wm.active = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OIDCRegistrationAccessTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.OIDCRegistrationAccessTokenAddedType,
+			project.ApplicationRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *OIDCRegistrationAccessTokenWriteModel) Exists() bool {
+	return wm.active
+}
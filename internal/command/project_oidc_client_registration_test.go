@@ -0,0 +1,655 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_AddOIDCInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		idGenerator  id.Generator
+		keyAlgorithm crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx   context.Context
+		token *OIDCInitialAccessToken
+	}
+	type res struct {
+		want        *domain.ObjectDetails
+		tokenPrefix string
+		err         func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no project id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				token: &OIDCInitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						ResourceOwner: "org1",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "expiration in the past, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				token: &OIDCInitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					ExpirationDate: time.Now().Add(-time.Hour),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				token: &OIDCInitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "token added",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectRandomPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewOIDCInitialAccessTokenAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+									nil,
+									time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+									[]string{"https://example.com/"},
+									[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+									nil,
+								),
+							),
+						},
+					),
+				),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "token1"),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				token: &OIDCInitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					Policy: domain.OIDCClientRegistrationPolicy{
+						AllowedRedirectURIPrefixes: []string{"https://example.com/"},
+						AllowedGrantTypes:          []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				tokenPrefix: "iat:project1:token1:",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: tt.fields.keyAlgorithm,
+			}
+			got, err := r.AddOIDCInitialAccessToken(tt.args.ctx, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				decoded, err := base64.RawURLEncoding.DecodeString(tt.args.token.Token)
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(string(decoded), tt.res.tokenPrefix))
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOIDCInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		tokenID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no token id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "token not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "token removed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewOIDCInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								nil,
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+								nil,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewOIDCInitialAccessTokenRemovedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveOIDCInitialAccessToken(tt.args.ctx, tt.args.projectID, tt.args.tokenID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_CheckOIDCRegistrationAccessToken(t *testing.T) {
+	secretHash, err := crypto.Hash([]byte("secret"), crypto.NewSHA256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		token string
+	}
+	type res struct {
+		appID string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "initial access token, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("iat:project1:token1:secret")),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "token rotated, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewOIDCRegistrationAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"token1",
+								secretHash,
+								"initial1",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCRegistrationAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"token2",
+								secretHash,
+								"initial1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("rat:project1:token1:secret")),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "app removed, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewOIDCRegistrationAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"token1",
+								secretHash,
+								"initial1",
+							),
+						),
+						eventFromEventPusher(
+							project.NewApplicationRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+								"",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("rat:project1:token1:secret")),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "secret invalid, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewOIDCRegistrationAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"token1",
+								secretHash,
+								"initial1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("rat:project1:token1:guessed")),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "token valid",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewOIDCRegistrationAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"token1",
+								secretHash,
+								"initial1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("rat:project1:token1:secret")),
+			},
+			res: res{
+				appID: "app1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := r.CheckOIDCRegistrationAccessToken(tt.args.ctx, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.appID, got.AppID)
+				assert.Equal(t, "project1", got.AggregateID)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RegisterOIDCClient(t *testing.T) {
+	secretHash, err := crypto.Hash([]byte("secret"), crypto.NewSHA256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	initialTokenAdded := func(expiration time.Time) *repository.Event {
+		return eventFromEventPusher(
+			project.NewOIDCInitialAccessTokenAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"token1",
+				secretHash,
+				expiration,
+				nil,
+				nil,
+				nil,
+			),
+		)
+	}
+	newApp := func() *domain.OIDCApp {
+		return &domain.OIDCApp{
+			RedirectUris:    []string{"https://test.ch/callback"},
+			ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+			GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+			ApplicationType: domain.OIDCApplicationTypeWeb,
+			AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+			AccessTokenType: domain.OIDCTokenTypeBearer,
+		}
+	}
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx   context.Context
+		token string
+		app   *domain.OIDCApp
+	}
+	type res struct {
+		appID string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "token expired, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						initialTokenAdded(time.Now().Add(-time.Hour)),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("iat:project1:token1:secret")),
+				app:   newApp(),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "push failed, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						initialTokenAdded(time.Now().Add(time.Hour)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project",
+								false,
+								false,
+								false,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectRandomPushFailed(caos_errs.ThrowInternal(nil, "", "push failed"),
+						[]*repository.Event{
+							eventFromEventPusher(project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"dynamic-client-token2",
+							)),
+							eventFromEventPusher(project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1, "app1", "client1@project", nil, nil, nil, nil, 0, 0, nil, false, 0, false, false, false, 0, nil, false, 0, 0,
+							)),
+							eventFromEventPusher(project.NewOIDCRegistrationAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"token2",
+								secretHash,
+								"token1",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("dynamic-client-token2", "project1")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "token2", "app1", "client1"),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("iat:project1:token1:secret")),
+				app:   newApp(),
+			},
+			res: res{
+				err: caos_errs.IsInternal,
+			},
+		},
+		{
+			name: "app and registration access token pushed together",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						initialTokenAdded(time.Now().Add(time.Hour)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project",
+								false,
+								false,
+								false,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectRandomPush(
+						[]*repository.Event{
+							eventFromEventPusher(project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"dynamic-client-token2",
+							)),
+							eventFromEventPusher(project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1, "app1", "client1@project", nil, nil, nil, nil, 0, 0, nil, false, 0, false, false, false, 0, nil, false, 0, 0,
+							)),
+							eventFromEventPusher(project.NewOIDCRegistrationAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"token2",
+								secretHash,
+								"token1",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("dynamic-client-token2", "project1")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "token2", "app1", "client1"),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: base64.RawURLEncoding.EncodeToString([]byte("iat:project1:token1:secret")),
+				app:   newApp(),
+			},
+			res: res{
+				appID: "app1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, registrationAccessToken, err := r.RegisterOIDCClient(tt.args.ctx, tt.args.token, tt.args.app, crypto.CreateMockHashAlg(gomock.NewController(t)))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.appID, got.AppID)
+				assert.Equal(t, "client1@project", got.ClientID)
+				assert.NotEmpty(t, registrationAccessToken)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ type SessionCommands struct {
 	passwordWriteModel *HumanPasswordWriteModel
 	eventstore         *eventstore.Eventstore
 	userPasswordAlg    crypto.HashAlgorithm
+	userCodeAlg        crypto.EncryptionAlgorithm
 	createToken        func(sessionID string) (id string, token string, err error)
 	now                func() time.Time
 }
@@ -35,6 +36,7 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		sessionWriteModel: session,
 		eventstore:        c.eventstore,
 		userPasswordAlg:   c.userPasswordAlg,
+		userCodeAlg:       c.userEncryption,
 		createToken:       c.sessionTokenCreator,
 		now:               time.Now,
 	}
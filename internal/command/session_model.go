@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -34,13 +35,15 @@ func (p *PasskeyChallengeModel) WebAuthNLogin(human *domain.Human, credentialAss
 type SessionWriteModel struct {
 	eventstore.WriteModel
 
-	TokenID           string
-	UserID            string
-	UserCheckedAt     time.Time
-	PasswordCheckedAt time.Time
-	PasskeyCheckedAt  time.Time
-	Metadata          map[string][]byte
-	State             domain.SessionState
+	TokenID               string
+	UserID                string
+	UserCheckedAt         time.Time
+	PasswordCheckedAt     time.Time
+	PasskeyCheckedAt      time.Time
+	MagicLinkCheckedAt    time.Time
+	RecoveryCodeCheckedAt time.Time
+	Metadata              map[string][]byte
+	State                 domain.SessionState
 
 	PasskeyChallenge *PasskeyChallengeModel
 
@@ -72,6 +75,10 @@ func (wm *SessionWriteModel) Reduce() error {
 			wm.reducePasskeyChallenged(e)
 		case *session.PasskeyCheckedEvent:
 			wm.reducePasskeyChecked(e)
+		case *session.MagicLinkCheckedEvent:
+			wm.reduceMagicLinkChecked(e)
+		case *session.RecoveryCodeCheckedEvent:
+			wm.reduceRecoveryCodeChecked(e)
 		case *session.TokenSetEvent:
 			wm.reduceTokenSet(e)
 		case *session.TerminateEvent:
@@ -92,6 +99,8 @@ func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			session.PasswordCheckedType,
 			session.PasskeyChallengedType,
 			session.PasskeyCheckedType,
+			session.MagicLinkCheckedType,
+			session.RecoveryCodeCheckedType,
 			session.TokenSetType,
 			session.MetadataSetType,
 			session.TerminateType,
@@ -130,6 +139,14 @@ func (wm *SessionWriteModel) reducePasskeyChecked(e *session.PasskeyCheckedEvent
 	wm.PasskeyCheckedAt = e.CheckedAt
 }
 
+func (wm *SessionWriteModel) reduceMagicLinkChecked(e *session.MagicLinkCheckedEvent) {
+	wm.MagicLinkCheckedAt = e.CheckedAt
+}
+
+func (wm *SessionWriteModel) reduceRecoveryCodeChecked(e *session.RecoveryCodeCheckedEvent) {
+	wm.RecoveryCodeCheckedAt = e.CheckedAt
+}
+
 func (wm *SessionWriteModel) reduceTokenSet(e *session.TokenSetEvent) {
 	wm.TokenID = e.TokenID
 }
@@ -164,6 +181,22 @@ func (wm *SessionWriteModel) PasskeyChecked(ctx context.Context, checkedAt time.
 	)
 }
 
+// MagicLinkChecked sets the check on the session and consumes the code of the user
+func (wm *SessionWriteModel) MagicLinkChecked(ctx context.Context, checkedAt time.Time, userAgg *eventstore.Aggregate, codeID string) {
+	wm.commands = append(wm.commands,
+		session.NewMagicLinkCheckedEvent(ctx, wm.aggregate, checkedAt),
+		usr_repo.NewHumanMagicLinkCodeCheckSucceededEvent(ctx, userAgg, codeID, nil),
+	)
+}
+
+// RecoveryCodeChecked sets the check on the session and consumes the recovery code of the user
+func (wm *SessionWriteModel) RecoveryCodeChecked(ctx context.Context, checkedAt time.Time, userAgg *eventstore.Aggregate, index int, code *crypto.CryptoValue) {
+	wm.commands = append(wm.commands,
+		session.NewRecoveryCodeCheckedEvent(ctx, wm.aggregate, checkedAt),
+		usr_repo.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, index, code, nil),
+	)
+}
+
 func (wm *SessionWriteModel) SetToken(ctx context.Context, tokenID string) {
 	wm.commands = append(wm.commands, session.NewTokenSetEvent(ctx, wm.aggregate, tokenID))
 }
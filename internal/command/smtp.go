@@ -15,27 +15,33 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
-func (c *Commands) AddSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
+// AddSMTPConfig adds an additional SMTP configuration to the instance.
+// The configurations are used in the order of their priority, the next one is only used if sending over the previous one failed.
+func (c *Commands) AddSMTPConfig(ctx context.Context, priority uint32, config *smtp.Config) (string, *domain.ObjectDetails, error) {
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareAddSMTPConfig(instanceAgg, config.From, config.FromName, config.SMTP.Host, config.SMTP.User, []byte(config.SMTP.Password), config.Tls)
+	validation := c.prepareAddSMTPConfig(instanceAgg, id, priority, config.From, config.FromName, config.SMTP.Host, config.SMTP.User, []byte(config.SMTP.Password), config.Tls, config.SMTP.XOAuth2)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	events, err := c.eventstore.Push(ctx, cmds...)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	return &domain.ObjectDetails{
+	return id, &domain.ObjectDetails{
 		Sequence:      events[len(events)-1].Sequence(),
 		EventDate:     events[len(events)-1].CreationDate(),
 		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
 	}, nil
 }
 
-func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeSMTPConfig(ctx context.Context, id string, priority uint32, config *smtp.Config) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareChangeSMTPConfig(instanceAgg, config.From, config.FromName, config.SMTP.Host, config.SMTP.User, config.Tls)
+	validation := c.prepareChangeSMTPConfig(instanceAgg, id, priority, config.From, config.FromName, config.SMTP.Host, config.SMTP.User, config.Tls, config.SMTP.XOAuth2)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -51,9 +57,14 @@ func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*
 	}, nil
 }
 
-func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string) (*domain.ObjectDetails, error) {
+// ChangeSMTPConfigPassword changes the password of the SMTP configuration,
+// which is used as client secret if the configuration authenticates with XOAUTH2
+func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, id, password string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Wq7bd", "Errors.IDMissing")
+	}
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, "")
+	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, id, "")
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +81,7 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string
 	events, err := c.eventstore.Push(ctx, instance.NewSMTPConfigPasswordChangedEvent(
 		ctx,
 		&instanceAgg.Aggregate,
+		id,
 		smtpPassword))
 	if err != nil {
 		return nil, err
@@ -81,9 +93,9 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string
 	}, nil
 }
 
-func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails, error) {
+func (c *Commands) RemoveSMTPConfig(ctx context.Context, id string) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareRemoveSMTPConfig(instanceAgg)
+	validation := c.prepareRemoveSMTPConfig(instanceAgg, id)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -99,7 +111,7 @@ func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails,
 	}, nil
 }
 
-func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, hostAndPort, user string, password []byte, tls bool) preparation.Validation {
+func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, id string, priority uint32, from, name, hostAndPort, user string, password []byte, tls bool, xoauth2 *smtp.XOAuth2) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if from = strings.TrimSpace(from); from == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-mruNY", "Errors.Invalid.Argument")
@@ -108,10 +120,12 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, hostA
 		if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-9JdRe", "Errors.Invalid.Argument")
 		}
+		smtpXOAuth2 := smtpXOAuth2ToDomain(xoauth2)
+		if err := validateSMTPXOAuth2(user, smtpXOAuth2); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
-			fromSplitted := strings.Split(from, "@")
-			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, domain.SMTPSenderDomain(from))
 			if err != nil {
 				return nil, err
 			}
@@ -133,20 +147,27 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, hostA
 				instance.NewSMTPConfigAddedEvent(
 					ctx,
 					&a.Aggregate,
+					id,
+					priority,
 					tls,
 					from,
 					name,
 					hostAndPort,
 					user,
 					smtpPassword,
+					smtpAuthType(smtpXOAuth2),
+					smtpXOAuth2,
 				),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, hostAndPort, user string, tls bool) preparation.Validation {
+func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, id string, priority uint32, from, name, hostAndPort, user string, tls bool, xoauth2 *smtp.XOAuth2) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "INST-Fp3sn", "Errors.IDMissing")
+		}
 		if from = strings.TrimSpace(from); from == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-ASv2d", "Errors.Invalid.Argument")
 		}
@@ -154,10 +175,12 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, ho
 		if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-Kv875", "Errors.Invalid.Argument")
 		}
+		smtpXOAuth2 := smtpXOAuth2ToDomain(xoauth2)
+		if err := validateSMTPXOAuth2(user, smtpXOAuth2); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
-			fromSplitted := strings.Split(from, "@")
-			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, domain.SMTPSenderDomain(from))
 			if err != nil {
 				return nil, err
 			}
@@ -171,11 +194,13 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, ho
 			changedEvent, hasChanged, err := writeModel.NewChangedEvent(
 				ctx,
 				&a.Aggregate,
+				priority,
 				tls,
 				from,
 				name,
 				hostAndPort,
 				user,
+				smtpXOAuth2,
 			)
 			if err != nil {
 				return nil, err
@@ -190,10 +215,13 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, ho
 	}
 }
 
-func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate) preparation.Validation {
+func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate, id string) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "INST-Gv9ej", "Errors.IDMissing")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, "")
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
 			if err != nil {
 				return nil, err
 			}
@@ -201,7 +229,7 @@ func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate) preparation.Va
 				return nil, errors.ThrowNotFound(nil, "INST-Sfefg", "Errors.SMTPConfig.NotFound")
 			}
 			return []eventstore.Command{
-				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate),
+				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate, id),
 			}, nil
 		}, nil
 	}
@@ -217,8 +245,8 @@ func checkSenderAddress(writeModel *InstanceSMTPConfigWriteModel) error {
 	return nil
 }
 
-func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
-	writeModel := NewInstanceSMTPConfigWriteModel(authz.GetInstance(ctx).InstanceID(), domain)
+func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, id, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
+	writeModel := NewInstanceSMTPConfigWriteModel(authz.GetInstance(ctx).InstanceID(), id, domain)
 	events, err := filter(ctx, writeModel.Query())
 	if err != nil {
 		return nil, err
@@ -230,3 +258,24 @@ func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQue
 	err = writeModel.Reduce()
 	return writeModel, err
 }
+
+func validateSMTPXOAuth2(user string, xoauth2 *domain.SMTPXOAuth2) error {
+	if xoauth2 == nil {
+		return nil
+	}
+	if strings.TrimSpace(user) == "" || !xoauth2.IsValid() {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Xo2va", "Errors.SMTPConfig.XOAuth2Invalid")
+	}
+	return nil
+}
+
+func smtpXOAuth2ToDomain(xoauth2 *smtp.XOAuth2) *domain.SMTPXOAuth2 {
+	if xoauth2 == nil {
+		return nil
+	}
+	return &domain.SMTPXOAuth2{
+		TokenEndpoint: strings.TrimSpace(xoauth2.TokenEndpoint),
+		ClientID:      strings.TrimSpace(xoauth2.ClientID),
+		Scopes:        xoauth2.Scopes,
+	}
+}
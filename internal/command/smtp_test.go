@@ -13,21 +13,26 @@ import (
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
 func TestCommandSide_AddSMTPConfig(t *testing.T) {
 	type fields struct {
-		eventstore *eventstore.Eventstore
-		alg        crypto.EncryptionAlgorithm
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
 	}
 	type args struct {
-		ctx  context.Context
-		smtp *smtp.Config
+		ctx      context.Context
+		priority uint32
+		smtp     *smtp.Config
 	}
 	type res struct {
 		want *domain.ObjectDetails
+		id   string
 		err  func(error) bool
 	}
 	tests := []struct {
@@ -39,6 +44,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, custom domain not existing",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -54,7 +60,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -73,6 +80,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, error already exists",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -92,19 +100,24 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						eventFromEventPusher(
 							instance.NewSMTPConfigAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configID",
+								1,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPAuthTypePlain,
+								nil,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -123,6 +136,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "add smtp config, ok",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -147,6 +161,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								instance.NewSMTPConfigAddedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configID",
+									1,
 									true,
 									"from@domain.ch",
 									"name",
@@ -158,6 +174,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 										KeyID:      "id",
 										Crypted:    []byte("password"),
 									},
+									domain.SMTPAuthTypePlain,
+									nil,
 								),
 							),
 						},
@@ -166,7 +184,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -182,15 +201,18 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
+				id: "configID",
 			},
 		},
 		{
 			name: "smtp config, port is missing",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
+				eventstore:  eventstoreExpect(t),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -209,10 +231,12 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, host is empty",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
+				eventstore:  eventstoreExpect(t),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -231,6 +255,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "add smtp config, ipv6 works",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -255,6 +280,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								instance.NewSMTPConfigAddedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configID",
+									1,
 									true,
 									"from@domain.ch",
 									"name",
@@ -266,6 +293,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 										KeyID:      "id",
 										Crypted:    []byte("password"),
 									},
+									domain.SMTPAuthTypePlain,
+									nil,
 								),
 							),
 						},
@@ -274,7 +303,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -290,6 +320,108 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
+				id: "configID",
+			},
+		},
+		{
+			name: "smtp config, xoauth2 invalid",
+			fields: fields{
+				eventstore:  eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
+			},
+			args: args{
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:     "host:587",
+						User:     "user",
+						Password: "secret",
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "not a url",
+							ClientID:      "client",
+						},
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add smtp config with xoauth2, ok",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewSMTPConfigAddedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configID",
+									1,
+									true,
+									"from@domain.ch",
+									"name",
+									"smtp.office365.com:587",
+									"user@domain.ch",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("secret"),
+									},
+									domain.SMTPAuthTypeXOAuth2,
+									&domain.SMTPXOAuth2{
+										TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+										ClientID:      "client",
+										Scopes:        []string{"https://outlook.office365.com/.default"},
+									},
+								),
+							),
+						},
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				priority: 1,
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:     "smtp.office365.com:587",
+						User:     "user@domain.ch",
+						Password: "secret",
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+							ClientID:      "client",
+							Scopes:        []string{"https://outlook.office365.com/.default"},
+						},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+				id: "configID",
 			},
 		},
 	}
@@ -297,9 +429,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore:     tt.fields.eventstore,
+				idGenerator:    tt.fields.idGenerator,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.AddSMTPConfig(tt.args.ctx, tt.args.smtp)
+			gotID, got, err := r.AddSMTPConfig(tt.args.ctx, tt.args.priority, tt.args.smtp)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -307,6 +440,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				t.Errorf("got wrong err: %v ", err)
 			}
 			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, gotID)
 				assert.Equal(t, tt.res.want, got)
 			}
 		})
@@ -319,6 +453,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 	}
 	type args struct {
 		ctx  context.Context
+		id   string
 		smtp *smtp.Config
 	}
 	type res struct {
@@ -340,6 +475,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx:  authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:   "INSTANCE",
 				smtp: &smtp.Config{},
 			},
 			res: res{
@@ -356,6 +492,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -393,12 +530,16 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPAuthTypePlain,
+								nil,
 							),
 						),
 					),
@@ -406,6 +547,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@wrongdomain.ch",
@@ -443,12 +585,16 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPAuthTypePlain,
+								nil,
 							),
 						),
 					),
@@ -456,6 +602,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -493,12 +640,16 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPAuthTypePlain,
+								nil,
 							),
 						),
 					),
@@ -508,6 +659,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"INSTANCE",
 								newSMTPConfigChangedEvent(
 									context.Background(),
+									"INSTANCE",
 									false,
 									"from2@domain.ch",
 									"name2",
@@ -521,6 +673,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 				smtp: &smtp.Config{
 					Tls:      false,
 					From:     "from2@domain.ch",
@@ -544,6 +697,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -566,6 +720,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -604,12 +759,16 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPAuthTypePlain,
+								nil,
 							),
 						),
 					),
@@ -619,6 +778,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"INSTANCE",
 								newSMTPConfigChangedEvent(
 									context.Background(),
+									"INSTANCE",
 									false,
 									"from2@domain.ch",
 									"name2",
@@ -632,6 +792,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 				smtp: &smtp.Config{
 					Tls:      false,
 					From:     "from2@domain.ch",
@@ -654,7 +815,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeSMTPConfig(tt.args.ctx, tt.args.smtp)
+			got, err := r.ChangeSMTPConfig(tt.args.ctx, tt.args.id, 0, tt.args.smtp)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -675,6 +836,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 	}
 	type args struct {
 		ctx      context.Context
+		id       string
 		password string
 	}
 	type res struct {
@@ -697,6 +859,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 			},
 			args: args{
 				ctx:      context.Background(),
+				id:       "INSTANCE",
 				password: "",
 			},
 			res: res{
@@ -713,12 +876,16 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								0,
 								true,
 								"from",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPAuthTypePlain,
+								nil,
 							),
 						),
 					),
@@ -729,6 +896,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 								instance.NewSMTPConfigPasswordChangedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"INSTANCE",
 									&crypto.CryptoValue{
 										CryptoType: crypto.TypeEncryption,
 										Algorithm:  "enc",
@@ -744,6 +912,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 			},
 			args: args{
 				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:       "INSTANCE",
 				password: "password",
 			},
 			res: res{
@@ -759,7 +928,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, tt.args.password)
+			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, tt.args.id, tt.args.password)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -780,6 +949,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 	}
 	type args struct {
 		ctx context.Context
+		id  string
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -801,6 +971,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
+				id:  "INSTANCE",
 			},
 			res: res{
 				err: caos_errs.IsNotFound,
@@ -816,12 +987,16 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								0,
 								true,
 								"from",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPAuthTypePlain,
+								nil,
 							),
 						),
 					),
@@ -832,6 +1007,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 								instance.NewSMTPConfigRemovedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"INSTANCE",
 								),
 							),
 						},
@@ -840,6 +1016,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -854,7 +1031,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.RemoveSMTPConfig(tt.args.ctx)
+			got, err := r.RemoveSMTPConfig(tt.args.ctx, tt.args.id)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -868,7 +1045,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 	}
 }
 
-func newSMTPConfigChangedEvent(ctx context.Context, tls bool, fromAddress, fromName, host, user string) *instance.SMTPConfigChangedEvent {
+func newSMTPConfigChangedEvent(ctx context.Context, id string, tls bool, fromAddress, fromName, host, user string) *instance.SMTPConfigChangedEvent {
 	changes := []instance.SMTPConfigChanges{
 		instance.ChangeSMTPConfigTLS(tls),
 		instance.ChangeSMTPConfigFromAddress(fromAddress),
@@ -878,6 +1055,7 @@ func newSMTPConfigChangedEvent(ctx context.Context, tls bool, fromAddress, fromN
 	}
 	event, _ := instance.NewSMTPConfigChangeEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
+		id,
 		changes,
 	)
 	return event
@@ -0,0 +1,203 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// runAddHumanPreCreationActions maps the human to the domain representation of the actions
+// and applies the changes of the actions of the pre creation trigger
+func (c *Commands) runAddHumanPreCreationActions(ctx context.Context, orgID string, human *AddHuman) error {
+	if c.activeActions == nil {
+		return nil
+	}
+	user := &domain.Human{
+		ObjectRoot: models.ObjectRoot{AggregateID: human.ID, ResourceOwner: orgID},
+		Username:   human.Username,
+		Profile: &domain.Profile{
+			FirstName:         human.FirstName,
+			LastName:          human.LastName,
+			NickName:          human.NickName,
+			DisplayName:       human.DisplayName,
+			PreferredLanguage: human.PreferredLanguage,
+			Gender:            human.Gender,
+		},
+		Email: &domain.Email{
+			EmailAddress:    human.Email.Address,
+			IsEmailVerified: human.Email.Verified,
+		},
+		Phone: &domain.Phone{
+			PhoneNumber:     human.Phone.Number,
+			IsPhoneVerified: human.Phone.Verified,
+		},
+	}
+	metadata := make([]*domain.Metadata, len(human.Metadata))
+	for i, entry := range human.Metadata {
+		metadata[i] = &domain.Metadata{Key: entry.Key, Value: entry.Value}
+	}
+	metadata, err := c.runUserPreCreationActions(ctx, orgID, user, metadata)
+	if err != nil {
+		return err
+	}
+	human.Username = user.Username
+	human.FirstName = user.FirstName
+	human.LastName = user.LastName
+	human.NickName = user.NickName
+	human.DisplayName = user.DisplayName
+	human.PreferredLanguage = user.PreferredLanguage
+	human.Gender = user.Gender
+	human.Email.Address = user.EmailAddress
+	human.Email.Verified = user.IsEmailVerified
+	human.Phone.Number = user.PhoneNumber
+	human.Phone.Verified = user.IsPhoneVerified
+	human.Metadata = make([]*AddMetadataEntry, len(metadata))
+	for i, entry := range metadata {
+		human.Metadata[i] = &AddMetadataEntry{Key: entry.Key, Value: entry.Value}
+	}
+	return nil
+}
+
+// runUserPreCreationActions runs the actions of the pre creation trigger of the user management flow.
+// The actions are able to change the user and to append metadata before the user is created.
+func (c *Commands) runUserPreCreationActions(ctx context.Context, orgID string, user *domain.Human, metadata []*domain.Metadata) ([]*domain.Metadata, error) {
+	if c.activeActions == nil {
+		return metadata, nil
+	}
+	metadataList := object.MetadataListFromDomain(metadata)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("setFirstName", func(firstName string) {
+			user.FirstName = firstName
+		}),
+		actions.SetFields("setLastName", func(lastName string) {
+			user.LastName = lastName
+		}),
+		actions.SetFields("setNickName", func(nickName string) {
+			user.NickName = nickName
+		}),
+		actions.SetFields("setDisplayName", func(displayName string) {
+			user.DisplayName = displayName
+		}),
+		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) {
+			user.PreferredLanguage = language.Make(preferredLanguage)
+		}),
+		actions.SetFields("setGender", func(gender domain.Gender) {
+			user.Gender = gender
+		}),
+		actions.SetFields("setUsername", func(username string) {
+			user.Username = username
+		}),
+		actions.SetFields("setEmail", func(email domain.EmailAddress) {
+			if user.Email == nil {
+				user.Email = &domain.Email{}
+			}
+			user.Email.EmailAddress = email
+		}),
+		actions.SetFields("setEmailVerified", func(verified bool) {
+			if user.Email == nil {
+				return
+			}
+			user.Email.IsEmailVerified = verified
+		}),
+		actions.SetFields("setPhone", func(phone domain.PhoneNumber) {
+			if user.Phone == nil {
+				user.Phone = &domain.Phone{}
+			}
+			user.Phone.PhoneNumber = phone
+		}),
+		actions.SetFields("setPhoneVerified", func(verified bool) {
+			if user.Phone == nil {
+				return
+			}
+			user.Phone.IsPhoneVerified = verified
+		}),
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+			),
+		),
+	)
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("user", func(fieldConfig *actions.FieldConfig) interface{} {
+				return object.UserFromHuman(fieldConfig, user)
+			}),
+			actions.SetFields("metadata", func(fieldConfig *actions.FieldConfig) interface{} {
+				return metadataList.MetadataListFromDomain(fieldConfig.Runtime)
+			}),
+		),
+	)
+	err := actions.RunTrigger(ctx, c.activeActions, domain.FlowTypeUserManagement, domain.TriggerTypePreCreation, orgID, ctxFields, apiFields)
+	if err != nil {
+		return nil, err
+	}
+	return object.MetadataListToDomain(metadataList), nil
+}
+
+// runUserPostActions runs the actions of the passed post trigger of the user management flow
+// after the events of the user were pushed.
+// The actions are able to read the (human) user and to set metadata on the user.
+// As the change is already stored, errors of the actions (including the metadata they set) are only logged.
+func (c *Commands) runUserPostActions(ctx context.Context, triggerType domain.TriggerType, orgID, userID string, ctxOpts ...actions.FieldOption) {
+	if c.activeActions == nil {
+		return
+	}
+	v1Fields := []interface{}{
+		actions.SetFields("userId", userID),
+		actions.SetFields("resourceOwner", orgID),
+		actions.SetFields("getUser", func(fieldConfig *actions.FieldConfig) interface{} {
+			return func(call goja.FunctionCall) goja.Value {
+				user, err := c.getHuman(ctx, userID, orgID)
+				if err != nil {
+					panic(err)
+				}
+				return object.UserFromHuman(fieldConfig, user)
+			}
+		}),
+	}
+	for _, opt := range ctxOpts {
+		v1Fields = append(v1Fields, opt)
+	}
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1", v1Fields...),
+	)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
+					if len(call.Arguments) != 2 {
+						panic("exactly 2 (key, value) arguments expected")
+					}
+					key := call.Arguments[0].Export().(string)
+					value, err := json.Marshal(call.Arguments[1].Export())
+					if err != nil {
+						logging.WithError(err).Debug("unable to marshal")
+						panic(err)
+					}
+					if _, err = c.SetUserMetadata(ctx, &domain.Metadata{Key: key, Value: value}, userID, orgID); err != nil {
+						logging.WithError(err).Info("unable to set md in action")
+						panic(err)
+					}
+					return nil
+				}),
+			),
+		),
+	)
+	err := actions.RunTrigger(ctx, c.activeActions, domain.FlowTypeUserManagement, triggerType, orgID, ctxFields, apiFields)
+	logging.WithFields("trigger", triggerType, "orgID", orgID, "userID", userID).OnError(err).Warn("post actions of user failed")
+}
+
+// runPostPasswordChangeActions runs the actions of the post password change trigger of the user management flow
+func (c *Commands) runPostPasswordChangeActions(ctx context.Context, orgID, userID string, changeRequired bool) {
+	c.runUserPostActions(ctx, domain.TriggerTypePostPasswordChange, orgID, userID,
+		actions.SetFields("passwordChangeRequired", changeRequired),
+	)
+}
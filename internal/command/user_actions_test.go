@@ -0,0 +1,98 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func activeActionsQuery(script string) actions.ActiveActionsQuery {
+	return func(_ context.Context, flowType domain.FlowType, triggerType domain.TriggerType, _ string, _ bool) ([]*query.Action, error) {
+		if flowType != domain.FlowTypeUserManagement || triggerType != domain.TriggerTypePreCreation {
+			return nil, nil
+		}
+		return []*query.Action{{Name: "preCreation", Script: script}}, nil
+	}
+}
+
+func TestCommands_runAddHumanPreCreationActions(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name    string
+		script  string
+		human   *AddHuman
+		want    *AddHuman
+		wantErr bool
+	}{
+		{
+			name:   "no changes",
+			script: "function preCreation(ctx, api) {}",
+			human: &AddHuman{
+				Username:  "username",
+				FirstName: "firstname",
+				LastName:  "lastname",
+				Email:     Email{Address: "email@test.ch"},
+			},
+			want: &AddHuman{
+				Username:  "username",
+				FirstName: "firstname",
+				LastName:  "lastname",
+				Email:     Email{Address: "email@test.ch"},
+				Metadata:  []*AddMetadataEntry{},
+			},
+		},
+		{
+			name: "changed by action",
+			script: `function preCreation(ctx, api) {
+	api.setFirstName(ctx.v1.user.human.firstName.toUpperCase());
+	api.setPreferredLanguage("de");
+	api.setEmailVerified(true);
+	api.v1.user.appendMetadata("key", "value");
+}`,
+			human: &AddHuman{
+				Username:  "username",
+				FirstName: "firstname",
+				LastName:  "lastname",
+				Email:     Email{Address: "email@test.ch"},
+			},
+			want: &AddHuman{
+				Username:          "username",
+				FirstName:         "FIRSTNAME",
+				LastName:          "lastname",
+				PreferredLanguage: language.German,
+				Email:             Email{Address: "email@test.ch", Verified: true},
+				Metadata:          []*AddMetadataEntry{{Key: "key", Value: []byte(`"value"`)}},
+			},
+		},
+		{
+			name:   "action fails",
+			script: "function preCreation(ctx, api) { throw 'failed' }",
+			human: &AddHuman{
+				Username: "username",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				activeActions: activeActionsQuery(tt.script),
+			}
+			err := c.runAddHumanPreCreationActions(context.Background(), "org1", tt.human)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.human)
+		})
+	}
+}
@@ -0,0 +1,504 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/userbulkjob"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+// AddUserBulkImportJob stores the file in the static storage and queues the import of its users.
+// The file is parsed upfront, so an invalid file is rejected immediately, invalid rows are reported by the job.
+func (c *Commands) AddUserBulkImportJob(ctx context.Context, resourceOwner string, format domain.UserBulkJobFormat, file []byte) (string, *domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ubj1o", "Errors.ResourceOwnerMissing")
+	}
+	rows, err := domain.ParseUserBulkImportFile(format, file)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(rows) == 0 {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ubj2r", "Errors.UserBulkJob.NoRows")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	fileName := domain.GetUserBulkJobAssetPath(id, domain.UserBulkJobTypeImport, format)
+	if err = c.putUserBulkJobFile(ctx, resourceOwner, fileName, format, file); err != nil {
+		return "", nil, err
+	}
+	writeModel := NewUserBulkJobWriteModel(id, resourceOwner)
+	pushedEvents, err := c.eventstore.Push(ctx, userbulkjob.NewAddedEvent(
+		ctx,
+		UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel),
+		domain.UserBulkJobTypeImport,
+		format,
+		fileName,
+		uint64(len(rows)),
+		nil,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// AddUserBulkExportJob queues the export of the users of the organisation matching the filter
+func (c *Commands) AddUserBulkExportJob(ctx context.Context, resourceOwner string, format domain.UserBulkJobFormat, filter *domain.UserBulkExportFilter) (string, *domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ubj3o", "Errors.ResourceOwnerMissing")
+	}
+	if !format.Valid() {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ubj4f", "Errors.UserBulkJob.FormatInvalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel := NewUserBulkJobWriteModel(id, resourceOwner)
+	pushedEvents, err := c.eventstore.Push(ctx, userbulkjob.NewAddedEvent(
+		ctx,
+		UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel),
+		domain.UserBulkJobTypeExport,
+		format,
+		"",
+		0,
+		userbulkjob.ExportFilterFromDomain(filter),
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// CancelUserBulkJob stops a queued or running job, users which are already imported are kept
+func (c *Commands) CancelUserBulkJob(ctx context.Context, jobID, resourceOwner string) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingUserBulkJobWriteModel(ctx, jobID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Pending() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj5p", "Errors.UserBulkJob.NotPending")
+	}
+	if err = c.removeUserBulkImportFile(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, userbulkjob.NewCancelledEvent(ctx, UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveUserBulkJob removes the job and its file, a pending job is stopped
+func (c *Commands) RemoveUserBulkJob(ctx context.Context, jobID, resourceOwner string) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingUserBulkJobWriteModel(ctx, jobID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.FileName != "" {
+		if err = c.removeAsset(ctx, resourceOwner, writeModel.FileName); err != nil {
+			return nil, err
+		}
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, userbulkjob.NewRemovedEvent(ctx, UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// UserBulkJobFile returns the created file of a finished export.
+// The files of imports are not returned, as they might contain password hashes and secrets.
+func (c *Commands) UserBulkJobFile(ctx context.Context, jobID, resourceOwner string) (_ []byte, _ domain.UserBulkJobFormat, err error) {
+	writeModel, err := c.existingUserBulkJobWriteModel(ctx, jobID, resourceOwner)
+	if err != nil {
+		return nil, domain.UserBulkJobFormatUnspecified, err
+	}
+	if writeModel.Type != domain.UserBulkJobTypeExport {
+		return nil, domain.UserBulkJobFormatUnspecified, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj7e", "Errors.UserBulkJob.TypeInvalid")
+	}
+	if writeModel.FileName == "" {
+		return nil, domain.UserBulkJobFormatUnspecified, caos_errs.ThrowNotFound(nil, "COMMAND-Ubj6f", "Errors.UserBulkJob.FileNotFound")
+	}
+	file, _, err := c.static.GetObject(ctx, authz.GetInstance(ctx).InstanceID(), resourceOwner, writeModel.FileName)
+	if err != nil {
+		return nil, domain.UserBulkJobFormatUnspecified, err
+	}
+	return file, writeModel.Format, nil
+}
+
+// StartUserBulkJob marks a queued job as running, a running job is resumed
+func (c *Commands) StartUserBulkJob(ctx context.Context, jobID, resourceOwner string) error {
+	writeModel, err := c.existingUserBulkJobWriteModel(ctx, jobID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return c.startUserBulkJob(ctx, writeModel)
+}
+
+func (c *Commands) startUserBulkJob(ctx context.Context, writeModel *UserBulkJobWriteModel) error {
+	if writeModel.State == domain.UserBulkJobStateRunning {
+		return nil
+	}
+	if writeModel.State != domain.UserBulkJobStateQueued {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj7p", "Errors.UserBulkJob.NotPending")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, userbulkjob.NewStartedEvent(ctx, UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return err
+	}
+	return AppendAndReduce(writeModel, pushedEvents...)
+}
+
+// FailUserBulkJob stops a pending job because it cannot be processed
+func (c *Commands) FailUserBulkJob(ctx context.Context, jobID, resourceOwner, reason string) error {
+	writeModel, err := c.existingUserBulkJobWriteModel(ctx, jobID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !writeModel.State.Pending() {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj8p", "Errors.UserBulkJob.NotPending")
+	}
+	return c.failUserBulkJob(ctx, writeModel, reason)
+}
+
+func (c *Commands) failUserBulkJob(ctx context.Context, writeModel *UserBulkJobWriteModel, reason string) error {
+	if err := c.removeUserBulkImportFile(ctx, writeModel); err != nil {
+		return err
+	}
+	_, err := c.eventstore.Push(ctx, userbulkjob.NewFailedEvent(ctx, UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel), reason))
+	return err
+}
+
+// CompleteUserBulkExportJob stores the created file of a running export in the static storage
+func (c *Commands) CompleteUserBulkExportJob(ctx context.Context, jobID, resourceOwner string, file []byte, total uint64) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingUserBulkJobWriteModel(ctx, jobID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.Type != domain.UserBulkJobTypeExport {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj9t", "Errors.UserBulkJob.TypeInvalid")
+	}
+	if writeModel.State != domain.UserBulkJobStateRunning {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj0p", "Errors.UserBulkJob.NotPending")
+	}
+	fileName := domain.GetUserBulkJobAssetPath(jobID, domain.UserBulkJobTypeExport, writeModel.Format)
+	if err = c.putUserBulkJobFile(ctx, resourceOwner, fileName, writeModel.Format, file); err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, userbulkjob.NewDoneEvent(ctx, UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel), fileName, total))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ProcessUserBulkImportJob imports the rows of the file, which were not processed yet.
+// Every user is pushed together with the result of its row, so a resumed import never imports a row twice.
+// Rows which cannot be imported are reported on the job and don't stop the import.
+// Actions are not executed for users of a bulk import.
+// The import stops if the context is done or if the job was cancelled or removed meanwhile.
+// The file is removed as soon as the job is done or failed, as it contains password hashes and secrets.
+func (c *Commands) ProcessUserBulkImportJob(ctx context.Context, jobID, resourceOwner string) error {
+	writeModel, err := c.existingUserBulkJobWriteModel(ctx, jobID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if writeModel.Type != domain.UserBulkJobTypeImport {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj1t", "Errors.UserBulkJob.TypeInvalid")
+	}
+	if err = c.startUserBulkJob(ctx, writeModel); err != nil {
+		return err
+	}
+	jobAgg := UserBulkJobAggregateFromWriteModel(&writeModel.WriteModel)
+
+	file, _, err := c.static.GetObject(ctx, authz.GetInstance(ctx).InstanceID(), resourceOwner, writeModel.FileName)
+	if caos_errs.IsNotFound(err) {
+		// the file is removed before the job is marked as done, so a previous run might have processed all rows
+		if writeModel.Processed >= writeModel.Total {
+			_, err = c.eventstore.Push(ctx, userbulkjob.NewDoneEvent(ctx, jobAgg, "", 0))
+			return err
+		}
+		_, err = c.eventstore.Push(ctx, userbulkjob.NewFailedEvent(ctx, jobAgg, "Errors.UserBulkJob.FileNotFound"))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := domain.ParseUserBulkImportFile(writeModel.Format, file)
+	if err != nil {
+		return c.failUserBulkJob(ctx, writeModel, "Errors.UserBulkJob.FileInvalid")
+	}
+	bulkImport, err := c.newUserBulkImport(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+
+	for writeModel.Processed < uint64(len(rows)) {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if writeModel.State != domain.UserBulkJobStateRunning {
+			return nil
+		}
+		row := rows[writeModel.Processed]
+		cmds, userID, err := c.importUserBulkRow(ctx, bulkImport, row)
+		if err == nil {
+			cmds = append(cmds, userbulkjob.NewRowImportedEvent(ctx, jobAgg, uint64(row.Row), userID))
+			_, err = c.eventstore.Push(ctx, cmds...)
+		}
+		if err != nil {
+			// errors which are not caused by the row (e.g. an unavailable database) stop the import,
+			// so the row is retried on the next run
+			if caos_errs.IsInternal(err) || ctx.Err() != nil {
+				return err
+			}
+			errorID, message := userBulkRowError(err)
+			if _, err = c.eventstore.Push(ctx, userbulkjob.NewRowFailedEvent(ctx, jobAgg, uint64(row.Row), row.UserID, errorID, message)); err != nil {
+				return err
+			}
+		}
+		// the refresh only reads the events after the processed sequence of the write model,
+		// which are the pushed row and a cancellation or removal of the job meanwhile
+		if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+	}
+	if writeModel.State != domain.UserBulkJobStateRunning {
+		return nil
+	}
+	if err = c.removeUserBulkImportFile(ctx, writeModel); err != nil {
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, userbulkjob.NewDoneEvent(ctx, jobAgg, "", 0))
+	return err
+}
+
+// removeUserBulkImportFile removes the uploaded file of an import which ends,
+// the file of an export is kept until the job is removed, so it can be downloaded
+func (c *Commands) removeUserBulkImportFile(ctx context.Context, writeModel *UserBulkJobWriteModel) error {
+	if writeModel.Type != domain.UserBulkJobTypeImport || writeModel.FileName == "" {
+		return nil
+	}
+	return c.removeAsset(ctx, writeModel.ResourceOwner, writeModel.FileName)
+}
+
+func userBulkRowError(err error) (id, message string) {
+	var caosErr caos_errs.Error
+	if errors.As(err, &caosErr) {
+		return caosErr.GetID(), caosErr.GetMessage()
+	}
+	return "", err.Error()
+}
+
+func (c *Commands) existingUserBulkJobWriteModel(ctx context.Context, jobID, resourceOwner string) (*UserBulkJobWriteModel, error) {
+	if jobID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ubj2i", "Errors.IDMissing")
+	}
+	writeModel := NewUserBulkJobWriteModel(jobID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ubj3n", "Errors.UserBulkJob.NotFound")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) putUserBulkJobFile(ctx context.Context, resourceOwner, fileName string, format domain.UserBulkJobFormat, file []byte) error {
+	_, err := c.static.PutObject(ctx,
+		authz.GetInstance(ctx).InstanceID(),
+		"",
+		resourceOwner,
+		fileName,
+		format.ContentType(),
+		static.ObjectTypeUserBulkJob,
+		bytes.NewReader(file),
+		int64(len(file)),
+	)
+	return err
+}
+
+// userBulkImport contains the policies and code generators for the users of an import,
+// they are loaded once per run of the job
+type userBulkImport struct {
+	orgID              string
+	domainPolicy       *domain.DomainPolicy
+	initCodeGenerator  crypto.Generator
+	emailCodeGenerator crypto.Generator
+	phoneCodeGenerator crypto.Generator
+}
+
+func (c *Commands) newUserBulkImport(ctx context.Context, orgID string) (_ *userBulkImport, err error) {
+	bulkImport := &userBulkImport{
+		orgID: orgID,
+	}
+	bulkImport.domainPolicy, err = c.getOrgDomainPolicy(ctx, orgID)
+	if err != nil {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-Ubj4d", "Errors.Org.DomainPolicy.NotFound")
+	}
+	bulkImport.initCodeGenerator, _, err = secretGenerator(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeInitCode, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	bulkImport.emailCodeGenerator, _, err = secretGenerator(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeVerifyEmailCode, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	bulkImport.phoneCodeGenerator, _, err = secretGenerator(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeVerifyPhoneCode, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	return bulkImport, nil
+}
+
+// importUserBulkRow returns the commands to create the user of the row including its second factor, metadata and grants
+func (c *Commands) importUserBulkRow(ctx context.Context, bulkImport *userBulkImport, row *domain.UserBulkImportRow) (_ []eventstore.Command, userID string, err error) {
+	if row.Err != nil {
+		return nil, "", row.Err
+	}
+	human, err := row.Human()
+	if err != nil {
+		return nil, "", err
+	}
+	if row.PasswordHash != "" {
+		// hashes can only be verified by the configured algorithm
+		if row.PasswordHashAlgorithm != c.userPasswordAlg.Algorithm() {
+			return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ubj8h", "Errors.UserBulkJob.PasswordHashAlgorithmNotSupported")
+		}
+		human.HashedPassword = domain.NewHashedPassword(row.PasswordHash, row.PasswordHashAlgorithm)
+	}
+	if human.AggregateID != "" {
+		existing, err := c.getHumanWriteModelByID(ctx, human.AggregateID, bulkImport.orgID)
+		if err != nil {
+			return nil, "", err
+		}
+		if existing.UserState != domain.UserStateUnspecified {
+			return nil, "", caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ubj5e", "Errors.User.AlreadyExisting")
+		}
+	}
+	links := make([]*domain.UserIDPLink, len(row.IDPLinks))
+	for i, link := range row.IDPLinks {
+		links[i] = &domain.UserIDPLink{
+			IDPConfigID:    link.IDPID,
+			ExternalUserID: link.ExternalUserID,
+			DisplayName:    link.DisplayName,
+		}
+	}
+	cmds, humanWriteModel, _, _, err := c.importHuman(ctx, bulkImport.orgID, human, false, links, bulkImport.domainPolicy, nil, bulkImport.initCodeGenerator, bulkImport.emailCodeGenerator, bulkImport.phoneCodeGenerator, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	userID = humanWriteModel.AggregateID
+	userAgg := UserAggregateFromWriteModel(&humanWriteModel.WriteModel)
+
+	if row.TOTPSecret != "" {
+		encryptedSecret, err := domain.EncryptOTPSecret(row.TOTPSecret, c.multifactors.OTP.CryptoMFA)
+		if err != nil {
+			return nil, "", err
+		}
+		cmds = append(cmds,
+			user.NewHumanOTPAddedEvent(ctx, userAgg, encryptedSecret),
+			user.NewHumanOTPVerifiedEvent(ctx, userAgg, ""),
+		)
+	}
+
+	metadataCmds, err := c.importUserBulkRowMetadata(ctx, bulkImport.orgID, userAgg, row.Metadata)
+	if err != nil {
+		return nil, "", err
+	}
+	cmds = append(cmds, metadataCmds...)
+
+	for _, grant := range row.Grants {
+		grantCmd, err := c.importUserBulkRowGrant(ctx, bulkImport.orgID, userID, grant)
+		if err != nil {
+			return nil, "", err
+		}
+		cmds = append(cmds, grantCmd)
+	}
+	return cmds, userID, nil
+}
+
+// importUserBulkRowMetadata sets the metadata sorted by key and checks the required attributes of the user schema
+func (c *Commands) importUserBulkRowMetadata(ctx context.Context, orgID string, userAgg *eventstore.Aggregate, metadata map[string]string) ([]eventstore.Command, error) {
+	schema, err := userSchemaWriteModel(ctx, c.eventstore.Filter, userAgg.ID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	cmds := make([]eventstore.Command, len(keys))
+	for i, key := range keys {
+		cmds[i], err = c.setUserMetadata(ctx, userAgg, schema, &domain.Metadata{Key: key, Value: []byte(metadata[key])})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = schema.checkRequired(); err != nil {
+		return nil, err
+	}
+	return cmds, nil
+}
+
+func (c *Commands) importUserBulkRowGrant(ctx context.Context, orgID, userID string, grant *domain.UserBulkImportGrant) (eventstore.Command, error) {
+	userGrant := &domain.UserGrant{
+		UserID:         userID,
+		ProjectID:      grant.ProjectID,
+		ProjectGrantID: grant.ProjectGrantID,
+		RoleKeys:       grant.Roles,
+	}
+	if !userGrant.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ubj6g", "Errors.UserGrant.Invalid")
+	}
+	preConditions := NewUserGrantPreConditionReadModel(userID, grant.ProjectID, grant.ProjectGrantID, orgID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, preConditions); err != nil {
+		return nil, err
+	}
+	// the user is created in the same push, so only the project and roles are checked
+	if err := checkUserGrantProjectPreCondition(preConditions, userGrant); err != nil {
+		return nil, err
+	}
+	grantID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	grantWriteModel := NewUserGrantWriteModel(grantID, orgID)
+	return usergrant.NewUserGrantAddedEvent(
+		ctx,
+		UserGrantAggregateFromWriteModel(&grantWriteModel.WriteModel),
+		userID,
+		grant.ProjectID,
+		grant.ProjectGrantID,
+		grant.Roles,
+	), nil
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/userbulkjob"
+)
+
+type UserBulkJobWriteModel struct {
+	eventstore.WriteModel
+
+	Type     domain.UserBulkJobType
+	Format   domain.UserBulkJobFormat
+	State    domain.UserBulkJobState
+	FileName string
+	Filter   *domain.UserBulkExportFilter
+	Total    uint64
+	// Processed is the amount of imported and failed rows,
+	// which is the index of the next row to be imported
+	Processed uint64
+	Failed    uint64
+}
+
+func NewUserBulkJobWriteModel(id, resourceOwner string) *UserBulkJobWriteModel {
+	return &UserBulkJobWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *UserBulkJobWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *userbulkjob.AddedEvent:
+			wm.Type = e.JobType
+			wm.Format = e.Format
+			wm.FileName = e.FileName
+			wm.Total = e.Total
+			wm.Filter = e.Filter.ToDomain()
+			wm.State = domain.UserBulkJobStateQueued
+		case *userbulkjob.StartedEvent:
+			wm.State = domain.UserBulkJobStateRunning
+		case *userbulkjob.RowImportedEvent:
+			wm.Processed++
+		case *userbulkjob.RowFailedEvent:
+			wm.Processed++
+			wm.Failed++
+		case *userbulkjob.DoneEvent:
+			if e.FileName != "" {
+				wm.FileName = e.FileName
+				wm.Total = e.Total
+			}
+			wm.State = domain.UserBulkJobStateDone
+		case *userbulkjob.FailedEvent:
+			wm.State = domain.UserBulkJobStateFailed
+		case *userbulkjob.CancelledEvent:
+			wm.State = domain.UserBulkJobStateCancelled
+		case *userbulkjob.RemovedEvent:
+			wm.State = domain.UserBulkJobStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// Query only returns the events after the already processed ones,
+// so a running import can refresh the state of the job without reading all rows again
+func (wm *UserBulkJobWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(userbulkjob.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		SequenceGreater(wm.ProcessedSequence).
+		EventTypes(
+			userbulkjob.AddedEventType,
+			userbulkjob.StartedEventType,
+			userbulkjob.RowImportedEventType,
+			userbulkjob.RowFailedEventType,
+			userbulkjob.DoneEventType,
+			userbulkjob.FailedEventType,
+			userbulkjob.CancelledEventType,
+			userbulkjob.RemovedEventType,
+		).
+		Builder()
+}
+
+func UserBulkJobAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, userbulkjob.AggregateType, userbulkjob.AggregateVersion)
+}
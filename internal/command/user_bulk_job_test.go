@@ -0,0 +1,964 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/userbulkjob"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/static/mock"
+)
+
+func TestCommandSide_AddUserBulkImportJob(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		storage     static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		format        domain.UserBulkJobFormat
+		file          []byte
+	}
+	type res struct {
+		id   string
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resource owner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				format: domain.UserBulkJobFormatCSV,
+				file:   []byte("user_name\ngigi"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid file, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				format:        domain.UserBulkJobFormatCSV,
+				file:          []byte("first_name\nGigi"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no rows, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				format:        domain.UserBulkJobFormatCSV,
+				file:          []byte("user_name\n"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "store file failed, internal error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "job1"),
+				storage:     mock.NewStorage(t).ExpectPutObjectError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				format:        domain.UserBulkJobFormatCSV,
+				file:          []byte("user_name\ngigi\ngaga"),
+			},
+			res: res{
+				err: caos_errs.IsInternal,
+			},
+		},
+		{
+			name: "add import job, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewAddedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									domain.UserBulkJobTypeImport,
+									domain.UserBulkJobFormatCSV,
+									"user_bulk_jobs/job1/import.csv",
+									2,
+									nil,
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "job1"),
+				storage:     mock.NewStorage(t).ExpectPutObject(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				format:        domain.UserBulkJobFormatCSV,
+				file:          []byte("user_name\ngigi\ngaga"),
+			},
+			res: res{
+				id: "job1",
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+				static:      tt.fields.storage,
+			}
+			gotID, got, err := r.AddUserBulkImportJob(tt.args.ctx, tt.args.resourceOwner, tt.args.format, tt.args.file)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, gotID)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddUserBulkExportJob(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		format        domain.UserBulkJobFormat
+		filter        *domain.UserBulkExportFilter
+	}
+	type res struct {
+		id   string
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resource owner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				format: domain.UserBulkJobFormatJSON,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "format invalid, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				format:        domain.UserBulkJobFormatUnspecified,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add export job, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewAddedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									domain.UserBulkJobTypeExport,
+									domain.UserBulkJobFormatJSON,
+									"",
+									0,
+									&userbulkjob.ExportFilter{
+										Type:     domain.UserTypeHuman,
+										UserName: "gigi",
+									},
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "job1"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				format:        domain.UserBulkJobFormatJSON,
+				filter: &domain.UserBulkExportFilter{
+					Type:     domain.UserTypeHuman,
+					UserName: "gigi",
+				},
+			},
+			res: res{
+				id: "job1",
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			gotID, got, err := r.AddUserBulkExportJob(tt.args.ctx, tt.args.resourceOwner, tt.args.format, tt.args.filter)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, gotID)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_CancelUserBulkJob(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		storage    static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		jobID         string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "job id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "job not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "job done, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeExport,
+								domain.UserBulkJobFormatCSV,
+								"",
+								0,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							userbulkjob.NewStartedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+							),
+						),
+						eventFromEventPusher(
+							userbulkjob.NewDoneEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								"user_bulk_jobs/job1/export.csv",
+								1,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "cancel job, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeExport,
+								domain.UserBulkJobFormatCSV,
+								"",
+								0,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewCancelledEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "cancel import, file removed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/job1/import.csv",
+								2,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewCancelledEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+				storage: mock.NewMockStorage(gomock.NewController(t)).ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				static:     tt.fields.storage,
+			}
+			got, err := r.CancelUserBulkJob(tt.args.ctx, tt.args.jobID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveUserBulkJob(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		storage    static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		jobID         string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "job not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove job with file, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/job1/import.csv",
+								2,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewRemovedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+				storage: mock.NewMockStorage(gomock.NewController(t)).ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				static:     tt.fields.storage,
+			}
+			got, err := r.RemoveUserBulkJob(tt.args.ctx, tt.args.jobID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ProcessUserBulkImportJob(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		storage    func(*testing.T) static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		jobID         string
+		resourceOwner string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "export job, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeExport,
+								domain.UserBulkJobFormatCSV,
+								"",
+								0,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "cancelled job, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/job1/import.csv",
+								2,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							userbulkjob.NewCancelledEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "file not found, job failed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/job1/import.csv",
+								2,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewStartedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewFailedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									"Errors.UserBulkJob.FileNotFound",
+								),
+							),
+						},
+					),
+				),
+				storage: func(t *testing.T) static.Storage {
+					storage := mock.NewMockStorage(gomock.NewController(t))
+					storage.EXPECT().GetObject(gomock.Any(), gomock.Any(), "org1", "user_bulk_jobs/job1/import.csv").
+						Return(nil, nil, caos_errs.ThrowNotFound(nil, "", ""))
+					return storage
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{},
+		},
+		{
+			name: "file not found after all rows were processed, job done",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/job1/import.csv",
+								2,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							userbulkjob.NewStartedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+							),
+						),
+						eventFromEventPusher(
+							userbulkjob.NewRowImportedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								1,
+								"user1",
+							),
+						),
+						eventFromEventPusher(
+							userbulkjob.NewRowImportedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								2,
+								"user2",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewDoneEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									"",
+									0,
+								),
+							),
+						},
+					),
+				),
+				storage: func(t *testing.T) static.Storage {
+					storage := mock.NewMockStorage(gomock.NewController(t))
+					storage.EXPECT().GetObject(gomock.Any(), gomock.Any(), "org1", "user_bulk_jobs/job1/import.csv").
+						Return(nil, nil, caos_errs.ThrowNotFound(nil, "", ""))
+					return storage
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{},
+		},
+		{
+			name: "file invalid, file removed and job failed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/job1/import.csv",
+								2,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewStartedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewFailedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									"Errors.UserBulkJob.FileInvalid",
+								),
+							),
+						},
+					),
+				),
+				storage: func(t *testing.T) static.Storage {
+					storage := mock.NewMockStorage(gomock.NewController(t))
+					storage.EXPECT().GetObject(gomock.Any(), gomock.Any(), "org1", "user_bulk_jobs/job1/import.csv").
+						Return([]byte("first_name\nGigi\n"), nil, nil)
+					storage.EXPECT().RemoveObject(gomock.Any(), gomock.Any(), "org1", "user_bulk_jobs/job1/import.csv").
+						Return(nil)
+					return storage
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{},
+		},
+		{
+			name: "invalid rows reported, file removed and job done",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewAddedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/job1/import.csv",
+								2,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewStartedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+								true,
+								true,
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewRowFailedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									1,
+									"",
+									"DOMAIN-Ubj9c",
+									"Errors.UserBulkJob.RowInvalid",
+								),
+							),
+						},
+					),
+					// the refresh only returns the events after the processed sequence
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewRowFailedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								1,
+								"",
+								"DOMAIN-Ubj9c",
+								"Errors.UserBulkJob.RowInvalid",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewRowFailedEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									2,
+									"user2",
+									"COMMAND-Ubj8h",
+									"Errors.UserBulkJob.PasswordHashAlgorithmNotSupported",
+								),
+							),
+						},
+					),
+					expectFilter(
+						eventFromEventPusher(
+							userbulkjob.NewRowFailedEvent(context.Background(),
+								&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+								2,
+								"user2",
+								"COMMAND-Ubj8h",
+								"Errors.UserBulkJob.PasswordHashAlgorithmNotSupported",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								userbulkjob.NewDoneEvent(context.Background(),
+									&userbulkjob.NewAggregate("job1", "org1").Aggregate,
+									"",
+									0,
+								),
+							),
+						},
+					),
+				),
+				storage: func(t *testing.T) static.Storage {
+					storage := mock.NewMockStorage(gomock.NewController(t))
+					storage.EXPECT().GetObject(gomock.Any(), gomock.Any(), "org1", "user_bulk_jobs/job1/import.csv").
+						Return([]byte("user_id,user_name,first_name,last_name,email,password_hash,password_hash_algorithm\n"+
+							"user1,too,few\n"+
+							"user2,gigi,Gigi,Giraffe,gigi@zitadel.com,$1$hash,md5\n"), nil, nil)
+					storage.EXPECT().RemoveObject(gomock.Any(), gomock.Any(), "org1", "user_bulk_jobs/job1/import.csv").
+						Return(nil)
+					return storage
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				jobID:         "job1",
+				resourceOwner: "org1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore,
+				userEncryption:  crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+			}
+			if tt.fields.storage != nil {
+				r.static = tt.fields.storage(t)
+			}
+			err := r.ProcessUserBulkImportJob(tt.args.ctx, tt.args.jobID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
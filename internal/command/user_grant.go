@@ -294,14 +294,20 @@ func (c *Commands) checkUserGrantPreCondition(ctx context.Context, usergrant *do
 	if !preConditions.UserExists {
 		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-4f8sg", "Errors.User.NotFound")
 	}
+	return checkUserGrantProjectPreCondition(preConditions, usergrant)
+}
+
+// checkUserGrantProjectPreCondition checks the project (grant) and roles of the user grant,
+// it's also used for grants of users which are created in the same push
+func checkUserGrantProjectPreCondition(preConditions *UserGrantPreConditionReadModel, usergrant *domain.UserGrant) error {
 	if usergrant.ProjectGrantID == "" && !preConditions.ProjectExists {
-		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-3n77S", "Errors.Project.NotFound")
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3n77S", "Errors.Project.NotFound")
 	}
 	if usergrant.ProjectGrantID != "" && !preConditions.ProjectGrantExists {
-		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-4m9ff", "Errors.Project.Grant.NotFound")
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-4m9ff", "Errors.Project.Grant.NotFound")
 	}
 	if usergrant.HasInvalidRoles(preConditions.ExistingRoleKeys) {
-		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-mm9F4", "Errors.Project.Role.NotFound")
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-mm9F4", "Errors.Project.Role.NotFound")
 	}
 	return nil
 }
@@ -123,6 +123,9 @@ func (c *Commands) AddHuman(ctx context.Context, resourceOwner string, human *Ad
 	if resourceOwner == "" {
 		return errors.ThrowInvalidArgument(nil, "COMMA-5Ky74", "Errors.Internal")
 	}
+	if err = c.runAddHumanPreCreationActions(ctx, resourceOwner, human); err != nil {
+		return err
+	}
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter,
 		c.AddHumanCommand(
 			human,
@@ -145,6 +148,7 @@ func (c *Commands) AddHuman(ctx context.Context, resourceOwner string, human *Ad
 		ResourceOwner: events[len(events)-1].Aggregate().ResourceOwner,
 	}
 
+	c.runUserPostActions(ctx, domain.TriggerTypePostCreation, resourceOwner, human.ID)
 	return nil
 }
 
@@ -227,13 +231,19 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, passwordAlg cr
 				return nil, err
 			}
 
+			schema, err := userSchemaWriteModel(ctx, filter, a.ID, a.ResourceOwner)
+			if err != nil {
+				return nil, err
+			}
 			for _, metadataEntry := range human.Metadata {
-				cmds = append(cmds, user.NewMetadataSetEvent(
-					ctx,
-					&a.Aggregate,
-					metadataEntry.Key,
-					metadataEntry.Value,
-				))
+				cmd, err := schema.setMetadata(ctx, &a.Aggregate, metadataEntry.Key, metadataEntry.Value)
+				if err != nil {
+					return nil, err
+				}
+				cmds = append(cmds, cmd)
+			}
+			if err = schema.checkRequired(); err != nil {
+				return nil, err
 			}
 			for _, link := range human.Links {
 				cmd, err := addLink(ctx, filter, a, link)
@@ -400,6 +410,9 @@ func (h *AddHuman) shouldAddInitCode() bool {
 			h.Password == ""
 }
 
+// ImportHuman creates the human including its IDP links and optionally a passwordless init code.
+// The actions of the post creation trigger run after the user is stored,
+// so the metadata set by them is best-effort and a failed action doesn't fail the import.
 func (c *Commands) ImportHuman(ctx context.Context, orgID string, human *domain.Human, passwordless bool, links []*domain.UserIDPLink, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator, passwordlessCodeGenerator crypto.Generator) (_ *domain.Human, passwordlessCode *domain.PasswordlessInitCode, err error) {
 	if orgID == "" {
 		return nil, nil, errors.ThrowInvalidArgument(nil, "COMMAND-5N8fs", "Errors.ResourceOwnerMissing")
@@ -424,6 +437,10 @@ func (c *Commands) ImportHuman(ctx context.Context, orgID string, human *domain.
 		}
 	}
 
+	metadata, err := c.runUserPreCreationActions(ctx, orgID, human, nil)
+	if err != nil {
+		return nil, nil, err
+	}
 	events, addedHuman, addedCode, code, err := c.importHuman(ctx, orgID, human, passwordless, links, domainPolicy, pwPolicy, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator, passwordlessCodeGenerator)
 	if err != nil {
 		return nil, nil, err
@@ -432,6 +449,11 @@ func (c *Commands) ImportHuman(ctx context.Context, orgID string, human *domain.
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(metadata) > 0 {
+		if _, err = c.BulkSetUserMetadata(ctx, addedHuman.AggregateID, orgID, metadata...); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	err = AppendAndReduce(addedHuman, pushedEvents...)
 	if err != nil {
@@ -444,11 +466,14 @@ func (c *Commands) ImportHuman(ctx context.Context, orgID string, human *domain.
 		}
 		passwordlessCode = writeModelToPasswordlessInitCode(addedCode, code)
 	}
+	c.runUserPostActions(ctx, domain.TriggerTypePostCreation, orgID, addedHuman.AggregateID)
 
 	return writeModelToHuman(addedHuman), passwordlessCode, nil
 }
 
-func (c *Commands) RegisterHuman(ctx context.Context, orgID string, human *domain.Human, link *domain.UserIDPLink, orgMemberRoles []string, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator crypto.Generator) (*domain.Human, error) {
+// RegisterHuman creates a self registered user with the values of the attributes of the user schema in metadata.
+// Required attributes visible to the user are only enforced if the user registers without an identity provider.
+func (c *Commands) RegisterHuman(ctx context.Context, orgID string, human *domain.Human, link *domain.UserIDPLink, orgMemberRoles []string, metadata []*domain.Metadata, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator crypto.Generator) (*domain.Human, error) {
 	if orgID == "" {
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-GEdf2", "Errors.ResourceOwnerMissing")
 	}
@@ -471,6 +496,23 @@ func (c *Commands) RegisterHuman(ctx context.Context, orgID string, human *domai
 	if err != nil {
 		return nil, err
 	}
+	schema, err := userSchemaWriteModel(ctx, c.eventstore.Filter, registeredHuman.AggregateID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&registeredHuman.WriteModel)
+	for _, data := range metadata {
+		event, err := c.setUserMetadata(ctx, userAgg, schema, data)
+		if err != nil {
+			return nil, err
+		}
+		userEvents = append(userEvents, event)
+	}
+	if link == nil {
+		if err = schema.checkSelfRequired(); err != nil {
+			return nil, err
+		}
+	}
 
 	orgMemberWriteModel := NewOrgMemberWriteModel(orgID, registeredHuman.AggregateID)
 	orgAgg := OrgAggregateFromWriteModel(&orgMemberWriteModel.WriteModel)
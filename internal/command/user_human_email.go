@@ -61,6 +61,7 @@ func (c *Commands) ChangeHumanEmail(ctx context.Context, email *domain.Email, em
 	if err != nil {
 		return nil, err
 	}
+	c.runUserPostActions(ctx, domain.TriggerTypePostChange, existingEmail.ResourceOwner, existingEmail.AggregateID)
 	return writeModelToEmail(existingEmail), nil
 }
 
@@ -46,6 +46,7 @@ func (c *Commands) SetPassword(ctx context.Context, orgID, userID, passwordStrin
 	if err != nil {
 		return nil, err
 	}
+	c.runPostPasswordChangeActions(ctx, existingPassword.ResourceOwner, userID, password.ChangeRequired)
 	return writeModelToObjectDetails(&existingPassword.WriteModel), nil
 }
 
@@ -86,6 +87,7 @@ func (c *Commands) SetPasswordWithVerifyCode(ctx context.Context, orgID, userID,
 	if err != nil {
 		return nil, err
 	}
+	c.runPostPasswordChangeActions(ctx, existingCode.ResourceOwner, userID, password.ChangeRequired)
 	return writeModelToObjectDetails(&existingCode.WriteModel), nil
 }
 
@@ -131,6 +133,7 @@ func (c *Commands) ChangePassword(ctx context.Context, orgID, userID, oldPasswor
 	if err != nil {
 		return nil, err
 	}
+	c.runPostPasswordChangeActions(ctx, existingPassword.ResourceOwner, userID, password.ChangeRequired)
 	return writeModelToObjectDetails(&existingPassword.WriteModel), nil
 }
 
@@ -262,7 +265,7 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
 	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
 		if existingPassword.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			events = append(events, user.NewUserLockedEvent(ctx, userAgg))
+			events = append(events, user.NewUserLockedByLockoutPolicyEvent(ctx, userAgg))
 		}
 
 	}
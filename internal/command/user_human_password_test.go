@@ -1212,6 +1212,9 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1252,6 +1255,9 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1293,6 +1299,9 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1350,6 +1359,9 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1441,6 +1453,9 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1495,7 +1510,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								),
 							),
 							eventFromEventPusher(
-								user.NewUserLockedEvent(context.Background(),
+								user.NewUserLockedByLockoutPolicyEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
 								),
 							),
@@ -1539,6 +1554,9 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								0,
+								0,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -56,6 +56,7 @@ func (c *Commands) ChangeHumanPhone(ctx context.Context, phone *domain.Phone, re
 	if err != nil {
 		return nil, err
 	}
+	c.runUserPostActions(ctx, domain.TriggerTypePostChange, existingPhone.ResourceOwner, existingPhone.AggregateID)
 
 	return writeModelToPhone(existingPhone), nil
 }
@@ -39,6 +39,7 @@ func (c *Commands) ChangeHumanProfile(ctx context.Context, profile *domain.Profi
 	if err != nil {
 		return nil, err
 	}
+	c.runUserPostActions(ctx, domain.TriggerTypePostChange, existingProfile.ResourceOwner, existingProfile.AggregateID)
 
 	return writeModelToProfile(existingProfile), nil
 }
@@ -0,0 +1,192 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddHumanRecoveryCodes generates a set of recovery codes for the user, if the user has none yet.
+// The plain codes are only returned once and can't be retrieved afterwards.
+func (c *Commands) AddHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.RecoveryCodes, error) {
+	return c.generateHumanRecoveryCodes(ctx, userID, resourceOwner, false)
+}
+
+// RegenerateHumanRecoveryCodes generates a new set of recovery codes for the user,
+// which invalidates all codes of the previous set.
+func (c *Commands) RegenerateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.RecoveryCodes, error) {
+	return c.generateHumanRecoveryCodes(ctx, userID, resourceOwner, true)
+}
+
+// GenerateUserRecoveryCodes (re-)generates the recovery codes of the authenticated user.
+func (c *Commands) GenerateUserRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.RecoveryCodes, error) {
+	if err := authz.UserIDInCTX(ctx, userID); err != nil {
+		return nil, err
+	}
+	return c.RegenerateHumanRecoveryCodes(ctx, userID, resourceOwner)
+}
+
+func (c *Commands) generateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string, replace bool) (*domain.RecoveryCodes, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gk2pq", "Errors.User.UserIDMissing")
+	}
+	if _, err := c.getHuman(ctx, userID, resourceOwner); err != nil {
+		return nil, err
+	}
+	wm, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !replace && wm.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Tn8wz", "Errors.User.MFA.RecoveryCodes.AlreadyReady")
+	}
+	plain, hashed, err := domain.GenerateRecoveryCodes(domain.RecoveryCodesCount)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, wm, user.NewHumanRecoveryCodesAddedEvent(ctx, userAgg, hashed)); err != nil {
+		return nil, err
+	}
+	return &domain.RecoveryCodes{
+		ObjectDetails: writeModelToObjectDetails(&wm.WriteModel),
+		Codes:         plain,
+	}, nil
+}
+
+// RemoveUserRecoveryCodes removes the recovery codes of the authenticated user.
+func (c *Commands) RemoveUserRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if err := authz.UserIDInCTX(ctx, userID); err != nil {
+		return nil, err
+	}
+	return c.HumanRemoveRecoveryCodes(ctx, userID, resourceOwner)
+}
+
+func (c *Commands) HumanRemoveRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vb4nm", "Errors.User.UserIDMissing")
+	}
+	wm, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if wm.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Zu3rk", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, wm, user.NewHumanRecoveryCodesRemovedEvent(ctx, userAgg)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// HumanCheckRecoveryCode verifies the recovery code as second factor of the auth request.
+// The code is consumed on success.
+func (c *Commands) HumanCheckRecoveryCode(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lw8cz", "Errors.User.UserIDMissing")
+	}
+	wm, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if err = checkRecoveryCodesUsable(wm); err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	index, err := domain.VerifyRecoveryCode(code, wm.Codes, wm.Used)
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, index, wm.Codes[index], info))
+		return err
+	}
+	pushErr := pushRecoveryCodeCheckFailed(ctx, c.eventstore, wm, userAgg, info)
+	logging.WithFields("userID", userID).OnError(pushErr).Error("recovery code check failed push failed")
+	return err
+}
+
+// CheckRecoveryCode defines a recovery code check to be executed for a session update.
+// The code is consumed on success.
+func CheckRecoveryCode(code string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Dj3sn", "Errors.User.UserIDMissing")
+		}
+		wm := NewHumanRecoveryCodesWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+			return err
+		}
+		if err := checkRecoveryCodesUsable(wm); err != nil {
+			return err
+		}
+		userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
+		index, err := domain.VerifyRecoveryCode(code, wm.Codes, wm.Used)
+		if err != nil {
+			pushErr := pushRecoveryCodeCheckFailed(ctx, cmd.eventstore, wm, userAgg, nil)
+			logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("recovery code check failed push failed")
+			return err
+		}
+		cmd.sessionWriteModel.RecoveryCodeChecked(ctx, cmd.now(), userAgg, index, wm.Codes[index])
+		return nil
+	}
+}
+
+func checkRecoveryCodesUsable(wm *HumanRecoveryCodesWriteModel) error {
+	if wm.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ht5sq", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	if wm.UserLocked {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Rk4ua", "Errors.User.Locked")
+	}
+	return nil
+}
+
+// pushRecoveryCodeCheckFailed counts the failed check towards the lockout policy of the user's organisation
+// and locks the user as soon as the max attempts are reached, so the codes can't be brute-forced.
+// The lockout policy has no separate limit for second factors, so the max password attempts apply.
+func pushRecoveryCodeCheckFailed(ctx context.Context, es *eventstore.Eventstore, wm *HumanRecoveryCodesWriteModel, userAgg *eventstore.Aggregate, info *user.AuthRequestInfo) error {
+	events := []eventstore.Command{
+		user.NewHumanRecoveryCodeCheckFailedEvent(ctx, userAgg, info),
+	}
+	lockoutPolicy, err := lockoutPolicyByOrg(ctx, es, wm.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if lockoutPolicy.MaxPasswordAttempts > 0 && wm.CheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
+		events = append(events, user.NewUserLockedByLockoutPolicyEvent(ctx, userAgg))
+	}
+	_, err = es.Push(ctx, events...)
+	return err
+}
+
+// HumanRecoveryCodeUsedSent marks the notification about the used recovery code as sent.
+func (c *Commands) HumanRecoveryCodeUsedSent(ctx context.Context, userID, resourceOwner string, index int) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mx7va", "Errors.User.UserIDMissing")
+	}
+	wm, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeUsedSentEvent(ctx, UserAggregateFromWriteModel(&wm.WriteModel), index))
+	return err
+}
+
+func (c *Commands) recoveryCodesWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanRecoveryCodesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
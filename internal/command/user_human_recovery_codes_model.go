@@ -0,0 +1,79 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanRecoveryCodesWriteModel struct {
+	eventstore.WriteModel
+
+	State domain.MFAState
+	Codes []*crypto.CryptoValue
+	Used  []bool
+
+	CheckFailedCount uint64
+	UserLocked       bool
+}
+
+func NewHumanRecoveryCodesWriteModel(userID, resourceOwner string) *HumanRecoveryCodesWriteModel {
+	return &HumanRecoveryCodesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanRecoveryCodesAddedEvent:
+			wm.Codes = e.Codes
+			wm.Used = make([]bool, len(e.Codes))
+			wm.State = domain.MFAStateReady
+			wm.CheckFailedCount = 0
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			if e.Index >= 0 && e.Index < len(wm.Used) {
+				wm.Used[e.Index] = true
+			}
+			wm.CheckFailedCount = 0
+		case *user.HumanRecoveryCodeCheckFailedEvent:
+			wm.CheckFailedCount += 1
+		case *user.UserLockedEvent:
+			wm.UserLocked = true
+		case *user.UserUnlockedEvent:
+			wm.UserLocked = false
+			wm.CheckFailedCount = 0
+		case *user.HumanRecoveryCodesRemovedEvent:
+			wm.Codes, wm.Used = nil, nil
+			wm.State = domain.MFAStateRemoved
+		case *user.UserRemovedEvent:
+			wm.Codes, wm.Used = nil, nil
+			wm.State = domain.MFAStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanRecoveryCodesAddedType,
+			user.HumanRecoveryCodeCheckSucceededType,
+			user.HumanRecoveryCodeCheckFailedType,
+			user.HumanRecoveryCodesRemovedType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
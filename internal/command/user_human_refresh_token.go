@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -24,11 +25,13 @@ func (c *Commands) AddAccessAndRefreshToken(
 	refreshIdleExpiration,
 	refreshExpiration time.Duration,
 	authTime time.Time,
+	confirmation *authz.TokenConfirmation,
+	rotation domain.RefreshTokenRotationPolicy,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
+		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime, confirmation)
 	}
-	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime)
+	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime, confirmation, rotation)
 }
 
 func (c *Commands) AddNewRefreshTokenAndAccessToken(
@@ -44,6 +47,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	accessLifetime,
 	refreshIdleExpiration time.Duration,
 	authTime time.Time,
+	confirmation *authz.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if userID == "" || agentID == "" || clientID == "" {
 		return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-adg4r", "Errors.IDMissing")
@@ -53,7 +57,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	if err != nil {
 		return nil, "", err
 	}
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -79,13 +83,15 @@ func (c *Commands) RenewRefreshTokenAndAccessToken(
 	scopes []string,
 	idleExpiration,
 	accessLifetime time.Duration,
+	confirmation *authz.TokenConfirmation,
+	rotation domain.RefreshTokenRotationPolicy,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
-	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration)
+	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration, confirmation, rotation)
 	if err != nil {
 		return nil, "", err
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -136,11 +142,11 @@ func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Toke
 	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(accessToken.AggregateID, accessToken.ResourceOwner, accessToken.RefreshTokenID)
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenAddedEvent(ctx, userAgg, accessToken.RefreshTokenID, accessToken.ApplicationID, accessToken.UserAgentID,
-			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration),
+			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration, accessToken.Confirmation.GetJKT()),
 		refreshToken, nil
 }
 
-func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
+func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration, confirmation *authz.TokenConfirmation, rotation domain.RefreshTokenRotationPolicy) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
 	if refreshToken == "" {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-DHrr3", "Errors.IDMissing")
 	}
@@ -160,21 +166,43 @@ func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refresh
 	if refreshTokenWriteModel.UserState != domain.UserStateActive {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-BHnhs", "Errors.User.RefreshToken.Invalid")
 	}
-	if refreshTokenWriteModel.RefreshToken != token ||
-		refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
+	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
+	if refreshTokenWriteModel.RefreshToken != token {
+		if !refreshTokenWriteModel.RotatedRefreshTokens[token] {
+			return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
+		}
+		// the previous token is still accepted during the reuse interval (e.g. for retries after a lost response)
+		// and results in the current token of the family
+		if token != refreshTokenWriteModel.PreviousRefreshToken ||
+			refreshTokenWriteModel.RotationDate.Add(rotation.ReuseInterval).Before(time.Now()) {
+			// an already rotated token was replayed, so the whole family is revoked
+			_, err = c.eventstore.Push(ctx, user.NewHumanRefreshTokenReuseDetectedEvent(ctx, userAgg, tokenID, refreshTokenWriteModel.ClientID, refreshTokenWriteModel.UserAgentID))
+			if err != nil {
+				return nil, "", "", err
+			}
+			return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aeb3o", "Errors.User.RefreshToken.Invalid")
+		}
+	}
+	if refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
 		refreshTokenWriteModel.Expiration.Before(time.Now()) {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
 	}
+	// refresh tokens bound to a DPoP key can only be used with a proof of the same key
+	if refreshTokenWriteModel.ConfirmationJKT != "" && refreshTokenWriteModel.ConfirmationJKT != confirmation.GetJKT() {
+		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aib4o", "Errors.User.RefreshToken.Invalid")
+	}
 
-	newToken, err := c.idGenerator.Next()
-	if err != nil {
-		return nil, "", "", err
+	newToken := refreshTokenWriteModel.RefreshToken
+	if rotation.Rotation == domain.OIDCRefreshTokenRotationEnabled && refreshTokenWriteModel.RefreshToken == token {
+		newToken, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, "", "", err
+		}
 	}
 	newRefreshToken, err = domain.RefreshToken(userID, tokenID, newToken, c.keyAlgorithm)
 	if err != nil {
 		return nil, "", "", err
 	}
-	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenRenewedEvent(ctx, userAgg, tokenID, newToken, idleExpiration), tokenID, newRefreshToken, nil
 }
 
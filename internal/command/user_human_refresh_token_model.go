@@ -14,11 +14,20 @@ type HumanRefreshTokenWriteModel struct {
 
 	TokenID      string
 	RefreshToken string
+	ClientID     string
+
+	// PreviousRefreshToken and RotationDate are used to accept the previous token during the reuse interval
+	PreviousRefreshToken string
+	RotationDate         time.Time
+	// RotatedRefreshTokens contains all tokens of the family which were already replaced by a newer one
+	RotatedRefreshTokens map[string]bool
 
 	UserState      domain.UserState
 	IdleExpiration time.Time
 	Expiration     time.Time
 	UserAgentID    string
+
+	ConfirmationJKT string
 }
 
 func NewHumanRefreshTokenWriteModel(userID, resourceOwner, tokenID string) *HumanRefreshTokenWriteModel {
@@ -27,7 +36,8 @@ func NewHumanRefreshTokenWriteModel(userID, resourceOwner, tokenID string) *Huma
 			AggregateID:   userID,
 			ResourceOwner: resourceOwner,
 		},
-		TokenID: tokenID,
+		TokenID:              tokenID,
+		RotatedRefreshTokens: make(map[string]bool),
 	}
 }
 
@@ -49,6 +59,11 @@ func (wm *HumanRefreshTokenWriteModel) AppendEvents(events ...eventstore.Event)
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *user.HumanRefreshTokenReuseDetectedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		default:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -65,9 +80,13 @@ func (wm *HumanRefreshTokenWriteModel) Reduce() error {
 			wm.Expiration = e.CreationDate().Add(e.Expiration)
 			wm.UserState = domain.UserStateActive
 			wm.UserAgentID = e.UserAgentID
+			wm.ClientID = e.ClientID
+			wm.ConfirmationJKT = e.ConfirmationJKT
 		case *user.HumanRefreshTokenRenewedEvent:
-			if wm.UserState == domain.UserStateActive {
-				wm.RefreshToken = e.RefreshToken
+			if e.RefreshToken != wm.RefreshToken {
+				wm.PreviousRefreshToken = wm.RefreshToken
+				wm.RotatedRefreshTokens[wm.RefreshToken] = true
+				wm.RotationDate = e.CreationDate()
 			}
 			wm.RefreshToken = e.RefreshToken
 			wm.IdleExpiration = e.CreationDate().Add(e.IdleExpiration)
@@ -76,6 +95,7 @@ func (wm *HumanRefreshTokenWriteModel) Reduce() error {
 				wm.UserState = domain.UserStateDeleted
 			}
 		case *user.HumanRefreshTokenRemovedEvent,
+			*user.HumanRefreshTokenReuseDetectedEvent,
 			*user.UserLockedEvent,
 			*user.UserDeactivatedEvent,
 			*user.UserRemovedEvent:
@@ -94,6 +114,7 @@ func (wm *HumanRefreshTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanRefreshTokenAddedType,
 			user.HumanRefreshTokenRenewedType,
 			user.HumanRefreshTokenRemovedType,
+			user.HumanRefreshTokenReuseDetectedType,
 			user.HumanSignedOutType,
 			user.UserLockedType,
 			user.UserDeactivatedType,
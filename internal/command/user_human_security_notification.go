@@ -0,0 +1,25 @@
+package command
+
+import (
+	"context"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanSecurityNotificationSent marks the security notification of the message type
+// about the user event with the sequence as sent.
+func (c *Commands) HumanSecurityNotificationSent(ctx context.Context, orgID, userID, messageType string, eventSequence uint64) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sn8xq", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sn3nf", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanSecurityNotificationSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), messageType, eventSequence))
+	return err
+}
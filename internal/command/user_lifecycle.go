@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// AddUserLifecycleWarning informs the user that the account will be deactivated or deleted
+// because of the user lifecycle policy at the due date, unless the user logs in again.
+func (c *Commands) AddUserLifecycleWarning(ctx context.Context, orgID, userID string, action domain.UserLifecycleAction, dueDate time.Time) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lw8dq", "Errors.User.UserIDMissing")
+	}
+	if !action.IsWarning() {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lw2ia", "Errors.UserLifecyclePolicy.InvalidWarning")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lw5nf", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserLifecycleWarningAddedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), action, dueDate))
+	return err
+}
+
+func (c *Commands) UserLifecycleWarningSent(ctx context.Context, orgID, userID string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lw6sq", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lw1nf", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserLifecycleWarningSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+	return err
+}
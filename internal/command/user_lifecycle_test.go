@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_AddUserLifecycleWarning(t *testing.T) {
+	dueDate := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		orgID   string
+		userID  string
+		action  domain.UserLifecycleAction
+		dueDate time.Time
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "org1",
+				action:  domain.UserLifecycleActionWarnDeactivation,
+				dueDate: dueDate,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no warning action, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "org1",
+				userID:  "user1",
+				action:  domain.UserLifecycleActionDeactivate,
+				dueDate: dueDate,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "org1",
+				userID:  "user1",
+				action:  domain.UserLifecycleActionWarnDeactivation,
+				dueDate: dueDate,
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "warning added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewUserLifecycleWarningAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									domain.UserLifecycleActionWarnDeactivation,
+									dueDate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "org1",
+				userID:  "user1",
+				action:  domain.UserLifecycleActionWarnDeactivation,
+				dueDate: dueDate,
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.AddUserLifecycleWarning(tt.args.ctx, tt.args.orgID, tt.args.userID, tt.args.action, tt.args.dueDate)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -14,9 +14,13 @@ func (c *Commands) SetUserMetadata(ctx context.Context, metadata *domain.Metadat
 	if err != nil {
 		return nil, err
 	}
+	schema, err := userSchemaWriteModel(ctx, c.eventstore.Filter, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
 	setMetadata := NewUserMetadataWriteModel(userID, resourceOwner, metadata.Key)
 	userAgg := UserAggregateFromWriteModel(&setMetadata.WriteModel)
-	event, err := c.setUserMetadata(ctx, userAgg, metadata)
+	event, err := c.setUserMetadata(ctx, userAgg, schema, metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -41,11 +45,16 @@ func (c *Commands) BulkSetUserMetadata(ctx context.Context, userID, resourceOwne
 		return nil, err
 	}
 
+	schema, err := userSchemaWriteModel(ctx, c.eventstore.Filter, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
 	events := make([]eventstore.Command, len(metadatas))
 	setMetadata := NewUserMetadataListWriteModel(userID, resourceOwner)
 	userAgg := UserAggregateFromWriteModel(&setMetadata.WriteModel)
 	for i, data := range metadatas {
-		event, err := c.setUserMetadata(ctx, userAgg, data)
+		event, err := c.setUserMetadata(ctx, userAgg, schema, data)
 		if err != nil {
 			return nil, err
 		}
@@ -64,16 +73,11 @@ func (c *Commands) BulkSetUserMetadata(ctx context.Context, userID, resourceOwne
 	return writeModelToObjectDetails(&setMetadata.WriteModel), nil
 }
 
-func (c *Commands) setUserMetadata(ctx context.Context, userAgg *eventstore.Aggregate, metadata *domain.Metadata) (command eventstore.Command, err error) {
+func (c *Commands) setUserMetadata(ctx context.Context, userAgg *eventstore.Aggregate, schema *UserSchemaWriteModel, metadata *domain.Metadata) (command eventstore.Command, err error) {
 	if !metadata.IsValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "META-2m00f", "Errors.Metadata.Invalid")
 	}
-	return user.NewMetadataSetEvent(
-		ctx,
-		userAgg,
-		metadata.Key,
-		metadata.Value,
-	), nil
+	return schema.setMetadata(ctx, userAgg, metadata.Key, metadata.Value)
 }
 
 func (c *Commands) RemoveUserMetadata(ctx context.Context, metadataKey, userID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
@@ -91,8 +95,12 @@ func (c *Commands) RemoveUserMetadata(ctx context.Context, metadataKey, userID,
 	if !removeMetadata.State.Exists() {
 		return nil, caos_errs.ThrowNotFound(nil, "META-ncnw3", "Errors.Metadata.NotFound")
 	}
+	schema, err := userSchemaWriteModel(ctx, c.eventstore.Filter, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
 	userAgg := UserAggregateFromWriteModel(&removeMetadata.WriteModel)
-	event, err := c.removeUserMetadata(ctx, userAgg, metadataKey)
+	event, err := c.removeUserMetadata(ctx, userAgg, schema, metadataKey)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +130,10 @@ func (c *Commands) BulkRemoveUserMetadata(ctx context.Context, userID, resourceO
 	if err != nil {
 		return nil, err
 	}
+	schema, err := userSchemaWriteModel(ctx, c.eventstore.Filter, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
 	userAgg := UserAggregateFromWriteModel(&removeMetadata.WriteModel)
 	for i, key := range metadataKeys {
 		if key == "" {
@@ -130,7 +142,7 @@ func (c *Commands) BulkRemoveUserMetadata(ctx context.Context, userID, resourceO
 		if _, found := removeMetadata.metadataList[key]; !found {
 			return nil, caos_errs.ThrowNotFound(nil, "META-2nnds", "Errors.Metadata.KeyNotExisting")
 		}
-		event, err := c.removeUserMetadata(ctx, userAgg, key)
+		event, err := c.removeUserMetadata(ctx, userAgg, schema, key)
 		if err != nil {
 			return nil, err
 		}
@@ -167,13 +179,8 @@ func (c *Commands) removeUserMetadataFromOrg(ctx context.Context, resourceOwner
 	return events, nil
 }
 
-func (c *Commands) removeUserMetadata(ctx context.Context, userAgg *eventstore.Aggregate, metadataKey string) (command eventstore.Command, err error) {
-	command = user.NewMetadataRemovedEvent(
-		ctx,
-		userAgg,
-		metadataKey,
-	)
-	return command, nil
+func (c *Commands) removeUserMetadata(ctx context.Context, userAgg *eventstore.Aggregate, schema *UserSchemaWriteModel, metadataKey string) (command eventstore.Command, err error) {
+	return schema.removeMetadata(ctx, userAgg, metadataKey)
 }
 
 func (c *Commands) getUserMetadataModelByID(ctx context.Context, userID, resourceOwner, key string) (*UserMetadataWriteModel, error) {
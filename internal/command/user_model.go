@@ -17,6 +17,8 @@ type UserWriteModel struct {
 	IDPLinks  []*domain.UserIDPLink
 	UserState domain.UserState
 	UserType  domain.UserType
+	// UniqueMetadata are the values reserved by the user because of unique attributes of the user schema
+	UniqueMetadata map[string][]byte
 }
 
 func NewUserWriteModel(userID, resourceOwner string) *UserWriteModel {
@@ -86,11 +88,29 @@ func (wm *UserWriteModel) Reduce() error {
 			}
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
+			wm.UniqueMetadata = nil
+		case *user.MetadataSetEvent:
+			if e.Unique {
+				wm.reserveMetadata(e.Key, e.Value)
+			} else {
+				delete(wm.UniqueMetadata, e.Key)
+			}
+		case *user.MetadataRemovedEvent:
+			delete(wm.UniqueMetadata, e.Key)
+		case *user.MetadataRemovedAllEvent:
+			wm.UniqueMetadata = nil
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
 
+func (wm *UserWriteModel) reserveMetadata(key string, value []byte) {
+	if wm.UniqueMetadata == nil {
+		wm.UniqueMetadata = make(map[string][]byte)
+	}
+	wm.UniqueMetadata[key] = value
+}
+
 func (wm *UserWriteModel) Query() *eventstore.SearchQueryBuilder {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -113,7 +133,10 @@ func (wm *UserWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.UserRemovedType,
 			user.UserV1AddedType,
 			user.UserV1RegisteredType,
-			user.UserV1InitializedCheckSucceededType).
+			user.UserV1InitializedCheckSucceededType,
+			user.MetadataSetType,
+			user.MetadataRemovedType,
+			user.MetadataRemovedAllType).
 		Builder()
 
 	if wm.ResourceOwner != "" {
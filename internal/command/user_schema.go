@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func userSchemaWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, userID, orgID string) (*UserSchemaWriteModel, error) {
+	wm := NewUserSchemaWriteModel(ctx, userID, orgID)
+	events, err := filter(ctx, wm.Query())
+	if err != nil {
+		return nil, err
+	}
+	wm.AppendEvents(events...)
+	return wm, wm.Reduce()
+}
+
+// setMetadata validates the value if the key is an attribute of the user schema.
+// Values of unique attributes are reserved in the organisation and the previously reserved value is released.
+func (wm *UserSchemaWriteModel) setMetadata(ctx context.Context, userAgg *eventstore.Aggregate, key string, value []byte) (eventstore.Command, error) {
+	attribute := wm.policy.Attribute(key)
+	if attribute != nil {
+		if err := attribute.ValidateValue(value); err != nil {
+			return nil, err
+		}
+	}
+	unique := attribute != nil && attribute.Unique
+	releaseValue := wm.UniqueMetadata[key]
+
+	wm.Metadata[key] = value
+	delete(wm.UniqueMetadata, key)
+	if unique {
+		wm.UniqueMetadata[key] = value
+	}
+	return user.NewSchemaMetadataSetEvent(ctx, userAgg, key, value, unique, releaseValue), nil
+}
+
+// removeMetadata prevents the removal of required attributes of the user schema
+// and releases the value if it was reserved
+func (wm *UserSchemaWriteModel) removeMetadata(ctx context.Context, userAgg *eventstore.Aggregate, key string) (eventstore.Command, error) {
+	if attribute := wm.policy.Attribute(key); attribute != nil && attribute.Required {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Usc2d", "Errors.UserSchemaPolicy.AttributeRequired")
+	}
+	releaseValue := wm.UniqueMetadata[key]
+
+	delete(wm.Metadata, key)
+	delete(wm.UniqueMetadata, key)
+	return user.NewSchemaMetadataRemovedEvent(ctx, userAgg, key, releaseValue), nil
+}
+
+// checkRequired returns an error if a required attribute of the user schema has no value
+func (wm *UserSchemaWriteModel) checkRequired() error {
+	return wm.policy.CheckRequired(wm.Metadata)
+}
+
+// checkSelfRequired returns an error if a required attribute of the user schema, which is visible to the user, has no value
+func (wm *UserSchemaWriteModel) checkSelfRequired() error {
+	return wm.policy.CheckSelfRequired(wm.Metadata)
+}
@@ -0,0 +1,124 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// UserSchemaWriteModel combines the user schema policy of the organisation (or the instance)
+// with the metadata of the user, so that the values can be validated against the schema
+type UserSchemaWriteModel struct {
+	eventstore.WriteModel
+
+	orgPolicy      *OrgUserSchemaPolicyWriteModel
+	instancePolicy *InstanceUserSchemaPolicyWriteModel
+	policy         *domain.UserSchemaPolicy
+
+	Metadata map[string][]byte
+	// UniqueMetadata are the values reserved by the user because the attribute is unique
+	UniqueMetadata map[string][]byte
+}
+
+func NewUserSchemaWriteModel(ctx context.Context, userID, orgID string) *UserSchemaWriteModel {
+	return &UserSchemaWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: orgID,
+		},
+		orgPolicy:      NewOrgUserSchemaPolicyWriteModel(orgID),
+		instancePolicy: NewInstanceUserSchemaPolicyWriteModel(ctx),
+		Metadata:       make(map[string][]byte),
+		UniqueMetadata: make(map[string][]byte),
+	}
+}
+
+func (wm *UserSchemaWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch event.(type) {
+		case *org.UserSchemaPolicyAddedEvent,
+			*org.UserSchemaPolicyChangedEvent,
+			*org.UserSchemaPolicyRemovedEvent:
+			wm.orgPolicy.AppendEvents(event)
+		case *instance.UserSchemaPolicyAddedEvent,
+			*instance.UserSchemaPolicyChangedEvent:
+			wm.instancePolicy.AppendEvents(event)
+		case *user.MetadataSetEvent,
+			*user.MetadataRemovedEvent,
+			*user.MetadataRemovedAllEvent:
+			wm.WriteModel.AppendEvents(event)
+		}
+	}
+}
+
+func (wm *UserSchemaWriteModel) Reduce() error {
+	if err := wm.orgPolicy.Reduce(); err != nil {
+		return err
+	}
+	if err := wm.instancePolicy.Reduce(); err != nil {
+		return err
+	}
+	switch {
+	case wm.orgPolicy.State == domain.PolicyStateActive:
+		wm.policy = &domain.UserSchemaPolicy{Attributes: policy.UserSchemaAttributesToDomain(wm.orgPolicy.Attributes)}
+	case wm.instancePolicy.State == domain.PolicyStateActive:
+		wm.policy = &domain.UserSchemaPolicy{Attributes: policy.UserSchemaAttributesToDomain(wm.instancePolicy.Attributes)}
+	default:
+		wm.policy = nil
+	}
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.MetadataSetEvent:
+			wm.Metadata[e.Key] = e.Value
+			if e.Unique {
+				wm.UniqueMetadata[e.Key] = e.Value
+			} else {
+				delete(wm.UniqueMetadata, e.Key)
+			}
+		case *user.MetadataRemovedEvent:
+			delete(wm.Metadata, e.Key)
+			delete(wm.UniqueMetadata, e.Key)
+		case *user.MetadataRemovedAllEvent:
+			wm.Metadata = make(map[string][]byte)
+			wm.UniqueMetadata = make(map[string][]byte)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserSchemaWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.MetadataSetType,
+			user.MetadataRemovedType,
+			user.MetadataRemovedAllType).
+		Or().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.instancePolicy.AggregateID).
+		EventTypes(
+			instance.UserSchemaPolicyAddedEventType,
+			instance.UserSchemaPolicyChangedEventType)
+	if wm.ResourceOwner != "" {
+		query = query.Or().
+			AggregateTypes(org.AggregateType).
+			AggregateIDs(wm.ResourceOwner).
+			EventTypes(
+				org.UserSchemaPolicyAddedEventType,
+				org.UserSchemaPolicyChangedEventType,
+				org.UserSchemaPolicyRemovedEventType)
+	}
+	return query.Builder()
+}
+
+// Policy returns the active user schema policy, nil if neither the organisation nor the instance defines one
+func (wm *UserSchemaWriteModel) Policy() *domain.UserSchemaPolicy {
+	return wm.policy
+}
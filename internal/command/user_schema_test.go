@@ -0,0 +1,405 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_SetUserMetadataUserSchema(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx      context.Context
+			orgID    string
+			userID   string
+			metadata *domain.Metadata
+		}
+	)
+	type res struct {
+		want *domain.Metadata
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "value not matching type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(newUserSchemaTestHumanAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{
+									{Name: "employee", Type: domain.UserSchemaAttributeTypeNumber},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+				metadata: &domain.Metadata{
+					Key:   "employee",
+					Value: []byte("abc"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "value not matching pattern of default schema, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(newUserSchemaTestHumanAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								[]*policy.UserSchemaAttribute{
+									{Name: "employee", Pattern: "^[0-9]{3}$"},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+				metadata: &domain.Metadata{
+					Key:   "employee",
+					Value: []byte("1234"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unique value, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(newUserSchemaTestHumanAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{
+									{Name: "employee", Type: domain.UserSchemaAttributeTypeNumber, Unique: true},
+								},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewSchemaMetadataSetEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"employee",
+									[]byte("123"),
+									true,
+									nil,
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddSchemaAttributeUniqueConstraint("org1", "employee", []byte("123"))),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+				metadata: &domain.Metadata{
+					Key:   "employee",
+					Value: []byte("123"),
+				},
+			},
+			res: res{
+				want: &domain.Metadata{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "user1",
+						ResourceOwner: "org1",
+					},
+					Key:   "employee",
+					Value: []byte("123"),
+					State: domain.MetadataStateActive,
+				},
+			},
+		},
+		{
+			name: "change unique value, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(newUserSchemaTestHumanAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{
+									{Name: "employee", Type: domain.UserSchemaAttributeTypeNumber, Unique: true},
+								},
+							),
+						),
+						eventFromEventPusher(
+							user.NewSchemaMetadataSetEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"employee",
+								[]byte("123"),
+								true,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewSchemaMetadataSetEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"employee",
+									[]byte("456"),
+									true,
+									[]byte("123"),
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewRemoveSchemaAttributeUniqueConstraint("org1", "employee", []byte("123"))),
+						uniqueConstraintsFromEventConstraint(user.NewAddSchemaAttributeUniqueConstraint("org1", "employee", []byte("456"))),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+				metadata: &domain.Metadata{
+					Key:   "employee",
+					Value: []byte("456"),
+				},
+			},
+			res: res{
+				want: &domain.Metadata{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "user1",
+						ResourceOwner: "org1",
+					},
+					Key:   "employee",
+					Value: []byte("456"),
+					State: domain.MetadataStateActive,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetUserMetadata(tt.args.ctx, tt.args.metadata, tt.args.userID, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveUserMetadataUserSchema(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx         context.Context
+			orgID       string
+			userID      string
+			metadataKey string
+		}
+	)
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "required attribute, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(newUserSchemaTestHumanAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMetadataSetEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"employee",
+								[]byte("123"),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{
+									{Name: "employee", Required: true},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				metadataKey: "employee",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "unique attribute, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(newUserSchemaTestHumanAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMetadataSetEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"employee",
+								[]byte("123"),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewUserSchemaPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]*policy.UserSchemaAttribute{
+									{Name: "employee", Unique: true},
+								},
+							),
+						),
+						eventFromEventPusher(
+							user.NewSchemaMetadataSetEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"employee",
+								[]byte("123"),
+								true,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewSchemaMetadataRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"employee",
+									[]byte("123"),
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewRemoveSchemaAttributeUniqueConstraint("org1", "employee", []byte("123"))),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				metadataKey: "employee",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveUserMetadata(tt.args.ctx, tt.args.metadataKey, tt.args.userID, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newUserSchemaTestHumanAddedEvent() *user.HumanAddedEvent {
+	return user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		"username",
+		"firstname",
+		"lastname",
+		"",
+		"firstname lastname",
+		language.Und,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+}
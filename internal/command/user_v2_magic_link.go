@@ -0,0 +1,206 @@
+package command
+
+import (
+	"context"
+	"io"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// AddUserMagicLinkCode generates a magic link code and sends an email
+// with the default generated URL (pointing to zitadel).
+func (c *Commands) AddUserMagicLinkCode(ctx context.Context, userID, resourceOwner string, alg crypto.EncryptionAlgorithm) (*domain.ObjectDetails, error) {
+	details, err := c.addUserMagicLinkCode(ctx, userID, resourceOwner, alg, "", false, "")
+	if err != nil {
+		return nil, err
+	}
+	return details.ObjectDetails, err
+}
+
+// AddUserMagicLinkCodeURLTemplate generates a magic link code and sends an email
+// with the URL created from passed template string.
+// The template is executed as a test, before pushing to the eventstore.
+func (c *Commands) AddUserMagicLinkCodeURLTemplate(ctx context.Context, userID, resourceOwner string, alg crypto.EncryptionAlgorithm, urlTmpl string) (*domain.ObjectDetails, error) {
+	if err := domain.RenderMagicLinkURLTemplate(io.Discard, urlTmpl, userID, resourceOwner, "codeID", "code"); err != nil {
+		return nil, err
+	}
+	details, err := c.addUserMagicLinkCode(ctx, userID, resourceOwner, alg, urlTmpl, false, "")
+	if err != nil {
+		return nil, err
+	}
+	return details.ObjectDetails, err
+}
+
+// AddUserMagicLinkCodeReturn generates and returns a magic link code.
+// No email will be sent to the user.
+func (c *Commands) AddUserMagicLinkCodeReturn(ctx context.Context, userID, resourceOwner string, alg crypto.EncryptionAlgorithm) (*domain.MagicLinkCodeDetails, error) {
+	return c.addUserMagicLinkCode(ctx, userID, resourceOwner, alg, "", true, "")
+}
+
+// AddUserMagicLinkCodeForAuthRequest generates a magic link code and sends an email
+// with the default generated URL, which will continue the login of the passed auth request.
+func (c *Commands) AddUserMagicLinkCodeForAuthRequest(ctx context.Context, userID, resourceOwner, authRequestID string, alg crypto.EncryptionAlgorithm) (*domain.ObjectDetails, error) {
+	details, err := c.addUserMagicLinkCode(ctx, userID, resourceOwner, alg, "", false, authRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return details.ObjectDetails, err
+}
+
+func (c *Commands) addUserMagicLinkCode(ctx context.Context, userID, resourceOwner string, alg crypto.EncryptionAlgorithm, urlTmpl string, returnCode bool, authRequestID string) (*domain.MagicLinkCodeDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aix1e", "Errors.User.UserIDMissing")
+	}
+	email, err := c.emailWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkUserMagicLinkAllowed(email); err != nil {
+		return nil, err
+	}
+	policy, err := c.getOrgLoginPolicy(ctx, email.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.AllowEmailMagicLink {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohng4", "Errors.Org.LoginPolicy.MagicLinkNotAllowed")
+	}
+	codeID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	code, err := c.newCode(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeMagicLinkCode, alg)
+	if err != nil {
+		return nil, err
+	}
+	wm := NewUserMagicLinkCodeWriteModel(userID, codeID, email.ResourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, wm)
+	if err != nil {
+		return nil, err
+	}
+	agg := UserAggregateFromWriteModel(&wm.WriteModel)
+
+	cmd := user.NewHumanMagicLinkCodeAddedEvent(ctx, agg, codeID, code.Crypted, code.Expiry, urlTmpl, returnCode, authRequestID)
+	err = c.pushAppendAndReduce(ctx, wm, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MagicLinkCodeDetails{
+		ObjectDetails: writeModelToObjectDetails(&wm.WriteModel),
+		CodeID:        codeID,
+		Code:          code.Plain,
+	}, nil
+}
+
+// checkUserMagicLinkAllowed ensures the link is only sent to verified addresses of active users
+func checkUserMagicLinkAllowed(email *HumanEmailWriteModel) error {
+	if email.UserState == domain.UserStateUnspecified || email.UserState == domain.UserStateDeleted {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-ieZ0e", "Errors.User.NotFound")
+	}
+	if email.UserState != domain.UserStateActive {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Uu9ae", "Errors.User.NotActive")
+	}
+	if !email.IsEmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ahM8o", "Errors.User.Email.NotVerified")
+	}
+	return nil
+}
+
+// HumanMagicLinkCodeSent marks the magic link code as sent to the user.
+func (c *Commands) HumanMagicLinkCodeSent(ctx context.Context, userID, resourceOwner, codeID string) error {
+	if userID == "" || codeID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ju6ai", "Errors.IDMissing")
+	}
+	wm := NewUserMagicLinkCodeWriteModel(userID, codeID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, wm)
+	if err != nil {
+		return err
+	}
+	if wm.State != domain.MagicLinkCodeStateRequested {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Xee9o", "Errors.User.Code.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanMagicLinkCodeSentEvent(ctx, UserAggregateFromWriteModel(&wm.WriteModel), codeID),
+	)
+	return err
+}
+
+// CheckUserMagicLinkCode verifies the code of a magic link, which was opened in the login UI.
+// The auth request must be the same the link was requested for.
+// A code can only be used once.
+func (c *Commands) CheckUserMagicLinkCode(ctx context.Context, userID, resourceOwner, codeID, code string, authRequest *domain.AuthRequest, alg crypto.EncryptionAlgorithm) error {
+	if userID == "" || codeID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-aiH7e", "Errors.IDMissing")
+	}
+	email, err := c.emailWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if err = checkUserMagicLinkAllowed(email); err != nil {
+		return err
+	}
+	wm := NewUserMagicLinkCodeWriteModel(userID, codeID, email.ResourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return err
+	}
+	err = verifyUserMagicLinkCode(ctx, c.eventstore.Filter, wm, code, alg)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
+	if err == nil && authRequest != nil && wm.AuthRequestID != "" && wm.AuthRequestID != authRequest.ID {
+		err = caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ohc5a", "Errors.User.MagicLink.Invalid")
+	}
+	if err != nil {
+		_, pushErr := c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeCheckFailedEvent(ctx, userAgg, codeID, info))
+		logging.WithFields("userID", userID).OnError(pushErr).Error("magic link code check failed push failed")
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeCheckSucceededEvent(ctx, userAgg, codeID, info))
+	return err
+}
+
+// verifyUserMagicLinkCode returns an error if the code of the reduced write model is not valid (anymore).
+func verifyUserMagicLinkCode(ctx context.Context, filter preparation.FilterToQueryReducer, wm *UserMagicLinkCodeWriteModel, code string, alg crypto.EncryptionAlgorithm) error {
+	if wm.State != domain.MagicLinkCodeStateActive {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-eiT3a", "Errors.User.MagicLink.Invalid")
+	}
+	err := verifyCryptoCode(ctx, filter, domain.SecretGeneratorTypeMagicLinkCode, alg, wm.CodeCreationDate, wm.Expiry, wm.CryptoCode, code)
+	if err != nil {
+		return caos_errs.ThrowInvalidArgument(err, "COMMAND-Ang1u", "Errors.User.MagicLink.Invalid")
+	}
+	return nil
+}
+
+// CheckMagicLink defines a magic link check to be executed for a session update.
+// The code is consumed on success.
+func CheckMagicLink(codeID, code string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Re6ah", "Errors.User.UserIDMissing")
+		}
+		email := NewHumanEmailWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, email); err != nil {
+			return err
+		}
+		if err := checkUserMagicLinkAllowed(email); err != nil {
+			return err
+		}
+		wm := NewUserMagicLinkCodeWriteModel(cmd.sessionWriteModel.UserID, codeID, email.ResourceOwner)
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+			return err
+		}
+		userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
+		if err := verifyUserMagicLinkCode(ctx, cmd.eventstore.Filter, wm, code, cmd.userCodeAlg); err != nil {
+			_, pushErr := cmd.eventstore.Push(ctx, user.NewHumanMagicLinkCodeCheckFailedEvent(ctx, userAgg, codeID, nil))
+			logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("magic link code check failed push failed")
+			return err
+		}
+		cmd.sessionWriteModel.MagicLinkChecked(ctx, cmd.now(), userAgg, codeID)
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type UserMagicLinkCodeWriteModel struct {
+	eventstore.WriteModel
+
+	CodeID           string
+	CryptoCode       *crypto.CryptoValue
+	CodeCreationDate time.Time
+	Expiry           time.Duration
+	AuthRequestID    string
+	Attempts         uint8
+	State            domain.MagicLinkCodeState
+}
+
+func NewUserMagicLinkCodeWriteModel(userID, codeID, resourceOwner string) *UserMagicLinkCodeWriteModel {
+	return &UserMagicLinkCodeWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		CodeID: codeID,
+	}
+}
+
+func (wm *UserMagicLinkCodeWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanMagicLinkCodeAddedEvent:
+			if wm.CodeID == e.ID {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *user.HumanMagicLinkCodeSentEvent:
+			if wm.CodeID == e.ID {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *user.HumanMagicLinkCodeCheckFailedEvent:
+			if wm.CodeID == e.CodeID {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *user.HumanMagicLinkCodeCheckSucceededEvent:
+			if wm.CodeID == e.CodeID {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *user.UserRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *UserMagicLinkCodeWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanMagicLinkCodeAddedEvent:
+			wm.CryptoCode = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.Expiry = e.Expiry
+			wm.AuthRequestID = e.AuthRequestID
+			wm.State = domain.MagicLinkCodeStateRequested
+			if e.CodeReturned {
+				wm.State = domain.MagicLinkCodeStateActive
+			}
+		case *user.HumanMagicLinkCodeSentEvent:
+			wm.State = domain.MagicLinkCodeStateActive
+		case *user.HumanMagicLinkCodeCheckFailedEvent:
+			wm.Attempts++
+			if wm.Attempts == 3 {
+				wm.State = domain.MagicLinkCodeStateRemoved
+			}
+		case *user.HumanMagicLinkCodeCheckSucceededEvent:
+			wm.State = domain.MagicLinkCodeStateRemoved
+		case *user.UserRemovedEvent:
+			wm.State = domain.MagicLinkCodeStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserMagicLinkCodeWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanMagicLinkCodeAddedType,
+			user.HumanMagicLinkCodeSentType,
+			user.HumanMagicLinkCodeCheckFailedType,
+			user.HumanMagicLinkCodeCheckSucceededType,
+			user.UserRemovedType,
+		).
+		Builder()
+}
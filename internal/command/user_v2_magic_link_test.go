@@ -0,0 +1,283 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func magicLinkLoginPolicyAddedEvent(allowEmailMagicLink bool) *org.LoginPolicyAddedEvent {
+	return org.NewLoginPolicyAddedEvent(context.Background(),
+		&org.NewAggregate("org1").Aggregate,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		allowEmailMagicLink,
+		0,
+		0,
+		domain.PasswordlessTypeAllowed,
+		"",
+		time.Hour*1,
+		time.Hour*2,
+		time.Hour*3,
+		time.Hour*4,
+		time.Hour*5,
+	)
+}
+
+func TestCommands_addUserMagicLinkCode(t *testing.T) {
+	alg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	humanAddedEvent := user.NewHumanAddedEvent(context.Background(),
+		userAgg,
+		"username",
+		"firstname",
+		"lastname",
+		"nickname",
+		"displayname",
+		language.German,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+	type fields struct {
+		newCode     cryptoCodeFunc
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		urlTmpl       string
+		returnCode    bool
+		authRequestID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *domain.MagicLinkCodeDetails
+		wantErr error
+	}{
+		{
+			name: "missing user id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				resourceOwner: "org1",
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aix1e", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "user not found",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			wantErr: caos_errs.ThrowNotFound(nil, "COMMAND-ieZ0e", "Errors.User.NotFound"),
+		},
+		{
+			name: "email not verified",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(humanAddedEvent),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ahM8o", "Errors.User.Email.NotVerified"),
+		},
+		{
+			name: "magic link not allowed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(humanAddedEvent),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(), userAgg),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(magicLinkLoginPolicyAddedEvent(false)),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohng4", "Errors.Org.LoginPolicy.MagicLinkNotAllowed"),
+		},
+		{
+			name: "success",
+			fields: fields{
+				newCode: mockCode("magiclink1", time.Minute),
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(humanAddedEvent),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(), userAgg),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(magicLinkLoginPolicyAddedEvent(true)),
+					),
+					expectFilter(),
+					expectPush([]*repository.Event{
+						eventFromEventPusher(
+							user.NewHumanMagicLinkCodeAddedEvent(context.Background(),
+								userAgg,
+								"123", &crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("magiclink1"),
+								}, time.Minute, "", true, "authRequest1",
+							),
+						),
+					}),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "123"),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				returnCode:    true,
+				authRequestID: "authRequest1",
+			},
+			want: &domain.MagicLinkCodeDetails{
+				ObjectDetails: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				CodeID: "123",
+				Code:   "magiclink1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				newCode:     tt.fields.newCode,
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.addUserMagicLinkCode(context.Background(), tt.args.userID, tt.args.resourceOwner, alg, tt.args.urlTmpl, tt.args.returnCode, tt.args.authRequestID)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_HumanMagicLinkCodeSent(t *testing.T) {
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		codeID        string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "missing code id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ju6ai", "Errors.IDMissing"),
+		},
+		{
+			name: "code not found",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				codeID:        "123",
+			},
+			wantErr: caos_errs.ThrowNotFound(nil, "COMMAND-Xee9o", "Errors.User.Code.NotFound"),
+		},
+		{
+			name: "success",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanMagicLinkCodeAddedEvent(context.Background(),
+								userAgg,
+								"123", &crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("magiclink1"),
+								}, time.Minute, "", false, "",
+							),
+						),
+					),
+					expectPush([]*repository.Event{
+						eventFromEventPusher(
+							user.NewHumanMagicLinkCodeSentEvent(context.Background(), userAgg, "123"),
+						),
+					}),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				codeID:        "123",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.HumanMagicLinkCodeSent(context.Background(), tt.args.userID, tt.args.resourceOwner, tt.args.codeID)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
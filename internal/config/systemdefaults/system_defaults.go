@@ -12,6 +12,7 @@ type SystemDefaults struct {
 	DomainVerification DomainVerification
 	Notifications      Notifications
 	KeyConfig          KeyConfig
+	ActionStorage      ActionStorage
 }
 
 type SecretGenerators struct {
@@ -33,7 +34,8 @@ type DomainVerification struct {
 }
 
 type Notifications struct {
-	FileSystemPath string
+	FileSystemPath        string
+	DeliveryCallbackToken string
 }
 
 type KeyConfig struct {
@@ -43,3 +45,12 @@ type KeyConfig struct {
 	CertificateSize     int
 	CertificateLifetime time.Duration
 }
+
+// ActionStorage limits the secrets and the key value store of the actions per organisation
+type ActionStorage struct {
+	MaxSecrets     int
+	MaxSecretSize  int
+	MaxEntries     int
+	MaxEntrySize   int
+	MaxStorageSize int
+}
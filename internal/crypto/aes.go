@@ -30,6 +30,16 @@ func NewAESCrypto(config *KeyConfig, keyStorage KeyStorage) (*AESCrypto, error)
 	}, nil
 }
 
+// NewAESCryptoWithKey creates an AESCrypto using only the passed key,
+// which is not loaded from a key storage
+func NewAESCryptoWithKey(keyID, key string) *AESCrypto {
+	return &AESCrypto{
+		keys:            map[string]string{keyID: key},
+		encryptionKeyID: keyID,
+		keyIDs:          []string{keyID},
+	}
+}
+
 func (a *AESCrypto) Algorithm() string {
 	return "aes"
 }
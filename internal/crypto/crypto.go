@@ -81,6 +81,7 @@ func Encrypt(value []byte, alg EncryptionAlgorithm) (*CryptoValue, error) {
 }
 
 func Decrypt(value *CryptoValue, alg EncryptionAlgorithm) ([]byte, error) {
+	alg = decryptionAlgorithm(value, alg)
 	if err := checkEncryptionAlgorithm(value, alg); err != nil {
 		return nil, err
 	}
@@ -88,6 +89,7 @@ func Decrypt(value *CryptoValue, alg EncryptionAlgorithm) ([]byte, error) {
 }
 
 func DecryptString(value *CryptoValue, alg EncryptionAlgorithm) (string, error) {
+	alg = decryptionAlgorithm(value, alg)
 	if err := checkEncryptionAlgorithm(value, alg); err != nil {
 		return "", err
 	}
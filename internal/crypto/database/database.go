@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"encoding/base64"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -34,6 +35,34 @@ func NewKeyStorage(client *sql.DB, masterKey string) (*database, error) {
 	}, nil
 }
 
+// NewProviderKeyStorage returns a key storage, which wraps the keys by the key of the key provider instead of a masterkey
+func NewProviderKeyStorage(client *sql.DB, provider crypto.KeyProvider, wrappingKeyID string) (*database, error) {
+	if provider == nil || wrappingKeyID == "" {
+		return nil, caos_errs.ThrowInternal(nil, "", "key provider and wrapping key must be set")
+	}
+	return &database{
+		client: client,
+		encrypt: func(key, _ string) (string, error) {
+			encrypted, err := provider.Encrypt(wrappingKeyID, []byte(key))
+			if err != nil {
+				return "", err
+			}
+			return base64.URLEncoding.EncodeToString(encrypted), nil
+		},
+		decrypt: func(encryptedKey, _ string) (string, error) {
+			encrypted, err := base64.URLEncoding.DecodeString(encryptedKey)
+			if err != nil {
+				return "", err
+			}
+			key, err := provider.Decrypt(wrappingKeyID, encrypted)
+			if err != nil {
+				return "", err
+			}
+			return string(key), nil
+		},
+	}, nil
+}
+
 func (d *database) ReadKeys() (crypto.Keys, error) {
 	keys := make(map[string]string)
 	stmt, args, err := sq.Select(encryptionKeysIDCol, encryptionKeysKeyCol).
@@ -125,6 +154,39 @@ func (d *database) CreateKeys(keys ...*crypto.Key) error {
 	return nil
 }
 
+// UpdateKeys replaces the stored value of existing keys,
+// which is used to re-wrap the keys with another masterkey or key provider
+func (d *database) UpdateKeys(keys ...*crypto.Key) error {
+	tx, err := d.client.Begin()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to update keys")
+	}
+	for _, key := range keys {
+		encryptionKey, err := d.encrypt(key.Value, d.masterKey)
+		if err != nil {
+			tx.Rollback()
+			return caos_errs.ThrowInternal(err, "", "unable to encrypt key")
+		}
+		stmt, args, err := sq.Update(EncryptionKeysTable).
+			Set(encryptionKeysKeyCol, encryptionKey).
+			Where(sq.Eq{encryptionKeysIDCol: key.ID}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return caos_errs.ThrowInternal(err, "", "unable to update keys")
+		}
+		if _, err = tx.Exec(stmt, args...); err != nil {
+			tx.Rollback()
+			return caos_errs.ThrowInternal(err, "", "unable to update keys")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to update keys")
+	}
+	return nil
+}
+
 func checkMasterKeyLength(masterKey string) error {
 	if length := len([]byte(masterKey)); length != 32 {
 		return caos_errs.ThrowInternalf(nil, "", "masterkey must be 32 bytes, but is %d", length)
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	UserDataKeysTable         = "eventstore.user_data_keys"
+	userDataKeysInstanceIDCol = "instance_id"
+	userDataKeysUserIDCol     = "user_id"
+	userDataKeysKeyCol        = "key"
+
+	defaultUserDataKeysCacheMaxEntries = 10000
+)
+
+type UserDataKeysConfig struct {
+	// CacheMaxAge defines how long a key is cached after it was read from the database.
+	// Keys destroyed by another instance of ZITADEL are usable until the cache expired,
+	// 0 caches the keys until they are destroyed
+	CacheMaxAge time.Duration
+	// CacheMaxEntries limits the amount of cached keys,
+	// if the cache is full, expired or random entries are evicted
+	CacheMaxEntries int
+}
+
+// UserDataKeys stores a data encryption key per user,
+// the keys are wrapped by the passed encryption algorithm
+type UserDataKeys struct {
+	client          *sql.DB
+	alg             crypto.EncryptionAlgorithm
+	cacheMaxAge     time.Duration
+	cacheMaxEntries int
+
+	mutex sync.RWMutex
+	cache map[string]*cachedUserDataKey
+}
+
+// cachedUserDataKey is the key of a user,
+// alg is nil if the user has no key (anymore)
+type cachedUserDataKey struct {
+	alg      crypto.EncryptionAlgorithm
+	cachedAt time.Time
+}
+
+func NewUserDataKeys(client *sql.DB, alg crypto.EncryptionAlgorithm, config UserDataKeysConfig) *UserDataKeys {
+	maxEntries := config.CacheMaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultUserDataKeysCacheMaxEntries
+	}
+	return &UserDataKeys{
+		client:          client,
+		alg:             alg,
+		cacheMaxAge:     config.CacheMaxAge,
+		cacheMaxEntries: maxEntries,
+		cache:           make(map[string]*cachedUserDataKey),
+	}
+}
+
+// Key returns the encryption algorithm of the data encryption key of the user.
+// If create is set, a key is generated for users without a key,
+// otherwise nil is returned.
+// Missing keys are cached as well, so the events of removed users don't query the database on every read.
+func (k *UserDataKeys) Key(ctx context.Context, instanceID, userID string, create bool) (crypto.EncryptionAlgorithm, error) {
+	if cached, ok := k.cached(instanceID, userID); ok && (cached.alg != nil || !create) {
+		return cached.alg, nil
+	}
+	alg, err := k.readKey(ctx, instanceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if alg != nil || !create {
+		k.setCache(instanceID, userID, alg)
+		return alg, nil
+	}
+	if err = k.createKey(ctx, instanceID, userID); err != nil {
+		return nil, err
+	}
+	// the key is read again in case another request created a key concurrently
+	alg, err = k.readKey(ctx, instanceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	k.setCache(instanceID, userID, alg)
+	return alg, nil
+}
+
+// Destroy deletes the key of the user,
+// so that the data encrypted by it can never be decrypted again
+func (k *UserDataKeys) Destroy(ctx context.Context, instanceID, userID string) error {
+	stmt, args, err := sq.Delete(UserDataKeysTable).
+		Where(sq.Eq{
+			userDataKeysInstanceIDCol: instanceID,
+			userDataKeysUserIDCol:     userID,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Ood4e", "unable to destroy user data key")
+	}
+	if _, err = k.client.ExecContext(ctx, stmt, args...); err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Iech3", "unable to destroy user data key")
+	}
+	k.setCache(instanceID, userID, nil)
+	return nil
+}
+
+func (k *UserDataKeys) readKey(ctx context.Context, instanceID, userID string) (crypto.EncryptionAlgorithm, error) {
+	stmt, args, err := sq.Select(userDataKeysKeyCol).
+		From(UserDataKeysTable).
+		Where(sq.Eq{
+			userDataKeysInstanceIDCol: instanceID,
+			userDataKeysUserIDCol:     userID,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-Thoh6", "unable to read user data key")
+	}
+	wrappedKey := new(crypto.CryptoValue)
+	if err = k.client.QueryRowContext(ctx, stmt, args...).Scan(wrappedKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-Aiw0u", "unable to read user data key")
+	}
+	key, err := crypto.DecryptString(wrappedKey, k.alg)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-Ub4ei", "unable to decrypt user data key")
+	}
+	return crypto.NewAESCryptoWithKey(userID, key), nil
+}
+
+func (k *UserDataKeys) createKey(ctx context.Context, instanceID, userID string) error {
+	key, err := crypto.NewKey(userID)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Eeng5", "unable to generate user data key")
+	}
+	wrappedKey, err := crypto.Encrypt([]byte(key.Value), k.alg)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Xah2o", "unable to encrypt user data key")
+	}
+	stmt, args, err := sq.Insert(UserDataKeysTable).
+		Columns(userDataKeysInstanceIDCol, userDataKeysUserIDCol, userDataKeysKeyCol).
+		Values(instanceID, userID, wrappedKey).
+		Suffix("ON CONFLICT (" + userDataKeysInstanceIDCol + ", " + userDataKeysUserIDCol + ") DO NOTHING").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Zoo7k", "unable to insert user data key")
+	}
+	if _, err = k.client.ExecContext(ctx, stmt, args...); err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Quae8", "unable to insert user data key")
+	}
+	return nil
+}
+
+func (k *UserDataKeys) cached(instanceID, userID string) (*cachedUserDataKey, bool) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	cached, ok := k.cache[cacheKey(instanceID, userID)]
+	if !ok || k.isExpired(cached, time.Now()) {
+		return nil, false
+	}
+	return cached, true
+}
+
+func (k *UserDataKeys) setCache(instanceID, userID string, alg crypto.EncryptionAlgorithm) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	key := cacheKey(instanceID, userID)
+	if _, ok := k.cache[key]; !ok && len(k.cache) >= k.cacheMaxEntries {
+		k.evict()
+	}
+	k.cache[key] = &cachedUserDataKey{alg: alg, cachedAt: time.Now()}
+}
+
+// evict removes the expired entries from the cache,
+// if none expired a random entry is removed.
+// The mutex must be locked by the caller.
+func (k *UserDataKeys) evict() {
+	now := time.Now()
+	for key, cached := range k.cache {
+		if k.isExpired(cached, now) {
+			delete(k.cache, key)
+		}
+	}
+	if len(k.cache) < k.cacheMaxEntries {
+		return
+	}
+	for key := range k.cache {
+		delete(k.cache, key)
+		return
+	}
+}
+
+func (k *UserDataKeys) isExpired(cached *cachedUserDataKey, now time.Time) bool {
+	return k.cacheMaxAge > 0 && now.Sub(cached.cachedAt) > k.cacheMaxAge
+}
+
+func cacheKey(instanceID, userID string) string {
+	return instanceID + "/" + userID
+}
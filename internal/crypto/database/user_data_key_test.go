@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+const (
+	testWrappingKey = "!thewrappingkeywhichis32byteslg!"
+	testUserDataKey = "!theuserdatakeywhichis32byteslg!"
+)
+
+func wrappedUserDataKey(t *testing.T, alg crypto.EncryptionAlgorithm) []byte {
+	t.Helper()
+	wrapped, err := crypto.Encrypt([]byte(testUserDataKey), alg)
+	require.NoError(t, err)
+	value, err := json.Marshal(wrapped)
+	require.NoError(t, err)
+	return value
+}
+
+func TestUserDataKeys_Key(t *testing.T) {
+	alg := crypto.NewAESCryptoWithKey("wrapping", testWrappingKey)
+	type args struct {
+		create bool
+	}
+	type res struct {
+		found bool
+		err   bool
+	}
+	tests := []struct {
+		name   string
+		client func(t *testing.T) db
+		args   args
+		res    res
+	}{
+		{
+			name: "query fails, error",
+			client: func(t *testing.T) db {
+				return dbMock(t,
+					expectQueryErr("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", sql.ErrConnDone, "instance", "user"),
+				)
+			},
+			res: res{
+				err: true,
+			},
+		},
+		{
+			name: "not found, nil",
+			client: func(t *testing.T) db {
+				return dbMock(t,
+					expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user"),
+				)
+			},
+			res: res{
+				found: false,
+			},
+		},
+		{
+			name: "found, ok",
+			client: func(t *testing.T) db {
+				return dbMock(t,
+					expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, [][]driver.Value{{wrappedUserDataKey(t, alg)}}, "instance", "user"),
+				)
+			},
+			res: res{
+				found: true,
+			},
+		},
+		{
+			name: "not found, created",
+			client: func(t *testing.T) db {
+				return dbMock(t,
+					expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user"),
+					expectExec("INSERT INTO eventstore.user_data_keys (instance_id,user_id,key) VALUES ($1,$2,$3) ON CONFLICT (instance_id, user_id) DO NOTHING", nil, "instance", "user", sqlmock.AnyArg()),
+					expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, [][]driver.Value{{wrappedUserDataKey(t, alg)}}, "instance", "user"),
+				)
+			},
+			args: args{
+				create: true,
+			},
+			res: res{
+				found: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := tt.client(t)
+			keys := NewUserDataKeys(client.db, alg, UserDataKeysConfig{})
+			got, err := keys.Key(context.Background(), "instance", "user", tt.args.create)
+			if tt.res.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.found, got != nil)
+			if got != nil {
+				encrypted, err := got.Encrypt([]byte("value"))
+				require.NoError(t, err)
+				decrypted, err := crypto.DecryptAES(encrypted, testUserDataKey)
+				require.NoError(t, err)
+				assert.Equal(t, []byte("value"), decrypted)
+			}
+			assert.NoError(t, client.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDataKeys_Destroy(t *testing.T) {
+	alg := crypto.NewAESCryptoWithKey("wrapping", testWrappingKey)
+	client := dbMock(t,
+		expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, [][]driver.Value{{wrappedUserDataKey(t, alg)}}, "instance", "user"),
+		expectExec("DELETE FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", nil, "instance", "user"),
+	)
+	keys := NewUserDataKeys(client.db, alg, UserDataKeysConfig{})
+
+	key, err := keys.Key(context.Background(), "instance", "user", false)
+	require.NoError(t, err)
+	assert.NotNil(t, key)
+
+	require.NoError(t, keys.Destroy(context.Background(), "instance", "user"))
+
+	// the destroyed key is cached, so no query is expected
+	key, err = keys.Key(context.Background(), "instance", "user", false)
+	require.NoError(t, err)
+	assert.Nil(t, key)
+	assert.NoError(t, client.mock.ExpectationsWereMet())
+}
+
+func TestUserDataKeys_cache(t *testing.T) {
+	alg := crypto.NewAESCryptoWithKey("wrapping", testWrappingKey)
+	t.Run("missing key cached", func(t *testing.T) {
+		client := dbMock(t,
+			expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user"),
+		)
+		keys := NewUserDataKeys(client.db, alg, UserDataKeysConfig{})
+		for i := 0; i < 3; i++ {
+			key, err := keys.Key(context.Background(), "instance", "user", false)
+			require.NoError(t, err)
+			assert.Nil(t, key)
+		}
+		assert.NoError(t, client.mock.ExpectationsWereMet())
+	})
+	t.Run("missing key cached, created on create", func(t *testing.T) {
+		client := dbMock(t,
+			expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user"),
+			expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user"),
+			expectExec("INSERT INTO eventstore.user_data_keys (instance_id,user_id,key) VALUES ($1,$2,$3) ON CONFLICT (instance_id, user_id) DO NOTHING", nil, "instance", "user", sqlmock.AnyArg()),
+			expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, [][]driver.Value{{wrappedUserDataKey(t, alg)}}, "instance", "user"),
+		)
+		keys := NewUserDataKeys(client.db, alg, UserDataKeysConfig{})
+		key, err := keys.Key(context.Background(), "instance", "user", false)
+		require.NoError(t, err)
+		assert.Nil(t, key)
+		key, err = keys.Key(context.Background(), "instance", "user", true)
+		require.NoError(t, err)
+		assert.NotNil(t, key)
+		key, err = keys.Key(context.Background(), "instance", "user", false)
+		require.NoError(t, err)
+		assert.NotNil(t, key)
+		assert.NoError(t, client.mock.ExpectationsWereMet())
+	})
+	t.Run("max entries, evicted", func(t *testing.T) {
+		client := dbMock(t,
+			expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user1"),
+			expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user2"),
+			expectQuery("SELECT key FROM eventstore.user_data_keys WHERE instance_id = $1 AND user_id = $2", []string{"key"}, nil, "instance", "user3"),
+		)
+		keys := NewUserDataKeys(client.db, alg, UserDataKeysConfig{CacheMaxEntries: 2})
+		for _, userID := range []string{"user1", "user2", "user3"} {
+			_, err := keys.Key(context.Background(), "instance", userID, false)
+			require.NoError(t, err)
+		}
+		assert.Len(t, keys.cache, 2)
+		assert.NoError(t, client.mock.ExpectationsWereMet())
+	})
+}
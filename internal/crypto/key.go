@@ -11,6 +11,10 @@ import (
 type KeyConfig struct {
 	EncryptionKeyID  string
 	DecryptionKeyIDs []string
+	// ProviderKeyID references a key of the key provider (HSM / KMS).
+	// If set, values are encrypted by the key provider
+	// and the keys above are only used to decrypt values encrypted before.
+	ProviderKeyID string
 }
 
 type Keys map[string]string
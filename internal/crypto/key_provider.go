@@ -0,0 +1,217 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// KeyProvider performs cryptographic operations with keys,
+// which are held outside of the ZITADEL process (e.g. in a HSM or KMS)
+type KeyProvider interface {
+	// Algorithm identifies the provider on the encrypted values
+	Algorithm() string
+	Encrypt(keyID string, value []byte) ([]byte, error)
+	Decrypt(keyID string, value []byte) ([]byte, error)
+	// Sign signs the digest as specified by crypto.Signer,
+	// the signature of EC keys is ASN.1 encoded
+	Sign(keyID string, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+	PublicKey(keyID string) (crypto.PublicKey, error)
+}
+
+var _ EncryptionAlgorithm = (*KeyProviderCrypto)(nil)
+
+// KeyProviderCrypto encrypts values with a key of the KeyProvider.
+// Values encrypted before the key provider was configured are decrypted by the fallback.
+type KeyProviderCrypto struct {
+	provider KeyProvider
+	keyID    string
+	fallback EncryptionAlgorithm
+}
+
+// NewEncryption returns the encryption algorithm for the config.
+// If a ProviderKeyID is configured, values are encrypted by the key provider
+// and the AES keys of the key storage (if any) are only used to decrypt existing values.
+func NewEncryption(config *KeyConfig, keyStorage KeyStorage, provider KeyProvider) (EncryptionAlgorithm, error) {
+	if config == nil {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Aeb5u", "config must not be nil")
+	}
+	if config.ProviderKeyID == "" {
+		return NewAESCrypto(config, keyStorage)
+	}
+	if provider == nil {
+		return nil, errors.ThrowPreconditionFailedf(nil, "CRYPT-ooJ6a", "key %s requires a key provider", config.ProviderKeyID)
+	}
+	alg := &KeyProviderCrypto{
+		provider: provider,
+		keyID:    config.ProviderKeyID,
+	}
+	if config.EncryptionKeyID == "" && len(config.DecryptionKeyIDs) == 0 {
+		return alg, nil
+	}
+	fallback, err := NewAESCrypto(config, keyStorage)
+	if err != nil {
+		return nil, err
+	}
+	alg.fallback = fallback
+	return alg, nil
+}
+
+func (k *KeyProviderCrypto) Algorithm() string {
+	return k.provider.Algorithm()
+}
+
+func (k *KeyProviderCrypto) EncryptionKeyID() string {
+	return k.keyID
+}
+
+func (k *KeyProviderCrypto) DecryptionKeyIDs() []string {
+	return []string{k.keyID}
+}
+
+func (k *KeyProviderCrypto) Encrypt(value []byte) ([]byte, error) {
+	return k.provider.Encrypt(k.keyID, value)
+}
+
+func (k *KeyProviderCrypto) Decrypt(value []byte, keyID string) ([]byte, error) {
+	if keyID != k.keyID {
+		return nil, errors.ThrowNotFound(nil, "CRYPT-Iu0ae", "unknown key id")
+	}
+	return k.provider.Decrypt(keyID, value)
+}
+
+func (k *KeyProviderCrypto) DecryptString(value []byte, keyID string) (string, error) {
+	b, err := k.Decrypt(value, keyID)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// decryptionAlgorithm returns the fallback of a KeyProviderCrypto,
+// if the value was encrypted before the key provider was configured
+func decryptionAlgorithm(value *CryptoValue, alg EncryptionAlgorithm) EncryptionAlgorithm {
+	providerAlg, ok := alg.(*KeyProviderCrypto)
+	if !ok || providerAlg.fallback == nil || value.Algorithm != providerAlg.fallback.Algorithm() {
+		return alg
+	}
+	return providerAlg.fallback
+}
+
+var _ crypto.Signer = (*KeyProviderSigner)(nil)
+
+// KeyProviderSigner signs with a private key of the KeyProvider
+type KeyProviderSigner struct {
+	provider  KeyProvider
+	keyID     string
+	publicKey crypto.PublicKey
+	algorithm string
+}
+
+func NewKeyProviderSigner(provider KeyProvider, keyID string) (*KeyProviderSigner, error) {
+	publicKey, err := provider.PublicKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := signingAlgorithmOfPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyProviderSigner{
+		provider:  provider,
+		keyID:     keyID,
+		publicKey: publicKey,
+		algorithm: algorithm,
+	}, nil
+}
+
+func (s *KeyProviderSigner) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm returns the JWS algorithm of the key
+func (s *KeyProviderSigner) Algorithm() string {
+	return s.algorithm
+}
+
+func (s *KeyProviderSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+func (s *KeyProviderSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.provider.Sign(s.keyID, digest, opts)
+}
+
+// SigningAlgorithmHash returns the hash function used by the JWS algorithm,
+// EdDSA signs the message itself and therefore returns 0
+func SigningAlgorithmHash(algorithm string) (crypto.Hash, error) {
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmES256:
+		return crypto.SHA256, nil
+	case SigningAlgorithmRS384, SigningAlgorithmES384:
+		return crypto.SHA384, nil
+	case SigningAlgorithmRS512:
+		return crypto.SHA512, nil
+	case SigningAlgorithmEdDSA:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedSigningAlgorithm, algorithm)
+	}
+}
+
+func signingAlgorithmOfPublicKey(publicKey crypto.PublicKey) (string, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return SigningAlgorithmRS256, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return SigningAlgorithmES256, nil
+		case elliptic.P384():
+			return SigningAlgorithmES384, nil
+		}
+	case ed25519.PublicKey:
+		return SigningAlgorithmEdDSA, nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedSigningAlgorithm, publicKey)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// ECDSASignatureToASN1 converts the concatenated r and s values (as returned by PKCS #11)
+// into the ASN.1 encoding of crypto.Signer
+func ECDSASignatureToASN1(signature []byte) ([]byte, error) {
+	if len(signature) == 0 || len(signature)%2 != 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Eex0u", "invalid signature length")
+	}
+	size := len(signature) / 2
+	return asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(signature[:size]),
+		S: new(big.Int).SetBytes(signature[size:]),
+	})
+}
+
+// ECDSASignatureFromASN1 converts the ASN.1 encoded signature into the concatenated r and s values
+// of the curve size (as used by JWS)
+func ECDSASignatureFromASN1(signature []byte, curve elliptic.Curve) ([]byte, error) {
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil || len(rest) > 0 {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-ahR0e", "invalid signature")
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	out := make([]byte, 2*size)
+	sig.R.FillBytes(out[:size])
+	sig.S.FillBytes(out[size:])
+	return out, nil
+}
@@ -0,0 +1,123 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyProvider "encrypts" by reversing the value and signs with in memory keys
+type testKeyProvider struct {
+	signers map[string]crypto.Signer
+}
+
+func (p *testKeyProvider) Algorithm() string {
+	return "test"
+}
+
+func (p *testKeyProvider) Encrypt(keyID string, value []byte) ([]byte, error) {
+	return reverse(value), nil
+}
+
+func (p *testKeyProvider) Decrypt(keyID string, value []byte) ([]byte, error) {
+	return reverse(value), nil
+}
+
+func (p *testKeyProvider) Sign(keyID string, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return p.signers[keyID].Sign(rand.Reader, digest, opts)
+}
+
+func (p *testKeyProvider) PublicKey(keyID string) (crypto.PublicKey, error) {
+	signer, ok := p.signers[keyID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return signer.Public(), nil
+}
+
+func reverse(value []byte) []byte {
+	reversed := make([]byte, len(value))
+	for i, b := range value {
+		reversed[len(value)-1-i] = b
+	}
+	return reversed
+}
+
+type testKeyStorage Keys
+
+func (s testKeyStorage) ReadKeys() (Keys, error) {
+	return Keys(s), nil
+}
+
+func (s testKeyStorage) ReadKey(id string) (*Key, error) {
+	return &Key{ID: id, Value: s[id]}, nil
+}
+
+func (s testKeyStorage) CreateKeys(...*Key) error {
+	return nil
+}
+
+func TestNewEncryption(t *testing.T) {
+	storage := testKeyStorage{"aesKey": "passphrasewhichneedstobe32bytes!"}
+	aesAlg, err := NewEncryption(&KeyConfig{EncryptionKeyID: "aesKey"}, storage, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &AESCrypto{}, aesAlg)
+	legacyValue, err := Encrypt([]byte("legacy"), aesAlg)
+	require.NoError(t, err)
+
+	_, err = NewEncryption(&KeyConfig{ProviderKeyID: "providerKey"}, storage, nil)
+	assert.Error(t, err)
+
+	providerAlg, err := NewEncryption(&KeyConfig{EncryptionKeyID: "aesKey", ProviderKeyID: "providerKey"}, storage, new(testKeyProvider))
+	require.NoError(t, err)
+	value, err := Encrypt([]byte("value"), providerAlg)
+	require.NoError(t, err)
+	assert.Equal(t, "test", value.Algorithm)
+	assert.Equal(t, "providerKey", value.KeyID)
+	assert.Equal(t, []byte("eulav"), value.Crypted)
+
+	decrypted, err := DecryptString(value, providerAlg)
+	require.NoError(t, err)
+	assert.Equal(t, "value", decrypted)
+	decrypted, err = DecryptString(legacyValue, providerAlg)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", decrypted)
+
+	withoutFallback, err := NewEncryption(&KeyConfig{ProviderKeyID: "providerKey"}, storage, new(testKeyProvider))
+	require.NoError(t, err)
+	_, err = Decrypt(legacyValue, withoutFallback)
+	assert.Error(t, err)
+}
+
+func TestKeyProviderSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	provider := &testKeyProvider{signers: map[string]crypto.Signer{"signingKey": key}}
+
+	_, err = NewKeyProviderSigner(provider, "unknown")
+	assert.Error(t, err)
+
+	signer, err := NewKeyProviderSigner(provider, "signingKey")
+	require.NoError(t, err)
+	assert.Equal(t, SigningAlgorithmES384, signer.Algorithm())
+	assert.Equal(t, &key.PublicKey, signer.Public())
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+
+	raw, err := ECDSASignatureFromASN1(signature, elliptic.P384())
+	require.NoError(t, err)
+	assert.Len(t, raw, 96)
+	asn1Signature, err := ECDSASignatureToASN1(raw)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], asn1Signature))
+}
@@ -0,0 +1,19 @@
+// Package pkcs11 implements a crypto.KeyProvider for hardware security modules
+// accessible through a PKCS #11 module (e.g. SoftHSM, YubiHSM or a cloud HSM).
+//
+// The provider requires cgo and is only available if ZITADEL is built with the pkcs11 build tag.
+// The keys are referenced by their label (CKA_LABEL):
+// AES keys are used for encryption (AES-GCM), RSA and EC key pairs for signing.
+package pkcs11
+
+const (
+	Algorithm = "pkcs11"
+)
+
+type Config struct {
+	// ModulePath is the path to the PKCS #11 library, e.g. /usr/lib/softhsm/libsofthsm2.so
+	ModulePath string
+	// TokenLabel selects the slot of the token
+	TokenLabel string
+	Pin        string
+}
@@ -0,0 +1,13 @@
+//go:build !pkcs11
+
+package pkcs11
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// New returns an error, because ZITADEL was built without the pkcs11 build tag
+func New(*Config) (crypto.KeyProvider, error) {
+	return nil, errors.ThrowUnimplemented(nil, "PKCS11-ahW4u", "ZITADEL was built without PKCS #11 support (build tag pkcs11)")
+}
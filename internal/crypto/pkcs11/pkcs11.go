@@ -0,0 +1,278 @@
+//go:build pkcs11
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+
+	zcrypto "github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	gcmNonceSize = 12
+	gcmTagBits   = 128
+)
+
+var (
+	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+
+	// digestInfoPrefixes are the DER encoded DigestInfo prefixes required by CKM_RSA_PKCS
+	digestInfoPrefixes = map[crypto.Hash][]byte{
+		crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+		crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+		crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+	}
+)
+
+var _ zcrypto.KeyProvider = (*Provider)(nil)
+
+// Provider uses a single session, which is not safe for concurrent use and therefore guarded by the mutex
+type Provider struct {
+	mu         sync.Mutex
+	ctx        *pkcs11.Ctx
+	session    pkcs11.SessionHandle
+	publicKeys map[string]crypto.PublicKey
+}
+
+func New(config *Config) (zcrypto.KeyProvider, error) {
+	if config == nil || config.ModulePath == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "PKCS11-Eek7a", "module path of the pkcs11 key provider must be set")
+	}
+	ctx := pkcs11.New(config.ModulePath)
+	if ctx == nil {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PKCS11-Ua5ie", "unable to load pkcs11 module %s", config.ModulePath)
+	}
+	if err := ctx.Initialize(); err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-ooS4a", "unable to initialize pkcs11 module")
+	}
+	slot, err := findSlot(ctx, config.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-Ahx9i", "unable to open pkcs11 session")
+	}
+	if err = ctx.Login(session, pkcs11.CKU_USER, config.Pin); err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		return nil, errors.ThrowPermissionDenied(err, "PKCS11-uY2ee", "unable to login to pkcs11 token")
+	}
+	return &Provider{
+		ctx:        ctx,
+		session:    session,
+		publicKeys: make(map[string]crypto.PublicKey),
+	}, nil
+}
+
+func findSlot(ctx *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, errors.ThrowInternal(err, "PKCS11-ie4Ae", "unable to list pkcs11 slots")
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, errors.ThrowNotFoundf(nil, "PKCS11-Ohp7u", "no pkcs11 token with label %s found", tokenLabel)
+}
+
+func (p *Provider) Algorithm() string {
+	return Algorithm
+}
+
+// Encrypt encrypts the value with AES-GCM, the random nonce is prepended to the cipher text
+func (p *Provider) Encrypt(keyID string, value []byte) ([]byte, error) {
+	nonce := make([]byte, gcmNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key, err := p.findObject(pkcs11.CKO_SECRET_KEY, keyID)
+	if err != nil {
+		return nil, err
+	}
+	params := pkcs11.NewGCMParams(nonce, nil, gcmTagBits)
+	defer params.Free()
+	if err = p.ctx.EncryptInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, key); err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-Zoo7e", "unable to encrypt")
+	}
+	encrypted, err := p.ctx.Encrypt(p.session, value)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-eiK3o", "unable to encrypt")
+	}
+	return append(nonce, encrypted...), nil
+}
+
+func (p *Provider) Decrypt(keyID string, value []byte) ([]byte, error) {
+	if len(value) < gcmNonceSize {
+		return nil, errors.ThrowPreconditionFailed(nil, "PKCS11-Hoo2u", "cipher text too short")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key, err := p.findObject(pkcs11.CKO_SECRET_KEY, keyID)
+	if err != nil {
+		return nil, err
+	}
+	params := pkcs11.NewGCMParams(value[:gcmNonceSize], nil, gcmTagBits)
+	defer params.Free()
+	if err = p.ctx.DecryptInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, key); err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-Aich1", "unable to decrypt")
+	}
+	decrypted, err := p.ctx.Decrypt(p.session, value[gcmNonceSize:])
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-Ahv4o", "unable to decrypt")
+	}
+	return decrypted, nil
+}
+
+// Sign signs the digest with CKM_RSA_PKCS (PKCS #1 v1.5) or CKM_ECDSA
+func (p *Provider) Sign(keyID string, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	publicKey, err := p.PublicKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key, err := p.findObject(pkcs11.CKO_PRIVATE_KEY, keyID)
+	if err != nil {
+		return nil, err
+	}
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			return nil, errors.ThrowUnimplemented(nil, "PKCS11-Nie5a", "RSA PSS is not supported")
+		}
+		prefix, ok := digestInfoPrefixes[opts.HashFunc()]
+		if !ok {
+			return nil, errors.ThrowInvalidArgumentf(nil, "PKCS11-eiP8a", "unsupported hash function %s", opts.HashFunc())
+		}
+		return p.sign(pkcs11.CKM_RSA_PKCS, key, append(append([]byte{}, prefix...), digest...))
+	case *ecdsa.PublicKey:
+		signature, err := p.sign(pkcs11.CKM_ECDSA, key, digest)
+		if err != nil {
+			return nil, err
+		}
+		return zcrypto.ECDSASignatureToASN1(signature)
+	default:
+		return nil, errors.ThrowUnimplementedf(nil, "PKCS11-Xoo3e", "unsupported key type %T", publicKey)
+	}
+}
+
+func (p *Provider) sign(mechanism uint, key pkcs11.ObjectHandle, data []byte) ([]byte, error) {
+	if err := p.ctx.SignInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(mechanism, nil)}, key); err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-Ye0ie", "unable to sign")
+	}
+	signature, err := p.ctx.Sign(p.session, data)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-aeN7o", "unable to sign")
+	}
+	return signature, nil
+}
+
+// PublicKey reads the public key object with the label of the key pair
+func (p *Provider) PublicKey(keyID string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if publicKey, ok := p.publicKeys[keyID]; ok {
+		return publicKey, nil
+	}
+	key, err := p.findObject(pkcs11.CKO_PUBLIC_KEY, keyID)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := p.rsaPublicKey(key)
+	if err != nil {
+		publicKey, err = p.ecPublicKey(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.publicKeys[keyID] = publicKey
+	return publicKey, nil
+}
+
+func (p *Provider) rsaPublicKey(key pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	attributes, err := p.ctx.GetAttributeValue(p.session, key, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil || len(attributes) != 2 {
+		return nil, errors.ThrowInternal(err, "PKCS11-Eiv5a", "unable to read rsa public key")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(attributes[0].Value),
+		E: int(new(big.Int).SetBytes(attributes[1].Value).Int64()),
+	}, nil
+}
+
+func (p *Provider) ecPublicKey(key pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	attributes, err := p.ctx.GetAttributeValue(p.session, key, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil || len(attributes) != 2 {
+		return nil, errors.ThrowInternal(err, "PKCS11-Ahm2o", "unable to read ec public key")
+	}
+	var curveOID asn1.ObjectIdentifier
+	if _, err = asn1.Unmarshal(attributes[0].Value, &curveOID); err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-Pha3u", "invalid ec params")
+	}
+	var curve elliptic.Curve
+	switch {
+	case curveOID.Equal(oidNamedCurveP256):
+		curve = elliptic.P256()
+	case curveOID.Equal(oidNamedCurveP384):
+		curve = elliptic.P384()
+	default:
+		return nil, errors.ThrowUnimplementedf(nil, "PKCS11-Ke4ei", "unsupported curve %s", curveOID)
+	}
+	// the point is encoded as DER octet string
+	var point []byte
+	if _, err = asn1.Unmarshal(attributes[1].Value, &point); err != nil {
+		return nil, errors.ThrowInternal(err, "PKCS11-ief2O", "invalid ec point")
+	}
+	x, y := elliptic.Unmarshal(curve, point)
+	if x == nil {
+		return nil, errors.ThrowInternal(nil, "PKCS11-Dae8u", "invalid ec point")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func (p *Provider) findObject(class uint, label string) (_ pkcs11.ObjectHandle, err error) {
+	err = p.ctx.FindObjectsInit(p.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	})
+	if err != nil {
+		return 0, errors.ThrowInternal(err, "PKCS11-Chee6", "unable to search key")
+	}
+	defer func() {
+		if finalErr := p.ctx.FindObjectsFinal(p.session); finalErr != nil && err == nil {
+			err = errors.ThrowInternal(finalErr, "PKCS11-ta4Ie", "unable to search key")
+		}
+	}()
+	objects, _, err := p.ctx.FindObjects(p.session, 1)
+	if err != nil {
+		return 0, errors.ThrowInternal(err, "PKCS11-Ohl2e", "unable to search key")
+	}
+	if len(objects) == 0 {
+		return 0, errors.ThrowNotFoundf(nil, "PKCS11-ooW3e", "key %s not found", label)
+	}
+	return objects[0], nil
+}
@@ -0,0 +1,91 @@
+//go:build pkcs11
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"os"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProvider connects to the token configured by the environment, e.g. for SoftHSM:
+//
+//	softhsm2-util --init-token --free --label zitadel --pin 1234 --so-pin 1234
+//	PKCS11_MODULE_PATH=/usr/lib/softhsm/libsofthsm2.so PKCS11_TOKEN_LABEL=zitadel PKCS11_PIN=1234 go test -tags pkcs11 ./internal/crypto/pkcs11/
+func testProvider(t *testing.T) *Provider {
+	modulePath := os.Getenv("PKCS11_MODULE_PATH")
+	if modulePath == "" {
+		t.Skip("PKCS11_MODULE_PATH not set")
+	}
+	provider, err := New(&Config{
+		ModulePath: modulePath,
+		TokenLabel: os.Getenv("PKCS11_TOKEN_LABEL"),
+		Pin:        os.Getenv("PKCS11_PIN"),
+	})
+	require.NoError(t, err)
+	return provider.(*Provider)
+}
+
+func TestProvider_Encryption(t *testing.T) {
+	p := testProvider(t)
+	_, err := p.ctx.GenerateKey(p.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, "aes"),
+			pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, 32),
+			pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+			pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+		},
+	)
+	require.NoError(t, err)
+
+	encrypted, err := p.Encrypt("aes", []byte("secret"))
+	require.NoError(t, err)
+	decrypted, err := p.Decrypt("aes", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), decrypted)
+
+	_, err = p.Encrypt("unknown", []byte("secret"))
+	assert.Error(t, err)
+}
+
+func TestProvider_SignEC(t *testing.T) {
+	p := testProvider(t)
+	ecParams, err := asn1.Marshal(oidNamedCurveP256)
+	require.NoError(t, err)
+	_, _, err = p.ctx.GenerateKeyPair(p.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, "ec"),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, ecParams),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, "ec"),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		},
+	)
+	require.NoError(t, err)
+
+	publicKey, err := p.PublicKey("ec")
+	require.NoError(t, err)
+	ecKey, ok := publicKey.(*ecdsa.PublicKey)
+	require.True(t, ok)
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := p.Sign("ec", digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(ecKey, digest[:], signature))
+}
@@ -0,0 +1,35 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*SHA256)(nil)
+
+// SHA256 is an unsalted and fast hash algorithm.
+// It must only be used for random values with a high entropy (e.g. generated codes), never for passwords.
+type SHA256 struct{}
+
+func NewSHA256() *SHA256 {
+	return &SHA256{}
+}
+
+func (s *SHA256) Algorithm() string {
+	return "sha256"
+}
+
+func (s *SHA256) Hash(value []byte) ([]byte, error) {
+	hash := sha256.Sum256(value)
+	return hash[:], nil
+}
+
+func (s *SHA256) CompareHash(hashed, value []byte) error {
+	hash := sha256.Sum256(value)
+	if subtle.ConstantTimeCompare(hashed, hash[:]) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ma3ts", "hash does not match")
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmRS384 = "RS384"
+	SigningAlgorithmRS512 = "RS512"
+	SigningAlgorithmES256 = "ES256"
+	SigningAlgorithmES384 = "ES384"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+var ErrUnsupportedSigningAlgorithm = errors.New("unsupported signing algorithm")
+
+// IsSupportedSigningAlgorithm reports whether signing keys can be generated for the JWS algorithm
+func IsSupportedSigningAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case SigningAlgorithmRS256,
+		SigningAlgorithmRS384,
+		SigningAlgorithmRS512,
+		SigningAlgorithmES256,
+		SigningAlgorithmES384,
+		SigningAlgorithmEdDSA:
+		return true
+	default:
+		return false
+	}
+}
+
+// GenerateSigningKeyPair generates a key pair for the JWS algorithm.
+// The bits are only used for RSA keys, the curve of EC keys is defined by the algorithm.
+func GenerateSigningKeyPair(algorithm string, bits int) (crypto.Signer, crypto.PublicKey, error) {
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmRS384, SigningAlgorithmRS512:
+		return GenerateKeyPair(bits)
+	case SigningAlgorithmES256:
+		return generateECKeyPair(elliptic.P256())
+	case SigningAlgorithmES384:
+		return generateECKeyPair(elliptic.P384())
+	case SigningAlgorithmEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, publicKey, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningAlgorithm, algorithm)
+	}
+}
+
+func generateECKeyPair(curve elliptic.Curve) (crypto.Signer, crypto.PublicKey, error) {
+	privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, &privateKey.PublicKey, nil
+}
+
+func GenerateEncryptedSigningKeyPair(algorithm string, bits int, alg EncryptionAlgorithm) (*CryptoValue, *CryptoValue, error) {
+	privateKey, publicKey, err := GenerateSigningKeyPair(algorithm, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateBytes, err := SigningPrivateKeyToBytes(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicBytes, err := SigningPublicKeyToBytes(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPrivateKey, err := Encrypt(privateBytes, alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPublicKey, err := Encrypt(publicBytes, alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encryptedPrivateKey, encryptedPublicKey, nil
+}
+
+// SigningPrivateKeyToBytes encodes RSA keys as PKCS #1 (as done by PrivateKeyToBytes)
+// and all other keys as PKCS #8
+func SigningPrivateKeyToBytes(privateKey crypto.Signer) ([]byte, error) {
+	if rsaKey, ok := privateKey.(*rsa.PrivateKey); ok {
+		return PrivateKeyToBytes(rsaKey), nil
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	}), nil
+}
+
+func SigningPublicKeyToBytes(publicKey crypto.PublicKey) ([]byte, error) {
+	if rsaKey, ok := publicKey.(*rsa.PublicKey); ok {
+		return PublicKeyToBytes(rsaKey)
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: der,
+	}), nil
+}
+
+// BytesToSigningPrivateKey decodes a PKCS #1 RSA or a PKCS #8 (RSA, EC or Ed25519) private key
+func BytesToSigningPrivateKey(priv []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(priv)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		return BytesToPrivateKey(priv)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedSigningAlgorithm, key)
+	}
+	return signer, nil
+}
+
+// BytesToSigningPublicKey decodes a PKIX public key of any supported type
+func BytesToSigningPublicKey(pub []byte) (crypto.PublicKey, error) {
+	if pub == nil {
+		return nil, ErrEmpty
+	}
+	block, _ := pem.Decode(pub)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateSigningKeyPair(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		check     func(t *testing.T, privateKey crypto.Signer)
+		wantErr   error
+	}{
+		{
+			name:      "RS256",
+			algorithm: SigningAlgorithmRS256,
+			check: func(t *testing.T, privateKey crypto.Signer) {
+				if _, ok := privateKey.(*rsa.PrivateKey); !ok {
+					t.Errorf("expected rsa key got %T", privateKey)
+				}
+			},
+		},
+		{
+			name:      "ES256",
+			algorithm: SigningAlgorithmES256,
+			check: func(t *testing.T, privateKey crypto.Signer) {
+				key, ok := privateKey.(*ecdsa.PrivateKey)
+				if !ok || key.Curve != elliptic.P256() {
+					t.Errorf("expected P-256 key got %T", privateKey)
+				}
+			},
+		},
+		{
+			name:      "ES384",
+			algorithm: SigningAlgorithmES384,
+			check: func(t *testing.T, privateKey crypto.Signer) {
+				key, ok := privateKey.(*ecdsa.PrivateKey)
+				if !ok || key.Curve != elliptic.P384() {
+					t.Errorf("expected P-384 key got %T", privateKey)
+				}
+			},
+		},
+		{
+			name:      "EdDSA",
+			algorithm: SigningAlgorithmEdDSA,
+			check: func(t *testing.T, privateKey crypto.Signer) {
+				if _, ok := privateKey.(ed25519.PrivateKey); !ok {
+					t.Errorf("expected ed25519 key got %T", privateKey)
+				}
+			},
+		},
+		{
+			name:      "unsupported",
+			algorithm: "HS256",
+			wantErr:   ErrUnsupportedSigningAlgorithm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKey, publicKey, err := GenerateSigningKeyPair(tt.algorithm, 1024)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateSigningKeyPair() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			tt.check(t, privateKey)
+
+			privateBytes, err := SigningPrivateKeyToBytes(privateKey)
+			if err != nil {
+				t.Fatalf("SigningPrivateKeyToBytes() error = %v", err)
+			}
+			decodedPrivate, err := BytesToSigningPrivateKey(privateBytes)
+			if err != nil {
+				t.Fatalf("BytesToSigningPrivateKey() error = %v", err)
+			}
+			if !reflect.DeepEqual(decodedPrivate, privateKey) {
+				t.Errorf("decoded private key differs from generated key")
+			}
+
+			publicBytes, err := SigningPublicKeyToBytes(publicKey)
+			if err != nil {
+				t.Fatalf("SigningPublicKeyToBytes() error = %v", err)
+			}
+			decodedPublic, err := BytesToSigningPublicKey(publicBytes)
+			if err != nil {
+				t.Fatalf("BytesToSigningPublicKey() error = %v", err)
+			}
+			if !reflect.DeepEqual(decodedPublic, publicKey) {
+				t.Errorf("decoded public key differs from generated key")
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Package transit implements a crypto.KeyProvider for remote key management services
+// with a HashiCorp Vault Transit compatible API (e.g. Vault or OpenBao).
+package transit
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	zcrypto "github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	Algorithm = "transit"
+
+	tokenHeader      = "X-Vault-Token"
+	namespaceHeader  = "X-Vault-Namespace"
+	defaultMountPath = "transit"
+	defaultTimeout   = 10 * time.Second
+)
+
+type Config struct {
+	// Address of the server, e.g. https://vault.example.com:8200
+	Address string
+	Token   string
+	// Namespace is optional and only sent if set
+	Namespace string
+	// MountPath of the transit secrets engine, defaults to "transit"
+	MountPath string
+	Timeout   time.Duration
+}
+
+var _ zcrypto.KeyProvider = (*Provider)(nil)
+
+type Provider struct {
+	client    *http.Client
+	address   string
+	token     string
+	namespace string
+	mountPath string
+}
+
+func New(config *Config) (*Provider, error) {
+	if config == nil || config.Address == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "TRANS-Ohw3a", "address of the transit key provider must be set")
+	}
+	if _, err := url.Parse(config.Address); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "TRANS-Vo2ai", "invalid address of the transit key provider")
+	}
+	mountPath := config.MountPath
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	return &Provider{
+		client:    &http.Client{Timeout: timeout},
+		address:   config.Address,
+		token:     config.Token,
+		namespace: config.Namespace,
+		mountPath: mountPath,
+	}, nil
+}
+
+func (p *Provider) Algorithm() string {
+	return Algorithm
+}
+
+type encryptRequest struct {
+	Plaintext string `json:"plaintext"`
+}
+
+type encryptResponse struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+func (p *Provider) Encrypt(keyID string, value []byte) ([]byte, error) {
+	resp := new(encryptResponse)
+	err := p.call(http.MethodPost, "encrypt/"+url.PathEscape(keyID), &encryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(value),
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(resp.Ciphertext), nil
+}
+
+type decryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type decryptResponse struct {
+	Plaintext string `json:"plaintext"`
+}
+
+func (p *Provider) Decrypt(keyID string, value []byte) ([]byte, error) {
+	resp := new(decryptResponse)
+	err := p.call(http.MethodPost, "decrypt/"+url.PathEscape(keyID), &decryptRequest{
+		Ciphertext: string(value),
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
+
+type signRequest struct {
+	Input               string `json:"input"`
+	Prehashed           bool   `json:"prehashed,omitempty"`
+	SignatureAlgorithm  string `json:"signature_algorithm,omitempty"`
+	MarshalingAlgorithm string `json:"marshaling_algorithm,omitempty"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+func (p *Provider) Sign(keyID string, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	req := &signRequest{
+		Input: base64.StdEncoding.EncodeToString(digest),
+	}
+	path := "sign/" + url.PathEscape(keyID)
+	if hash := opts.HashFunc(); hash != 0 {
+		hashAlgorithm, err := hashAlgorithm(hash)
+		if err != nil {
+			return nil, err
+		}
+		path += "/" + hashAlgorithm
+		req.Prehashed = true
+		req.SignatureAlgorithm = "pkcs1v15"
+		req.MarshalingAlgorithm = "asn1"
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			req.SignatureAlgorithm = "pss"
+		}
+	}
+	resp := new(signResponse)
+	if err := p.call(http.MethodPost, path, req, resp); err != nil {
+		return nil, err
+	}
+	return decodeVersioned(resp.Signature)
+}
+
+type keyResponse struct {
+	LatestVersion int `json:"latest_version"`
+	Keys          map[string]struct {
+		PublicKey string `json:"public_key"`
+	} `json:"keys"`
+	Type string `json:"type"`
+}
+
+func (p *Provider) PublicKey(keyID string) (crypto.PublicKey, error) {
+	resp := new(keyResponse)
+	if err := p.call(http.MethodGet, "keys/"+url.PathEscape(keyID), nil, resp); err != nil {
+		return nil, err
+	}
+	key, ok := resp.Keys[strconv.Itoa(resp.LatestVersion)]
+	if !ok || key.PublicKey == "" {
+		return nil, errors.ThrowNotFoundf(nil, "TRANS-ieG0u", "no public key found for key %s", keyID)
+	}
+	if resp.Type == "ed25519" {
+		// the public key of ed25519 keys is returned base64 encoded instead of PEM
+		publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "TRANS-eeR5i", "invalid public key")
+		}
+		return ed25519.PublicKey(publicKey), nil
+	}
+	publicKey, err := zcrypto.BytesToSigningPublicKey([]byte(key.PublicKey))
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "TRANS-uPh8a", "invalid public key")
+	}
+	switch publicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return publicKey, nil
+	}
+	return nil, errors.ThrowInternalf(nil, "TRANS-Yie4o", "unsupported key type %T", publicKey)
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors"`
+}
+
+func (p *Provider) call(method, path string, body, data interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return errors.ThrowInternal(err, "TRANS-Ieb6i", "unable to marshal request")
+		}
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, p.address+"/v1/"+p.mountPath+"/"+path, &reqBody)
+	if err != nil {
+		return errors.ThrowInternal(err, "TRANS-Ahz6p", "unable to create request")
+	}
+	req.Header.Set(tokenHeader, p.token)
+	req.Header.Set("Content-Type", "application/json")
+	if p.namespace != "" {
+		req.Header.Set(namespaceHeader, p.namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.ThrowUnavailable(err, "TRANS-shee2", "key provider not reachable")
+	}
+	defer resp.Body.Close()
+	r := new(response)
+	if err = json.NewDecoder(resp.Body).Decode(r); err != nil {
+		return errors.ThrowInternal(err, "TRANS-Ooz5e", "unable to unmarshal response")
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.ThrowInternalf(nil, "TRANS-ohX1a", "key provider returned %d: %v", resp.StatusCode, r.Errors)
+	}
+	if err = json.Unmarshal(r.Data, data); err != nil {
+		return errors.ThrowInternal(err, "TRANS-aiT9e", "unable to unmarshal response")
+	}
+	return nil
+}
+
+// decodeVersioned decodes a value of the format vault:v<version>:<base64 value>
+func decodeVersioned(value string) ([]byte, error) {
+	var version int
+	var encoded string
+	if _, err := fmt.Sscanf(value, "vault:v%d:%s", &version, &encoded); err != nil {
+		return nil, errors.ThrowInternal(err, "TRANS-ahH2i", "invalid value returned by key provider")
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+func hashAlgorithm(hash crypto.Hash) (string, error) {
+	switch hash {
+	case crypto.SHA256:
+		return "sha2-256", nil
+	case crypto.SHA384:
+		return "sha2-384", nil
+	case crypto.SHA512:
+		return "sha2-512", nil
+	default:
+		return "", errors.ThrowInvalidArgumentf(nil, "TRANS-Eiy3o", "unsupported hash function %s", hash)
+	}
+}
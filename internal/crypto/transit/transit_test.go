@@ -0,0 +1,124 @@
+package transit
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "token"
+
+// testServer implements the parts of the transit API used by the provider,
+// the "encryption" only prefixes the plaintext
+func testServer(t *testing.T, signer crypto.Signer) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(tokenHeader) != testToken {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(&response{Errors: []string{"permission denied"}})
+			return
+		}
+		var data interface{}
+		switch {
+		case r.URL.Path == "/v1/transit/encrypt/key":
+			req := new(encryptRequest)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			data = &encryptResponse{Ciphertext: "vault:v1:" + req.Plaintext}
+		case r.URL.Path == "/v1/transit/decrypt/key":
+			req := new(decryptRequest)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			data = &decryptResponse{Plaintext: strings.TrimPrefix(req.Ciphertext, "vault:v1:")}
+		case r.URL.Path == "/v1/transit/sign/key/sha2-256":
+			req := new(signRequest)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			digest, err := base64.StdEncoding.DecodeString(req.Input)
+			require.NoError(t, err)
+			signature, err := signer.Sign(rand.Reader, digest, crypto.SHA256)
+			require.NoError(t, err)
+			data = &signResponse{Signature: "vault:v1:" + base64.StdEncoding.EncodeToString(signature)}
+		case r.URL.Path == "/v1/transit/keys/key":
+			der, err := x509.MarshalPKIXPublicKey(signer.Public())
+			require.NoError(t, err)
+			data = map[string]interface{}{
+				"latest_version": 2,
+				"type":           "ecdsa-p256",
+				"keys": map[string]interface{}{
+					"2": map[string]string{
+						"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+					},
+				},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&response{Errors: []string{"not found"}})
+			return
+		}
+		raw, err := json.Marshal(data)
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(&response{Data: raw})
+	}))
+}
+
+func TestProvider(t *testing.T) {
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	server := testServer(t, signer)
+	defer server.Close()
+
+	provider, err := New(&Config{Address: server.URL, Token: testToken})
+	require.NoError(t, err)
+
+	encrypted, err := provider.Encrypt("key", []byte("secret"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(encrypted), "vault:v1:"))
+	decrypted, err := provider.Decrypt("key", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), decrypted)
+
+	publicKey, err := provider.PublicKey("key")
+	require.NoError(t, err)
+	assert.Equal(t, &signer.PublicKey, publicKey)
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := provider.Sign("key", digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&signer.PublicKey, digest[:], signature))
+
+	_, err = provider.Sign("key", digest[:], crypto.SHA1)
+	assert.Error(t, err)
+	_, err = provider.Encrypt("unknown", []byte("secret"))
+	assert.Error(t, err)
+}
+
+func TestProvider_Unauthorized(t *testing.T) {
+	signer, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	server := testServer(t, signer)
+	defer server.Close()
+
+	provider, err := New(&Config{Address: server.URL, Token: "wrong"})
+	require.NoError(t, err)
+	_, err = provider.Encrypt("key", []byte("secret"))
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&Config{})
+	assert.Error(t, err)
+	provider, err := New(&Config{Address: "http://localhost:8200"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultMountPath, provider.mountPath)
+}
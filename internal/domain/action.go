@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"bytes"
+	"encoding/base64"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -9,15 +11,44 @@ import (
 type Action struct {
 	models.ObjectRoot
 
-	Name          string
+	Name string
+	// Script contains the base64 encoded module if the runtime is [ActionRuntimeWASM]
 	Script        string
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         ActionState
+	Runtime       ActionRuntime
 }
 
 func (a *Action) IsValid() bool {
-	return a.Name != ""
+	if a.Name == "" || !a.Runtime.Valid() {
+		return false
+	}
+	if a.Runtime == ActionRuntimeWASM {
+		return IsWASMModule(a.Script)
+	}
+	return a.Script != ""
+}
+
+// wasmMagic is the beginning of every binary WebAssembly module
+var wasmMagic = []byte{0x00, 'a', 's', 'm'}
+
+// IsWASMModule checks if the script is a base64 encoded binary WebAssembly module
+func IsWASMModule(script string) bool {
+	module, err := base64.StdEncoding.DecodeString(script)
+	return err == nil && bytes.HasPrefix(module, wasmMagic)
+}
+
+type ActionRuntime int32
+
+const (
+	ActionRuntimeJavaScript ActionRuntime = iota
+	ActionRuntimeWASM
+	actionRuntimeCount
+)
+
+func (r ActionRuntime) Valid() bool {
+	return r >= 0 && r < actionRuntimeCount
 }
 
 type ActionState int32
@@ -25,27 +25,29 @@ const (
 type OIDCApp struct {
 	models.ObjectRoot
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []OIDCResponseType
-	GrantTypes               []OIDCGrantType
-	ApplicationType          OIDCApplicationType
-	AuthMethodType           OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              OIDCVersion
-	Compliance               *Compliance
-	DevMode                  bool
-	AccessTokenType          OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
+	AppID                     string
+	AppName                   string
+	ClientID                  string
+	ClientSecret              *crypto.CryptoValue
+	ClientSecretString        string
+	RedirectUris              []string
+	ResponseTypes             []OIDCResponseType
+	GrantTypes                []OIDCGrantType
+	ApplicationType           OIDCApplicationType
+	AuthMethodType            OIDCAuthMethodType
+	PostLogoutRedirectUris    []string
+	OIDCVersion               OIDCVersion
+	Compliance                *Compliance
+	DevMode                   bool
+	AccessTokenType           OIDCTokenType
+	AccessTokenRoleAssertion  bool
+	IDTokenRoleAssertion      bool
+	IDTokenUserinfoAssertion  bool
+	ClockSkew                 time.Duration
+	AdditionalOrigins         []string
+	SkipNativeAppSuccessPage  bool
+	RefreshTokenRotation      OIDCRefreshTokenRotation
+	RefreshTokenReuseInterval time.Duration
 
 	State AppState
 }
@@ -110,6 +112,22 @@ const (
 	OIDCAuthMethodTypePrivateKeyJWT
 )
 
+// OIDCRefreshTokenRotation defines if a new refresh token is issued every time a refresh token is used
+type OIDCRefreshTokenRotation int32
+
+const (
+	OIDCRefreshTokenRotationEnabled OIDCRefreshTokenRotation = iota
+	OIDCRefreshTokenRotationDisabled
+)
+
+// RefreshTokenRotationPolicy defines how the refresh tokens of an application are renewed.
+// The ReuseInterval is the period in which the previous refresh token of a family is still accepted
+// (e.g. for concurrent requests), before its reuse is treated as replay and the whole family is revoked.
+type RefreshTokenRotationPolicy struct {
+	Rotation      OIDCRefreshTokenRotation
+	ReuseInterval time.Duration
+}
+
 type Compliance struct {
 	NoneCompliant bool
 	Problems      []string
@@ -123,7 +141,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || a.RefreshTokenReuseInterval < 0 {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
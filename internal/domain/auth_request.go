@@ -45,6 +45,7 @@ type AuthRequest struct {
 	PossibleSteps            []NextStep `json:"-"`
 	PasswordVerified         bool
 	MFAsVerified             []MFAType
+	RiskAssessment           *RiskAssessment
 	Audience                 []string
 	AuthTime                 time.Time
 	Code                     string
@@ -105,6 +106,7 @@ const (
 	MFATypeOTP MFAType = iota
 	MFATypeU2F
 	MFATypeU2FUserVerification
+	MFATypeRecoveryCode
 )
 
 type MFALevel int
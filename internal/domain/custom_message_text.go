@@ -14,6 +14,17 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	MagicLinkMessageType                = "MagicLink"
+	RecoveryCodeUsedMessageType         = "RecoveryCodeUsed"
+	NewDeviceLoginMessageType           = "NewDeviceLogin"
+	MFAAddedMessageType                 = "MFAAdded"
+	MFARemovedMessageType               = "MFARemoved"
+	EmailChangedMessageType             = "EmailChanged"
+	PhoneChangedMessageType             = "PhoneChanged"
+	AccountLockedMessageType            = "AccountLocked"
+	PersonalAccessTokenAddedMessageType = "PersonalAccessTokenAdded"
+	UserDeactivationWarningMessageType  = "UserDeactivationWarning"
+	UserDeletionWarningMessageType      = "UserDeletionWarning"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -31,6 +42,17 @@ type MessageTexts struct {
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
 	PasswordChange           CustomMessageText
+	MagicLink                CustomMessageText
+	RecoveryCodeUsed         CustomMessageText
+	NewDeviceLogin           CustomMessageText
+	MFAAdded                 CustomMessageText
+	MFARemoved               CustomMessageText
+	EmailChanged             CustomMessageText
+	PhoneChanged             CustomMessageText
+	AccountLocked            CustomMessageText
+	PersonalAccessTokenAdded CustomMessageText
+	UserDeactivationWarning  CustomMessageText
+	UserDeletionWarning      CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -69,6 +91,28 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *CustomMessageText {
 		return &m.PasswordlessRegistration
 	case PasswordChangeMessageType:
 		return &m.PasswordChange
+	case MagicLinkMessageType:
+		return &m.MagicLink
+	case RecoveryCodeUsedMessageType:
+		return &m.RecoveryCodeUsed
+	case NewDeviceLoginMessageType:
+		return &m.NewDeviceLogin
+	case MFAAddedMessageType:
+		return &m.MFAAdded
+	case MFARemovedMessageType:
+		return &m.MFARemoved
+	case EmailChangedMessageType:
+		return &m.EmailChanged
+	case PhoneChangedMessageType:
+		return &m.PhoneChanged
+	case AccountLockedMessageType:
+		return &m.AccountLocked
+	case PersonalAccessTokenAddedMessageType:
+		return &m.PersonalAccessTokenAdded
+	case UserDeactivationWarningMessageType:
+		return &m.UserDeactivationWarning
+	case UserDeletionWarningMessageType:
+		return &m.UserDeletionWarning
 	}
 	return nil
 }
@@ -80,5 +124,16 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyPhoneMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == MagicLinkMessageType ||
+		textType == RecoveryCodeUsedMessageType ||
+		textType == NewDeviceLoginMessageType ||
+		textType == MFAAddedMessageType ||
+		textType == MFARemovedMessageType ||
+		textType == EmailChangedMessageType ||
+		textType == PhoneChangedMessageType ||
+		textType == AccountLockedMessageType ||
+		textType == PersonalAccessTokenAddedMessageType ||
+		textType == UserDeactivationWarningMessageType ||
+		textType == UserDeletionWarningMessageType
 }
@@ -0,0 +1,31 @@
+package domain
+
+type EmailHTTPConfigState int32
+
+const (
+	EmailHTTPConfigStateUnspecified EmailHTTPConfigState = iota
+	EmailHTTPConfigStateActive
+	EmailHTTPConfigStateInactive
+	EmailHTTPConfigStateRemoved
+)
+
+func (s EmailHTTPConfigState) Exists() bool {
+	return s != EmailHTTPConfigStateUnspecified && s != EmailHTTPConfigStateRemoved
+}
+
+// EmailHTTPPreset defines the defaults of the request to a transactional email API
+type EmailHTTPPreset int32
+
+const (
+	// EmailHTTPPresetCustom sends the request exactly as configured
+	EmailHTTPPresetCustom EmailHTTPPreset = iota
+	EmailHTTPPresetSendGrid
+	EmailHTTPPresetMailgun
+	// EmailHTTPPresetSES sends the body of the SES v2 SendEmail API,
+	// the request is not signed, so it's meant for SES compatible APIs authenticating with an api key
+	EmailHTTPPresetSES
+)
+
+func (p EmailHTTPPreset) Valid() bool {
+	return p >= EmailHTTPPresetCustom && p <= EmailHTTPPresetSES
+}
@@ -21,6 +21,9 @@ const (
 	FlowTypeExternalAuthentication
 	FlowTypeCustomiseToken
 	FlowTypeInternalAuthentication
+	FlowTypeCustomizeSAMLResponse
+	FlowTypeUserManagement
+	FlowTypeNotification
 	flowTypeCount
 )
 
@@ -55,6 +58,23 @@ func (s FlowType) TriggerTypes() []TriggerType {
 			TriggerTypePostAuthentication,
 			TriggerTypePreCreation,
 			TriggerTypePostCreation,
+			TriggerTypePostRiskEvaluation,
+		}
+	case FlowTypeCustomizeSAMLResponse:
+		return []TriggerType{
+			TriggerTypePreSAMLResponseCreation,
+		}
+	case FlowTypeUserManagement:
+		return []TriggerType{
+			TriggerTypePreCreation,
+			TriggerTypePostCreation,
+			TriggerTypePostChange,
+			TriggerTypePostDeactivation,
+			TriggerTypePostPasswordChange,
+		}
+	case FlowTypeNotification:
+		return []TriggerType{
+			TriggerTypePreNotificationSend,
 		}
 	default:
 		return nil
@@ -80,6 +100,12 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.CustomiseToken"
 	case FlowTypeInternalAuthentication:
 		return "Action.Flow.Type.InternalAuthentication"
+	case FlowTypeCustomizeSAMLResponse:
+		return "Action.Flow.Type.CustomizeSAMLResponse"
+	case FlowTypeUserManagement:
+		return "Action.Flow.Type.UserManagement"
+	case FlowTypeNotification:
+		return "Action.Flow.Type.Notification"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -94,6 +120,12 @@ const (
 	TriggerTypePostCreation
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
+	TriggerTypePreSAMLResponseCreation
+	TriggerTypePostChange
+	TriggerTypePostDeactivation
+	TriggerTypePostPasswordChange
+	TriggerTypePreNotificationSend
+	TriggerTypePostRiskEvaluation
 	triggerTypeCount
 )
 
@@ -124,6 +156,18 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreUserinfoCreation"
 	case TriggerTypePreAccessTokenCreation:
 		return "Action.TriggerType.PreAccessTokenCreation"
+	case TriggerTypePreSAMLResponseCreation:
+		return "Action.TriggerType.PreSAMLResponseCreation"
+	case TriggerTypePostChange:
+		return "Action.TriggerType.PostChange"
+	case TriggerTypePostDeactivation:
+		return "Action.TriggerType.PostDeactivation"
+	case TriggerTypePostPasswordChange:
+		return "Action.TriggerType.PostPasswordChange"
+	case TriggerTypePreNotificationSend:
+		return "Action.TriggerType.PreNotificationSend"
+	case TriggerTypePostRiskEvaluation:
+		return "Action.TriggerType.PostRiskEvaluation"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"encoding/base32"
+	"strings"
+
 	"github.com/pquerna/otp"
 	"github.com/pquerna/otp/totp"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -36,6 +39,16 @@ func NewOTPKey(issuer, accountName string, cryptoAlg crypto.EncryptionAlgorithm)
 	return key, encryptedSecret, nil
 }
 
+// EncryptOTPSecret encrypts an existing secret (e.g. of an imported user),
+// which must be base32 encoded like the secrets generated by NewOTPKey
+func EncryptOTPSecret(secret string, cryptoAlg crypto.EncryptionAlgorithm) (*crypto.CryptoValue, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.TrimSpace(secret), "="))
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret); err != nil || secret == "" {
+		return nil, caos_errs.ThrowInvalidArgument(err, "DOMAIN-Otp3s", "Errors.User.MFA.OTP.SecretInvalid")
+	}
+	return crypto.Encrypt([]byte(secret), cryptoAlg)
+}
+
 func VerifyMFAOTP(code string, secret *crypto.CryptoValue, cryptoAlg crypto.EncryptionAlgorithm) error {
 	decrypt, err := crypto.DecryptString(secret, cryptoAlg)
 	if err != nil {
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestEncryptOTPSecret(t *testing.T) {
+	type res struct {
+		secret string
+		err    func(error) bool
+	}
+	tests := []struct {
+		name   string
+		secret string
+		res    res
+	}{
+		{
+			name:   "empty, invalid argument error",
+			secret: "",
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:   "not base32, invalid argument error",
+			secret: "not-base32!",
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:   "base32, ok",
+			secret: "JBSWY3DPEHPK3PXP",
+			res: res{
+				secret: "JBSWY3DPEHPK3PXP",
+			},
+		},
+		{
+			name:   "lower case with padding, normalized",
+			secret: " jbswy3dpehpk3pxpjbswy3dpeh====== ",
+			res: res{
+				secret: "JBSWY3DPEHPK3PXPJBSWY3DPEH",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+			got, err := EncryptOTPSecret(tt.secret, alg)
+			if tt.res.err != nil {
+				if !tt.res.err(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			secret, err := crypto.DecryptString(got, alg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.res.secret, secret)
+		})
+	}
+}
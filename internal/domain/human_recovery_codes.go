@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// RecoveryCodesCount is the number of codes generated per set
+	RecoveryCodesCount = 10
+	recoveryCodeLength = 10
+)
+
+var (
+	// lower case letters and digits without the easily confused 0, 1, i, l and o
+	recoveryCodeChars = []rune("abcdefghjkmnpqrstuvwxyz23456789")
+	recoveryCodeAlg   = crypto.NewSHA256()
+)
+
+type RecoveryCodes struct {
+	*ObjectDetails
+
+	Codes []string
+}
+
+// GenerateRecoveryCodes returns the plain codes (formatted as xxxxx-xxxxx) and their hashes
+func GenerateRecoveryCodes(count int) (plain []string, hashed []*crypto.CryptoValue, err error) {
+	plain = make([]string, count)
+	hashed = make([]*crypto.CryptoValue, count)
+	for i := 0; i < count; i++ {
+		code, err := crypto.GenerateRandomString(recoveryCodeLength, recoveryCodeChars)
+		if err != nil {
+			return nil, nil, err
+		}
+		plain[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hashed[i], err = crypto.Hash([]byte(code), recoveryCodeAlg)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return plain, hashed, nil
+}
+
+// VerifyRecoveryCode returns the index of the unused code matching the passed one.
+// Separators, whitespace and case of the passed code are ignored.
+func VerifyRecoveryCode(code string, hashed []*crypto.CryptoValue, used []bool) (int, error) {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+	if len(normalized) == recoveryCodeLength {
+		for i, hash := range hashed {
+			if i < len(used) && used[i] {
+				continue
+			}
+			if crypto.CompareHash(hash, []byte(normalized), recoveryCodeAlg) == nil {
+				return i, nil
+			}
+		}
+	}
+	return -1, caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Rc7qa", "Errors.User.MFA.RecoveryCodes.InvalidCode")
+}
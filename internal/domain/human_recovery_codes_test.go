@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	plain, hashed, err := GenerateRecoveryCodes(RecoveryCodesCount)
+	require.NoError(t, err)
+	require.Len(t, plain, RecoveryCodesCount)
+	require.Len(t, hashed, RecoveryCodesCount)
+	for i, code := range plain {
+		assert.Len(t, code, recoveryCodeLength+1)
+		assert.Equal(t, "-", code[recoveryCodeLength/2:recoveryCodeLength/2+1])
+		index, err := VerifyRecoveryCode(code, hashed, nil)
+		require.NoError(t, err)
+		assert.Equal(t, i, index)
+	}
+}
+
+func TestVerifyRecoveryCode(t *testing.T) {
+	plain, hashed, err := GenerateRecoveryCodes(3)
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		code      string
+		used      []bool
+		wantIndex int
+		wantErr   func(error) bool
+	}{
+		{
+			name:      "valid",
+			code:      plain[1],
+			wantIndex: 1,
+		},
+		{
+			name:      "without separator and upper case",
+			code:      " " + strings.ToUpper(strings.ReplaceAll(plain[2], "-", "")) + " ",
+			wantIndex: 2,
+		},
+		{
+			name:      "used",
+			code:      plain[1],
+			used:      []bool{false, true, false},
+			wantIndex: -1,
+			wantErr:   caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:      "invalid",
+			code:      "aaaaa-aaaaa",
+			wantIndex: -1,
+			wantErr:   caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:      "wrong length",
+			code:      plain[0] + "a",
+			wantIndex: -1,
+			wantErr:   caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := VerifyRecoveryCode(tt.code, hashed, tt.used)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantIndex, index)
+		})
+	}
+}
@@ -111,3 +111,23 @@ func (s IDPIntentState) Valid() bool {
 func (s IDPIntentState) Exists() bool {
 	return s != IDPIntentStateUnspecified && s != IDPIntentStateFailed //TODO: ?
 }
+
+// AutoLinkingOption defines which attribute of an external user is used to search for an existing user,
+// if the external user is not linked to any user yet.
+// Usernames are not verified by the identity providers and therefore never used.
+type AutoLinkingOption uint8
+
+const (
+	AutoLinkingOptionUnspecified AutoLinkingOption = iota
+	AutoLinkingOptionEmail
+)
+
+// AutoLinkingMode defines if the user is asked to confirm the linking to an existing user found by the [AutoLinkingOption]
+// or if the existing user is selected directly.
+// In both cases, the external user is only linked after the user authenticated with the existing credentials.
+type AutoLinkingMode uint8
+
+const (
+	AutoLinkingModePrompt AutoLinkingMode = iota
+	AutoLinkingModeAutomatic
+)
@@ -55,3 +55,71 @@ func (k *KeyPair) IsValid() bool {
 func (k *Key) IsValid() bool {
 	return k.Key != nil
 }
+
+type KeyPairState int32
+
+const (
+	KeyPairStateUnspecified KeyPairState = iota
+	KeyPairStateActive
+	KeyPairStateRevoked
+)
+
+// DefaultKeyPrePublicationPeriod is used if the key rotation policy of the instance does not define a pre-publication period
+const DefaultKeyPrePublicationPeriod = 10 * time.Minute
+
+// KeyRotationPolicy defines how the signing keys and SAML certificates of an instance are rotated.
+// Unset (zero) values are taken from the system defaults.
+type KeyRotationPolicy struct {
+	// SigningKeyAlgorithm is the JWS algorithm of newly generated OIDC signing keys (RS256, ES256, ES384, EdDSA, ...)
+	SigningKeyAlgorithm string
+	// SigningKeyLifetime is the duration a signing key is used for signing (after pre-publication)
+	SigningKeyLifetime time.Duration
+	// CertificateLifetime is the duration a SAML certificate is used for signing (after pre-publication)
+	CertificateLifetime time.Duration
+	// PrePublicationPeriod is the duration a new key is published (e.g. in the JWKS) before it is used for signing
+	PrePublicationPeriod time.Duration
+	// RetirementGracePeriod is the duration a key is still published after it was retired,
+	// so that tokens and assertions signed with it can still be verified
+	RetirementGracePeriod time.Duration
+}
+
+// WithDefaults returns a copy of the policy, where every unset value is taken from the defaults
+func (p *KeyRotationPolicy) WithDefaults(defaults *KeyRotationPolicy) *KeyRotationPolicy {
+	policy := *defaults
+	if p == nil {
+		return &policy
+	}
+	if p.SigningKeyAlgorithm != "" {
+		policy.SigningKeyAlgorithm = p.SigningKeyAlgorithm
+	}
+	if p.SigningKeyLifetime > 0 {
+		policy.SigningKeyLifetime = p.SigningKeyLifetime
+	}
+	if p.CertificateLifetime > 0 {
+		policy.CertificateLifetime = p.CertificateLifetime
+	}
+	if p.PrePublicationPeriod > 0 {
+		policy.PrePublicationPeriod = p.PrePublicationPeriod
+	}
+	if p.RetirementGracePeriod > 0 {
+		policy.RetirementGracePeriod = p.RetirementGracePeriod
+	}
+	return &policy
+}
+
+// SigningKeyExpiration returns the expiration of the private and the public key of a signing key generated at the provided time
+func (p *KeyRotationPolicy) SigningKeyExpiration(now time.Time) (privateKeyExpiration, publicKeyExpiration time.Time) {
+	privateKeyExpiration = now.Add(p.PrePublicationPeriod + p.SigningKeyLifetime)
+	return privateKeyExpiration, privateKeyExpiration.Add(p.RetirementGracePeriod)
+}
+
+// CertificateExpiration returns the expiration of the private key and the certificate of a SAML certificate generated at the provided time
+func (p *KeyRotationPolicy) CertificateExpiration(now time.Time) (privateKeyExpiration, certificateExpiration time.Time) {
+	privateKeyExpiration = now.Add(p.PrePublicationPeriod + p.CertificateLifetime)
+	return privateKeyExpiration, privateKeyExpiration.Add(p.RetirementGracePeriod)
+}
+
+// IsPublished reports whether a key created at the provided time was published long enough to be used for signing
+func (p *KeyRotationPolicy) IsPublished(creationDate, now time.Time) bool {
+	return !creationDate.Add(p.PrePublicationPeriod).After(now)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type NotificationDeliveryStatus int32
+
+const (
+	NotificationDeliveryStatusUnspecified NotificationDeliveryStatus = iota
+	// NotificationDeliveryStatusSent means the provider accepted the message
+	NotificationDeliveryStatusSent
+	// NotificationDeliveryStatusFailed means the message could not be sent or the provider reported a failure
+	NotificationDeliveryStatusFailed
+	// NotificationDeliveryStatusDelivered means the provider reported the message as delivered
+	NotificationDeliveryStatusDelivered
+	// NotificationDeliveryStatusBounced means the provider reported the message as not deliverable to the recipient
+	NotificationDeliveryStatusBounced
+	// NotificationDeliveryStatusResent means the notification was sent again in a new delivery
+	NotificationDeliveryStatusResent
+
+	notificationDeliveryStatusCount
+)
+
+func (s NotificationDeliveryStatus) Valid() bool {
+	return s > NotificationDeliveryStatusUnspecified && s < notificationDeliveryStatusCount
+}
+
+func (s NotificationDeliveryStatus) Exists() bool {
+	return s != NotificationDeliveryStatusUnspecified
+}
+
+// IsReported returns true for the states providers are able to report on their delivery status callbacks
+func (s NotificationDeliveryStatus) IsReported() bool {
+	return s == NotificationDeliveryStatusDelivered ||
+		s == NotificationDeliveryStatusBounced ||
+		s == NotificationDeliveryStatusFailed
+}
+
+// NotificationDelivery is the record of a notification handed to a provider
+type NotificationDelivery struct {
+	models.ObjectRoot
+
+	UserID            string
+	Channel           NotificationType
+	Recipient         string
+	MessageType       string
+	ProviderMessageID string
+	Error             string
+
+	// the user event which caused the notification
+	TriggeringAggregateID string
+	TriggeringSequence    uint64
+	TriggeringEventType   string
+}
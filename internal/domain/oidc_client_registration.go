@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+)
+
+// OIDCRegistrationTokenType distinguishes the two token kinds used for
+// Dynamic Client Registration (RFC 7591) and its management protocol (RFC 7592).
+type OIDCRegistrationTokenType string
+
+const (
+	// OIDCRegistrationTokenTypeInitialAccess is issued per project and allows
+	// the registration of new clients on the registration endpoint.
+	OIDCRegistrationTokenTypeInitialAccess OIDCRegistrationTokenType = "iat"
+	// OIDCRegistrationTokenTypeRegistrationAccess is issued per registered client
+	// and allows reading, updating and deleting the client on the client configuration endpoint.
+	OIDCRegistrationTokenTypeRegistrationAccess OIDCRegistrationTokenType = "rat"
+)
+
+type OIDCInitialAccessTokenState int32
+
+const (
+	OIDCInitialAccessTokenStateUnspecified OIDCInitialAccessTokenState = iota
+	OIDCInitialAccessTokenStateActive
+	OIDCInitialAccessTokenStateRemoved
+
+	oidcInitialAccessTokenStateCount
+)
+
+func (s OIDCInitialAccessTokenState) Valid() bool {
+	return s >= 0 && s < oidcInitialAccessTokenStateCount
+}
+
+// OIDCClientRegistrationPolicy restricts the clients which can be registered
+// with an initial access token.
+// Empty lists do not restrict the corresponding field.
+type OIDCClientRegistrationPolicy struct {
+	AllowedRedirectURIPrefixes []string
+	AllowedGrantTypes          []OIDCGrantType
+	AllowedAuthMethodTypes     []OIDCAuthMethodType
+}
+
+// Check returns an error if the app does not satisfy the restrictions of the policy.
+func (p *OIDCClientRegistrationPolicy) Check(app *OIDCApp) error {
+	if p == nil {
+		return nil
+	}
+	if !p.redirectURIsAllowed(app.RedirectUris) || !p.redirectURIsAllowed(app.PostLogoutRedirectUris) {
+		return caos_errors.ThrowPermissionDenied(nil, "DOMAIN-Ohj2e", "Errors.Project.App.Registration.RedirectURINotAllowed")
+	}
+	if len(p.AllowedGrantTypes) > 0 {
+		for _, grantType := range app.GrantTypes {
+			if !containsOIDCGrantType(p.AllowedGrantTypes, grantType) {
+				return caos_errors.ThrowPermissionDenied(nil, "DOMAIN-Quo4a", "Errors.Project.App.Registration.GrantTypeNotAllowed")
+			}
+		}
+	}
+	if len(p.AllowedAuthMethodTypes) > 0 && !containsOIDCAuthMethodType(p.AllowedAuthMethodTypes, app.AuthMethodType) {
+		return caos_errors.ThrowPermissionDenied(nil, "DOMAIN-Eeph3", "Errors.Project.App.Registration.AuthMethodNotAllowed")
+	}
+	return nil
+}
+
+func (p *OIDCClientRegistrationPolicy) redirectURIsAllowed(uris []string) bool {
+	if len(p.AllowedRedirectURIPrefixes) == 0 {
+		return true
+	}
+uris:
+	for _, uri := range uris {
+		for _, prefix := range p.AllowedRedirectURIPrefixes {
+			if redirectURIMatchesPrefix(uri, prefix) {
+				continue uris
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// redirectURIMatchesPrefix compares scheme and host (including the port) of the uri and the prefix exactly
+// and requires the path of the uri to be the path of the prefix or below it.
+// URIs with user information are never allowed, as they are commonly used to disguise the host.
+func redirectURIMatchesPrefix(uri, prefix string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.User != nil {
+		return false
+	}
+	allowed, err := url.Parse(prefix)
+	if err != nil {
+		return false
+	}
+	if parsed.Opaque != "" || allowed.Opaque != "" {
+		return uri == prefix
+	}
+	if !strings.EqualFold(parsed.Scheme, allowed.Scheme) || !strings.EqualFold(parsed.Host, allowed.Host) {
+		return false
+	}
+	allowedPath := strings.TrimSuffix(allowed.Path, "/")
+	return allowedPath == "" || parsed.Path == allowedPath || strings.HasPrefix(parsed.Path, allowedPath+"/")
+}
+
+func containsOIDCAuthMethodType(authMethods []OIDCAuthMethodType, authMethod OIDCAuthMethodType) bool {
+	for _, a := range authMethods {
+		if a == authMethod {
+			return true
+		}
+	}
+	return false
+}
+
+const oidcRegistrationTokenSecretLength = 32
+
+var oidcRegistrationTokenSecretAlg = crypto.NewSHA256()
+
+// NewOIDCRegistrationToken creates an opaque token of the given type,
+// referencing the token (tokenID) on the project (projectID).
+// The token contains a random secret, only the returned hash of it must be stored
+// to verify the token with [VerifyOIDCRegistrationTokenSecret].
+func NewOIDCRegistrationToken(tokenType OIDCRegistrationTokenType, projectID, tokenID string, algorithm crypto.EncryptionAlgorithm) (token string, secretHash *crypto.CryptoValue, err error) {
+	secret := make([]byte, oidcRegistrationTokenSecretLength)
+	if _, err = rand.Read(secret); err != nil {
+		return "", nil, caos_errors.ThrowInternal(err, "DOMAIN-Thae5", "unable to generate token secret")
+	}
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+	secretHash, err = crypto.Hash([]byte(encodedSecret), oidcRegistrationTokenSecretAlg)
+	if err != nil {
+		return "", nil, err
+	}
+	encrypted, err := algorithm.Encrypt([]byte(string(tokenType) + ":" + projectID + ":" + tokenID + ":" + encodedSecret))
+	if err != nil {
+		return "", nil, err
+	}
+	return base64.RawURLEncoding.EncodeToString(encrypted), secretHash, nil
+}
+
+// FromOIDCRegistrationToken decrypts a token created by [NewOIDCRegistrationToken]
+// and returns the project and token id and the secret, if the token is of the expected type.
+// The secret must be verified with [VerifyOIDCRegistrationTokenSecret] against the stored hash.
+func FromOIDCRegistrationToken(token string, tokenType OIDCRegistrationTokenType, algorithm crypto.EncryptionAlgorithm) (projectID, tokenID, secret string, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", "", caos_errors.ThrowUnauthenticated(err, "DOMAIN-ahX0o", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	decrypted, err := algorithm.Decrypt(decoded, algorithm.EncryptionKeyID())
+	if err != nil {
+		return "", "", "", caos_errors.ThrowUnauthenticated(err, "DOMAIN-Uu9ae", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	split := strings.Split(string(decrypted), ":")
+	if len(split) != 4 || split[0] != string(tokenType) || split[3] == "" {
+		return "", "", "", caos_errors.ThrowUnauthenticated(nil, "DOMAIN-oo7Ie", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	return split[1], split[2], split[3], nil
+}
+
+// VerifyOIDCRegistrationTokenSecret compares the secret of a token with the stored hash in constant time.
+func VerifyOIDCRegistrationTokenSecret(secret string, secretHash *crypto.CryptoValue) error {
+	if secretHash == nil || crypto.CompareHash(secretHash, []byte(secret), oidcRegistrationTokenSecretAlg) != nil {
+		return caos_errors.ThrowUnauthenticated(nil, "DOMAIN-Eey3u", "Errors.Project.App.Registration.TokenInvalid")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestOIDCClientRegistrationPolicy_redirectURIsAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		uris     []string
+		want     bool
+	}{
+		{
+			name: "no prefixes, allowed",
+			uris: []string{"https://evil.net/cb"},
+			want: true,
+		},
+		{
+			name:     "same host and path below prefix, allowed",
+			prefixes: []string{"https://partner.example.com/app"},
+			uris:     []string{"https://partner.example.com/app/cb", "https://partner.example.com/app"},
+			want:     true,
+		},
+		{
+			name:     "host prefix only, allowed",
+			prefixes: []string{"https://partner.example.com"},
+			uris:     []string{"https://partner.example.com/cb"},
+			want:     true,
+		},
+		{
+			name:     "other host with same prefix, not allowed",
+			prefixes: []string{"https://partner.example.com"},
+			uris:     []string{"https://partner.example.com.evil.net/cb"},
+			want:     false,
+		},
+		{
+			name:     "user info, not allowed",
+			prefixes: []string{"https://partner.example.com"},
+			uris:     []string{"https://partner.example.com@evil.net/cb"},
+			want:     false,
+		},
+		{
+			name:     "other port, not allowed",
+			prefixes: []string{"https://partner.example.com"},
+			uris:     []string{"https://partner.example.com:8443/cb"},
+			want:     false,
+		},
+		{
+			name:     "other scheme, not allowed",
+			prefixes: []string{"https://partner.example.com"},
+			uris:     []string{"http://partner.example.com/cb"},
+			want:     false,
+		},
+		{
+			name:     "path not on segment boundary, not allowed",
+			prefixes: []string{"https://partner.example.com/app"},
+			uris:     []string{"https://partner.example.com/application/cb"},
+			want:     false,
+		},
+		{
+			name:     "one of multiple uris not allowed",
+			prefixes: []string{"https://partner.example.com/"},
+			uris:     []string{"https://partner.example.com/cb", "https://evil.net/cb"},
+			want:     false,
+		},
+		{
+			name:     "native app scheme, allowed",
+			prefixes: []string{"com.example.app:/"},
+			uris:     []string{"com.example.app:/callback"},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &OIDCClientRegistrationPolicy{AllowedRedirectURIPrefixes: tt.prefixes}
+			assert.Equal(t, tt.want, p.redirectURIsAllowed(tt.uris))
+		})
+	}
+}
+
+func TestOIDCRegistrationToken(t *testing.T) {
+	alg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+	token, secretHash, err := NewOIDCRegistrationToken(OIDCRegistrationTokenTypeRegistrationAccess, "project1", "token1", alg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	otherToken, otherSecretHash, err := NewOIDCRegistrationToken(OIDCRegistrationTokenTypeRegistrationAccess, "project1", "token1", alg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEqual(t, token, otherToken)
+
+	_, _, _, err = FromOIDCRegistrationToken(token, OIDCRegistrationTokenTypeInitialAccess, alg)
+	assert.True(t, caos_errs.IsUnauthenticated(err))
+
+	projectID, tokenID, secret, err := FromOIDCRegistrationToken(token, OIDCRegistrationTokenTypeRegistrationAccess, alg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "project1", projectID)
+	assert.Equal(t, "token1", tokenID)
+	assert.NoError(t, VerifyOIDCRegistrationTokenSecret(secret, secretHash))
+	assert.True(t, caos_errs.IsUnauthenticated(VerifyOIDCRegistrationTokenSecret(secret, otherSecretHash)))
+	assert.True(t, caos_errs.IsUnauthenticated(VerifyOIDCRegistrationTokenSecret(secret, nil)))
+}
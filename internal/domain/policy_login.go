@@ -30,6 +30,9 @@ type LoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowEmailMagicLink        bool
+	RiskStepUpThreshold        uint32
+	RiskBlockThreshold         uint32
 }
 
 func ValidateDefaultRedirectURI(rawURL string) bool {
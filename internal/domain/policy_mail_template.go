@@ -1,6 +1,11 @@
 package domain
 
-import "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+import (
+	html_template "html/template"
+	text_template "text/template"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
 
 type MailTemplate struct {
 	models.ObjectRoot
@@ -13,3 +18,35 @@ type MailTemplate struct {
 func (m *MailTemplate) IsValid() bool {
 	return m.Template != nil
 }
+
+// MailMessageTemplate overwrites the template of the mail template policy for a specific message type.
+// Subject is an optional template for the subject, which can use the (localized) message texts
+// e.g. `{{.Subject}} - ACME`
+type MailMessageTemplate struct {
+	models.ObjectRoot
+
+	MessageType string
+	Subject     string
+	HTML        []byte
+	Text        []byte
+}
+
+// IsValid checks that at least one of the templates is set and all of them can be parsed
+func (m *MailMessageTemplate) IsValid() bool {
+	if !IsMailMessageType(m.MessageType) || (len(m.HTML) == 0 && len(m.Text) == 0) {
+		return false
+	}
+	if _, err := text_template.New("subject").Parse(m.Subject); err != nil {
+		return false
+	}
+	if _, err := html_template.New("html").Parse(string(m.HTML)); err != nil {
+		return false
+	}
+	_, err := text_template.New("text").Parse(string(m.Text))
+	return err == nil
+}
+
+// IsMailMessageType checks if the message type is sent as mail
+func IsMailMessageType(messageType string) bool {
+	return messageType != VerifyPhoneMessageType && IsMessageTextType(messageType)
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const day = 24 * time.Hour
+
+// UserLifecyclePolicy defines after how many days of inactivity users are deactivated
+// and how long deactivated users are retained before they are deleted.
+// A value of 0 days disables the corresponding step.
+type UserLifecyclePolicy struct {
+	models.ObjectRoot
+
+	DeactivateAfterDays uint64
+	DeleteAfterDays     uint64
+	WarnDaysBefore      uint64
+	ExcludeMachineUsers bool
+	ExcludedUserIDs     []string
+	DryRun              bool
+}
+
+func (p *UserLifecyclePolicy) IsValid() error {
+	if p.DeactivateAfterDays > 0 && p.WarnDaysBefore >= p.DeactivateAfterDays {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-Ulc3w", "Errors.UserLifecyclePolicy.WarnDaysInvalid")
+	}
+	for _, userID := range p.ExcludedUserIDs {
+		if userID == "" {
+			return errors.ThrowInvalidArgument(nil, "DOMAIN-Ulc7e", "Errors.UserLifecyclePolicy.ExcludedUserIDInvalid")
+		}
+	}
+	return nil
+}
+
+type UserLifecycleAction int32
+
+const (
+	UserLifecycleActionNone UserLifecycleAction = iota
+	UserLifecycleActionWarnDeactivation
+	UserLifecycleActionDeactivate
+	UserLifecycleActionWarnDeletion
+	UserLifecycleActionDelete
+)
+
+func (a UserLifecycleAction) IsWarning() bool {
+	return a == UserLifecycleActionWarnDeactivation || a == UserLifecycleActionWarnDeletion
+}
+
+// UserActivity is the state of a user relevant for the lifecycle
+type UserActivity struct {
+	UserID string
+	Type   UserType
+	State  UserState
+	// LastActivity is the date of the last login or the creation of the user
+	LastActivity time.Time
+	// DeactivationDate is the date the user was deactivated
+	DeactivationDate time.Time
+	// WarningDate is the date the last lifecycle warning was sent to the user
+	WarningDate time.Time
+}
+
+func (p *UserLifecyclePolicy) IsExcluded(userID string, userType UserType) bool {
+	if p.ExcludeMachineUsers && userType == UserTypeMachine {
+		return true
+	}
+	for _, excluded := range p.ExcludedUserIDs {
+		if excluded == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAction returns the action which is due for the user at the given time and the date the step is (or was) due.
+// If warnings are enabled, a user is always warned at least WarnDaysBefore days before being deactivated or deleted,
+// which might delay the step if the policy was only just enabled.
+func (p *UserLifecyclePolicy) NextAction(user *UserActivity, now time.Time) (UserLifecycleAction, time.Time) {
+	if p.IsExcluded(user.UserID, user.Type) {
+		return UserLifecycleActionNone, time.Time{}
+	}
+	switch user.State {
+	case UserStateActive:
+		if p.DeactivateAfterDays == 0 {
+			return UserLifecycleActionNone, time.Time{}
+		}
+		return p.nextStep(user.LastActivity, user.WarningDate, p.DeactivateAfterDays, now,
+			UserLifecycleActionWarnDeactivation, UserLifecycleActionDeactivate)
+	case UserStateInactive:
+		if p.DeleteAfterDays == 0 || user.DeactivationDate.IsZero() {
+			return UserLifecycleActionNone, time.Time{}
+		}
+		return p.nextStep(user.DeactivationDate, user.WarningDate, p.DeleteAfterDays, now,
+			UserLifecycleActionWarnDeletion, UserLifecycleActionDelete)
+	default:
+		return UserLifecycleActionNone, time.Time{}
+	}
+}
+
+func (p *UserLifecyclePolicy) nextStep(since, warned time.Time, days uint64, now time.Time, warn, act UserLifecycleAction) (UserLifecycleAction, time.Time) {
+	due := since.Add(time.Duration(days) * day)
+	if p.WarnDaysBefore == 0 {
+		if now.Before(due) {
+			return UserLifecycleActionNone, due
+		}
+		return act, due
+	}
+	warnPeriod := time.Duration(p.WarnDaysBefore) * day
+	// a warning only counts if it was sent after the period started, e.g. not before the last login
+	if warned.IsZero() || warned.Before(since) {
+		if now.Before(due.Add(-warnPeriod)) {
+			return UserLifecycleActionNone, due
+		}
+		if earliest := now.Add(warnPeriod); due.Before(earliest) {
+			due = earliest
+		}
+		return warn, due
+	}
+	if earliest := warned.Add(warnPeriod); due.Before(earliest) {
+		due = earliest
+	}
+	if now.Before(due) {
+		return UserLifecycleActionNone, due
+	}
+	return act, due
+}
@@ -0,0 +1,378 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserLifecyclePolicy_NextAction(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * day)
+	}
+	type args struct {
+		policy *UserLifecyclePolicy
+		user   *UserActivity
+	}
+	type want struct {
+		action UserLifecycleAction
+		due    time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"deactivation disabled, none",
+			args{
+				policy: &UserLifecyclePolicy{},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(365),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+			},
+		},
+		{
+			"recently active, none",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(10),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+				due:    daysAgo(10).Add(90 * day),
+			},
+		},
+		{
+			"inactive without warning, deactivate",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(91),
+				},
+			},
+			want{
+				action: UserLifecycleActionDeactivate,
+				due:    daysAgo(1),
+			},
+		},
+		{
+			"machine user excluded, none",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, ExcludeMachineUsers: true},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeMachine,
+					State:        UserStateActive,
+					LastActivity: daysAgo(91),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+			},
+		},
+		{
+			"user excluded, none",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, ExcludedUserIDs: []string{"user1"}},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(91),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+			},
+		},
+		{
+			"in warning period, warn",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, WarnDaysBefore: 7},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(85),
+				},
+			},
+			want{
+				action: UserLifecycleActionWarnDeactivation,
+				due:    now.Add(7 * day),
+			},
+		},
+		{
+			"overdue without warning, warn and postpone",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, WarnDaysBefore: 7},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(200),
+				},
+			},
+			want{
+				action: UserLifecycleActionWarnDeactivation,
+				due:    now.Add(7 * day),
+			},
+		},
+		{
+			"warned before last login, warn again",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, WarnDaysBefore: 7},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(85),
+					WarningDate:  daysAgo(100),
+				},
+			},
+			want{
+				action: UserLifecycleActionWarnDeactivation,
+				due:    now.Add(7 * day),
+			},
+		},
+		{
+			"warned, warning period not over, none",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, WarnDaysBefore: 7},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(95),
+					WarningDate:  daysAgo(2),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+				due:    daysAgo(2).Add(7 * day),
+			},
+		},
+		{
+			"warned, warning period over, deactivate",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, WarnDaysBefore: 7},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateActive,
+					LastActivity: daysAgo(95),
+					WarningDate:  daysAgo(8),
+				},
+			},
+			want{
+				action: UserLifecycleActionDeactivate,
+				due:    daysAgo(1),
+			},
+		},
+		{
+			"deactivated, retention over, delete",
+			args{
+				policy: &UserLifecyclePolicy{DeleteAfterDays: 30},
+				user: &UserActivity{
+					UserID:           "user1",
+					Type:             UserTypeHuman,
+					State:            UserStateInactive,
+					LastActivity:     daysAgo(200),
+					DeactivationDate: daysAgo(31),
+				},
+			},
+			want{
+				action: UserLifecycleActionDelete,
+				due:    daysAgo(1),
+			},
+		},
+		{
+			"deactivated, deactivation warning not counted, warn deletion",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, DeleteAfterDays: 30, WarnDaysBefore: 7},
+				user: &UserActivity{
+					UserID:           "user1",
+					Type:             UserTypeHuman,
+					State:            UserStateInactive,
+					LastActivity:     daysAgo(200),
+					DeactivationDate: daysAgo(25),
+					WarningDate:      daysAgo(32),
+				},
+			},
+			want{
+				action: UserLifecycleActionWarnDeletion,
+				due:    now.Add(7 * day),
+			},
+		},
+		{
+			"machine user not excluded, deactivate",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeMachine,
+					State:        UserStateActive,
+					LastActivity: daysAgo(91),
+				},
+			},
+			want{
+				action: UserLifecycleActionDeactivate,
+				due:    daysAgo(1),
+			},
+		},
+		{
+			"deactivated, deletion disabled, none",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90},
+				user: &UserActivity{
+					UserID:           "user1",
+					Type:             UserTypeHuman,
+					State:            UserStateInactive,
+					LastActivity:     daysAgo(400),
+					DeactivationDate: daysAgo(300),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+			},
+		},
+		{
+			"deactivated manually without deactivation date, none",
+			args{
+				policy: &UserLifecyclePolicy{DeleteAfterDays: 30},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateInactive,
+					LastActivity: daysAgo(400),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+			},
+		},
+		{
+			"deactivated, retention not over, none",
+			args{
+				policy: &UserLifecyclePolicy{DeleteAfterDays: 30},
+				user: &UserActivity{
+					UserID:           "user1",
+					Type:             UserTypeHuman,
+					State:            UserStateInactive,
+					LastActivity:     daysAgo(200),
+					DeactivationDate: daysAgo(10),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+				due:    daysAgo(10).Add(30 * day),
+			},
+		},
+		{
+			"deletion warned, warning period over, delete",
+			args{
+				policy: &UserLifecyclePolicy{DeleteAfterDays: 30, WarnDaysBefore: 7},
+				user: &UserActivity{
+					UserID:           "user1",
+					Type:             UserTypeHuman,
+					State:            UserStateInactive,
+					LastActivity:     daysAgo(200),
+					DeactivationDate: daysAgo(31),
+					WarningDate:      daysAgo(8),
+				},
+			},
+			want{
+				action: UserLifecycleActionDelete,
+				due:    daysAgo(1),
+			},
+		},
+		{
+			"initial user, none",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, DeleteAfterDays: 30},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateInitial,
+					LastActivity: daysAgo(200),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+			},
+		},
+		{
+			"locked user, none",
+			args{
+				policy: &UserLifecyclePolicy{DeactivateAfterDays: 90, DeleteAfterDays: 30},
+				user: &UserActivity{
+					UserID:       "user1",
+					Type:         UserTypeHuman,
+					State:        UserStateLocked,
+					LastActivity: daysAgo(200),
+				},
+			},
+			want{
+				action: UserLifecycleActionNone,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, due := tt.args.policy.NextAction(tt.args.user, now)
+			assert.Equal(t, tt.want.action, action)
+			assert.Equal(t, tt.want.due, due)
+		})
+	}
+}
+
+func TestUserLifecyclePolicy_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *UserLifecyclePolicy
+		wantErr bool
+	}{
+		{
+			"disabled, ok",
+			&UserLifecyclePolicy{},
+			false,
+		},
+		{
+			"warning shorter than deactivation, ok",
+			&UserLifecyclePolicy{DeactivateAfterDays: 90, WarnDaysBefore: 7},
+			false,
+		},
+		{
+			"warning as long as deactivation, error",
+			&UserLifecyclePolicy{DeactivateAfterDays: 7, WarnDaysBefore: 7},
+			true,
+		},
+		{
+			"empty excluded user id, error",
+			&UserLifecyclePolicy{ExcludedUserIDs: []string{""}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.IsValid()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
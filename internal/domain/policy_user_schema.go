@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// UserSchemaDateLayout is the format of the values of date attributes
+const UserSchemaDateLayout = "2006-01-02"
+
+var userSchemaAttributeNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.\-]{0,199}$`)
+
+// UserSchemaPolicy defines the attributes of the users in addition to the profile.
+// The values of the attributes are stored as metadata of the user, the name of the attribute is the key.
+type UserSchemaPolicy struct {
+	models.ObjectRoot
+
+	Attributes []*UserSchemaAttribute
+}
+
+type UserSchemaAttribute struct {
+	// Name is the key of the metadata
+	Name string
+	// DisplayName is used as label of the attribute in forms
+	DisplayName string
+	Type        UserSchemaAttributeType
+	Required    bool
+	// Pattern is a regular expression the value must match
+	Pattern string
+	// Unique values can only be used by a single user of the organisation
+	Unique     bool
+	Visibility UserSchemaAttributeVisibility
+	// InTokenClaims adds the value of the attribute to the claims of the tokens and userinfo,
+	// which is only allowed for attributes visible to the user
+	InTokenClaims bool
+}
+
+type UserSchemaAttributeType int32
+
+const (
+	UserSchemaAttributeTypeString UserSchemaAttributeType = iota
+	UserSchemaAttributeTypeNumber
+	UserSchemaAttributeTypeBoolean
+	UserSchemaAttributeTypeDate
+
+	userSchemaAttributeTypeCount
+)
+
+func (t UserSchemaAttributeType) Valid() bool {
+	return t >= 0 && t < userSchemaAttributeTypeCount
+}
+
+type UserSchemaAttributeVisibility int32
+
+const (
+	// UserSchemaAttributeVisibilityAdmin attributes are only visible to administrators
+	UserSchemaAttributeVisibilityAdmin UserSchemaAttributeVisibility = iota
+	// UserSchemaAttributeVisibilitySelf attributes are visible to the user as well
+	// and are part of the registration form
+	UserSchemaAttributeVisibilitySelf
+
+	userSchemaAttributeVisibilityCount
+)
+
+func (v UserSchemaAttributeVisibility) Valid() bool {
+	return v >= 0 && v < userSchemaAttributeVisibilityCount
+}
+
+func (p *UserSchemaPolicy) IsValid() error {
+	names := make(map[string]struct{}, len(p.Attributes))
+	for _, attribute := range p.Attributes {
+		if err := attribute.IsValid(); err != nil {
+			return err
+		}
+		if _, ok := names[attribute.Name]; ok {
+			return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc8d", "Errors.UserSchemaPolicy.AttributeNameDuplicate")
+		}
+		names[attribute.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Attribute returns the attribute with the given name or nil if the schema doesn't define it
+func (p *UserSchemaPolicy) Attribute(name string) *UserSchemaAttribute {
+	if p == nil {
+		return nil
+	}
+	for _, attribute := range p.Attributes {
+		if attribute.Name == name {
+			return attribute
+		}
+	}
+	return nil
+}
+
+// CheckRequired returns an error if a required attribute has no value
+func (p *UserSchemaPolicy) CheckRequired(values map[string][]byte) error {
+	return p.checkRequired(values, false)
+}
+
+// CheckSelfRequired returns an error if a required attribute visible to the user has no value,
+// as only those can be provided by the users themselves (e.g. on registration)
+func (p *UserSchemaPolicy) CheckSelfRequired(values map[string][]byte) error {
+	return p.checkRequired(values, true)
+}
+
+func (p *UserSchemaPolicy) checkRequired(values map[string][]byte, selfOnly bool) error {
+	if p == nil {
+		return nil
+	}
+	for _, attribute := range p.Attributes {
+		if selfOnly && attribute.Visibility != UserSchemaAttributeVisibilitySelf {
+			continue
+		}
+		if attribute.Required && len(values[attribute.Name]) == 0 {
+			return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc2r", "Errors.UserSchemaPolicy.AttributeRequired")
+		}
+	}
+	return nil
+}
+
+func (a *UserSchemaAttribute) IsValid() error {
+	if !userSchemaAttributeNameRegex.MatchString(a.Name) {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc4n", "Errors.UserSchemaPolicy.AttributeNameInvalid")
+	}
+	if !a.Type.Valid() {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc6t", "Errors.UserSchemaPolicy.AttributeTypeInvalid")
+	}
+	if !a.Visibility.Valid() {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc1v", "Errors.UserSchemaPolicy.AttributeVisibilityInvalid")
+	}
+	if a.InTokenClaims && a.Visibility != UserSchemaAttributeVisibilitySelf {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc7c", "Errors.UserSchemaPolicy.AttributeAdminInTokenClaims")
+	}
+	if a.Pattern != "" {
+		if _, err := regexp.Compile(a.Pattern); err != nil {
+			return errors.ThrowInvalidArgument(err, "DOMAIN-Usc9p", "Errors.UserSchemaPolicy.AttributePatternInvalid")
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks the value against the type and the pattern of the attribute
+func (a *UserSchemaAttribute) ValidateValue(value []byte) error {
+	if !utf8.Valid(value) {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc3u", "Errors.UserSchemaPolicy.AttributeValueInvalid")
+	}
+	var err error
+	switch a.Type {
+	case UserSchemaAttributeTypeNumber:
+		_, err = strconv.ParseFloat(string(value), 64)
+	case UserSchemaAttributeTypeBoolean:
+		_, err = strconv.ParseBool(string(value))
+	case UserSchemaAttributeTypeDate:
+		_, err = time.Parse(UserSchemaDateLayout, string(value))
+	}
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "DOMAIN-Usc5v", "Errors.UserSchemaPolicy.AttributeValueInvalid")
+	}
+	if a.Pattern == "" {
+		return nil
+	}
+	pattern, err := regexp.Compile(a.Pattern)
+	if err != nil {
+		return errors.ThrowInternal(err, "DOMAIN-Usc7c", "Errors.UserSchemaPolicy.AttributePatternInvalid")
+	}
+	if !pattern.Match(value) {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-Usc0m", "Errors.UserSchemaPolicy.AttributeValueInvalid")
+	}
+	return nil
+}
+
+// ClaimValue returns the value of the attribute typed for the claims of the tokens
+func (a *UserSchemaAttribute) ClaimValue(value []byte) interface{} {
+	switch a.Type {
+	case UserSchemaAttributeTypeNumber:
+		if number, err := strconv.ParseFloat(string(value), 64); err == nil {
+			return number
+		}
+	case UserSchemaAttributeTypeBoolean:
+		if boolean, err := strconv.ParseBool(string(value)); err == nil {
+			return boolean
+		}
+	}
+	return string(value)
+}
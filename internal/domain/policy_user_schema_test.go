@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestUserSchemaPolicy_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *UserSchemaPolicy
+		err    func(error) bool
+	}{
+		{
+			"no attributes, ok",
+			&UserSchemaPolicy{},
+			nil,
+		},
+		{
+			"valid attributes, ok",
+			&UserSchemaPolicy{
+				Attributes: []*UserSchemaAttribute{
+					{Name: "department", Pattern: "^[A-Z]{2,}$"},
+					{Name: "cost.center", Type: UserSchemaAttributeTypeNumber, Visibility: UserSchemaAttributeVisibilitySelf},
+				},
+			},
+			nil,
+		},
+		{
+			"invalid name, error",
+			&UserSchemaPolicy{
+				Attributes: []*UserSchemaAttribute{{Name: "cost center"}},
+			},
+			caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			"duplicate name, error",
+			&UserSchemaPolicy{
+				Attributes: []*UserSchemaAttribute{{Name: "department"}, {Name: "department"}},
+			},
+			caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			"invalid type, error",
+			&UserSchemaPolicy{
+				Attributes: []*UserSchemaAttribute{{Name: "department", Type: userSchemaAttributeTypeCount}},
+			},
+			caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			"invalid visibility, error",
+			&UserSchemaPolicy{
+				Attributes: []*UserSchemaAttribute{{Name: "department", Visibility: userSchemaAttributeVisibilityCount}},
+			},
+			caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			"admin attribute in token claims, error",
+			&UserSchemaPolicy{
+				Attributes: []*UserSchemaAttribute{{Name: "department", Visibility: UserSchemaAttributeVisibilityAdmin, InTokenClaims: true}},
+			},
+			caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			"invalid pattern, error",
+			&UserSchemaPolicy{
+				Attributes: []*UserSchemaAttribute{{Name: "department", Pattern: "[A-Z"}},
+			},
+			caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.IsValid()
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.err(err), "got wrong err: %v", err)
+		})
+	}
+}
+
+func TestUserSchemaPolicy_CheckRequired(t *testing.T) {
+	policy := &UserSchemaPolicy{
+		Attributes: []*UserSchemaAttribute{
+			{Name: "employee_id", Required: true, Visibility: UserSchemaAttributeVisibilityAdmin},
+			{Name: "department", Required: true, Visibility: UserSchemaAttributeVisibilitySelf},
+			{Name: "nickname", Visibility: UserSchemaAttributeVisibilitySelf},
+		},
+	}
+	tests := []struct {
+		name     string
+		policy   *UserSchemaPolicy
+		values   map[string][]byte
+		selfOnly bool
+		wantErr  bool
+	}{
+		{
+			"no policy, ok",
+			nil,
+			nil,
+			false,
+			false,
+		},
+		{
+			"all required values, ok",
+			policy,
+			map[string][]byte{"employee_id": []byte("1"), "department": []byte("IT")},
+			false,
+			false,
+		},
+		{
+			"admin value missing, error",
+			policy,
+			map[string][]byte{"department": []byte("IT")},
+			false,
+			true,
+		},
+		{
+			"admin value missing on self check, ok",
+			policy,
+			map[string][]byte{"department": []byte("IT")},
+			true,
+			false,
+		},
+		{
+			"self value missing on self check, error",
+			policy,
+			map[string][]byte{"employee_id": []byte("1")},
+			true,
+			true,
+		},
+		{
+			"empty value, error",
+			policy,
+			map[string][]byte{"employee_id": []byte("1"), "department": {}},
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.selfOnly {
+				err = tt.policy.CheckSelfRequired(tt.values)
+			} else {
+				err = tt.policy.CheckRequired(tt.values)
+			}
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, caos_errs.IsErrorInvalidArgument(err), "got wrong err: %v", err)
+		})
+	}
+}
+
+func TestUserSchemaAttribute_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute *UserSchemaAttribute
+		value     string
+		wantErr   bool
+	}{
+		{
+			"string, ok",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeString},
+			"IT",
+			false,
+		},
+		{
+			"number, ok",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeNumber},
+			"42.5",
+			false,
+		},
+		{
+			"number, error",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeNumber},
+			"forty-two",
+			true,
+		},
+		{
+			"boolean, ok",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeBoolean},
+			"true",
+			false,
+		},
+		{
+			"boolean, error",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeBoolean},
+			"yes",
+			true,
+		},
+		{
+			"date, ok",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeDate},
+			"1990-12-31",
+			false,
+		},
+		{
+			"date, error",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeDate},
+			"31.12.1990",
+			true,
+		},
+		{
+			"pattern matching, ok",
+			&UserSchemaAttribute{Pattern: "^[A-Z]{2}$"},
+			"IT",
+			false,
+		},
+		{
+			"pattern not matching, error",
+			&UserSchemaAttribute{Pattern: "^[A-Z]{2}$"},
+			"sales",
+			true,
+		},
+		{
+			"invalid utf8, error",
+			&UserSchemaAttribute{},
+			"\xff",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.attribute.ValidateValue([]byte(tt.value))
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, caos_errs.IsErrorInvalidArgument(err), "got wrong err: %v", err)
+		})
+	}
+}
+
+func TestUserSchemaAttribute_ClaimValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute *UserSchemaAttribute
+		value     string
+		want      interface{}
+	}{
+		{
+			"string",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeString},
+			"IT",
+			"IT",
+		},
+		{
+			"number",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeNumber},
+			"42",
+			float64(42),
+		},
+		{
+			"boolean",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeBoolean},
+			"true",
+			true,
+		},
+		{
+			"date",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeDate},
+			"1990-12-31",
+			"1990-12-31",
+		},
+		{
+			"invalid number falls back to string",
+			&UserSchemaAttribute{Type: UserSchemaAttributeTypeNumber},
+			"n/a",
+			"n/a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.attribute.ClaimValue([]byte(tt.value)))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+type RiskSignal string
+
+const (
+	// RiskSignalNewUserAgent is raised if the user never authenticated successfully with the user agent before
+	RiskSignalNewUserAgent RiskSignal = "new_user_agent"
+	// RiskSignalNewNetwork is raised if the user never authenticated successfully from the network (or autonomous system) before
+	RiskSignalNewNetwork RiskSignal = "new_network"
+	// RiskSignalImpossibleTravel is raised if the distance to the location of the last successful authentication
+	// could not have been travelled in the elapsed time
+	RiskSignalImpossibleTravel RiskSignal = "impossible_travel"
+	// RiskSignalFailedAttempts is raised if there were recent failed authentication attempts
+	RiskSignalFailedAttempts RiskSignal = "failed_attempts"
+)
+
+// RiskAssessment is the result of the risk evaluation of an auth request for a specific user
+type RiskAssessment struct {
+	UserID         string
+	Score          uint32
+	Signals        []RiskSignal
+	EvaluationDate time.Time
+}
+
+// AddScore adds the score of the signal to the assessment.
+// A negative score lowers the assessment, but never below zero.
+func (a *RiskAssessment) AddScore(score int64, signal RiskSignal) {
+	total := int64(a.Score) + score
+	switch {
+	case total < 0:
+		a.Score = 0
+	case total > int64(^uint32(0)):
+		a.Score = ^uint32(0)
+	default:
+		a.Score = uint32(total)
+	}
+	if signal != "" {
+		a.Signals = append(a.Signals, signal)
+	}
+}
+
+type RiskDecision int32
+
+const (
+	RiskDecisionAllow RiskDecision = iota
+	RiskDecisionStepUp
+	RiskDecisionBlock
+)
+
+// ValidateRiskThresholds checks that step-up is required before the login is blocked,
+// a threshold of 0 disables the corresponding decision
+func ValidateRiskThresholds(stepUpThreshold, blockThreshold uint32) bool {
+	return stepUpThreshold == 0 || blockThreshold == 0 || stepUpThreshold < blockThreshold
+}
+
+// RiskEvaluationEnabled is true if the policy requires a decision based on the risk score
+func (p *LoginPolicy) RiskEvaluationEnabled() bool {
+	return p.RiskStepUpThreshold > 0 || p.RiskBlockThreshold > 0
+}
+
+// RiskDecision returns the decision of the policy for the passed risk score
+func (p *LoginPolicy) RiskDecision(score uint32) RiskDecision {
+	if p.RiskBlockThreshold > 0 && score >= p.RiskBlockThreshold {
+		return RiskDecisionBlock
+	}
+	if p.RiskStepUpThreshold > 0 && score >= p.RiskStepUpThreshold {
+		return RiskDecisionStepUp
+	}
+	return RiskDecisionAllow
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskAssessment_AddScore(t *testing.T) {
+	type args struct {
+		score  int64
+		signal RiskSignal
+	}
+	tests := []struct {
+		name       string
+		assessment *RiskAssessment
+		args       args
+		want       *RiskAssessment
+	}{
+		{
+			name:       "add signal",
+			assessment: &RiskAssessment{Score: 10},
+			args: args{
+				score:  20,
+				signal: RiskSignalNewNetwork,
+			},
+			want: &RiskAssessment{Score: 30, Signals: []RiskSignal{RiskSignalNewNetwork}},
+		},
+		{
+			name:       "add score without signal",
+			assessment: &RiskAssessment{Score: 10},
+			args: args{
+				score: 5,
+			},
+			want: &RiskAssessment{Score: 15},
+		},
+		{
+			name:       "negative score, minimum 0",
+			assessment: &RiskAssessment{Score: 10},
+			args: args{
+				score:  -20,
+				signal: "trusted_network",
+			},
+			want: &RiskAssessment{Score: 0, Signals: []RiskSignal{"trusted_network"}},
+		},
+		{
+			name:       "overflow, maximum uint32",
+			assessment: &RiskAssessment{Score: 10},
+			args: args{
+				score: 1 << 40,
+			},
+			want: &RiskAssessment{Score: ^uint32(0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assessment.AddScore(tt.args.score, tt.args.signal)
+			assert.Equal(t, tt.want, tt.assessment)
+		})
+	}
+}
+
+func TestValidateRiskThresholds(t *testing.T) {
+	tests := []struct {
+		name            string
+		stepUpThreshold uint32
+		blockThreshold  uint32
+		want            bool
+	}{
+		{
+			name: "disabled",
+			want: true,
+		},
+		{
+			name:            "step-up only",
+			stepUpThreshold: 50,
+			want:            true,
+		},
+		{
+			name:           "block only",
+			blockThreshold: 50,
+			want:           true,
+		},
+		{
+			name:            "step-up before block",
+			stepUpThreshold: 50,
+			blockThreshold:  80,
+			want:            true,
+		},
+		{
+			name:            "block before step-up",
+			stepUpThreshold: 80,
+			blockThreshold:  80,
+			want:            false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateRiskThresholds(tt.stepUpThreshold, tt.blockThreshold))
+		})
+	}
+}
+
+func TestLoginPolicy_RiskDecision(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *LoginPolicy
+		score  uint32
+		want   RiskDecision
+	}{
+		{
+			name:   "disabled",
+			policy: &LoginPolicy{},
+			score:  100,
+			want:   RiskDecisionAllow,
+		},
+		{
+			name:   "below step-up",
+			policy: &LoginPolicy{RiskStepUpThreshold: 50, RiskBlockThreshold: 80},
+			score:  49,
+			want:   RiskDecisionAllow,
+		},
+		{
+			name:   "step-up",
+			policy: &LoginPolicy{RiskStepUpThreshold: 50, RiskBlockThreshold: 80},
+			score:  50,
+			want:   RiskDecisionStepUp,
+		},
+		{
+			name:   "block",
+			policy: &LoginPolicy{RiskStepUpThreshold: 50, RiskBlockThreshold: 80},
+			score:  80,
+			want:   RiskDecisionBlock,
+		},
+		{
+			name:   "block without step-up",
+			policy: &LoginPolicy{RiskBlockThreshold: 80},
+			score:  79,
+			want:   RiskDecisionAllow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.RiskDecision(tt.score))
+		})
+	}
+}
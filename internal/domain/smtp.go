@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"net/url"
+	"strings"
+)
+
 type SMTPConfigState int32
 
 const (
@@ -7,3 +12,37 @@ const (
 	SMTPConfigStateActive
 	SMTPConfigStateRemoved
 )
+
+type SMTPAuthType int32
+
+const (
+	// SMTPAuthTypePlain authenticates with user and password, if both are set
+	SMTPAuthTypePlain SMTPAuthType = iota
+	// SMTPAuthTypeXOAuth2 authenticates with an access token requested by the client credentials grant
+	SMTPAuthTypeXOAuth2
+)
+
+func (t SMTPAuthType) Valid() bool {
+	return t >= SMTPAuthTypePlain && t <= SMTPAuthTypeXOAuth2
+}
+
+// SMTPXOAuth2 contains the client used to request the access token for the XOAUTH2 authentication.
+// The client secret is stored as password of the SMTP configuration.
+type SMTPXOAuth2 struct {
+	TokenEndpoint string   `json:"tokenEndpoint,omitempty"`
+	ClientID      string   `json:"clientId,omitempty"`
+	Scopes        []string `json:"scopes,omitempty"`
+}
+
+func (x *SMTPXOAuth2) IsValid() bool {
+	if x == nil || strings.TrimSpace(x.ClientID) == "" {
+		return false
+	}
+	endpoint, err := url.Parse(x.TokenEndpoint)
+	return err == nil && endpoint.Scheme != "" && endpoint.Host != ""
+}
+
+// SMTPSenderDomain returns the domain part of the sender address
+func SMTPSenderDomain(senderAddress string) string {
+	return senderAddress[strings.LastIndex(senderAddress, "@")+1:]
+}
@@ -0,0 +1,372 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const userBulkMetadataColumnPrefix = "metadata."
+
+// UserBulkImportRow is a human user of an import file.
+// In CSV files the IDP links and grants are JSON encoded and every metadata is a separate column prefixed with `metadata.`
+type UserBulkImportRow struct {
+	// Row is the position of the user in the file, starting with 1
+	Row int `json:"-"`
+	// Err is set if the row could not be parsed
+	Err error `json:"-"`
+
+	UserID                string                   `json:"user_id,omitempty"`
+	UserName              string                   `json:"user_name"`
+	FirstName             string                   `json:"first_name"`
+	LastName              string                   `json:"last_name"`
+	NickName              string                   `json:"nick_name,omitempty"`
+	DisplayName           string                   `json:"display_name,omitempty"`
+	PreferredLanguage     string                   `json:"preferred_language,omitempty"`
+	Gender                string                   `json:"gender,omitempty"`
+	Email                 string                   `json:"email"`
+	EmailVerified         bool                     `json:"email_verified,omitempty"`
+	Phone                 string                   `json:"phone,omitempty"`
+	PhoneVerified         bool                     `json:"phone_verified,omitempty"`
+	PasswordHash          string                   `json:"password_hash,omitempty"`
+	PasswordHashAlgorithm string                   `json:"password_hash_algorithm,omitempty"`
+	TOTPSecret            string                   `json:"totp_secret,omitempty"`
+	IDPLinks              []*UserBulkImportIDPLink `json:"idp_links,omitempty"`
+	Metadata              map[string]string        `json:"metadata,omitempty"`
+	Grants                []*UserBulkImportGrant   `json:"grants,omitempty"`
+}
+
+type UserBulkImportIDPLink struct {
+	IDPID          string `json:"idp_id"`
+	ExternalUserID string `json:"external_user_id"`
+	DisplayName    string `json:"display_name,omitempty"`
+}
+
+type UserBulkImportGrant struct {
+	ProjectID      string   `json:"project_id"`
+	ProjectGrantID string   `json:"project_grant_id,omitempty"`
+	Roles          []string `json:"roles,omitempty"`
+}
+
+// Human maps the row to the human to be imported, the password hash is not part of it,
+// as its algorithm must be checked against the configuration
+func (r *UserBulkImportRow) Human() (*Human, error) {
+	gender, err := ParseGender(r.Gender)
+	if err != nil {
+		return nil, err
+	}
+	var preferredLanguage language.Tag
+	if r.PreferredLanguage != "" {
+		preferredLanguage, err = language.Parse(r.PreferredLanguage)
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "DOMAIN-Ubj4l", "Errors.UserBulkJob.RowInvalid")
+		}
+	}
+	human := &Human{
+		ObjectRoot: models.ObjectRoot{AggregateID: r.UserID},
+		Username:   r.UserName,
+		Profile: &Profile{
+			FirstName:         r.FirstName,
+			LastName:          r.LastName,
+			NickName:          r.NickName,
+			DisplayName:       r.DisplayName,
+			PreferredLanguage: preferredLanguage,
+			Gender:            gender,
+		},
+		Email: &Email{
+			EmailAddress:    EmailAddress(r.Email),
+			IsEmailVerified: r.EmailVerified,
+		},
+	}
+	if r.Phone != "" {
+		human.Phone = &Phone{
+			PhoneNumber:     PhoneNumber(r.Phone),
+			IsPhoneVerified: r.PhoneVerified,
+		}
+	}
+	return human, nil
+}
+
+// ParseUserBulkImportFile returns the rows of the file.
+// Rows which cannot be parsed are returned with an error, so they can be reported without failing the whole import.
+func ParseUserBulkImportFile(format UserBulkJobFormat, data []byte) ([]*UserBulkImportRow, error) {
+	switch format {
+	case UserBulkJobFormatCSV:
+		return parseUserBulkImportCSV(data)
+	case UserBulkJobFormatJSON:
+		return parseUserBulkImportJSON(data)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-Ubj2f", "Errors.UserBulkJob.FormatInvalid")
+	}
+}
+
+func parseUserBulkImportJSON(data []byte) ([]*UserBulkImportRow, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "DOMAIN-Ubj7j", "Errors.UserBulkJob.FileInvalid")
+	}
+	rows := make([]*UserBulkImportRow, len(raws))
+	for i, raw := range raws {
+		row := new(UserBulkImportRow)
+		if err := json.Unmarshal(raw, row); err != nil {
+			row = &UserBulkImportRow{Err: errors.ThrowInvalidArgument(err, "DOMAIN-Ubj3r", "Errors.UserBulkJob.RowInvalid")}
+		}
+		row.Row = i + 1
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func parseUserBulkImportCSV(data []byte) ([]*UserBulkImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "DOMAIN-Ubj8c", "Errors.UserBulkJob.FileInvalid")
+	}
+	if len(records) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-Ubj5h", "Errors.UserBulkJob.HeaderInvalid")
+	}
+	header := make([]string, len(records[0]))
+	hasUserName := false
+	for i, column := range records[0] {
+		header[i] = strings.TrimSpace(column)
+		hasUserName = hasUserName || header[i] == "user_name"
+	}
+	if !hasUserName {
+		return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-Ubj6h", "Errors.UserBulkJob.HeaderInvalid")
+	}
+	rows := make([]*UserBulkImportRow, len(records)-1)
+	for i, record := range records[1:] {
+		row := &UserBulkImportRow{Row: i + 1}
+		if len(record) != len(header) {
+			row.Err = errors.ThrowInvalidArgument(nil, "DOMAIN-Ubj9c", "Errors.UserBulkJob.RowInvalid")
+		} else {
+			row.Err = row.setCSVValues(header, record)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func (r *UserBulkImportRow) setCSVValues(header, record []string) (err error) {
+	for i, column := range header {
+		value := record[i]
+		switch column {
+		case "user_id":
+			r.UserID = value
+		case "user_name":
+			r.UserName = value
+		case "first_name":
+			r.FirstName = value
+		case "last_name":
+			r.LastName = value
+		case "nick_name":
+			r.NickName = value
+		case "display_name":
+			r.DisplayName = value
+		case "preferred_language":
+			r.PreferredLanguage = value
+		case "gender":
+			r.Gender = value
+		case "email":
+			r.Email = value
+		case "email_verified":
+			r.EmailVerified, err = parseUserBulkBool(value)
+		case "phone":
+			r.Phone = value
+		case "phone_verified":
+			r.PhoneVerified, err = parseUserBulkBool(value)
+		case "password_hash":
+			r.PasswordHash = value
+		case "password_hash_algorithm":
+			r.PasswordHashAlgorithm = value
+		case "totp_secret":
+			r.TOTPSecret = value
+		case "idp_links":
+			err = parseUserBulkJSONValue(value, &r.IDPLinks)
+		case "grants":
+			err = parseUserBulkJSONValue(value, &r.Grants)
+		default:
+			if !strings.HasPrefix(column, userBulkMetadataColumnPrefix) || value == "" {
+				continue
+			}
+			if r.Metadata == nil {
+				r.Metadata = make(map[string]string)
+			}
+			r.Metadata[strings.TrimPrefix(column, userBulkMetadataColumnPrefix)] = value
+		}
+		if err != nil {
+			return errors.ThrowInvalidArgument(err, "DOMAIN-Ubj0v", "Errors.UserBulkJob.RowInvalid")
+		}
+	}
+	return nil
+}
+
+func parseUserBulkBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func parseUserBulkJSONValue(value string, v interface{}) error {
+	if value == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(value), v)
+}
+
+// UserBulkExportRow is a user of an export file.
+// The columns are compatible with the import, so an export of human users can be imported into another organisation.
+type UserBulkExportRow struct {
+	UserID            string `json:"user_id"`
+	UserName          string `json:"user_name"`
+	Type              string `json:"type"`
+	State             string `json:"state"`
+	FirstName         string `json:"first_name,omitempty"`
+	LastName          string `json:"last_name,omitempty"`
+	NickName          string `json:"nick_name,omitempty"`
+	DisplayName       string `json:"display_name,omitempty"`
+	PreferredLanguage string `json:"preferred_language,omitempty"`
+	Gender            string `json:"gender,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"email_verified,omitempty"`
+	Phone             string `json:"phone,omitempty"`
+	PhoneVerified     bool   `json:"phone_verified,omitempty"`
+}
+
+var userBulkExportCSVHeader = []string{
+	"user_id",
+	"user_name",
+	"type",
+	"state",
+	"first_name",
+	"last_name",
+	"nick_name",
+	"display_name",
+	"preferred_language",
+	"gender",
+	"email",
+	"email_verified",
+	"phone",
+	"phone_verified",
+}
+
+func (r *UserBulkExportRow) csvRecord() []string {
+	return []string{
+		r.UserID,
+		r.UserName,
+		r.Type,
+		r.State,
+		r.FirstName,
+		r.LastName,
+		r.NickName,
+		r.DisplayName,
+		r.PreferredLanguage,
+		r.Gender,
+		r.Email,
+		strconv.FormatBool(r.EmailVerified),
+		r.Phone,
+		strconv.FormatBool(r.PhoneVerified),
+	}
+}
+
+// WriteUserBulkExportFile returns the content of the export file in the requested format
+func WriteUserBulkExportFile(format UserBulkJobFormat, rows []*UserBulkExportRow) ([]byte, error) {
+	switch format {
+	case UserBulkJobFormatCSV:
+		buf := new(bytes.Buffer)
+		writer := csv.NewWriter(buf)
+		if err := writer.Write(userBulkExportCSVHeader); err != nil {
+			return nil, errors.ThrowInternal(err, "DOMAIN-Ubj1w", "Errors.Internal")
+		}
+		for _, row := range rows {
+			if err := writer.Write(row.csvRecord()); err != nil {
+				return nil, errors.ThrowInternal(err, "DOMAIN-Ubj2w", "Errors.Internal")
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return nil, errors.ThrowInternal(err, "DOMAIN-Ubj3w", "Errors.Internal")
+		}
+		return buf.Bytes(), nil
+	case UserBulkJobFormatJSON:
+		if rows == nil {
+			rows = []*UserBulkExportRow{}
+		}
+		data, err := json.Marshal(rows)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "DOMAIN-Ubj4w", "Errors.Internal")
+		}
+		return data, nil
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-Ubj5f", "Errors.UserBulkJob.FormatInvalid")
+	}
+}
+
+// ParseGender parses the lower case name of the gender, an empty value is unspecified
+func ParseGender(value string) (Gender, error) {
+	switch strings.ToLower(value) {
+	case "":
+		return GenderUnspecified, nil
+	case "female":
+		return GenderFemale, nil
+	case "male":
+		return GenderMale, nil
+	case "diverse":
+		return GenderDiverse, nil
+	default:
+		return GenderUnspecified, errors.ThrowInvalidArgument(nil, "DOMAIN-Ubj6g", "Errors.UserBulkJob.RowInvalid")
+	}
+}
+
+func (f Gender) Name() string {
+	switch f {
+	case GenderFemale:
+		return "female"
+	case GenderMale:
+		return "male"
+	case GenderDiverse:
+		return "diverse"
+	default:
+		return ""
+	}
+}
+
+func (f UserType) Name() string {
+	switch f {
+	case UserTypeHuman:
+		return "human"
+	case UserTypeMachine:
+		return "machine"
+	default:
+		return ""
+	}
+}
+
+func (s UserState) Name() string {
+	switch s {
+	case UserStateActive:
+		return "active"
+	case UserStateInactive:
+		return "inactive"
+	case UserStateDeleted:
+		return "deleted"
+	case UserStateLocked:
+		return "locked"
+	case UserStateSuspend:
+		return "suspended"
+	case UserStateInitial:
+		return "initial"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,293 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestParseUserBulkImportFile(t *testing.T) {
+	type args struct {
+		format UserBulkJobFormat
+		data   string
+	}
+	type res struct {
+		rows      []*UserBulkImportRow
+		rowErrors []bool
+		err       func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "invalid format, error",
+			args: args{
+				format: UserBulkJobFormatUnspecified,
+				data:   "user_name\ngigi",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "csv without user_name column, error",
+			args: args{
+				format: UserBulkJobFormatCSV,
+				data:   "first_name,last_name\nGigi,Giraffe",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "csv empty, error",
+			args: args{
+				format: UserBulkJobFormatCSV,
+				data:   "",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "csv, ok",
+			args: args{
+				format: UserBulkJobFormatCSV,
+				data: "user_id,user_name,first_name,last_name,email,email_verified,password_hash,password_hash_algorithm,metadata.department,unknown,idp_links,grants\n" +
+					`user1,gigi,Gigi,Giraffe,gigi@zitadel.com,true,$2a$14$hash,bcrypt,Engineering,ignored,"[{""idp_id"":""idp1"",""external_user_id"":""ext1""}]","[{""project_id"":""project1"",""roles"":[""role1""]}]"` + "\n" +
+					"user2,gaga,Gaga,Giraffe,gaga@zitadel.com,,,,,,,\n" +
+					"user3,too,few\n" +
+					"user4,gugu,Gugu,Giraffe,gugu@zitadel.com,maybe,,,,,,\n",
+			},
+			res: res{
+				rows: []*UserBulkImportRow{
+					{
+						Row:                   1,
+						UserID:                "user1",
+						UserName:              "gigi",
+						FirstName:             "Gigi",
+						LastName:              "Giraffe",
+						Email:                 "gigi@zitadel.com",
+						EmailVerified:         true,
+						PasswordHash:          "$2a$14$hash",
+						PasswordHashAlgorithm: "bcrypt",
+						Metadata:              map[string]string{"department": "Engineering"},
+						IDPLinks:              []*UserBulkImportIDPLink{{IDPID: "idp1", ExternalUserID: "ext1"}},
+						Grants:                []*UserBulkImportGrant{{ProjectID: "project1", Roles: []string{"role1"}}},
+					},
+					{
+						Row:       2,
+						UserID:    "user2",
+						UserName:  "gaga",
+						FirstName: "Gaga",
+						LastName:  "Giraffe",
+						Email:     "gaga@zitadel.com",
+					},
+					{
+						Row: 3,
+					},
+					{
+						Row: 4,
+					},
+				},
+				rowErrors: []bool{false, false, true, true},
+			},
+		},
+		{
+			name: "json invalid, error",
+			args: args{
+				format: UserBulkJobFormatJSON,
+				data:   `{"user_name": "gigi"}`,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "json, ok",
+			args: args{
+				format: UserBulkJobFormatJSON,
+				data: `[
+					{"user_name": "gigi", "first_name": "Gigi", "last_name": "Giraffe", "email": "gigi@zitadel.com", "totp_secret": "secret", "metadata": {"department": "Engineering"}},
+					{"user_name": 1}
+				]`,
+			},
+			res: res{
+				rows: []*UserBulkImportRow{
+					{
+						Row:        1,
+						UserName:   "gigi",
+						FirstName:  "Gigi",
+						LastName:   "Giraffe",
+						Email:      "gigi@zitadel.com",
+						TOTPSecret: "secret",
+						Metadata:   map[string]string{"department": "Engineering"},
+					},
+					{
+						Row: 2,
+					},
+				},
+				rowErrors: []bool{false, true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseUserBulkImportFile(tt.args.format, []byte(tt.args.data))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err != nil {
+				return
+			}
+			for i, row := range rows {
+				assert.Equal(t, tt.res.rowErrors[i], row.Err != nil, "row %d: %v", row.Row, row.Err)
+				if row.Err != nil {
+					assert.True(t, caos_errs.IsErrorInvalidArgument(row.Err))
+					// the values of invalid rows are irrelevant
+					rows[i] = &UserBulkImportRow{Row: row.Row}
+				}
+			}
+			assert.Equal(t, tt.res.rows, rows)
+		})
+	}
+}
+
+func TestUserBulkImportRow_Human(t *testing.T) {
+	tests := []struct {
+		name  string
+		row   *UserBulkImportRow
+		human *Human
+		err   func(error) bool
+	}{
+		{
+			name: "invalid gender, error",
+			row:  &UserBulkImportRow{UserName: "gigi", Gender: "giraffe"},
+			err:  caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "invalid language, error",
+			row:  &UserBulkImportRow{UserName: "gigi", PreferredLanguage: "not a language"},
+			err:  caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "ok",
+			row: &UserBulkImportRow{
+				UserName:          "gigi",
+				FirstName:         "Gigi",
+				LastName:          "Giraffe",
+				PreferredLanguage: "de",
+				Gender:            "Female",
+				Email:             "gigi@zitadel.com",
+				EmailVerified:     true,
+				Phone:             "+41791234567",
+			},
+			human: &Human{
+				Username: "gigi",
+				Profile: &Profile{
+					FirstName:         "Gigi",
+					LastName:          "Giraffe",
+					PreferredLanguage: language.German,
+					Gender:            GenderFemale,
+				},
+				Email: &Email{
+					EmailAddress:    "gigi@zitadel.com",
+					IsEmailVerified: true,
+				},
+				Phone: &Phone{
+					PhoneNumber: "+41791234567",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			human, err := tt.row.Human()
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.human, human)
+		})
+	}
+}
+
+func TestWriteUserBulkExportFile(t *testing.T) {
+	rows := []*UserBulkExportRow{
+		{
+			UserID:        "user1",
+			UserName:      "gigi",
+			Type:          "human",
+			State:         "active",
+			FirstName:     "Gigi",
+			LastName:      "Giraffe",
+			Email:         "gigi@zitadel.com",
+			EmailVerified: true,
+		},
+		{
+			UserID:      "user2",
+			UserName:    "machine",
+			Type:        "machine",
+			State:       "active",
+			DisplayName: "Machine, Inc.",
+		},
+	}
+	tests := []struct {
+		name   string
+		format UserBulkJobFormat
+		rows   []*UserBulkExportRow
+		want   string
+		err    func(error) bool
+	}{
+		{
+			name:   "invalid format, error",
+			format: UserBulkJobFormatUnspecified,
+			rows:   rows,
+			err:    caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:   "csv, ok",
+			format: UserBulkJobFormatCSV,
+			rows:   rows,
+			want: "user_id,user_name,type,state,first_name,last_name,nick_name,display_name,preferred_language,gender,email,email_verified,phone,phone_verified\n" +
+				"user1,gigi,human,active,Gigi,Giraffe,,,,,gigi@zitadel.com,true,,false\n" +
+				`user2,machine,machine,active,,,,"Machine, Inc.",,,,false,,false` + "\n",
+		},
+		{
+			name:   "json, ok",
+			format: UserBulkJobFormatJSON,
+			rows:   rows,
+			want: `[{"user_id":"user1","user_name":"gigi","type":"human","state":"active","first_name":"Gigi","last_name":"Giraffe","email":"gigi@zitadel.com","email_verified":true},` +
+				`{"user_id":"user2","user_name":"machine","type":"machine","state":"active","display_name":"Machine, Inc."}]`,
+		},
+		{
+			name:   "json without users, ok",
+			format: UserBulkJobFormatJSON,
+			want:   `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WriteUserBulkExportFile(tt.format, tt.rows)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.err == nil {
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const userBulkJobAssetPrefix = "user_bulk_jobs/"
+
+// UserBulkJob imports users from or exports users to a file stored in the static storage.
+// The job is processed asynchronously and can be resumed after a restart.
+type UserBulkJob struct {
+	models.ObjectRoot
+
+	Type   UserBulkJobType
+	Format UserBulkJobFormat
+	State  UserBulkJobState
+	// FileName is the name of the uploaded file of an import or the created file of an export
+	FileName string
+	Filter   *UserBulkExportFilter
+	// Total is the amount of rows of the import file or the amount of exported users
+	Total     uint64
+	Processed uint64
+	Failed    uint64
+	Error     string
+}
+
+// UserBulkExportFilter restricts the users of an export, empty values are ignored
+type UserBulkExportFilter struct {
+	Type     UserType
+	State    UserState
+	UserName string
+	Email    string
+}
+
+type UserBulkJobType int32
+
+const (
+	UserBulkJobTypeUnspecified UserBulkJobType = iota
+	UserBulkJobTypeImport
+	UserBulkJobTypeExport
+)
+
+type UserBulkJobFormat int32
+
+const (
+	UserBulkJobFormatUnspecified UserBulkJobFormat = iota
+	UserBulkJobFormatCSV
+	UserBulkJobFormatJSON
+
+	userBulkJobFormatCount
+)
+
+func (f UserBulkJobFormat) Valid() bool {
+	return f > UserBulkJobFormatUnspecified && f < userBulkJobFormatCount
+}
+
+func (f UserBulkJobFormat) ContentType() string {
+	switch f {
+	case UserBulkJobFormatCSV:
+		return "text/csv"
+	case UserBulkJobFormatJSON:
+		return "application/json"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func (f UserBulkJobFormat) FileExtension() string {
+	switch f {
+	case UserBulkJobFormatCSV:
+		return ".csv"
+	case UserBulkJobFormatJSON:
+		return ".json"
+	default:
+		return ""
+	}
+}
+
+type UserBulkJobState int32
+
+const (
+	UserBulkJobStateUnspecified UserBulkJobState = iota
+	UserBulkJobStateQueued
+	UserBulkJobStateRunning
+	UserBulkJobStateDone
+	UserBulkJobStateFailed
+	UserBulkJobStateCancelled
+	UserBulkJobStateRemoved
+)
+
+func (s UserBulkJobState) Exists() bool {
+	return s != UserBulkJobStateUnspecified && s != UserBulkJobStateRemoved
+}
+
+// Pending jobs are still to be processed by the runner
+func (s UserBulkJobState) Pending() bool {
+	return s == UserBulkJobStateQueued || s == UserBulkJobStateRunning
+}
+
+// GetUserBulkJobAssetPath returns the name of the file of the job in the static storage
+func GetUserBulkJobAssetPath(jobID string, jobType UserBulkJobType, format UserBulkJobFormat) string {
+	name := "import"
+	if jobType == UserBulkJobTypeExport {
+		name = "export"
+	}
+	return userBulkJobAssetPrefix + jobID + "/" + name + format.FileExtension()
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+)
+
+type MagicLinkURLData struct {
+	UserID string
+	OrgID  string
+	CodeID string
+	Code   string
+}
+
+// RenderMagicLinkURLTemplate parses and renders tmpl.
+// userID, orgID, codeID and code are passed into the [MagicLinkURLData].
+func RenderMagicLinkURLTemplate(w io.Writer, tmpl, userID, orgID, codeID, code string) error {
+	return renderURLTemplate(w, tmpl, &MagicLinkURLData{userID, orgID, codeID, code})
+}
+
+// MagicLinkCodeLink returns the link to the login UI, which verifies the code.
+// The authRequestID is only appended if the link was requested during a login.
+func MagicLinkCodeLink(baseURL, userID, orgID, codeID, code, authRequestID string) string {
+	link := fmt.Sprintf("%s?userID=%s&orgID=%s&codeID=%s&code=%s", baseURL, userID, orgID, codeID, code)
+	if authRequestID == "" {
+		return link
+	}
+	return link + "&authRequestID=" + authRequestID
+}
+
+type MagicLinkCodeDetails struct {
+	*ObjectDetails
+	CodeID string
+	Code   string
+}
+
+type MagicLinkCodeState int32
+
+const (
+	MagicLinkCodeStateUnspecified MagicLinkCodeState = iota
+	MagicLinkCodeStateRequested
+	MagicLinkCodeStateActive
+	MagicLinkCodeStateRemoved
+)
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestRenderMagicLinkURLTemplate(t *testing.T) {
+	type args struct {
+		tmpl   string
+		userID string
+		orgID  string
+		codeID string
+		code   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantW   string
+		wantErr error
+	}{
+		{
+			name: "parse error",
+			args: args{
+				tmpl: "{{",
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "DOMAIN-oGh5e", "Errors.User.InvalidURLTemplate"),
+		},
+		{
+			name: "success",
+			args: args{
+				tmpl:   "https://example.com/magiclink?userID={{.UserID}}&orgID={{.OrgID}}&codeID={{.CodeID}}&code={{.Code}}",
+				userID: "user1",
+				orgID:  "org1",
+				codeID: "99",
+				code:   "123",
+			},
+			wantW: "https://example.com/magiclink?userID=user1&orgID=org1&codeID=99&code=123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			err := RenderMagicLinkURLTemplate(w, tt.args.tmpl, tt.args.userID, tt.args.orgID, tt.args.codeID, tt.args.code)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantW, w.String())
+		})
+	}
+}
+
+func TestMagicLinkCodeLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		authRequestID string
+		want          string
+	}{
+		{
+			name: "without auth request",
+			want: "https://example.com/magiclink?userID=user1&orgID=org1&codeID=99&code=123",
+		},
+		{
+			name:          "with auth request",
+			authRequestID: "authRequest1",
+			want:          "https://example.com/magiclink?userID=user1&orgID=org1&codeID=99&code=123&authRequestID=authRequest1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MagicLinkCodeLink("https://example.com/magiclink", "user1", "org1", "99", "123", tt.authRequestID)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -12,6 +12,9 @@ type Config struct {
 	PushTimeout              time.Duration
 	Client                   *database.DB
 	AllowOrderByCreationDate bool
+	// PersonalDataKeys enables the encryption of the personal data in the payload of the events,
+	// without keys the personal data is stored in plain text
+	PersonalDataKeys PersonalDataKeys
 
 	repo repository.Repository
 }
@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
 // Eventstore abstracts all functions needed to store valid events
@@ -23,10 +25,23 @@ type Eventstore struct {
 	eventTypes        []string
 	aggregateTypes    []string
 	PushTimeout       time.Duration
+	personalDataKeys  PersonalDataKeys
 }
 
 type eventTypeInterceptors struct {
-	eventMapper func(*repository.Event) (Event, error)
+	eventMapper       func(*repository.Event) (Event, error)
+	pushInterceptor   func(context.Context, *repository.Event) error
+	filterInterceptor func(context.Context, *repository.Event) error
+}
+
+// PersonalDataKeys manages the keys which encrypt the personal data in the payload of the events of an aggregate
+type PersonalDataKeys interface {
+	// Key returns the encryption algorithm of the key of the aggregate.
+	// If create is set, a key is created for aggregates without a key.
+	// If the aggregate has no key (anymore) nil is returned
+	Key(ctx context.Context, instanceID, aggregateID string, create bool) (crypto.EncryptionAlgorithm, error)
+	// Destroy deletes the key of the aggregate, so that the encrypted personal data can't be read anymore
+	Destroy(ctx context.Context, instanceID, aggregateID string) error
 }
 
 func NewEventstore(config *Config) *Eventstore {
@@ -35,9 +50,16 @@ func NewEventstore(config *Config) *Eventstore {
 		eventInterceptors: map[EventType]eventTypeInterceptors{},
 		interceptorMutex:  sync.Mutex{},
 		PushTimeout:       config.PushTimeout,
+		personalDataKeys:  config.PersonalDataKeys,
 	}
 }
 
+// PersonalDataKeys returns the key storage for the encryption of personal data,
+// nil is returned if the personal data is stored in plain text
+func (es *Eventstore) PersonalDataKeys() PersonalDataKeys {
+	return es.personalDataKeys
+}
+
 // Health checks if the eventstore can properly work
 // It checks if the repository can serve load
 func (es *Eventstore) Health(ctx context.Context) error {
@@ -51,6 +73,9 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 	if err != nil {
 		return nil, err
 	}
+	if err = es.interceptPush(ctx, events); err != nil {
+		return nil, err
+	}
 
 	if es.PushTimeout > 0 {
 		var cancel func()
@@ -63,7 +88,7 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 		return nil, err
 	}
 
-	eventReaders, err := es.mapEvents(events)
+	eventReaders, err := es.mapEvents(ctx, events)
 	if err != nil {
 		return nil, err
 	}
@@ -152,18 +177,20 @@ func (es *Eventstore) Filter(ctx context.Context, queryFactory *SearchQueryBuild
 		return nil, err
 	}
 
-	return es.mapEvents(events)
+	return es.mapEvents(ctx, events)
 }
 
-func (es *Eventstore) mapEvents(events []*repository.Event) (mappedEvents []Event, err error) {
+func (es *Eventstore) mapEvents(ctx context.Context, events []*repository.Event) (mappedEvents []Event, err error) {
 	mappedEvents = make([]Event, len(events))
 
-	es.interceptorMutex.Lock()
-	defer es.interceptorMutex.Unlock()
-
 	for i, event := range events {
-		interceptors, ok := es.eventInterceptors[EventType(event.Type)]
-		if !ok || interceptors.eventMapper == nil {
+		interceptors := es.interceptors(EventType(event.Type))
+		if interceptors.filterInterceptor != nil {
+			if err = interceptors.filterInterceptor(ctx, event); err != nil {
+				return nil, err
+			}
+		}
+		if interceptors.eventMapper == nil {
 			mappedEvents[i] = BaseEventFromRepo(event)
 			//TODO: return error if unable to map event
 			continue
@@ -252,6 +279,80 @@ func (es *Eventstore) RegisterFilterEventMapper(aggregateType AggregateType, eve
 	return es
 }
 
+// RegisterPushEventInterceptor registers a function which is able to modify the events of the given type before they are stored
+// e.g. to encrypt parts of the payload
+func (es *Eventstore) RegisterPushEventInterceptor(eventType EventType, pushInterceptor func(context.Context, *repository.Event) error) *Eventstore {
+	if pushInterceptor == nil || eventType == "" {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	interceptor := es.eventInterceptors[eventType]
+	interceptor.pushInterceptor = pushInterceptor
+	es.eventInterceptors[eventType] = interceptor
+
+	return es
+}
+
+// RegisterFilterEventInterceptor registers a function which is able to modify the filtered events of the given type before they are mapped
+// e.g. to decrypt parts of the payload
+func (es *Eventstore) RegisterFilterEventInterceptor(eventType EventType, filterInterceptor func(context.Context, *repository.Event) error) *Eventstore {
+	if filterInterceptor == nil || eventType == "" {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	interceptor := es.eventInterceptors[eventType]
+	interceptor.filterInterceptor = filterInterceptor
+	es.eventInterceptors[eventType] = interceptor
+
+	return es
+}
+
+// InterceptV1Filter passes the events filtered by the eventstore v1 to the filter interceptors
+func (es *Eventstore) InterceptV1Filter(ctx context.Context, event *models.Event) error {
+	interceptors := es.interceptors(EventType(event.Type))
+	if interceptors.filterInterceptor == nil {
+		return nil
+	}
+	repoEvent := &repository.Event{
+		ID:            event.ID,
+		Type:          repository.EventType(event.Type),
+		Data:          event.Data,
+		AggregateType: repository.AggregateType(event.AggregateType),
+		AggregateID:   event.AggregateID,
+		InstanceID:    event.InstanceID,
+	}
+	if err := interceptors.filterInterceptor(ctx, repoEvent); err != nil {
+		return err
+	}
+	event.Data = repoEvent.Data
+	return nil
+}
+
+func (es *Eventstore) interceptPush(ctx context.Context, events []*repository.Event) error {
+	for _, event := range events {
+		interceptors := es.interceptors(EventType(event.Type))
+		if interceptors.pushInterceptor == nil {
+			continue
+		}
+		if err := interceptors.pushInterceptor(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interceptors returns the interceptors of the event type,
+// the mutex is only held for the lookup so the interceptors may access other resources
+func (es *Eventstore) interceptors(eventType EventType) eventTypeInterceptors {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+	return es.eventInterceptors[eventType]
+}
+
 func (es *Eventstore) appendEventType(typ EventType) {
 	i := sort.SearchStrings(es.eventTypes, string(typ))
 	if i < len(es.eventTypes) && es.eventTypes[i] == string(typ) {
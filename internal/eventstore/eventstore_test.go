@@ -179,6 +179,106 @@ func Test_eventstore_RegisterFilterEventMapper(t *testing.T) {
 	}
 }
 
+func Test_eventstore_interceptPush(t *testing.T) {
+	type args struct {
+		eventType   EventType
+		interceptor func(context.Context, *repository.Event) error
+		events      []*repository.Event
+	}
+	type res struct {
+		data    [][]byte
+		wantErr bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "no interceptor",
+			args: args{
+				eventType:   "event.type",
+				interceptor: nil,
+				events: []*repository.Event{
+					{Type: "event.type", Data: []byte(`{"name":"hodor"}`)},
+				},
+			},
+			res: res{
+				data: [][]byte{[]byte(`{"name":"hodor"}`)},
+			},
+		},
+		{
+			name: "interceptor of other event type",
+			args: args{
+				eventType: "other.type",
+				interceptor: func(_ context.Context, event *repository.Event) error {
+					event.Data = []byte(`{"name":"intercepted"}`)
+					return nil
+				},
+				events: []*repository.Event{
+					{Type: "event.type", Data: []byte(`{"name":"hodor"}`)},
+				},
+			},
+			res: res{
+				data: [][]byte{[]byte(`{"name":"hodor"}`)},
+			},
+		},
+		{
+			name: "interceptor modifies event",
+			args: args{
+				eventType: "event.type",
+				interceptor: func(_ context.Context, event *repository.Event) error {
+					event.Data = []byte(`{"name":"intercepted"}`)
+					return nil
+				},
+				events: []*repository.Event{
+					{Type: "event.type", Data: []byte(`{"name":"hodor"}`)},
+					{Type: "other.type", Data: []byte(`{"name":"hodor"}`)},
+				},
+			},
+			res: res{
+				data: [][]byte{[]byte(`{"name":"intercepted"}`), []byte(`{"name":"hodor"}`)},
+			},
+		},
+		{
+			name: "interceptor fails",
+			args: args{
+				eventType: "event.type",
+				interceptor: func(_ context.Context, event *repository.Event) error {
+					return errors.ThrowInternal(nil, "V2-Kw9cn", "interceptor failed")
+				},
+				events: []*repository.Event{
+					{Type: "event.type", Data: []byte(`{"name":"hodor"}`)},
+				},
+			},
+			res: res{
+				wantErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &Eventstore{
+				eventInterceptors: map[EventType]eventTypeInterceptors{},
+			}
+			es = es.RegisterPushEventInterceptor(tt.args.eventType, tt.args.interceptor)
+			err := es.interceptPush(context.Background(), tt.args.events)
+			if (err != nil) != tt.res.wantErr {
+				t.Errorf("interceptPush() error = %v, wantErr %v", err, tt.res.wantErr)
+				return
+			}
+			if tt.res.wantErr {
+				return
+			}
+			for i, event := range tt.args.events {
+				if !reflect.DeepEqual(tt.res.data[i], event.Data) {
+					t.Errorf("unexpected data: want %s, got %s", tt.res.data[i], event.Data)
+				}
+			}
+		})
+	}
+}
+
 func Test_eventData(t *testing.T) {
 	type args struct {
 		event Command
@@ -1505,7 +1605,8 @@ func compareEvents(t *testing.T, want, got *repository.Event) {
 
 func TestEventstore_mapEvents(t *testing.T) {
 	type fields struct {
-		eventMapper map[EventType]func(*repository.Event) (Event, error)
+		eventMapper       map[EventType]func(*repository.Event) (Event, error)
+		filterInterceptor map[EventType]func(context.Context, *repository.Event) error
 	}
 	type args struct {
 		events []*repository.Event
@@ -1581,6 +1682,64 @@ func TestEventstore_mapEvents(t *testing.T) {
 				wantErr: false,
 			},
 		},
+		{
+			name: "filter interceptor failed",
+			args: args{
+				events: []*repository.Event{
+					{
+						Type: "test.event",
+					},
+				},
+			},
+			fields: fields{
+				eventMapper: map[EventType]func(*repository.Event) (Event, error){
+					"test.event": func(*repository.Event) (Event, error) {
+						return &testEvent{}, nil
+					},
+				},
+				filterInterceptor: map[EventType]func(context.Context, *repository.Event) error{
+					"test.event": func(context.Context, *repository.Event) error {
+						return errors.ThrowInternal(nil, "V2-Ahx3o", "test err")
+					},
+				},
+			},
+			res: res{
+				wantErr: true,
+			},
+		},
+		{
+			name: "filter interceptor before mapping",
+			args: args{
+				events: []*repository.Event{
+					{
+						Type: "test.event",
+						Data: []byte(`{"name":"encrypted"}`),
+					},
+				},
+			},
+			fields: fields{
+				eventMapper: map[EventType]func(*repository.Event) (Event, error){
+					"test.event": func(event *repository.Event) (Event, error) {
+						if string(event.Data) != `{"name":"decrypted"}` {
+							return nil, errors.ThrowInternal(nil, "V2-Oe4ai", "not intercepted")
+						}
+						return &testEvent{}, nil
+					},
+				},
+				filterInterceptor: map[EventType]func(context.Context, *repository.Event) error{
+					"test.event": func(_ context.Context, event *repository.Event) error {
+						event.Data = []byte(`{"name":"decrypted"}`)
+						return nil
+					},
+				},
+			},
+			res: res{
+				events: []Event{
+					&testEvent{},
+				},
+				wantErr: false,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1595,8 +1754,11 @@ func TestEventstore_mapEvents(t *testing.T) {
 				t.Errorf("register event mapper failed expected mapper amount: %d, got: %d", len(tt.fields.eventMapper), len(es.eventInterceptors))
 				t.FailNow()
 			}
+			for eventType, interceptor := range tt.fields.filterInterceptor {
+				es = es.RegisterFilterEventInterceptor(eventType, interceptor)
+			}
 
-			gotMappedEvents, err := es.mapEvents(tt.args.events)
+			gotMappedEvents, err := es.mapEvents(context.Background(), tt.args.events)
 			if (err != nil) != tt.res.wantErr {
 				t.Errorf("Eventstore.mapEvents() error = %v, wantErr %v", err, tt.res.wantErr)
 				return
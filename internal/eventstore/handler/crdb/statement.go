@@ -261,6 +261,17 @@ func NewArrayRemoveCol(column string, value interface{}) handler.Column {
 	}
 }
 
+// NewIncrementCol adds the value to the current value of the column
+func NewIncrementCol(column string, value interface{}) handler.Column {
+	return handler.Column{
+		Name:  column,
+		Value: value,
+		ParameterOpt: func(placeholder string) string {
+			return column + " + " + placeholder
+		},
+	}
+}
+
 func NewArrayIntersectCol(column string, value interface{}) handler.Column {
 	var arrayType string
 	switch value.(type) {
@@ -18,13 +18,19 @@ type Eventstore interface {
 
 var _ Eventstore = (*eventstore)(nil)
 
+// FilterInterceptor is able to modify the filtered events
+// e.g. to decrypt parts of the payload
+type FilterInterceptor func(context.Context, *models.Event) error
+
 type eventstore struct {
-	repo repository.Repository
+	repo              repository.Repository
+	filterInterceptor FilterInterceptor
 }
 
-func Start(db *database.DB, allowOrderByCreationDate bool) (Eventstore, error) {
+func Start(db *database.DB, allowOrderByCreationDate bool, filterInterceptor FilterInterceptor) (Eventstore, error) {
 	return &eventstore{
-		repo: z_sql.Start(db, allowOrderByCreationDate),
+		repo:              z_sql.Start(db, allowOrderByCreationDate),
+		filterInterceptor: filterInterceptor,
 	}, nil
 }
 
@@ -32,7 +38,23 @@ func (es *eventstore) FilterEvents(ctx context.Context, searchQuery *models.Sear
 	if err := searchQuery.Validate(); err != nil {
 		return nil, err
 	}
-	return es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	events, err := es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	if err != nil {
+		return nil, err
+	}
+	return events, es.interceptFilter(ctx, events)
+}
+
+func (es *eventstore) interceptFilter(ctx context.Context, events []*models.Event) error {
+	if es.filterInterceptor == nil {
+		return nil
+	}
+	for _, event := range events {
+		if err := es.filterInterceptor(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (es *eventstore) Health(ctx context.Context) error {
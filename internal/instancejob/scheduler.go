@@ -0,0 +1,100 @@
+// Package instancejob periodically runs background jobs for each instance,
+// while an instance is processed it's locked so only a single ZITADEL runs the job for it.
+package instancejob
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+)
+
+const locksTable = "projections.locks"
+
+// RunInstance processes a single instance, the instance is already set on the context
+type RunInstance func(ctx context.Context, instanceID string) error
+
+type Scheduler struct {
+	name         string
+	interval     time.Duration
+	lockDuration time.Duration
+	es           *eventstore.Eventstore
+	locker       crdb.Locker
+	run          RunInstance
+}
+
+// NewScheduler creates a scheduler which calls run for every instance each interval.
+// The name identifies the job in the locks and logs.
+func NewScheduler(name string, interval, lockDuration time.Duration, db *database.DB, es *eventstore.Eventstore, run RunInstance) *Scheduler {
+	return &Scheduler{
+		name:         name,
+		interval:     interval,
+		lockDuration: lockDuration,
+		es:           es,
+		locker:       crdb.NewLocker(db.DB, locksTable, name),
+		run:          run,
+	}
+}
+
+// Start runs the job in the background until the context is done
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.schedule(ctx)
+}
+
+func (s *Scheduler) schedule(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runInstances(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) runInstances(ctx context.Context) {
+	instanceIDs, err := s.es.InstanceIDs(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).AllowTimeTravel().AddQuery().ExcludedInstanceID("").Builder())
+	if err != nil {
+		logging.WithFields("job", s.name).WithError(err).Warn("unable to query instances")
+		return
+	}
+	for _, instanceID := range instanceIDs {
+		err = s.runInstance(ctx, instanceID)
+		logging.WithFields("job", s.name, "instance", instanceID).OnError(err).Warn("unable to process instance")
+	}
+}
+
+// runInstance runs the job for the instance, as long as the instance can be locked.
+// If another ZITADEL is already processing the instance, it is skipped.
+func (s *Scheduler) runInstance(ctx context.Context, instanceID string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := s.locker.Lock(ctx, s.lockDuration, instanceID)
+	err, ok := <-errs
+	if err != nil || !ok {
+		if errors.IsErrorAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	go func() {
+		for err := range errs {
+			logging.WithFields("job", s.name, "instance", instanceID).OnError(err).Warn("unable to renew lock")
+		}
+	}()
+	defer func() {
+		err := s.locker.Unlock(instanceID)
+		logging.WithFields("job", s.name, "instance", instanceID).OnError(err).Debug("unable to unlock")
+	}()
+
+	return s.run(authz.WithInstanceID(ctx, instanceID), instanceID)
+}
@@ -0,0 +1,99 @@
+package instancejob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type mockLocker struct {
+	lockErr  error
+	unlocked []string
+}
+
+func (l *mockLocker) Lock(ctx context.Context, _ time.Duration, _ ...string) <-chan error {
+	errs := make(chan error, 1)
+	errs <- l.lockErr
+	go func() {
+		<-ctx.Done()
+		close(errs)
+	}()
+	return errs
+}
+
+func (l *mockLocker) Unlock(instanceIDs ...string) error {
+	l.unlocked = append(l.unlocked, instanceIDs...)
+	return nil
+}
+
+func TestScheduler_runInstance(t *testing.T) {
+	type res struct {
+		ran      bool
+		unlocked bool
+		err      func(error) bool
+	}
+	tests := []struct {
+		name    string
+		lockErr error
+		runErr  error
+		res     res
+	}{
+		{
+			name: "locked, run",
+			res: res{
+				ran:      true,
+				unlocked: true,
+			},
+		},
+		{
+			name:    "locked by other, skipped",
+			lockErr: errors.ThrowAlreadyExists(nil, "CRDB-mmi4J", "projection already locked"),
+			res:     res{},
+		},
+		{
+			name:    "lock failed, error",
+			lockErr: errors.ThrowInternal(nil, "", "lock failed"),
+			res: res{
+				err: errors.IsInternal,
+			},
+		},
+		{
+			name:   "run failed, error and unlocked",
+			runErr: errors.ThrowInternal(nil, "", "run failed"),
+			res: res{
+				ran:      true,
+				unlocked: true,
+				err:      errors.IsInternal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker := &mockLocker{lockErr: tt.lockErr}
+			var ran bool
+			s := &Scheduler{
+				name:   "job",
+				locker: locker,
+				run: func(ctx context.Context, instanceID string) error {
+					ran = true
+					assert.Equal(t, "instance1", instanceID)
+					assert.Equal(t, "instance1", authz.GetInstance(ctx).InstanceID())
+					return tt.runErr
+				},
+			}
+			err := s.runInstance(context.Background(), "instance1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.ran, ran)
+			assert.Equal(t, tt.res.unlocked, len(locker.unlocked) > 0)
+		})
+	}
+}
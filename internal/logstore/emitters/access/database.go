@@ -145,6 +145,46 @@ func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string,
 	return count, nil
 }
 
+// QueryFailedRequests counts the requests of the remote ip to the instance since the start time,
+// which were rejected because of invalid input or missing authentication.
+// The remote ip is taken from the first entry of the x-forwarded-for header.
+func (l *databaseLogStorage) QueryFailedRequests(ctx context.Context, instanceID, remoteIP string, start time.Time) (uint64, error) {
+	stmt, args, err := squirrel.Select(
+		fmt.Sprintf("count(%s)", accessInstanceIdCol),
+	).
+		From(accessLogsTable + l.dbClient.Timetravel(call.Took(ctx))).
+		Where(squirrel.And{
+			squirrel.Eq{accessInstanceIdCol: instanceID},
+			squirrel.GtOrEq{accessTimestampCol: start},
+			squirrel.Expr(fmt.Sprintf(`trim(split_part(%s #>> '{%s,0}', ',', 1)) = ?`, accessRequestHeadersCol, strings.ToLower(zitadel_http.ForwardedFor)), remoteIP),
+			squirrel.Or{
+				squirrel.And{
+					squirrel.Eq{accessProtocolCol: HTTP},
+					squirrel.Eq{accessResponseStatusCol: []uint32{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+				},
+				squirrel.And{
+					squirrel.Eq{accessProtocolCol: GRPC},
+					squirrel.Eq{accessResponseStatusCol: []codes.Code{codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied}},
+				},
+			},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+
+	if err != nil {
+		return 0, caos_errors.ThrowInternal(err, "ACCESS-Jd8wq", "Errors.Internal")
+	}
+
+	var count uint64
+	if err = l.dbClient.
+		QueryRowContext(ctx, stmt, args...).
+		Scan(&count); err != nil {
+		return 0, caos_errors.ThrowInternal(err, "ACCESS-x0Vbe", "Errors.Logstore.Access.ScanFailed")
+	}
+
+	return count, nil
+}
+
 func (l *databaseLogStorage) Cleanup(ctx context.Context, keep time.Duration) error {
 	stmt, args, err := squirrel.Delete(accessLogsTable).
 		Where(squirrel.LtOrEq{accessTimestampCol: time.Now().Add(-keep)}).
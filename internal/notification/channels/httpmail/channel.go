@@ -0,0 +1,183 @@
+package httpmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// maxResponseSize limits the part of the response body which is read for the success criteria and the message id
+	maxResponseSize = 64 * 1024
+)
+
+var _ channels.NotificationChannel = (*Email)(nil)
+
+type Email struct {
+	ctx    context.Context
+	config *Config
+	preset preset
+	body   *template.Template
+}
+
+// Data is passed to the body template of the request
+type Data struct {
+	From     string
+	FromName string
+	// Sender is the formatted sender, containing the name (if set) and the address
+	Sender    string
+	To        []string
+	CC        []string
+	BCC       []string
+	Subject   string
+	HTML      string
+	Text      string
+	MessageID string
+}
+
+// InitChannel creates the channel of a transactional email API,
+// empty fields of the config are completed by its preset
+func InitChannel(ctx context.Context, config *Config) (*Email, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config = config.withPreset()
+	body, err := parseBodyTemplate(config.BodyTemplate)
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "HTTPM-Wq2bd", "Errors.EmailHTTPConfig.BodyTemplateInvalid")
+	}
+	logging.Debug("successfully initialized http email channel")
+	return &Email{
+		ctx:    ctx,
+		config: config,
+		preset: presets[config.Preset],
+		body:   body,
+	}, nil
+}
+
+func (email *Email) HandleMessage(message channels.Message) error {
+	emailMsg, ok := message.(*messages.Email)
+	if !ok {
+		return caos_errs.ThrowInternal(nil, "HTTPM-Xo9dm", "message is not EmailMessage")
+	}
+	if emailMsg.Content == "" || emailMsg.Subject == "" || len(emailMsg.Recipients) == 0 {
+		return caos_errs.ThrowInternalf(nil, "HTTPM-Ra3ue", "subject, recipients and content must be set but got subject %s, recipients length %d and content length %d", emailMsg.Subject, len(emailMsg.Recipients), len(emailMsg.Content))
+	}
+	emailMsg.SenderEmail = email.config.From
+	emailMsg.SenderName = email.config.FromName
+	if emailMsg.MessageID == "" {
+		messageID, err := messages.NewMessageID(email.config.From)
+		if err != nil {
+			return err
+		}
+		emailMsg.MessageID = messageID
+	}
+	body := new(bytes.Buffer)
+	if err := email.body.Execute(body, templateData(emailMsg)); err != nil {
+		return caos_errs.ThrowInternal(err, "HTTPM-Bd7rn", "could not render request body")
+	}
+
+	ctx, cancel := context.WithTimeout(email.ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, email.config.Endpoint, body)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "HTTPM-Rq4vz", "could not create request")
+	}
+	req.Header.Set("Content-Type", email.config.ContentType)
+	if email.config.AuthHeader != "" && email.config.APIKey != "" {
+		req.Header.Set(email.config.AuthHeader, email.preset.authValue(email.config.APIKey))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "HTTPM-Cl5xq", "could not send message")
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "HTTPM-Rb8sy", "could not read response")
+	}
+	if !email.succeeded(resp.StatusCode, respBody) {
+		return caos_errs.ThrowInternal(fmt.Errorf("calling %s returned %s", email.config.Endpoint, resp.Status), "HTTPM-Su6fk", "message was not accepted")
+	}
+	if providerID := email.providerMessageID(resp.Header, respBody); providerID != "" {
+		emailMsg.MessageID = providerID
+	}
+	logging.WithFields("endpoint", email.config.Endpoint, "status", resp.StatusCode).Debug("email sent")
+	return nil
+}
+
+// succeeded checks the response against the success criteria of the config
+func (email *Email) succeeded(statusCode int, body []byte) bool {
+	statusOK := statusCode >= 200 && statusCode < 300
+	if len(email.config.SuccessStatusCodes) > 0 {
+		statusOK = false
+		for _, code := range email.config.SuccessStatusCodes {
+			if int(code) == statusCode {
+				statusOK = true
+				break
+			}
+		}
+	}
+	return statusOK && strings.Contains(string(body), email.config.SuccessBodyContains)
+}
+
+// providerMessageID returns the id the provider assigned to the message, if the preset knows where to find it
+func (email *Email) providerMessageID(header http.Header, body []byte) string {
+	if email.preset.messageIDHeader != "" {
+		return header.Get(email.preset.messageIDHeader)
+	}
+	if email.preset.messageIDField == "" {
+		return ""
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return ""
+	}
+	id, _ := fields[email.preset.messageIDField].(string)
+	return strings.Trim(id, "<>")
+}
+
+func templateData(msg *messages.Email) *Data {
+	sender := msg.SenderEmail
+	if msg.SenderName != "" {
+		sender = fmt.Sprintf("%s <%s>", msg.SenderName, msg.SenderEmail)
+	}
+	return &Data{
+		From:      msg.SenderEmail,
+		FromName:  msg.SenderName,
+		Sender:    sender,
+		To:        nonNil(msg.Recipients),
+		CC:        nonNil(msg.CC),
+		BCC:       nonNil(msg.BCC),
+		Subject:   msg.Subject,
+		HTML:      msg.Content,
+		Text:      msg.TextContent,
+		MessageID: msg.MessageID,
+	}
+}
+
+// nonNil makes sure the json function of the template renders empty lists as `[]`
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
@@ -0,0 +1,260 @@
+package httpmail
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+type stubRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// stubServer records the requests and answers them with the status, header and body
+func stubServer(t *testing.T, status int, header map[string]string, body string) (*httptest.Server, *[]stubRequest) {
+	requests := make([]stubRequest, 0, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, stubRequest{header: r.Header, body: data})
+		for key, value := range header {
+			w.Header().Set(key, value)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testMessage() *messages.Email {
+	return &messages.Email{
+		Recipients:  []string{"user@acme.ch"},
+		Subject:     "Verify email",
+		Content:     "<html><p>Hello & welcome</p></html>",
+		TextContent: "Hello & welcome",
+		MessageID:   "message@acme.ch",
+	}
+}
+
+func TestEmail_HandleMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *Config
+		status        int
+		header        map[string]string
+		body          string
+		wantErr       bool
+		wantMessageID string
+		check         func(t *testing.T, req stubRequest)
+	}{
+		{
+			name: "custom, ok",
+			config: &Config{
+				Preset:       domain.EmailHTTPPresetCustom,
+				BodyTemplate: `{"to":{{json .To}},"subject":{{json .Subject}},"html":{{json .HTML}}}`,
+				AuthHeader:   "X-Api-Key",
+				APIKey:       "key",
+			},
+			status:        http.StatusOK,
+			wantMessageID: "message@acme.ch",
+			check: func(t *testing.T, req stubRequest) {
+				assert.Equal(t, "key", req.header.Get("X-Api-Key"))
+				assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+				body := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(req.body, &body))
+				assert.Equal(t, []interface{}{"user@acme.ch"}, body["to"])
+				assert.Equal(t, "Verify email", body["subject"])
+				assert.Equal(t, "<html><p>Hello & welcome</p></html>", body["html"])
+			},
+		},
+		{
+			name: "custom, unexpected status",
+			config: &Config{
+				Preset:             domain.EmailHTTPPresetCustom,
+				BodyTemplate:       `{}`,
+				SuccessStatusCodes: []int32{201},
+			},
+			status:  http.StatusOK,
+			wantErr: true,
+		},
+		{
+			name: "custom, error status",
+			config: &Config{
+				Preset:       domain.EmailHTTPPresetCustom,
+				BodyTemplate: `{}`,
+			},
+			status:  http.StatusUnauthorized,
+			wantErr: true,
+		},
+		{
+			name: "custom, body not matching",
+			config: &Config{
+				Preset:              domain.EmailHTTPPresetCustom,
+				BodyTemplate:        `{}`,
+				SuccessBodyContains: `"success":true`,
+			},
+			status:  http.StatusOK,
+			body:    `{"success":false}`,
+			wantErr: true,
+		},
+		{
+			name: "sendgrid, ok",
+			config: &Config{
+				Preset: domain.EmailHTTPPresetSendGrid,
+				APIKey: "key",
+			},
+			status:        http.StatusAccepted,
+			header:        map[string]string{"X-Message-Id": "sendgrid-id"},
+			wantMessageID: "sendgrid-id",
+			check: func(t *testing.T, req stubRequest) {
+				assert.Equal(t, "Bearer key", req.header.Get("Authorization"))
+				body := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(req.body, &body))
+				assert.Equal(t, "Verify email", body["subject"])
+				assert.Equal(t, map[string]interface{}{"email": "from@acme.ch", "name": "ACME"}, body["from"])
+				assert.Len(t, body["content"], 2)
+			},
+		},
+		{
+			name: "mailgun, ok",
+			config: &Config{
+				Preset: domain.EmailHTTPPresetMailgun,
+				APIKey: "key",
+			},
+			status:        http.StatusOK,
+			body:          `{"id":"<mailgun-id@acme.ch>","message":"Queued. Thank you."}`,
+			wantMessageID: "mailgun-id@acme.ch",
+			check: func(t *testing.T, req stubRequest) {
+				assert.Equal(t, "Basic YXBpOmtleQ==", req.header.Get("Authorization"))
+				assert.Equal(t, "application/x-www-form-urlencoded", req.header.Get("Content-Type"))
+				form, err := url.ParseQuery(string(req.body))
+				require.NoError(t, err)
+				assert.Equal(t, "ACME <from@acme.ch>", form.Get("from"))
+				assert.Equal(t, []string{"user@acme.ch"}, form["to"])
+				assert.Equal(t, "Hello & welcome", form.Get("text"))
+				assert.Equal(t, "<message@acme.ch>", form.Get("h:Message-Id"))
+			},
+		},
+		{
+			name: "ses, ok",
+			config: &Config{
+				Preset: domain.EmailHTTPPresetSES,
+				APIKey: "key",
+			},
+			status:        http.StatusOK,
+			body:          `{"MessageId":"ses-id"}`,
+			wantMessageID: "ses-id",
+			check: func(t *testing.T, req stubRequest) {
+				body := struct {
+					FromEmailAddress string
+					Destination      struct {
+						ToAddresses  []string
+						CcAddresses  []string
+						BccAddresses []string
+					}
+				}{}
+				require.NoError(t, json.Unmarshal(req.body, &body))
+				assert.Equal(t, "ACME <from@acme.ch>", body.FromEmailAddress)
+				assert.Equal(t, []string{"user@acme.ch"}, body.Destination.ToAddresses)
+				assert.Empty(t, body.Destination.CcAddresses)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := stubServer(t, tt.status, tt.header, tt.body)
+			tt.config.Endpoint = server.URL
+			tt.config.From = "from@acme.ch"
+			tt.config.FromName = "ACME"
+			channel, err := InitChannel(context.Background(), tt.config)
+			require.NoError(t, err)
+
+			msg := testMessage()
+			err = channel.HandleMessage(msg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, *requests, 1)
+			assert.Equal(t, tt.wantMessageID, msg.MessageID)
+			if tt.check != nil {
+				tt.check(t, (*requests)[0])
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr bool
+	}{
+		{
+			name: "sendgrid preset, ok",
+			config: &Config{
+				Preset: domain.EmailHTTPPresetSendGrid,
+				From:   "from@acme.ch",
+			},
+		},
+		{
+			name: "mailgun without endpoint, error",
+			config: &Config{
+				Preset: domain.EmailHTTPPresetMailgun,
+				From:   "from@acme.ch",
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom without body template, error",
+			config: &Config{
+				Preset:   domain.EmailHTTPPresetCustom,
+				Endpoint: "https://mail.acme.ch/send",
+				From:     "from@acme.ch",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid body template, error",
+			config: &Config{
+				Preset:       domain.EmailHTTPPresetCustom,
+				Endpoint:     "https://mail.acme.ch/send",
+				BodyTemplate: "{{.Subject",
+				From:         "from@acme.ch",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid status code, error",
+			config: &Config{
+				Preset:             domain.EmailHTTPPresetSendGrid,
+				SuccessStatusCodes: []int32{42},
+				From:               "from@acme.ch",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
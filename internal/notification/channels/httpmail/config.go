@@ -0,0 +1,79 @@
+package httpmail
+
+import (
+	"net/url"
+	"text/template"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type Config struct {
+	// Preset provides the defaults of all empty fields
+	Preset domain.EmailHTTPPreset
+	// Endpoint is the url the messages are posted to
+	Endpoint string
+	// ContentType of the request, application/json if empty
+	ContentType string
+	// BodyTemplate is the text/template of the request body, it's executed with the Data of the message
+	BodyTemplate string
+	// AuthHeader is the name of the header the APIKey is sent in
+	AuthHeader string
+	APIKey     string
+	// SuccessStatusCodes are the response codes of a sent message, any 2xx code if empty
+	SuccessStatusCodes []int32
+	// SuccessBodyContains must be part of the response body of a sent message, if set
+	SuccessBodyContains string
+	From                string
+	FromName            string
+}
+
+// withPreset returns a copy of the config with the defaults of the preset in its empty fields
+func (c *Config) withPreset() *Config {
+	p := presets[c.Preset]
+	config := *c
+	if config.Endpoint == "" {
+		config.Endpoint = p.endpoint
+	}
+	if config.ContentType == "" {
+		config.ContentType = p.contentType
+	}
+	if config.BodyTemplate == "" {
+		config.BodyTemplate = p.bodyTemplate
+	}
+	if config.AuthHeader == "" {
+		config.AuthHeader = p.authHeader
+	}
+	if len(config.SuccessStatusCodes) == 0 {
+		config.SuccessStatusCodes = p.successStatusCodes
+	}
+	return &config
+}
+
+// Validate checks if the config (completed by its preset) is able to send messages
+func (c *Config) Validate() error {
+	if !c.Preset.Valid() {
+		return caos_errs.ThrowInvalidArgument(nil, "HTTPM-Pr3st", "Errors.EmailHTTPConfig.PresetInvalid")
+	}
+	config := c.withPreset()
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return caos_errs.ThrowInvalidArgument(err, "HTTPM-En6pt", "Errors.EmailHTTPConfig.EndpointInvalid")
+	}
+	if _, err = parseBodyTemplate(config.BodyTemplate); err != nil || config.BodyTemplate == "" {
+		return caos_errs.ThrowInvalidArgument(err, "HTTPM-Tm8pl", "Errors.EmailHTTPConfig.BodyTemplateInvalid")
+	}
+	for _, code := range config.SuccessStatusCodes {
+		if code < 100 || code > 599 {
+			return caos_errs.ThrowInvalidArgument(nil, "HTTPM-St4ts", "Errors.EmailHTTPConfig.StatusCodeInvalid")
+		}
+	}
+	if c.From == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "HTTPM-Fr0mx", "Errors.Invalid.Argument")
+	}
+	return nil
+}
+
+func parseBodyTemplate(body string) (*template.Template, error) {
+	return template.New("body").Funcs(template.FuncMap{"json": toJSON}).Parse(body)
+}
@@ -0,0 +1,80 @@
+package httpmail
+
+import (
+	"encoding/base64"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeForm = "application/x-www-form-urlencoded"
+)
+
+type preset struct {
+	endpoint           string
+	contentType        string
+	bodyTemplate       string
+	authHeader         string
+	successStatusCodes []int32
+	// authValue formats the api key as value of the auth header
+	authValue func(apiKey string) string
+	// messageIDHeader or messageIDField (of a json response) contain the id the provider assigned to the message
+	messageIDHeader string
+	messageIDField  string
+}
+
+var presets = map[domain.EmailHTTPPreset]preset{
+	domain.EmailHTTPPresetCustom: {
+		contentType: contentTypeJSON,
+		authValue:   func(apiKey string) string { return apiKey },
+	},
+	domain.EmailHTTPPresetSendGrid: {
+		endpoint:           "https://api.sendgrid.com/v3/mail/send",
+		contentType:        contentTypeJSON,
+		bodyTemplate:       sendGridBody,
+		authHeader:         "Authorization",
+		successStatusCodes: []int32{202},
+		authValue:          bearer,
+		messageIDHeader:    "X-Message-Id",
+	},
+	domain.EmailHTTPPresetMailgun: {
+		contentType:        contentTypeForm,
+		bodyTemplate:       mailgunBody,
+		authHeader:         "Authorization",
+		successStatusCodes: []int32{200},
+		authValue: func(apiKey string) string {
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte("api:"+apiKey))
+		},
+		messageIDField: "id",
+	},
+	domain.EmailHTTPPresetSES: {
+		contentType:        contentTypeJSON,
+		bodyTemplate:       sesBody,
+		authHeader:         "Authorization",
+		successStatusCodes: []int32{200},
+		authValue:          bearer,
+		messageIDField:     "MessageId",
+	},
+}
+
+func bearer(apiKey string) string {
+	return "Bearer " + apiKey
+}
+
+const sendGridBody = `{"personalizations":[{"to":[{{range $i, $to := .To}}{{if $i}},{{end}}{"email":{{json $to}}}{{end}}]` +
+	`{{if .CC}},"cc":[{{range $i, $cc := .CC}}{{if $i}},{{end}}{"email":{{json $cc}}}{{end}}]{{end}}` +
+	`{{if .BCC}},"bcc":[{{range $i, $bcc := .BCC}}{{if $i}},{{end}}{"email":{{json $bcc}}}{{end}}]{{end}}}],` +
+	`"from":{"email":{{json .From}}{{if .FromName}},"name":{{json .FromName}}{{end}}},` +
+	`"subject":{{json .Subject}},` +
+	`"content":[{{if .Text}}{"type":"text/plain","value":{{json .Text}}},{{end}}{"type":"text/html","value":{{json .HTML}}}],` +
+	`"custom_args":{"message_id":{{json .MessageID}}}}`
+
+const mailgunBody = `from={{urlquery .Sender}}{{range .To}}&to={{urlquery .}}{{end}}{{range .CC}}&cc={{urlquery .}}{{end}}{{range .BCC}}&bcc={{urlquery .}}{{end}}` +
+	`&subject={{urlquery .Subject}}&html={{urlquery .HTML}}{{if .Text}}&text={{urlquery .Text}}{{end}}` +
+	`&h:Message-Id={{urlquery (printf "<%s>" .MessageID)}}`
+
+const sesBody = `{"FromEmailAddress":{{json .Sender}},` +
+	`"Destination":{"ToAddresses":{{json .To}},"CcAddresses":{{json .CC}},"BccAddresses":{{json .BCC}}},` +
+	`"Content":{"Simple":{"Subject":{"Data":{{json .Subject}},"Charset":"UTF-8"},` +
+	`"Body":{"Html":{"Data":{{json .HTML}},"Charset":"UTF-8"}{{if .Text}},"Text":{"Data":{{json .Text}},"Charset":"UTF-8"}{{end}}}}}}`
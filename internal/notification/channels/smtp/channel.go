@@ -17,33 +17,27 @@ import (
 var _ channels.NotificationChannel = (*Email)(nil)
 
 type Email struct {
-	smtpClient    *smtp.Client
+	ctx           context.Context
+	smtpConfig    SMTP
+	tls           bool
 	senderAddress string
 	senderName    string
 }
 
-func InitChannel(ctx context.Context, getSMTPConfig func(ctx context.Context) (*Config, error)) (*Email, error) {
-	smtpConfig, err := getSMTPConfig(ctx)
-	if err != nil {
-		return nil, err
-	}
-	client, err := smtpConfig.SMTP.connectToSMTP(smtpConfig.Tls)
-	if err != nil {
-		logging.New().WithError(err).Error("could not connect to smtp")
-		return nil, err
-	}
-
-	logging.New().Debug("successfully initialized smtp email channel")
-
+// InitChannel creates the channel of an SMTP server.
+// The connection to the server is established for every message,
+// so an unavailable server results in an error of the message.
+func InitChannel(ctx context.Context, smtpConfig *Config) *Email {
 	return &Email{
-		smtpClient:    client,
+		ctx:           ctx,
+		smtpConfig:    smtpConfig.SMTP,
+		tls:           smtpConfig.Tls,
 		senderName:    smtpConfig.FromName,
 		senderAddress: smtpConfig.From,
-	}, nil
+	}
 }
 
 func (email *Email) HandleMessage(message channels.Message) error {
-	defer email.smtpClient.Close()
 	emailMsg, ok := message.(*messages.Email)
 	if !ok {
 		return caos_errs.ThrowInternal(nil, "EMAIL-s8JLs", "message is not EmailMessage")
@@ -54,18 +48,31 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	}
 	emailMsg.SenderEmail = email.senderAddress
 	emailMsg.SenderName = email.senderName
+	if emailMsg.MessageID == "" {
+		messageID, err := messages.NewMessageID(email.senderAddress)
+		if err != nil {
+			return err
+		}
+		emailMsg.MessageID = messageID
+	}
+	smtpClient, err := email.smtpConfig.connectToSMTP(email.ctx, email.tls)
+	if err != nil {
+		logging.WithError(err).Warn("could not connect to smtp")
+		return err
+	}
+	defer smtpClient.Close()
 	// To && From
-	if err := email.smtpClient.Mail(emailMsg.SenderEmail); err != nil {
+	if err := smtpClient.Mail(emailMsg.SenderEmail); err != nil {
 		return caos_errs.ThrowInternalf(err, "EMAIL-s3is3", "could not set sender: %v", emailMsg.SenderEmail)
 	}
 	for _, recp := range append(append(emailMsg.Recipients, emailMsg.CC...), emailMsg.BCC...) {
-		if err := email.smtpClient.Rcpt(recp); err != nil {
+		if err := smtpClient.Rcpt(recp); err != nil {
 			return caos_errs.ThrowInternalf(err, "EMAIL-s4is4", "could not set recipient: %v", recp)
 		}
 	}
 
 	// Data
-	w, err := email.smtpClient.Data()
+	w, err := smtpClient.Data()
 	if err != nil {
 		return err
 	}
@@ -85,10 +92,10 @@ func (email *Email) HandleMessage(message channels.Message) error {
 		return err
 	}
 
-	return email.smtpClient.Quit()
+	return smtpClient.Quit()
 }
 
-func (smtpConfig SMTP) connectToSMTP(tlsRequired bool) (client *smtp.Client, err error) {
+func (smtpConfig SMTP) connectToSMTP(ctx context.Context, tlsRequired bool) (client *smtp.Client, err error) {
 	host, _, err := net.SplitHostPort(smtpConfig.Host)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "EMAIL-spR56", "could not split host and port for connect to smtp")
@@ -103,8 +110,9 @@ func (smtpConfig SMTP) connectToSMTP(tlsRequired bool) (client *smtp.Client, err
 		return nil, err
 	}
 
-	err = smtpConfig.smtpAuth(client, host)
+	err = smtpConfig.smtpAuth(ctx, client, host)
 	if err != nil {
+		client.Close()
 		return nil, err
 	}
 	return client, nil
@@ -151,12 +159,19 @@ func (smtpConfig SMTP) getSMPTClientWithStartTls(host string) (*smtp.Client, err
 	return client, nil
 }
 
-func (smtpConfig SMTP) smtpAuth(client *smtp.Client, host string) error {
+func (smtpConfig SMTP) smtpAuth(ctx context.Context, client *smtp.Client, host string) error {
 	if !smtpConfig.HasAuth() {
 		return nil
 	}
 	// Auth
 	auth := smtp.PlainAuth("", smtpConfig.User, smtpConfig.Password, host)
+	if smtpConfig.XOAuth2 != nil {
+		token, err := smtpConfig.XOAuth2.token(ctx, smtpConfig.Password)
+		if err != nil {
+			return err
+		}
+		auth = &xoauth2Auth{user: smtpConfig.User, token: token}
+	}
 	err := client.Auth(auth)
 	if err != nil {
 		return caos_errs.ThrowInternalf(err, "EMAIL-s9kfs", "could not add smtp auth for user %s", smtpConfig.User)
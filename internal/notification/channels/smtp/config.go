@@ -11,6 +11,15 @@ type SMTP struct {
 	Host     string
 	User     string
 	Password string
+	// XOAuth2 is set if the server requires the XOAUTH2 authentication,
+	// the password is then used as client secret
+	XOAuth2 *XOAuth2
+}
+
+type XOAuth2 struct {
+	TokenEndpoint string
+	ClientID      string
+	Scopes        []string
 }
 
 func (smtp *SMTP) HasAuth() bool {
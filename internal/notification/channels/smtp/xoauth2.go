@@ -0,0 +1,49 @@
+package smtp
+
+import (
+	"context"
+	"net/smtp"
+
+	"golang.org/x/oauth2/clientcredentials"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ smtp.Auth = (*xoauth2Auth)(nil)
+
+// xoauth2Auth implements the XOAUTH2 mechanism used by providers which disabled the basic authentication
+// https://developers.google.com/gmail/imap/xoauth2-protocol#the_sasl_xoauth2_mechanism
+type xoauth2Auth struct {
+	user  string
+	token string
+}
+
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS {
+		return "", nil, caos_errs.ThrowInternal(nil, "EMAIL-Rb4yq", "xoauth2 requires an encrypted connection")
+	}
+	return "XOAUTH2", []byte("user=" + a.user + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+func (a *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		// the server only continues the exchange to describe the failed authentication
+		return nil, caos_errs.ThrowInternalf(nil, "EMAIL-Ux7pd", "xoauth2 authentication failed: %s", fromServer)
+	}
+	return nil, nil
+}
+
+// token requests an access token with the client credentials grant
+func (x *XOAuth2) token(ctx context.Context, clientSecret string) (string, error) {
+	config := &clientcredentials.Config{
+		ClientID:     x.ClientID,
+		ClientSecret: clientSecret,
+		TokenURL:     x.TokenEndpoint,
+		Scopes:       x.Scopes,
+	}
+	token, err := config.Token(ctx)
+	if err != nil {
+		return "", caos_errs.ThrowInternal(err, "EMAIL-Gk2qa", "could not get xoauth2 token")
+	}
+	return token.AccessToken, nil
+}
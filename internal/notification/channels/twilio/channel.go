@@ -1,6 +1,9 @@
 package twilio
 
 import (
+	"context"
+	"net/url"
+
 	"github.com/kevinburke/twilio-go"
 	"github.com/zitadel/logging"
 
@@ -23,10 +26,18 @@ func InitChannel(config Config) channels.NotificationChannel {
 		if err != nil {
 			return err
 		}
-		m, err := client.Messages.SendMessage(twilioMsg.SenderPhoneNumber, twilioMsg.RecipientPhoneNumber, content, nil)
+		data := url.Values{}
+		data.Set("Body", content)
+		data.Set("From", twilioMsg.SenderPhoneNumber)
+		data.Set("To", twilioMsg.RecipientPhoneNumber)
+		if twilioMsg.StatusCallbackURL != "" {
+			data.Set("StatusCallback", twilioMsg.StatusCallbackURL)
+		}
+		m, err := client.Messages.Create(context.Background(), data)
 		if err != nil {
 			return caos_errs.ThrowInternal(err, "TWILI-osk3S", "could not send message")
 		}
+		twilioMsg.ProviderMessageID = m.Sid
 		logging.WithFields("message_sid", m.Sid, "status", m.Status).Debug("sms sent")
 		return nil
 	})
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+)
+
+// GetEmailConfigs reads the email provider configs in the order they are used for sending:
+// the SMTP override of the organisation (if any), the active transactional email APIs of the iam
+// and the SMTP configs of the iam ordered by priority
+func (n *NotificationQueries) GetEmailConfigs(ctx context.Context, orgID string) ([]*senders.EmailConfig, error) {
+	configs := make([]*senders.EmailConfig, 0, 3)
+	if orgID != "" {
+		orgConfigs, err := n.smtpConfigsOf(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range orgConfigs {
+			configs = append(configs, &senders.EmailConfig{SMTP: config})
+		}
+	}
+	httpConfigs, err := n.activeEmailHTTPConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range httpConfigs {
+		configs = append(configs, &senders.EmailConfig{HTTP: config})
+	}
+	instanceConfigs, err := n.smtpConfigsOf(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range instanceConfigs {
+		configs = append(configs, &senders.EmailConfig{SMTP: config})
+	}
+	return configs, nil
+}
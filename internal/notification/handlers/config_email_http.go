@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpmail"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// activeEmailHTTPConfigs reads the active transactional email APIs of the iam in the order they were added
+func (n *NotificationQueries) activeEmailHTTPConfigs(ctx context.Context) ([]*httpmail.Config, error) {
+	active, err := query.NewEmailHTTPConfigStateSearchQuery(domain.EmailHTTPConfigStateActive)
+	if err != nil {
+		return nil, err
+	}
+	result, err := n.SearchEmailHTTPConfigs(ctx, &query.EmailHTTPConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			SortingColumn: query.EmailHTTPConfigColumnCreationDate,
+			Asc:           true,
+		},
+		Queries: []query.SearchQuery{active},
+	})
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]*httpmail.Config, len(result.Configs))
+	for i, config := range result.Configs {
+		var apiKey string
+		if config.APIKey != nil {
+			apiKey, err = crypto.DecryptString(config.APIKey, n.SMTPPasswordCrypto)
+			if err != nil {
+				return nil, err
+			}
+		}
+		configs[i] = &httpmail.Config{
+			Preset:              config.Preset,
+			Endpoint:            config.Endpoint,
+			ContentType:         config.ContentType,
+			BodyTemplate:        config.BodyTemplate,
+			AuthHeader:          config.AuthHeader,
+			APIKey:              apiKey,
+			SuccessStatusCodes:  config.SuccessStatusCodes,
+			SuccessBodyContains: config.SuccessBodyContains,
+			From:                config.SenderAddress,
+			FromName:            config.SenderName,
+		}
+	}
+	return configs, nil
+}
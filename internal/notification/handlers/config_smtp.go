@@ -3,22 +3,38 @@ package handlers
 import (
 	"context"
 
-	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
-// GetSMTPConfig reads the iam SMTP provider config
-func (n *NotificationQueries) GetSMTPConfig(ctx context.Context) (*smtp.Config, error) {
-	config, err := n.SMTPConfigByAggregateID(ctx, authz.GetInstance(ctx).InstanceID())
+// smtpConfigsOf reads the SMTP configs of the organisation or the instance ordered by priority
+func (n *NotificationQueries) smtpConfigsOf(ctx context.Context, resourceOwner string) ([]*smtp.Config, error) {
+	ownerQuery, err := query.NewSMTPConfigResourceOwnerSearchQuery(resourceOwner)
 	if err != nil {
 		return nil, err
 	}
+	result, err := n.SearchSMTPConfigs(ctx, &query.SMTPConfigSearchQueries{Queries: []query.SearchQuery{ownerQuery}})
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]*smtp.Config, len(result.SMTPConfigs))
+	for i, config := range result.SMTPConfigs {
+		configs[i], err = n.smtpConfigToChannel(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+func (n *NotificationQueries) smtpConfigToChannel(config *query.SMTPConfig) (*smtp.Config, error) {
 	password, err := crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
 	if err != nil {
 		return nil, err
 	}
-	return &smtp.Config{
+	channelConfig := &smtp.Config{
 		From:     config.SenderAddress,
 		FromName: config.SenderName,
 		Tls:      config.TLS,
@@ -27,5 +43,13 @@ func (n *NotificationQueries) GetSMTPConfig(ctx context.Context) (*smtp.Config,
 			User:     config.User,
 			Password: password,
 		},
-	}, nil
+	}
+	if config.AuthType == domain.SMTPAuthTypeXOAuth2 && config.XOAuth2 != nil {
+		channelConfig.SMTP.XOAuth2 = &smtp.XOAuth2{
+			TokenEndpoint: config.XOAuth2.TokenEndpoint,
+			ClientID:      config.XOAuth2.ClientID,
+			Scopes:        config.XOAuth2.Scopes,
+		}
+	}
+	return channelConfig, nil
 }
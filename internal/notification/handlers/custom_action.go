@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	notificationChannelEmail = "email"
+	notificationChannelSMS   = "sms"
+)
+
+// withPreSendActions runs the actions of the pre notification send trigger before the notification is sent.
+// The actions are able to add arguments for the texts of the message or to cancel the notification.
+func (u *userNotifier) withPreSendActions(ctx context.Context, notifyUser *query.NotifyUser, channel string, notify types.Notify) types.Notify {
+	return func(url string, args map[string]interface{}, messageType string, allowUnverifiedNotificationChannel bool) error {
+		if args == nil {
+			args = make(map[string]interface{})
+		}
+		var cancelled bool
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("notification",
+					actions.SetFields("messageType", messageType),
+					actions.SetFields("channel", channel),
+				),
+				actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+					return func(call goja.FunctionCall) goja.Value {
+						user, err := u.queries.GetUserByID(ctx, true, notifyUser.ID, false)
+						if err != nil {
+							panic(err)
+						}
+						return object.UserFromQuery(c, user)
+					}
+				}),
+			),
+		)
+		apiFields := actions.WithAPIFields(
+			actions.SetFields("v1",
+				actions.SetFields("notification",
+					actions.SetFields("setArg", func(key string, value interface{}) {
+						if _, ok := args[key]; ok {
+							logging.WithFields("key", key).Info("notification argument already exists")
+							return
+						}
+						args[key] = value
+					}),
+					actions.SetFields("cancel", func() {
+						cancelled = true
+					}),
+				),
+			),
+		)
+		err := actions.RunTrigger(ctx, u.queries.GetActiveActionsByFlowAndTriggerType, domain.FlowTypeNotification, domain.TriggerTypePreNotificationSend, notifyUser.ResourceOwner, ctxFields, apiFields)
+		if err != nil {
+			return err
+		}
+		if cancelled {
+			return nil
+		}
+		return notify(url, args, messageType, allowUnverifiedNotificationChannel)
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// recordDelivery adds every notification handed to a provider to the delivery log.
+// Failing to record a delivery does not fail the notification, as it would be sent again.
+func (u *userNotifier) recordDelivery(ctx context.Context, event eventstore.Event) types.DeliveryRecorder {
+	return func(d *types.Delivery) {
+		notificationDelivery := &domain.NotificationDelivery{
+			UserID:                event.Aggregate().ID,
+			Channel:               d.Channel,
+			Recipient:             d.Recipient,
+			MessageType:           d.MessageType,
+			ProviderMessageID:     d.ProviderMessageID,
+			TriggeringAggregateID: event.Aggregate().ID,
+			TriggeringSequence:    event.Sequence(),
+			TriggeringEventType:   string(event.Type()),
+		}
+		if d.Err != nil {
+			notificationDelivery.Error = d.Err.Error()
+		}
+		_, err := u.commands.AddNotificationDelivery(ctx, event.Aggregate().ResourceOwner, notificationDelivery)
+		logging.WithFields("instance", event.Aggregate().InstanceID, "user", event.Aggregate().ID, "event", event.Type()).
+			OnError(err).Error("could not record notification delivery")
+	}
+}
+
+// reduceResendRequested handles the user event of a failed notification again,
+// which sends the notification unless it's already sent or expired.
+func (u *userNotifier) reduceResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.ResendRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ut4sd", "reduce.wrong.event.type %s", notification.ResendRequestedEventType)
+	}
+	reduce := u.userEventReducer(eventstore.EventType(e.TriggeringEventType))
+	if reduce == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	triggeringEvent, err := u.queries.userEvent(ctx, e.Aggregate().InstanceID, e.TriggeringAggregateID, e.TriggeringSequence)
+	if err != nil {
+		return nil, err
+	}
+	// the statement of the triggering event must not be executed, as the projection is at the sequence of the resend event
+	if _, err = reduce(triggeringEvent); err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (u *userNotifier) userEventReducer(eventType eventstore.EventType) handler.Reduce {
+	for _, aggregateReducer := range u.reducers() {
+		if aggregateReducer.Aggregate != user.AggregateType {
+			continue
+		}
+		for _, eventReducer := range aggregateReducer.EventRedusers {
+			if eventReducer.Event == eventType {
+				return eventReducer.Reduce
+			}
+		}
+	}
+	return nil
+}
+
+func (n *NotificationQueries) userEvent(ctx context.Context, instanceID, userID string, sequence uint64) (eventstore.Event, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(instanceID).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(userID).
+			SequenceGreater(sequence-1).
+			SequenceLess(sequence+1).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.ThrowNotFound(nil, "HANDL-Fd8qa", "Errors.Notification.Delivery.NotFound")
+	}
+	return events[0], nil
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"context"
+	"net"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+var (
+	loginSucceededEventTypes = []eventstore.EventType{
+		user.UserV1PasswordCheckSucceededType,
+		user.HumanPasswordCheckSucceededType,
+		user.HumanPasswordlessTokenCheckSucceededType,
+		user.UserIDPLoginCheckSucceededType,
+		user.HumanMagicLinkCodeCheckSucceededType,
+	}
+	mfaAddedEventTypes = []eventstore.EventType{
+		user.UserV1MFAOTPVerifiedType,
+		user.HumanMFAOTPVerifiedType,
+		user.HumanU2FTokenVerifiedType,
+		user.HumanPasswordlessTokenVerifiedType,
+		user.HumanRecoveryCodesAddedType,
+	}
+	mfaRemovedEventTypes = []eventstore.EventType{
+		user.UserV1MFAOTPRemovedType,
+		user.HumanMFAOTPRemovedType,
+		user.HumanU2FTokenRemovedType,
+		user.HumanPasswordlessTokenRemovedType,
+		user.HumanRecoveryCodesRemovedType,
+	}
+	emailEventTypes = []eventstore.EventType{
+		user.UserV1AddedType,
+		user.UserV1RegisteredType,
+		user.UserV1EmailChangedType,
+		user.HumanAddedType,
+		user.HumanRegisteredType,
+		user.HumanEmailChangedType,
+	}
+)
+
+// securityNotificationRecipient returns the user the notification is sent to and additional template arguments.
+// If no user is returned, the notification is skipped.
+type securityNotificationRecipient func(ctx context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, map[string]interface{}, error)
+
+func (u *userNotifier) reduceNewDeviceLogin(event eventstore.Event) (*handler.Statement, error) {
+	info := authRequestInfoOfLoginEvent(event)
+	if info == nil {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	return u.sendSecurityNotification(event, domain.NewDeviceLoginMessageType,
+		func(policy *query.NotificationPolicy) bool { return policy.NewDeviceLogin },
+		func(ctx context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, map[string]interface{}, error) {
+			isNew, err := u.queries.IsNewDeviceLogin(ctx, event, info)
+			if err != nil || !isNew {
+				return nil, nil, err
+			}
+			args := make(map[string]interface{})
+			if info.BrowserInfo != nil {
+				args["UserAgent"] = info.UserAgent
+				args["RemoteIP"] = info.RemoteIP.String()
+			}
+			return notifyUser, args, nil
+		},
+	)
+}
+
+func (u *userNotifier) reduceMFAChanged(event eventstore.Event) (*handler.Statement, error) {
+	messageType := domain.MFAAddedMessageType
+	if containsEventType(mfaRemovedEventTypes, event.Type()) {
+		messageType = domain.MFARemovedMessageType
+	} else if !containsEventType(mfaAddedEventTypes, event.Type()) {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mf4c2", "reduce.wrong.event.type %s", event.Type())
+	}
+	return u.sendSecurityNotification(event, messageType,
+		func(policy *query.NotificationPolicy) bool { return policy.MFAChange },
+		defaultSecurityNotificationRecipient,
+	)
+}
+
+func (u *userNotifier) reduceEmailChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ec7m2", "reduce.wrong.event.type %s", user.HumanEmailChangedType)
+	}
+	return u.sendSecurityNotification(e, domain.EmailChangedMessageType,
+		func(policy *query.NotificationPolicy) bool { return policy.EmailChange },
+		func(ctx context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, map[string]interface{}, error) {
+			previousEmail, err := u.queries.PreviousEmail(ctx, e)
+			if err != nil {
+				return nil, nil, err
+			}
+			if previousEmail == "" || previousEmail == e.EmailAddress {
+				return nil, nil, nil
+			}
+			// the notification must reach the old address, so the owner is informed if the account was taken over
+			recipient := *notifyUser
+			recipient.LastEmail = string(previousEmail)
+			recipient.VerifiedEmail = string(previousEmail)
+			return &recipient, map[string]interface{}{"NewEmail": string(e.EmailAddress)}, nil
+		},
+	)
+}
+
+func (u *userNotifier) reducePhoneChanged(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanPhoneChangedEvent, *user.HumanPhoneRemovedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ph5c1", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPhoneChangedType, user.HumanPhoneRemovedType})
+	}
+	return u.sendSecurityNotification(event, domain.PhoneChangedMessageType,
+		func(policy *query.NotificationPolicy) bool { return policy.PhoneChange },
+		defaultSecurityNotificationRecipient,
+	)
+}
+
+func (u *userNotifier) reduceAccountLocked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLockedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Al9k3", "reduce.wrong.event.type %s", user.UserLockedType)
+	}
+	// users locked manually by an administrator are not notified
+	if !e.ByLockoutPolicy {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	return u.sendSecurityNotification(e, domain.AccountLockedMessageType,
+		func(policy *query.NotificationPolicy) bool { return policy.AccountLocked },
+		defaultSecurityNotificationRecipient,
+	)
+}
+
+func (u *userNotifier) reducePersonalAccessTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.PersonalAccessTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pt2a8", "reduce.wrong.event.type %s", user.PersonalAccessTokenAddedType)
+	}
+	return u.sendSecurityNotification(e, domain.PersonalAccessTokenAddedMessageType,
+		func(policy *query.NotificationPolicy) bool { return policy.PersonalAccessTokenAdded },
+		func(_ context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, map[string]interface{}, error) {
+			return notifyUser, map[string]interface{}{"Expiration": e.Expiration}, nil
+		},
+	)
+}
+
+func (u *userNotifier) sendSecurityNotification(
+	event eventstore.Event,
+	messageType string,
+	enabled func(*query.NotificationPolicy) bool,
+	recipient securityNotificationRecipient,
+) (*handler.Statement, error) {
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event,
+		map[string]interface{}{"messageType": messageType, "eventSequence": event.Sequence()},
+		user.HumanSecurityNotificationSentType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	notificationPolicy, err := u.queries.NotificationPolicyByOrg(ctx, true, event.Aggregate().ResourceOwner, false)
+	if errors.IsNotFound(err) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !enabled(notificationPolicy) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID, false)
+	if errors.IsNotFound(err) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	notifyUser, args, err := recipient(ctx, notifyUser)
+	if err != nil {
+		return nil, err
+	}
+	// machine users and users without email address can't be notified
+	if notifyUser == nil || notifyUser.LastEmail == "" {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, event.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.MailTemplateByOrg(ctx, event.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetEmailConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			event,
+			u.recordDelivery(ctx, event),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
+	).SendSecurityNotification(notifyUser, origin, messageType, args)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.HumanSecurityNotificationSent(ctx, event.Aggregate().ResourceOwner, event.Aggregate().ID, messageType, event.Sequence())
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(event), nil
+}
+
+func defaultSecurityNotificationRecipient(_ context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, map[string]interface{}, error) {
+	return notifyUser, nil, nil
+}
+
+// IsNewDeviceLogin checks if the user agent or the network of the login was not used in any previous login of the user.
+// The first login of a user is never considered as new.
+func (n *NotificationQueries) IsNewDeviceLogin(ctx context.Context, event eventstore.Event, info *user.AuthRequestInfo) (bool, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			SequenceLess(event.Sequence()).
+			EventTypes(loginSucceededEventTypes...).
+			Builder(),
+	)
+	if err != nil {
+		return false, err
+	}
+	if len(events) == 0 {
+		return false, nil
+	}
+	// information missing on the login can't be compared and is therefore treated as known
+	knownUserAgent := info.UserAgentID == ""
+	knownNetwork := info.BrowserInfo == nil || info.RemoteIP == nil
+	for _, previous := range events {
+		previousInfo := authRequestInfoOfLoginEvent(previous)
+		if previousInfo == nil {
+			continue
+		}
+		if !knownUserAgent && previousInfo.UserAgentID == info.UserAgentID {
+			knownUserAgent = true
+		}
+		if !knownNetwork && previousInfo.BrowserInfo != nil &&
+			sameNetwork(info.RemoteIP, previousInfo.RemoteIP) {
+			knownNetwork = true
+		}
+	}
+	return !knownUserAgent || !knownNetwork, nil
+}
+
+// PreviousEmail returns the email address of the user before the passed event
+func (n *NotificationQueries) PreviousEmail(ctx context.Context, event eventstore.Event) (domain.EmailAddress, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			SequenceLess(event.Sequence()).
+			EventTypes(emailEventTypes...).
+			Builder(),
+	)
+	if err != nil {
+		return "", err
+	}
+	var email domain.EmailAddress
+	for _, previous := range events {
+		switch e := previous.(type) {
+		case *user.HumanAddedEvent:
+			email = e.EmailAddress
+		case *user.HumanRegisteredEvent:
+			email = e.EmailAddress
+		case *user.HumanEmailChangedEvent:
+			email = e.EmailAddress
+		}
+	}
+	return email, nil
+}
+
+func authRequestInfoOfLoginEvent(event eventstore.Event) *user.AuthRequestInfo {
+	switch e := event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent:
+		return e.AuthRequestInfo
+	case *user.HumanPasswordlessCheckSucceededEvent:
+		return e.AuthRequestInfo
+	case *user.UserIDPCheckSucceededEvent:
+		return e.AuthRequestInfo
+	case *user.HumanMagicLinkCodeCheckSucceededEvent:
+		return e.AuthRequestInfo
+	}
+	return nil
+}
+
+// sameNetwork compares the /24 (IPv4) or /64 (IPv6) networks of the addresses
+func sameNetwork(a, b net.IP) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a4, b4 := a.To4(), b.To4(); a4 != nil || b4 != nil {
+		if a4 == nil || b4 == nil {
+			return false
+		}
+		mask := net.CIDRMask(24, 32)
+		return a4.Mask(mask).Equal(b4.Mask(mask))
+	}
+	mask := net.CIDRMask(64, 128)
+	return a.Mask(mask).Equal(b.Mask(mask))
+}
+
+func containsEventType(eventTypes []eventstore.EventType, eventType eventstore.EventType) bool {
+	for _, t := range eventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -2,24 +2,32 @@ package handlers
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
 func (n *NotificationQueries) GetTranslatorWithOrgTexts(ctx context.Context, orgID, textType string) (*i18n.Translator, error) {
-	translator, err := i18n.NewTranslator(n.statikDir, n.GetDefaultLanguage(ctx), "")
+	return TranslatorWithOrgTexts(ctx, n.Queries, n.statikDir, orgID, textType)
+}
+
+// TranslatorWithOrgTexts returns a translator of the notification texts (in the statikDir),
+// which are overwritten by the custom texts of the instance and the organization
+func TranslatorWithOrgTexts(ctx context.Context, queries *query.Queries, statikDir http.FileSystem, orgID, textType string) (*i18n.Translator, error) {
+	translator, err := i18n.NewTranslator(statikDir, queries.GetDefaultLanguage(ctx), "")
 	if err != nil {
 		return nil, err
 	}
 
-	allCustomTexts, err := n.CustomTextListByTemplate(ctx, authz.GetInstance(ctx).InstanceID(), textType, false)
+	allCustomTexts, err := queries.CustomTextListByTemplate(ctx, authz.GetInstance(ctx).InstanceID(), textType, false)
 	if err != nil {
 		return translator, nil
 	}
-	customTexts, err := n.CustomTextListByTemplate(ctx, orgID, textType, false)
+	customTexts, err := queries.CustomTextListByTemplate(ctx, orgID, textType, false)
 	if err != nil {
 		return translator, nil
 	}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func (u *userNotifier) reduceUserLifecycleWarningAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLifecycleWarningAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lw4a2", "reduce.wrong.event.type %s", user.UserLifecycleWarningAddedType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil, user.UserLifecycleWarningSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if errors.IsNotFound(err) {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// machine users and users without email address can't be warned
+	if notifyUser.LastEmail == "" {
+		return crdb.NewNoOpStatement(e), nil
+	}
+
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	messageType := domain.UserDeactivationWarningMessageType
+	if e.Action == domain.UserLifecycleActionWarnDeletion {
+		messageType = domain.UserDeletionWarningMessageType
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetEmailConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			e,
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
+	).SendUserLifecycleWarning(notifyUser, origin, e.Action, e.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.UserLifecycleWarningSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/delivery"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -13,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
@@ -106,6 +108,119 @@ func (u *userNotifier) reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: u.reducePasswordChanged,
 				},
+				{
+					Event:  user.HumanMagicLinkCodeAddedType,
+					Reduce: u.reduceMagicLinkCodeAdded,
+				},
+				{
+					Event:  user.HumanRecoveryCodeCheckSucceededType,
+					Reduce: u.reduceRecoveryCodeUsed,
+				},
+				{
+					Event:  user.UserV1PasswordCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.HumanMagicLinkCodeCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.UserV1MFAOTPVerifiedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanRecoveryCodesAddedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.UserV1MFAOTPRemovedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.HumanRecoveryCodesRemovedType,
+					Reduce: u.reduceMFAChanged,
+				},
+				{
+					Event:  user.UserV1EmailChangedType,
+					Reduce: u.reduceEmailChanged,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: u.reduceEmailChanged,
+				},
+				{
+					Event:  user.UserV1PhoneChangedType,
+					Reduce: u.reducePhoneChanged,
+				},
+				{
+					Event:  user.HumanPhoneChangedType,
+					Reduce: u.reducePhoneChanged,
+				},
+				{
+					Event:  user.UserV1PhoneRemovedType,
+					Reduce: u.reducePhoneChanged,
+				},
+				{
+					Event:  user.HumanPhoneRemovedType,
+					Reduce: u.reducePhoneChanged,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceAccountLocked,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: u.reducePersonalAccessTokenAdded,
+				},
+				{
+					Event:  user.UserLifecycleWarningAddedType,
+					Reduce: u.reduceUserLifecycleWarningAdded,
+				},
+			},
+		},
+		{
+			Aggregate: notification.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  notification.ResendRequestedEventType,
+					Reduce: u.reduceResendRequested,
+				},
 			},
 		},
 	}
@@ -153,19 +268,22 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 	if err != nil {
 		return nil, err
 	}
-	err = types.SendEmail(
-		ctx,
-		string(template.Template),
-		translator,
-		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
-		colors,
-		u.assetsPrefix(ctx),
-		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetEmailConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			e,
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
 	).SendUserInitCode(notifyUser, origin, code)
 	if err != nil {
 		return nil, err
@@ -222,19 +340,22 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 	if err != nil {
 		return nil, err
 	}
-	err = types.SendEmail(
-		ctx,
-		string(template.Template),
-		translator,
-		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
-		colors,
-		u.assetsPrefix(ctx),
-		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetEmailConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			e,
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
 	).SendEmailVerificationCode(notifyUser, origin, code, e.URLTemplate)
 	if err != nil {
 		return nil, err
@@ -291,33 +412,40 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 	if err != nil {
 		return nil, err
 	}
-	notify := types.SendEmail(
-		ctx,
-		string(template.Template),
-		translator,
-		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
-		colors,
-		u.assetsPrefix(ctx),
-		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
-	)
-	if e.NotificationType == domain.NotificationTypeSms {
-		notify = types.SendSMSTwilio(
+	notify := u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
 			ctx,
+			template,
 			translator,
 			notifyUser,
-			u.queries.GetTwilioConfig,
+			u.queries.GetEmailConfigs,
 			u.queries.GetFileSystemProvider,
 			u.queries.GetLogProvider,
 			colors,
 			u.assetsPrefix(ctx),
 			e,
-			u.metricSuccessfulDeliveriesSMS,
-			u.metricFailedDeliveriesSMS,
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
+	)
+	if e.NotificationType == domain.NotificationTypeSms {
+		notify = u.withPreSendActions(ctx, notifyUser, notificationChannelSMS,
+			types.SendSMSTwilio(
+				ctx,
+				translator,
+				notifyUser,
+				u.queries.GetTwilioConfig,
+				u.queries.GetFileSystemProvider,
+				u.queries.GetLogProvider,
+				colors,
+				u.assetsPrefix(ctx),
+				e,
+				delivery.TwilioStatusCallbackURL(origin),
+				u.recordDelivery(ctx, e),
+				u.metricSuccessfulDeliveriesSMS,
+				u.metricFailedDeliveriesSMS,
+			),
 		)
 	}
 	err = notify.SendPasswordCode(notifyUser, origin, code, e.URLTemplate)
@@ -368,19 +496,22 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 	if err != nil {
 		return nil, err
 	}
-	err = types.SendEmail(
-		ctx,
-		string(template.Template),
-		translator,
-		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
-		colors,
-		u.assetsPrefix(ctx),
-		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetEmailConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			e,
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
 	).SendDomainClaimed(notifyUser, origin, e.UserName)
 	if err != nil {
 		return nil, err
@@ -435,19 +566,22 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 	if err != nil {
 		return nil, err
 	}
-	err = types.SendEmail(
-		ctx,
-		string(template.Template),
-		translator,
-		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
-		colors,
-		u.assetsPrefix(ctx),
-		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetEmailConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			e,
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
 	).SendPasswordlessRegistrationLink(notifyUser, origin, code, e.ID, e.URLTemplate)
 	if err != nil {
 		return nil, err
@@ -459,6 +593,76 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 	return crdb.NewNoOpStatement(e), nil
 }
 
+func (u *userNotifier) reduceMagicLinkCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanMagicLinkCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ja4Ei", "reduce.wrong.event.type %s", user.HumanMagicLinkCodeAddedType)
+	}
+	if e.CodeReturned {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, map[string]interface{}{"id": e.ID}, user.HumanMagicLinkCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.MagicLinkMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetEmailConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			e,
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
+	).SendMagicLink(notifyUser, origin, code, e.ID, e.URLTemplate, e.AuthRequestID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.HumanMagicLinkCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.ID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
 func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPasswordChangedEvent)
 	if !ok {
@@ -505,27 +709,93 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 		if err != nil {
 			return nil, err
 		}
-		err = types.SendEmail(
+		err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+			types.SendEmail(
+				ctx,
+				template,
+				translator,
+				notifyUser,
+				u.queries.GetEmailConfigs,
+				u.queries.GetFileSystemProvider,
+				u.queries.GetLogProvider,
+				colors,
+				u.assetsPrefix(ctx),
+				e,
+				u.recordDelivery(ctx, e),
+				u.metricSuccessfulDeliveriesEmail,
+				u.metricFailedDeliveriesEmail,
+			),
+		).SendPasswordChange(notifyUser, origin)
+		if err != nil {
+			return nil, err
+		}
+		err = u.commands.PasswordChangeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (u *userNotifier) reduceRecoveryCodeUsed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodeCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pq8vk", "reduce.wrong.event.type %s", user.HumanRecoveryCodeCheckSucceededType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"index": e.Index}, user.HumanRecoveryCodeUsedSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.RecoveryCodeUsedMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelEmail,
+		types.SendEmail(
 			ctx,
-			string(template.Template),
+			template,
 			translator,
 			notifyUser,
-			u.queries.GetSMTPConfig,
+			u.queries.GetEmailConfigs,
 			u.queries.GetFileSystemProvider,
 			u.queries.GetLogProvider,
 			colors,
 			u.assetsPrefix(ctx),
 			e,
+			u.recordDelivery(ctx, e),
 			u.metricSuccessfulDeliveriesEmail,
 			u.metricFailedDeliveriesEmail,
-		).SendPasswordChange(notifyUser, origin)
-		if err != nil {
-			return nil, err
-		}
-		err = u.commands.PasswordChangeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
-		if err != nil {
-			return nil, err
-		}
+		),
+	).SendRecoveryCodeUsed(notifyUser, origin)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.HumanRecoveryCodeUsedSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.Index)
+	if err != nil {
+		return nil, err
 	}
 	return crdb.NewNoOpStatement(e), nil
 }
@@ -567,18 +837,22 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	if err != nil {
 		return nil, err
 	}
-	err = types.SendSMSTwilio(
-		ctx,
-		translator,
-		notifyUser,
-		u.queries.GetTwilioConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
-		colors,
-		u.assetsPrefix(ctx),
-		e,
-		u.metricSuccessfulDeliveriesSMS,
-		u.metricFailedDeliveriesSMS,
+	err = u.withPreSendActions(ctx, notifyUser, notificationChannelSMS,
+		types.SendSMSTwilio(
+			ctx,
+			translator,
+			notifyUser,
+			u.queries.GetTwilioConfig,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			e,
+			delivery.TwilioStatusCallbackURL(origin),
+			u.recordDelivery(ctx, e),
+			u.metricSuccessfulDeliveriesSMS,
+			u.metricFailedDeliveriesSMS,
+		),
 	).SendPhoneVerificationCode(notifyUser, origin, code)
 	if err != nil {
 		return nil, err
@@ -1,10 +1,17 @@
 package messages
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
 	"regexp"
 	"strings"
 
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 )
@@ -17,13 +24,17 @@ var (
 var _ channels.Message = (*Email)(nil)
 
 type Email struct {
-	Recipients      []string
-	BCC             []string
-	CC              []string
-	SenderEmail     string
-	SenderName      string
-	Subject         string
-	Content         string
+	Recipients  []string
+	BCC         []string
+	CC          []string
+	SenderEmail string
+	SenderName  string
+	Subject     string
+	Content     string
+	// TextContent is the optional plain text alternative of an html Content
+	TextContent string
+	// MessageID is set as Message-ID header and used to match the delivery status reported by the provider
+	MessageID       string
 	TriggeringEvent eventstore.Event
 }
 
@@ -37,23 +48,62 @@ func (msg *Email) GetContent() (string, error) {
 	headers["Return-Path"] = msg.SenderEmail
 	headers["To"] = strings.Join(msg.Recipients, ", ")
 	headers["Cc"] = strings.Join(msg.CC, ", ")
+	if msg.MessageID != "" {
+		headers["Message-ID"] = "<" + msg.MessageID + ">"
+	}
 
 	message := ""
 	for k, v := range headers {
 		message += fmt.Sprintf("%s: %s"+lineBreak, k, v)
 	}
 
+	subject := "Subject: " + mime.QEncoding.Encode("UTF-8", msg.Subject) + lineBreak
+	if msg.TextContent != "" && isHTML(msg.Content) {
+		body, err := msg.multipartContent()
+		if err != nil {
+			return "", err
+		}
+		return message + subject + body, nil
+	}
+
 	//default mime-type is html
-	mime := "MIME-version: 1.0;" + lineBreak + "Content-Type: text/html; charset=\"UTF-8\";" + lineBreak + lineBreak
+	mimeType := "MIME-version: 1.0;" + lineBreak + "Content-Type: text/html; charset=\"UTF-8\";" + lineBreak + lineBreak
 	if !isHTML(msg.Content) {
-		mime = "MIME-version: 1.0;" + lineBreak + "Content-Type: text/plain; charset=\"UTF-8\";" + lineBreak + lineBreak
+		mimeType = "MIME-version: 1.0;" + lineBreak + "Content-Type: text/plain; charset=\"UTF-8\";" + lineBreak + lineBreak
 	}
-	subject := "Subject: " + msg.Subject + lineBreak
-	message += subject + mime + lineBreak + msg.Content
+	message += subject + mimeType + lineBreak + msg.Content
 
 	return message, nil
 }
 
+// multipartContent returns the mime headers and the body of a multipart/alternative message
+// with the plain text and the html content (in order of increasing preference)
+func (msg *Email) multipartContent() (string, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=\"UTF-8\"", content: msg.TextContent},
+		{contentType: "text/html; charset=\"UTF-8\"", content: msg.Content},
+	}
+	for _, part := range parts {
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return "", err
+		}
+		if _, err = w.Write([]byte(part.content)); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	header := "MIME-version: 1.0;" + lineBreak + "Content-Type: multipart/alternative; boundary=\"" + writer.Boundary() + "\"" + lineBreak + lineBreak
+	return header + body.String(), nil
+}
+
 func (msg *Email) GetTriggeringEvent() eventstore.Event {
 	return msg.TriggeringEvent
 }
@@ -61,3 +111,13 @@ func (msg *Email) GetTriggeringEvent() eventstore.Event {
 func isHTML(input string) bool {
 	return isHTMLRgx.MatchString(input)
 }
+
+// NewMessageID creates a unique id for the Message-ID header in the domain of the sender address
+func NewMessageID(senderAddress string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", caos_errs.ThrowInternal(err, "EMAIL-Qz3ne", "could not generate message id")
+	}
+	domain := senderAddress[strings.LastIndex(senderAddress, "@")+1:]
+	return hex.EncodeToString(random) + "@" + domain, nil
+}
@@ -11,7 +11,11 @@ type SMS struct {
 	SenderPhoneNumber    string
 	RecipientPhoneNumber string
 	Content              string
-	TriggeringEvent      eventstore.Event
+	// StatusCallbackURL is called by the provider on changes of the delivery status
+	StatusCallbackURL string
+	// ProviderMessageID is set by the channel to the id the provider assigned to the message
+	ProviderMessageID string
+	TriggeringEvent   eventstore.Event
 }
 
 func (msg *SMS) GetContent() (string, error) {
@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	statik_fs "github.com/rakyll/statik/fs"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+var (
+	previewStatikFS     http.FileSystem
+	previewStatikFSErr  error
+	previewStatikFSOnce sync.Once
+)
+
+// PreviewEmail renders the mail of the message type as it would be sent to a user of the organization
+// with the language, using the mail templates, message texts and branding of the organization
+// (or the ones of the instance, if the organization has none).
+// If previewBranding is set, the branding, which is not yet activated, is used.
+func PreviewEmail(ctx context.Context, queries *query.Queries, assetsPrefix, orgID, messageType, lang string, previewBranding bool) (*types.Email, error) {
+	if !domain.IsMailMessageType(messageType) {
+		return nil, errors.ThrowInvalidArgument(nil, "NOTIF-Ns8ce", "Errors.Notification.MessageTypeInvalid")
+	}
+	previewStatikFSOnce.Do(func() {
+		previewStatikFS, previewStatikFSErr = statik_fs.NewWithNamespace("notification")
+	})
+	if previewStatikFSErr != nil {
+		return nil, errors.ThrowInternal(previewStatikFSErr, "NOTIF-Vn3dq", "Errors.Internal")
+	}
+	mailTemplate, err := queries.MailTemplateByOrg(ctx, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	var labelPolicy *query.LabelPolicy
+	if previewBranding {
+		labelPolicy, err = queries.PreviewLabelPolicyByOrg(ctx, orgID)
+	} else {
+		labelPolicy, err = queries.ActiveLabelPolicyByOrg(ctx, orgID, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	translator, err := handlers.TranslatorWithOrgTexts(ctx, queries, previewStatikFS, orgID, messageType)
+	if err != nil {
+		return nil, err
+	}
+	return types.PreviewEmail(translator, mailTemplate, labelPolicy, assetsPrefix, http_utils.OriginFromCtx(ctx), messageType, lang)
+}
@@ -8,33 +8,57 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpmail"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 )
 
-const smtpSpanName = "smtp.NotificationChannel"
+const emailSpanName = "email.NotificationChannel"
 
+// EmailConfig is the config of an email provider,
+// either SMTP for an SMTP server or HTTP for a transactional email API is set
+type EmailConfig struct {
+	SMTP *smtp.Config
+	HTTP *httpmail.Config
+}
+
+// EmailChannels chains the email providers and the debug channels.
+// The providers are used in the order of the configs, the next provider is only used if the previous one failed.
 func EmailChannels(
 	ctx context.Context,
-	emailConfig func(ctx context.Context) (*smtp.Config, error),
+	emailConfigs func(ctx context.Context) ([]*EmailConfig, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
-	channels := make([]channels.NotificationChannel, 0, 3)
-	p, err := smtp.InitChannel(ctx, emailConfig)
+	configs, err := emailConfigs(ctx)
 	logging.WithFields(
 		"instance", authz.GetInstance(ctx).InstanceID(),
-	).OnError(err).Debug("initializing SMTP channel failed")
-	if err == nil {
+	).OnError(err).Debug("reading email configs failed")
+	providers := make([]channels.NotificationChannel, 0, len(configs))
+	for _, config := range configs {
+		if config.SMTP != nil {
+			providers = append(providers, smtp.InitChannel(ctx, config.SMTP))
+		}
+		if config.HTTP != nil {
+			provider, err := httpmail.InitChannel(ctx, config.HTTP)
+			if err != nil {
+				logging.WithFields("instance", authz.GetInstance(ctx).InstanceID()).WithError(err).Warn("invalid http email config")
+				continue
+			}
+			providers = append(providers, provider)
+		}
+	}
+	channels := make([]channels.NotificationChannel, 0, 3)
+	if len(providers) > 0 {
 		channels = append(
 			channels,
 			instrumenting.Wrap(
 				ctx,
-				p,
-				smtpSpanName,
+				failoverChannels(providers...),
+				emailSpanName,
 				successMetricName,
 				failureMetricName,
 			),
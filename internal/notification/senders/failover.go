@@ -0,0 +1,30 @@
+package senders
+
+import (
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+var _ channels.NotificationChannel = (*Failover)(nil)
+
+type Failover struct {
+	channels []channels.NotificationChannel
+}
+
+func failoverChannels(channel ...channels.NotificationChannel) *Failover {
+	return &Failover{channels: channel}
+}
+
+// HandleMessage sends the message to the first channel which handles it without an error
+// channels are tried in the same order they were provided to failoverChannels()
+// the error of the last channel is returned if no channel succeeded
+func (f *Failover) HandleMessage(message channels.Message) (err error) {
+	for i := range f.channels {
+		if err = f.channels[i].HandleMessage(message); err == nil {
+			return nil
+		}
+		logging.WithFields("channel", i).WithError(err).Warn("notification channel failed")
+	}
+	return err
+}
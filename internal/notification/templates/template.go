@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"io/ioutil"
 	"net/http"
+	text_template "text/template"
 )
 
 const (
@@ -20,6 +21,16 @@ func GetParsedTemplate(mailhtml string, contentData interface{}) (string, error)
 	return ParseTemplateText(template, contentData)
 }
 
+// GetParsedTextTemplate parses the plain text (or subject) template and the message texts it contains,
+// other than GetParsedTemplate the content is not html escaped
+func GetParsedTextTemplate(mailtext string, contentData interface{}) (string, error) {
+	text, err := parseTextTemplate(mailtext, contentData)
+	if err != nil {
+		return "", err
+	}
+	return parseTextTemplate(text, contentData)
+}
+
 func ParseTemplateFile(mailhtml string, data interface{}) (string, error) {
 	tmpl, err := template.New("tmpl").Parse(mailhtml)
 	if err != nil {
@@ -37,6 +48,18 @@ func ParseTemplateText(text string, data interface{}) (string, error) {
 	return parseTemplate(template, data)
 }
 
+func parseTextTemplate(text string, data interface{}) (string, error) {
+	tmpl, err := text_template.New("text").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 func parseTemplate(template *template.Template, data interface{}) (string, error) {
 	buf := new(bytes.Buffer)
 	if err := template.Execute(buf, data); err != nil {
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// Delivery is the outcome of handing a notification to the provider
+type Delivery struct {
+	Channel           domain.NotificationType
+	Recipient         string
+	MessageType       string
+	ProviderMessageID string
+	Err               error
+}
+
+// DeliveryRecorder is called after every attempt to send a notification
+type DeliveryRecorder func(delivery *Delivery)
+
+func (r DeliveryRecorder) record(delivery *Delivery) {
+	if r == nil {
+		return
+	}
+	r(delivery)
+}
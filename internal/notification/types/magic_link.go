@@ -0,0 +1,24 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendMagicLink(user *query.NotifyUser, origin, code, codeID, urlTmpl, authRequestID string) error {
+	var url string
+	if urlTmpl == "" {
+		url = domain.MagicLinkCodeLink(origin+login.HandlerPrefix+login.EndpointMagicLink, user.ID, user.ResourceOwner, codeID, code, authRequestID)
+	} else {
+		var buf strings.Builder
+		if err := domain.RenderMagicLinkURLTemplate(&buf, urlTmpl, user.ID, user.ResourceOwner, codeID, code); err != nil {
+			return err
+		}
+		url = buf.String()
+	}
+
+	return notify(url, nil, domain.MagicLinkMessageType, false)
+}
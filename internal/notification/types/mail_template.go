@@ -0,0 +1,46 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// Email is the rendered subject and content of a mail
+type Email struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// RenderEmail renders the mail of the message type with the (translated) template data.
+// The templates set for the message type take precedence over the template of the mail template policy,
+// the plain text alternative is only rendered if a text template is set.
+func RenderEmail(mailTemplate *query.MailTemplate, messageType string, data templates.TemplateData) (_ *Email, err error) {
+	email := &Email{
+		Subject: data.Subject,
+	}
+	htmlTemplate := string(mailTemplate.Template)
+	messageTemplate := mailTemplate.MessageTemplate(messageType)
+	if messageTemplate != nil {
+		if messageTemplate.Subject != "" {
+			email.Subject, err = templates.GetParsedTextTemplate(messageTemplate.Subject, data)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(messageTemplate.HTML) > 0 {
+			htmlTemplate = string(messageTemplate.HTML)
+		}
+		if len(messageTemplate.Text) > 0 {
+			email.Text, err = templates.GetParsedTextTemplate(string(messageTemplate.Text), data)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	email.HTML, err = templates.GetParsedTemplate(htmlTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	return email, nil
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func TestRenderEmail(t *testing.T) {
+	data := templates.TemplateData{
+		Subject: "Initialize User",
+		Text:    "Hello & welcome",
+	}
+	mailTemplate := &query.MailTemplate{
+		Template: []byte("<html><p>{{.Text}}</p></html>"),
+		MessageTemplates: []*query.MailMessageTemplate{
+			{
+				MessageType: domain.InitCodeMessageType,
+				Subject:     "{{.Subject}} - ACME",
+				Text:        []byte("{{.Text}}"),
+			},
+			{
+				MessageType: domain.DomainClaimedMessageType,
+				HTML:        []byte("<html><h1>{{.Subject}}</h1></html>"),
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		messageType string
+		want        *Email
+	}{
+		{
+			name:        "policy template",
+			messageType: domain.PasswordResetMessageType,
+			want: &Email{
+				Subject: "Initialize User",
+				HTML:    "<html><p>Hello &amp; welcome</p></html>",
+			},
+		},
+		{
+			name:        "message type subject and text",
+			messageType: domain.InitCodeMessageType,
+			want: &Email{
+				Subject: "Initialize User - ACME",
+				HTML:    "<html><p>Hello &amp; welcome</p></html>",
+				Text:    "Hello & welcome",
+			},
+		},
+		{
+			name:        "message type html",
+			messageType: domain.DomainClaimedMessageType,
+			want: &Email{
+				Subject: "Initialize User",
+				HTML:    "<html><h1>Initialize User</h1></html>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderEmail(mailTemplate, tt.messageType, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,10 +7,9 @@ import (
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
-	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
-	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
@@ -23,15 +22,16 @@ type Notify func(
 
 func SendEmail(
 	ctx context.Context,
-	mailhtml string,
+	mailTemplate *query.MailTemplate,
 	translator *i18n.Translator,
 	user *query.NotifyUser,
-	emailConfig func(ctx context.Context) (*smtp.Config, error),
+	emailConfigs func(ctx context.Context, orgID string) ([]*senders.EmailConfig, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	colors *query.LabelPolicy,
 	assetsPrefix string,
 	triggeringEvent eventstore.Event,
+	recordDelivery DeliveryRecorder,
 	successMetricName,
 	failureMetricName string,
 ) Notify {
@@ -43,20 +43,21 @@ func SendEmail(
 	) error {
 		args = mapNotifyUserToArgs(user, args)
 		data := GetTemplateData(translator, args, assetsPrefix, url, messageType, user.PreferredLanguage.String(), colors)
-		template, err := templates.GetParsedTemplate(mailhtml, data)
+		email, err := RenderEmail(mailTemplate, messageType, data)
 		if err != nil {
 			return err
 		}
 		return generateEmail(
 			ctx,
 			user,
-			data.Subject,
-			template,
-			emailConfig,
+			email,
+			messageType,
+			emailConfigs,
 			getFileSystemProvider,
 			getLogProvider,
 			allowUnverifiedNotificationChannel,
 			triggeringEvent,
+			recordDelivery,
 			successMetricName,
 			failureMetricName,
 		)
@@ -73,6 +74,8 @@ func SendSMSTwilio(
 	colors *query.LabelPolicy,
 	assetsPrefix string,
 	triggeringEvent eventstore.Event,
+	statusCallbackURL string,
+	recordDelivery DeliveryRecorder,
 	successMetricName,
 	failureMetricName string,
 ) Notify {
@@ -88,11 +91,14 @@ func SendSMSTwilio(
 			ctx,
 			user,
 			data.Text,
+			messageType,
 			twilioConfig,
 			getFileSystemProvider,
 			getLogProvider,
 			allowUnverifiedNotificationChannel,
 			triggeringEvent,
+			statusCallbackURL,
+			recordDelivery,
 			successMetricName,
 			failureMetricName,
 		)
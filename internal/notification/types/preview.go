@@ -0,0 +1,47 @@
+package types
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// PreviewEmail renders the mail of the message type with sample user data,
+// as it would be sent to a user with the preferred language
+func PreviewEmail(
+	translator *i18n.Translator,
+	mailTemplate *query.MailTemplate,
+	labelPolicy *query.LabelPolicy,
+	assetsPrefix,
+	url,
+	messageType,
+	lang string,
+) (*Email, error) {
+	args := mapNotifyUserToArgs(previewUser(), map[string]interface{}{
+		"Code":         "ABC123",
+		"Domain":       "example.com",
+		"TempUsername": "john.doe@temporary",
+	})
+	data := GetTemplateData(translator, args, assetsPrefix, url, messageType, lang, labelPolicy)
+	return RenderEmail(mailTemplate, messageType, data)
+}
+
+func previewUser() *query.NotifyUser {
+	now := time.Now()
+	return &query.NotifyUser{
+		CreationDate:       now,
+		ChangeDate:         now,
+		Username:           "john.doe",
+		LoginNames:         []string{"john.doe@example.com"},
+		PreferredLoginName: "john.doe@example.com",
+		FirstName:          "John",
+		LastName:           "Doe",
+		NickName:           "John",
+		DisplayName:        "John Doe",
+		LastEmail:          "john.doe@example.com",
+		VerifiedEmail:      "john.doe@example.com",
+		LastPhone:          "+41 71 000 00 00",
+		VerifiedPhone:      "+41 71 000 00 00",
+	}
+}
@@ -0,0 +1,16 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// SendSecurityNotification informs the user about a security relevant change on the account.
+// The mail is sent to the last known address, as the user might not have verified the new one (yet).
+func (notify Notify) SendSecurityNotification(user *query.NotifyUser, origin, messageType string, args map[string]interface{}) error {
+	url := console.LoginHintLink(origin, user.PreferredLoginName)
+	if args == nil {
+		args = make(map[string]interface{})
+	}
+	return notify(url, args, messageType, true)
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	"html"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
-	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/query"
@@ -17,21 +17,22 @@ import (
 func generateEmail(
 	ctx context.Context,
 	user *query.NotifyUser,
-	subject,
-	content string,
-	smtpConfig func(ctx context.Context) (*smtp.Config, error),
+	email *Email,
+	messageType string,
+	emailConfigs func(ctx context.Context, orgID string) ([]*senders.EmailConfig, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	lastEmail bool,
 	triggeringEvent eventstore.Event,
+	recordDelivery DeliveryRecorder,
 	successMetricName,
 	failureMetricName string,
 ) error {
-	content = html.UnescapeString(content)
 	message := &messages.Email{
 		Recipients:      []string{user.VerifiedEmail},
-		Subject:         subject,
-		Content:         content,
+		Subject:         email.Subject,
+		Content:         html.UnescapeString(email.HTML),
+		TextContent:     html.UnescapeString(email.Text),
 		TriggeringEvent: triggeringEvent,
 	}
 	if lastEmail {
@@ -40,7 +41,9 @@ func generateEmail(
 
 	channelChain, err := senders.EmailChannels(
 		ctx,
-		smtpConfig,
+		func(ctx context.Context) ([]*senders.EmailConfig, error) {
+			return emailConfigs(ctx, user.ResourceOwner)
+		},
 		getFileSystemProvider,
 		getLogProvider,
 		successMetricName,
@@ -51,9 +54,18 @@ func generateEmail(
 	}
 
 	if channelChain.Len() == 0 {
-		return errors.ThrowPreconditionFailed(nil, "MAIL-83nof", "Errors.Notification.Channels.NotPresent")
+		err = errors.ThrowPreconditionFailed(nil, "MAIL-83nof", "Errors.Notification.Channels.NotPresent")
+	} else {
+		err = channelChain.HandleMessage(message)
 	}
-	return channelChain.HandleMessage(message)
+	recordDelivery.record(&Delivery{
+		Channel:           domain.NotificationTypeEmail,
+		Recipient:         message.Recipients[0],
+		MessageType:       messageType,
+		ProviderMessageID: message.MessageID,
+		Err:               err,
+	})
+	return err
 }
 
 func mapNotifyUserToArgs(user *query.NotifyUser, args map[string]interface{}) map[string]interface{} {
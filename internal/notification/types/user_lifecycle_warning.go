@@ -0,0 +1,22 @@
+package types
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// SendUserLifecycleWarning informs the user about the upcoming deactivation or deletion of the inactive account.
+func (notify Notify) SendUserLifecycleWarning(user *query.NotifyUser, origin string, action domain.UserLifecycleAction, dueDate time.Time) error {
+	url := console.LoginHintLink(origin, user.PreferredLoginName)
+	messageType := domain.UserDeactivationWarningMessageType
+	if action == domain.UserLifecycleActionWarnDeletion {
+		messageType = domain.UserDeletionWarningMessageType
+	}
+	args := map[string]interface{}{
+		"DueDate": dueDate.Format("2006-01-02"),
+	}
+	return notify(url, args, messageType, true)
+}
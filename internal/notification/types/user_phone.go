@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
@@ -19,11 +20,14 @@ func generateSms(
 	ctx context.Context,
 	user *query.NotifyUser,
 	content string,
+	messageType string,
 	getTwilioProvider func(ctx context.Context) (*twilio.Config, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	lastPhone bool,
 	triggeringEvent eventstore.Event,
+	statusCallbackURL string,
+	recordDelivery DeliveryRecorder,
 	successMetricName,
 	failureMetricName string,
 ) error {
@@ -36,6 +40,7 @@ func generateSms(
 		SenderPhoneNumber:    number,
 		RecipientPhoneNumber: user.VerifiedPhone,
 		Content:              content,
+		StatusCallbackURL:    statusCallbackURL,
 		TriggeringEvent:      triggeringEvent,
 	}
 	if lastPhone {
@@ -53,7 +58,16 @@ func generateSms(
 	logging.OnError(err).Error("could not create sms channel")
 
 	if channelChain.Len() == 0 {
-		return errors.ThrowPreconditionFailed(nil, "PHONE-w8nfow", "Errors.Notification.Channels.NotPresent")
+		err = errors.ThrowPreconditionFailed(nil, "PHONE-w8nfow", "Errors.Notification.Channels.NotPresent")
+	} else {
+		err = channelChain.HandleMessage(message)
 	}
-	return channelChain.HandleMessage(message)
+	recordDelivery.record(&Delivery{
+		Channel:           domain.NotificationTypeSms,
+		Recipient:         message.RecipientPhoneNumber,
+		MessageType:       messageType,
+		ProviderMessageID: message.ProviderMessageID,
+		Err:               err,
+	})
+	return err
 }
@@ -73,6 +73,10 @@ var (
 		name:  projection.ActionOwnerRemovedCol,
 		table: actionTable,
 	}
+	ActionColumnRuntime = Column{
+		name:  projection.ActionRuntimeCol,
+		table: actionTable,
+	}
 )
 
 type Actions struct {
@@ -92,6 +96,7 @@ type Action struct {
 	Script        string
 	timeout       time.Duration
 	AllowedToFail bool
+	Runtime       domain.ActionRuntime
 }
 
 func (a *Action) Timeout() time.Duration {
@@ -192,6 +197,7 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnRuntime.identifier(),
 			countColumn.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -211,6 +217,7 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 					&action.Script,
 					&action.timeout,
 					&action.AllowedToFail,
+					&action.Runtime,
 					&count,
 				)
 				if err != nil {
@@ -244,6 +251,7 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnRuntime.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Action, error) {
@@ -259,6 +267,7 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&action.Script,
 				&action.timeout,
 				&action.AllowedToFail,
+				&action.Runtime,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
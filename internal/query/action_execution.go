@@ -0,0 +1,196 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/sirupsen/logrus"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// the execution logs are written by the execution emitter of the logstore,
+// the records of a run are grouped by the run id stored in the metadata
+const (
+	executionLogsTable      = "logstore.execution"
+	executionLogDateCol     = "log_date"
+	executionTookCol        = "took"
+	executionMessageCol     = "message"
+	executionLogLevelCol    = "loglevel"
+	executionInstanceIDCol  = "instance_id"
+	executionActionIDCol    = "action_id"
+	executionRunIDSelector  = "metadata->>'runId'"
+	executionTookMillisExpr = "(EXTRACT(EPOCH FROM " + executionTookCol + ") * 1000)::INT8"
+
+	// executionLogWindow is the maximum duration between the first and the last record of a run
+	executionLogWindow  = time.Minute
+	maxActionExecutions = 100
+)
+
+type ActionExecutions struct {
+	Executions []*ActionExecution
+}
+
+// ActionExecution is a single run of an action
+type ActionExecution struct {
+	RunID     string
+	Finished  time.Time
+	Took      time.Duration
+	Succeeded bool
+	Logs      []*ActionExecutionLog
+}
+
+type ActionExecutionLog struct {
+	LogDate  time.Time
+	Message  string
+	LogLevel logrus.Level
+}
+
+// SearchActionExecutions returns the latest finished runs of the action including their log records, newest first
+func (q *Queries) SearchActionExecutions(ctx context.Context, actionID string, limit uint64) (_ *ActionExecutions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if limit == 0 || limit > maxActionExecutions {
+		limit = maxActionExecutions
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+
+	query, scan := prepareActionExecutionsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		executionInstanceIDCol: instanceID,
+		executionActionIDCol:   actionID,
+	}).Limit(limit).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ooR8e", "Errors.Query.SQLStatment")
+	}
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Eex7a", "Errors.Internal")
+	}
+	executions, err := scan(rows)
+	if err != nil || len(executions.Executions) == 0 {
+		return executions, err
+	}
+
+	runIDs := make([]string, len(executions.Executions))
+	for i, execution := range executions.Executions {
+		runIDs[i] = execution.RunID
+	}
+	oldest := executions.Executions[len(executions.Executions)-1].Finished
+	logsQuery, scanLogs := prepareActionExecutionLogsQuery(ctx, q.client)
+	stmt, args, err = logsQuery.Where(sq.And{
+		sq.Eq{
+			executionInstanceIDCol: instanceID,
+			executionActionIDCol:   actionID,
+			executionRunIDSelector: runIDs,
+		},
+		sq.GtOrEq{executionLogDateCol: oldest.Add(-executionLogWindow)},
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Vah6u", "Errors.Query.SQLStatment")
+	}
+	rows, err = q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ahm4a", "Errors.Internal")
+	}
+	logs, err := scanLogs(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, execution := range executions.Executions {
+		execution.Logs = logs[execution.RunID]
+	}
+	return executions, nil
+}
+
+// prepareActionExecutionsQuery selects the last record of the runs, which contains the duration of the run
+func prepareActionExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*ActionExecutions, error)) {
+	return sq.Select(
+			executionRunIDSelector,
+			executionLogDateCol,
+			executionTookMillisExpr,
+			executionLogLevelCol).
+			From(executionLogsTable + db.Timetravel(call.Took(ctx))).
+			Where(sq.NotEq{executionTookCol: nil}).
+			OrderBy(executionLogDateCol + " DESC").
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ActionExecutions, error) {
+			executions := make([]*ActionExecution, 0)
+			for rows.Next() {
+				var (
+					runID    sql.NullString
+					took     int64
+					logLevel uint32
+				)
+				execution := new(ActionExecution)
+				err := rows.Scan(
+					&runID,
+					&execution.Finished,
+					&took,
+					&logLevel,
+				)
+				if err != nil {
+					return nil, err
+				}
+				// records written before the introduction of the run id can't be grouped
+				if !runID.Valid {
+					continue
+				}
+				execution.RunID = runID.String
+				execution.Took = time.Duration(took) * time.Millisecond
+				execution.Succeeded = logrus.Level(logLevel) != logrus.ErrorLevel
+				executions = append(executions, execution)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Iek5u", "Errors.Query.CloseRows")
+			}
+
+			return &ActionExecutions{Executions: executions}, nil
+		}
+}
+
+// prepareActionExecutionLogsQuery returns the log records grouped by the run id
+func prepareActionExecutionLogsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (map[string][]*ActionExecutionLog, error)) {
+	return sq.Select(
+			executionRunIDSelector,
+			executionLogDateCol,
+			executionMessageCol,
+			executionLogLevelCol).
+			From(executionLogsTable + db.Timetravel(call.Took(ctx))).
+			OrderBy(executionLogDateCol).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (map[string][]*ActionExecutionLog, error) {
+			logs := make(map[string][]*ActionExecutionLog)
+			for rows.Next() {
+				var (
+					runID    string
+					logLevel uint32
+				)
+				log := new(ActionExecutionLog)
+				err := rows.Scan(
+					&runID,
+					&log.LogDate,
+					&log.Message,
+					&logLevel,
+				)
+				if err != nil {
+					return nil, err
+				}
+				log.LogLevel = logrus.Level(logLevel)
+				logs[runID] = append(logs[runID], log)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-jai4O", "Errors.Query.CloseRows")
+			}
+
+			return logs, nil
+		}
+}
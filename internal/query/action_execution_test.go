@@ -0,0 +1,205 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	actionExecutionsQuery = `SELECT metadata->>'runId',` +
+		` log_date,` +
+		` (EXTRACT(EPOCH FROM took) * 1000)::INT8,` +
+		` loglevel` +
+		` FROM logstore.execution` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` WHERE took IS NOT NULL` +
+		` ORDER BY log_date DESC`
+	actionExecutionsCols = []string{
+		"runId",
+		"log_date",
+		"took",
+		"loglevel",
+	}
+	actionExecutionLogsQuery = `SELECT metadata->>'runId',` +
+		` log_date,` +
+		` message,` +
+		` loglevel` +
+		` FROM logstore.execution` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY log_date`
+	actionExecutionLogsCols = []string{
+		"runId",
+		"log_date",
+		"message",
+		"loglevel",
+	}
+)
+
+func Test_ActionExecutionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionExecutionsQuery no result",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(actionExecutionsQuery),
+					nil,
+					nil,
+				),
+			},
+			object: &ActionExecutions{Executions: []*ActionExecution{}},
+		},
+		{
+			name:    "prepareActionExecutionsQuery multiple results",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(actionExecutionsQuery),
+					actionExecutionsCols,
+					[][]driver.Value{
+						{
+							"run2",
+							testNow,
+							int64(150),
+							uint32(logrus.ErrorLevel),
+						},
+						{
+							nil,
+							testNow,
+							int64(10),
+							uint32(logrus.InfoLevel),
+						},
+						{
+							"run1",
+							testNow,
+							int64(20),
+							uint32(logrus.InfoLevel),
+						},
+					},
+				),
+			},
+			object: &ActionExecutions{
+				Executions: []*ActionExecution{
+					{
+						RunID:     "run2",
+						Finished:  testNow,
+						Took:      150 * time.Millisecond,
+						Succeeded: false,
+					},
+					{
+						RunID:     "run1",
+						Finished:  testNow,
+						Took:      20 * time.Millisecond,
+						Succeeded: true,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionExecutionsQuery sql err",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(actionExecutionsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareActionExecutionLogsQuery multiple results",
+			prepare: prepareActionExecutionLogsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(actionExecutionLogsQuery),
+					actionExecutionLogsCols,
+					[][]driver.Value{
+						{
+							"run1",
+							testNow,
+							"action run started",
+							uint32(logrus.InfoLevel),
+						},
+						{
+							"run2",
+							testNow,
+							"action run started",
+							uint32(logrus.InfoLevel),
+						},
+						{
+							"run1",
+							testNow,
+							"action run succeeded",
+							uint32(logrus.InfoLevel),
+						},
+					},
+				),
+			},
+			object: map[string][]*ActionExecutionLog{
+				"run1": {
+					{
+						LogDate:  testNow,
+						Message:  "action run started",
+						LogLevel: logrus.InfoLevel,
+					},
+					{
+						LogDate:  testNow,
+						Message:  "action run succeeded",
+						LogLevel: logrus.InfoLevel,
+					},
+				},
+				"run2": {
+					{
+						LogDate:  testNow,
+						Message:  "action run started",
+						LogLevel: logrus.InfoLevel,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionExecutionLogsQuery sql err",
+			prepare: prepareActionExecutionLogsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(actionExecutionLogsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
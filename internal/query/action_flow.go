@@ -179,6 +179,7 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 			ActionColumnScript.identifier(),
 			ActionColumnAllowedToFail.identifier(),
 			ActionColumnTimeout.identifier(),
+			ActionColumnRuntime.identifier(),
 		).
 			From(flowsTriggersTable.name).
 			LeftJoin(join(ActionColumnID, FlowsTriggersColumnActionID) + db.Timetravel(call.Took(ctx))).
@@ -198,6 +199,7 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 					&action.Script,
 					&action.AllowedToFail,
 					&action.timeout,
+					&action.Runtime,
 				)
 				if err != nil {
 					return nil, err
@@ -225,6 +227,7 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 			ActionColumnScript.identifier(),
 			ActionColumnAllowedToFail.identifier(),
 			ActionColumnTimeout.identifier(),
+			ActionColumnRuntime.identifier(),
 			FlowsTriggersColumnTriggerType.identifier(),
 			FlowsTriggersColumnTriggerSequence.identifier(),
 			FlowsTriggersColumnFlowType.identifier(),
@@ -252,6 +255,7 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					actionScript        sql.NullString
 					actionAllowedToFail sql.NullBool
 					actionTimeout       sql.NullInt64
+					actionRuntime       sql.NullInt32
 
 					triggerType     domain.TriggerType
 					triggerSequence int
@@ -267,6 +271,7 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					&actionScript,
 					&actionAllowedToFail,
 					&actionTimeout,
+					&actionRuntime,
 					&triggerType,
 					&triggerSequence,
 					&flow.Type,
@@ -291,6 +296,7 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					Script:        actionScript.String,
 					AllowedToFail: actionAllowedToFail.Bool,
 					timeout:       time.Duration(actionTimeout.Int64),
+					Runtime:       domain.ActionRuntime(actionRuntime.Int32),
 				})
 			}
 
@@ -16,16 +16,17 @@ import (
 )
 
 var (
-	prepareFlowStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout,` +
+	prepareFlowStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.runtime,` +
 		` projections.flow_triggers2.trigger_type,` +
 		` projections.flow_triggers2.trigger_sequence,` +
 		` projections.flow_triggers2.flow_type,` +
@@ -33,7 +34,7 @@ var (
 		` projections.flow_triggers2.sequence,` +
 		` projections.flow_triggers2.resource_owner` +
 		` FROM projections.flow_triggers2` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers2.action_id = projections.actions3.id AND projections.flow_triggers2.instance_id = projections.actions3.instance_id` +
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers2.action_id = projections.actions4.id AND projections.flow_triggers2.instance_id = projections.actions4.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareFlowCols = []string{
 		"id",
@@ -46,6 +47,7 @@ var (
 		"script",
 		"allowed_to_fail",
 		"timeout",
+		"runtime",
 		// flow
 		"trigger_type",
 		"trigger_sequence",
@@ -55,18 +57,19 @@ var (
 		"resource_owner",
 	}
 
-	prepareTriggerActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout` +
+	prepareTriggerActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.runtime` +
 		` FROM projections.flow_triggers2` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers2.action_id = projections.actions3.id AND projections.flow_triggers2.instance_id = projections.actions3.instance_id` +
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers2.action_id = projections.actions4.id AND projections.flow_triggers2.instance_id = projections.actions4.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 
 	prepareTriggerActionCols = []string{
@@ -80,6 +83,7 @@ var (
 		"script",
 		"allowed_to_fail",
 		"timeout",
+		"runtime",
 	}
 
 	prepareFlowTypeStmt = `SELECT projections.flow_triggers2.flow_type` +
@@ -140,6 +144,7 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionRuntimeJavaScript,
 							domain.TriggerTypePreCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -194,6 +199,7 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionRuntimeJavaScript,
 							domain.TriggerTypePreCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -212,6 +218,7 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							false,
 							5000000000,
+							domain.ActionRuntimeJavaScript,
 							domain.TriggerTypePostCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -280,6 +287,7 @@ func Test_FlowPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							domain.TriggerTypePostCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -348,6 +356,7 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionRuntimeJavaScript,
 						},
 					},
 				),
@@ -386,6 +395,7 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionRuntimeJavaScript,
 						},
 						{
 							"action-id-2",
@@ -398,6 +408,7 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							false,
 							5000000000,
+							domain.ActionRuntimeJavaScript,
 						},
 					},
 				),
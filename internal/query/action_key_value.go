@@ -0,0 +1,165 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type ActionKeyValues struct {
+	SearchResponse
+	Entries []*ActionKeyValue
+}
+
+type ActionKeyValue struct {
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	Key           string
+	Value         []byte
+}
+
+type ActionKeyValueSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+var (
+	actionKeyValueTable = table{
+		name:          projection.ActionKeyValueProjectionTable,
+		instanceIDCol: projection.ActionKeyValueColumnInstanceID,
+	}
+	ActionKeyValueOrgIDCol = Column{
+		name:  projection.ActionKeyValueColumnOrgID,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueKeyCol = Column{
+		name:  projection.ActionKeyValueColumnKey,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueValueCol = Column{
+		name:  projection.ActionKeyValueColumnValue,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueCreationDateCol = Column{
+		name:  projection.ActionKeyValueColumnCreationDate,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueChangeDateCol = Column{
+		name:  projection.ActionKeyValueColumnChangeDate,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueSequenceCol = Column{
+		name:  projection.ActionKeyValueColumnSequence,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueResourceOwnerCol = Column{
+		name:  projection.ActionKeyValueColumnResourceOwner,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueInstanceIDCol = Column{
+		name:  projection.ActionKeyValueColumnInstanceID,
+		table: actionKeyValueTable,
+	}
+	ActionKeyValueOwnerRemovedCol = Column{
+		name:  projection.ActionKeyValueColumnOwnerRemoved,
+		table: actionKeyValueTable,
+	}
+)
+
+func (q *Queries) SearchActionKeyValues(ctx context.Context, shouldTriggerBulk bool, orgID string, queries *ActionKeyValueSearchQueries, withOwnerRemoved bool) (_ *ActionKeyValues, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.ActionKeyValueProjection.Trigger(ctx)
+	}
+	eq := sq.Eq{
+		ActionKeyValueOrgIDCol.identifier():      orgID,
+		ActionKeyValueInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[ActionKeyValueOwnerRemovedCol.identifier()] = false
+	}
+	query, scan := prepareActionKeyValuesQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Eih1e", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ooK0i", "Errors.Internal")
+	}
+	entries, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	entries.LatestSequence, err = q.latestSequence(ctx, actionKeyValueTable)
+	return entries, err
+}
+
+func (q *ActionKeyValueSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewActionKeyValueKeySearchQuery(value string, comparison TextComparison) (SearchQuery, error) {
+	return NewTextQuery(ActionKeyValueKeyCol, value, comparison)
+}
+
+func prepareActionKeyValuesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*ActionKeyValues, error)) {
+	return sq.Select(
+			ActionKeyValueCreationDateCol.identifier(),
+			ActionKeyValueChangeDateCol.identifier(),
+			ActionKeyValueResourceOwnerCol.identifier(),
+			ActionKeyValueSequenceCol.identifier(),
+			ActionKeyValueKeyCol.identifier(),
+			ActionKeyValueValueCol.identifier(),
+			countColumn.identifier()).
+			From(actionKeyValueTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ActionKeyValues, error) {
+			entries := make([]*ActionKeyValue, 0)
+			var count uint64
+			for rows.Next() {
+				entry := new(ActionKeyValue)
+				err := rows.Scan(
+					&entry.CreationDate,
+					&entry.ChangeDate,
+					&entry.ResourceOwner,
+					&entry.Sequence,
+					&entry.Key,
+					&entry.Value,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				entries = append(entries, entry)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Mei9a", "Errors.Query.CloseRows")
+			}
+
+			return &ActionKeyValues{
+				Entries: entries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,114 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	actionKeyValuesQuery = `SELECT projections.action_key_values.creation_date,` +
+		` projections.action_key_values.change_date,` +
+		` projections.action_key_values.resource_owner,` +
+		` projections.action_key_values.sequence,` +
+		` projections.action_key_values.key,` +
+		` projections.action_key_values.value,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.action_key_values` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	actionKeyValuesCols = []string{
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"key",
+		"value",
+		"count",
+	}
+)
+
+func Test_ActionKeyValuePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionKeyValuesQuery no result",
+			prepare: prepareActionKeyValuesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(actionKeyValuesQuery),
+					nil,
+					nil,
+				),
+			},
+			object: &ActionKeyValues{Entries: []*ActionKeyValue{}},
+		},
+		{
+			name:    "prepareActionKeyValuesQuery one result",
+			prepare: prepareActionKeyValuesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(actionKeyValuesQuery),
+					actionKeyValuesCols,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							"counter",
+							[]byte("1"),
+						},
+					},
+				),
+			},
+			object: &ActionKeyValues{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Entries: []*ActionKeyValue{
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						Key:           "counter",
+						Value:         []byte("1"),
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionKeyValuesQuery sql err",
+			prepare: prepareActionKeyValuesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(actionKeyValuesQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ActionRevision is the state of an action after it was added or changed
+type ActionRevision struct {
+	Revision      uint64
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	EditorUserID  string
+	Name          string
+	Script        string
+	Timeout       time.Duration
+	AllowedToFail bool
+	Runtime       domain.ActionRuntime
+}
+
+// ActionRevisions returns all revisions of the action ordered by the revision, the last one is the current state
+func (q *Queries) ActionRevisions(ctx context.Context, actionID, orgID string) (_ []*ActionRevision, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if actionID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-ohM1u", "Errors.IDMissing")
+	}
+	readModel := NewActionRevisionsReadModel(actionID, orgID)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	if len(readModel.Revisions) == 0 {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Ahph9", "Errors.Action.NotFound")
+	}
+	return readModel.Revisions, nil
+}
+
+type ActionRevisionsReadModel struct {
+	*eventstore.ReadModel
+
+	Revisions []*ActionRevision
+}
+
+func NewActionRevisionsReadModel(actionID, resourceOwner string) *ActionRevisionsReadModel {
+	return &ActionRevisionsReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID:   actionID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *ActionRevisionsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *action.AddedEvent:
+			rm.Revisions = append(rm.Revisions, &ActionRevision{
+				Revision:      1,
+				ChangeDate:    e.CreationDate(),
+				Sequence:      e.Sequence(),
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				EditorUserID:  e.EditorUser(),
+				Name:          e.Name,
+				Script:        e.Script,
+				Timeout:       e.Timeout,
+				AllowedToFail: e.AllowedToFail,
+				Runtime:       e.Runtime,
+			})
+		case *action.ChangedEvent:
+			if len(rm.Revisions) == 0 {
+				continue
+			}
+			previous := rm.Revisions[len(rm.Revisions)-1]
+			revision := &ActionRevision{
+				Revision:      previous.Revision + 1,
+				ChangeDate:    e.CreationDate(),
+				Sequence:      e.Sequence(),
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				EditorUserID:  e.EditorUser(),
+				Name:          previous.Name,
+				Script:        previous.Script,
+				Timeout:       previous.Timeout,
+				AllowedToFail: previous.AllowedToFail,
+				Runtime:       previous.Runtime,
+			}
+			if e.Name != nil {
+				revision.Name = *e.Name
+			}
+			if e.Script != nil {
+				revision.Script = *e.Script
+			}
+			if e.Timeout != nil {
+				revision.Timeout = *e.Timeout
+			}
+			if e.AllowedToFail != nil {
+				revision.AllowedToFail = *e.AllowedToFail
+			}
+			if e.Runtime != nil {
+				revision.Runtime = *e.Runtime
+			}
+			rm.Revisions = append(rm.Revisions, revision)
+		case *action.RemovedEvent:
+			rm.Revisions = nil
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *ActionRevisionsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(rm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(action.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			action.AddedEventType,
+			action.ChangedEventType,
+			action.RemovedEventType,
+		).
+		Builder()
+}
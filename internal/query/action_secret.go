@@ -0,0 +1,159 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ActionSecrets only contains the names of the secrets, the values can only be read by the actions
+type ActionSecrets struct {
+	SearchResponse
+	Secrets []*ActionSecret
+}
+
+type ActionSecret struct {
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	Name          string
+}
+
+type ActionSecretSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+var (
+	actionSecretTable = table{
+		name:          projection.ActionSecretProjectionTable,
+		instanceIDCol: projection.ActionSecretColumnInstanceID,
+	}
+	ActionSecretOrgIDCol = Column{
+		name:  projection.ActionSecretColumnOrgID,
+		table: actionSecretTable,
+	}
+	ActionSecretNameCol = Column{
+		name:  projection.ActionSecretColumnName,
+		table: actionSecretTable,
+	}
+	ActionSecretCreationDateCol = Column{
+		name:  projection.ActionSecretColumnCreationDate,
+		table: actionSecretTable,
+	}
+	ActionSecretChangeDateCol = Column{
+		name:  projection.ActionSecretColumnChangeDate,
+		table: actionSecretTable,
+	}
+	ActionSecretSequenceCol = Column{
+		name:  projection.ActionSecretColumnSequence,
+		table: actionSecretTable,
+	}
+	ActionSecretResourceOwnerCol = Column{
+		name:  projection.ActionSecretColumnResourceOwner,
+		table: actionSecretTable,
+	}
+	ActionSecretInstanceIDCol = Column{
+		name:  projection.ActionSecretColumnInstanceID,
+		table: actionSecretTable,
+	}
+	ActionSecretOwnerRemovedCol = Column{
+		name:  projection.ActionSecretColumnOwnerRemoved,
+		table: actionSecretTable,
+	}
+)
+
+func (q *Queries) SearchActionSecrets(ctx context.Context, shouldTriggerBulk bool, orgID string, queries *ActionSecretSearchQueries, withOwnerRemoved bool) (_ *ActionSecrets, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.ActionSecretProjection.Trigger(ctx)
+	}
+	eq := sq.Eq{
+		ActionSecretOrgIDCol.identifier():      orgID,
+		ActionSecretInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[ActionSecretOwnerRemovedCol.identifier()] = false
+	}
+	query, scan := prepareActionSecretsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ahV3o", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Aeb6i", "Errors.Internal")
+	}
+	secrets, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	secrets.LatestSequence, err = q.latestSequence(ctx, actionSecretTable)
+	return secrets, err
+}
+
+func (q *ActionSecretSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewActionSecretNameSearchQuery(value string, comparison TextComparison) (SearchQuery, error) {
+	return NewTextQuery(ActionSecretNameCol, value, comparison)
+}
+
+func prepareActionSecretsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*ActionSecrets, error)) {
+	return sq.Select(
+			ActionSecretCreationDateCol.identifier(),
+			ActionSecretChangeDateCol.identifier(),
+			ActionSecretResourceOwnerCol.identifier(),
+			ActionSecretSequenceCol.identifier(),
+			ActionSecretNameCol.identifier(),
+			countColumn.identifier()).
+			From(actionSecretTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ActionSecrets, error) {
+			secrets := make([]*ActionSecret, 0)
+			var count uint64
+			for rows.Next() {
+				secret := new(ActionSecret)
+				err := rows.Scan(
+					&secret.CreationDate,
+					&secret.ChangeDate,
+					&secret.ResourceOwner,
+					&secret.Sequence,
+					&secret.Name,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				secrets = append(secrets, secret)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-eeP6i", "Errors.Query.CloseRows")
+			}
+
+			return &ActionSecrets{
+				Secrets: secrets,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
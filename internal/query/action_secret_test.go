@@ -0,0 +1,110 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	actionSecretsQuery = `SELECT projections.action_secrets.creation_date,` +
+		` projections.action_secrets.change_date,` +
+		` projections.action_secrets.resource_owner,` +
+		` projections.action_secrets.sequence,` +
+		` projections.action_secrets.name,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.action_secrets` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	actionSecretsCols = []string{
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"name",
+		"count",
+	}
+)
+
+func Test_ActionSecretPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionSecretsQuery no result",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(actionSecretsQuery),
+					nil,
+					nil,
+				),
+			},
+			object: &ActionSecrets{Secrets: []*ActionSecret{}},
+		},
+		{
+			name:    "prepareActionSecretsQuery one result",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(actionSecretsQuery),
+					actionSecretsCols,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							"apiKey",
+						},
+					},
+				),
+			},
+			object: &ActionSecrets{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Secrets: []*ActionSecret{
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						Name:          "apiKey",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionSecretsQuery sql err",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(actionSecretsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
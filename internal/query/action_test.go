@@ -14,18 +14,19 @@ import (
 )
 
 var (
-	prepareActionsStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail,` +
+	prepareActionsStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.runtime,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.actions3` +
+		` FROM projections.actions4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionsCols = []string{
 		"id",
@@ -38,20 +39,22 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"runtime",
 		"count",
 	}
 
-	prepareActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail` +
-		` FROM projections.actions3` +
+	prepareActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.runtime` +
+		` FROM projections.actions4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionCols = []string{
 		"id",
@@ -64,6 +67,7 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"runtime",
 	}
 )
 
@@ -109,6 +113,7 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionRuntimeJavaScript,
 						},
 					},
 				),
@@ -152,6 +157,7 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionRuntimeJavaScript,
 						},
 						{
 							"id-2",
@@ -164,6 +170,7 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionRuntimeJavaScript,
 						},
 					},
 				),
@@ -253,6 +260,7 @@ func Test_ActionPrepares(t *testing.T) {
 						"script",
 						1 * time.Second,
 						true,
+						domain.ActionRuntimeWASM,
 					},
 				),
 			},
@@ -267,6 +275,7 @@ func Test_ActionPrepares(t *testing.T) {
 				Script:        "script",
 				timeout:       1 * time.Second,
 				AllowedToFail: true,
+				Runtime:       domain.ActionRuntimeWASM,
 			},
 		},
 		{
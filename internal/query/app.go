@@ -40,24 +40,26 @@ type App struct {
 }
 
 type OIDCApp struct {
-	RedirectURIs             database.StringArray
-	ResponseTypes            database.EnumArray[domain.OIDCResponseType]
-	GrantTypes               database.EnumArray[domain.OIDCGrantType]
-	AppType                  domain.OIDCApplicationType
-	ClientID                 string
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs   database.StringArray
-	Version                  domain.OIDCVersion
-	ComplianceProblems       database.StringArray
-	IsDevMode                bool
-	AccessTokenType          domain.OIDCTokenType
-	AssertAccessTokenRole    bool
-	AssertIDTokenRole        bool
-	AssertIDTokenUserinfo    bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        database.StringArray
-	AllowedOrigins           database.StringArray
-	SkipNativeAppSuccessPage bool
+	RedirectURIs              database.StringArray
+	ResponseTypes             database.EnumArray[domain.OIDCResponseType]
+	GrantTypes                database.EnumArray[domain.OIDCGrantType]
+	AppType                   domain.OIDCApplicationType
+	ClientID                  string
+	AuthMethodType            domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs    database.StringArray
+	Version                   domain.OIDCVersion
+	ComplianceProblems        database.StringArray
+	IsDevMode                 bool
+	AccessTokenType           domain.OIDCTokenType
+	AssertAccessTokenRole     bool
+	AssertIDTokenRole         bool
+	AssertIDTokenUserinfo     bool
+	ClockSkew                 time.Duration
+	AdditionalOrigins         database.StringArray
+	AllowedOrigins            database.StringArray
+	SkipNativeAppSuccessPage  bool
+	RefreshTokenRotation      domain.OIDCRefreshTokenRotation
+	RefreshTokenReuseInterval time.Duration
 }
 
 type SAMLApp struct {
@@ -246,6 +248,14 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRefreshTokenRotation = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenRotation,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRefreshTokenReuseInterval = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenReuseInterval,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string, withOwnerRemoved bool) (_ *App, err error) {
@@ -541,6 +551,8 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnRefreshTokenRotation.identifier(),
+			AppOIDCConfigColumnRefreshTokenReuseInterval.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -590,6 +602,8 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.refreshTokenRotation,
+				&oidcConfig.refreshTokenReuseInterval,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
@@ -711,6 +725,8 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnRefreshTokenRotation.identifier(),
+			AppOIDCConfigColumnRefreshTokenReuseInterval.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -763,6 +779,8 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.refreshTokenRotation,
+					&oidcConfig.refreshTokenReuseInterval,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -818,23 +836,25 @@ func prepareClientIDsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 }
 
 type sqlOIDCConfig struct {
-	appID                    sql.NullString
-	version                  sql.NullInt32
-	clientID                 sql.NullString
-	redirectUris             database.StringArray
-	applicationType          sql.NullInt16
-	authMethodType           sql.NullInt16
-	postLogoutRedirectUris   database.StringArray
-	devMode                  sql.NullBool
-	accessTokenType          sql.NullInt16
-	accessTokenRoleAssertion sql.NullBool
-	iDTokenRoleAssertion     sql.NullBool
-	iDTokenUserinfoAssertion sql.NullBool
-	clockSkew                sql.NullInt64
-	additionalOrigins        database.StringArray
-	responseTypes            database.EnumArray[domain.OIDCResponseType]
-	grantTypes               database.EnumArray[domain.OIDCGrantType]
-	skipNativeAppSuccessPage sql.NullBool
+	appID                     sql.NullString
+	version                   sql.NullInt32
+	clientID                  sql.NullString
+	redirectUris              database.StringArray
+	applicationType           sql.NullInt16
+	authMethodType            sql.NullInt16
+	postLogoutRedirectUris    database.StringArray
+	devMode                   sql.NullBool
+	accessTokenType           sql.NullInt16
+	accessTokenRoleAssertion  sql.NullBool
+	iDTokenRoleAssertion      sql.NullBool
+	iDTokenUserinfoAssertion  sql.NullBool
+	clockSkew                 sql.NullInt64
+	additionalOrigins         database.StringArray
+	responseTypes             database.EnumArray[domain.OIDCResponseType]
+	grantTypes                database.EnumArray[domain.OIDCGrantType]
+	skipNativeAppSuccessPage  sql.NullBool
+	refreshTokenRotation      sql.NullInt16
+	refreshTokenReuseInterval sql.NullInt64
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -842,22 +862,24 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                  domain.OIDCVersion(c.version.Int32),
-		ClientID:                 c.clientID.String,
-		RedirectURIs:             c.redirectUris,
-		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
-		IsDevMode:                c.devMode.Bool,
-		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:                time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:        c.additionalOrigins,
-		ResponseTypes:            c.responseTypes,
-		GrantTypes:               c.grantTypes,
-		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		Version:                   domain.OIDCVersion(c.version.Int32),
+		ClientID:                  c.clientID.String,
+		RedirectURIs:              c.redirectUris,
+		AppType:                   domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:            domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:    c.postLogoutRedirectUris,
+		IsDevMode:                 c.devMode.Bool,
+		AccessTokenType:           domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:     c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:         c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:     c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                 time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:         c.additionalOrigins,
+		ResponseTypes:             c.responseTypes,
+		GrantTypes:                c.grantTypes,
+		SkipNativeAppSuccessPage:  c.skipNativeAppSuccessPage.Bool,
+		RefreshTokenRotation:      domain.OIDCRefreshTokenRotation(c.refreshTokenRotation.Int16),
+		RefreshTokenReuseInterval: time.Duration(c.refreshTokenReuseInterval.Int64),
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -15,98 +15,102 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
+		` projections.apps6.name,` +
+		` projections.apps6.project_id,` +
+		` projections.apps6.creation_date,` +
+		` projections.apps6.change_date,` +
+		` projections.apps6.resource_owner,` +
+		` projections.apps6.state,` +
+		` projections.apps6.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps6_api_configs.app_id,` +
+		` projections.apps6_api_configs.client_id,` +
+		` projections.apps6_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps6_oidc_configs.app_id,` +
+		` projections.apps6_oidc_configs.version,` +
+		` projections.apps6_oidc_configs.client_id,` +
+		` projections.apps6_oidc_configs.redirect_uris,` +
+		` projections.apps6_oidc_configs.response_types,` +
+		` projections.apps6_oidc_configs.grant_types,` +
+		` projections.apps6_oidc_configs.application_type,` +
+		` projections.apps6_oidc_configs.auth_method_type,` +
+		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps6_oidc_configs.is_dev_mode,` +
+		` projections.apps6_oidc_configs.access_token_type,` +
+		` projections.apps6_oidc_configs.access_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps6_oidc_configs.clock_skew,` +
+		` projections.apps6_oidc_configs.additional_origins,` +
+		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps6_oidc_configs.refresh_token_rotation,` +
+		` projections.apps6_oidc_configs.refresh_token_reuse_interval,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` projections.apps6_saml_configs.app_id,` +
+		` projections.apps6_saml_configs.entity_id,` +
+		` projections.apps6_saml_configs.metadata,` +
+		` projections.apps6_saml_configs.metadata_url` +
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
+		` projections.apps6.name,` +
+		` projections.apps6.project_id,` +
+		` projections.apps6.creation_date,` +
+		` projections.apps6.change_date,` +
+		` projections.apps6.resource_owner,` +
+		` projections.apps6.state,` +
+		` projections.apps6.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps6_api_configs.app_id,` +
+		` projections.apps6_api_configs.client_id,` +
+		` projections.apps6_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps6_oidc_configs.app_id,` +
+		` projections.apps6_oidc_configs.version,` +
+		` projections.apps6_oidc_configs.client_id,` +
+		` projections.apps6_oidc_configs.redirect_uris,` +
+		` projections.apps6_oidc_configs.response_types,` +
+		` projections.apps6_oidc_configs.grant_types,` +
+		` projections.apps6_oidc_configs.application_type,` +
+		` projections.apps6_oidc_configs.auth_method_type,` +
+		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps6_oidc_configs.is_dev_mode,` +
+		` projections.apps6_oidc_configs.access_token_type,` +
+		` projections.apps6_oidc_configs.access_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps6_oidc_configs.clock_skew,` +
+		` projections.apps6_oidc_configs.additional_origins,` +
+		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps6_oidc_configs.refresh_token_rotation,` +
+		` projections.apps6_oidc_configs.refresh_token_reuse_interval,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url,` +
+		` projections.apps6_saml_configs.app_id,` +
+		` projections.apps6_saml_configs.entity_id,` +
+		` projections.apps6_saml_configs.metadata,` +
+		` projections.apps6_saml_configs.metadata_url,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps5_api_configs.client_id,` +
-		` projections.apps5_oidc_configs.client_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps6_api_configs.client_id,` +
+		` projections.apps6_oidc_configs.client_id` +
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.project_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.project_id` +
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects3.id,` +
 		` projections.projects3.creation_date,` +
@@ -120,10 +124,10 @@ var (
 		` projections.projects3.has_project_check,` +
 		` projections.projects3.private_labeling_setting` +
 		` FROM projections.projects3` +
-		` JOIN projections.apps5 ON projections.projects3.id = projections.apps5.project_id AND projections.projects3.instance_id = projections.apps5.instance_id` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` JOIN projections.apps6 ON projections.projects3.id = projections.apps6.project_id AND projections.projects3.instance_id = projections.apps6.instance_id` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	appCols = database.StringArray{
@@ -157,6 +161,8 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"refresh_token_rotation",
+		"refresh_token_reuse_interval",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -228,6 +234,8 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -294,6 +302,8 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -363,6 +373,8 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -434,6 +446,8 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -520,6 +534,8 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -606,6 +622,8 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -692,6 +710,8 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -778,6 +798,8 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -864,6 +886,8 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							true,
+							domain.OIDCRefreshTokenRotationDisabled,
+							time.Minute,
 							// saml config
 							nil,
 							nil,
@@ -888,24 +912,26 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						OIDCConfig: &OIDCApp{
-							Version:                  domain.OIDCVersionV1,
-							ClientID:                 "oidc-client-id",
-							RedirectURIs:             database.StringArray{"https://redirect.to/me"},
-							ResponseTypes:            database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
-							GrantTypes:               database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
-							AppType:                  domain.OIDCApplicationTypeNative,
-							AuthMethodType:           domain.OIDCAuthMethodTypeNone,
-							PostLogoutRedirectURIs:   database.StringArray{"post.logout.ch"},
-							IsDevMode:                false,
-							AccessTokenType:          domain.OIDCTokenTypeJWT,
-							AssertAccessTokenRole:    false,
-							AssertIDTokenRole:        false,
-							AssertIDTokenUserinfo:    true,
-							ClockSkew:                1 * time.Second,
-							AdditionalOrigins:        database.StringArray{"additional.origin"},
-							ComplianceProblems:       nil,
-							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
-							SkipNativeAppSuccessPage: true,
+							Version:                   domain.OIDCVersionV1,
+							ClientID:                  "oidc-client-id",
+							RedirectURIs:              database.StringArray{"https://redirect.to/me"},
+							ResponseTypes:             database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							GrantTypes:                database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							AppType:                   domain.OIDCApplicationTypeNative,
+							AuthMethodType:            domain.OIDCAuthMethodTypeNone,
+							PostLogoutRedirectURIs:    database.StringArray{"post.logout.ch"},
+							IsDevMode:                 false,
+							AccessTokenType:           domain.OIDCTokenTypeJWT,
+							AssertAccessTokenRole:     false,
+							AssertIDTokenRole:         false,
+							AssertIDTokenUserinfo:     true,
+							ClockSkew:                 1 * time.Second,
+							AdditionalOrigins:         database.StringArray{"additional.origin"},
+							ComplianceProblems:        nil,
+							AllowedOrigins:            database.StringArray{"https://redirect.to", "additional.origin"},
+							SkipNativeAppSuccessPage:  true,
+							RefreshTokenRotation:      domain.OIDCRefreshTokenRotationDisabled,
+							RefreshTokenReuseInterval: time.Minute,
 						},
 					},
 				},
@@ -950,6 +976,8 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -987,6 +1015,8 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1024,6 +1054,8 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1192,6 +1224,8 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1250,6 +1284,8 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1313,6 +1349,8 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -1391,6 +1429,8 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1455,6 +1495,8 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -1534,6 +1576,8 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -1613,6 +1657,8 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
@@ -1692,6 +1738,8 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							domain.OIDCRefreshTokenRotationEnabled,
+							0,
 							// saml config
 							nil,
 							nil,
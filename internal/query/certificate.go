@@ -19,6 +19,7 @@ import (
 type Certificate interface {
 	Key
 	Expiry() time.Time
+	PrivateKeyExpiry() time.Time
 	Key() *crypto.CryptoValue
 	Certificate() []byte
 }
@@ -30,15 +31,20 @@ type Certificates struct {
 
 type rsaCertificate struct {
 	key
-	expiry      time.Time
-	privateKey  *crypto.CryptoValue
-	certificate []byte
+	expiry           time.Time
+	privateKeyExpiry time.Time
+	privateKey       *crypto.CryptoValue
+	certificate      []byte
 }
 
 func (c *rsaCertificate) Expiry() time.Time {
 	return c.expiry
 }
 
+func (c *rsaCertificate) PrivateKeyExpiry() time.Time {
+	return c.privateKeyExpiry
+}
+
 func (c *rsaCertificate) Key() *crypto.CryptoValue {
 	return c.privateKey
 }
@@ -115,6 +121,7 @@ func prepareCertificateQuery(ctx context.Context, db prepareDatabase) (sq.Select
 			CertificateColExpiry.identifier(),
 			CertificateColCertificate.identifier(),
 			KeyPrivateColKey.identifier(),
+			KeyPrivateColExpiry.identifier(),
 			countColumn.identifier(),
 		).From(keyTable.identifier()).
 			LeftJoin(join(CertificateColID, KeyColID)).
@@ -136,6 +143,7 @@ func prepareCertificateQuery(ctx context.Context, db prepareDatabase) (sq.Select
 					&k.expiry,
 					&k.certificate,
 					&k.privateKey,
+					&k.privateKeyExpiry,
 					&count,
 				)
 				if err != nil {
@@ -24,6 +24,7 @@ var (
 		` projections.keys4_certificate.expiry,` +
 		` projections.keys4_certificate.certificate,` +
 		` projections.keys4_private.key,` +
+		` projections.keys4_private.expiry,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.keys4` +
 		` LEFT JOIN projections.keys4_certificate ON projections.keys4.id = projections.keys4_certificate.id AND projections.keys4.instance_id = projections.keys4_certificate.instance_id` +
@@ -40,6 +41,7 @@ var (
 		"expiry",
 		"certificate",
 		"key",
+		"expiry",
 		"count",
 	}
 )
@@ -92,6 +94,7 @@ func Test_CertificatePrepares(t *testing.T) {
 							testNow,
 							[]byte(`privateKey`),
 							[]byte(`{"Algorithm": "enc", "Crypted": "cHJpdmF0ZUtleQ==", "CryptoType": 0, "KeyID": "id"}`),
+							testNow,
 						},
 					},
 				),
@@ -111,8 +114,9 @@ func Test_CertificatePrepares(t *testing.T) {
 							algorithm:     "",
 							use:           domain.KeyUsageSAMLMetadataSigning,
 						},
-						expiry:      testNow,
-						certificate: []byte("privateKey"),
+						expiry:           testNow,
+						privateKeyExpiry: testNow,
+						certificate:      []byte("privateKey"),
 						privateKey: &crypto.CryptoValue{
 							CryptoType: crypto.TypeEncryption,
 							Algorithm:  "enc",
@@ -0,0 +1,293 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type EmailHTTPConfigs struct {
+	SearchResponse
+	Configs []*EmailHTTPConfig
+}
+
+type EmailHTTPConfig struct {
+	AggregateID   string
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.EmailHTTPConfigState
+	Sequence      uint64
+
+	Preset              domain.EmailHTTPPreset
+	Endpoint            string
+	ContentType         string
+	BodyTemplate        string
+	AuthHeader          string
+	APIKey              *crypto.CryptoValue
+	SuccessStatusCodes  database.EnumArray[int32]
+	SuccessBodyContains string
+	SenderAddress       string
+	SenderName          string
+}
+
+type EmailHTTPConfigsSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *EmailHTTPConfigsSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+var (
+	emailHTTPConfigsTable = table{
+		name:          projection.EmailHTTPConfigProjectionTable,
+		instanceIDCol: projection.EmailHTTPConfigColumnInstanceID,
+	}
+	EmailHTTPConfigColumnID = Column{
+		name:  projection.EmailHTTPConfigColumnID,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnAggregateID = Column{
+		name:  projection.EmailHTTPConfigColumnAggregateID,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnCreationDate = Column{
+		name:  projection.EmailHTTPConfigColumnCreationDate,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnChangeDate = Column{
+		name:  projection.EmailHTTPConfigColumnChangeDate,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnSequence = Column{
+		name:  projection.EmailHTTPConfigColumnSequence,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnState = Column{
+		name:  projection.EmailHTTPConfigColumnState,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnResourceOwner = Column{
+		name:  projection.EmailHTTPConfigColumnResourceOwner,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnInstanceID = Column{
+		name:  projection.EmailHTTPConfigColumnInstanceID,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnPreset = Column{
+		name:  projection.EmailHTTPConfigColumnPreset,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnEndpoint = Column{
+		name:  projection.EmailHTTPConfigColumnEndpoint,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnContentType = Column{
+		name:  projection.EmailHTTPConfigColumnContentType,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnBodyTemplate = Column{
+		name:  projection.EmailHTTPConfigColumnBodyTemplate,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnAuthHeader = Column{
+		name:  projection.EmailHTTPConfigColumnAuthHeader,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnAPIKey = Column{
+		name:  projection.EmailHTTPConfigColumnAPIKey,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnSuccessStatusCodes = Column{
+		name:  projection.EmailHTTPConfigColumnSuccessStatusCodes,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnSuccessBodyContains = Column{
+		name:  projection.EmailHTTPConfigColumnSuccessBodyContains,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnSenderAddress = Column{
+		name:  projection.EmailHTTPConfigColumnSenderAddress,
+		table: emailHTTPConfigsTable,
+	}
+	EmailHTTPConfigColumnSenderName = Column{
+		name:  projection.EmailHTTPConfigColumnSenderName,
+		table: emailHTTPConfigsTable,
+	}
+)
+
+func (q *Queries) EmailHTTPConfigByID(ctx context.Context, id string) (_ *EmailHTTPConfig, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEmailHTTPConfigQuery(ctx, q.client)
+	stmt, args, err := query.Where(
+		sq.Eq{
+			EmailHTTPConfigColumnID.identifier():         id,
+			EmailHTTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		},
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Eh3sq", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchEmailHTTPConfigs(ctx context.Context, queries *EmailHTTPConfigsSearchQueries) (_ *EmailHTTPConfigs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEmailHTTPConfigsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			EmailHTTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Eh8rq", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Eh5qx", "Errors.Internal")
+	}
+	configs, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	configs.LatestSequence, err = q.latestSequence(ctx, emailHTTPConfigsTable)
+	return configs, err
+}
+
+func NewEmailHTTPConfigStateSearchQuery(state domain.EmailHTTPConfigState) (SearchQuery, error) {
+	return NewNumberQuery(EmailHTTPConfigColumnState, state, NumberEquals)
+}
+
+func prepareEmailHTTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*EmailHTTPConfig, error)) {
+	return sq.Select(
+			EmailHTTPConfigColumnID.identifier(),
+			EmailHTTPConfigColumnAggregateID.identifier(),
+			EmailHTTPConfigColumnCreationDate.identifier(),
+			EmailHTTPConfigColumnChangeDate.identifier(),
+			EmailHTTPConfigColumnResourceOwner.identifier(),
+			EmailHTTPConfigColumnState.identifier(),
+			EmailHTTPConfigColumnSequence.identifier(),
+			EmailHTTPConfigColumnPreset.identifier(),
+			EmailHTTPConfigColumnEndpoint.identifier(),
+			EmailHTTPConfigColumnContentType.identifier(),
+			EmailHTTPConfigColumnBodyTemplate.identifier(),
+			EmailHTTPConfigColumnAuthHeader.identifier(),
+			EmailHTTPConfigColumnAPIKey.identifier(),
+			EmailHTTPConfigColumnSuccessStatusCodes.identifier(),
+			EmailHTTPConfigColumnSuccessBodyContains.identifier(),
+			EmailHTTPConfigColumnSenderAddress.identifier(),
+			EmailHTTPConfigColumnSenderName.identifier(),
+		).From(emailHTTPConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*EmailHTTPConfig, error) {
+			config := new(EmailHTTPConfig)
+			err := row.Scan(
+				&config.ID,
+				&config.AggregateID,
+				&config.CreationDate,
+				&config.ChangeDate,
+				&config.ResourceOwner,
+				&config.State,
+				&config.Sequence,
+				&config.Preset,
+				&config.Endpoint,
+				&config.ContentType,
+				&config.BodyTemplate,
+				&config.AuthHeader,
+				&config.APIKey,
+				&config.SuccessStatusCodes,
+				&config.SuccessBodyContains,
+				&config.SenderAddress,
+				&config.SenderName,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Eh6nf", "Errors.EmailHTTPConfig.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Eh2sc", "Errors.Internal")
+			}
+			return config, nil
+		}
+}
+
+func prepareEmailHTTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*EmailHTTPConfigs, error)) {
+	return sq.Select(
+			EmailHTTPConfigColumnID.identifier(),
+			EmailHTTPConfigColumnAggregateID.identifier(),
+			EmailHTTPConfigColumnCreationDate.identifier(),
+			EmailHTTPConfigColumnChangeDate.identifier(),
+			EmailHTTPConfigColumnResourceOwner.identifier(),
+			EmailHTTPConfigColumnState.identifier(),
+			EmailHTTPConfigColumnSequence.identifier(),
+			EmailHTTPConfigColumnPreset.identifier(),
+			EmailHTTPConfigColumnEndpoint.identifier(),
+			EmailHTTPConfigColumnContentType.identifier(),
+			EmailHTTPConfigColumnBodyTemplate.identifier(),
+			EmailHTTPConfigColumnAuthHeader.identifier(),
+			EmailHTTPConfigColumnAPIKey.identifier(),
+			EmailHTTPConfigColumnSuccessStatusCodes.identifier(),
+			EmailHTTPConfigColumnSuccessBodyContains.identifier(),
+			EmailHTTPConfigColumnSenderAddress.identifier(),
+			EmailHTTPConfigColumnSenderName.identifier(),
+			countColumn.identifier(),
+		).From(emailHTTPConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(rows *sql.Rows) (*EmailHTTPConfigs, error) {
+			configs := &EmailHTTPConfigs{Configs: []*EmailHTTPConfig{}}
+			for rows.Next() {
+				config := new(EmailHTTPConfig)
+				err := rows.Scan(
+					&config.ID,
+					&config.AggregateID,
+					&config.CreationDate,
+					&config.ChangeDate,
+					&config.ResourceOwner,
+					&config.State,
+					&config.Sequence,
+					&config.Preset,
+					&config.Endpoint,
+					&config.ContentType,
+					&config.BodyTemplate,
+					&config.AuthHeader,
+					&config.APIKey,
+					&config.SuccessStatusCodes,
+					&config.SuccessBodyContains,
+					&config.SenderAddress,
+					&config.SenderName,
+					&configs.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Eh9sr", "Errors.Internal")
+				}
+				configs.Configs = append(configs.Configs, config)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Eh4cl", "Errors.Query.CloseRows")
+			}
+			return configs, nil
+		}
+}
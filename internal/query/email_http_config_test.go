@@ -0,0 +1,228 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	emailHTTPConfigSelect = `SELECT projections.email_http_configs.id,` +
+		` projections.email_http_configs.aggregate_id,` +
+		` projections.email_http_configs.creation_date,` +
+		` projections.email_http_configs.change_date,` +
+		` projections.email_http_configs.resource_owner,` +
+		` projections.email_http_configs.state,` +
+		` projections.email_http_configs.sequence,` +
+		` projections.email_http_configs.preset,` +
+		` projections.email_http_configs.endpoint,` +
+		` projections.email_http_configs.content_type,` +
+		` projections.email_http_configs.body_template,` +
+		` projections.email_http_configs.auth_header,` +
+		` projections.email_http_configs.api_key,` +
+		` projections.email_http_configs.success_status_codes,` +
+		` projections.email_http_configs.success_body_contains,` +
+		` projections.email_http_configs.sender_address,` +
+		` projections.email_http_configs.sender_name`
+	expectedEmailHTTPConfigQuery = regexp.QuoteMeta(emailHTTPConfigSelect +
+		` FROM projections.email_http_configs` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	expectedEmailHTTPConfigsQuery = regexp.QuoteMeta(emailHTTPConfigSelect + `,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.email_http_configs` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+
+	emailHTTPConfigCols = []string{
+		"id",
+		"aggregate_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"state",
+		"sequence",
+		"preset",
+		"endpoint",
+		"content_type",
+		"body_template",
+		"auth_header",
+		"api_key",
+		"success_status_codes",
+		"success_body_contains",
+		"sender_address",
+		"sender_name",
+	}
+	emailHTTPConfigsCols = append(emailHTTPConfigCols, "count")
+)
+
+func Test_EmailHTTPConfigPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareEmailHTTPConfigsQuery no result",
+			prepare: prepareEmailHTTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEmailHTTPConfigsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &EmailHTTPConfigs{Configs: []*EmailHTTPConfig{}},
+		},
+		{
+			name:    "prepareEmailHTTPConfigsQuery one result",
+			prepare: prepareEmailHTTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEmailHTTPConfigsQuery,
+					emailHTTPConfigsCols,
+					[][]driver.Value{
+						{
+							"config-id",
+							"agg-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.EmailHTTPConfigStateActive,
+							uint64(20211109),
+							domain.EmailHTTPPresetSendGrid,
+							"",
+							"",
+							"",
+							"",
+							&crypto.CryptoValue{},
+							database.EnumArray[int32]{202},
+							"",
+							"from@acme.ch",
+							"ACME",
+						},
+					},
+				),
+			},
+			object: &EmailHTTPConfigs{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Configs: []*EmailHTTPConfig{
+					{
+						ID:                 "config-id",
+						AggregateID:        "agg-id",
+						CreationDate:       testNow,
+						ChangeDate:         testNow,
+						ResourceOwner:      "ro",
+						State:              domain.EmailHTTPConfigStateActive,
+						Sequence:           20211109,
+						Preset:             domain.EmailHTTPPresetSendGrid,
+						APIKey:             &crypto.CryptoValue{},
+						SuccessStatusCodes: database.EnumArray[int32]{202},
+						SenderAddress:      "from@acme.ch",
+						SenderName:         "ACME",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareEmailHTTPConfigsQuery sql err",
+			prepare: prepareEmailHTTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedEmailHTTPConfigsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareEmailHTTPConfigQuery no result",
+			prepare: prepareEmailHTTPConfigQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEmailHTTPConfigQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*EmailHTTPConfig)(nil),
+		},
+		{
+			name:    "prepareEmailHTTPConfigQuery found",
+			prepare: prepareEmailHTTPConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedEmailHTTPConfigQuery,
+					emailHTTPConfigCols,
+					[]driver.Value{
+						"config-id",
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						domain.EmailHTTPConfigStateInactive,
+						uint64(20211109),
+						domain.EmailHTTPPresetCustom,
+						"https://mail.acme.ch/send",
+						"application/json",
+						`{"to":{{json .To}}}`,
+						"X-Api-Key",
+						nil,
+						nil,
+						"queued",
+						"from@acme.ch",
+						"ACME",
+					},
+				),
+			},
+			object: &EmailHTTPConfig{
+				ID:                  "config-id",
+				AggregateID:         "agg-id",
+				CreationDate:        testNow,
+				ChangeDate:          testNow,
+				ResourceOwner:       "ro",
+				State:               domain.EmailHTTPConfigStateInactive,
+				Sequence:            20211109,
+				Preset:              domain.EmailHTTPPresetCustom,
+				Endpoint:            "https://mail.acme.ch/send",
+				ContentType:         "application/json",
+				BodyTemplate:        `{"to":{{json .To}}}`,
+				AuthHeader:          "X-Api-Key",
+				SuccessStatusCodes:  database.EnumArray[int32]{},
+				SuccessBodyContains: "queued",
+				SenderAddress:       "from@acme.ch",
+				SenderName:          "ACME",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package query
 
 import (
 	"context"
-	"crypto/rsa"
 	"database/sql"
 	"time"
 
@@ -19,6 +18,7 @@ import (
 
 type Key interface {
 	ID() string
+	CreationDate() time.Time
 	Algorithm() string
 	Use() domain.KeyUsage
 	Sequence() uint64
@@ -60,6 +60,10 @@ func (k *key) ID() string {
 	return k.id
 }
 
+func (k *key) CreationDate() time.Time {
+	return k.creationDate
+}
+
 func (k *key) Algorithm() string {
 	return k.algorithm
 }
@@ -86,17 +90,18 @@ func (k *privateKey) Key() *crypto.CryptoValue {
 	return k.privateKey
 }
 
-type rsaPublicKey struct {
+// publicKey holds the parsed key, which is either an RSA, EC or Ed25519 key depending on the algorithm
+type publicKey struct {
 	key
 	expiry    time.Time
-	publicKey *rsa.PublicKey
+	publicKey interface{}
 }
 
-func (r *rsaPublicKey) Expiry() time.Time {
+func (r *publicKey) Expiry() time.Time {
 	return r.expiry
 }
 
-func (r *rsaPublicKey) Key() interface{} {
+func (r *publicKey) Key() interface{} {
 	return r.publicKey
 }
 
@@ -263,7 +268,7 @@ func preparePublicKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			keys := make([]PublicKey, 0)
 			var count uint64
 			for rows.Next() {
-				k := new(rsaPublicKey)
+				k := new(publicKey)
 				var keyValue []byte
 				err := rows.Scan(
 					&k.id,
@@ -280,7 +285,7 @@ func preparePublicKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				if err != nil {
 					return nil, err
 				}
-				k.publicKey, err = crypto.BytesToPublicKey(keyValue)
+				k.publicKey, err = crypto.BytesToSigningPublicKey(keyValue)
 				if err != nil {
 					return nil, err
 				}
@@ -0,0 +1,134 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	keyRotationPolicyTable = table{
+		name:          projection.KeyRotationPolicyProjectionTable,
+		instanceIDCol: projection.KeyRotationPolicyColumnInstanceID,
+	}
+	KeyRotationPolicyColumnCreationDate = Column{
+		name:  projection.KeyRotationPolicyColumnCreationDate,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnChangeDate = Column{
+		name:  projection.KeyRotationPolicyColumnChangeDate,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnInstanceID = Column{
+		name:  projection.KeyRotationPolicyColumnInstanceID,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnSequence = Column{
+		name:  projection.KeyRotationPolicyColumnSequence,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnSigningKeyAlgorithm = Column{
+		name:  projection.KeyRotationPolicyColumnSigningKeyAlgorithm,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnSigningKeyLifetime = Column{
+		name:  projection.KeyRotationPolicyColumnSigningKeyLifetime,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnCertificateLifetime = Column{
+		name:  projection.KeyRotationPolicyColumnCertificateLifetime,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnPrePublicationPeriod = Column{
+		name:  projection.KeyRotationPolicyColumnPrePublicationPeriod,
+		table: keyRotationPolicyTable,
+	}
+	KeyRotationPolicyColumnRetirementGracePeriod = Column{
+		name:  projection.KeyRotationPolicyColumnRetirementGracePeriod,
+		table: keyRotationPolicyTable,
+	}
+)
+
+// KeyRotationPolicy of the instance, unset (zero) values are taken from the system defaults
+type KeyRotationPolicy struct {
+	AggregateID   string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	SigningKeyAlgorithm   string
+	SigningKeyLifetime    time.Duration
+	CertificateLifetime   time.Duration
+	PrePublicationPeriod  time.Duration
+	RetirementGracePeriod time.Duration
+}
+
+// KeyPrePublicationPeriod returns the duration a new key must be published before it's used for signing
+func (p *KeyRotationPolicy) KeyPrePublicationPeriod() time.Duration {
+	if p.PrePublicationPeriod > 0 {
+		return p.PrePublicationPeriod
+	}
+	return domain.DefaultKeyPrePublicationPeriod
+}
+
+func (q *Queries) KeyRotationPolicy(ctx context.Context) (_ *KeyRotationPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareKeyRotationPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		KeyRotationPolicyColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ooy8e", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareKeyRotationPolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*KeyRotationPolicy, error)) {
+	return sq.Select(
+			KeyRotationPolicyColumnInstanceID.identifier(),
+			KeyRotationPolicyColumnCreationDate.identifier(),
+			KeyRotationPolicyColumnChangeDate.identifier(),
+			KeyRotationPolicyColumnInstanceID.identifier(),
+			KeyRotationPolicyColumnSequence.identifier(),
+			KeyRotationPolicyColumnSigningKeyAlgorithm.identifier(),
+			KeyRotationPolicyColumnSigningKeyLifetime.identifier(),
+			KeyRotationPolicyColumnCertificateLifetime.identifier(),
+			KeyRotationPolicyColumnPrePublicationPeriod.identifier(),
+			KeyRotationPolicyColumnRetirementGracePeriod.identifier()).
+			From(keyRotationPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*KeyRotationPolicy, error) {
+			policy := new(KeyRotationPolicy)
+			err := row.Scan(
+				&policy.AggregateID,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.Sequence,
+				&policy.SigningKeyAlgorithm,
+				&policy.SigningKeyLifetime,
+				&policy.CertificateLifetime,
+				&policy.PrePublicationPeriod,
+				&policy.RetirementGracePeriod,
+			)
+			if err != nil && !errs.Is(err, sql.ErrNoRows) { // ignore not found errors
+				return nil, errors.ThrowInternal(err, "QUERY-Iej3u", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	prepareKeyRotationPolicyStmt = `SELECT projections.key_rotation_policies.instance_id,` +
+		` projections.key_rotation_policies.creation_date,` +
+		` projections.key_rotation_policies.change_date,` +
+		` projections.key_rotation_policies.instance_id,` +
+		` projections.key_rotation_policies.sequence,` +
+		` projections.key_rotation_policies.signing_key_algorithm,` +
+		` projections.key_rotation_policies.signing_key_lifetime,` +
+		` projections.key_rotation_policies.certificate_lifetime,` +
+		` projections.key_rotation_policies.pre_publication_period,` +
+		` projections.key_rotation_policies.retirement_grace_period` +
+		` FROM projections.key_rotation_policies` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareKeyRotationPolicyCols = []string{
+		"instance_id",
+		"creation_date",
+		"change_date",
+		"instance_id",
+		"sequence",
+		"signing_key_algorithm",
+		"signing_key_lifetime",
+		"certificate_lifetime",
+		"pre_publication_period",
+		"retirement_grace_period",
+	}
+)
+
+func Test_KeyRotationPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareKeyRotationPolicyQuery no result",
+			prepare: prepareKeyRotationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareKeyRotationPolicyStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &KeyRotationPolicy{},
+		},
+		{
+			name:    "prepareKeyRotationPolicyQuery found",
+			prepare: prepareKeyRotationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareKeyRotationPolicyStmt),
+					prepareKeyRotationPolicyCols,
+					[]driver.Value{
+						"instance-id",
+						testNow,
+						testNow,
+						"instance-id",
+						uint64(20230101),
+						"ES256",
+						time.Hour * 6,
+						time.Hour * 24,
+						time.Minute * 10,
+						time.Hour * 12,
+					},
+				),
+			},
+			object: &KeyRotationPolicy{
+				AggregateID:           "instance-id",
+				CreationDate:          testNow,
+				ChangeDate:            testNow,
+				ResourceOwner:         "instance-id",
+				Sequence:              20230101,
+				SigningKeyAlgorithm:   "ES256",
+				SigningKeyLifetime:    time.Hour * 6,
+				CertificateLifetime:   time.Hour * 24,
+				PrePublicationPeriod:  time.Minute * 10,
+				RetirementGracePeriod: time.Hour * 12,
+			},
+		},
+		{
+			name:    "prepareKeyRotationPolicyQuery sql err",
+			prepare: prepareKeyRotationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareKeyRotationPolicyStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
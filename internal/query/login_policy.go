@@ -35,6 +35,9 @@ type LoginPolicy struct {
 	AllowDomainDiscovery       bool
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowEmailMagicLink        bool
+	RiskStepUpThreshold        uint32
+	RiskBlockThreshold         uint32
 	DefaultRedirectURI         string
 	PasswordCheckLifetime      time.Duration
 	ExternalLoginCheckLifetime time.Duration
@@ -131,6 +134,18 @@ var (
 		name:  projection.DisableLoginWithPhone,
 		table: loginPolicyTable,
 	}
+	LoginPolicyColumnAllowEmailMagicLink = Column{
+		name:  projection.AllowEmailMagicLink,
+		table: loginPolicyTable,
+	}
+	LoginPolicyColumnRiskStepUpThreshold = Column{
+		name:  projection.RiskStepUpThreshold,
+		table: loginPolicyTable,
+	}
+	LoginPolicyColumnRiskBlockThreshold = Column{
+		name:  projection.RiskBlockThreshold,
+		table: loginPolicyTable,
+	}
 	LoginPolicyColumnDefaultRedirectURI = Column{
 		name:  projection.DefaultRedirectURI,
 		table: loginPolicyTable,
@@ -360,6 +375,9 @@ func prepareLoginPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Select
 			LoginPolicyColumnAllowDomainDiscovery.identifier(),
 			LoginPolicyColumnDisableLoginWithEmail.identifier(),
 			LoginPolicyColumnDisableLoginWithPhone.identifier(),
+			LoginPolicyColumnAllowEmailMagicLink.identifier(),
+			LoginPolicyColumnRiskStepUpThreshold.identifier(),
+			LoginPolicyColumnRiskBlockThreshold.identifier(),
 			LoginPolicyColumnDefaultRedirectURI.identifier(),
 			LoginPolicyColumnPasswordCheckLifetime.identifier(),
 			LoginPolicyColumnExternalLoginCheckLifetime.identifier(),
@@ -390,6 +408,9 @@ func prepareLoginPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Select
 					&p.AllowDomainDiscovery,
 					&p.DisableLoginWithEmail,
 					&p.DisableLoginWithPhone,
+					&p.AllowEmailMagicLink,
+					&p.RiskStepUpThreshold,
+					&p.RiskBlockThreshold,
 					&defaultRedirectURI,
 					&p.PasswordCheckLifetime,
 					&p.ExternalLoginCheckLifetime,
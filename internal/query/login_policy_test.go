@@ -15,30 +15,33 @@ import (
 )
 
 var (
-	loginPolicyQuery = `SELECT projections.login_policies4.aggregate_id,` +
-		` projections.login_policies4.creation_date,` +
-		` projections.login_policies4.change_date,` +
-		` projections.login_policies4.sequence,` +
-		` projections.login_policies4.allow_register,` +
-		` projections.login_policies4.allow_username_password,` +
-		` projections.login_policies4.allow_external_idps,` +
-		` projections.login_policies4.force_mfa,` +
-		` projections.login_policies4.second_factors,` +
-		` projections.login_policies4.multi_factors,` +
-		` projections.login_policies4.passwordless_type,` +
-		` projections.login_policies4.is_default,` +
-		` projections.login_policies4.hide_password_reset,` +
-		` projections.login_policies4.ignore_unknown_usernames,` +
-		` projections.login_policies4.allow_domain_discovery,` +
-		` projections.login_policies4.disable_login_with_email,` +
-		` projections.login_policies4.disable_login_with_phone,` +
-		` projections.login_policies4.default_redirect_uri,` +
-		` projections.login_policies4.password_check_lifetime,` +
-		` projections.login_policies4.external_login_check_lifetime,` +
-		` projections.login_policies4.mfa_init_skip_lifetime,` +
-		` projections.login_policies4.second_factor_check_lifetime,` +
-		` projections.login_policies4.multi_factor_check_lifetime` +
-		` FROM projections.login_policies4` +
+	loginPolicyQuery = `SELECT projections.login_policies6.aggregate_id,` +
+		` projections.login_policies6.creation_date,` +
+		` projections.login_policies6.change_date,` +
+		` projections.login_policies6.sequence,` +
+		` projections.login_policies6.allow_register,` +
+		` projections.login_policies6.allow_username_password,` +
+		` projections.login_policies6.allow_external_idps,` +
+		` projections.login_policies6.force_mfa,` +
+		` projections.login_policies6.second_factors,` +
+		` projections.login_policies6.multi_factors,` +
+		` projections.login_policies6.passwordless_type,` +
+		` projections.login_policies6.is_default,` +
+		` projections.login_policies6.hide_password_reset,` +
+		` projections.login_policies6.ignore_unknown_usernames,` +
+		` projections.login_policies6.allow_domain_discovery,` +
+		` projections.login_policies6.disable_login_with_email,` +
+		` projections.login_policies6.disable_login_with_phone,` +
+		` projections.login_policies6.allow_email_magic_link,` +
+		` projections.login_policies6.risk_step_up_threshold,` +
+		` projections.login_policies6.risk_block_threshold,` +
+		` projections.login_policies6.default_redirect_uri,` +
+		` projections.login_policies6.password_check_lifetime,` +
+		` projections.login_policies6.external_login_check_lifetime,` +
+		` projections.login_policies6.mfa_init_skip_lifetime,` +
+		` projections.login_policies6.second_factor_check_lifetime,` +
+		` projections.login_policies6.multi_factor_check_lifetime` +
+		` FROM projections.login_policies6` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	loginPolicyCols = []string{
 		"aggregate_id",
@@ -58,6 +61,9 @@ var (
 		"allow_domain_discovery",
 		"disable_login_with_email",
 		"disable_login_with_phone",
+		"allow_email_magic_link",
+		"risk_step_up_threshold",
+		"risk_block_threshold",
 		"default_redirect_uri",
 		"password_check_lifetime",
 		"external_login_check_lifetime",
@@ -66,15 +72,15 @@ var (
 		"multi_factor_check_lifetime",
 	}
 
-	prepareLoginPolicy2FAsStmt = `SELECT projections.login_policies4.second_factors` +
-		` FROM projections.login_policies4` +
+	prepareLoginPolicy2FAsStmt = `SELECT projections.login_policies6.second_factors` +
+		` FROM projections.login_policies6` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareLoginPolicy2FAsCols = []string{
 		"second_factors",
 	}
 
-	prepareLoginPolicyMFAsStmt = `SELECT projections.login_policies4.multi_factors` +
-		` FROM projections.login_policies4` +
+	prepareLoginPolicyMFAsStmt = `SELECT projections.login_policies6.multi_factors` +
+		` FROM projections.login_policies6` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareLoginPolicyMFAsCols = []string{
 		"multi_factors",
@@ -135,6 +141,9 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						true,
+						50,
+						80,
 						"https://example.com/redirect",
 						time.Hour * 2,
 						time.Hour * 2,
@@ -162,6 +171,9 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 				AllowDomainDiscovery:       true,
 				DisableLoginWithEmail:      true,
 				DisableLoginWithPhone:      true,
+				AllowEmailMagicLink:        true,
+				RiskStepUpThreshold:        50,
+				RiskBlockThreshold:         80,
 				DefaultRedirectURI:         "https://example.com/redirect",
 				PasswordCheckLifetime:      time.Hour * 2,
 				ExternalLoginCheckLifetime: time.Hour * 2,
@@ -28,6 +28,35 @@ type MailTemplate struct {
 
 	Template  []byte
 	IsDefault bool
+
+	// MessageTemplates overwrite the Template for specific message types,
+	// the templates of the organization are listed before the ones of the instance
+	MessageTemplates []*MailMessageTemplate
+}
+
+type MailMessageTemplate struct {
+	AggregateID  string
+	Sequence     uint64
+	CreationDate time.Time
+	ChangeDate   time.Time
+
+	MessageType string
+	Subject     string
+	HTML        []byte
+	Text        []byte
+	IsDefault   bool
+}
+
+// MessageTemplate returns the template of the message type,
+// preferring the one of the organization over the one of the instance.
+// It returns nil if no specific template is set
+func (t *MailTemplate) MessageTemplate(messageType string) *MailMessageTemplate {
+	for _, template := range t.MessageTemplates {
+		if template.MessageType == messageType {
+			return template
+		}
+	}
+	return nil
 }
 
 var (
@@ -73,6 +102,57 @@ var (
 	}
 )
 
+var (
+	mailMessageTemplateTable = table{
+		name:          projection.MailMessageTemplateTable,
+		instanceIDCol: projection.MailMessageTemplateInstanceIDCol,
+	}
+	MailMessageTemplateColAggregateID = Column{
+		name:  projection.MailMessageTemplateAggregateIDCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColInstanceID = Column{
+		name:  projection.MailMessageTemplateInstanceIDCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColSequence = Column{
+		name:  projection.MailMessageTemplateSequenceCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColCreationDate = Column{
+		name:  projection.MailMessageTemplateCreationDateCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColChangeDate = Column{
+		name:  projection.MailMessageTemplateChangeDateCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColIsDefault = Column{
+		name:  projection.MailMessageTemplateIsDefaultCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColMessageType = Column{
+		name:  projection.MailMessageTemplateMessageTypeCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColSubject = Column{
+		name:  projection.MailMessageTemplateSubjectCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColHTML = Column{
+		name:  projection.MailMessageTemplateHTMLCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColText = Column{
+		name:  projection.MailMessageTemplateTextCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColOwnerRemoved = Column{
+		name:  projection.MailMessageTemplateOwnerRemovedCol,
+		table: mailMessageTemplateTable,
+	}
+)
+
 func (q *Queries) MailTemplateByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (_ *MailTemplate, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -97,7 +177,15 @@ func (q *Queries) MailTemplateByOrg(ctx context.Context, orgID string, withOwner
 	}
 
 	row := q.client.QueryRowContext(ctx, query, args...)
-	return scan(row)
+	template, err := scan(row)
+	if err != nil {
+		return nil, err
+	}
+	template.MessageTemplates, err = q.mailMessageTemplates(ctx, orgID, withOwnerRemoved)
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
 }
 
 func (q *Queries) DefaultMailTemplate(ctx context.Context) (_ *MailTemplate, err error) {
@@ -116,7 +204,47 @@ func (q *Queries) DefaultMailTemplate(ctx context.Context) (_ *MailTemplate, err
 	}
 
 	row := q.client.QueryRowContext(ctx, query, args...)
-	return scan(row)
+	template, err := scan(row)
+	if err != nil {
+		return nil, err
+	}
+	template.MessageTemplates, err = q.mailMessageTemplates(ctx, authz.GetInstance(ctx).InstanceID(), false)
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// mailMessageTemplates returns the message templates of the organization and the instance,
+// the ones of the organization first
+func (q *Queries) mailMessageTemplates(ctx context.Context, orgID string, withOwnerRemoved bool) (_ []*MailMessageTemplate, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareMailMessageTemplatesQuery(ctx, q.client)
+	eq := sq.Eq{MailMessageTemplateColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	if !withOwnerRemoved {
+		eq[MailMessageTemplateColOwnerRemoved.identifier()] = false
+	}
+	query, args, err := stmt.Where(
+		sq.And{
+			eq,
+			sq.Or{
+				sq.Eq{MailMessageTemplateColAggregateID.identifier(): orgID},
+				sq.Eq{MailMessageTemplateColAggregateID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(MailMessageTemplateColIsDefault.identifier()).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tk2fw", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Qm4xd", "Errors.Internal")
+	}
+	return scan(rows)
 }
 
 func prepareMailTemplateQuery(ctx context.Context, db prepareDatabase, orgID string) (sq.SelectBuilder, func(*sql.Row) (*MailTemplate, error)) {
@@ -157,6 +285,48 @@ func prepareMailTemplateQuery(ctx context.Context, db prepareDatabase, orgID str
 		}
 }
 
+func prepareMailMessageTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*MailMessageTemplate, error)) {
+	return sq.Select(
+			MailMessageTemplateColAggregateID.identifier(),
+			MailMessageTemplateColSequence.identifier(),
+			MailMessageTemplateColCreationDate.identifier(),
+			MailMessageTemplateColChangeDate.identifier(),
+			MailMessageTemplateColMessageType.identifier(),
+			MailMessageTemplateColSubject.identifier(),
+			MailMessageTemplateColHTML.identifier(),
+			MailMessageTemplateColText.identifier(),
+			MailMessageTemplateColIsDefault.identifier(),
+		).
+			From(mailMessageTemplateTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*MailMessageTemplate, error) {
+			templates := make([]*MailMessageTemplate, 0)
+			for rows.Next() {
+				template := new(MailMessageTemplate)
+				err := rows.Scan(
+					&template.AggregateID,
+					&template.Sequence,
+					&template.CreationDate,
+					&template.ChangeDate,
+					&template.MessageType,
+					&template.Subject,
+					&template.HTML,
+					&template.Text,
+					&template.IsDefault,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Ho3ag", "Errors.Internal")
+				}
+				templates = append(templates, template)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-xP6wn", "Errors.Query.CloseRows")
+			}
+			return templates, nil
+		}
+}
+
 func downloadTemplate(orgId string) ([]byte, bool) {
 	if len(orgId) <= 0 {
 		return []byte{}, false
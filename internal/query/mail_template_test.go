@@ -0,0 +1,157 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	prepareMailMessageTemplatesStmt = `SELECT projections.mail_message_templates.aggregate_id,` +
+		` projections.mail_message_templates.sequence,` +
+		` projections.mail_message_templates.creation_date,` +
+		` projections.mail_message_templates.change_date,` +
+		` projections.mail_message_templates.message_type,` +
+		` projections.mail_message_templates.subject,` +
+		` projections.mail_message_templates.html,` +
+		` projections.mail_message_templates.text,` +
+		` projections.mail_message_templates.is_default` +
+		` FROM projections.mail_message_templates` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareMailMessageTemplatesCols = []string{
+		"aggregate_id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"message_type",
+		"subject",
+		"html",
+		"text",
+		"is_default",
+	}
+)
+
+func Test_MailMessageTemplatePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareMailMessageTemplatesQuery no result",
+			prepare: prepareMailMessageTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareMailMessageTemplatesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*MailMessageTemplate{},
+		},
+		{
+			name:    "prepareMailMessageTemplatesQuery multiple result",
+			prepare: prepareMailMessageTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareMailMessageTemplatesStmt),
+					prepareMailMessageTemplatesCols,
+					[][]driver.Value{
+						{
+							"org-id",
+							uint64(20211109),
+							testNow,
+							testNow,
+							"InitCode",
+							"{{.Subject}} - ACME",
+							[]byte("<p>{{.Text}}</p>"),
+							[]byte("{{.Text}}"),
+							false,
+						},
+						{
+							"instance-id",
+							uint64(20211109),
+							testNow,
+							testNow,
+							"PasswordReset",
+							"",
+							[]byte("<p>{{.Text}}</p>"),
+							nil,
+							true,
+						},
+					},
+				),
+			},
+			object: []*MailMessageTemplate{
+				{
+					AggregateID:  "org-id",
+					Sequence:     20211109,
+					CreationDate: testNow,
+					ChangeDate:   testNow,
+					MessageType:  "InitCode",
+					Subject:      "{{.Subject}} - ACME",
+					HTML:         []byte("<p>{{.Text}}</p>"),
+					Text:         []byte("{{.Text}}"),
+					IsDefault:    false,
+				},
+				{
+					AggregateID:  "instance-id",
+					Sequence:     20211109,
+					CreationDate: testNow,
+					ChangeDate:   testNow,
+					MessageType:  "PasswordReset",
+					HTML:         []byte("<p>{{.Text}}</p>"),
+					IsDefault:    true,
+				},
+			},
+		},
+		{
+			name:    "prepareMailMessageTemplatesQuery sql err",
+			prepare: prepareMailMessageTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareMailMessageTemplatesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestMailTemplate_MessageTemplate(t *testing.T) {
+	orgTemplate := &MailMessageTemplate{AggregateID: "org-id", MessageType: "InitCode"}
+	instanceTemplate := &MailMessageTemplate{AggregateID: "instance-id", MessageType: "InitCode", IsDefault: true}
+	resetTemplate := &MailMessageTemplate{AggregateID: "instance-id", MessageType: "PasswordReset", IsDefault: true}
+	template := &MailTemplate{
+		MessageTemplates: []*MailMessageTemplate{orgTemplate, instanceTemplate, resetTemplate},
+	}
+	if got := template.MessageTemplate("InitCode"); got != orgTemplate {
+		t.Errorf("expected template of org, got %v", got)
+	}
+	if got := template.MessageTemplate("PasswordReset"); got != resetTemplate {
+		t.Errorf("expected template of instance, got %v", got)
+	}
+	if got := template.MessageTemplate("DomainClaimed"); got != nil {
+		t.Errorf("expected no template, got %v", got)
+	}
+}
@@ -31,6 +31,17 @@ type MessageTexts struct {
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
 	PasswordChange           MessageText
+	MagicLink                MessageText
+	RecoveryCodeUsed         MessageText
+	NewDeviceLogin           MessageText
+	MFAAdded                 MessageText
+	MFARemoved               MessageText
+	EmailChanged             MessageText
+	PhoneChanged             MessageText
+	AccountLocked            MessageText
+	PersonalAccessTokenAdded MessageText
+	UserDeactivationWarning  MessageText
+	UserDeletionWarning      MessageText
 }
 
 type MessageText struct {
@@ -335,6 +346,28 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordlessRegistration
 	case domain.PasswordChangeMessageType:
 		return &m.PasswordChange
+	case domain.MagicLinkMessageType:
+		return &m.MagicLink
+	case domain.RecoveryCodeUsedMessageType:
+		return &m.RecoveryCodeUsed
+	case domain.NewDeviceLoginMessageType:
+		return &m.NewDeviceLogin
+	case domain.MFAAddedMessageType:
+		return &m.MFAAdded
+	case domain.MFARemovedMessageType:
+		return &m.MFARemoved
+	case domain.EmailChangedMessageType:
+		return &m.EmailChanged
+	case domain.PhoneChangedMessageType:
+		return &m.PhoneChanged
+	case domain.AccountLockedMessageType:
+		return &m.AccountLocked
+	case domain.PersonalAccessTokenAddedMessageType:
+		return &m.PersonalAccessTokenAdded
+	case domain.UserDeactivationWarningMessageType:
+		return &m.UserDeactivationWarning
+	case domain.UserDeletionWarningMessageType:
+		return &m.UserDeletionWarning
 	}
 	return nil
 }
@@ -0,0 +1,303 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	notificationDeliveryTable = table{
+		name:          projection.NotificationDeliveryTable,
+		instanceIDCol: projection.NotificationDeliveryInstanceIDCol,
+	}
+	NotificationDeliveryColumnID = Column{
+		name:  projection.NotificationDeliveryIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnCreationDate = Column{
+		name:  projection.NotificationDeliveryCreationDateCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnChangeDate = Column{
+		name:  projection.NotificationDeliveryChangeDateCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnSequence = Column{
+		name:  projection.NotificationDeliverySequenceCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnResourceOwner = Column{
+		name:  projection.NotificationDeliveryResourceOwnerCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnInstanceID = Column{
+		name:  projection.NotificationDeliveryInstanceIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnUserID = Column{
+		name:  projection.NotificationDeliveryUserIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnChannel = Column{
+		name:  projection.NotificationDeliveryChannelCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnRecipient = Column{
+		name:  projection.NotificationDeliveryRecipientCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnMessageType = Column{
+		name:  projection.NotificationDeliveryMessageTypeCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnStatus = Column{
+		name:  projection.NotificationDeliveryStatusCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnProviderMessageID = Column{
+		name:  projection.NotificationDeliveryProviderMessageIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnError = Column{
+		name:  projection.NotificationDeliveryErrorCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnOwnerRemoved = Column{
+		name:  projection.NotificationDeliveryOwnerRemovedCol,
+		table: notificationDeliveryTable,
+	}
+)
+
+type NotificationDeliveries struct {
+	SearchResponse
+	Deliveries []*NotificationDelivery
+}
+
+type NotificationDelivery struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+
+	UserID            string
+	Channel           domain.NotificationType
+	Recipient         string
+	MessageType       string
+	Status            domain.NotificationDeliveryStatus
+	ProviderMessageID string
+	Error             string
+}
+
+type NotificationDeliverySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationDeliverySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchNotificationDeliveries(ctx context.Context, queries *NotificationDeliverySearchQueries, withOwnerRemoved bool) (deliveries *NotificationDeliveries, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationDeliveriesQuery(ctx, q.client)
+	eq := sq.Eq{
+		NotificationDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[NotificationDeliveryColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Wr4nb", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Xa2yd", "Errors.Internal")
+	}
+	deliveries, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	deliveries.LatestSequence, err = q.latestSequence(ctx, notificationDeliveryTable)
+	return deliveries, err
+}
+
+// NotificationDeliveryByID returns the delivery of the instance, optionally restricted to the organisation
+func (q *Queries) NotificationDeliveryByID(ctx context.Context, id, resourceOwner string, withOwnerRemoved bool) (_ *NotificationDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		NotificationDeliveryColumnID.identifier():         id,
+		NotificationDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if resourceOwner != "" {
+		eq[NotificationDeliveryColumnResourceOwner.identifier()] = resourceOwner
+	}
+	return q.notificationDelivery(ctx, eq, withOwnerRemoved)
+}
+
+// NotificationDeliveryByProviderMessageID returns the delivery the provider reports a status for
+func (q *Queries) NotificationDeliveryByProviderMessageID(ctx context.Context, providerMessageID string) (_ *NotificationDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if providerMessageID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Vq5ob", "Errors.Notification.Delivery.NotFound")
+	}
+	return q.notificationDelivery(ctx, sq.Eq{
+		NotificationDeliveryColumnProviderMessageID.identifier(): providerMessageID,
+		NotificationDeliveryColumnInstanceID.identifier():        authz.GetInstance(ctx).InstanceID(),
+	}, false)
+}
+
+func (q *Queries) notificationDelivery(ctx context.Context, eq sq.Eq, withOwnerRemoved bool) (*NotificationDelivery, error) {
+	if !withOwnerRemoved {
+		eq[NotificationDeliveryColumnOwnerRemoved.identifier()] = false
+	}
+	query, scan := prepareNotificationDeliveryQuery(ctx, q.client)
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Jz8cm", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func NewNotificationDeliveryResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationDeliveryColumnResourceOwner, value, TextEquals)
+}
+
+func NewNotificationDeliveryUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationDeliveryColumnUserID, value, TextEquals)
+}
+
+func NewNotificationDeliveryStatusSearchQuery(value domain.NotificationDeliveryStatus) (SearchQuery, error) {
+	return NewNumberQuery(NotificationDeliveryColumnStatus, int(value), NumberEquals)
+}
+
+func NewNotificationDeliveryChannelSearchQuery(value domain.NotificationType) (SearchQuery, error) {
+	return NewNumberQuery(NotificationDeliveryColumnChannel, int(value), NumberEquals)
+}
+
+func NewNotificationDeliveryMessageTypeSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationDeliveryColumnMessageType, value, TextEquals)
+}
+
+func prepareNotificationDeliveriesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*NotificationDeliveries, error)) {
+	return sq.Select(
+			NotificationDeliveryColumnID.identifier(),
+			NotificationDeliveryColumnCreationDate.identifier(),
+			NotificationDeliveryColumnChangeDate.identifier(),
+			NotificationDeliveryColumnSequence.identifier(),
+			NotificationDeliveryColumnResourceOwner.identifier(),
+			NotificationDeliveryColumnUserID.identifier(),
+			NotificationDeliveryColumnChannel.identifier(),
+			NotificationDeliveryColumnRecipient.identifier(),
+			NotificationDeliveryColumnMessageType.identifier(),
+			NotificationDeliveryColumnStatus.identifier(),
+			NotificationDeliveryColumnProviderMessageID.identifier(),
+			NotificationDeliveryColumnError.identifier(),
+			countColumn.identifier(),
+		).From(notificationDeliveryTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*NotificationDeliveries, error) {
+			deliveries := make([]*NotificationDelivery, 0)
+			var count uint64
+			for rows.Next() {
+				delivery := new(NotificationDelivery)
+				err := rows.Scan(
+					&delivery.ID,
+					&delivery.CreationDate,
+					&delivery.ChangeDate,
+					&delivery.Sequence,
+					&delivery.ResourceOwner,
+					&delivery.UserID,
+					&delivery.Channel,
+					&delivery.Recipient,
+					&delivery.MessageType,
+					&delivery.Status,
+					&delivery.ProviderMessageID,
+					&delivery.Error,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				deliveries = append(deliveries, delivery)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Fe6ws", "Errors.Query.CloseRows")
+			}
+
+			return &NotificationDeliveries{
+				Deliveries: deliveries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareNotificationDeliveryQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*NotificationDelivery, error)) {
+	return sq.Select(
+			NotificationDeliveryColumnID.identifier(),
+			NotificationDeliveryColumnCreationDate.identifier(),
+			NotificationDeliveryColumnChangeDate.identifier(),
+			NotificationDeliveryColumnSequence.identifier(),
+			NotificationDeliveryColumnResourceOwner.identifier(),
+			NotificationDeliveryColumnUserID.identifier(),
+			NotificationDeliveryColumnChannel.identifier(),
+			NotificationDeliveryColumnRecipient.identifier(),
+			NotificationDeliveryColumnMessageType.identifier(),
+			NotificationDeliveryColumnStatus.identifier(),
+			NotificationDeliveryColumnProviderMessageID.identifier(),
+			NotificationDeliveryColumnError.identifier(),
+		).From(notificationDeliveryTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*NotificationDelivery, error) {
+			delivery := new(NotificationDelivery)
+			err := row.Scan(
+				&delivery.ID,
+				&delivery.CreationDate,
+				&delivery.ChangeDate,
+				&delivery.Sequence,
+				&delivery.ResourceOwner,
+				&delivery.UserID,
+				&delivery.Channel,
+				&delivery.Recipient,
+				&delivery.MessageType,
+				&delivery.Status,
+				&delivery.ProviderMessageID,
+				&delivery.Error,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ot1ve", "Errors.Notification.Delivery.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ik7ra", "Errors.Internal")
+			}
+			return delivery, nil
+		}
+}
@@ -0,0 +1,253 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	notificationDeliveryStmt = `SELECT projections.notification_deliveries.id,` +
+		` projections.notification_deliveries.creation_date,` +
+		` projections.notification_deliveries.change_date,` +
+		` projections.notification_deliveries.sequence,` +
+		` projections.notification_deliveries.resource_owner,` +
+		` projections.notification_deliveries.user_id,` +
+		` projections.notification_deliveries.channel,` +
+		` projections.notification_deliveries.recipient,` +
+		` projections.notification_deliveries.message_type,` +
+		` projections.notification_deliveries.status,` +
+		` projections.notification_deliveries.provider_message_id,` +
+		` projections.notification_deliveries.error` +
+		` FROM projections.notification_deliveries` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	notificationDeliveryCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"user_id",
+		"channel",
+		"recipient",
+		"message_type",
+		"status",
+		"provider_message_id",
+		"error",
+	}
+	notificationDeliveriesStmt = `SELECT projections.notification_deliveries.id,` +
+		` projections.notification_deliveries.creation_date,` +
+		` projections.notification_deliveries.change_date,` +
+		` projections.notification_deliveries.sequence,` +
+		` projections.notification_deliveries.resource_owner,` +
+		` projections.notification_deliveries.user_id,` +
+		` projections.notification_deliveries.channel,` +
+		` projections.notification_deliveries.recipient,` +
+		` projections.notification_deliveries.message_type,` +
+		` projections.notification_deliveries.status,` +
+		` projections.notification_deliveries.provider_message_id,` +
+		` projections.notification_deliveries.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.notification_deliveries` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	notificationDeliveriesCols = append(notificationDeliveryCols, "count")
+)
+
+func Test_NotificationDeliveryPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationDeliveryQuery no result",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(notificationDeliveryStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationDelivery)(nil),
+		},
+		{
+			name:    "prepareNotificationDeliveryQuery found",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(notificationDeliveryStmt),
+					notificationDeliveryCols,
+					[]driver.Value{
+						"delivery-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"ro",
+						"user-id",
+						domain.NotificationTypeEmail,
+						"gigi@zitadel.com",
+						"InitCode",
+						domain.NotificationDeliveryStatusDelivered,
+						"message-id@zitadel.com",
+						"",
+					},
+				),
+			},
+			object: &NotificationDelivery{
+				ID:                "delivery-id",
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				UserID:            "user-id",
+				Channel:           domain.NotificationTypeEmail,
+				Recipient:         "gigi@zitadel.com",
+				MessageType:       "InitCode",
+				Status:            domain.NotificationDeliveryStatusDelivered,
+				ProviderMessageID: "message-id@zitadel.com",
+			},
+		},
+		{
+			name:    "prepareNotificationDeliveryQuery sql err",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(notificationDeliveryStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery no result",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(notificationDeliveriesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &NotificationDeliveries{Deliveries: []*NotificationDelivery{}},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery multiple results",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(notificationDeliveriesStmt),
+					notificationDeliveriesCols,
+					[][]driver.Value{
+						{
+							"delivery-id",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"ro",
+							"user-id",
+							domain.NotificationTypeEmail,
+							"gigi@zitadel.com",
+							"InitCode",
+							domain.NotificationDeliveryStatusSent,
+							"message-id@zitadel.com",
+							"",
+						},
+						{
+							"delivery-id2",
+							testNow,
+							testNow,
+							uint64(20211110),
+							"ro",
+							"user-id",
+							domain.NotificationTypeSms,
+							"+41791234567",
+							"VerifyPhone",
+							domain.NotificationDeliveryStatusFailed,
+							"",
+							"no sms provider",
+						},
+					},
+				),
+			},
+			object: &NotificationDeliveries{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Deliveries: []*NotificationDelivery{
+					{
+						ID:                "delivery-id",
+						CreationDate:      testNow,
+						ChangeDate:        testNow,
+						Sequence:          20211109,
+						ResourceOwner:     "ro",
+						UserID:            "user-id",
+						Channel:           domain.NotificationTypeEmail,
+						Recipient:         "gigi@zitadel.com",
+						MessageType:       "InitCode",
+						Status:            domain.NotificationDeliveryStatusSent,
+						ProviderMessageID: "message-id@zitadel.com",
+					},
+					{
+						ID:            "delivery-id2",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211110,
+						ResourceOwner: "ro",
+						UserID:        "user-id",
+						Channel:       domain.NotificationTypeSms,
+						Recipient:     "+41791234567",
+						MessageType:   "VerifyPhone",
+						Status:        domain.NotificationDeliveryStatusFailed,
+						Error:         "no sms provider",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery sql err",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(notificationDeliveriesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
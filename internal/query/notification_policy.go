@@ -24,7 +24,13 @@ type NotificationPolicy struct {
 	ResourceOwner string
 	State         domain.PolicyState
 
-	PasswordChange bool
+	PasswordChange           bool
+	NewDeviceLogin           bool
+	MFAChange                bool
+	EmailChange              bool
+	PhoneChange              bool
+	AccountLocked            bool
+	PersonalAccessTokenAdded bool
 
 	IsDefault bool
 }
@@ -62,6 +68,30 @@ var (
 		name:  projection.NotificationPolicyColumnPasswordChange,
 		table: notificationPolicyTable,
 	}
+	NotificationPolicyColNewDeviceLogin = Column{
+		name:  projection.NotificationPolicyColumnNewDeviceLogin,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFAChange = Column{
+		name:  projection.NotificationPolicyColumnMFAChange,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColEmailChange = Column{
+		name:  projection.NotificationPolicyColumnEmailChange,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColPhoneChange = Column{
+		name:  projection.NotificationPolicyColumnPhoneChange,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColAccountLocked = Column{
+		name:  projection.NotificationPolicyColumnAccountLocked,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColPersonalAccessTokenAdded = Column{
+		name:  projection.NotificationPolicyColumnPersonalAccessTokenAdded,
+		table: notificationPolicyTable,
+	}
 	NotificationPolicyColIsDefault = Column{
 		name:  projection.NotificationPolicyColumnIsDefault,
 		table: notificationPolicyTable,
@@ -140,6 +170,12 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 			NotificationPolicyColChangeDate.identifier(),
 			NotificationPolicyColResourceOwner.identifier(),
 			NotificationPolicyColPasswordChange.identifier(),
+			NotificationPolicyColNewDeviceLogin.identifier(),
+			NotificationPolicyColMFAChange.identifier(),
+			NotificationPolicyColEmailChange.identifier(),
+			NotificationPolicyColPhoneChange.identifier(),
+			NotificationPolicyColAccountLocked.identifier(),
+			NotificationPolicyColPersonalAccessTokenAdded.identifier(),
 			NotificationPolicyColIsDefault.identifier(),
 			NotificationPolicyColState.identifier(),
 		).
@@ -154,6 +190,12 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 				&policy.ChangeDate,
 				&policy.ResourceOwner,
 				&policy.PasswordChange,
+				&policy.NewDeviceLogin,
+				&policy.MFAChange,
+				&policy.EmailChange,
+				&policy.PhoneChange,
+				&policy.AccountLocked,
+				&policy.PersonalAccessTokenAdded,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -13,15 +13,21 @@ import (
 )
 
 var (
-	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies.id,` +
-		` projections.notification_policies.sequence,` +
-		` projections.notification_policies.creation_date,` +
-		` projections.notification_policies.change_date,` +
-		` projections.notification_policies.resource_owner,` +
-		` projections.notification_policies.password_change,` +
-		` projections.notification_policies.is_default,` +
-		` projections.notification_policies.state` +
-		` FROM projections.notification_policies` +
+	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies1.id,` +
+		` projections.notification_policies1.sequence,` +
+		` projections.notification_policies1.creation_date,` +
+		` projections.notification_policies1.change_date,` +
+		` projections.notification_policies1.resource_owner,` +
+		` projections.notification_policies1.password_change,` +
+		` projections.notification_policies1.new_device_login,` +
+		` projections.notification_policies1.mfa_change,` +
+		` projections.notification_policies1.email_change,` +
+		` projections.notification_policies1.phone_change,` +
+		` projections.notification_policies1.account_locked,` +
+		` projections.notification_policies1.personal_access_token_added,` +
+		` projections.notification_policies1.is_default,` +
+		` projections.notification_policies1.state` +
+		` FROM projections.notification_policies1` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	notificationPolicyCols = []string{
 		"id",
@@ -30,6 +36,12 @@ var (
 		"change_date",
 		"resource_owner",
 		"password_change",
+		"new_device_login",
+		"mfa_change",
+		"email_change",
+		"phone_change",
+		"account_locked",
+		"personal_access_token_added",
 		"is_default",
 		"state",
 	}
@@ -79,6 +91,12 @@ func Test_NotificationPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						true,
+						false,
+						true,
+						false,
+						true,
+						false,
+						true,
 						domain.PolicyStateActive,
 					},
 				),
@@ -91,6 +109,9 @@ func Test_NotificationPolicyPrepares(t *testing.T) {
 				ResourceOwner:  "ro",
 				State:          domain.PolicyStateActive,
 				PasswordChange: true,
+				NewDeviceLogin: true,
+				EmailChange:    true,
+				AccountLocked:  true,
 				IsDefault:      true,
 			},
 		},
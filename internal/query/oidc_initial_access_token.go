@@ -0,0 +1,190 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	oidcInitialAccessTokensTable = table{
+		name:          projection.OIDCInitialAccessTokenProjectionTable,
+		instanceIDCol: projection.OIDCInitialAccessTokenColumnInstanceID,
+	}
+	OIDCInitialAccessTokenColumnID = Column{
+		name:  projection.OIDCInitialAccessTokenColumnID,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnProjectID = Column{
+		name:  projection.OIDCInitialAccessTokenColumnProjectID,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnCreationDate = Column{
+		name:  projection.OIDCInitialAccessTokenColumnCreationDate,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnChangeDate = Column{
+		name:  projection.OIDCInitialAccessTokenColumnChangeDate,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnSequence = Column{
+		name:  projection.OIDCInitialAccessTokenColumnSequence,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnResourceOwner = Column{
+		name:  projection.OIDCInitialAccessTokenColumnResourceOwner,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnInstanceID = Column{
+		name:  projection.OIDCInitialAccessTokenColumnInstanceID,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnExpiration = Column{
+		name:  projection.OIDCInitialAccessTokenColumnExpiration,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnAllowedRedirectURIPrefixes = Column{
+		name:  projection.OIDCInitialAccessTokenColumnAllowedRedirectURIPrefixes,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnAllowedGrantTypes = Column{
+		name:  projection.OIDCInitialAccessTokenColumnAllowedGrantTypes,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnAllowedAuthMethodTypes = Column{
+		name:  projection.OIDCInitialAccessTokenColumnAllowedAuthMethodTypes,
+		table: oidcInitialAccessTokensTable,
+	}
+	OIDCInitialAccessTokenColumnOwnerRemoved = Column{
+		name:  projection.OIDCInitialAccessTokenColumnOwnerRemoved,
+		table: oidcInitialAccessTokensTable,
+	}
+)
+
+type OIDCInitialAccessTokens struct {
+	SearchResponse
+	OIDCInitialAccessTokens []*OIDCInitialAccessToken
+}
+
+// OIDCInitialAccessToken describes an issued initial access token, the token itself is never stored.
+type OIDCInitialAccessToken struct {
+	ID            string
+	ProjectID     string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Expiration                 time.Time
+	AllowedRedirectURIPrefixes database.StringArray
+	AllowedGrantTypes          database.EnumArray[domain.OIDCGrantType]
+	AllowedAuthMethodTypes     database.EnumArray[domain.OIDCAuthMethodType]
+}
+
+type OIDCInitialAccessTokenSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *Queries) SearchOIDCInitialAccessTokens(ctx context.Context, queries *OIDCInitialAccessTokenSearchQueries) (tokens *OIDCInitialAccessTokens, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareOIDCInitialAccessTokensQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		OIDCInitialAccessTokenColumnInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		OIDCInitialAccessTokenColumnOwnerRemoved.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Iat1s", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Iat2q", "Errors.Internal")
+	}
+	tokens, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	tokens.LatestSequence, err = q.latestSequence(ctx, oidcInitialAccessTokensTable)
+	return tokens, err
+}
+
+func NewOIDCInitialAccessTokenResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(OIDCInitialAccessTokenColumnResourceOwner, value, TextEquals)
+}
+
+func NewOIDCInitialAccessTokenProjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(OIDCInitialAccessTokenColumnProjectID, value, TextEquals)
+}
+
+func (q *OIDCInitialAccessTokenSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func prepareOIDCInitialAccessTokensQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*OIDCInitialAccessTokens, error)) {
+	return sq.Select(
+			OIDCInitialAccessTokenColumnID.identifier(),
+			OIDCInitialAccessTokenColumnProjectID.identifier(),
+			OIDCInitialAccessTokenColumnCreationDate.identifier(),
+			OIDCInitialAccessTokenColumnChangeDate.identifier(),
+			OIDCInitialAccessTokenColumnResourceOwner.identifier(),
+			OIDCInitialAccessTokenColumnSequence.identifier(),
+			OIDCInitialAccessTokenColumnExpiration.identifier(),
+			OIDCInitialAccessTokenColumnAllowedRedirectURIPrefixes.identifier(),
+			OIDCInitialAccessTokenColumnAllowedGrantTypes.identifier(),
+			OIDCInitialAccessTokenColumnAllowedAuthMethodTypes.identifier(),
+			countColumn.identifier()).
+			From(oidcInitialAccessTokensTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*OIDCInitialAccessTokens, error) {
+			tokens := make([]*OIDCInitialAccessToken, 0)
+			var count uint64
+			for rows.Next() {
+				token := new(OIDCInitialAccessToken)
+				err := rows.Scan(
+					&token.ID,
+					&token.ProjectID,
+					&token.CreationDate,
+					&token.ChangeDate,
+					&token.ResourceOwner,
+					&token.Sequence,
+					&token.Expiration,
+					&token.AllowedRedirectURIPrefixes,
+					&token.AllowedGrantTypes,
+					&token.AllowedAuthMethodTypes,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, token)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Iat3c", "Errors.Query.CloseRows")
+			}
+
+			return &OIDCInitialAccessTokens{
+				OIDCInitialAccessTokens: tokens,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,135 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	oidcInitialAccessTokensStmt = regexp.QuoteMeta(
+		"SELECT projections.oidc_initial_access_tokens.id," +
+			" projections.oidc_initial_access_tokens.project_id," +
+			" projections.oidc_initial_access_tokens.creation_date," +
+			" projections.oidc_initial_access_tokens.change_date," +
+			" projections.oidc_initial_access_tokens.resource_owner," +
+			" projections.oidc_initial_access_tokens.sequence," +
+			" projections.oidc_initial_access_tokens.expiration," +
+			" projections.oidc_initial_access_tokens.allowed_redirect_uri_prefixes," +
+			" projections.oidc_initial_access_tokens.allowed_grant_types," +
+			" projections.oidc_initial_access_tokens.allowed_auth_method_types," +
+			" COUNT(*) OVER ()" +
+			" FROM projections.oidc_initial_access_tokens" +
+			" AS OF SYSTEM TIME '-1 ms'")
+	oidcInitialAccessTokensCols = []string{
+		"id",
+		"project_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"expiration",
+		"allowed_redirect_uri_prefixes",
+		"allowed_grant_types",
+		"allowed_auth_method_types",
+		"count",
+	}
+)
+
+func Test_OIDCInitialAccessTokenPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareOIDCInitialAccessTokensQuery no result",
+			prepare: prepareOIDCInitialAccessTokensQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					oidcInitialAccessTokensStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &OIDCInitialAccessTokens{OIDCInitialAccessTokens: []*OIDCInitialAccessToken{}},
+		},
+		{
+			name:    "prepareOIDCInitialAccessTokensQuery one token",
+			prepare: prepareOIDCInitialAccessTokensQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					oidcInitialAccessTokensStmt,
+					oidcInitialAccessTokensCols,
+					[][]driver.Value{
+						{
+							"token-id",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211202),
+							time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							database.StringArray{"https://partner.example.com/"},
+							database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeAuthorizationCode},
+							database.EnumArray[domain.OIDCAuthMethodType]{domain.OIDCAuthMethodTypeBasic},
+						},
+					},
+				),
+			},
+			object: &OIDCInitialAccessTokens{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				OIDCInitialAccessTokens: []*OIDCInitialAccessToken{
+					{
+						ID:                         "token-id",
+						ProjectID:                  "project-id",
+						CreationDate:               testNow,
+						ChangeDate:                 testNow,
+						ResourceOwner:              "ro",
+						Sequence:                   20211202,
+						Expiration:                 time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+						AllowedRedirectURIPrefixes: database.StringArray{"https://partner.example.com/"},
+						AllowedGrantTypes:          database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeAuthorizationCode},
+						AllowedAuthMethodTypes:     database.EnumArray[domain.OIDCAuthMethodType]{domain.OIDCAuthMethodTypeBasic},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareOIDCInitialAccessTokensQuery sql err",
+			prepare: prepareOIDCInitialAccessTokensQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					oidcInitialAccessTokensStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
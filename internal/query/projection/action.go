@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	ActionTable            = "projections.actions3"
+	ActionTable            = "projections.actions4"
 	ActionIDCol            = "id"
 	ActionCreationDateCol  = "creation_date"
 	ActionChangeDateCol    = "change_date"
@@ -27,6 +27,7 @@ const (
 	ActionTimeoutCol       = "timeout"
 	ActionAllowedToFailCol = "allowed_to_fail"
 	ActionOwnerRemovedCol  = "owner_removed"
+	ActionRuntimeCol       = "runtime"
 )
 
 type actionProjection struct {
@@ -51,6 +52,7 @@ func newActionProjection(ctx context.Context, config crdb.StatementHandlerConfig
 			crdb.NewColumn(ActionTimeoutCol, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(ActionAllowedToFailCol, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(ActionOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(ActionRuntimeCol, crdb.ColumnTypeEnum, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(ActionInstanceIDCol, ActionIDCol),
 			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{ActionResourceOwnerCol})),
@@ -128,6 +130,7 @@ func (p *actionProjection) reduceActionAdded(event eventstore.Event) (*handler.S
 			handler.NewCol(ActionTimeoutCol, e.Timeout),
 			handler.NewCol(ActionAllowedToFailCol, e.AllowedToFail),
 			handler.NewCol(ActionStateCol, domain.ActionStateActive),
+			handler.NewCol(ActionRuntimeCol, e.Runtime),
 		},
 	), nil
 }
@@ -153,6 +156,9 @@ func (p *actionProjection) reduceActionChanged(event eventstore.Event) (*handler
 	if e.AllowedToFail != nil {
 		values = append(values, handler.NewCol(ActionAllowedToFailCol, *e.AllowedToFail))
 	}
+	if e.Runtime != nil {
+		values = append(values, handler.NewCol(ActionRuntimeCol, *e.Runtime))
+	}
 	return crdb.NewUpdateStatement(
 		e,
 		values,
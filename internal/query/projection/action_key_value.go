@@ -0,0 +1,146 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	ActionKeyValueProjectionTable = "projections.action_key_values"
+
+	ActionKeyValueColumnOrgID         = "org_id"
+	ActionKeyValueColumnKey           = "key"
+	ActionKeyValueColumnValue         = "value"
+	ActionKeyValueColumnCreationDate  = "creation_date"
+	ActionKeyValueColumnChangeDate    = "change_date"
+	ActionKeyValueColumnSequence      = "sequence"
+	ActionKeyValueColumnResourceOwner = "resource_owner"
+	ActionKeyValueColumnInstanceID    = "instance_id"
+	ActionKeyValueColumnOwnerRemoved  = "owner_removed"
+)
+
+type actionKeyValueProjection struct {
+	crdb.StatementHandler
+}
+
+func newActionKeyValueProjection(ctx context.Context, config crdb.StatementHandlerConfig) *actionKeyValueProjection {
+	p := new(actionKeyValueProjection)
+	config.ProjectionName = ActionKeyValueProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ActionKeyValueColumnOrgID, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionKeyValueColumnKey, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionKeyValueColumnValue, crdb.ColumnTypeBytes),
+			crdb.NewColumn(ActionKeyValueColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionKeyValueColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionKeyValueColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionKeyValueColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionKeyValueColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionKeyValueColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(ActionKeyValueColumnInstanceID, ActionKeyValueColumnOrgID, ActionKeyValueColumnKey),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{ActionKeyValueColumnOwnerRemoved})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *actionKeyValueProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.ActionKeyValueSetEventType,
+					Reduce: p.reduceEntrySet,
+				},
+				{
+					Event:  org.ActionKeyValueRemovedEventType,
+					Reduce: p.reduceEntryRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ActionKeyValueColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *actionKeyValueProjection) reduceEntrySet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionKeyValueSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Uu5ae", "reduce.wrong.event.type %s", org.ActionKeyValueSetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionKeyValueColumnInstanceID, nil),
+			handler.NewCol(ActionKeyValueColumnOrgID, nil),
+			handler.NewCol(ActionKeyValueColumnKey, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(ActionKeyValueColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(ActionKeyValueColumnOrgID, e.Aggregate().ID),
+			handler.NewCol(ActionKeyValueColumnKey, e.Key),
+			handler.NewCol(ActionKeyValueColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(ActionKeyValueColumnCreationDate, e.CreationDate()),
+			handler.NewCol(ActionKeyValueColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ActionKeyValueColumnSequence, e.Sequence()),
+			handler.NewCol(ActionKeyValueColumnValue, e.Value),
+		},
+	), nil
+}
+
+func (p *actionKeyValueProjection) reduceEntryRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionKeyValueRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Xai6o", "reduce.wrong.event.type %s", org.ActionKeyValueRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ActionKeyValueColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(ActionKeyValueColumnOrgID, e.Aggregate().ID),
+			handler.NewCond(ActionKeyValueColumnKey, e.Key),
+		},
+	), nil
+}
+
+func (p *actionKeyValueProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ke4mo", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionKeyValueColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ActionKeyValueColumnSequence, e.Sequence()),
+			handler.NewCol(ActionKeyValueColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionKeyValueColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(ActionKeyValueColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
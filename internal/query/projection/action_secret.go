@@ -0,0 +1,144 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	ActionSecretProjectionTable = "projections.action_secrets"
+
+	ActionSecretColumnOrgID         = "org_id"
+	ActionSecretColumnName          = "name"
+	ActionSecretColumnCreationDate  = "creation_date"
+	ActionSecretColumnChangeDate    = "change_date"
+	ActionSecretColumnSequence      = "sequence"
+	ActionSecretColumnResourceOwner = "resource_owner"
+	ActionSecretColumnInstanceID    = "instance_id"
+	ActionSecretColumnOwnerRemoved  = "owner_removed"
+)
+
+// actionSecretProjection only contains the names of the secrets, the values are never projected
+type actionSecretProjection struct {
+	crdb.StatementHandler
+}
+
+func newActionSecretProjection(ctx context.Context, config crdb.StatementHandlerConfig) *actionSecretProjection {
+	p := new(actionSecretProjection)
+	config.ProjectionName = ActionSecretProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ActionSecretColumnOrgID, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionSecretColumnName, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionSecretColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionSecretColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionSecretColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionSecretColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionSecretColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionSecretColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(ActionSecretColumnInstanceID, ActionSecretColumnOrgID, ActionSecretColumnName),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{ActionSecretColumnOwnerRemoved})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *actionSecretProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.ActionSecretSetEventType,
+					Reduce: p.reduceSecretSet,
+				},
+				{
+					Event:  org.ActionSecretRemovedEventType,
+					Reduce: p.reduceSecretRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ActionSecretColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *actionSecretProjection) reduceSecretSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionSecretSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ohT5i", "reduce.wrong.event.type %s", org.ActionSecretSetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionSecretColumnInstanceID, nil),
+			handler.NewCol(ActionSecretColumnOrgID, nil),
+			handler.NewCol(ActionSecretColumnName, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(ActionSecretColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(ActionSecretColumnOrgID, e.Aggregate().ID),
+			handler.NewCol(ActionSecretColumnName, e.Name),
+			handler.NewCol(ActionSecretColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(ActionSecretColumnCreationDate, e.CreationDate()),
+			handler.NewCol(ActionSecretColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ActionSecretColumnSequence, e.Sequence()),
+		},
+	), nil
+}
+
+func (p *actionSecretProjection) reduceSecretRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionSecretRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Oowe2", "reduce.wrong.event.type %s", org.ActionSecretRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ActionSecretColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(ActionSecretColumnOrgID, e.Aggregate().ID),
+			handler.NewCond(ActionSecretColumnName, e.Name),
+		},
+	), nil
+}
+
+func (p *actionSecretProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-iuX7e", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionSecretColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ActionSecretColumnSequence, e.Sequence()),
+			handler.NewCol(ActionSecretColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionSecretColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(ActionSecretColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,164 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestActionSecretProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSecretSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.ActionSecretSetEventType),
+					org.AggregateType,
+					[]byte(`{
+						"name": "apiKey",
+						"value": {
+							"cryptoType": 0,
+							"algorithm": "enc",
+							"keyID": "id",
+							"crypted": "c2VjcmV0"
+						}
+					}`),
+				), eventstore.GenericEventMapper[org.ActionSecretSetEvent]),
+			},
+			reduce: (&actionSecretProjection{}).reduceSecretSet,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.action_secrets (instance_id, org_id, name, resource_owner, creation_date, change_date, sequence) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, org_id, name) DO UPDATE SET (resource_owner, creation_date, change_date, sequence) = (EXCLUDED.resource_owner, EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"apiKey",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSecretRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.ActionSecretRemovedEventType),
+					org.AggregateType,
+					[]byte(`{
+						"name": "apiKey"
+					}`),
+				), eventstore.GenericEventMapper[org.ActionSecretRemovedEvent]),
+			},
+			reduce: (&actionSecretProjection{}).reduceSecretRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.action_secrets WHERE (instance_id = $1) AND (org_id = $2) AND (name = $3)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"apiKey",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOwnerRemoved(org removed)",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&actionSecretProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.action_secrets SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(ActionSecretColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.action_secrets WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, ActionSecretProjectionTable, tt.want)
+		})
+	}
+}
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	AppProjectionTable = "projections.apps5"
+	AppProjectionTable = "projections.apps6"
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
@@ -38,26 +38,28 @@ const (
 	AppAPIConfigColumnClientSecret = "client_secret"
 	AppAPIConfigColumnAuthMethod   = "auth_method"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
-	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
+	appOIDCTableSuffix                           = "oidc_configs"
+	AppOIDCConfigColumnAppID                     = "app_id"
+	AppOIDCConfigColumnInstanceID                = "instance_id"
+	AppOIDCConfigColumnVersion                   = "version"
+	AppOIDCConfigColumnClientID                  = "client_id"
+	AppOIDCConfigColumnClientSecret              = "client_secret"
+	AppOIDCConfigColumnRedirectUris              = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes             = "response_types"
+	AppOIDCConfigColumnGrantTypes                = "grant_types"
+	AppOIDCConfigColumnApplicationType           = "application_type"
+	AppOIDCConfigColumnAuthMethodType            = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris    = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                   = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType           = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion  = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion      = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion  = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                 = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins         = "additional_origins"
+	AppOIDCConfigColumnSkipNativeAppSuccessPage  = "skip_native_app_success_page"
+	AppOIDCConfigColumnRefreshTokenRotation      = "refresh_token_rotation"
+	AppOIDCConfigColumnRefreshTokenReuseInterval = "refresh_token_reuse_interval"
 
 	appSAMLTableSuffix             = "saml_configs"
 	AppSAMLConfigColumnAppID       = "app_id"
@@ -124,6 +126,8 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppOIDCConfigColumnClockSkew, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(AppOIDCConfigColumnAdditionalOrigins, crdb.ColumnTypeTextArray, crdb.Nullable()),
 			crdb.NewColumn(AppOIDCConfigColumnSkipNativeAppSuccessPage, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnRefreshTokenRotation, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(AppOIDCConfigColumnRefreshTokenReuseInterval, crdb.ColumnTypeInt64, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -466,6 +470,8 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.StringArray(e.AdditionalOrigins)),
 				handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, e.SkipNativeAppSuccessPage),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenRotation, e.RefreshTokenRotation),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenReuseInterval, e.RefreshTokenReuseInterval),
 			},
 			crdb.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -488,7 +494,7 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-GNHU1", "reduce.wrong.event.type %s", project.OIDCConfigChangedType)
 	}
 
-	cols := make([]handler.Column, 0, 15)
+	cols := make([]handler.Column, 0, 17)
 	if e.Version != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnVersion, *e.Version))
 	}
@@ -534,6 +540,12 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.SkipNativeAppSuccessPage != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, *e.SkipNativeAppSuccessPage))
 	}
+	if e.RefreshTokenRotation != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenRotation, *e.RefreshTokenRotation))
+	}
+	if e.RefreshTokenReuseInterval != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenReuseInterval, *e.RefreshTokenReuseInterval))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
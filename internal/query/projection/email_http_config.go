@@ -0,0 +1,257 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	EmailHTTPConfigProjectionTable = "projections.email_http_configs"
+
+	EmailHTTPConfigColumnID                  = "id"
+	EmailHTTPConfigColumnAggregateID         = "aggregate_id"
+	EmailHTTPConfigColumnCreationDate        = "creation_date"
+	EmailHTTPConfigColumnChangeDate          = "change_date"
+	EmailHTTPConfigColumnSequence            = "sequence"
+	EmailHTTPConfigColumnState               = "state"
+	EmailHTTPConfigColumnResourceOwner       = "resource_owner"
+	EmailHTTPConfigColumnInstanceID          = "instance_id"
+	EmailHTTPConfigColumnPreset              = "preset"
+	EmailHTTPConfigColumnEndpoint            = "endpoint"
+	EmailHTTPConfigColumnContentType         = "content_type"
+	EmailHTTPConfigColumnBodyTemplate        = "body_template"
+	EmailHTTPConfigColumnAuthHeader          = "auth_header"
+	EmailHTTPConfigColumnAPIKey              = "api_key"
+	EmailHTTPConfigColumnSuccessStatusCodes  = "success_status_codes"
+	EmailHTTPConfigColumnSuccessBodyContains = "success_body_contains"
+	EmailHTTPConfigColumnSenderAddress       = "sender_address"
+	EmailHTTPConfigColumnSenderName          = "sender_name"
+)
+
+type emailHTTPConfigProjection struct {
+	crdb.StatementHandler
+}
+
+func newEmailHTTPConfigProjection(ctx context.Context, config crdb.StatementHandlerConfig) *emailHTTPConfigProjection {
+	p := new(emailHTTPConfigProjection)
+	config.ProjectionName = EmailHTTPConfigProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(EmailHTTPConfigColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnAggregateID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(EmailHTTPConfigColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(EmailHTTPConfigColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(EmailHTTPConfigColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(EmailHTTPConfigColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnPreset, crdb.ColumnTypeEnum),
+			crdb.NewColumn(EmailHTTPConfigColumnEndpoint, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnContentType, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnBodyTemplate, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnAuthHeader, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnAPIKey, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(EmailHTTPConfigColumnSuccessStatusCodes, crdb.ColumnTypeEnumArray, crdb.Nullable()),
+			crdb.NewColumn(EmailHTTPConfigColumnSuccessBodyContains, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnSenderAddress, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailHTTPConfigColumnSenderName, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(EmailHTTPConfigColumnInstanceID, EmailHTTPConfigColumnID),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *emailHTTPConfigProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.EmailHTTPConfigAddedEventType,
+					Reduce: p.reduceEmailHTTPConfigAdded,
+				},
+				{
+					Event:  instance.EmailHTTPConfigChangedEventType,
+					Reduce: p.reduceEmailHTTPConfigChanged,
+				},
+				{
+					Event:  instance.EmailHTTPConfigAPIKeyChangedEventType,
+					Reduce: p.reduceEmailHTTPConfigAPIKeyChanged,
+				},
+				{
+					Event:  instance.EmailHTTPConfigActivatedEventType,
+					Reduce: p.reduceEmailHTTPConfigActivated,
+				},
+				{
+					Event:  instance.EmailHTTPConfigDeactivatedEventType,
+					Reduce: p.reduceEmailHTTPConfigDeactivated,
+				},
+				{
+					Event:  instance.EmailHTTPConfigRemovedEventType,
+					Reduce: p.reduceEmailHTTPConfigRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(EmailHTTPConfigColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *emailHTTPConfigProjection) reduceEmailHTTPConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailHTTPConfigAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eh5ad", "reduce.wrong.event.type %s", instance.EmailHTTPConfigAddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EmailHTTPConfigColumnID, e.ID),
+			handler.NewCol(EmailHTTPConfigColumnAggregateID, e.Aggregate().ID),
+			handler.NewCol(EmailHTTPConfigColumnCreationDate, e.CreationDate()),
+			handler.NewCol(EmailHTTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(EmailHTTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(EmailHTTPConfigColumnState, domain.EmailHTTPConfigStateInactive),
+			handler.NewCol(EmailHTTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(EmailHTTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(EmailHTTPConfigColumnPreset, e.Preset),
+			handler.NewCol(EmailHTTPConfigColumnEndpoint, e.Endpoint),
+			handler.NewCol(EmailHTTPConfigColumnContentType, e.ContentType),
+			handler.NewCol(EmailHTTPConfigColumnBodyTemplate, e.BodyTemplate),
+			handler.NewCol(EmailHTTPConfigColumnAuthHeader, e.AuthHeader),
+			handler.NewCol(EmailHTTPConfigColumnAPIKey, e.APIKey),
+			handler.NewCol(EmailHTTPConfigColumnSuccessStatusCodes, database.EnumArray[int32](e.SuccessStatusCodes)),
+			handler.NewCol(EmailHTTPConfigColumnSuccessBodyContains, e.SuccessBodyContains),
+			handler.NewCol(EmailHTTPConfigColumnSenderAddress, e.SenderAddress),
+			handler.NewCol(EmailHTTPConfigColumnSenderName, e.SenderName),
+		},
+	), nil
+}
+
+func (p *emailHTTPConfigProjection) reduceEmailHTTPConfigChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailHTTPConfigChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eh3cg", "reduce.wrong.event.type %s", instance.EmailHTTPConfigChangedEventType)
+	}
+	columns := []handler.Column{
+		handler.NewCol(EmailHTTPConfigColumnChangeDate, e.CreationDate()),
+		handler.NewCol(EmailHTTPConfigColumnSequence, e.Sequence()),
+	}
+	if e.Preset != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnPreset, *e.Preset))
+	}
+	if e.Endpoint != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnEndpoint, *e.Endpoint))
+	}
+	if e.ContentType != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnContentType, *e.ContentType))
+	}
+	if e.BodyTemplate != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnBodyTemplate, *e.BodyTemplate))
+	}
+	if e.AuthHeader != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnAuthHeader, *e.AuthHeader))
+	}
+	if e.SuccessStatusCodes != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnSuccessStatusCodes, database.EnumArray[int32](*e.SuccessStatusCodes)))
+	}
+	if e.SuccessBodyContains != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnSuccessBodyContains, *e.SuccessBodyContains))
+	}
+	if e.SenderAddress != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnSenderAddress, *e.SenderAddress))
+	}
+	if e.SenderName != nil {
+		columns = append(columns, handler.NewCol(EmailHTTPConfigColumnSenderName, *e.SenderName))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		columns,
+		[]handler.Condition{
+			handler.NewCond(EmailHTTPConfigColumnID, e.ID),
+			handler.NewCond(EmailHTTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *emailHTTPConfigProjection) reduceEmailHTTPConfigAPIKeyChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailHTTPConfigAPIKeyChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eh8ak", "reduce.wrong.event.type %s", instance.EmailHTTPConfigAPIKeyChangedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EmailHTTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(EmailHTTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(EmailHTTPConfigColumnAPIKey, e.APIKey),
+		},
+		[]handler.Condition{
+			handler.NewCond(EmailHTTPConfigColumnID, e.ID),
+			handler.NewCond(EmailHTTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *emailHTTPConfigProjection) reduceEmailHTTPConfigActivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailHTTPConfigActivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eh2av", "reduce.wrong.event.type %s", instance.EmailHTTPConfigActivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EmailHTTPConfigColumnState, domain.EmailHTTPConfigStateActive),
+			handler.NewCol(EmailHTTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(EmailHTTPConfigColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(EmailHTTPConfigColumnID, e.ID),
+			handler.NewCond(EmailHTTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *emailHTTPConfigProjection) reduceEmailHTTPConfigDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailHTTPConfigDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eh6dv", "reduce.wrong.event.type %s", instance.EmailHTTPConfigDeactivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EmailHTTPConfigColumnState, domain.EmailHTTPConfigStateInactive),
+			handler.NewCol(EmailHTTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(EmailHTTPConfigColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(EmailHTTPConfigColumnID, e.ID),
+			handler.NewCond(EmailHTTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *emailHTTPConfigProjection) reduceEmailHTTPConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailHTTPConfigRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eh4rm", "reduce.wrong.event.type %s", instance.EmailHTTPConfigRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(EmailHTTPConfigColumnID, e.ID),
+			handler.NewCond(EmailHTTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
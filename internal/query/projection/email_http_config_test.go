@@ -0,0 +1,224 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestEmailHTTPConfigProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceEmailHTTPConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailHTTPConfigAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id",
+						"preset": 1,
+						"apiKey": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id",
+							"crypted": "Y3J5cHRlZA=="
+						},
+						"successStatusCodes": [202],
+						"senderAddress": "sender",
+						"senderName": "name"
+					}`),
+				), instance.EmailHTTPConfigAddedEventMapper),
+			},
+			reduce: (&emailHTTPConfigProjection{}).reduceEmailHTTPConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.email_http_configs (id, aggregate_id, creation_date, change_date, sequence, state, resource_owner, instance_id, preset, endpoint, content_type, body_template, auth_header, api_key, success_status_codes, success_body_contains, sender_address, sender_name) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)",
+							expectedArgs: []interface{}{
+								"id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.EmailHTTPConfigStateInactive,
+								"ro-id",
+								"instance-id",
+								domain.EmailHTTPPresetSendGrid,
+								"",
+								"",
+								"",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "RSA-265",
+									KeyID:      "key-id",
+									Crypted:    []byte("crypted"),
+								},
+								database.EnumArray[int32]{202},
+								"",
+								"sender",
+								"name",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceEmailHTTPConfigChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailHTTPConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id",
+						"preset": 2,
+						"endpoint": "https://api.mailgun.net/v3/acme.ch/messages",
+						"successStatusCodes": []
+					}`),
+				), instance.EmailHTTPConfigChangedEventMapper),
+			},
+			reduce: (&emailHTTPConfigProjection{}).reduceEmailHTTPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.email_http_configs SET (change_date, sequence, preset, endpoint, success_status_codes) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.EmailHTTPPresetMailgun,
+								"https://api.mailgun.net/v3/acme.ch/messages",
+								database.EnumArray[int32]{},
+								"id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceEmailHTTPConfigActivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailHTTPConfigActivatedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id"
+					}`),
+				), instance.EmailHTTPConfigActivatedEventMapper),
+			},
+			reduce: (&emailHTTPConfigProjection{}).reduceEmailHTTPConfigActivated,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.email_http_configs SET (state, change_date, sequence) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								domain.EmailHTTPConfigStateActive,
+								anyArg{},
+								uint64(15),
+								"id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceEmailHTTPConfigRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailHTTPConfigRemovedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id"
+					}`),
+				), instance.EmailHTTPConfigRemovedEventMapper),
+			},
+			reduce: (&emailHTTPConfigProjection{}).reduceEmailHTTPConfigRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.email_http_configs WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(EmailHTTPConfigColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.email_http_configs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, EmailHTTPConfigProjectionTable, tt.want)
+		})
+	}
+}
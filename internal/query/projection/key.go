@@ -121,6 +121,14 @@ func (p *keyProjection) reducers() []handler.AggregateReducer {
 					Event:  keypair.AddedCertificateEventType,
 					Reduce: p.reduceCertificateAdded,
 				},
+				{
+					Event:  keypair.RetiredEventType,
+					Reduce: p.reduceKeyPairRetired,
+				},
+				{
+					Event:  keypair.RevokedEventType,
+					Reduce: p.reduceKeyPairRevoked,
+				},
 			},
 		},
 		{
@@ -214,3 +222,70 @@ func (p *keyProjection) reduceCertificateAdded(event eventstore.Event) (*handler
 
 	return crdb.NewMultiStatement(e, creates...), nil
 }
+
+func (p *keyProjection) reduceKeyPairRetired(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*keypair.RetiredEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ohgh6", "reduce.wrong.event.type %s", keypair.RetiredEventType)
+	}
+	updates := []func(eventstore.Event) crdb.Exec{
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(KeyColumnChangeDate, e.CreationDate()),
+				handler.NewCol(KeyColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(KeyColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(KeyPrivateColumnExpiry, e.PrivateKeyExpiry),
+			},
+			[]handler.Condition{
+				handler.NewCond(KeyPrivateColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyPrivateColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(privateKeyTableSuffix),
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(KeyPublicColumnExpiry, e.PublicKeyExpiry),
+			},
+			[]handler.Condition{
+				handler.NewCond(KeyPublicColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyPublicColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(publicKeyTableSuffix),
+		),
+	}
+	if !e.CertificateExpiry.IsZero() {
+		updates = append(updates, crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(CertificateColumnExpiry, e.CertificateExpiry),
+			},
+			[]handler.Condition{
+				handler.NewCond(CertificateColumnID, e.Aggregate().ID),
+				handler.NewCond(CertificateColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(certificateTableSuffix),
+		))
+	}
+	return crdb.NewMultiStatement(e, updates...), nil
+}
+
+func (p *keyProjection) reduceKeyPairRevoked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*keypair.RevokedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Quoo9", "reduce.wrong.event.type %s", keypair.RevokedEventType)
+	}
+	// private and public key and certificate are removed by cascade
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(KeyColumnID, e.Aggregate().ID),
+			handler.NewCond(KeyColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
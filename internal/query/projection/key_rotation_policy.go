@@ -0,0 +1,104 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	KeyRotationPolicyProjectionTable             = "projections.key_rotation_policies"
+	KeyRotationPolicyColumnInstanceID            = "instance_id"
+	KeyRotationPolicyColumnCreationDate          = "creation_date"
+	KeyRotationPolicyColumnChangeDate            = "change_date"
+	KeyRotationPolicyColumnSequence              = "sequence"
+	KeyRotationPolicyColumnSigningKeyAlgorithm   = "signing_key_algorithm"
+	KeyRotationPolicyColumnSigningKeyLifetime    = "signing_key_lifetime"
+	KeyRotationPolicyColumnCertificateLifetime   = "certificate_lifetime"
+	KeyRotationPolicyColumnPrePublicationPeriod  = "pre_publication_period"
+	KeyRotationPolicyColumnRetirementGracePeriod = "retirement_grace_period"
+)
+
+type keyRotationPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newKeyRotationPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *keyRotationPolicyProjection {
+	p := new(keyRotationPolicyProjection)
+	config.ProjectionName = KeyRotationPolicyProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(KeyRotationPolicyColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(KeyRotationPolicyColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(KeyRotationPolicyColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(KeyRotationPolicyColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(KeyRotationPolicyColumnSigningKeyAlgorithm, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(KeyRotationPolicyColumnSigningKeyLifetime, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(KeyRotationPolicyColumnCertificateLifetime, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(KeyRotationPolicyColumnPrePublicationPeriod, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(KeyRotationPolicyColumnRetirementGracePeriod, crdb.ColumnTypeInt64, crdb.Default(0)),
+		},
+			crdb.NewPrimaryKey(KeyRotationPolicyColumnInstanceID),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *keyRotationPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.KeyRotationPolicySetEventType,
+					Reduce: p.reduceKeyRotationPolicySet,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(KeyRotationPolicyColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *keyRotationPolicyProjection) reduceKeyRotationPolicySet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.KeyRotationPolicySetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eeph7", "reduce.wrong.event.type %s", instance.KeyRotationPolicySetEventType)
+	}
+	changes := []handler.Column{
+		handler.NewCol(KeyRotationPolicyColumnCreationDate, e.CreationDate()),
+		handler.NewCol(KeyRotationPolicyColumnChangeDate, e.CreationDate()),
+		handler.NewCol(KeyRotationPolicyColumnInstanceID, e.Aggregate().InstanceID),
+		handler.NewCol(KeyRotationPolicyColumnSequence, e.Sequence()),
+	}
+	if e.SigningKeyAlgorithm != nil {
+		changes = append(changes, handler.NewCol(KeyRotationPolicyColumnSigningKeyAlgorithm, *e.SigningKeyAlgorithm))
+	}
+	if e.SigningKeyLifetime != nil {
+		changes = append(changes, handler.NewCol(KeyRotationPolicyColumnSigningKeyLifetime, *e.SigningKeyLifetime))
+	}
+	if e.CertificateLifetime != nil {
+		changes = append(changes, handler.NewCol(KeyRotationPolicyColumnCertificateLifetime, *e.CertificateLifetime))
+	}
+	if e.PrePublicationPeriod != nil {
+		changes = append(changes, handler.NewCol(KeyRotationPolicyColumnPrePublicationPeriod, *e.PrePublicationPeriod))
+	}
+	if e.RetirementGracePeriod != nil {
+		changes = append(changes, handler.NewCol(KeyRotationPolicyColumnRetirementGracePeriod, *e.RetirementGracePeriod))
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(KeyRotationPolicyColumnInstanceID, ""),
+		},
+		changes,
+	), nil
+}
@@ -155,6 +155,86 @@ func TestKeyProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceKeyPairRetired",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(keypair.RetiredEventType),
+					keypair.AggregateType,
+					[]byte(`{"privateKeyExpiry": "2023-01-01T10:00:00Z", "publicKeyExpiry": "2023-01-02T10:00:00Z", "certificateExpiry": "2023-01-02T10:00:00Z"}`),
+				), keypair.RetiredEventMapper),
+			},
+			reduce: (&keyProjection{}).reduceKeyPairRetired,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("key_pair"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.keys4 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.keys4_private SET expiry = $1 WHERE (id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.keys4_public SET expiry = $1 WHERE (id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.keys4_certificate SET expiry = $1 WHERE (id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceKeyPairRevoked",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(keypair.RevokedEventType),
+					keypair.AggregateType,
+					nil,
+				), keypair.RevokedEventMapper),
+			},
+			reduce: (&keyProjection{}).reduceKeyPairRevoked,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("key_pair"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.keys4 WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	LoginPolicyTable = "projections.login_policies4"
+	LoginPolicyTable = "projections.login_policies6"
 
 	LoginPolicyIDCol                    = "aggregate_id"
 	LoginPolicyInstanceIDCol            = "instance_id"
@@ -33,6 +33,9 @@ const (
 	AllowDomainDiscovery                = "allow_domain_discovery"
 	DisableLoginWithEmail               = "disable_login_with_email"
 	DisableLoginWithPhone               = "disable_login_with_phone"
+	AllowEmailMagicLink                 = "allow_email_magic_link"
+	RiskStepUpThreshold                 = "risk_step_up_threshold"
+	RiskBlockThreshold                  = "risk_block_threshold"
 	DefaultRedirectURI                  = "default_redirect_uri"
 	PasswordCheckLifetimeCol            = "password_check_lifetime"
 	ExternalLoginCheckLifetimeCol       = "external_login_check_lifetime"
@@ -70,6 +73,9 @@ func newLoginPolicyProjection(ctx context.Context, config crdb.StatementHandlerC
 			crdb.NewColumn(AllowDomainDiscovery, crdb.ColumnTypeBool),
 			crdb.NewColumn(DisableLoginWithEmail, crdb.ColumnTypeBool),
 			crdb.NewColumn(DisableLoginWithPhone, crdb.ColumnTypeBool),
+			crdb.NewColumn(AllowEmailMagicLink, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(RiskStepUpThreshold, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(RiskBlockThreshold, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(DefaultRedirectURI, crdb.ColumnTypeText, crdb.Nullable()),
 			crdb.NewColumn(PasswordCheckLifetimeCol, crdb.ColumnTypeInt64),
 			crdb.NewColumn(ExternalLoginCheckLifetimeCol, crdb.ColumnTypeInt64),
@@ -192,6 +198,9 @@ func (p *loginPolicyProjection) reduceLoginPolicyAdded(event eventstore.Event) (
 		handler.NewCol(AllowDomainDiscovery, policyEvent.AllowDomainDiscovery),
 		handler.NewCol(DisableLoginWithEmail, policyEvent.DisableLoginWithEmail),
 		handler.NewCol(DisableLoginWithPhone, policyEvent.DisableLoginWithPhone),
+		handler.NewCol(AllowEmailMagicLink, policyEvent.AllowEmailMagicLink),
+		handler.NewCol(RiskStepUpThreshold, policyEvent.RiskStepUpThreshold),
+		handler.NewCol(RiskBlockThreshold, policyEvent.RiskBlockThreshold),
 		handler.NewCol(DefaultRedirectURI, policyEvent.DefaultRedirectURI),
 		handler.NewCol(PasswordCheckLifetimeCol, policyEvent.PasswordCheckLifetime),
 		handler.NewCol(ExternalLoginCheckLifetimeCol, policyEvent.ExternalLoginCheckLifetime),
@@ -246,6 +255,15 @@ func (p *loginPolicyProjection) reduceLoginPolicyChanged(event eventstore.Event)
 	if policyEvent.DisableLoginWithPhone != nil {
 		cols = append(cols, handler.NewCol(DisableLoginWithPhone, *policyEvent.DisableLoginWithPhone))
 	}
+	if policyEvent.AllowEmailMagicLink != nil {
+		cols = append(cols, handler.NewCol(AllowEmailMagicLink, *policyEvent.AllowEmailMagicLink))
+	}
+	if policyEvent.RiskStepUpThreshold != nil {
+		cols = append(cols, handler.NewCol(RiskStepUpThreshold, *policyEvent.RiskStepUpThreshold))
+	}
+	if policyEvent.RiskBlockThreshold != nil {
+		cols = append(cols, handler.NewCol(RiskBlockThreshold, *policyEvent.RiskBlockThreshold))
+	}
 	if policyEvent.DefaultRedirectURI != nil {
 		cols = append(cols, handler.NewCol(DefaultRedirectURI, *policyEvent.DefaultRedirectURI))
 	}
@@ -0,0 +1,171 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	MailMessageTemplateTable = "projections.mail_message_templates"
+
+	MailMessageTemplateAggregateIDCol  = "aggregate_id"
+	MailMessageTemplateInstanceIDCol   = "instance_id"
+	MailMessageTemplateCreationDateCol = "creation_date"
+	MailMessageTemplateChangeDateCol   = "change_date"
+	MailMessageTemplateSequenceCol     = "sequence"
+	MailMessageTemplateIsDefaultCol    = "is_default"
+	MailMessageTemplateMessageTypeCol  = "message_type"
+	MailMessageTemplateSubjectCol      = "subject"
+	MailMessageTemplateHTMLCol         = "html"
+	MailMessageTemplateTextCol         = "text"
+	MailMessageTemplateOwnerRemovedCol = "owner_removed"
+)
+
+type mailMessageTemplateProjection struct {
+	crdb.StatementHandler
+}
+
+func newMailMessageTemplateProjection(ctx context.Context, config crdb.StatementHandlerConfig) *mailMessageTemplateProjection {
+	p := new(mailMessageTemplateProjection)
+	config.ProjectionName = MailMessageTemplateTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(MailMessageTemplateAggregateIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(MailMessageTemplateInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(MailMessageTemplateCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(MailMessageTemplateChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(MailMessageTemplateSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(MailMessageTemplateIsDefaultCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(MailMessageTemplateMessageTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(MailMessageTemplateSubjectCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(MailMessageTemplateHTMLCol, crdb.ColumnTypeBytes, crdb.Nullable()),
+			crdb.NewColumn(MailMessageTemplateTextCol, crdb.ColumnTypeBytes, crdb.Nullable()),
+			crdb.NewColumn(MailMessageTemplateOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(MailMessageTemplateInstanceIDCol, MailMessageTemplateAggregateIDCol, MailMessageTemplateMessageTypeCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{MailMessageTemplateOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *mailMessageTemplateProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.MailTemplateMessageSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.MailTemplateMessageRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.MailTemplateMessageSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  instance.MailTemplateMessageRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(MailMessageTemplateInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *mailMessageTemplateProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	var templateEvent policy.MailTemplateMessageSetEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.MailTemplateMessageSetEvent:
+		templateEvent = e.MailTemplateMessageSetEvent
+		isDefault = false
+	case *instance.MailTemplateMessageSetEvent:
+		templateEvent = e.MailTemplateMessageSetEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Nf7dq", "reduce.wrong.event.type %v", []eventstore.EventType{org.MailTemplateMessageSetEventType, instance.MailTemplateMessageSetEventType})
+	}
+	return crdb.NewUpsertStatement(
+		&templateEvent,
+		[]handler.Column{
+			handler.NewCol(MailMessageTemplateInstanceIDCol, nil),
+			handler.NewCol(MailMessageTemplateAggregateIDCol, nil),
+			handler.NewCol(MailMessageTemplateMessageTypeCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(MailMessageTemplateAggregateIDCol, templateEvent.Aggregate().ID),
+			handler.NewCol(MailMessageTemplateInstanceIDCol, templateEvent.Aggregate().InstanceID),
+			handler.NewCol(MailMessageTemplateCreationDateCol, templateEvent.CreationDate()),
+			handler.NewCol(MailMessageTemplateChangeDateCol, templateEvent.CreationDate()),
+			handler.NewCol(MailMessageTemplateSequenceCol, templateEvent.Sequence()),
+			handler.NewCol(MailMessageTemplateIsDefaultCol, isDefault),
+			handler.NewCol(MailMessageTemplateMessageTypeCol, templateEvent.MessageType),
+			handler.NewCol(MailMessageTemplateSubjectCol, templateEvent.Subject),
+			handler.NewCol(MailMessageTemplateHTMLCol, templateEvent.HTML),
+			handler.NewCol(MailMessageTemplateTextCol, templateEvent.Text),
+		}), nil
+}
+
+func (p *mailMessageTemplateProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var templateEvent policy.MailTemplateMessageRemovedEvent
+	switch e := event.(type) {
+	case *org.MailTemplateMessageRemovedEvent:
+		templateEvent = e.MailTemplateMessageRemovedEvent
+	case *instance.MailTemplateMessageRemovedEvent:
+		templateEvent = e.MailTemplateMessageRemovedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ur2cK", "reduce.wrong.event.type %v", []eventstore.EventType{org.MailTemplateMessageRemovedEventType, instance.MailTemplateMessageRemovedEventType})
+	}
+	return crdb.NewDeleteStatement(
+		&templateEvent,
+		[]handler.Condition{
+			handler.NewCond(MailMessageTemplateAggregateIDCol, templateEvent.Aggregate().ID),
+			handler.NewCond(MailMessageTemplateInstanceIDCol, templateEvent.Aggregate().InstanceID),
+			handler.NewCond(MailMessageTemplateMessageTypeCol, templateEvent.MessageType),
+		}), nil
+}
+
+func (p *mailMessageTemplateProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-b4Lxw", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(MailMessageTemplateChangeDateCol, e.CreationDate()),
+			handler.NewCol(MailMessageTemplateSequenceCol, e.Sequence()),
+			handler.NewCol(MailMessageTemplateOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(MailMessageTemplateInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(MailMessageTemplateAggregateIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
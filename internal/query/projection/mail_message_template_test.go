@@ -0,0 +1,232 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestMailMessageTemplateProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "org.reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MailTemplateMessageSetEventType),
+					org.AggregateType,
+					[]byte(`{
+						"messageType": "InitCode",
+						"subject": "{{.Subject}}",
+						"html": "PHA+e3suVGV4dH19PC9wPg==",
+						"text": "e3suVGV4dH19"
+					}`),
+				), org.MailTemplateMessageSetEventMapper),
+			},
+			reduce: (&mailMessageTemplateProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.mail_message_templates (aggregate_id, instance_id, creation_date, change_date, sequence, is_default, message_type, subject, html, text) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (instance_id, aggregate_id, message_type) DO UPDATE SET (creation_date, change_date, sequence, is_default, subject, html, text) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.is_default, EXCLUDED.subject, EXCLUDED.html, EXCLUDED.text)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								false,
+								"InitCode",
+								"{{.Subject}}",
+								[]byte("<p>{{.Text}}</p>"),
+								[]byte("{{.Text}}"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org.reduceRemoved",
+			reduce: (&mailMessageTemplateProjection{}).reduceRemoved,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MailTemplateMessageRemovedEventType),
+					org.AggregateType,
+					[]byte(`{
+						"messageType": "InitCode"
+					}`),
+				), org.MailTemplateMessageRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.mail_message_templates WHERE (aggregate_id = $1) AND (instance_id = $2) AND (message_type = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"InitCode",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org.reduceOwnerRemoved",
+			reduce: (&mailMessageTemplateProjection{}).reduceOwnerRemoved,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.mail_message_templates SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (aggregate_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "instance.reduceSet",
+			reduce: (&mailMessageTemplateProjection{}).reduceSet,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.MailTemplateMessageSetEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"messageType": "PasswordReset",
+						"html": "PHA+e3suVGV4dH19PC9wPg=="
+					}`),
+				), instance.MailTemplateMessageSetEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.mail_message_templates (aggregate_id, instance_id, creation_date, change_date, sequence, is_default, message_type, subject, html, text) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (instance_id, aggregate_id, message_type) DO UPDATE SET (creation_date, change_date, sequence, is_default, subject, html, text) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.is_default, EXCLUDED.subject, EXCLUDED.html, EXCLUDED.text)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								true,
+								"PasswordReset",
+								"",
+								[]byte("<p>{{.Text}}</p>"),
+								[]byte(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "instance.reduceRemoved",
+			reduce: (&mailMessageTemplateProjection{}).reduceRemoved,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.MailTemplateMessageRemovedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"messageType": "PasswordReset"
+					}`),
+				), instance.MailTemplateMessageRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.mail_message_templates WHERE (aggregate_id = $1) AND (instance_id = $2) AND (message_type = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"PasswordReset",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(MailMessageTemplateInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.mail_message_templates WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, MailMessageTemplateTable, tt.want)
+		})
+	}
+}
@@ -274,7 +274,18 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyPhoneMessageType ||
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
-		template == domain.PasswordChangeMessageType
+		template == domain.PasswordChangeMessageType ||
+		template == domain.MagicLinkMessageType ||
+		template == domain.RecoveryCodeUsedMessageType ||
+		template == domain.NewDeviceLoginMessageType ||
+		template == domain.MFAAddedMessageType ||
+		template == domain.MFARemovedMessageType ||
+		template == domain.EmailChangedMessageType ||
+		template == domain.PhoneChangedMessageType ||
+		template == domain.AccountLockedMessageType ||
+		template == domain.PersonalAccessTokenAddedMessageType ||
+		template == domain.UserDeactivationWarningMessageType ||
+		template == domain.UserDeletionWarningMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
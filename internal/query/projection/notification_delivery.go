@@ -0,0 +1,214 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	NotificationDeliveryTable = "projections.notification_deliveries"
+
+	NotificationDeliveryIDCol                = "id"
+	NotificationDeliveryCreationDateCol      = "creation_date"
+	NotificationDeliveryChangeDateCol        = "change_date"
+	NotificationDeliverySequenceCol          = "sequence"
+	NotificationDeliveryResourceOwnerCol     = "resource_owner"
+	NotificationDeliveryInstanceIDCol        = "instance_id"
+	NotificationDeliveryUserIDCol            = "user_id"
+	NotificationDeliveryChannelCol           = "channel"
+	NotificationDeliveryRecipientCol         = "recipient"
+	NotificationDeliveryMessageTypeCol       = "message_type"
+	NotificationDeliveryStatusCol            = "status"
+	NotificationDeliveryProviderMessageIDCol = "provider_message_id"
+	NotificationDeliveryErrorCol             = "error"
+	NotificationDeliveryOwnerRemovedCol      = "owner_removed"
+)
+
+type notificationDeliveryProjection struct {
+	crdb.StatementHandler
+}
+
+func newNotificationDeliveryProjection(ctx context.Context, config crdb.StatementHandlerConfig) *notificationDeliveryProjection {
+	p := new(notificationDeliveryProjection)
+	config.ProjectionName = NotificationDeliveryTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(NotificationDeliveryIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationDeliveryCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationDeliveryChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationDeliverySequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(NotificationDeliveryResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationDeliveryInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationDeliveryUserIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationDeliveryChannelCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(NotificationDeliveryRecipientCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationDeliveryMessageTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationDeliveryStatusCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(NotificationDeliveryProviderMessageIDCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(NotificationDeliveryErrorCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(NotificationDeliveryOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(NotificationDeliveryInstanceIDCol, NotificationDeliveryIDCol),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{NotificationDeliveryUserIDCol})),
+			crdb.WithIndex(crdb.NewIndex("provider_message_id", []string{NotificationDeliveryProviderMessageIDCol})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{NotificationDeliveryOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *notificationDeliveryProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notification.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  notification.SentEventType,
+					Reduce: p.reduceSent,
+				},
+				{
+					Event:  notification.FailedEventType,
+					Reduce: p.reduceFailed,
+				},
+				{
+					Event:  notification.StatusChangedEventType,
+					Reduce: p.reduceStatusChanged,
+				},
+				{
+					Event:  notification.ResendRequestedEventType,
+					Reduce: p.reduceResendRequested,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationDeliveryInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationDeliveryProjection) reduceSent(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.SentEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Wc4ju", "reduce.wrong.event.type %s", notification.SentEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		append(deliveryColumns(e, &e.Delivery),
+			handler.NewCol(NotificationDeliveryStatusCol, domain.NotificationDeliveryStatusSent),
+			handler.NewCol(NotificationDeliveryProviderMessageIDCol, e.ProviderMessageID),
+		),
+	), nil
+}
+
+func (p *notificationDeliveryProjection) reduceFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.FailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-oK9fa", "reduce.wrong.event.type %s", notification.FailedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		append(deliveryColumns(e, &e.Delivery),
+			handler.NewCol(NotificationDeliveryStatusCol, domain.NotificationDeliveryStatusFailed),
+			handler.NewCol(NotificationDeliveryErrorCol, e.Error),
+		),
+	), nil
+}
+
+func deliveryColumns(event eventstore.Event, delivery *notification.Delivery) []handler.Column {
+	return []handler.Column{
+		handler.NewCol(NotificationDeliveryIDCol, event.Aggregate().ID),
+		handler.NewCol(NotificationDeliveryCreationDateCol, event.CreationDate()),
+		handler.NewCol(NotificationDeliveryChangeDateCol, event.CreationDate()),
+		handler.NewCol(NotificationDeliverySequenceCol, event.Sequence()),
+		handler.NewCol(NotificationDeliveryResourceOwnerCol, event.Aggregate().ResourceOwner),
+		handler.NewCol(NotificationDeliveryInstanceIDCol, event.Aggregate().InstanceID),
+		handler.NewCol(NotificationDeliveryUserIDCol, delivery.UserID),
+		handler.NewCol(NotificationDeliveryChannelCol, delivery.Channel),
+		handler.NewCol(NotificationDeliveryRecipientCol, delivery.Recipient),
+		handler.NewCol(NotificationDeliveryMessageTypeCol, delivery.MessageType),
+	}
+}
+
+func (p *notificationDeliveryProjection) reduceStatusChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.StatusChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Jm3qo", "reduce.wrong.event.type %s", notification.StatusChangedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationDeliveryChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationDeliverySequenceCol, e.Sequence()),
+			handler.NewCol(NotificationDeliveryStatusCol, e.Status),
+			handler.NewCol(NotificationDeliveryErrorCol, e.Reason),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationDeliveryIDCol, e.Aggregate().ID),
+			handler.NewCond(NotificationDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationDeliveryProjection) reduceResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.ResendRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ce8vr", "reduce.wrong.event.type %s", notification.ResendRequestedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationDeliveryChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationDeliverySequenceCol, e.Sequence()),
+			handler.NewCol(NotificationDeliveryStatusCol, domain.NotificationDeliveryStatusResent),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationDeliveryIDCol, e.Aggregate().ID),
+			handler.NewCond(NotificationDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationDeliveryProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Hs5gw", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationDeliveryChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationDeliverySequenceCol, e.Sequence()),
+			handler.NewCol(NotificationDeliveryOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationDeliveryResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
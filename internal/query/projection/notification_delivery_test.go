@@ -0,0 +1,253 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestNotificationDeliveryProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSent",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.SentEventType),
+					notification.AggregateType,
+					[]byte(`{
+						"userId": "user-id",
+						"channel": 0,
+						"recipient": "gigi@zitadel.com",
+						"messageType": "InitCode",
+						"triggeringAggregateId": "user-id",
+						"triggeringSequence": 5,
+						"triggeringEventType": "user.human.initialization.code.added",
+						"providerMessageId": "message-id@zitadel.com"
+					}`),
+				), notification.SentEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceSent,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_deliveries (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, channel, recipient, message_type, status, provider_message_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"user-id",
+								domain.NotificationTypeEmail,
+								"gigi@zitadel.com",
+								"InitCode",
+								domain.NotificationDeliveryStatusSent,
+								"message-id@zitadel.com",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.FailedEventType),
+					notification.AggregateType,
+					[]byte(`{
+						"userId": "user-id",
+						"channel": 1,
+						"recipient": "+41791234567",
+						"messageType": "VerifyPhone",
+						"error": "no sms provider"
+					}`),
+				), notification.FailedEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceFailed,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_deliveries (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, channel, recipient, message_type, status, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"user-id",
+								domain.NotificationTypeSms,
+								"+41791234567",
+								"VerifyPhone",
+								domain.NotificationDeliveryStatusFailed,
+								"no sms provider",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceStatusChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.StatusChangedEventType),
+					notification.AggregateType,
+					[]byte(`{
+						"status": 4,
+						"reason": "mailbox unavailable"
+					}`),
+				), notification.StatusChangedEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceStatusChanged,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, status, error) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStatusBounced,
+								"mailbox unavailable",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceResendRequested",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.ResendRequestedEventType),
+					notification.AggregateType,
+					[]byte(`{
+						"triggeringAggregateId": "user-id",
+						"triggeringSequence": 5,
+						"triggeringEventType": "user.human.phone.code.added"
+					}`),
+				), notification.ResendRequestedEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceResendRequested,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, status) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStatusResent,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org.reduceOwnerRemoved",
+			reduce: (&notificationDeliveryProjection{}).reduceOwnerRemoved,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationDeliveryInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_deliveries WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationDeliveryTable, tt.want)
+		})
+	}
+}
@@ -14,18 +14,24 @@ import (
 )
 
 const (
-	NotificationPolicyProjectionTable = "projections.notification_policies"
+	NotificationPolicyProjectionTable = "projections.notification_policies1"
 
-	NotificationPolicyColumnID             = "id"
-	NotificationPolicyColumnCreationDate   = "creation_date"
-	NotificationPolicyColumnChangeDate     = "change_date"
-	NotificationPolicyColumnResourceOwner  = "resource_owner"
-	NotificationPolicyColumnInstanceID     = "instance_id"
-	NotificationPolicyColumnSequence       = "sequence"
-	NotificationPolicyColumnStateCol       = "state"
-	NotificationPolicyColumnIsDefault      = "is_default"
-	NotificationPolicyColumnPasswordChange = "password_change"
-	NotificationPolicyColumnOwnerRemoved   = "owner_removed"
+	NotificationPolicyColumnID                       = "id"
+	NotificationPolicyColumnCreationDate             = "creation_date"
+	NotificationPolicyColumnChangeDate               = "change_date"
+	NotificationPolicyColumnResourceOwner            = "resource_owner"
+	NotificationPolicyColumnInstanceID               = "instance_id"
+	NotificationPolicyColumnSequence                 = "sequence"
+	NotificationPolicyColumnStateCol                 = "state"
+	NotificationPolicyColumnIsDefault                = "is_default"
+	NotificationPolicyColumnPasswordChange           = "password_change"
+	NotificationPolicyColumnNewDeviceLogin           = "new_device_login"
+	NotificationPolicyColumnMFAChange                = "mfa_change"
+	NotificationPolicyColumnEmailChange              = "email_change"
+	NotificationPolicyColumnPhoneChange              = "phone_change"
+	NotificationPolicyColumnAccountLocked            = "account_locked"
+	NotificationPolicyColumnPersonalAccessTokenAdded = "personal_access_token_added"
+	NotificationPolicyColumnOwnerRemoved             = "owner_removed"
 )
 
 type notificationPolicyProjection struct {
@@ -47,6 +53,12 @@ func newNotificationPolicyProjection(ctx context.Context, config crdb.StatementH
 			crdb.NewColumn(NotificationPolicyColumnStateCol, crdb.ColumnTypeEnum),
 			crdb.NewColumn(NotificationPolicyColumnIsDefault, crdb.ColumnTypeBool),
 			crdb.NewColumn(NotificationPolicyColumnPasswordChange, crdb.ColumnTypeBool),
+			crdb.NewColumn(NotificationPolicyColumnNewDeviceLogin, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnMFAChange, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnEmailChange, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnPhoneChange, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnAccountLocked, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnPersonalAccessTokenAdded, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(NotificationPolicyColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(NotificationPolicyColumnInstanceID, NotificationPolicyColumnID),
@@ -121,6 +133,12 @@ func (p *notificationPolicyProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(NotificationPolicyColumnID, policyEvent.Aggregate().ID),
 			handler.NewCol(NotificationPolicyColumnStateCol, domain.PolicyStateActive),
 			handler.NewCol(NotificationPolicyColumnPasswordChange, policyEvent.PasswordChange),
+			handler.NewCol(NotificationPolicyColumnNewDeviceLogin, policyEvent.NewDeviceLogin),
+			handler.NewCol(NotificationPolicyColumnMFAChange, policyEvent.MFAChange),
+			handler.NewCol(NotificationPolicyColumnEmailChange, policyEvent.EmailChange),
+			handler.NewCol(NotificationPolicyColumnPhoneChange, policyEvent.PhoneChange),
+			handler.NewCol(NotificationPolicyColumnAccountLocked, policyEvent.AccountLocked),
+			handler.NewCol(NotificationPolicyColumnPersonalAccessTokenAdded, policyEvent.PersonalAccessTokenAdded),
 			handler.NewCol(NotificationPolicyColumnIsDefault, isDefault),
 			handler.NewCol(NotificationPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(NotificationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
@@ -144,6 +162,24 @@ func (p *notificationPolicyProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.PasswordChange != nil {
 		cols = append(cols, handler.NewCol(NotificationPolicyColumnPasswordChange, *policyEvent.PasswordChange))
 	}
+	if policyEvent.NewDeviceLogin != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnNewDeviceLogin, *policyEvent.NewDeviceLogin))
+	}
+	if policyEvent.MFAChange != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMFAChange, *policyEvent.MFAChange))
+	}
+	if policyEvent.EmailChange != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnEmailChange, *policyEvent.EmailChange))
+	}
+	if policyEvent.PhoneChange != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnPhoneChange, *policyEvent.PhoneChange))
+	}
+	if policyEvent.AccountLocked != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnAccountLocked, *policyEvent.AccountLocked))
+	}
+	if policyEvent.PersonalAccessTokenAdded != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnPersonalAccessTokenAdded, *policyEvent.PersonalAccessTokenAdded))
+	}
 	return crdb.NewUpdateStatement(
 		&policyEvent,
 		cols,
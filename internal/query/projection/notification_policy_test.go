@@ -29,7 +29,8 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 					repository.EventType(org.NotificationPolicyAddedEventType),
 					org.AggregateType,
 					[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"mfaChange": true
 }`),
 				), org.NotificationPolicyAddedEventMapper),
 			},
@@ -41,7 +42,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.notification_policies (creation_date, change_date, sequence, id, state, password_change, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.notification_policies1 (creation_date, change_date, sequence, id, state, password_change, new_device_login, mfa_change, email_change, phone_change, account_locked, personal_access_token_added, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -50,6 +51,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 								domain.PolicyStateActive,
 								true,
 								false,
+								true,
+								false,
+								false,
+								false,
+								false,
+								false,
 								"ro-id",
 								"instance-id",
 							},
@@ -66,7 +73,8 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 					repository.EventType(org.NotificationPolicyChangedEventType),
 					org.AggregateType,
 					[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"accountLocked": true
 		}`),
 				), org.NotificationPolicyChangedEventMapper),
 			},
@@ -77,11 +85,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.notification_policies1 SET (change_date, sequence, password_change, account_locked) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								true,
+								true,
 								"agg-id",
 								"instance-id",
 							},
@@ -107,7 +116,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.notification_policies WHERE (id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.notification_policies1 WHERE (id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -133,7 +142,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.notification_policies WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.notification_policies1 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -161,7 +170,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.notification_policies (creation_date, change_date, sequence, id, state, password_change, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.notification_policies1 (creation_date, change_date, sequence, id, state, password_change, new_device_login, mfa_change, email_change, phone_change, account_locked, personal_access_token_added, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -169,6 +178,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 								true,
 								"ro-id",
 								"instance-id",
@@ -197,7 +212,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.notification_policies1 SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -227,7 +242,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedStmt: "UPDATE projections.notification_policies1 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -0,0 +1,175 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	OIDCInitialAccessTokenProjectionTable = "projections.oidc_initial_access_tokens"
+
+	OIDCInitialAccessTokenColumnID                         = "id"
+	OIDCInitialAccessTokenColumnProjectID                  = "project_id"
+	OIDCInitialAccessTokenColumnCreationDate               = "creation_date"
+	OIDCInitialAccessTokenColumnChangeDate                 = "change_date"
+	OIDCInitialAccessTokenColumnSequence                   = "sequence"
+	OIDCInitialAccessTokenColumnResourceOwner              = "resource_owner"
+	OIDCInitialAccessTokenColumnInstanceID                 = "instance_id"
+	OIDCInitialAccessTokenColumnExpiration                 = "expiration"
+	OIDCInitialAccessTokenColumnAllowedRedirectURIPrefixes = "allowed_redirect_uri_prefixes"
+	OIDCInitialAccessTokenColumnAllowedGrantTypes          = "allowed_grant_types"
+	OIDCInitialAccessTokenColumnAllowedAuthMethodTypes     = "allowed_auth_method_types"
+	OIDCInitialAccessTokenColumnOwnerRemoved               = "owner_removed"
+)
+
+type oidcInitialAccessTokenProjection struct {
+	crdb.StatementHandler
+}
+
+func newOIDCInitialAccessTokenProjection(ctx context.Context, config crdb.StatementHandlerConfig) *oidcInitialAccessTokenProjection {
+	p := new(oidcInitialAccessTokenProjection)
+	config.ProjectionName = OIDCInitialAccessTokenProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(OIDCInitialAccessTokenColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnAllowedRedirectURIPrefixes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnAllowedGrantTypes, crdb.ColumnTypeEnumArray, crdb.Nullable()),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnAllowedAuthMethodTypes, crdb.ColumnTypeEnumArray, crdb.Nullable()),
+			crdb.NewColumn(OIDCInitialAccessTokenColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(OIDCInitialAccessTokenColumnInstanceID, OIDCInitialAccessTokenColumnID),
+			crdb.WithIndex(crdb.NewIndex("project_id", []string{OIDCInitialAccessTokenColumnProjectID})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{OIDCInitialAccessTokenColumnOwnerRemoved})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *oidcInitialAccessTokenProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.OIDCInitialAccessTokenAddedType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  project.OIDCInitialAccessTokenRemovedType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(OIDCInitialAccessTokenColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *oidcInitialAccessTokenProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.OIDCInitialAccessTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Iat1a", "reduce.wrong.event.type %s", project.OIDCInitialAccessTokenAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OIDCInitialAccessTokenColumnID, e.TokenID),
+			handler.NewCol(OIDCInitialAccessTokenColumnProjectID, e.Aggregate().ID),
+			handler.NewCol(OIDCInitialAccessTokenColumnCreationDate, e.CreationDate()),
+			handler.NewCol(OIDCInitialAccessTokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(OIDCInitialAccessTokenColumnSequence, e.Sequence()),
+			handler.NewCol(OIDCInitialAccessTokenColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(OIDCInitialAccessTokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(OIDCInitialAccessTokenColumnExpiration, e.Expiration),
+			handler.NewCol(OIDCInitialAccessTokenColumnAllowedRedirectURIPrefixes, database.StringArray(e.AllowedRedirectURIPrefixes)),
+			handler.NewCol(OIDCInitialAccessTokenColumnAllowedGrantTypes, database.EnumArray[domain.OIDCGrantType](e.AllowedGrantTypes)),
+			handler.NewCol(OIDCInitialAccessTokenColumnAllowedAuthMethodTypes, database.EnumArray[domain.OIDCAuthMethodType](e.AllowedAuthMethodTypes)),
+		},
+	), nil
+}
+
+func (p *oidcInitialAccessTokenProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.OIDCInitialAccessTokenRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Iat2b", "reduce.wrong.event.type %s", project.OIDCInitialAccessTokenRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OIDCInitialAccessTokenColumnID, e.TokenID),
+			handler.NewCond(OIDCInitialAccessTokenColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *oidcInitialAccessTokenProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Iat3c", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OIDCInitialAccessTokenColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(OIDCInitialAccessTokenColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *oidcInitialAccessTokenProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Iat4d", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OIDCInitialAccessTokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(OIDCInitialAccessTokenColumnSequence, e.Sequence()),
+			handler.NewCol(OIDCInitialAccessTokenColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(OIDCInitialAccessTokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(OIDCInitialAccessTokenColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
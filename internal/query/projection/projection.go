@@ -39,6 +39,8 @@ var (
 	IDPLoginPolicyLinkProjection        *idpLoginPolicyLinkProjection
 	IDPTemplateProjection               *idpTemplateProjection
 	MailTemplateProjection              *mailTemplateProjection
+	MailMessageTemplateProjection       *mailMessageTemplateProjection
+	NotificationDeliveryProjection      *notificationDeliveryProjection
 	MessageTextProjection               *messageTextProjection
 	CustomTextProjection                *customTextProjection
 	UserProjection                      *userProjection
@@ -50,6 +52,8 @@ var (
 	ProjectGrantMemberProjection        *projectGrantMemberProjection
 	AuthNKeyProjection                  *authNKeyProjection
 	PersonalAccessTokenProjection       *personalAccessTokenProjection
+	OIDCInitialAccessTokenProjection    *oidcInitialAccessTokenProjection
+	RefreshTokenFamilyProjection        *refreshTokenFamilyProjection
 	UserGrantProjection                 *userGrantProjection
 	UserMetadataProjection              *userMetadataProjection
 	UserAuthMethodProjection            *userAuthMethodProjection
@@ -57,15 +61,24 @@ var (
 	SecretGeneratorProjection           *secretGeneratorProjection
 	SMTPConfigProjection                *smtpConfigProjection
 	SMSConfigProjection                 *smsConfigProjection
+	EmailHTTPConfigProjection           *emailHTTPConfigProjection
 	OIDCSettingsProjection              *oidcSettingsProjection
 	DebugNotificationProviderProjection *debugNotificationProviderProjection
 	KeyProjection                       *keyProjection
 	SecurityPolicyProjection            *securityPolicyProjection
+	KeyRotationPolicyProjection         *keyRotationPolicyProjection
 	NotificationPolicyProjection        *notificationPolicyProjection
 	NotificationsProjection             interface{}
 	NotificationsQuotaProjection        interface{}
 	DeviceAuthProjection                *deviceAuthProjection
 	SessionProjection                   *sessionProjection
+	ActionSecretProjection              *actionSecretProjection
+	ActionKeyValueProjection            *actionKeyValueProjection
+	UserLifecyclePolicyProjection       *userLifecyclePolicyProjection
+	UserSchemaPolicyProjection          *userSchemaPolicyProjection
+	UserBulkJobProjection               *userBulkJobProjection
+	UserActivityProjection              *userActivityProjection
+	UserDataKeyProjection               *userDataKeyProjection
 )
 
 type projection interface {
@@ -118,6 +131,8 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	IDPLoginPolicyLinkProjection = newIDPLoginPolicyLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_login_policy_links"]))
 	IDPTemplateProjection = newIDPTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_templates"]))
 	MailTemplateProjection = newMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_templates"]))
+	MailMessageTemplateProjection = newMailMessageTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_message_templates"]))
+	NotificationDeliveryProjection = newNotificationDeliveryProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_deliveries"]))
 	MessageTextProjection = newMessageTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["message_texts"]))
 	CustomTextProjection = newCustomTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_texts"]))
 	UserProjection = newUserProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["users"]))
@@ -129,6 +144,8 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	ProjectGrantMemberProjection = newProjectGrantMemberProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["project_grant_members"]))
 	AuthNKeyProjection = newAuthNKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["authn_keys"]))
 	PersonalAccessTokenProjection = newPersonalAccessTokenProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["personal_access_tokens"]))
+	OIDCInitialAccessTokenProjection = newOIDCInitialAccessTokenProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_initial_access_tokens"]))
+	RefreshTokenFamilyProjection = newRefreshTokenFamilyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["refresh_token_families"]))
 	UserGrantProjection = newUserGrantProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_grants"]))
 	UserMetadataProjection = newUserMetadataProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_metadata"]))
 	UserAuthMethodProjection = newUserAuthMethodProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_auth_method"]))
@@ -136,13 +153,22 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	SecretGeneratorProjection = newSecretGeneratorProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["secret_generators"]))
 	SMTPConfigProjection = newSMTPConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["smtp_configs"]))
 	SMSConfigProjection = newSMSConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sms_config"]))
+	EmailHTTPConfigProjection = newEmailHTTPConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["email_http_configs"]))
 	OIDCSettingsProjection = newOIDCSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_settings"]))
 	DebugNotificationProviderProjection = newDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
+	KeyRotationPolicyProjection = newKeyRotationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["key_rotation_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
+	ActionSecretProjection = newActionSecretProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["action_secrets"]))
+	ActionKeyValueProjection = newActionKeyValueProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["action_key_values"]))
+	UserLifecyclePolicyProjection = newUserLifecyclePolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_lifecycle_policies"]))
+	UserSchemaPolicyProjection = newUserSchemaPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_schema_policies"]))
+	UserActivityProjection = newUserActivityProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_activities"]))
+	UserBulkJobProjection = newUserBulkJobProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_bulk_jobs"]))
+	UserDataKeyProjection = newUserDataKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_data_keys"]))
 	newProjectionsList()
 	return nil
 }
@@ -215,6 +241,8 @@ func newProjectionsList() {
 		IDPUserLinkProjection,
 		IDPLoginPolicyLinkProjection,
 		MailTemplateProjection,
+		MailMessageTemplateProjection,
+		NotificationDeliveryProjection,
 		MessageTextProjection,
 		CustomTextProjection,
 		UserProjection,
@@ -226,6 +254,8 @@ func newProjectionsList() {
 		ProjectGrantMemberProjection,
 		AuthNKeyProjection,
 		PersonalAccessTokenProjection,
+		OIDCInitialAccessTokenProjection,
+		RefreshTokenFamilyProjection,
 		UserGrantProjection,
 		UserMetadataProjection,
 		UserAuthMethodProjection,
@@ -233,12 +263,21 @@ func newProjectionsList() {
 		SecretGeneratorProjection,
 		SMTPConfigProjection,
 		SMSConfigProjection,
+		EmailHTTPConfigProjection,
 		OIDCSettingsProjection,
 		DebugNotificationProviderProjection,
 		KeyProjection,
 		SecurityPolicyProjection,
+		KeyRotationPolicyProjection,
 		NotificationPolicyProjection,
 		DeviceAuthProjection,
 		SessionProjection,
+		ActionSecretProjection,
+		ActionKeyValueProjection,
+		UserLifecyclePolicyProjection,
+		UserActivityProjection,
+		UserSchemaPolicyProjection,
+		UserBulkJobProjection,
+		UserDataKeyProjection,
 	}
 }
@@ -0,0 +1,247 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	RefreshTokenFamilyProjectionTable = "projections.refresh_token_families"
+
+	RefreshTokenFamilyColumnID             = "id"
+	RefreshTokenFamilyColumnCreationDate   = "creation_date"
+	RefreshTokenFamilyColumnChangeDate     = "change_date"
+	RefreshTokenFamilyColumnSequence       = "sequence"
+	RefreshTokenFamilyColumnResourceOwner  = "resource_owner"
+	RefreshTokenFamilyColumnInstanceID     = "instance_id"
+	RefreshTokenFamilyColumnUserID         = "user_id"
+	RefreshTokenFamilyColumnClientID       = "client_id"
+	RefreshTokenFamilyColumnUserAgentID    = "user_agent_id"
+	RefreshTokenFamilyColumnAuthTime       = "auth_time"
+	RefreshTokenFamilyColumnIdleExpiration = "idle_expiration"
+	RefreshTokenFamilyColumnExpiration     = "expiration"
+	RefreshTokenFamilyColumnScopes         = "scopes"
+	RefreshTokenFamilyColumnAudience       = "audience"
+	RefreshTokenFamilyColumnOwnerRemoved   = "owner_removed"
+)
+
+type refreshTokenFamilyProjection struct {
+	crdb.StatementHandler
+}
+
+func newRefreshTokenFamilyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *refreshTokenFamilyProjection {
+	p := new(refreshTokenFamilyProjection)
+	config.ProjectionName = RefreshTokenFamilyProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(RefreshTokenFamilyColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenFamilyColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenFamilyColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenFamilyColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(RefreshTokenFamilyColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenFamilyColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenFamilyColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenFamilyColumnClientID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenFamilyColumnUserAgentID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenFamilyColumnAuthTime, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenFamilyColumnIdleExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenFamilyColumnExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenFamilyColumnScopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenFamilyColumnAudience, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenFamilyColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(RefreshTokenFamilyColumnInstanceID, RefreshTokenFamilyColumnID),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{RefreshTokenFamilyColumnUserID})),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{RefreshTokenFamilyColumnResourceOwner})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{RefreshTokenFamilyColumnOwnerRemoved})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *refreshTokenFamilyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.HumanRefreshTokenAddedType,
+					Reduce: p.reduceRefreshTokenAdded,
+				},
+				{
+					Event:  user.HumanRefreshTokenRenewedType,
+					Reduce: p.reduceRefreshTokenRenewed,
+				},
+				{
+					Event:  user.HumanRefreshTokenRemovedType,
+					Reduce: p.reduceRefreshTokenRemoved,
+				},
+				{
+					Event:  user.HumanRefreshTokenReuseDetectedType,
+					Reduce: p.reduceRefreshTokenRemoved,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: p.reduceHumanSignedOut,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserInactive,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserInactive,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserInactive,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(RefreshTokenFamilyColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *refreshTokenFamilyProjection) reduceRefreshTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Iech5", "reduce.wrong.event.type %s", user.HumanRefreshTokenAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RefreshTokenFamilyColumnID, e.TokenID),
+			handler.NewCol(RefreshTokenFamilyColumnCreationDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenFamilyColumnChangeDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenFamilyColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(RefreshTokenFamilyColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(RefreshTokenFamilyColumnSequence, e.Sequence()),
+			handler.NewCol(RefreshTokenFamilyColumnUserID, e.Aggregate().ID),
+			handler.NewCol(RefreshTokenFamilyColumnClientID, e.ClientID),
+			handler.NewCol(RefreshTokenFamilyColumnUserAgentID, e.UserAgentID),
+			handler.NewCol(RefreshTokenFamilyColumnAuthTime, e.AuthTime),
+			handler.NewCol(RefreshTokenFamilyColumnIdleExpiration, e.CreationDate().Add(e.IdleExpiration)),
+			handler.NewCol(RefreshTokenFamilyColumnExpiration, e.CreationDate().Add(e.Expiration)),
+			handler.NewCol(RefreshTokenFamilyColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(RefreshTokenFamilyColumnAudience, database.StringArray(e.Audience)),
+		},
+	), nil
+}
+
+func (p *refreshTokenFamilyProjection) reduceRefreshTokenRenewed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRenewedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ooC5u", "reduce.wrong.event.type %s", user.HumanRefreshTokenRenewedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RefreshTokenFamilyColumnChangeDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenFamilyColumnSequence, e.Sequence()),
+			handler.NewCol(RefreshTokenFamilyColumnIdleExpiration, e.CreationDate().Add(e.IdleExpiration)),
+		},
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenFamilyColumnID, e.TokenID),
+			handler.NewCond(RefreshTokenFamilyColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *refreshTokenFamilyProjection) reduceRefreshTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var tokenID string
+	switch e := event.(type) {
+	case *user.HumanRefreshTokenRemovedEvent:
+		tokenID = e.TokenID
+	case *user.HumanRefreshTokenReuseDetectedEvent:
+		tokenID = e.TokenID
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-aiW4e", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanRefreshTokenRemovedType, user.HumanRefreshTokenReuseDetectedType})
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenFamilyColumnID, tokenID),
+			handler.NewCond(RefreshTokenFamilyColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *refreshTokenFamilyProjection) reduceHumanSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Quai3", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenFamilyColumnUserID, e.Aggregate().ID),
+			handler.NewCond(RefreshTokenFamilyColumnUserAgentID, e.UserAgentID),
+			handler.NewCond(RefreshTokenFamilyColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *refreshTokenFamilyProjection) reduceUserInactive(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent,
+		*user.UserDeactivatedEvent,
+		*user.UserRemovedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eeth6", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType, user.UserRemovedType})
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenFamilyColumnUserID, event.Aggregate().ID),
+			handler.NewCond(RefreshTokenFamilyColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *refreshTokenFamilyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Soh2a", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RefreshTokenFamilyColumnChangeDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenFamilyColumnSequence, e.Sequence()),
+			handler.NewCol(RefreshTokenFamilyColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenFamilyColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(RefreshTokenFamilyColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,275 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestRefreshTokenFamilyProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRefreshTokenAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenAddedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "tokenID", "clientId": "clientID", "userAgentId": "agentID", "audience": ["clientID"], "scopes": ["openid", "offline_access"], "authTime": "2023-01-01T00:00:00Z", "idleExpiration": 3600000000000, "expiration": 86400000000000}`),
+				), user.HumanRefreshTokenAddedEventMapper),
+			},
+			reduce: (&refreshTokenFamilyProjection{}).reduceRefreshTokenAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.refresh_token_families (id, creation_date, change_date, resource_owner, instance_id, sequence, user_id, client_id, user_agent_id, auth_time, idle_expiration, expiration, scopes, audience) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
+							expectedArgs: []interface{}{
+								"tokenID",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								"agg-id",
+								"clientID",
+								"agentID",
+								time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+								anyArg{},
+								anyArg{},
+								database.StringArray{"openid", "offline_access"},
+								database.StringArray{"clientID"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRenewed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenRenewedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "tokenID", "refreshToken": "token", "idleExpiration": 3600000000000}`),
+				), user.HumanRefreshTokenRenewedEventEventMapper),
+			},
+			reduce: (&refreshTokenFamilyProjection{}).reduceRefreshTokenRenewed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.refresh_token_families SET (change_date, sequence, idle_expiration) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"tokenID",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenRemovedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "tokenID"}`),
+				), user.HumanRefreshTokenRemovedEventEventMapper),
+			},
+			reduce: (&refreshTokenFamilyProjection{}).reduceRefreshTokenRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_token_families WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"tokenID",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRemoved reuse detected",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenReuseDetectedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "tokenID", "clientId": "clientID", "userAgentId": "agentID"}`),
+				), user.HumanRefreshTokenReuseDetectedEventMapper),
+			},
+			reduce: (&refreshTokenFamilyProjection{}).reduceRefreshTokenRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_token_families WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"tokenID",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceHumanSignedOut",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanSignedOutType),
+					user.AggregateType,
+					[]byte(`{"userAgentID": "agentID"}`),
+				), user.HumanSignedOutEventMapper),
+			},
+			reduce: (&refreshTokenFamilyProjection{}).reduceHumanSignedOut,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_token_families WHERE (user_id = $1) AND (user_agent_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"agentID",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserInactive",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserLockedType),
+					user.AggregateType,
+					nil,
+				), user.UserLockedEventMapper),
+			},
+			reduce: (&refreshTokenFamilyProjection{}).reduceUserInactive,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_token_families WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&refreshTokenFamilyProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.refresh_token_families SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(RefreshTokenFamilyColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_token_families WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, RefreshTokenFamilyProjectionTable, tt.want)
+		})
+	}
+}
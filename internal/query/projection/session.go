@@ -14,22 +14,24 @@ import (
 )
 
 const (
-	SessionsProjectionTable = "projections.sessions1"
+	SessionsProjectionTable = "projections.sessions3"
 
-	SessionColumnID                = "id"
-	SessionColumnCreationDate      = "creation_date"
-	SessionColumnChangeDate        = "change_date"
-	SessionColumnSequence          = "sequence"
-	SessionColumnState             = "state"
-	SessionColumnResourceOwner     = "resource_owner"
-	SessionColumnInstanceID        = "instance_id"
-	SessionColumnCreator           = "creator"
-	SessionColumnUserID            = "user_id"
-	SessionColumnUserCheckedAt     = "user_checked_at"
-	SessionColumnPasswordCheckedAt = "password_checked_at"
-	SessionColumnPasskeyCheckedAt  = "passkey_checked_at"
-	SessionColumnMetadata          = "metadata"
-	SessionColumnTokenID           = "token_id"
+	SessionColumnID                    = "id"
+	SessionColumnCreationDate          = "creation_date"
+	SessionColumnChangeDate            = "change_date"
+	SessionColumnSequence              = "sequence"
+	SessionColumnState                 = "state"
+	SessionColumnResourceOwner         = "resource_owner"
+	SessionColumnInstanceID            = "instance_id"
+	SessionColumnCreator               = "creator"
+	SessionColumnUserID                = "user_id"
+	SessionColumnUserCheckedAt         = "user_checked_at"
+	SessionColumnPasswordCheckedAt     = "password_checked_at"
+	SessionColumnPasskeyCheckedAt      = "passkey_checked_at"
+	SessionColumnMagicLinkCheckedAt    = "magic_link_checked_at"
+	SessionColumnRecoveryCodeCheckedAt = "recovery_code_checked_at"
+	SessionColumnMetadata              = "metadata"
+	SessionColumnTokenID               = "token_id"
 )
 
 type sessionProjection struct {
@@ -54,6 +56,8 @@ func newSessionProjection(ctx context.Context, config crdb.StatementHandlerConfi
 			crdb.NewColumn(SessionColumnUserCheckedAt, crdb.ColumnTypeTimestamp, crdb.Nullable()),
 			crdb.NewColumn(SessionColumnPasswordCheckedAt, crdb.ColumnTypeTimestamp, crdb.Nullable()),
 			crdb.NewColumn(SessionColumnPasskeyCheckedAt, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(SessionColumnMagicLinkCheckedAt, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(SessionColumnRecoveryCodeCheckedAt, crdb.ColumnTypeTimestamp, crdb.Nullable()),
 			crdb.NewColumn(SessionColumnMetadata, crdb.ColumnTypeJSONB, crdb.Nullable()),
 			crdb.NewColumn(SessionColumnTokenID, crdb.ColumnTypeText, crdb.Nullable()),
 		},
@@ -85,6 +89,14 @@ func (p *sessionProjection) reducers() []handler.AggregateReducer {
 					Event:  session.PasskeyCheckedType,
 					Reduce: p.reducePasskeyChecked,
 				},
+				{
+					Event:  session.MagicLinkCheckedType,
+					Reduce: p.reduceMagicLinkChecked,
+				},
+				{
+					Event:  session.RecoveryCodeCheckedType,
+					Reduce: p.reduceRecoveryCodeChecked,
+				},
 				{
 					Event:  session.TokenSetType,
 					Reduce: p.reduceTokenSet,
@@ -201,6 +213,46 @@ func (p *sessionProjection) reducePasskeyChecked(event eventstore.Event) (*handl
 	), nil
 }
 
+func (p *sessionProjection) reduceMagicLinkChecked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.MagicLinkCheckedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-oo8Ae", "reduce.wrong.event.type %s", session.MagicLinkCheckedType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SessionColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SessionColumnSequence, e.Sequence()),
+			handler.NewCol(SessionColumnMagicLinkCheckedAt, e.CheckedAt),
+		},
+		[]handler.Condition{
+			handler.NewCond(SessionColumnID, e.Aggregate().ID),
+			handler.NewCond(SessionColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *sessionProjection) reduceRecoveryCodeChecked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.RecoveryCodeCheckedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Xk4rb", "reduce.wrong.event.type %s", session.RecoveryCodeCheckedType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SessionColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SessionColumnSequence, e.Sequence()),
+			handler.NewCol(SessionColumnRecoveryCodeCheckedAt, e.CheckedAt),
+		},
+		[]handler.Condition{
+			handler.NewCond(SessionColumnID, e.Aggregate().ID),
+			handler.NewCond(SessionColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *sessionProjection) reduceTokenSet(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*session.TokenSetEvent)
 	if !ok {
@@ -3,28 +3,39 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
 const (
-	SMTPConfigProjectionTable = "projections.smtp_configs"
-
-	SMTPConfigColumnAggregateID   = "aggregate_id"
-	SMTPConfigColumnCreationDate  = "creation_date"
-	SMTPConfigColumnChangeDate    = "change_date"
-	SMTPConfigColumnSequence      = "sequence"
-	SMTPConfigColumnResourceOwner = "resource_owner"
-	SMTPConfigColumnInstanceID    = "instance_id"
-	SMTPConfigColumnTLS           = "tls"
-	SMTPConfigColumnSenderAddress = "sender_address"
-	SMTPConfigColumnSenderName    = "sender_name"
-	SMTPConfigColumnSMTPHost      = "host"
-	SMTPConfigColumnSMTPUser      = "username"
-	SMTPConfigColumnSMTPPassword  = "password"
+	SMTPConfigProjectionTable = "projections.smtp_configs1"
+
+	SMTPConfigColumnID                   = "id"
+	SMTPConfigColumnAggregateID          = "aggregate_id"
+	SMTPConfigColumnCreationDate         = "creation_date"
+	SMTPConfigColumnChangeDate           = "change_date"
+	SMTPConfigColumnSequence             = "sequence"
+	SMTPConfigColumnResourceOwner        = "resource_owner"
+	SMTPConfigColumnInstanceID           = "instance_id"
+	SMTPConfigColumnPriority             = "priority"
+	SMTPConfigColumnTLS                  = "tls"
+	SMTPConfigColumnSenderAddress        = "sender_address"
+	SMTPConfigColumnSenderName           = "sender_name"
+	SMTPConfigColumnSMTPHost             = "host"
+	SMTPConfigColumnSMTPUser             = "username"
+	SMTPConfigColumnSMTPPassword         = "password"
+	SMTPConfigColumnAuthType             = "auth_type"
+	SMTPConfigColumnXOAuth2TokenEndpoint = "xoauth2_token_endpoint"
+	SMTPConfigColumnXOAuth2ClientID      = "xoauth2_client_id"
+	SMTPConfigColumnXOAuth2Scopes        = "xoauth2_scopes"
+	SMTPConfigColumnOwnerRemoved         = "owner_removed"
 )
 
 type smtpConfigProjection struct {
@@ -37,20 +48,29 @@ func newSMTPConfigProjection(ctx context.Context, config crdb.StatementHandlerCo
 	config.Reducers = p.reducers()
 	config.InitCheck = crdb.NewTableCheck(
 		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(SMTPConfigColumnID, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnAggregateID, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnCreationDate, crdb.ColumnTypeTimestamp),
 			crdb.NewColumn(SMTPConfigColumnChangeDate, crdb.ColumnTypeTimestamp),
 			crdb.NewColumn(SMTPConfigColumnSequence, crdb.ColumnTypeInt64),
 			crdb.NewColumn(SMTPConfigColumnResourceOwner, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMTPConfigColumnPriority, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(SMTPConfigColumnTLS, crdb.ColumnTypeBool),
 			crdb.NewColumn(SMTPConfigColumnSenderAddress, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSenderName, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPHost, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPUser, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPPassword, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(SMTPConfigColumnAuthType, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(SMTPConfigColumnXOAuth2TokenEndpoint, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMTPConfigColumnXOAuth2ClientID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMTPConfigColumnXOAuth2Scopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(SMTPConfigColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
-			crdb.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnAggregateID),
+			crdb.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnID),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{SMTPConfigColumnResourceOwner})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{SMTPConfigColumnOwnerRemoved})),
 		),
 	)
 	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
@@ -74,77 +94,192 @@ func (p *smtpConfigProjection) reducers() []handler.AggregateReducer {
 					Event:  instance.SMTPConfigPasswordChangedEventType,
 					Reduce: p.reduceSMTPConfigPasswordChanged,
 				},
+				{
+					Event:  instance.SMTPConfigRemovedEventType,
+					Reduce: p.reduceSMTPConfigRemoved,
+				},
 				{
 					Event:  instance.InstanceRemovedEventType,
 					Reduce: reduceInstanceRemovedHelper(SMTPConfigColumnInstanceID),
 				},
 			},
 		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.SMTPConfigAddedEventType,
+					Reduce: p.reduceSMTPConfigAdded,
+				},
+				{
+					Event:  org.SMTPConfigChangedEventType,
+					Reduce: p.reduceSMTPConfigChanged,
+				},
+				{
+					Event:  org.SMTPConfigPasswordChangedEventType,
+					Reduce: p.reduceSMTPConfigPasswordChanged,
+				},
+				{
+					Event:  org.SMTPConfigRemovedEventType,
+					Reduce: p.reduceSMTPConfigRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
 	}
 }
 
 func (p *smtpConfigProjection) reduceSMTPConfigAdded(event eventstore.Event) (*handler.Statement, error) {
-	e, ok := event.(*instance.SMTPConfigAddedEvent)
-	if !ok {
-		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-sk99F", "reduce.wrong.event.type %s", instance.SMTPConfigAddedEventType)
+	var (
+		id, senderAddress, senderName, host, user string
+		priority                                  uint32
+		tls                                       bool
+		password                                  *crypto.CryptoValue
+		authType                                  domain.SMTPAuthType
+		xoauth2                                   *domain.SMTPXOAuth2
+	)
+	switch e := event.(type) {
+	case *instance.SMTPConfigAddedEvent:
+		id, priority, tls, senderAddress, senderName, host, user, password, authType, xoauth2 =
+			e.ConfigID(), e.Priority, e.TLS, e.SenderAddress, e.SenderName, e.Host, e.User, e.Password, e.AuthType, e.XOAuth2
+	case *org.SMTPConfigAddedEvent:
+		id, tls, senderAddress, senderName, host, user, password, authType, xoauth2 =
+			e.Aggregate().ID, e.TLS, e.SenderAddress, e.SenderName, e.Host, e.User, e.Password, e.AuthType, e.XOAuth2
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-sk99F", "reduce.wrong.event.type %v", []eventstore.EventType{instance.SMTPConfigAddedEventType, org.SMTPConfigAddedEventType})
 	}
 	return crdb.NewCreateStatement(
-		e,
-		[]handler.Column{
-			handler.NewCol(SMTPConfigColumnAggregateID, e.Aggregate().ID),
-			handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
-			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
-			handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
-			handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
-			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
-			handler.NewCol(SMTPConfigColumnTLS, e.TLS),
-			handler.NewCol(SMTPConfigColumnSenderAddress, e.SenderAddress),
-			handler.NewCol(SMTPConfigColumnSenderName, e.SenderName),
-			handler.NewCol(SMTPConfigColumnSMTPHost, e.Host),
-			handler.NewCol(SMTPConfigColumnSMTPUser, e.User),
-			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
-		},
+		event,
+		append([]handler.Column{
+			handler.NewCol(SMTPConfigColumnID, id),
+			handler.NewCol(SMTPConfigColumnAggregateID, event.Aggregate().ID),
+			handler.NewCol(SMTPConfigColumnCreationDate, event.CreationDate()),
+			handler.NewCol(SMTPConfigColumnChangeDate, event.CreationDate()),
+			handler.NewCol(SMTPConfigColumnResourceOwner, event.Aggregate().ResourceOwner),
+			handler.NewCol(SMTPConfigColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCol(SMTPConfigColumnSequence, event.Sequence()),
+			handler.NewCol(SMTPConfigColumnPriority, priority),
+			handler.NewCol(SMTPConfigColumnTLS, tls),
+			handler.NewCol(SMTPConfigColumnSenderAddress, senderAddress),
+			handler.NewCol(SMTPConfigColumnSenderName, senderName),
+			handler.NewCol(SMTPConfigColumnSMTPHost, host),
+			handler.NewCol(SMTPConfigColumnSMTPUser, user),
+			handler.NewCol(SMTPConfigColumnSMTPPassword, password),
+			handler.NewCol(SMTPConfigColumnAuthType, authType),
+		}, xoauth2Columns(xoauth2)...),
 	), nil
 }
 
 func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (*handler.Statement, error) {
-	e, ok := event.(*instance.SMTPConfigChangedEvent)
-	if !ok {
-		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-wl0wd", "reduce.wrong.event.type %s", instance.SMTPConfigChangedEventType)
+	columns := make([]handler.Column, 0, 12)
+	columns = append(columns, handler.NewCol(SMTPConfigColumnChangeDate, event.CreationDate()),
+		handler.NewCol(SMTPConfigColumnSequence, event.Sequence()))
+	var (
+		id                                    string
+		tls                                   *bool
+		senderAddress, senderName, host, user *string
+		authType                              *domain.SMTPAuthType
+		xoauth2                               *domain.SMTPXOAuth2
+	)
+	switch e := event.(type) {
+	case *instance.SMTPConfigChangedEvent:
+		if e.Priority != nil {
+			columns = append(columns, handler.NewCol(SMTPConfigColumnPriority, *e.Priority))
+		}
+		id, tls, senderAddress, senderName, host, user, authType, xoauth2 =
+			e.ConfigID(), e.TLS, e.FromAddress, e.FromName, e.Host, e.User, e.AuthType, e.XOAuth2
+	case *org.SMTPConfigChangedEvent:
+		id, tls, senderAddress, senderName, host, user, authType, xoauth2 =
+			e.Aggregate().ID, e.TLS, e.FromAddress, e.FromName, e.Host, e.User, e.AuthType, e.XOAuth2
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-wl0wd", "reduce.wrong.event.type %v", []eventstore.EventType{instance.SMTPConfigChangedEventType, org.SMTPConfigChangedEventType})
 	}
-
-	columns := make([]handler.Column, 0, 7)
-	columns = append(columns, handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
-		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()))
-	if e.TLS != nil {
-		columns = append(columns, handler.NewCol(SMTPConfigColumnTLS, *e.TLS))
+	if tls != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnTLS, *tls))
+	}
+	if senderAddress != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderAddress, *senderAddress))
+	}
+	if senderName != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderName, *senderName))
 	}
-	if e.FromAddress != nil {
-		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderAddress, *e.FromAddress))
+	if host != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPHost, *host))
 	}
-	if e.FromName != nil {
-		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderName, *e.FromName))
+	if user != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPUser, *user))
 	}
-	if e.Host != nil {
-		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPHost, *e.Host))
+	if authType != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnAuthType, *authType))
 	}
-	if e.User != nil {
-		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPUser, *e.User))
+	if xoauth2 != nil {
+		columns = append(columns, xoauth2Columns(xoauth2)...)
 	}
 	return crdb.NewUpdateStatement(
-		e,
+		event,
 		columns,
 		[]handler.Condition{
-			handler.NewCond(SMTPConfigColumnAggregateID, e.Aggregate().ID),
-			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(SMTPConfigColumnID, id),
+			handler.NewCond(SMTPConfigColumnInstanceID, event.Aggregate().InstanceID),
 		},
 	), nil
 }
 
 func (p *smtpConfigProjection) reduceSMTPConfigPasswordChanged(event eventstore.Event) (*handler.Statement, error) {
-	e, ok := event.(*instance.SMTPConfigPasswordChangedEvent)
+	var (
+		id       string
+		password *crypto.CryptoValue
+	)
+	switch e := event.(type) {
+	case *instance.SMTPConfigPasswordChangedEvent:
+		id, password = e.ConfigID(), e.Password
+	case *org.SMTPConfigPasswordChangedEvent:
+		id, password = e.Aggregate().ID, e.Password
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-fk02f", "reduce.wrong.event.type %v", []eventstore.EventType{instance.SMTPConfigPasswordChangedEventType, org.SMTPConfigPasswordChangedEventType})
+	}
+
+	return crdb.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, event.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, event.Sequence()),
+			handler.NewCol(SMTPConfigColumnSMTPPassword, password),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, id),
+			handler.NewCond(SMTPConfigColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var id string
+	switch e := event.(type) {
+	case *instance.SMTPConfigRemovedEvent:
+		id = e.ConfigID()
+	case *org.SMTPConfigRemovedEvent:
+		id = e.Aggregate().ID
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ql7ch", "reduce.wrong.event.type %v", []eventstore.EventType{instance.SMTPConfigRemovedEventType, org.SMTPConfigRemovedEventType})
+	}
+
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, id),
+			handler.NewCond(SMTPConfigColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
 	if !ok {
-		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-fk02f", "reduce.wrong.event.type %s", instance.SMTPConfigChangedEventType)
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Vd4ja", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
 	}
 
 	return crdb.NewUpdateStatement(
@@ -152,11 +287,22 @@ func (p *smtpConfigProjection) reduceSMTPConfigPasswordChanged(event eventstore.
 		[]handler.Column{
 			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
 			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
-			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
+			handler.NewCol(SMTPConfigColumnOwnerRemoved, true),
 		},
 		[]handler.Condition{
-			handler.NewCond(SMTPConfigColumnAggregateID, e.Aggregate().ID),
 			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ID),
 		},
 	), nil
 }
+
+func xoauth2Columns(xoauth2 *domain.SMTPXOAuth2) []handler.Column {
+	if xoauth2 == nil {
+		xoauth2 = new(domain.SMTPXOAuth2)
+	}
+	return []handler.Column{
+		handler.NewCol(SMTPConfigColumnXOAuth2TokenEndpoint, xoauth2.TokenEndpoint),
+		handler.NewCol(SMTPConfigColumnXOAuth2ClientID, xoauth2.ClientID),
+		handler.NewCol(SMTPConfigColumnXOAuth2Scopes, database.StringArray(xoauth2.Scopes)),
+	}
+}
@@ -3,11 +3,14 @@ package projection
 import (
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
 func TestSMTPConfigProjection_reduces(t *testing.T) {
@@ -44,7 +47,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.smtp_configs SET (change_date, sequence, tls, sender_address, sender_name, host, username) = ($1, $2, $3, $4, $5, $6, $7) WHERE (aggregate_id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.smtp_configs1 SET (change_date, sequence, tls, sender_address, sender_name, host, username) = ($1, $2, $3, $4, $5, $6, $7) WHERE (id = $8) AND (instance_id = $9)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -89,20 +92,26 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.smtp_configs (aggregate_id, creation_date, change_date, resource_owner, instance_id, sequence, tls, sender_address, sender_name, host, username, password) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedStmt: "INSERT INTO projections.smtp_configs1 (id, aggregate_id, creation_date, change_date, resource_owner, instance_id, sequence, priority, tls, sender_address, sender_name, host, username, password, auth_type, xoauth2_token_endpoint, xoauth2_client_id, xoauth2_scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)",
 							expectedArgs: []interface{}{
+								"agg-id",
 								"agg-id",
 								anyArg{},
 								anyArg{},
 								"ro-id",
 								"instance-id",
 								uint64(15),
+								uint32(0),
 								true,
 								"sender",
 								"name",
 								"host",
 								"user",
 								anyArg{},
+								domain.SMTPAuthTypePlain,
+								"",
+								"",
+								database.StringArray(nil),
 							},
 						},
 					},
@@ -132,11 +141,217 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.smtp_configs SET (change_date, sequence, password) = ($1, $2, $3) WHERE (aggregate_id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.smtp_configs1 SET (change_date, sequence, password) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigAdded with id and xoauth2",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id",
+						"priority": 2,
+						"tls": true,
+						"senderAddress": "sender",
+						"senderName": "name",
+						"host": "host",
+						"user": "user",
+						"password": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id"
+						},
+						"authType": 1,
+						"xoauth2": {
+							"tokenEndpoint": "https://login.example.com/token",
+							"clientId": "client-id",
+							"scopes": ["smtp"]
+						}
+					}`),
+				), instance.SMTPConfigAddedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.smtp_configs1 (id, aggregate_id, creation_date, change_date, resource_owner, instance_id, sequence, priority, tls, sender_address, sender_name, host, username, password, auth_type, xoauth2_token_endpoint, xoauth2_client_id, xoauth2_scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)",
+							expectedArgs: []interface{}{
+								"config-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								uint32(2),
+								true,
+								"sender",
+								"name",
+								"host",
+								"user",
+								anyArg{},
+								domain.SMTPAuthTypeXOAuth2,
+								"https://login.example.com/token",
+								"client-id",
+								database.StringArray{"smtp"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigChanged priority",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id",
+						"priority": 1
+					}`,
+					),
+				), instance.SMTPConfigChangedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.smtp_configs1 SET (change_date, sequence, priority) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								uint32(1),
+								"config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigRemovedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id"
+					}`),
+				), instance.SMTPConfigRemovedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.smtp_configs1 WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSMTPConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.SMTPConfigAddedEventType),
+					org.AggregateType,
+					[]byte(`{
+						"tls": true,
+						"senderAddress": "sender",
+						"senderName": "name",
+						"host": "host",
+						"user": "user",
+						"password": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id"
+						}
+					}`),
+				), org.SMTPConfigAddedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.smtp_configs1 (id, aggregate_id, creation_date, change_date, resource_owner, instance_id, sequence, priority, tls, sender_address, sender_name, host, username, password, auth_type, xoauth2_token_endpoint, xoauth2_client_id, xoauth2_scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)",
 							expectedArgs: []interface{}{
+								"agg-id",
+								"agg-id",
+								anyArg{},
 								anyArg{},
+								"ro-id",
+								"instance-id",
 								uint64(15),
+								uint32(0),
+								true,
+								"sender",
+								"name",
+								"host",
+								"user",
 								anyArg{},
+								domain.SMTPAuthTypePlain,
+								"",
+								"",
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSMTPConfigRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.SMTPConfigRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.SMTPConfigRemovedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.smtp_configs1 WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
 							},
@@ -145,6 +360,36 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.smtp_configs1 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "instance reduceInstanceRemoved",
 			args: args{
@@ -162,7 +407,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.smtp_configs WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.smtp_configs1 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -0,0 +1,513 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	UserActivityProjectionTable = "projections.user_activities"
+	UserActivitySessionTable    = UserActivityProjectionTable + "_" + userActivitySessionTableSuffix
+
+	UserActivityColumnUserID           = "user_id"
+	UserActivityColumnInstanceID       = "instance_id"
+	UserActivityColumnResourceOwner    = "resource_owner"
+	UserActivityColumnCreationDate     = "creation_date"
+	UserActivityColumnChangeDate       = "change_date"
+	UserActivityColumnSequence         = "sequence"
+	UserActivityColumnUserType         = "user_type"
+	UserActivityColumnState            = "state"
+	UserActivityColumnLastActivity     = "last_activity"
+	UserActivityColumnDeactivationDate = "deactivation_date"
+	UserActivityColumnWarningDate      = "warning_date"
+	UserActivityColumnOwnerRemoved     = "owner_removed"
+
+	userActivitySessionTableSuffix      = "sessions"
+	UserActivitySessionColumnSessionID  = "session_id"
+	UserActivitySessionColumnInstanceID = "instance_id"
+	UserActivitySessionColumnUserID     = "user_id"
+)
+
+// userActivityProjection keeps track of the last login of every user,
+// which is used to deactivate and delete inactive users (see [domain.UserLifecyclePolicy]).
+// As the checks of the session API are stored on the session, the user of every session is kept
+// in a separate table to be able to update the activity of the user.
+type userActivityProjection struct {
+	crdb.StatementHandler
+}
+
+func newUserActivityProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userActivityProjection {
+	p := new(userActivityProjection)
+	config.ProjectionName = UserActivityProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(UserActivityColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserActivityColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserActivityColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(UserActivityColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserActivityColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserActivityColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserActivityColumnUserType, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserActivityColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserActivityColumnLastActivity, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserActivityColumnDeactivationDate, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserActivityColumnWarningDate, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserActivityColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(UserActivityColumnInstanceID, UserActivityColumnUserID),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{UserActivityColumnResourceOwner})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{UserActivityColumnOwnerRemoved})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(UserActivitySessionColumnSessionID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserActivitySessionColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserActivitySessionColumnUserID, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(UserActivitySessionColumnInstanceID, UserActivitySessionColumnSessionID),
+			userActivitySessionTableSuffix,
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{UserActivitySessionColumnUserID})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userActivityProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserV1AddedType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.HumanAddedType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.UserV1RegisteredType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.HumanRegisteredType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.MachineAddedEventType,
+					Reduce: p.reduceMachineAdded,
+				},
+				{
+					Event:  user.UserV1InitialCodeAddedType,
+					Reduce: p.reduceInitCodeAdded,
+				},
+				{
+					Event:  user.HumanInitialCodeAddedType,
+					Reduce: p.reduceInitCodeAdded,
+				},
+				{
+					Event:  user.UserV1InitializedCheckSucceededType,
+					Reduce: p.reduceInitCheckSucceeded,
+				},
+				{
+					Event:  user.HumanInitializedCheckSucceededType,
+					Reduce: p.reduceInitCheckSucceeded,
+				},
+				{
+					Event:  user.UserV1PasswordCheckSucceededType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.HumanMagicLinkCodeCheckSucceededType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.UserTokenAddedType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.MachineSecretCheckSucceededType,
+					Reduce: p.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserLocked,
+				},
+				{
+					Event:  user.UserUnlockedType,
+					Reduce: p.reduceUserUnlocked,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserDeactivated,
+				},
+				{
+					Event:  user.UserReactivatedType,
+					Reduce: p.reduceUserReactivated,
+				},
+				{
+					Event:  user.UserLifecycleWarningAddedType,
+					Reduce: p.reduceLifecycleWarningAdded,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: session.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  session.UserCheckedType,
+					Reduce: p.reduceSessionUserChecked,
+				},
+				{
+					Event:  session.PasswordCheckedType,
+					Reduce: p.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.PasskeyCheckedType,
+					Reduce: p.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.MagicLinkCheckedType,
+					Reduce: p.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.RecoveryCodeCheckedType,
+					Reduce: p.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.TerminateType,
+					Reduce: p.reduceSessionTerminated,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *userActivityProjection) reduceHumanAdded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac2h", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanAddedType, user.HumanRegisteredType})
+	}
+	return p.addStatement(event, domain.UserTypeHuman), nil
+}
+
+func (p *userActivityProjection) reduceMachineAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.MachineAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac3m", "reduce.wrong.event.type %s", user.MachineAddedEventType)
+	}
+	return p.addStatement(e, domain.UserTypeMachine), nil
+}
+
+// addStatement creates the activity of a new user, for which the creation counts as first activity
+func (p *userActivityProjection) addStatement(event eventstore.Event, userType domain.UserType) *handler.Statement {
+	return crdb.NewCreateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(UserActivityColumnUserID, event.Aggregate().ID),
+			handler.NewCol(UserActivityColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCol(UserActivityColumnResourceOwner, event.Aggregate().ResourceOwner),
+			handler.NewCol(UserActivityColumnCreationDate, event.CreationDate()),
+			handler.NewCol(UserActivityColumnChangeDate, event.CreationDate()),
+			handler.NewCol(UserActivityColumnSequence, event.Sequence()),
+			handler.NewCol(UserActivityColumnUserType, userType),
+			handler.NewCol(UserActivityColumnState, domain.UserStateActive),
+			handler.NewCol(UserActivityColumnLastActivity, event.CreationDate()),
+		},
+	)
+}
+
+func (p *userActivityProjection) reduceInitCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanInitialCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac4i", "reduce.wrong.event.type %s", user.HumanInitialCodeAddedType)
+	}
+	return p.updateStatement(e, handler.NewCol(UserActivityColumnState, domain.UserStateInitial)), nil
+}
+
+func (p *userActivityProjection) reduceInitCheckSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanInitializedCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac5s", "reduce.wrong.event.type %s", user.HumanInitializedCheckSucceededType)
+	}
+	return p.updateStatement(e,
+		handler.NewCol(UserActivityColumnState, domain.UserStateActive),
+		handler.NewCol(UserActivityColumnLastActivity, e.CreationDate()),
+	), nil
+}
+
+func (p *userActivityProjection) reduceLoginSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent,
+		*user.HumanPasswordlessCheckSucceededEvent,
+		*user.UserIDPCheckSucceededEvent,
+		*user.HumanMagicLinkCodeCheckSucceededEvent,
+		*user.UserTokenAddedEvent,
+		*user.PersonalAccessTokenAddedEvent,
+		*user.MachineSecretCheckSucceededEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac6l", "reduce.wrong.event.type %v", []eventstore.EventType{
+			user.HumanPasswordCheckSucceededType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.UserIDPLoginCheckSucceededType,
+			user.HumanMagicLinkCodeCheckSucceededType,
+			user.UserTokenAddedType,
+			user.PersonalAccessTokenAddedType,
+			user.MachineSecretCheckSucceededType,
+		})
+	}
+	return p.updateStatement(event, handler.NewCol(UserActivityColumnLastActivity, event.CreationDate())), nil
+}
+
+func (p *userActivityProjection) reduceUserLocked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLockedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac7k", "reduce.wrong.event.type %s", user.UserLockedType)
+	}
+	return p.updateStatement(e, handler.NewCol(UserActivityColumnState, domain.UserStateLocked)), nil
+}
+
+func (p *userActivityProjection) reduceUserUnlocked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserUnlockedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac8u", "reduce.wrong.event.type %s", user.UserUnlockedType)
+	}
+	return p.updateStatement(e, handler.NewCol(UserActivityColumnState, domain.UserStateActive)), nil
+}
+
+func (p *userActivityProjection) reduceUserDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac9d", "reduce.wrong.event.type %s", user.UserDeactivatedType)
+	}
+	return p.updateStatement(e,
+		handler.NewCol(UserActivityColumnState, domain.UserStateInactive),
+		handler.NewCol(UserActivityColumnDeactivationDate, e.CreationDate()),
+	), nil
+}
+
+func (p *userActivityProjection) reduceUserReactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserReactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac1r", "reduce.wrong.event.type %s", user.UserReactivatedType)
+	}
+	// a reactivation counts as activity, so the user isn't deactivated again right away
+	return p.updateStatement(e,
+		handler.NewCol(UserActivityColumnState, domain.UserStateActive),
+		handler.NewCol(UserActivityColumnLastActivity, e.CreationDate()),
+		handler.NewCol(UserActivityColumnDeactivationDate, nil),
+	), nil
+}
+
+func (p *userActivityProjection) reduceLifecycleWarningAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLifecycleWarningAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac2w", "reduce.wrong.event.type %s", user.UserLifecycleWarningAddedType)
+	}
+	return p.updateStatement(e, handler.NewCol(UserActivityColumnWarningDate, e.CreationDate())), nil
+}
+
+func (p *userActivityProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac3r", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserActivityColumnUserID, e.Aggregate().ID),
+				handler.NewCond(UserActivityColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserActivitySessionColumnUserID, e.Aggregate().ID),
+				handler.NewCond(UserActivitySessionColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(userActivitySessionTableSuffix),
+		),
+	), nil
+}
+
+func (p *userActivityProjection) reduceSessionUserChecked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.UserCheckedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac5u", "reduce.wrong.event.type %s", session.UserCheckedType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserActivitySessionColumnInstanceID, nil),
+			handler.NewCol(UserActivitySessionColumnSessionID, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(UserActivitySessionColumnSessionID, e.Aggregate().ID),
+			handler.NewCol(UserActivitySessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(UserActivitySessionColumnUserID, e.UserID),
+		},
+		crdb.WithTableSuffix(userActivitySessionTableSuffix),
+	), nil
+}
+
+// reduceSessionCheckSucceeded updates the activity of the user of the session,
+// which is taken from the sessions table as the check events do not contain the user
+func (p *userActivityProjection) reduceSessionCheckSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *session.PasswordCheckedEvent,
+		*session.PasskeyCheckedEvent,
+		*session.MagicLinkCheckedEvent,
+		*session.RecoveryCodeCheckedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac6c", "reduce.wrong.event.type %v", []eventstore.EventType{
+			session.PasswordCheckedType,
+			session.PasskeyCheckedType,
+			session.MagicLinkCheckedType,
+			session.RecoveryCodeCheckedType,
+		})
+	}
+	return crdb.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(UserActivityColumnChangeDate, event.CreationDate()),
+			handler.NewCol(UserActivityColumnSequence, event.Sequence()),
+			handler.NewCol(UserActivityColumnLastActivity, event.CreationDate()),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserActivityColumnInstanceID, event.Aggregate().InstanceID),
+			newUserActivitySessionUserCond(event.Aggregate().ID),
+		},
+	), nil
+}
+
+// newUserActivitySessionUserCond selects the user of the session by the session id
+func newUserActivitySessionUserCond(sessionID string) handler.Condition {
+	return handler.Condition{
+		Name:  UserActivityColumnUserID,
+		Value: sessionID,
+		ParameterOpt: func(placeholder string) string {
+			return " = (SELECT " + UserActivitySessionColumnUserID +
+				" FROM " + UserActivitySessionTable +
+				" WHERE " + UserActivitySessionColumnSessionID + " = " + placeholder +
+				" AND " + UserActivitySessionColumnInstanceID + " = user_activities." + UserActivityColumnInstanceID + ")"
+		},
+	}
+}
+
+func (p *userActivityProjection) reduceSessionTerminated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.TerminateEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac7t", "reduce.wrong.event.type %s", session.TerminateType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserActivitySessionColumnSessionID, e.Aggregate().ID),
+			handler.NewCond(UserActivitySessionColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(userActivitySessionTableSuffix),
+	), nil
+}
+
+func (p *userActivityProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac4o", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserActivityColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserActivityColumnSequence, e.Sequence()),
+			handler.NewCol(UserActivityColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserActivityColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserActivityColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *userActivityProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.InstanceRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Uac8r", "reduce.wrong.event.type %s", instance.InstanceRemovedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserActivityColumnInstanceID, e.Aggregate().ID),
+			},
+		),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserActivitySessionColumnInstanceID, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(userActivitySessionTableSuffix),
+		),
+	), nil
+}
+
+func (p *userActivityProjection) updateStatement(event eventstore.Event, cols ...handler.Column) *handler.Statement {
+	return crdb.NewUpdateStatement(
+		event,
+		append([]handler.Column{
+			handler.NewCol(UserActivityColumnChangeDate, event.CreationDate()),
+			handler.NewCol(UserActivityColumnSequence, event.Sequence()),
+		}, cols...),
+		[]handler.Condition{
+			handler.NewCond(UserActivityColumnUserID, event.Aggregate().ID),
+			handler.NewCond(UserActivityColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	)
+}
@@ -0,0 +1,473 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestUserActivityProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceHumanAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanAddedType),
+					user.AggregateType,
+					[]byte(`{"username": "user-name", "email": "email@zitadel.com"}`),
+				), user.HumanAddedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceHumanAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_activities (user_id, instance_id, resource_owner, creation_date, change_date, sequence, user_type, state, last_activity) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.UserTypeHuman,
+								domain.UserStateActive,
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceMachineAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.MachineAddedEventType),
+					user.AggregateType,
+					[]byte(`{"username": "username", "name": "machine-name"}`),
+				), user.MachineAddedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceMachineAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_activities (user_id, instance_id, resource_owner, creation_date, change_date, sequence, user_type, state, last_activity) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.UserTypeMachine,
+								domain.UserStateActive,
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceLoginSucceeded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordCheckSucceededType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.HumanPasswordCheckSucceededEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceLoginSucceeded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity) = ($1, $2, $3) WHERE (user_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceLoginSucceeded token added",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserTokenAddedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "token-id"}`),
+				), user.UserTokenAddedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceLoginSucceeded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity) = ($1, $2, $3) WHERE (user_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceLoginSucceeded machine secret checked",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.MachineSecretCheckSucceededType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.MachineSecretCheckSucceededEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceLoginSucceeded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity) = ($1, $2, $3) WHERE (user_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "session reduceSessionUserChecked",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(session.UserCheckedType),
+					session.AggregateType,
+					[]byte(`{"userID": "user-id"}`),
+				), session.UserCheckedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceSessionUserChecked,
+			want: wantReduce{
+				aggregateType:    session.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_activities_sessions (session_id, instance_id, user_id) VALUES ($1, $2, $3) ON CONFLICT (instance_id, session_id) DO UPDATE SET user_id = EXCLUDED.user_id",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"user-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "session reduceSessionCheckSucceeded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(session.PasswordCheckedType),
+					session.AggregateType,
+					[]byte(`{}`),
+				), session.PasswordCheckedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceSessionCheckSucceeded,
+			want: wantReduce{
+				aggregateType:    session.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_id = (SELECT user_id FROM projections.user_activities_sessions WHERE session_id = $5 AND instance_id = user_activities.instance_id))",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "session reduceSessionTerminated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(session.TerminateType),
+					session.AggregateType,
+					[]byte(`{}`),
+				), session.TerminateEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceSessionTerminated,
+			want: wantReduce{
+				aggregateType:    session.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_activities_sessions WHERE (session_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserDeactivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserDeactivatedType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.UserDeactivatedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceUserDeactivated,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, state, deactivation_date) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserStateInactive,
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserReactivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserReactivatedType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.UserReactivatedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceUserReactivated,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, state, last_activity, deactivation_date) = ($1, $2, $3, $4, $5) WHERE (user_id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserStateActive,
+								anyArg{},
+								nil,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceLifecycleWarningAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserLifecycleWarningAddedType),
+					user.AggregateType,
+					[]byte(`{"action": 1, "dueDate": "2023-06-08T12:00:00Z"}`),
+				), user.UserLifecycleWarningAddedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceLifecycleWarningAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, warning_date) = ($1, $2, $3) WHERE (user_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_activities WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.user_activities_sessions WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_activities WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.user_activities_sessions WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, UserActivityProjectionTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/userbulkjob"
+)
+
+const (
+	UserBulkJobTable     = "projections.user_bulk_jobs"
+	UserBulkJobRowTable  = UserBulkJobTable + "_" + UserBulkJobRowSuffix
+	UserBulkJobRowSuffix = "rows"
+
+	UserBulkJobIDCol            = "id"
+	UserBulkJobCreationDateCol  = "creation_date"
+	UserBulkJobChangeDateCol    = "change_date"
+	UserBulkJobResourceOwnerCol = "resource_owner"
+	UserBulkJobInstanceIDCol    = "instance_id"
+	UserBulkJobSequenceCol      = "sequence"
+	UserBulkJobStateCol         = "state"
+	UserBulkJobTypeCol          = "type"
+	UserBulkJobFormatCol        = "format"
+	UserBulkJobFileNameCol      = "file_name"
+	UserBulkJobFilterCol        = "filter"
+	UserBulkJobTotalCol         = "total"
+	UserBulkJobProcessedCol     = "processed"
+	UserBulkJobFailedCol        = "failed"
+	UserBulkJobErrorCol         = "error"
+	UserBulkJobOwnerRemovedCol  = "owner_removed"
+
+	UserBulkJobRowJobIDCol        = "job_id"
+	UserBulkJobRowInstanceIDCol   = "instance_id"
+	UserBulkJobRowCreationDateCol = "creation_date"
+	UserBulkJobRowRowCol          = "row_number"
+	UserBulkJobRowUserIDCol       = "user_id"
+	UserBulkJobRowErrorIDCol      = "error_id"
+	UserBulkJobRowErrorCol        = "error"
+)
+
+type userBulkJobProjection struct {
+	crdb.StatementHandler
+}
+
+func newUserBulkJobProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userBulkJobProjection {
+	p := new(userBulkJobProjection)
+	config.ProjectionName = UserBulkJobTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(UserBulkJobIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(UserBulkJobCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserBulkJobChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserBulkJobResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(UserBulkJobInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(UserBulkJobSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserBulkJobStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserBulkJobTypeCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserBulkJobFormatCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserBulkJobFileNameCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(UserBulkJobFilterCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(UserBulkJobTotalCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(UserBulkJobProcessedCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(UserBulkJobFailedCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(UserBulkJobErrorCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(UserBulkJobOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(UserBulkJobInstanceIDCol, UserBulkJobIDCol),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{UserBulkJobResourceOwnerCol})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{UserBulkJobOwnerRemovedCol})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(UserBulkJobRowJobIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(UserBulkJobRowInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(UserBulkJobRowCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserBulkJobRowRowCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserBulkJobRowUserIDCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(UserBulkJobRowErrorIDCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(UserBulkJobRowErrorCol, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(UserBulkJobRowInstanceIDCol, UserBulkJobRowJobIDCol, UserBulkJobRowRowCol),
+			UserBulkJobRowSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("job", []string{UserBulkJobRowInstanceIDCol, UserBulkJobRowJobIDCol}, []string{UserBulkJobInstanceIDCol, UserBulkJobIDCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userBulkJobProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: userbulkjob.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  userbulkjob.AddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  userbulkjob.StartedEventType,
+					Reduce: p.reduceStarted,
+				},
+				{
+					Event:  userbulkjob.RowImportedEventType,
+					Reduce: p.reduceRowImported,
+				},
+				{
+					Event:  userbulkjob.RowFailedEventType,
+					Reduce: p.reduceRowFailed,
+				},
+				{
+					Event:  userbulkjob.DoneEventType,
+					Reduce: p.reduceDone,
+				},
+				{
+					Event:  userbulkjob.FailedEventType,
+					Reduce: p.reduceFailed,
+				},
+				{
+					Event:  userbulkjob.CancelledEventType,
+					Reduce: p.reduceCancelled,
+				},
+				{
+					Event:  userbulkjob.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserBulkJobInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *userBulkJobProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj1a", "reduce.wrong.event.type %s", userbulkjob.AddedEventType)
+	}
+	var filter []byte
+	if e.Filter != nil {
+		var err error
+		filter, err = json.Marshal(e.Filter)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "PROJE-Ubj2j", "Errors.Internal")
+		}
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserBulkJobIDCol, e.Aggregate().ID),
+			handler.NewCol(UserBulkJobCreationDateCol, e.CreationDate()),
+			handler.NewCol(UserBulkJobChangeDateCol, e.CreationDate()),
+			handler.NewCol(UserBulkJobResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(UserBulkJobSequenceCol, e.Sequence()),
+			handler.NewCol(UserBulkJobStateCol, domain.UserBulkJobStateQueued),
+			handler.NewCol(UserBulkJobTypeCol, e.JobType),
+			handler.NewCol(UserBulkJobFormatCol, e.Format),
+			handler.NewCol(UserBulkJobFileNameCol, e.FileName),
+			handler.NewCol(UserBulkJobFilterCol, filter),
+			handler.NewCol(UserBulkJobTotalCol, e.Total),
+		},
+	), nil
+}
+
+func (p *userBulkJobProjection) reduceStarted(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.StartedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj3s", "reduce.wrong.event.type %s", userbulkjob.StartedEventType)
+	}
+	return p.reduceStateChanged(e, domain.UserBulkJobStateRunning), nil
+}
+
+func (p *userBulkJobProjection) reduceRowImported(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.RowImportedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj4i", "reduce.wrong.event.type %s", userbulkjob.RowImportedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(UserBulkJobChangeDateCol, e.CreationDate()),
+				handler.NewCol(UserBulkJobSequenceCol, e.Sequence()),
+				crdb.NewIncrementCol(UserBulkJobProcessedCol, 1),
+			},
+			[]handler.Condition{
+				handler.NewCond(UserBulkJobIDCol, e.Aggregate().ID),
+				handler.NewCond(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(UserBulkJobRowJobIDCol, e.Aggregate().ID),
+				handler.NewCol(UserBulkJobRowInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(UserBulkJobRowCreationDateCol, e.CreationDate()),
+				handler.NewCol(UserBulkJobRowRowCol, e.Row),
+				handler.NewCol(UserBulkJobRowUserIDCol, e.UserID),
+			},
+			crdb.WithTableSuffix(UserBulkJobRowSuffix),
+		),
+	), nil
+}
+
+func (p *userBulkJobProjection) reduceRowFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.RowFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj5f", "reduce.wrong.event.type %s", userbulkjob.RowFailedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(UserBulkJobChangeDateCol, e.CreationDate()),
+				handler.NewCol(UserBulkJobSequenceCol, e.Sequence()),
+				crdb.NewIncrementCol(UserBulkJobProcessedCol, 1),
+				crdb.NewIncrementCol(UserBulkJobFailedCol, 1),
+			},
+			[]handler.Condition{
+				handler.NewCond(UserBulkJobIDCol, e.Aggregate().ID),
+				handler.NewCond(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(UserBulkJobRowJobIDCol, e.Aggregate().ID),
+				handler.NewCol(UserBulkJobRowInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(UserBulkJobRowCreationDateCol, e.CreationDate()),
+				handler.NewCol(UserBulkJobRowRowCol, e.Row),
+				handler.NewCol(UserBulkJobRowUserIDCol, e.UserID),
+				handler.NewCol(UserBulkJobRowErrorIDCol, e.ErrorID),
+				handler.NewCol(UserBulkJobRowErrorCol, e.Error),
+			},
+			crdb.WithTableSuffix(UserBulkJobRowSuffix),
+		),
+	), nil
+}
+
+func (p *userBulkJobProjection) reduceDone(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.DoneEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj6d", "reduce.wrong.event.type %s", userbulkjob.DoneEventType)
+	}
+	cols := []handler.Column{
+		handler.NewCol(UserBulkJobChangeDateCol, e.CreationDate()),
+		handler.NewCol(UserBulkJobSequenceCol, e.Sequence()),
+		handler.NewCol(UserBulkJobStateCol, domain.UserBulkJobStateDone),
+	}
+	if e.FileName != "" {
+		cols = append(cols,
+			handler.NewCol(UserBulkJobFileNameCol, e.FileName),
+			handler.NewCol(UserBulkJobTotalCol, e.Total),
+			handler.NewCol(UserBulkJobProcessedCol, e.Total),
+		)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(UserBulkJobIDCol, e.Aggregate().ID),
+			handler.NewCond(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userBulkJobProjection) reduceFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.FailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj7f", "reduce.wrong.event.type %s", userbulkjob.FailedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserBulkJobChangeDateCol, e.CreationDate()),
+			handler.NewCol(UserBulkJobSequenceCol, e.Sequence()),
+			handler.NewCol(UserBulkJobStateCol, domain.UserBulkJobStateFailed),
+			handler.NewCol(UserBulkJobErrorCol, e.Reason),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserBulkJobIDCol, e.Aggregate().ID),
+			handler.NewCond(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userBulkJobProjection) reduceCancelled(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.CancelledEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj8c", "reduce.wrong.event.type %s", userbulkjob.CancelledEventType)
+	}
+	return p.reduceStateChanged(e, domain.UserBulkJobStateCancelled), nil
+}
+
+func (p *userBulkJobProjection) reduceStateChanged(e eventstore.Event, state domain.UserBulkJobState) *handler.Statement {
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserBulkJobChangeDateCol, e.CreationDate()),
+			handler.NewCol(UserBulkJobSequenceCol, e.Sequence()),
+			handler.NewCol(UserBulkJobStateCol, state),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserBulkJobIDCol, e.Aggregate().ID),
+			handler.NewCond(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	)
+}
+
+func (p *userBulkJobProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*userbulkjob.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj9r", "reduce.wrong.event.type %s", userbulkjob.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserBulkJobIDCol, e.Aggregate().ID),
+			handler.NewCond(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userBulkJobProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ubj0o", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserBulkJobChangeDateCol, e.CreationDate()),
+			handler.NewCol(UserBulkJobSequenceCol, e.Sequence()),
+			handler.NewCol(UserBulkJobOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserBulkJobInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(UserBulkJobResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
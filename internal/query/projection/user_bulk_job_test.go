@@ -0,0 +1,435 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/userbulkjob"
+)
+
+func TestUserBulkJobProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded import",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.AddedEventType),
+					userbulkjob.AggregateType,
+					[]byte(`{"jobType": 1, "format": 1, "fileName": "user_bulk_jobs/agg-id/import.csv", "total": 3}`),
+				), userbulkjob.AddedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_bulk_jobs (id, creation_date, change_date, resource_owner, instance_id, sequence, state, type, format, file_name, filter, total) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								domain.UserBulkJobStateQueued,
+								domain.UserBulkJobTypeImport,
+								domain.UserBulkJobFormatCSV,
+								"user_bulk_jobs/agg-id/import.csv",
+								[]byte(nil),
+								uint64(3),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceAdded export",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.AddedEventType),
+					userbulkjob.AggregateType,
+					[]byte(`{"jobType": 2, "format": 2, "filter": {"type": 1, "email": "zitadel.ch"}}`),
+				), userbulkjob.AddedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_bulk_jobs (id, creation_date, change_date, resource_owner, instance_id, sequence, state, type, format, file_name, filter, total) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								domain.UserBulkJobStateQueued,
+								domain.UserBulkJobTypeExport,
+								domain.UserBulkJobFormatJSON,
+								"",
+								[]byte(`{"type":1,"email":"zitadel.ch"}`),
+								uint64(0),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceStarted",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.StartedEventType),
+					userbulkjob.AggregateType,
+					nil,
+				), userbulkjob.StartedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceStarted,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserBulkJobStateRunning,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRowImported",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.RowImportedEventType),
+					userbulkjob.AggregateType,
+					[]byte(`{"row": 2, "userId": "user1"}`),
+				), userbulkjob.RowImportedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceRowImported,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, processed) = ($1, $2, processed + $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								1,
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.user_bulk_jobs_rows (job_id, instance_id, creation_date, row_number, user_id) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								anyArg{},
+								uint64(2),
+								"user1",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRowFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.RowFailedEventType),
+					userbulkjob.AggregateType,
+					[]byte(`{"row": 3, "userId": "user2", "errorId": "COMMAND-k2unb", "error": "Errors.User.AlreadyExisting"}`),
+				), userbulkjob.RowFailedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceRowFailed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, processed, failed) = ($1, $2, processed + $3, failed + $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								1,
+								1,
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.user_bulk_jobs_rows (job_id, instance_id, creation_date, row_number, user_id, error_id, error) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								anyArg{},
+								uint64(3),
+								"user2",
+								"COMMAND-k2unb",
+								"Errors.User.AlreadyExisting",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDone import",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.DoneEventType),
+					userbulkjob.AggregateType,
+					[]byte(`{}`),
+				), userbulkjob.DoneEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceDone,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserBulkJobStateDone,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDone export",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.DoneEventType),
+					userbulkjob.AggregateType,
+					[]byte(`{"fileName": "user_bulk_jobs/agg-id/export.json", "total": 42}`),
+				), userbulkjob.DoneEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceDone,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, state, file_name, total, processed) = ($1, $2, $3, $4, $5, $6) WHERE (id = $7) AND (instance_id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserBulkJobStateDone,
+								"user_bulk_jobs/agg-id/export.json",
+								uint64(42),
+								uint64(42),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.FailedEventType),
+					userbulkjob.AggregateType,
+					[]byte(`{"reason": "Errors.UserBulkJob.FileInvalid"}`),
+				), userbulkjob.FailedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceFailed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, state, error) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserBulkJobStateFailed,
+								"Errors.UserBulkJob.FileInvalid",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceCancelled",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.CancelledEventType),
+					userbulkjob.AggregateType,
+					nil,
+				), userbulkjob.CancelledEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceCancelled,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserBulkJobStateCancelled,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(userbulkjob.RemovedEventType),
+					userbulkjob.AggregateType,
+					nil,
+				), userbulkjob.RemovedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user_bulk_job"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_bulk_jobs WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&userBulkJobProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_bulk_jobs SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(UserBulkJobInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_bulk_jobs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+
+			if ok := errors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, UserBulkJobTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	UserDataKeyProjectionTable = "projections.user_data_keys"
+)
+
+// userDataKeyProjection destroys the data encryption key of removed users,
+// so the personal data in their events can't be decrypted anymore.
+// The key is destroyed by the projection instead of the command,
+// so that the destruction is retried until it succeeded.
+type userDataKeyProjection struct {
+	crdb.StatementHandler
+	keys eventstore.PersonalDataKeys
+}
+
+func newUserDataKeyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userDataKeyProjection {
+	p := new(userDataKeyProjection)
+	config.ProjectionName = UserDataKeyProjectionTable
+	config.Reducers = p.reducers()
+	p.keys = config.Eventstore.PersonalDataKeys()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userDataKeyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *userDataKeyProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Udk1r", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	if p.keys == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	if err := p.keys.Destroy(context.Background(), e.Aggregate().InstanceID, e.Aggregate().ID); err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
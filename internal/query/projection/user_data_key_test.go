@@ -0,0 +1,94 @@
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type testPersonalDataKeys struct {
+	err       error
+	destroyed []string
+}
+
+func (k *testPersonalDataKeys) Key(context.Context, string, string, bool) (crypto.EncryptionAlgorithm, error) {
+	return nil, nil
+}
+
+func (k *testPersonalDataKeys) Destroy(_ context.Context, instanceID, aggregateID string) error {
+	if k.err != nil {
+		return k.err
+	}
+	k.destroyed = append(k.destroyed, instanceID+"/"+aggregateID)
+	return nil
+}
+
+func TestUserDataKeyProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+		keys  *testPersonalDataKeys
+	}
+	tests := []struct {
+		name          string
+		args          args
+		want          wantReduce
+		wantDestroyed []string
+	}{
+		{
+			name: "reduceUserRemoved, key destroyed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+				keys: &testPersonalDataKeys{},
+			},
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+			wantDestroyed: []string{"instance-id/agg-id"},
+		},
+		{
+			name: "reduceUserRemoved, destroy failed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+				keys: &testPersonalDataKeys{err: errors.ThrowInternal(nil, "CRYPT-Iech3", "unable to destroy user data key")},
+			},
+			want: wantReduce{
+				err: errors.IsInternal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &userDataKeyProjection{keys: tt.args.keys}
+			event := baseEvent(t)
+			got, err := p.reduceUserRemoved(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = p.reduceUserRemoved(event)
+			assertReduce(t, got, err, UserDataKeyProjectionTable, tt.want)
+			assert.Equal(t, tt.wantDestroyed, tt.args.keys.destroyed)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	UserLifecyclePolicyProjectionTable = "projections.user_lifecycle_policies"
+
+	UserLifecyclePolicyColumnID                  = "id"
+	UserLifecyclePolicyColumnCreationDate        = "creation_date"
+	UserLifecyclePolicyColumnChangeDate          = "change_date"
+	UserLifecyclePolicyColumnResourceOwner       = "resource_owner"
+	UserLifecyclePolicyColumnInstanceID          = "instance_id"
+	UserLifecyclePolicyColumnSequence            = "sequence"
+	UserLifecyclePolicyColumnStateCol            = "state"
+	UserLifecyclePolicyColumnIsDefault           = "is_default"
+	UserLifecyclePolicyColumnDeactivateAfterDays = "deactivate_after_days"
+	UserLifecyclePolicyColumnDeleteAfterDays     = "delete_after_days"
+	UserLifecyclePolicyColumnWarnDaysBefore      = "warn_days_before"
+	UserLifecyclePolicyColumnExcludeMachineUsers = "exclude_machine_users"
+	UserLifecyclePolicyColumnExcludedUserIDs     = "excluded_user_ids"
+	UserLifecyclePolicyColumnDryRun              = "dry_run"
+	UserLifecyclePolicyColumnOwnerRemoved        = "owner_removed"
+)
+
+type userLifecyclePolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newUserLifecyclePolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userLifecyclePolicyProjection {
+	p := new(userLifecyclePolicyProjection)
+	config.ProjectionName = UserLifecyclePolicyProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(UserLifecyclePolicyColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserLifecyclePolicyColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserLifecyclePolicyColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserLifecyclePolicyColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(UserLifecyclePolicyColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserLifecyclePolicyColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserLifecyclePolicyColumnStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserLifecyclePolicyColumnIsDefault, crdb.ColumnTypeBool),
+			crdb.NewColumn(UserLifecyclePolicyColumnDeactivateAfterDays, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserLifecyclePolicyColumnDeleteAfterDays, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserLifecyclePolicyColumnWarnDaysBefore, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserLifecyclePolicyColumnExcludeMachineUsers, crdb.ColumnTypeBool),
+			crdb.NewColumn(UserLifecyclePolicyColumnExcludedUserIDs, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(UserLifecyclePolicyColumnDryRun, crdb.ColumnTypeBool),
+			crdb.NewColumn(UserLifecyclePolicyColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(UserLifecyclePolicyColumnInstanceID, UserLifecyclePolicyColumnID),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{UserLifecyclePolicyColumnOwnerRemoved})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userLifecyclePolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.UserLifecyclePolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.UserLifecyclePolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.UserLifecyclePolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserLifecyclePolicyColumnInstanceID),
+				},
+				{
+					Event:  instance.UserLifecyclePolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.UserLifecyclePolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *userLifecyclePolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.UserLifecyclePolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.UserLifecyclePolicyAddedEvent:
+		policyEvent = e.UserLifecyclePolicyAddedEvent
+		isDefault = false
+	case *instance.UserLifecyclePolicyAddedEvent:
+		policyEvent = e.UserLifecyclePolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ulc2a", "reduce.wrong.event.type %v", []eventstore.EventType{org.UserLifecyclePolicyAddedEventType, instance.UserLifecyclePolicyAddedEventType})
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(UserLifecyclePolicyColumnCreationDate, policyEvent.CreationDate()),
+			handler.NewCol(UserLifecyclePolicyColumnChangeDate, policyEvent.CreationDate()),
+			handler.NewCol(UserLifecyclePolicyColumnSequence, policyEvent.Sequence()),
+			handler.NewCol(UserLifecyclePolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCol(UserLifecyclePolicyColumnStateCol, domain.PolicyStateActive),
+			handler.NewCol(UserLifecyclePolicyColumnDeactivateAfterDays, policyEvent.DeactivateAfterDays),
+			handler.NewCol(UserLifecyclePolicyColumnDeleteAfterDays, policyEvent.DeleteAfterDays),
+			handler.NewCol(UserLifecyclePolicyColumnWarnDaysBefore, policyEvent.WarnDaysBefore),
+			handler.NewCol(UserLifecyclePolicyColumnExcludeMachineUsers, policyEvent.ExcludeMachineUsers),
+			handler.NewCol(UserLifecyclePolicyColumnExcludedUserIDs, database.StringArray(policyEvent.ExcludedUserIDs)),
+			handler.NewCol(UserLifecyclePolicyColumnDryRun, policyEvent.DryRun),
+			handler.NewCol(UserLifecyclePolicyColumnIsDefault, isDefault),
+			handler.NewCol(UserLifecyclePolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(UserLifecyclePolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *userLifecyclePolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.UserLifecyclePolicyChangedEvent
+	switch e := event.(type) {
+	case *org.UserLifecyclePolicyChangedEvent:
+		policyEvent = e.UserLifecyclePolicyChangedEvent
+	case *instance.UserLifecyclePolicyChangedEvent:
+		policyEvent = e.UserLifecyclePolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ulc5c", "reduce.wrong.event.type %v", []eventstore.EventType{org.UserLifecyclePolicyChangedEventType, instance.UserLifecyclePolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(UserLifecyclePolicyColumnChangeDate, policyEvent.CreationDate()),
+		handler.NewCol(UserLifecyclePolicyColumnSequence, policyEvent.Sequence()),
+	}
+	if policyEvent.DeactivateAfterDays != nil {
+		cols = append(cols, handler.NewCol(UserLifecyclePolicyColumnDeactivateAfterDays, *policyEvent.DeactivateAfterDays))
+	}
+	if policyEvent.DeleteAfterDays != nil {
+		cols = append(cols, handler.NewCol(UserLifecyclePolicyColumnDeleteAfterDays, *policyEvent.DeleteAfterDays))
+	}
+	if policyEvent.WarnDaysBefore != nil {
+		cols = append(cols, handler.NewCol(UserLifecyclePolicyColumnWarnDaysBefore, *policyEvent.WarnDaysBefore))
+	}
+	if policyEvent.ExcludeMachineUsers != nil {
+		cols = append(cols, handler.NewCol(UserLifecyclePolicyColumnExcludeMachineUsers, *policyEvent.ExcludeMachineUsers))
+	}
+	if policyEvent.ExcludedUserIDs != nil {
+		cols = append(cols, handler.NewCol(UserLifecyclePolicyColumnExcludedUserIDs, database.StringArray(*policyEvent.ExcludedUserIDs)))
+	}
+	if policyEvent.DryRun != nil {
+		cols = append(cols, handler.NewCol(UserLifecyclePolicyColumnDryRun, *policyEvent.DryRun))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(UserLifecyclePolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(UserLifecyclePolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *userLifecyclePolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.UserLifecyclePolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ulc8r", "reduce.wrong.event.type %s", org.UserLifecyclePolicyRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(UserLifecyclePolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(UserLifecyclePolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *userLifecyclePolicyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ulc4o", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserLifecyclePolicyColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserLifecyclePolicyColumnSequence, e.Sequence()),
+			handler.NewCol(UserLifecyclePolicyColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserLifecyclePolicyColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserLifecyclePolicyColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
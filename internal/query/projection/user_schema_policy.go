@@ -0,0 +1,197 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	UserSchemaPolicyProjectionTable = "projections.user_schema_policies"
+
+	UserSchemaPolicyColumnID            = "id"
+	UserSchemaPolicyColumnCreationDate  = "creation_date"
+	UserSchemaPolicyColumnChangeDate    = "change_date"
+	UserSchemaPolicyColumnResourceOwner = "resource_owner"
+	UserSchemaPolicyColumnInstanceID    = "instance_id"
+	UserSchemaPolicyColumnSequence      = "sequence"
+	UserSchemaPolicyColumnStateCol      = "state"
+	UserSchemaPolicyColumnIsDefault     = "is_default"
+	UserSchemaPolicyColumnAttributes    = "attributes"
+	UserSchemaPolicyColumnOwnerRemoved  = "owner_removed"
+)
+
+type userSchemaPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newUserSchemaPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userSchemaPolicyProjection {
+	p := new(userSchemaPolicyProjection)
+	config.ProjectionName = UserSchemaPolicyProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(UserSchemaPolicyColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSchemaPolicyColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSchemaPolicyColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSchemaPolicyColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSchemaPolicyColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSchemaPolicyColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserSchemaPolicyColumnStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserSchemaPolicyColumnIsDefault, crdb.ColumnTypeBool),
+			crdb.NewColumn(UserSchemaPolicyColumnAttributes, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(UserSchemaPolicyColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(UserSchemaPolicyColumnInstanceID, UserSchemaPolicyColumnID),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{UserSchemaPolicyColumnOwnerRemoved})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userSchemaPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.UserSchemaPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.UserSchemaPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.UserSchemaPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserSchemaPolicyColumnInstanceID),
+				},
+				{
+					Event:  instance.UserSchemaPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.UserSchemaPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *userSchemaPolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.UserSchemaPolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.UserSchemaPolicyAddedEvent:
+		policyEvent = e.UserSchemaPolicyAddedEvent
+		isDefault = false
+	case *instance.UserSchemaPolicyAddedEvent:
+		policyEvent = e.UserSchemaPolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Usc3a", "reduce.wrong.event.type %v", []eventstore.EventType{org.UserSchemaPolicyAddedEventType, instance.UserSchemaPolicyAddedEventType})
+	}
+	attributes, err := json.Marshal(policyEvent.Attributes)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJE-Usc6j", "Errors.Internal")
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(UserSchemaPolicyColumnCreationDate, policyEvent.CreationDate()),
+			handler.NewCol(UserSchemaPolicyColumnChangeDate, policyEvent.CreationDate()),
+			handler.NewCol(UserSchemaPolicyColumnSequence, policyEvent.Sequence()),
+			handler.NewCol(UserSchemaPolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCol(UserSchemaPolicyColumnStateCol, domain.PolicyStateActive),
+			handler.NewCol(UserSchemaPolicyColumnAttributes, attributes),
+			handler.NewCol(UserSchemaPolicyColumnIsDefault, isDefault),
+			handler.NewCol(UserSchemaPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(UserSchemaPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *userSchemaPolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.UserSchemaPolicyChangedEvent
+	switch e := event.(type) {
+	case *org.UserSchemaPolicyChangedEvent:
+		policyEvent = e.UserSchemaPolicyChangedEvent
+	case *instance.UserSchemaPolicyChangedEvent:
+		policyEvent = e.UserSchemaPolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Usc1c", "reduce.wrong.event.type %v", []eventstore.EventType{org.UserSchemaPolicyChangedEventType, instance.UserSchemaPolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(UserSchemaPolicyColumnChangeDate, policyEvent.CreationDate()),
+		handler.NewCol(UserSchemaPolicyColumnSequence, policyEvent.Sequence()),
+	}
+	if policyEvent.Attributes != nil {
+		attributes, err := json.Marshal(*policyEvent.Attributes)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "PROJE-Usc2j", "Errors.Internal")
+		}
+		cols = append(cols, handler.NewCol(UserSchemaPolicyColumnAttributes, attributes))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(UserSchemaPolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(UserSchemaPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *userSchemaPolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.UserSchemaPolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Usc9r", "reduce.wrong.event.type %s", org.UserSchemaPolicyRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(UserSchemaPolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(UserSchemaPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *userSchemaPolicyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Usc5o", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserSchemaPolicyColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserSchemaPolicyColumnSequence, e.Sequence()),
+			handler.NewCol(UserSchemaPolicyColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserSchemaPolicyColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserSchemaPolicyColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
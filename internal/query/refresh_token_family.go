@@ -0,0 +1,223 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	refreshTokenFamiliesTable = table{
+		name:          projection.RefreshTokenFamilyProjectionTable,
+		instanceIDCol: projection.RefreshTokenFamilyColumnInstanceID,
+	}
+	RefreshTokenFamilyColumnID = Column{
+		name:  projection.RefreshTokenFamilyColumnID,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnCreationDate = Column{
+		name:  projection.RefreshTokenFamilyColumnCreationDate,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnChangeDate = Column{
+		name:  projection.RefreshTokenFamilyColumnChangeDate,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnSequence = Column{
+		name:  projection.RefreshTokenFamilyColumnSequence,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnResourceOwner = Column{
+		name:  projection.RefreshTokenFamilyColumnResourceOwner,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnInstanceID = Column{
+		name:  projection.RefreshTokenFamilyColumnInstanceID,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnUserID = Column{
+		name:  projection.RefreshTokenFamilyColumnUserID,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnClientID = Column{
+		name:  projection.RefreshTokenFamilyColumnClientID,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnUserAgentID = Column{
+		name:  projection.RefreshTokenFamilyColumnUserAgentID,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnAuthTime = Column{
+		name:  projection.RefreshTokenFamilyColumnAuthTime,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnIdleExpiration = Column{
+		name:  projection.RefreshTokenFamilyColumnIdleExpiration,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnExpiration = Column{
+		name:  projection.RefreshTokenFamilyColumnExpiration,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnScopes = Column{
+		name:  projection.RefreshTokenFamilyColumnScopes,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnAudience = Column{
+		name:  projection.RefreshTokenFamilyColumnAudience,
+		table: refreshTokenFamiliesTable,
+	}
+	RefreshTokenFamilyColumnOwnerRemoved = Column{
+		name:  projection.RefreshTokenFamilyColumnOwnerRemoved,
+		table: refreshTokenFamiliesTable,
+	}
+)
+
+type RefreshTokenFamilies struct {
+	SearchResponse
+	RefreshTokenFamilies []*RefreshTokenFamily
+}
+
+// RefreshTokenFamily represents all refresh tokens issued by renewing the token of a single authentication.
+// The ID stays the same on every rotation.
+type RefreshTokenFamily struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	UserID         string
+	ClientID       string
+	UserAgentID    string
+	AuthTime       time.Time
+	IdleExpiration time.Time
+	Expiration     time.Time
+	Scopes         database.StringArray
+	Audience       database.StringArray
+}
+
+type RefreshTokenFamilySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *Queries) SearchRefreshTokenFamilies(ctx context.Context, queries *RefreshTokenFamilySearchQueries, withOwnerRemoved bool) (families *RefreshTokenFamilies, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareRefreshTokenFamiliesQuery(ctx, q.client)
+	eq := sq.Eq{
+		RefreshTokenFamilyColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[RefreshTokenFamilyColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Eiz4a", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ieP3e", "Errors.Internal")
+	}
+	families, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	families.LatestSequence, err = q.latestSequence(ctx, refreshTokenFamiliesTable)
+	return families, err
+}
+
+func NewRefreshTokenFamilyResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RefreshTokenFamilyColumnResourceOwner, value, TextEquals)
+}
+
+func NewRefreshTokenFamilyUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RefreshTokenFamilyColumnUserID, value, TextEquals)
+}
+
+func (r *RefreshTokenFamilySearchQueries) AppendMyResourceOwnerQuery(orgID string) error {
+	query, err := NewRefreshTokenFamilyResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return err
+	}
+	r.Queries = append(r.Queries, query)
+	return nil
+}
+
+func (q *RefreshTokenFamilySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func prepareRefreshTokenFamiliesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*RefreshTokenFamilies, error)) {
+	return sq.Select(
+			RefreshTokenFamilyColumnID.identifier(),
+			RefreshTokenFamilyColumnCreationDate.identifier(),
+			RefreshTokenFamilyColumnChangeDate.identifier(),
+			RefreshTokenFamilyColumnResourceOwner.identifier(),
+			RefreshTokenFamilyColumnSequence.identifier(),
+			RefreshTokenFamilyColumnUserID.identifier(),
+			RefreshTokenFamilyColumnClientID.identifier(),
+			RefreshTokenFamilyColumnUserAgentID.identifier(),
+			RefreshTokenFamilyColumnAuthTime.identifier(),
+			RefreshTokenFamilyColumnIdleExpiration.identifier(),
+			RefreshTokenFamilyColumnExpiration.identifier(),
+			RefreshTokenFamilyColumnScopes.identifier(),
+			RefreshTokenFamilyColumnAudience.identifier(),
+			countColumn.identifier()).
+			From(refreshTokenFamiliesTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*RefreshTokenFamilies, error) {
+			families := make([]*RefreshTokenFamily, 0)
+			var count uint64
+			for rows.Next() {
+				family := new(RefreshTokenFamily)
+				err := rows.Scan(
+					&family.ID,
+					&family.CreationDate,
+					&family.ChangeDate,
+					&family.ResourceOwner,
+					&family.Sequence,
+					&family.UserID,
+					&family.ClientID,
+					&family.UserAgentID,
+					&family.AuthTime,
+					&family.IdleExpiration,
+					&family.Expiration,
+					&family.Scopes,
+					&family.Audience,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				families = append(families, family)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ohb8o", "Errors.Query.CloseRows")
+			}
+
+			return &RefreshTokenFamilies{
+				RefreshTokenFamilies: families,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,146 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	refreshTokenFamiliesStmt = regexp.QuoteMeta(
+		"SELECT projections.refresh_token_families.id," +
+			" projections.refresh_token_families.creation_date," +
+			" projections.refresh_token_families.change_date," +
+			" projections.refresh_token_families.resource_owner," +
+			" projections.refresh_token_families.sequence," +
+			" projections.refresh_token_families.user_id," +
+			" projections.refresh_token_families.client_id," +
+			" projections.refresh_token_families.user_agent_id," +
+			" projections.refresh_token_families.auth_time," +
+			" projections.refresh_token_families.idle_expiration," +
+			" projections.refresh_token_families.expiration," +
+			" projections.refresh_token_families.scopes," +
+			" projections.refresh_token_families.audience," +
+			" COUNT(*) OVER ()" +
+			" FROM projections.refresh_token_families" +
+			" AS OF SYSTEM TIME '-1 ms'")
+	refreshTokenFamiliesCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"user_id",
+		"client_id",
+		"user_agent_id",
+		"auth_time",
+		"idle_expiration",
+		"expiration",
+		"scopes",
+		"audience",
+		"count",
+	}
+)
+
+func Test_RefreshTokenFamilyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareRefreshTokenFamiliesQuery no result",
+			prepare: prepareRefreshTokenFamiliesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					refreshTokenFamiliesStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &RefreshTokenFamilies{RefreshTokenFamilies: []*RefreshTokenFamily{}},
+		},
+		{
+			name:    "prepareRefreshTokenFamiliesQuery one family",
+			prepare: prepareRefreshTokenFamiliesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					refreshTokenFamiliesStmt,
+					refreshTokenFamiliesCols,
+					[][]driver.Value{
+						{
+							"token-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20230101),
+							"user-id",
+							"client-id",
+							"agent-id",
+							testNow,
+							testNow.Add(time.Hour),
+							testNow.Add(24 * time.Hour),
+							database.StringArray{"openid", "offline_access"},
+							database.StringArray{"client-id"},
+						},
+					},
+				),
+			},
+			object: &RefreshTokenFamilies{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				RefreshTokenFamilies: []*RefreshTokenFamily{
+					{
+						ID:             "token-id",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						ResourceOwner:  "ro",
+						Sequence:       20230101,
+						UserID:         "user-id",
+						ClientID:       "client-id",
+						UserAgentID:    "agent-id",
+						AuthTime:       testNow,
+						IdleExpiration: testNow.Add(time.Hour),
+						Expiration:     testNow.Add(24 * time.Hour),
+						Scopes:         database.StringArray{"openid", "offline_access"},
+						Audience:       database.StringArray{"client-id"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareRefreshTokenFamiliesQuery sql err",
+			prepare: prepareRefreshTokenFamiliesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					refreshTokenFamiliesStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
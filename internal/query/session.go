@@ -23,17 +23,19 @@ type Sessions struct {
 }
 
 type Session struct {
-	ID             string
-	CreationDate   time.Time
-	ChangeDate     time.Time
-	Sequence       uint64
-	State          domain.SessionState
-	ResourceOwner  string
-	Creator        string
-	UserFactor     SessionUserFactor
-	PasswordFactor SessionPasswordFactor
-	PasskeyFactor  SessionPasskeyFactor
-	Metadata       map[string][]byte
+	ID                 string
+	CreationDate       time.Time
+	ChangeDate         time.Time
+	Sequence           uint64
+	State              domain.SessionState
+	ResourceOwner      string
+	Creator            string
+	UserFactor         SessionUserFactor
+	PasswordFactor     SessionPasswordFactor
+	PasskeyFactor      SessionPasskeyFactor
+	MagicLinkFactor    SessionMagicLinkFactor
+	RecoveryCodeFactor SessionRecoveryCodeFactor
+	Metadata           map[string][]byte
 }
 
 type SessionUserFactor struct {
@@ -51,6 +53,14 @@ type SessionPasskeyFactor struct {
 	PasskeyCheckedAt time.Time
 }
 
+type SessionMagicLinkFactor struct {
+	MagicLinkCheckedAt time.Time
+}
+
+type SessionRecoveryCodeFactor struct {
+	RecoveryCodeCheckedAt time.Time
+}
+
 type SessionsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -117,6 +127,14 @@ var (
 		name:  projection.SessionColumnPasskeyCheckedAt,
 		table: sessionsTable,
 	}
+	SessionColumnMagicLinkCheckedAt = Column{
+		name:  projection.SessionColumnMagicLinkCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnRecoveryCodeCheckedAt = Column{
+		name:  projection.SessionColumnRecoveryCodeCheckedAt,
+		table: sessionsTable,
+	}
 	SessionColumnMetadata = Column{
 		name:  projection.SessionColumnMetadata,
 		table: sessionsTable,
@@ -193,6 +211,10 @@ func NewSessionCreatorSearchQuery(creator string) (SearchQuery, error) {
 	return NewTextQuery(SessionColumnCreator, creator, TextEquals)
 }
 
+func NewSessionUserIDSearchQuery(userID string) (SearchQuery, error) {
+	return NewTextQuery(SessionColumnUserID, userID, TextEquals)
+}
+
 func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*Session, string, error)) {
 	return sq.Select(
 			SessionColumnID.identifier(),
@@ -208,6 +230,8 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			HumanDisplayNameCol.identifier(),
 			SessionColumnPasswordCheckedAt.identifier(),
 			SessionColumnPasskeyCheckedAt.identifier(),
+			SessionColumnMagicLinkCheckedAt.identifier(),
+			SessionColumnRecoveryCodeCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnToken.identifier(),
 		).From(sessionsTable.identifier()).
@@ -217,14 +241,16 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			session := new(Session)
 
 			var (
-				userID            sql.NullString
-				userCheckedAt     sql.NullTime
-				loginName         sql.NullString
-				displayName       sql.NullString
-				passwordCheckedAt sql.NullTime
-				passkeyCheckedAt  sql.NullTime
-				metadata          database.Map[[]byte]
-				token             sql.NullString
+				userID                sql.NullString
+				userCheckedAt         sql.NullTime
+				loginName             sql.NullString
+				displayName           sql.NullString
+				passwordCheckedAt     sql.NullTime
+				passkeyCheckedAt      sql.NullTime
+				magicLinkCheckedAt    sql.NullTime
+				recoveryCodeCheckedAt sql.NullTime
+				metadata              database.Map[[]byte]
+				token                 sql.NullString
 			)
 
 			err := row.Scan(
@@ -241,6 +267,8 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				&displayName,
 				&passwordCheckedAt,
 				&passkeyCheckedAt,
+				&magicLinkCheckedAt,
+				&recoveryCodeCheckedAt,
 				&metadata,
 				&token,
 			)
@@ -258,6 +286,8 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			session.UserFactor.DisplayName = displayName.String
 			session.PasswordFactor.PasswordCheckedAt = passwordCheckedAt.Time
 			session.PasskeyFactor.PasskeyCheckedAt = passkeyCheckedAt.Time
+			session.MagicLinkFactor.MagicLinkCheckedAt = magicLinkCheckedAt.Time
+			session.RecoveryCodeFactor.RecoveryCodeCheckedAt = recoveryCodeCheckedAt.Time
 			session.Metadata = metadata
 
 			return session, token.String, nil
@@ -279,6 +309,8 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			HumanDisplayNameCol.identifier(),
 			SessionColumnPasswordCheckedAt.identifier(),
 			SessionColumnPasskeyCheckedAt.identifier(),
+			SessionColumnMagicLinkCheckedAt.identifier(),
+			SessionColumnRecoveryCodeCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			countColumn.identifier(),
 		).From(sessionsTable.identifier()).
@@ -291,13 +323,15 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 				session := new(Session)
 
 				var (
-					userID            sql.NullString
-					userCheckedAt     sql.NullTime
-					loginName         sql.NullString
-					displayName       sql.NullString
-					passwordCheckedAt sql.NullTime
-					passkeyCheckedAt  sql.NullTime
-					metadata          database.Map[[]byte]
+					userID                sql.NullString
+					userCheckedAt         sql.NullTime
+					loginName             sql.NullString
+					displayName           sql.NullString
+					passwordCheckedAt     sql.NullTime
+					passkeyCheckedAt      sql.NullTime
+					magicLinkCheckedAt    sql.NullTime
+					recoveryCodeCheckedAt sql.NullTime
+					metadata              database.Map[[]byte]
 				)
 
 				err := rows.Scan(
@@ -314,6 +348,8 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					&displayName,
 					&passwordCheckedAt,
 					&passkeyCheckedAt,
+					&magicLinkCheckedAt,
+					&recoveryCodeCheckedAt,
 					&metadata,
 					&sessions.Count,
 				)
@@ -327,6 +363,8 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 				session.UserFactor.DisplayName = displayName.String
 				session.PasswordFactor.PasswordCheckedAt = passwordCheckedAt.Time
 				session.PasskeyFactor.PasskeyCheckedAt = passkeyCheckedAt.Time
+				session.MagicLinkFactor.MagicLinkCheckedAt = magicLinkCheckedAt.Time
+				session.RecoveryCodeFactor.RecoveryCodeCheckedAt = recoveryCodeCheckedAt.Time
 				session.Metadata = metadata
 
 				sessions.Sessions = append(sessions.Sessions, session)
@@ -17,43 +17,47 @@ import (
 )
 
 var (
-	expectedSessionQuery = regexp.QuoteMeta(`SELECT projections.sessions1.id,` +
-		` projections.sessions1.creation_date,` +
-		` projections.sessions1.change_date,` +
-		` projections.sessions1.sequence,` +
-		` projections.sessions1.state,` +
-		` projections.sessions1.resource_owner,` +
-		` projections.sessions1.creator,` +
-		` projections.sessions1.user_id,` +
-		` projections.sessions1.user_checked_at,` +
+	expectedSessionQuery = regexp.QuoteMeta(`SELECT projections.sessions3.id,` +
+		` projections.sessions3.creation_date,` +
+		` projections.sessions3.change_date,` +
+		` projections.sessions3.sequence,` +
+		` projections.sessions3.state,` +
+		` projections.sessions3.resource_owner,` +
+		` projections.sessions3.creator,` +
+		` projections.sessions3.user_id,` +
+		` projections.sessions3.user_checked_at,` +
 		` projections.login_names2.login_name,` +
 		` projections.users8_humans.display_name,` +
-		` projections.sessions1.password_checked_at,` +
-		` projections.sessions1.passkey_checked_at,` +
-		` projections.sessions1.metadata,` +
-		` projections.sessions1.token_id` +
-		` FROM projections.sessions1` +
-		` LEFT JOIN projections.login_names2 ON projections.sessions1.user_id = projections.login_names2.user_id AND projections.sessions1.instance_id = projections.login_names2.instance_id` +
-		` LEFT JOIN projections.users8_humans ON projections.sessions1.user_id = projections.users8_humans.user_id AND projections.sessions1.instance_id = projections.users8_humans.instance_id` +
+		` projections.sessions3.password_checked_at,` +
+		` projections.sessions3.passkey_checked_at,` +
+		` projections.sessions3.magic_link_checked_at,` +
+		` projections.sessions3.recovery_code_checked_at,` +
+		` projections.sessions3.metadata,` +
+		` projections.sessions3.token_id` +
+		` FROM projections.sessions3` +
+		` LEFT JOIN projections.login_names2 ON projections.sessions3.user_id = projections.login_names2.user_id AND projections.sessions3.instance_id = projections.login_names2.instance_id` +
+		` LEFT JOIN projections.users8_humans ON projections.sessions3.user_id = projections.users8_humans.user_id AND projections.sessions3.instance_id = projections.users8_humans.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedSessionsQuery = regexp.QuoteMeta(`SELECT projections.sessions1.id,` +
-		` projections.sessions1.creation_date,` +
-		` projections.sessions1.change_date,` +
-		` projections.sessions1.sequence,` +
-		` projections.sessions1.state,` +
-		` projections.sessions1.resource_owner,` +
-		` projections.sessions1.creator,` +
-		` projections.sessions1.user_id,` +
-		` projections.sessions1.user_checked_at,` +
+	expectedSessionsQuery = regexp.QuoteMeta(`SELECT projections.sessions3.id,` +
+		` projections.sessions3.creation_date,` +
+		` projections.sessions3.change_date,` +
+		` projections.sessions3.sequence,` +
+		` projections.sessions3.state,` +
+		` projections.sessions3.resource_owner,` +
+		` projections.sessions3.creator,` +
+		` projections.sessions3.user_id,` +
+		` projections.sessions3.user_checked_at,` +
 		` projections.login_names2.login_name,` +
 		` projections.users8_humans.display_name,` +
-		` projections.sessions1.password_checked_at,` +
-		` projections.sessions1.passkey_checked_at,` +
-		` projections.sessions1.metadata,` +
+		` projections.sessions3.password_checked_at,` +
+		` projections.sessions3.passkey_checked_at,` +
+		` projections.sessions3.magic_link_checked_at,` +
+		` projections.sessions3.recovery_code_checked_at,` +
+		` projections.sessions3.metadata,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.sessions1` +
-		` LEFT JOIN projections.login_names2 ON projections.sessions1.user_id = projections.login_names2.user_id AND projections.sessions1.instance_id = projections.login_names2.instance_id` +
-		` LEFT JOIN projections.users8_humans ON projections.sessions1.user_id = projections.users8_humans.user_id AND projections.sessions1.instance_id = projections.users8_humans.instance_id` +
+		` FROM projections.sessions3` +
+		` LEFT JOIN projections.login_names2 ON projections.sessions3.user_id = projections.login_names2.user_id AND projections.sessions3.instance_id = projections.login_names2.instance_id` +
+		` LEFT JOIN projections.users8_humans ON projections.sessions3.user_id = projections.users8_humans.user_id AND projections.sessions3.instance_id = projections.users8_humans.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	sessionCols = []string{
@@ -70,6 +74,8 @@ var (
 		"display_name",
 		"password_checked_at",
 		"passkey_checked_at",
+		"magic_link_checked_at",
+		"recovery_code_checked_at",
 		"metadata",
 		"token",
 	}
@@ -88,6 +94,8 @@ var (
 		"display_name",
 		"password_checked_at",
 		"passkey_checked_at",
+		"magic_link_checked_at",
+		"recovery_code_checked_at",
 		"metadata",
 		"count",
 	}
@@ -138,6 +146,8 @@ func Test_SessionsPrepare(t *testing.T) {
 							"display-name",
 							testNow,
 							testNow,
+							testNow,
+							testNow,
 							[]byte(`{"key": "dmFsdWU="}`),
 						},
 					},
@@ -168,6 +178,12 @@ func Test_SessionsPrepare(t *testing.T) {
 						PasskeyFactor: SessionPasskeyFactor{
 							PasskeyCheckedAt: testNow,
 						},
+						MagicLinkFactor: SessionMagicLinkFactor{
+							MagicLinkCheckedAt: testNow,
+						},
+						RecoveryCodeFactor: SessionRecoveryCodeFactor{
+							RecoveryCodeCheckedAt: testNow,
+						},
 						Metadata: map[string][]byte{
 							"key": []byte("value"),
 						},
@@ -197,6 +213,8 @@ func Test_SessionsPrepare(t *testing.T) {
 							"display-name",
 							testNow,
 							testNow,
+							testNow,
+							testNow,
 							[]byte(`{"key": "dmFsdWU="}`),
 						},
 						{
@@ -213,6 +231,8 @@ func Test_SessionsPrepare(t *testing.T) {
 							"display-name2",
 							testNow,
 							testNow,
+							testNow,
+							testNow,
 							[]byte(`{"key": "dmFsdWU="}`),
 						},
 					},
@@ -243,6 +263,12 @@ func Test_SessionsPrepare(t *testing.T) {
 						PasskeyFactor: SessionPasskeyFactor{
 							PasskeyCheckedAt: testNow,
 						},
+						MagicLinkFactor: SessionMagicLinkFactor{
+							MagicLinkCheckedAt: testNow,
+						},
+						RecoveryCodeFactor: SessionRecoveryCodeFactor{
+							RecoveryCodeCheckedAt: testNow,
+						},
 						Metadata: map[string][]byte{
 							"key": []byte("value"),
 						},
@@ -267,6 +293,12 @@ func Test_SessionsPrepare(t *testing.T) {
 						PasskeyFactor: SessionPasskeyFactor{
 							PasskeyCheckedAt: testNow,
 						},
+						MagicLinkFactor: SessionMagicLinkFactor{
+							MagicLinkCheckedAt: testNow,
+						},
+						RecoveryCodeFactor: SessionRecoveryCodeFactor{
+							RecoveryCodeCheckedAt: testNow,
+						},
 						Metadata: map[string][]byte{
 							"key": []byte("value"),
 						},
@@ -349,6 +381,8 @@ func Test_SessionPrepare(t *testing.T) {
 						"display-name",
 						testNow,
 						testNow,
+						testNow,
+						testNow,
 						[]byte(`{"key": "dmFsdWU="}`),
 						"tokenID",
 					},
@@ -374,6 +408,12 @@ func Test_SessionPrepare(t *testing.T) {
 				PasskeyFactor: SessionPasskeyFactor{
 					PasskeyCheckedAt: testNow,
 				},
+				MagicLinkFactor: SessionMagicLinkFactor{
+					MagicLinkCheckedAt: testNow,
+				},
+				RecoveryCodeFactor: SessionRecoveryCodeFactor{
+					RecoveryCodeCheckedAt: testNow,
+				},
 				Metadata: map[string][]byte{
 					"key": []byte("value"),
 				},
@@ -11,6 +11,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -21,6 +23,10 @@ var (
 		name:          projection.SMTPConfigProjectionTable,
 		instanceIDCol: projection.SMTPConfigColumnInstanceID,
 	}
+	SMTPConfigColumnID = Column{
+		name:  projection.SMTPConfigColumnID,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnAggregateID = Column{
 		name:  projection.SMTPConfigColumnAggregateID,
 		table: smtpConfigsTable,
@@ -45,6 +51,10 @@ var (
 		name:  projection.SMTPConfigColumnSequence,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnPriority = Column{
+		name:  projection.SMTPConfigColumnPriority,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnTLS = Column{
 		name:  projection.SMTPConfigColumnTLS,
 		table: smtpConfigsTable,
@@ -69,6 +79,26 @@ var (
 		name:  projection.SMTPConfigColumnSMTPPassword,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnAuthType = Column{
+		name:  projection.SMTPConfigColumnAuthType,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2TokenEndpoint = Column{
+		name:  projection.SMTPConfigColumnXOAuth2TokenEndpoint,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2ClientID = Column{
+		name:  projection.SMTPConfigColumnXOAuth2ClientID,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2Scopes = Column{
+		name:  projection.SMTPConfigColumnXOAuth2Scopes,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnOwnerRemoved = Column{
+		name:  projection.SMTPConfigColumnOwnerRemoved,
+		table: smtpConfigsTable,
+	}
 )
 
 type SMTPConfigs struct {
@@ -77,28 +107,52 @@ type SMTPConfigs struct {
 }
 
 type SMTPConfig struct {
+	ID            string
 	AggregateID   string
 	CreationDate  time.Time
 	ChangeDate    time.Time
 	ResourceOwner string
 	Sequence      uint64
 
+	Priority      uint32
 	TLS           bool
 	SenderAddress string
 	SenderName    string
 	Host          string
 	User          string
 	Password      *crypto.CryptoValue
+	AuthType      domain.SMTPAuthType
+	XOAuth2       *domain.SMTPXOAuth2
 }
 
-func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID string) (_ *SMTPConfig, err error) {
+type SMTPConfigSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *SMTPConfigSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewSMTPConfigResourceOwnerSearchQuery(resourceOwner string) (SearchQuery, error) {
+	return NewTextQuery(SMTPConfigColumnResourceOwner, resourceOwner, TextEquals)
+}
+
+// SMTPConfigByID returns the SMTP configuration of the resource owner (instance or organization)
+func (q *Queries) SMTPConfigByID(ctx context.Context, resourceOwner, id string) (_ *SMTPConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
 	stmt, scan := prepareSMTPConfigQuery(ctx, q.client)
 	query, args, err := stmt.Where(sq.Eq{
-		SMTPConfigColumnAggregateID.identifier(): aggregateID,
-		SMTPConfigColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		SMTPConfigColumnID.identifier():            id,
+		SMTPConfigColumnResourceOwner.identifier(): resourceOwner,
+		SMTPConfigColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		SMTPConfigColumnOwnerRemoved.identifier():  false,
 	}).ToSql()
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-3m9sl", "Errors.Query.SQLStatment")
@@ -108,37 +162,81 @@ func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID strin
 	return scan(row)
 }
 
+// SearchSMTPConfigs returns the SMTP configurations ordered by their priority,
+// which is the order they are used for sending emails
+func (q *Queries) SearchSMTPConfigs(ctx context.Context, queries *SMTPConfigSearchQueries) (configs *SMTPConfigs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareSMTPConfigsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			SMTPConfigColumnInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+			SMTPConfigColumnOwnerRemoved.identifier(): false,
+		}).
+		OrderBy(SMTPConfigColumnPriority.identifier(), SMTPConfigColumnCreationDate.identifier()).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Hn2pv", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Rj5wc", "Errors.Internal")
+	}
+	configs, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	configs.LatestSequence, err = q.latestSequence(ctx, smtpConfigsTable)
+	return configs, err
+}
+
 func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
 	password := new(crypto.CryptoValue)
 
 	return sq.Select(
+			SMTPConfigColumnID.identifier(),
 			SMTPConfigColumnAggregateID.identifier(),
 			SMTPConfigColumnCreationDate.identifier(),
 			SMTPConfigColumnChangeDate.identifier(),
 			SMTPConfigColumnResourceOwner.identifier(),
 			SMTPConfigColumnSequence.identifier(),
+			SMTPConfigColumnPriority.identifier(),
 			SMTPConfigColumnTLS.identifier(),
 			SMTPConfigColumnSenderAddress.identifier(),
 			SMTPConfigColumnSenderName.identifier(),
 			SMTPConfigColumnSMTPHost.identifier(),
 			SMTPConfigColumnSMTPUser.identifier(),
-			SMTPConfigColumnSMTPPassword.identifier()).
+			SMTPConfigColumnSMTPPassword.identifier(),
+			SMTPConfigColumnAuthType.identifier(),
+			SMTPConfigColumnXOAuth2TokenEndpoint.identifier(),
+			SMTPConfigColumnXOAuth2ClientID.identifier(),
+			SMTPConfigColumnXOAuth2Scopes.identifier()).
 			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SMTPConfig, error) {
 			config := new(SMTPConfig)
+			xoauth2 := new(domain.SMTPXOAuth2)
+			var scopes database.StringArray
 			err := row.Scan(
+				&config.ID,
 				&config.AggregateID,
 				&config.CreationDate,
 				&config.ChangeDate,
 				&config.ResourceOwner,
 				&config.Sequence,
+				&config.Priority,
 				&config.TLS,
 				&config.SenderAddress,
 				&config.SenderName,
 				&config.Host,
 				&config.User,
 				&password,
+				&config.AuthType,
+				&xoauth2.TokenEndpoint,
+				&xoauth2.ClientID,
+				&scopes,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -147,6 +245,84 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				return nil, errors.ThrowInternal(err, "QUERY-9k87F", "Errors.Internal")
 			}
 			config.Password = password
+			if config.AuthType == domain.SMTPAuthTypeXOAuth2 {
+				xoauth2.Scopes = scopes
+				config.XOAuth2 = xoauth2
+			}
 			return config, nil
 		}
 }
+
+func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*SMTPConfigs, error)) {
+	return sq.Select(
+			SMTPConfigColumnID.identifier(),
+			SMTPConfigColumnAggregateID.identifier(),
+			SMTPConfigColumnCreationDate.identifier(),
+			SMTPConfigColumnChangeDate.identifier(),
+			SMTPConfigColumnResourceOwner.identifier(),
+			SMTPConfigColumnSequence.identifier(),
+			SMTPConfigColumnPriority.identifier(),
+			SMTPConfigColumnTLS.identifier(),
+			SMTPConfigColumnSenderAddress.identifier(),
+			SMTPConfigColumnSenderName.identifier(),
+			SMTPConfigColumnSMTPHost.identifier(),
+			SMTPConfigColumnSMTPUser.identifier(),
+			SMTPConfigColumnSMTPPassword.identifier(),
+			SMTPConfigColumnAuthType.identifier(),
+			SMTPConfigColumnXOAuth2TokenEndpoint.identifier(),
+			SMTPConfigColumnXOAuth2ClientID.identifier(),
+			SMTPConfigColumnXOAuth2Scopes.identifier(),
+			countColumn.identifier()).
+			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SMTPConfigs, error) {
+			configs := make([]*SMTPConfig, 0)
+			var count uint64
+			for rows.Next() {
+				config := new(SMTPConfig)
+				password := new(crypto.CryptoValue)
+				xoauth2 := new(domain.SMTPXOAuth2)
+				var scopes database.StringArray
+				err := rows.Scan(
+					&config.ID,
+					&config.AggregateID,
+					&config.CreationDate,
+					&config.ChangeDate,
+					&config.ResourceOwner,
+					&config.Sequence,
+					&config.Priority,
+					&config.TLS,
+					&config.SenderAddress,
+					&config.SenderName,
+					&config.Host,
+					&config.User,
+					&password,
+					&config.AuthType,
+					&xoauth2.TokenEndpoint,
+					&xoauth2.ClientID,
+					&scopes,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				config.Password = password
+				if config.AuthType == domain.SMTPAuthTypeXOAuth2 {
+					xoauth2.Scopes = scopes
+					config.XOAuth2 = xoauth2
+				}
+				configs = append(configs, config)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ut3pe", "Errors.Query.CloseRows")
+			}
+
+			return &SMTPConfigs{
+				SMTPConfigs: configs,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
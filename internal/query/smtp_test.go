@@ -9,36 +9,71 @@ import (
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	errs "github.com/zitadel/zitadel/internal/errors"
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs.aggregate_id,` +
-		` projections.smtp_configs.creation_date,` +
-		` projections.smtp_configs.change_date,` +
-		` projections.smtp_configs.resource_owner,` +
-		` projections.smtp_configs.sequence,` +
-		` projections.smtp_configs.tls,` +
-		` projections.smtp_configs.sender_address,` +
-		` projections.smtp_configs.sender_name,` +
-		` projections.smtp_configs.host,` +
-		` projections.smtp_configs.username,` +
-		` projections.smtp_configs.password` +
-		` FROM projections.smtp_configs` +
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs1.id,` +
+		` projections.smtp_configs1.aggregate_id,` +
+		` projections.smtp_configs1.creation_date,` +
+		` projections.smtp_configs1.change_date,` +
+		` projections.smtp_configs1.resource_owner,` +
+		` projections.smtp_configs1.sequence,` +
+		` projections.smtp_configs1.priority,` +
+		` projections.smtp_configs1.tls,` +
+		` projections.smtp_configs1.sender_address,` +
+		` projections.smtp_configs1.sender_name,` +
+		` projections.smtp_configs1.host,` +
+		` projections.smtp_configs1.username,` +
+		` projections.smtp_configs1.password,` +
+		` projections.smtp_configs1.auth_type,` +
+		` projections.smtp_configs1.xoauth2_token_endpoint,` +
+		` projections.smtp_configs1.xoauth2_client_id,` +
+		` projections.smtp_configs1.xoauth2_scopes` +
+		` FROM projections.smtp_configs1` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareSMTPConfigCols = []string{
+		"id",
 		"aggregate_id",
 		"creation_date",
 		"change_date",
 		"resource_owner",
 		"sequence",
+		"priority",
 		"tls",
 		"sender_address",
 		"sender_name",
-		"smtp_host",
-		"smtp_user",
-		"smtp_password",
+		"host",
+		"username",
+		"password",
+		"auth_type",
+		"xoauth2_token_endpoint",
+		"xoauth2_client_id",
+		"xoauth2_scopes",
 	}
+	prepareSMTPConfigsStmt = `SELECT projections.smtp_configs1.id,` +
+		` projections.smtp_configs1.aggregate_id,` +
+		` projections.smtp_configs1.creation_date,` +
+		` projections.smtp_configs1.change_date,` +
+		` projections.smtp_configs1.resource_owner,` +
+		` projections.smtp_configs1.sequence,` +
+		` projections.smtp_configs1.priority,` +
+		` projections.smtp_configs1.tls,` +
+		` projections.smtp_configs1.sender_address,` +
+		` projections.smtp_configs1.sender_name,` +
+		` projections.smtp_configs1.host,` +
+		` projections.smtp_configs1.username,` +
+		` projections.smtp_configs1.password,` +
+		` projections.smtp_configs1.auth_type,` +
+		` projections.smtp_configs1.xoauth2_token_endpoint,` +
+		` projections.smtp_configs1.xoauth2_client_id,` +
+		` projections.smtp_configs1.xoauth2_scopes,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.smtp_configs1` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareSMTPConfigsCols = append(prepareSMTPConfigCols, "count")
 )
 
 func Test_SMTPConfigsPrepares(t *testing.T) {
@@ -78,21 +113,28 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 					regexp.QuoteMeta(prepareSMTPConfigStmt),
 					prepareSMTPConfigCols,
 					[]driver.Value{
+						"agg-id",
 						"agg-id",
 						testNow,
 						testNow,
 						"ro",
 						uint64(20211108),
+						uint32(0),
 						true,
 						"sender",
 						"name",
 						"host",
 						"user",
 						&crypto.CryptoValue{},
+						domain.SMTPAuthTypePlain,
+						"",
+						"",
+						nil,
 					},
 				),
 			},
 			object: &SMTPConfig{
+				ID:            "agg-id",
 				AggregateID:   "agg-id",
 				CreationDate:  testNow,
 				ChangeDate:    testNow,
@@ -104,6 +146,7 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				Host:          "host",
 				User:          "user",
 				Password:      &crypto.CryptoValue{},
+				AuthType:      domain.SMTPAuthTypePlain,
 			},
 		},
 		{
@@ -123,6 +166,129 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 			},
 			object: nil,
 		},
+		{
+			name:    "prepareSMTPConfigsQuery no result",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &SMTPConfigs{SMTPConfigs: []*SMTPConfig{}},
+		},
+		{
+			name:    "prepareSMTPConfigsQuery multiple result",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					prepareSMTPConfigsCols,
+					[][]driver.Value{
+						{
+							"config-id",
+							"instance-id",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211108),
+							uint32(1),
+							true,
+							"sender",
+							"name",
+							"host",
+							"user",
+							&crypto.CryptoValue{},
+							domain.SMTPAuthTypeXOAuth2,
+							"https://login.example.com/token",
+							"client-id",
+							database.StringArray{"smtp"},
+						},
+						{
+							"instance-id",
+							"instance-id",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211108),
+							uint32(2),
+							true,
+							"sender",
+							"name",
+							"host2",
+							"user",
+							&crypto.CryptoValue{},
+							domain.SMTPAuthTypePlain,
+							"",
+							"",
+							nil,
+						},
+					},
+				),
+			},
+			object: &SMTPConfigs{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				SMTPConfigs: []*SMTPConfig{
+					{
+						ID:            "config-id",
+						AggregateID:   "instance-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "instance-id",
+						Sequence:      20211108,
+						Priority:      1,
+						TLS:           true,
+						SenderAddress: "sender",
+						SenderName:    "name",
+						Host:          "host",
+						User:          "user",
+						Password:      &crypto.CryptoValue{},
+						AuthType:      domain.SMTPAuthTypeXOAuth2,
+						XOAuth2: &domain.SMTPXOAuth2{
+							TokenEndpoint: "https://login.example.com/token",
+							ClientID:      "client-id",
+							Scopes:        []string{"smtp"},
+						},
+					},
+					{
+						ID:            "instance-id",
+						AggregateID:   "instance-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "instance-id",
+						Sequence:      20211108,
+						Priority:      2,
+						TLS:           true,
+						SenderAddress: "sender",
+						SenderName:    "name",
+						Host:          "host2",
+						User:          "user",
+						Password:      &crypto.CryptoValue{},
+						AuthType:      domain.SMTPAuthTypePlain,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSMTPConfigsQuery sql err",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
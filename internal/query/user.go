@@ -546,6 +546,77 @@ func (q *Queries) SearchUsers(ctx context.Context, queries *UserSearchQueries, w
 	return users, err
 }
 
+// UserForAutoLinking searches the human user an external user of the identity provider can be linked to,
+// based on the verified email of the external user.
+// Only users of organizations, which allow the identity provider in their login policy, are considered.
+// If the resourceOwner is set, only users of that organization are considered.
+// A NotFound error is returned if no or more than one user matches.
+func (q *Queries) UserForAutoLinking(ctx context.Context, idpID string, email domain.EmailAddress, isEmailVerified bool, resourceOwner string) (_ *User, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	// the email has to be verified by the identity provider, otherwise anyone could claim any address
+	if email == "" || !isEmailVerified {
+		return nil, errors.ThrowNotFound(nil, "QUERY-u8Rwe", "Errors.User.NotFound")
+	}
+	typeQuery, err := NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, err
+	}
+	emailQuery, err := NewUserEmailSearchQuery(string(email.Normalize()), TextEqualsIgnoreCase)
+	if err != nil {
+		return nil, err
+	}
+	queries := []SearchQuery{typeQuery, emailQuery}
+	if resourceOwner != "" {
+		resourceOwnerQuery, err := NewUserResourceOwnerSearchQuery(resourceOwner, TextEquals)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, resourceOwnerQuery)
+	}
+	users, err := q.SearchUsers(ctx, &UserSearchQueries{Queries: queries}, false)
+	if err != nil {
+		return nil, err
+	}
+	var found *User
+	for _, user := range users.Users {
+		// only verified emails prove that the user owns the address
+		if user.Human == nil || !user.Human.IsEmailVerified {
+			continue
+		}
+		allowed, err := q.isIDPAllowedForOrg(ctx, idpID, user.ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+		if found != nil {
+			return nil, errors.ThrowNotFound(nil, "QUERY-Wm9fz", "Errors.User.NotFound")
+		}
+		found = user
+	}
+	if found == nil {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Rk0sp", "Errors.User.NotFound")
+	}
+	return found, nil
+}
+
+// isIDPAllowedForOrg checks if the identity provider is part of the active login policy of the organization
+func (q *Queries) isIDPAllowedForOrg(ctx context.Context, idpID, orgID string) (bool, error) {
+	links, err := q.IDPLoginPolicyLinks(ctx, orgID, &IDPLoginPolicyLinksSearchQuery{}, false)
+	if err != nil {
+		return false, err
+	}
+	for _, link := range links.Links {
+		if link.IDPID == idpID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (q *Queries) IsUserUnique(ctx context.Context, username, email, resourceOwner string, withOwnerRemoved bool) (_ bool, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -0,0 +1,274 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type UserActivity struct {
+	UserID           string
+	ResourceOwner    string
+	Type             domain.UserType
+	State            domain.UserState
+	LastActivity     time.Time
+	DeactivationDate time.Time
+	WarningDate      time.Time
+}
+
+var (
+	userActivityTable = table{
+		name:          projection.UserActivityProjectionTable,
+		instanceIDCol: projection.UserActivityColumnInstanceID,
+	}
+	UserActivityColUserID = Column{
+		name:  projection.UserActivityColumnUserID,
+		table: userActivityTable,
+	}
+	UserActivityColInstanceID = Column{
+		name:  projection.UserActivityColumnInstanceID,
+		table: userActivityTable,
+	}
+	UserActivityColResourceOwner = Column{
+		name:  projection.UserActivityColumnResourceOwner,
+		table: userActivityTable,
+	}
+	UserActivityColUserType = Column{
+		name:  projection.UserActivityColumnUserType,
+		table: userActivityTable,
+	}
+	UserActivityColState = Column{
+		name:  projection.UserActivityColumnState,
+		table: userActivityTable,
+	}
+	UserActivityColLastActivity = Column{
+		name:  projection.UserActivityColumnLastActivity,
+		table: userActivityTable,
+	}
+	UserActivityColDeactivationDate = Column{
+		name:  projection.UserActivityColumnDeactivationDate,
+		table: userActivityTable,
+	}
+	UserActivityColWarningDate = Column{
+		name:  projection.UserActivityColumnWarningDate,
+		table: userActivityTable,
+	}
+	UserActivityColOwnerRemoved = Column{
+		name:  projection.UserActivityColumnOwnerRemoved,
+		table: userActivityTable,
+	}
+)
+
+// UserActivityResourceOwners returns the ids of all organisations of the instance which have users
+func (q *Queries) UserActivityResourceOwners(ctx context.Context) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareUserActivityResourceOwnersQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		UserActivityColInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		UserActivityColOwnerRemoved.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Uac3q", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Uac4q", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// UserLifecycleCandidates returns the users of the organisation which might be affected by the [domain.UserLifecyclePolicy]:
+// active users without any activity since inactiveSince and inactive users deactivated before deactivatedBefore.
+// A zero time skips the respective state.
+func (q *Queries) UserLifecycleCandidates(ctx context.Context, orgID string, inactiveSince, deactivatedBefore time.Time) (_ []*UserActivity, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	states := sq.Or{}
+	if !inactiveSince.IsZero() {
+		states = append(states, sq.And{
+			sq.Eq{UserActivityColState.identifier(): domain.UserStateActive},
+			sq.Lt{UserActivityColLastActivity.identifier(): inactiveSince},
+		})
+	}
+	if !deactivatedBefore.IsZero() {
+		states = append(states, sq.And{
+			sq.Eq{UserActivityColState.identifier(): domain.UserStateInactive},
+			sq.Lt{UserActivityColDeactivationDate.identifier(): deactivatedBefore},
+		})
+	}
+	if len(states) == 0 {
+		return nil, nil
+	}
+
+	stmt, scan := prepareUserActivitiesQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.And{
+		sq.Eq{
+			UserActivityColInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+			UserActivityColResourceOwner.identifier(): orgID,
+			UserActivityColOwnerRemoved.identifier():  false,
+		},
+		states,
+	}).OrderBy(UserActivityColUserID.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Uac5q", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Uac6q", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// UserLifecycleCandidate is a user for whom an action of the [domain.UserLifecyclePolicy] is due
+type UserLifecycleCandidate struct {
+	UserID        string
+	ResourceOwner string
+	Type          domain.UserType
+	Action        domain.UserLifecycleAction
+	DueDate       time.Time
+}
+
+type UserLifecycleReport struct {
+	Policy     *UserLifecyclePolicy
+	Candidates []*UserLifecycleCandidate
+}
+
+// UserLifecycleReport returns the users of the organisation which will be warned, deactivated or deleted
+// at the given time by the (default) user lifecycle policy of the organisation.
+func (q *Queries) UserLifecycleReport(ctx context.Context, orgID string, now time.Time) (_ *UserLifecycleReport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	policy, err := q.UserLifecyclePolicyByOrg(ctx, false, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	domainPolicy := policy.ToDomain()
+	activities, err := q.UserLifecycleCandidates(ctx, orgID,
+		lifecycleThreshold(now, domainPolicy.DeactivateAfterDays, domainPolicy.WarnDaysBefore),
+		lifecycleThreshold(now, domainPolicy.DeleteAfterDays, domainPolicy.WarnDaysBefore),
+	)
+	if err != nil {
+		return nil, err
+	}
+	report := &UserLifecycleReport{
+		Policy:     policy,
+		Candidates: make([]*UserLifecycleCandidate, 0, len(activities)),
+	}
+	for _, activity := range activities {
+		action, dueDate := domainPolicy.NextAction(activity.ToDomain(), now)
+		if action == domain.UserLifecycleActionNone {
+			continue
+		}
+		report.Candidates = append(report.Candidates, &UserLifecycleCandidate{
+			UserID:        activity.UserID,
+			ResourceOwner: activity.ResourceOwner,
+			Type:          activity.Type,
+			Action:        action,
+			DueDate:       dueDate,
+		})
+	}
+	return report, nil
+}
+
+// lifecycleThreshold returns the date before which the period must have started for any action (including the warning) to be due.
+// A zero time is returned if the step is disabled.
+func lifecycleThreshold(now time.Time, days, warnDays uint64) time.Time {
+	if days == 0 {
+		return time.Time{}
+	}
+	if warnDays >= days {
+		return now
+	}
+	return now.Add(-time.Duration(days-warnDays) * 24 * time.Hour)
+}
+
+func (a *UserActivity) ToDomain() *domain.UserActivity {
+	return &domain.UserActivity{
+		UserID:           a.UserID,
+		Type:             a.Type,
+		State:            a.State,
+		LastActivity:     a.LastActivity,
+		DeactivationDate: a.DeactivationDate,
+		WarningDate:      a.WarningDate,
+	}
+}
+
+func prepareUserActivityResourceOwnersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]string, error)) {
+	return sq.Select(
+			UserActivityColResourceOwner.identifier(),
+		).Distinct().
+			From(userActivityTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]string, error) {
+			resourceOwners := make([]string, 0)
+			for rows.Next() {
+				var resourceOwner string
+				if err := rows.Scan(&resourceOwner); err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Uac7s", "Errors.Internal")
+				}
+				resourceOwners = append(resourceOwners, resourceOwner)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Uac8c", "Errors.Query.CloseRows")
+			}
+			return resourceOwners, nil
+		}
+}
+
+func prepareUserActivitiesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*UserActivity, error)) {
+	return sq.Select(
+			UserActivityColUserID.identifier(),
+			UserActivityColResourceOwner.identifier(),
+			UserActivityColUserType.identifier(),
+			UserActivityColState.identifier(),
+			UserActivityColLastActivity.identifier(),
+			UserActivityColDeactivationDate.identifier(),
+			UserActivityColWarningDate.identifier(),
+		).
+			From(userActivityTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*UserActivity, error) {
+			activities := make([]*UserActivity, 0)
+			for rows.Next() {
+				activity := new(UserActivity)
+				var (
+					deactivationDate sql.NullTime
+					warningDate      sql.NullTime
+				)
+				err := rows.Scan(
+					&activity.UserID,
+					&activity.ResourceOwner,
+					&activity.Type,
+					&activity.State,
+					&activity.LastActivity,
+					&deactivationDate,
+					&warningDate,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Uac9s", "Errors.Internal")
+				}
+				activity.DeactivationDate = deactivationDate.Time
+				activity.WarningDate = warningDate.Time
+				activities = append(activities, activity)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Uac1c", "Errors.Query.CloseRows")
+			}
+			return activities, nil
+		}
+}
@@ -0,0 +1,190 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	userActivitiesStmt = regexp.QuoteMeta(`SELECT projections.user_activities.user_id,` +
+		` projections.user_activities.resource_owner,` +
+		` projections.user_activities.user_type,` +
+		` projections.user_activities.state,` +
+		` projections.user_activities.last_activity,` +
+		` projections.user_activities.deactivation_date,` +
+		` projections.user_activities.warning_date` +
+		` FROM projections.user_activities` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	userActivitiesCols = []string{
+		"user_id",
+		"resource_owner",
+		"user_type",
+		"state",
+		"last_activity",
+		"deactivation_date",
+		"warning_date",
+	}
+	userActivityResourceOwnersStmt = regexp.QuoteMeta(`SELECT DISTINCT projections.user_activities.resource_owner` +
+		` FROM projections.user_activities` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+)
+
+func Test_UserActivityPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserActivitiesQuery no result",
+			prepare: prepareUserActivitiesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userActivitiesStmt,
+					nil,
+					nil,
+				),
+			},
+			object: []*UserActivity{},
+		},
+		{
+			name:    "prepareUserActivitiesQuery found",
+			prepare: prepareUserActivitiesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userActivitiesStmt,
+					userActivitiesCols,
+					[][]driver.Value{
+						{
+							"user1",
+							"ro",
+							domain.UserTypeHuman,
+							domain.UserStateActive,
+							testNow,
+							nil,
+							nil,
+						},
+						{
+							"user2",
+							"ro",
+							domain.UserTypeMachine,
+							domain.UserStateInactive,
+							testNow,
+							testNow,
+							testNow,
+						},
+					},
+				),
+			},
+			object: []*UserActivity{
+				{
+					UserID:        "user1",
+					ResourceOwner: "ro",
+					Type:          domain.UserTypeHuman,
+					State:         domain.UserStateActive,
+					LastActivity:  testNow,
+				},
+				{
+					UserID:           "user2",
+					ResourceOwner:    "ro",
+					Type:             domain.UserTypeMachine,
+					State:            domain.UserStateInactive,
+					LastActivity:     testNow,
+					DeactivationDate: testNow,
+					WarningDate:      testNow,
+				},
+			},
+		},
+		{
+			name:    "prepareUserActivitiesQuery sql err",
+			prepare: prepareUserActivitiesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userActivitiesStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareUserActivityResourceOwnersQuery found",
+			prepare: prepareUserActivityResourceOwnersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userActivityResourceOwnersStmt,
+					[]string{"resource_owner"},
+					[][]driver.Value{
+						{"ro1"},
+						{"ro2"},
+					},
+				),
+			},
+			object: []string{"ro1", "ro2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_lifecycleThreshold(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		days     uint64
+		warnDays uint64
+		want     time.Time
+	}{
+		{
+			"disabled",
+			0,
+			7,
+			time.Time{},
+		},
+		{
+			"without warning",
+			90,
+			0,
+			now.Add(-90 * 24 * time.Hour),
+		},
+		{
+			"with warning",
+			90,
+			7,
+			now.Add(-83 * 24 * time.Hour),
+		},
+		{
+			"warning longer than period",
+			5,
+			7,
+			now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lifecycleThreshold(now, tt.days, tt.warnDays); !got.Equal(tt.want) {
+				t.Errorf("lifecycleThreshold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
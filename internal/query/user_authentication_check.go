@@ -0,0 +1,126 @@
+package query
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// UserAuthenticationCheck is a single (succeeded or failed) check of an authentication factor of a user
+type UserAuthenticationCheck struct {
+	CreationDate  time.Time
+	Succeeded     bool
+	AuthRequestID string
+	UserAgentID   string
+	RemoteIP      net.IP
+}
+
+// UserAuthenticationChecks returns the checks of the authentication factors of the user since the passed time,
+// ordered by their creation date
+func (q *Queries) UserAuthenticationChecks(ctx context.Context, userID string, since time.Time) (_ []*UserAuthenticationCheck, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Zr3fB", "Errors.User.UserIDMissing")
+	}
+	readModel := NewUserAuthenticationChecksReadModel(userID, since)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.Checks, nil
+}
+
+type UserAuthenticationChecksReadModel struct {
+	*eventstore.ReadModel
+
+	since  time.Time
+	Checks []*UserAuthenticationCheck
+}
+
+func NewUserAuthenticationChecksReadModel(userID string, since time.Time) *UserAuthenticationChecksReadModel {
+	return &UserAuthenticationChecksReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID: userID,
+		},
+		since: since,
+	}
+}
+
+func (rm *UserAuthenticationChecksReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.HumanPasswordCheckSucceededEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, true)
+		case *user.HumanPasswordCheckFailedEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, false)
+		case *user.HumanOTPCheckSucceededEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, true)
+		case *user.HumanOTPCheckFailedEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, false)
+		case *user.HumanU2FCheckSucceededEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, true)
+		case *user.HumanU2FCheckFailedEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, false)
+		case *user.HumanPasswordlessCheckSucceededEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, true)
+		case *user.HumanPasswordlessCheckFailedEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, false)
+		case *user.HumanMagicLinkCodeCheckSucceededEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, true)
+		case *user.HumanMagicLinkCodeCheckFailedEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, false)
+		case *user.UserIDPCheckSucceededEvent:
+			rm.appendCheck(e, e.AuthRequestInfo, true)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *UserAuthenticationChecksReadModel) appendCheck(event eventstore.Event, info *user.AuthRequestInfo, succeeded bool) {
+	check := &UserAuthenticationCheck{
+		CreationDate: event.CreationDate(),
+		Succeeded:    succeeded,
+	}
+	if info != nil {
+		check.AuthRequestID = info.ID
+		check.UserAgentID = info.UserAgentID
+		if info.BrowserInfo != nil {
+			check.RemoteIP = info.RemoteIP
+		}
+	}
+	rm.Checks = append(rm.Checks, check)
+}
+
+func (rm *UserAuthenticationChecksReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			user.HumanPasswordCheckSucceededType,
+			user.HumanPasswordCheckFailedType,
+			user.UserV1PasswordCheckSucceededType,
+			user.UserV1PasswordCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanMFAOTPCheckFailedType,
+			user.UserV1MFAOTPCheckSucceededType,
+			user.UserV1MFAOTPCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckFailedType,
+			user.HumanMagicLinkCodeCheckSucceededType,
+			user.HumanMagicLinkCodeCheckFailedType,
+			user.UserIDPLoginCheckSucceededType,
+		)
+	if !rm.since.IsZero() {
+		query = query.CreationDateAfter(rm.since)
+	}
+	return query.Builder()
+}
@@ -0,0 +1,483 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/userbulkjob"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type UserBulkJob struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	State         domain.UserBulkJobState
+	Type          domain.UserBulkJobType
+	Format        domain.UserBulkJobFormat
+	FileName      string
+	Filter        *domain.UserBulkExportFilter
+	Total         uint64
+	Processed     uint64
+	Failed        uint64
+	Error         string
+}
+
+type UserBulkJobs struct {
+	SearchResponse
+	Jobs []*UserBulkJob
+}
+
+type UserBulkJobSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+// UserBulkJobRow is the result of a single row of an import
+type UserBulkJobRow struct {
+	JobID        string
+	CreationDate time.Time
+	Row          uint64
+	UserID       string
+	ErrorID      string
+	Error        string
+}
+
+type UserBulkJobRows struct {
+	SearchResponse
+	Rows []*UserBulkJobRow
+}
+
+type UserBulkJobRowSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+var (
+	userBulkJobTable = table{
+		name:          projection.UserBulkJobTable,
+		instanceIDCol: projection.UserBulkJobInstanceIDCol,
+	}
+	UserBulkJobColID = Column{
+		name:  projection.UserBulkJobIDCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColCreationDate = Column{
+		name:  projection.UserBulkJobCreationDateCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColChangeDate = Column{
+		name:  projection.UserBulkJobChangeDateCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColResourceOwner = Column{
+		name:  projection.UserBulkJobResourceOwnerCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColInstanceID = Column{
+		name:  projection.UserBulkJobInstanceIDCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColSequence = Column{
+		name:  projection.UserBulkJobSequenceCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColState = Column{
+		name:  projection.UserBulkJobStateCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColType = Column{
+		name:  projection.UserBulkJobTypeCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColFormat = Column{
+		name:  projection.UserBulkJobFormatCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColFileName = Column{
+		name:  projection.UserBulkJobFileNameCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColFilter = Column{
+		name:  projection.UserBulkJobFilterCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColTotal = Column{
+		name:  projection.UserBulkJobTotalCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColProcessed = Column{
+		name:  projection.UserBulkJobProcessedCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColFailed = Column{
+		name:  projection.UserBulkJobFailedCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColError = Column{
+		name:  projection.UserBulkJobErrorCol,
+		table: userBulkJobTable,
+	}
+	UserBulkJobColOwnerRemoved = Column{
+		name:  projection.UserBulkJobOwnerRemovedCol,
+		table: userBulkJobTable,
+	}
+)
+
+var (
+	userBulkJobRowTable = table{
+		name:          projection.UserBulkJobRowTable,
+		instanceIDCol: projection.UserBulkJobRowInstanceIDCol,
+	}
+	UserBulkJobRowColJobID = Column{
+		name:  projection.UserBulkJobRowJobIDCol,
+		table: userBulkJobRowTable,
+	}
+	UserBulkJobRowColInstanceID = Column{
+		name:  projection.UserBulkJobRowInstanceIDCol,
+		table: userBulkJobRowTable,
+	}
+	UserBulkJobRowColCreationDate = Column{
+		name:  projection.UserBulkJobRowCreationDateCol,
+		table: userBulkJobRowTable,
+	}
+	UserBulkJobRowColRow = Column{
+		name:  projection.UserBulkJobRowRowCol,
+		table: userBulkJobRowTable,
+	}
+	UserBulkJobRowColUserID = Column{
+		name:  projection.UserBulkJobRowUserIDCol,
+		table: userBulkJobRowTable,
+	}
+	UserBulkJobRowColErrorID = Column{
+		name:  projection.UserBulkJobRowErrorIDCol,
+		table: userBulkJobRowTable,
+	}
+	UserBulkJobRowColError = Column{
+		name:  projection.UserBulkJobRowErrorCol,
+		table: userBulkJobRowTable,
+	}
+)
+
+func (q *Queries) UserBulkJobByID(ctx context.Context, shouldTriggerBulk bool, id, orgID string) (_ *UserBulkJob, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.UserBulkJobProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareUserBulkJobQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		UserBulkJobColID.identifier():            id,
+		UserBulkJobColResourceOwner.identifier(): orgID,
+		UserBulkJobColInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		UserBulkJobColOwnerRemoved.identifier():  false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ubj1s", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchUserBulkJobs(ctx context.Context, queries *UserBulkJobSearchQueries) (_ *UserBulkJobs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserBulkJobsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		UserBulkJobColInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		UserBulkJobColOwnerRemoved.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ubj2s", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ubj3s", "Errors.Internal")
+	}
+	jobs, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	jobs.LatestSequence, err = q.latestSequence(ctx, userBulkJobTable)
+	return jobs, err
+}
+
+// PendingUserBulkJobs returns the queued and running jobs of the instance in the order they were added
+func (q *Queries) PendingUserBulkJobs(ctx context.Context) (_ *UserBulkJobs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserBulkJobsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		UserBulkJobColState.identifier():        []domain.UserBulkJobState{domain.UserBulkJobStateQueued, domain.UserBulkJobStateRunning},
+		UserBulkJobColInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		UserBulkJobColOwnerRemoved.identifier(): false,
+	}).OrderBy(UserBulkJobColCreationDate.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ubj4s", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ubj5s", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// SearchUserBulkJobRows returns the results of the rows of an import,
+// the job must be checked by the caller (e.g. using UserBulkJobByID)
+func (q *Queries) SearchUserBulkJobRows(ctx context.Context, jobID string, queries *UserBulkJobRowSearchQueries) (_ *UserBulkJobRows, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserBulkJobRowsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		UserBulkJobRowColJobID.identifier():      jobID,
+		UserBulkJobRowColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ubj6s", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ubj7s", "Errors.Internal")
+	}
+	jobRows, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	jobRows.LatestSequence, err = q.latestSequence(ctx, userBulkJobTable)
+	return jobRows, err
+}
+
+func (q *UserBulkJobSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *UserBulkJobRowSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *UserBulkJobSearchQueries) AppendMyResourceOwnerQuery(orgID string) error {
+	query, err := NewUserBulkJobResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return err
+	}
+	q.Queries = append(q.Queries, query)
+	return nil
+}
+
+func NewUserBulkJobResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserBulkJobColResourceOwner, value, TextEquals)
+}
+
+func NewUserBulkJobTypeSearchQuery(value domain.UserBulkJobType) (SearchQuery, error) {
+	return NewNumberQuery(UserBulkJobColType, value, NumberEquals)
+}
+
+func NewUserBulkJobStateSearchQuery(value domain.UserBulkJobState) (SearchQuery, error) {
+	return NewNumberQuery(UserBulkJobColState, value, NumberEquals)
+}
+
+// NewUserBulkJobRowFailedSearchQuery restricts the rows to the ones which could not be imported
+func NewUserBulkJobRowFailedSearchQuery() (SearchQuery, error) {
+	return NewTextQuery(UserBulkJobRowColError, "", TextNotEquals)
+}
+
+func prepareUserBulkJobQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserBulkJob, error)) {
+	return sq.Select(
+			UserBulkJobColID.identifier(),
+			UserBulkJobColCreationDate.identifier(),
+			UserBulkJobColChangeDate.identifier(),
+			UserBulkJobColResourceOwner.identifier(),
+			UserBulkJobColSequence.identifier(),
+			UserBulkJobColState.identifier(),
+			UserBulkJobColType.identifier(),
+			UserBulkJobColFormat.identifier(),
+			UserBulkJobColFileName.identifier(),
+			UserBulkJobColFilter.identifier(),
+			UserBulkJobColTotal.identifier(),
+			UserBulkJobColProcessed.identifier(),
+			UserBulkJobColFailed.identifier(),
+			UserBulkJobColError.identifier(),
+		).
+			From(userBulkJobTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserBulkJob, error) {
+			job := new(UserBulkJob)
+			var filter []byte
+			err := row.Scan(
+				&job.ID,
+				&job.CreationDate,
+				&job.ChangeDate,
+				&job.ResourceOwner,
+				&job.Sequence,
+				&job.State,
+				&job.Type,
+				&job.Format,
+				&job.FileName,
+				&filter,
+				&job.Total,
+				&job.Processed,
+				&job.Failed,
+				&job.Error,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ubj8s", "Errors.UserBulkJob.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ubj9s", "Errors.Internal")
+			}
+			if job.Filter, err = unmarshalUserBulkExportFilter(filter); err != nil {
+				return nil, err
+			}
+			return job, nil
+		}
+}
+
+func prepareUserBulkJobsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserBulkJobs, error)) {
+	return sq.Select(
+			UserBulkJobColID.identifier(),
+			UserBulkJobColCreationDate.identifier(),
+			UserBulkJobColChangeDate.identifier(),
+			UserBulkJobColResourceOwner.identifier(),
+			UserBulkJobColSequence.identifier(),
+			UserBulkJobColState.identifier(),
+			UserBulkJobColType.identifier(),
+			UserBulkJobColFormat.identifier(),
+			UserBulkJobColFileName.identifier(),
+			UserBulkJobColFilter.identifier(),
+			UserBulkJobColTotal.identifier(),
+			UserBulkJobColProcessed.identifier(),
+			UserBulkJobColFailed.identifier(),
+			UserBulkJobColError.identifier(),
+			countColumn.identifier(),
+		).
+			From(userBulkJobTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserBulkJobs, error) {
+			jobs := make([]*UserBulkJob, 0)
+			var count uint64
+			for rows.Next() {
+				job := new(UserBulkJob)
+				var filter []byte
+				err := rows.Scan(
+					&job.ID,
+					&job.CreationDate,
+					&job.ChangeDate,
+					&job.ResourceOwner,
+					&job.Sequence,
+					&job.State,
+					&job.Type,
+					&job.Format,
+					&job.FileName,
+					&filter,
+					&job.Total,
+					&job.Processed,
+					&job.Failed,
+					&job.Error,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if job.Filter, err = unmarshalUserBulkExportFilter(filter); err != nil {
+					return nil, err
+				}
+				jobs = append(jobs, job)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ubj0s", "Errors.Query.CloseRows")
+			}
+
+			return &UserBulkJobs{
+				Jobs: jobs,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareUserBulkJobRowsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserBulkJobRows, error)) {
+	return sq.Select(
+			UserBulkJobRowColJobID.identifier(),
+			UserBulkJobRowColCreationDate.identifier(),
+			UserBulkJobRowColRow.identifier(),
+			UserBulkJobRowColUserID.identifier(),
+			UserBulkJobRowColErrorID.identifier(),
+			UserBulkJobRowColError.identifier(),
+			countColumn.identifier(),
+		).
+			From(userBulkJobRowTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserBulkJobRows, error) {
+			jobRows := make([]*UserBulkJobRow, 0)
+			var count uint64
+			for rows.Next() {
+				row := new(UserBulkJobRow)
+				err := rows.Scan(
+					&row.JobID,
+					&row.CreationDate,
+					&row.Row,
+					&row.UserID,
+					&row.ErrorID,
+					&row.Error,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				jobRows = append(jobRows, row)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ubj1r", "Errors.Query.CloseRows")
+			}
+
+			return &UserBulkJobRows{
+				Rows: jobRows,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func unmarshalUserBulkExportFilter(data []byte) (*domain.UserBulkExportFilter, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	filter := new(userbulkjob.ExportFilter)
+	if err := json.Unmarshal(data, filter); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ubj2r", "Errors.Internal")
+	}
+	return filter.ToDomain(), nil
+}
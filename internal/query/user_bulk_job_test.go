@@ -0,0 +1,325 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	userBulkJobQuery = `SELECT projections.user_bulk_jobs.id,` +
+		` projections.user_bulk_jobs.creation_date,` +
+		` projections.user_bulk_jobs.change_date,` +
+		` projections.user_bulk_jobs.resource_owner,` +
+		` projections.user_bulk_jobs.sequence,` +
+		` projections.user_bulk_jobs.state,` +
+		` projections.user_bulk_jobs.type,` +
+		` projections.user_bulk_jobs.format,` +
+		` projections.user_bulk_jobs.file_name,` +
+		` projections.user_bulk_jobs.filter,` +
+		` projections.user_bulk_jobs.total,` +
+		` projections.user_bulk_jobs.processed,` +
+		` projections.user_bulk_jobs.failed,` +
+		` projections.user_bulk_jobs.error` +
+		` FROM projections.user_bulk_jobs` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userBulkJobCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"type",
+		"format",
+		"file_name",
+		"filter",
+		"total",
+		"processed",
+		"failed",
+		"error",
+	}
+	userBulkJobsQuery = `SELECT projections.user_bulk_jobs.id,` +
+		` projections.user_bulk_jobs.creation_date,` +
+		` projections.user_bulk_jobs.change_date,` +
+		` projections.user_bulk_jobs.resource_owner,` +
+		` projections.user_bulk_jobs.sequence,` +
+		` projections.user_bulk_jobs.state,` +
+		` projections.user_bulk_jobs.type,` +
+		` projections.user_bulk_jobs.format,` +
+		` projections.user_bulk_jobs.file_name,` +
+		` projections.user_bulk_jobs.filter,` +
+		` projections.user_bulk_jobs.total,` +
+		` projections.user_bulk_jobs.processed,` +
+		` projections.user_bulk_jobs.failed,` +
+		` projections.user_bulk_jobs.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.user_bulk_jobs` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userBulkJobsCols     = append(append([]string{}, userBulkJobCols...), "count")
+	userBulkJobRowsQuery = `SELECT projections.user_bulk_jobs_rows.job_id,` +
+		` projections.user_bulk_jobs_rows.creation_date,` +
+		` projections.user_bulk_jobs_rows.row_number,` +
+		` projections.user_bulk_jobs_rows.user_id,` +
+		` projections.user_bulk_jobs_rows.error_id,` +
+		` projections.user_bulk_jobs_rows.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.user_bulk_jobs_rows` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userBulkJobRowsCols = []string{
+		"job_id",
+		"creation_date",
+		"row_number",
+		"user_id",
+		"error_id",
+		"error",
+		"count",
+	}
+)
+
+func Test_UserBulkJobPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserBulkJobQuery no result",
+			prepare: prepareUserBulkJobQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(userBulkJobQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserBulkJob)(nil),
+		},
+		{
+			name:    "prepareUserBulkJobQuery found export",
+			prepare: prepareUserBulkJobQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(userBulkJobQuery),
+					userBulkJobCols,
+					[]driver.Value{
+						"job-id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211108),
+						domain.UserBulkJobStateDone,
+						domain.UserBulkJobTypeExport,
+						domain.UserBulkJobFormatCSV,
+						"user_bulk_jobs/job-id/export.csv",
+						[]byte(`{"type":1,"email":"zitadel.ch"}`),
+						uint64(2),
+						uint64(2),
+						uint64(0),
+						"",
+					},
+				),
+			},
+			object: &UserBulkJob{
+				ID:            "job-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211108,
+				State:         domain.UserBulkJobStateDone,
+				Type:          domain.UserBulkJobTypeExport,
+				Format:        domain.UserBulkJobFormatCSV,
+				FileName:      "user_bulk_jobs/job-id/export.csv",
+				Filter: &domain.UserBulkExportFilter{
+					Type:  domain.UserTypeHuman,
+					Email: "zitadel.ch",
+				},
+				Total:     2,
+				Processed: 2,
+			},
+		},
+		{
+			name:    "prepareUserBulkJobQuery sql err",
+			prepare: prepareUserBulkJobQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userBulkJobQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareUserBulkJobsQuery no result",
+			prepare: prepareUserBulkJobsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userBulkJobsQuery),
+					nil,
+					nil,
+				),
+			},
+			object: &UserBulkJobs{Jobs: []*UserBulkJob{}},
+		},
+		{
+			name:    "prepareUserBulkJobsQuery one result",
+			prepare: prepareUserBulkJobsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userBulkJobsQuery),
+					userBulkJobsCols,
+					[][]driver.Value{
+						{
+							"job-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							domain.UserBulkJobStateRunning,
+							domain.UserBulkJobTypeImport,
+							domain.UserBulkJobFormatJSON,
+							"user_bulk_jobs/job-id/import.json",
+							nil,
+							uint64(10),
+							uint64(4),
+							uint64(1),
+							"",
+						},
+					},
+				),
+			},
+			object: &UserBulkJobs{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Jobs: []*UserBulkJob{
+					{
+						ID:            "job-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						State:         domain.UserBulkJobStateRunning,
+						Type:          domain.UserBulkJobTypeImport,
+						Format:        domain.UserBulkJobFormatJSON,
+						FileName:      "user_bulk_jobs/job-id/import.json",
+						Total:         10,
+						Processed:     4,
+						Failed:        1,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserBulkJobsQuery sql err",
+			prepare: prepareUserBulkJobsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userBulkJobsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareUserBulkJobRowsQuery multiple results",
+			prepare: prepareUserBulkJobRowsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userBulkJobRowsQuery),
+					userBulkJobRowsCols,
+					[][]driver.Value{
+						{
+							"job-id",
+							testNow,
+							uint64(2),
+							"user1",
+							"",
+							"",
+						},
+						{
+							"job-id",
+							testNow,
+							uint64(3),
+							"user2",
+							"COMMAND-k2unb",
+							"Errors.User.AlreadyExisting",
+						},
+					},
+				),
+			},
+			object: &UserBulkJobRows{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Rows: []*UserBulkJobRow{
+					{
+						JobID:        "job-id",
+						CreationDate: testNow,
+						Row:          2,
+						UserID:       "user1",
+					},
+					{
+						JobID:        "job-id",
+						CreationDate: testNow,
+						Row:          3,
+						UserID:       "user2",
+						ErrorID:      "COMMAND-k2unb",
+						Error:        "Errors.User.AlreadyExisting",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserBulkJobRowsQuery sql err",
+			prepare: prepareUserBulkJobRowsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userBulkJobRowsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
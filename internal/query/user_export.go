@@ -0,0 +1,329 @@
+package query
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// secretPayloadFields are the keys of user event payloads which hold secrets,
+// codes or hashes and are therefore never part of an export
+var secretPayloadFields = map[string]struct{}{
+	"secret":       {},
+	"otpSecret":    {},
+	"clientSecret": {},
+	"code":         {},
+	"codes":        {},
+	"challenge":    {},
+	"refreshToken": {},
+}
+
+type UserDataExport struct {
+	ExportedAt           time.Time              `json:"exportedAt"`
+	User                 *User                  `json:"user"`
+	Metadata             []*UserMetadata        `json:"metadata"`
+	Grants               []*UserGrant           `json:"grants"`
+	Memberships          []*Membership          `json:"memberships"`
+	IDPLinks             []*IDPUserLink         `json:"idpLinks"`
+	AuthMethods          []*AuthMethod          `json:"authMethods"`
+	PersonalAccessTokens []*PersonalAccessToken `json:"personalAccessTokens"`
+	Keys                 []*AuthNKey            `json:"keys"`
+	Sessions             []*Session             `json:"sessions"`
+	Events               []*UserDataExportEvent `json:"events"`
+}
+
+type UserDataExportEvent struct {
+	Type          string          `json:"type"`
+	Sequence      uint64          `json:"sequence"`
+	CreationDate  time.Time       `json:"creationDate"`
+	EditorUser    string          `json:"editorUser"`
+	EditorService string          `json:"editorService"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+// UserDataExport assembles all data stored about the user,
+// the queries are only applied to the lookup of the user itself
+func (q *Queries) UserDataExport(ctx context.Context, userID string, queries ...SearchQuery) (_ *UserDataExport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	export := &UserDataExport{ExportedAt: time.Now().UTC()}
+	export.User, err = q.GetUserByID(ctx, true, userID, false, queries...)
+	if err != nil {
+		return nil, err
+	}
+	if export.Metadata, err = q.userExportMetadata(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Grants, err = q.userExportGrants(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Memberships, err = q.userExportMemberships(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.IDPLinks, err = q.userExportIDPLinks(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.AuthMethods, err = q.userExportAuthMethods(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.PersonalAccessTokens, err = q.userExportPersonalAccessTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Keys, err = q.userExportKeys(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Sessions, err = q.userExportSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Events, err = q.userExportEvents(ctx, userID); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (q *Queries) userExportMetadata(ctx context.Context, userID string) ([]*UserMetadata, error) {
+	metadata, err := q.SearchUserMetadata(ctx, false, userID, &UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.Metadata, nil
+}
+
+func (q *Queries) userExportGrants(ctx context.Context, userID string) ([]*UserGrant, error) {
+	userIDQuery, err := NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := q.UserGrants(ctx, &UserGrantsQueries{Queries: []SearchQuery{userIDQuery}}, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return grants.UserGrants, nil
+}
+
+func (q *Queries) userExportMemberships(ctx context.Context, userID string) ([]*Membership, error) {
+	userIDQuery, err := NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := q.Memberships(ctx, &MembershipSearchQuery{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	return memberships.Memberships, nil
+}
+
+func (q *Queries) userExportIDPLinks(ctx context.Context, userID string) ([]*IDPUserLink, error) {
+	userIDQuery, err := NewIDPUserLinksUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := q.IDPUserLinks(ctx, &IDPUserLinksSearchQuery{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	return links.Links, nil
+}
+
+func (q *Queries) userExportAuthMethods(ctx context.Context, userID string) ([]*AuthMethod, error) {
+	userIDQuery, err := NewUserAuthMethodUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := q.SearchUserAuthMethods(ctx, &UserAuthMethodSearchQueries{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	return methods.AuthMethods, nil
+}
+
+func (q *Queries) userExportPersonalAccessTokens(ctx context.Context, userID string) ([]*PersonalAccessToken, error) {
+	userIDQuery, err := NewPersonalAccessTokenUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := q.SearchPersonalAccessTokens(ctx, &PersonalAccessTokenSearchQueries{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	return tokens.PersonalAccessTokens, nil
+}
+
+func (q *Queries) userExportKeys(ctx context.Context, userID string) ([]*AuthNKey, error) {
+	userIDQuery, err := NewAuthNKeyAggregateIDQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := q.SearchAuthNKeys(ctx, &AuthNKeySearchQueries{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	return keys.AuthNKeys, nil
+}
+
+func (q *Queries) userExportSessions(ctx context.Context, userID string) ([]*Session, error) {
+	userIDQuery, err := NewSessionUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := q.SearchSessions(ctx, &SessionsSearchQueries{Queries: []SearchQuery{userIDQuery}})
+	if err != nil {
+		return nil, err
+	}
+	return sessions.Sessions, nil
+}
+
+func (q *Queries) userExportEvents(ctx context.Context, userID string) ([]*UserDataExportEvent, error) {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		Builder()
+	events, err := q.eventstore.Filter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportEvent, len(events))
+	for i, event := range events {
+		payload, err := sanitizeUserExportPayload(event.DataAsBytes())
+		if err != nil {
+			return nil, err
+		}
+		exported[i] = &UserDataExportEvent{
+			Type:          string(event.Type()),
+			Sequence:      event.Sequence(),
+			CreationDate:  event.CreationDate(),
+			EditorUser:    event.EditorUser(),
+			EditorService: event.EditorService(),
+			Payload:       payload,
+		}
+	}
+	return exported, nil
+}
+
+// sanitizeUserExportPayload removes all secretPayloadFields from the event payload
+func sanitizeUserExportPayload(payload []byte) (json.RawMessage, error) {
+	if len(payload) == 0 {
+		return nil, nil
+	}
+	var data interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ghs8d", "Errors.Internal")
+	}
+	sanitized, err := json.Marshal(removeSecretPayloadFields(data))
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-P2kfn", "Errors.Internal")
+	}
+	return sanitized, nil
+}
+
+func removeSecretPayloadFields(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if _, ok := secretPayloadFields[key]; ok {
+				delete(value, key)
+				continue
+			}
+			value[key] = removeSecretPayloadFields(field)
+		}
+	case []interface{}:
+		for i, field := range value {
+			value[i] = removeSecretPayloadFields(field)
+		}
+	}
+	return data
+}
+
+// RemoveMetadata removes the metadata with the given keys from the export,
+// including the events which set or removed them
+func (e *UserDataExport) RemoveMetadata(keys map[string]struct{}) {
+	if len(keys) == 0 {
+		return
+	}
+	metadata := make([]*UserMetadata, 0, len(e.Metadata))
+	for _, data := range e.Metadata {
+		if _, ok := keys[data.Key]; !ok {
+			metadata = append(metadata, data)
+		}
+	}
+	e.Metadata = metadata
+
+	events := make([]*UserDataExportEvent, 0, len(e.Events))
+	for _, event := range e.Events {
+		if !isUserMetadataEventOfKeys(event, keys) {
+			events = append(events, event)
+		}
+	}
+	e.Events = events
+}
+
+func isUserMetadataEventOfKeys(event *UserDataExportEvent, keys map[string]struct{}) bool {
+	if event.Type != string(user.MetadataSetType) && event.Type != string(user.MetadataRemovedType) {
+		return false
+	}
+	payload := struct {
+		Key string `json:"key"`
+	}{}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		// without a readable key the event can't be attributed, so it's not exported
+		return true
+	}
+	_, ok := keys[payload.Key]
+	return ok
+}
+
+// JSON renders the export as a single json document
+func (e *UserDataExport) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lq9vM", "Errors.Internal")
+	}
+	return data, nil
+}
+
+// ZIP renders the export as an archive containing a json file per section
+func (e *UserDataExport) ZIP() ([]byte, error) {
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"user.json", e.User},
+		{"metadata.json", e.Metadata},
+		{"grants.json", e.Grants},
+		{"memberships.json", e.Memberships},
+		{"idp_links.json", e.IDPLinks},
+		{"auth_methods.json", e.AuthMethods},
+		{"personal_access_tokens.json", e.PersonalAccessTokens},
+		{"keys.json", e.Keys},
+		{"sessions.json", e.Sessions},
+		{"events.json", e.Events},
+	}
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "QUERY-Tn3wa", "Errors.Internal")
+		}
+		writer, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: e.ExportedAt})
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "QUERY-Rb7sQ", "Errors.Internal")
+		}
+		if _, err = writer.Write(data); err != nil {
+			return nil, errors.ThrowInternal(err, "QUERY-Xm2Kc", "Errors.Internal")
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Dz6Yh", "Errors.Internal")
+	}
+	return buf.Bytes(), nil
+}
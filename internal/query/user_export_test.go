@@ -0,0 +1,120 @@
+package query
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sanitizeUserExportPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		want    json.RawMessage
+		wantErr bool
+	}{
+		{
+			name:    "empty payload",
+			payload: nil,
+			want:    nil,
+		},
+		{
+			name:    "invalid payload",
+			payload: []byte("{"),
+			wantErr: true,
+		},
+		{
+			name:    "no secrets",
+			payload: []byte(`{"email":"user@zitadel.ch"}`),
+			want:    json.RawMessage(`{"email":"user@zitadel.ch"}`),
+		},
+		{
+			name:    "secrets removed",
+			payload: []byte(`{"userName":"user","secret":{"crypted":"aGFzaA=="},"code":{"crypted":"Y29kZQ=="},"otpSecret":{"crypted":"b3Rw"}}`),
+			want:    json.RawMessage(`{"userName":"user"}`),
+		},
+		{
+			name:    "nested secrets removed",
+			payload: []byte(`{"tokens":[{"id":"1","refreshToken":"token"}],"codes":[{"crypted":"Y29kZQ=="}]}`),
+			want:    json.RawMessage(`{"tokens":[{"id":"1"}]}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizeUserExportPayload(tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserDataExport_ZIP(t *testing.T) {
+	export := &UserDataExport{
+		ExportedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		User:       &User{ID: "user-id", Username: "username"},
+		Metadata:   []*UserMetadata{{Key: "key", Value: []byte("value")}},
+		Events: []*UserDataExportEvent{
+			{Type: "user.human.added", Sequence: 1, Payload: json.RawMessage(`{"userName":"username"}`)},
+		},
+	}
+	data, err := export.ZIP()
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	names := make([]string, len(archive.File))
+	for i, file := range archive.File {
+		names[i] = file.Name
+	}
+	assert.Equal(t, []string{
+		"user.json",
+		"metadata.json",
+		"grants.json",
+		"memberships.json",
+		"idp_links.json",
+		"auth_methods.json",
+		"personal_access_tokens.json",
+		"keys.json",
+		"sessions.json",
+		"events.json",
+	}, names)
+
+	file, err := archive.Open("user.json")
+	require.NoError(t, err)
+	user := new(User)
+	require.NoError(t, json.NewDecoder(file).Decode(user))
+	assert.Equal(t, "user-id", user.ID)
+	assert.Equal(t, "username", user.Username)
+}
+
+func TestUserDataExport_RemoveMetadata(t *testing.T) {
+	export := &UserDataExport{
+		Metadata: []*UserMetadata{
+			{Key: "visible", Value: []byte("value")},
+			{Key: "admin", Value: []byte("value")},
+		},
+		Events: []*UserDataExportEvent{
+			{Type: "user.human.added", Sequence: 1, Payload: json.RawMessage(`{"userName":"username"}`)},
+			{Type: "user.metadata.set", Sequence: 2, Payload: json.RawMessage(`{"key":"visible","value":"dmFsdWU="}`)},
+			{Type: "user.metadata.set", Sequence: 3, Payload: json.RawMessage(`{"key":"admin","value":"dmFsdWU="}`)},
+			{Type: "user.metadata.removed", Sequence: 4, Payload: json.RawMessage(`{"key":"admin"}`)},
+		},
+	}
+	export.RemoveMetadata(map[string]struct{}{"admin": {}})
+
+	assert.Equal(t, []*UserMetadata{{Key: "visible", Value: []byte("value")}}, export.Metadata)
+	sequences := make([]uint64, len(export.Events))
+	for i, event := range export.Events {
+		sequences[i] = event.Sequence
+	}
+	assert.Equal(t, []uint64{1, 2}, sequences)
+}
@@ -0,0 +1,215 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type UserLifecyclePolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	DeactivateAfterDays uint64
+	DeleteAfterDays     uint64
+	WarnDaysBefore      uint64
+	ExcludeMachineUsers bool
+	ExcludedUserIDs     database.StringArray
+	DryRun              bool
+
+	IsDefault bool
+}
+
+var (
+	userLifecyclePolicyTable = table{
+		name:          projection.UserLifecyclePolicyProjectionTable,
+		instanceIDCol: projection.UserLifecyclePolicyColumnInstanceID,
+	}
+	UserLifecyclePolicyColID = Column{
+		name:  projection.UserLifecyclePolicyColumnID,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColSequence = Column{
+		name:  projection.UserLifecyclePolicyColumnSequence,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColCreationDate = Column{
+		name:  projection.UserLifecyclePolicyColumnCreationDate,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColChangeDate = Column{
+		name:  projection.UserLifecyclePolicyColumnChangeDate,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColResourceOwner = Column{
+		name:  projection.UserLifecyclePolicyColumnResourceOwner,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColInstanceID = Column{
+		name:  projection.UserLifecyclePolicyColumnInstanceID,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColDeactivateAfterDays = Column{
+		name:  projection.UserLifecyclePolicyColumnDeactivateAfterDays,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColDeleteAfterDays = Column{
+		name:  projection.UserLifecyclePolicyColumnDeleteAfterDays,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColWarnDaysBefore = Column{
+		name:  projection.UserLifecyclePolicyColumnWarnDaysBefore,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColExcludeMachineUsers = Column{
+		name:  projection.UserLifecyclePolicyColumnExcludeMachineUsers,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColExcludedUserIDs = Column{
+		name:  projection.UserLifecyclePolicyColumnExcludedUserIDs,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColDryRun = Column{
+		name:  projection.UserLifecyclePolicyColumnDryRun,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColIsDefault = Column{
+		name:  projection.UserLifecyclePolicyColumnIsDefault,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColState = Column{
+		name:  projection.UserLifecyclePolicyColumnStateCol,
+		table: userLifecyclePolicyTable,
+	}
+	UserLifecyclePolicyColOwnerRemoved = Column{
+		name:  projection.UserLifecyclePolicyColumnOwnerRemoved,
+		table: userLifecyclePolicyTable,
+	}
+)
+
+func (q *Queries) UserLifecyclePolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (_ *UserLifecyclePolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		if err := projection.UserLifecyclePolicyProjection.Trigger(ctx); err != nil {
+			return nil, err
+		}
+	}
+	eq := sq.Eq{UserLifecyclePolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	if !withOwnerRemoved {
+		eq[UserLifecyclePolicyColOwnerRemoved.identifier()] = false
+	}
+	stmt, scan := prepareUserLifecyclePolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(
+		sq.And{
+			eq,
+			sq.Or{
+				sq.Eq{UserLifecyclePolicyColID.identifier(): orgID},
+				sq.Eq{UserLifecyclePolicyColID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(UserLifecyclePolicyColIsDefault.identifier()).Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ulc2q", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultUserLifecyclePolicy(ctx context.Context, shouldTriggerBulk bool) (_ *UserLifecyclePolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		if err := projection.UserLifecyclePolicyProjection.Trigger(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	stmt, scan := prepareUserLifecyclePolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		UserLifecyclePolicyColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		UserLifecyclePolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(UserLifecyclePolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ulc5q", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (p *UserLifecyclePolicy) ToDomain() *domain.UserLifecyclePolicy {
+	return &domain.UserLifecyclePolicy{
+		DeactivateAfterDays: p.DeactivateAfterDays,
+		DeleteAfterDays:     p.DeleteAfterDays,
+		WarnDaysBefore:      p.WarnDaysBefore,
+		ExcludeMachineUsers: p.ExcludeMachineUsers,
+		ExcludedUserIDs:     p.ExcludedUserIDs,
+		DryRun:              p.DryRun,
+	}
+}
+
+func prepareUserLifecyclePolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserLifecyclePolicy, error)) {
+	return sq.Select(
+			UserLifecyclePolicyColID.identifier(),
+			UserLifecyclePolicyColSequence.identifier(),
+			UserLifecyclePolicyColCreationDate.identifier(),
+			UserLifecyclePolicyColChangeDate.identifier(),
+			UserLifecyclePolicyColResourceOwner.identifier(),
+			UserLifecyclePolicyColDeactivateAfterDays.identifier(),
+			UserLifecyclePolicyColDeleteAfterDays.identifier(),
+			UserLifecyclePolicyColWarnDaysBefore.identifier(),
+			UserLifecyclePolicyColExcludeMachineUsers.identifier(),
+			UserLifecyclePolicyColExcludedUserIDs.identifier(),
+			UserLifecyclePolicyColDryRun.identifier(),
+			UserLifecyclePolicyColIsDefault.identifier(),
+			UserLifecyclePolicyColState.identifier(),
+		).
+			From(userLifecyclePolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserLifecyclePolicy, error) {
+			policy := new(UserLifecyclePolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.DeactivateAfterDays,
+				&policy.DeleteAfterDays,
+				&policy.WarnDaysBefore,
+				&policy.ExcludeMachineUsers,
+				&policy.ExcludedUserIDs,
+				&policy.DryRun,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ulc7n", "Errors.UserLifecyclePolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ulc8i", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
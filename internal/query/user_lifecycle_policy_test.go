@@ -0,0 +1,140 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	userLifecyclePolicyStmt = regexp.QuoteMeta(`SELECT projections.user_lifecycle_policies.id,` +
+		` projections.user_lifecycle_policies.sequence,` +
+		` projections.user_lifecycle_policies.creation_date,` +
+		` projections.user_lifecycle_policies.change_date,` +
+		` projections.user_lifecycle_policies.resource_owner,` +
+		` projections.user_lifecycle_policies.deactivate_after_days,` +
+		` projections.user_lifecycle_policies.delete_after_days,` +
+		` projections.user_lifecycle_policies.warn_days_before,` +
+		` projections.user_lifecycle_policies.exclude_machine_users,` +
+		` projections.user_lifecycle_policies.excluded_user_ids,` +
+		` projections.user_lifecycle_policies.dry_run,` +
+		` projections.user_lifecycle_policies.is_default,` +
+		` projections.user_lifecycle_policies.state` +
+		` FROM projections.user_lifecycle_policies` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	userLifecyclePolicyCols = []string{
+		"id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"deactivate_after_days",
+		"delete_after_days",
+		"warn_days_before",
+		"exclude_machine_users",
+		"excluded_user_ids",
+		"dry_run",
+		"is_default",
+		"state",
+	}
+)
+
+func Test_UserLifecyclePolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserLifecyclePolicyQuery no result",
+			prepare: prepareUserLifecyclePolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userLifecyclePolicyStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserLifecyclePolicy)(nil),
+		},
+		{
+			name:    "prepareUserLifecyclePolicyQuery found",
+			prepare: prepareUserLifecyclePolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					userLifecyclePolicyStmt,
+					userLifecyclePolicyCols,
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						uint64(90),
+						uint64(30),
+						uint64(7),
+						true,
+						database.StringArray{"user1"},
+						false,
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &UserLifecyclePolicy{
+				ID:                  "pol-id",
+				CreationDate:        testNow,
+				ChangeDate:          testNow,
+				Sequence:            20211109,
+				ResourceOwner:       "ro",
+				State:               domain.PolicyStateActive,
+				DeactivateAfterDays: 90,
+				DeleteAfterDays:     30,
+				WarnDaysBefore:      7,
+				ExcludeMachineUsers: true,
+				ExcludedUserIDs:     database.StringArray{"user1"},
+				IsDefault:           true,
+			},
+		},
+		{
+			name:    "prepareUserLifecyclePolicyQuery sql err",
+			prepare: prepareUserLifecyclePolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userLifecyclePolicyStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type UserSchemaPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	Attributes []*domain.UserSchemaAttribute
+
+	IsDefault bool
+}
+
+var (
+	userSchemaPolicyTable = table{
+		name:          projection.UserSchemaPolicyProjectionTable,
+		instanceIDCol: projection.UserSchemaPolicyColumnInstanceID,
+	}
+	UserSchemaPolicyColID = Column{
+		name:  projection.UserSchemaPolicyColumnID,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColSequence = Column{
+		name:  projection.UserSchemaPolicyColumnSequence,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColCreationDate = Column{
+		name:  projection.UserSchemaPolicyColumnCreationDate,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColChangeDate = Column{
+		name:  projection.UserSchemaPolicyColumnChangeDate,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColResourceOwner = Column{
+		name:  projection.UserSchemaPolicyColumnResourceOwner,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColInstanceID = Column{
+		name:  projection.UserSchemaPolicyColumnInstanceID,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColAttributes = Column{
+		name:  projection.UserSchemaPolicyColumnAttributes,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColIsDefault = Column{
+		name:  projection.UserSchemaPolicyColumnIsDefault,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColState = Column{
+		name:  projection.UserSchemaPolicyColumnStateCol,
+		table: userSchemaPolicyTable,
+	}
+	UserSchemaPolicyColOwnerRemoved = Column{
+		name:  projection.UserSchemaPolicyColumnOwnerRemoved,
+		table: userSchemaPolicyTable,
+	}
+)
+
+func (q *Queries) UserSchemaPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (_ *UserSchemaPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		if err := projection.UserSchemaPolicyProjection.Trigger(ctx); err != nil {
+			return nil, err
+		}
+	}
+	eq := sq.Eq{UserSchemaPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	if !withOwnerRemoved {
+		eq[UserSchemaPolicyColOwnerRemoved.identifier()] = false
+	}
+	stmt, scan := prepareUserSchemaPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(
+		sq.And{
+			eq,
+			sq.Or{
+				sq.Eq{UserSchemaPolicyColID.identifier(): orgID},
+				sq.Eq{UserSchemaPolicyColID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(UserSchemaPolicyColIsDefault.identifier()).Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Usc2q", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultUserSchemaPolicy(ctx context.Context, shouldTriggerBulk bool) (_ *UserSchemaPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		if err := projection.UserSchemaPolicyProjection.Trigger(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	stmt, scan := prepareUserSchemaPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		UserSchemaPolicyColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		UserSchemaPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(UserSchemaPolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Usc5q", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (p *UserSchemaPolicy) ToDomain() *domain.UserSchemaPolicy {
+	return &domain.UserSchemaPolicy{
+		Attributes: p.Attributes,
+	}
+}
+
+func prepareUserSchemaPolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserSchemaPolicy, error)) {
+	return sq.Select(
+			UserSchemaPolicyColID.identifier(),
+			UserSchemaPolicyColSequence.identifier(),
+			UserSchemaPolicyColCreationDate.identifier(),
+			UserSchemaPolicyColChangeDate.identifier(),
+			UserSchemaPolicyColResourceOwner.identifier(),
+			UserSchemaPolicyColAttributes.identifier(),
+			UserSchemaPolicyColIsDefault.identifier(),
+			UserSchemaPolicyColState.identifier(),
+		).
+			From(userSchemaPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserSchemaPolicy, error) {
+			policy := new(UserSchemaPolicy)
+			var attributes []byte
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&attributes,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Usc7n", "Errors.UserSchemaPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Usc8i", "Errors.Internal")
+			}
+			policy.Attributes, err = userSchemaAttributesFromJSON(attributes)
+			if err != nil {
+				return nil, err
+			}
+			return policy, nil
+		}
+}
+
+func userSchemaAttributesFromJSON(data []byte) ([]*domain.UserSchemaAttribute, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var attributes []*policy.UserSchemaAttribute
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Usc4j", "Errors.Internal")
+	}
+	return policy.UserSchemaAttributesToDomain(attributes), nil
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	userSchemaPolicyStmt = regexp.QuoteMeta(`SELECT projections.user_schema_policies.id,` +
+		` projections.user_schema_policies.sequence,` +
+		` projections.user_schema_policies.creation_date,` +
+		` projections.user_schema_policies.change_date,` +
+		` projections.user_schema_policies.resource_owner,` +
+		` projections.user_schema_policies.attributes,` +
+		` projections.user_schema_policies.is_default,` +
+		` projections.user_schema_policies.state` +
+		` FROM projections.user_schema_policies` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	userSchemaPolicyCols = []string{
+		"id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"attributes",
+		"is_default",
+		"state",
+	}
+)
+
+func Test_UserSchemaPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserSchemaPolicyQuery no result",
+			prepare: prepareUserSchemaPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userSchemaPolicyStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserSchemaPolicy)(nil),
+		},
+		{
+			name:    "prepareUserSchemaPolicyQuery found",
+			prepare: prepareUserSchemaPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					userSchemaPolicyStmt,
+					userSchemaPolicyCols,
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						[]byte(`[{"name":"employeeNumber","type":1,"required":true,"unique":true,"visibility":1}]`),
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &UserSchemaPolicy{
+				ID:            "pol-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				ResourceOwner: "ro",
+				State:         domain.PolicyStateActive,
+				Attributes: []*domain.UserSchemaAttribute{
+					{
+						Name:       "employeeNumber",
+						Type:       domain.UserSchemaAttributeTypeNumber,
+						Required:   true,
+						Unique:     true,
+						Visibility: domain.UserSchemaAttributeVisibilitySelf,
+					},
+				},
+				IsDefault: true,
+			},
+		},
+		{
+			name:    "prepareUserSchemaPolicyQuery sql err",
+			prepare: prepareUserSchemaPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userSchemaPolicyStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
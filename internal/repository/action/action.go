@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
@@ -36,10 +37,11 @@ func NewRemoveActionNameUniqueConstraint(actionName, resourceOwner string) *even
 type AddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Name          string        `json:"name"`
-	Script        string        `json:"script,omitempty"`
-	Timeout       time.Duration `json:"timeout,omitempty"`
-	AllowedToFail bool          `json:"allowedToFail"`
+	Name          string               `json:"name"`
+	Script        string               `json:"script,omitempty"`
+	Timeout       time.Duration        `json:"timeout,omitempty"`
+	AllowedToFail bool                 `json:"allowedToFail"`
+	Runtime       domain.ActionRuntime `json:"runtime,omitempty"`
 }
 
 func (e *AddedEvent) Data() interface{} {
@@ -57,6 +59,7 @@ func NewAddedEvent(
 	script string,
 	timeout time.Duration,
 	allowedToFail bool,
+	runtime domain.ActionRuntime,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -68,6 +71,7 @@ func NewAddedEvent(
 		Script:        script,
 		Timeout:       timeout,
 		AllowedToFail: allowedToFail,
+		Runtime:       runtime,
 	}
 }
 
@@ -87,10 +91,11 @@ func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
 type ChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Name          *string        `json:"name,omitempty"`
-	Script        *string        `json:"script,omitempty"`
-	Timeout       *time.Duration `json:"timeout,omitempty"`
-	AllowedToFail *bool          `json:"allowedToFail,omitempty"`
+	Name          *string               `json:"name,omitempty"`
+	Script        *string               `json:"script,omitempty"`
+	Timeout       *time.Duration        `json:"timeout,omitempty"`
+	AllowedToFail *bool                 `json:"allowedToFail,omitempty"`
+	Runtime       *domain.ActionRuntime `json:"runtime,omitempty"`
 	oldName       string
 }
 
@@ -156,6 +161,12 @@ func ChangeAllowedToFail(allowedToFail bool) func(event *ChangedEvent) {
 	}
 }
 
+func ChangeRuntime(runtime domain.ActionRuntime) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Runtime = &runtime
+	}
+}
+
 func ChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &ChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -3,23 +3,28 @@ package idp
 import (
 	"encoding/json"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 )
 
 type Options struct {
-	IsCreationAllowed bool `json:"isCreationAllowed,omitempty"`
-	IsLinkingAllowed  bool `json:"isLinkingAllowed,omitempty"`
-	IsAutoCreation    bool `json:"isAutoCreation,omitempty"`
-	IsAutoUpdate      bool `json:"isAutoUpdate,omitempty"`
+	IsCreationAllowed bool                     `json:"isCreationAllowed,omitempty"`
+	IsLinkingAllowed  bool                     `json:"isLinkingAllowed,omitempty"`
+	IsAutoCreation    bool                     `json:"isAutoCreation,omitempty"`
+	IsAutoUpdate      bool                     `json:"isAutoUpdate,omitempty"`
+	AutoLinking       domain.AutoLinkingOption `json:"autoLinking,omitempty"`
+	AutoLinkingMode   domain.AutoLinkingMode   `json:"autoLinkingMode,omitempty"`
 }
 
 type OptionChanges struct {
-	IsCreationAllowed *bool `json:"isCreationAllowed,omitempty"`
-	IsLinkingAllowed  *bool `json:"isLinkingAllowed,omitempty"`
-	IsAutoCreation    *bool `json:"isAutoCreation,omitempty"`
-	IsAutoUpdate      *bool `json:"isAutoUpdate,omitempty"`
+	IsCreationAllowed *bool                     `json:"isCreationAllowed,omitempty"`
+	IsLinkingAllowed  *bool                     `json:"isLinkingAllowed,omitempty"`
+	IsAutoCreation    *bool                     `json:"isAutoCreation,omitempty"`
+	IsAutoUpdate      *bool                     `json:"isAutoUpdate,omitempty"`
+	AutoLinking       *domain.AutoLinkingOption `json:"autoLinking,omitempty"`
+	AutoLinkingMode   *domain.AutoLinkingMode   `json:"autoLinkingMode,omitempty"`
 }
 
 func (o *Options) Changes(options Options) OptionChanges {
@@ -36,6 +41,12 @@ func (o *Options) Changes(options Options) OptionChanges {
 	if o.IsAutoUpdate != options.IsAutoUpdate {
 		opts.IsAutoUpdate = &options.IsAutoUpdate
 	}
+	if o.AutoLinking != options.AutoLinking {
+		opts.AutoLinking = &options.AutoLinking
+	}
+	if o.AutoLinkingMode != options.AutoLinkingMode {
+		opts.AutoLinkingMode = &options.AutoLinkingMode
+	}
 	return opts
 }
 
@@ -52,10 +63,17 @@ func (o *Options) ReduceChanges(changes OptionChanges) {
 	if changes.IsAutoUpdate != nil {
 		o.IsAutoUpdate = *changes.IsAutoUpdate
 	}
+	if changes.AutoLinking != nil {
+		o.AutoLinking = *changes.AutoLinking
+	}
+	if changes.AutoLinkingMode != nil {
+		o.AutoLinkingMode = *changes.AutoLinkingMode
+	}
 }
 
 func (o *OptionChanges) IsZero() bool {
-	return o.IsCreationAllowed == nil && o.IsLinkingAllowed == nil && o.IsAutoCreation == nil && o.IsAutoUpdate == nil
+	return o.IsCreationAllowed == nil && o.IsLinkingAllowed == nil && o.IsAutoCreation == nil && o.IsAutoUpdate == nil &&
+		o.AutoLinking == nil && o.AutoLinkingMode == nil
 }
 
 type RemovedEvent struct {
@@ -72,6 +72,7 @@ type SucceededEvent struct {
 	IDPUserID      string              `json:"idpUserId,omitempty"`
 	IDPUserName    string              `json:"idpUserName,omitempty"`
 	UserID         string              `json:"userId,omitempty"`
+	LinkingUserID  string              `json:"linkingUserId,omitempty"`
 	IDPAccessToken *crypto.CryptoValue `json:"idpAccessToken,omitempty"`
 	IDPIDToken     string              `json:"idpIdToken,omitempty"`
 }
@@ -82,7 +83,8 @@ func NewSucceededEvent(
 	idpUser []byte,
 	idpUserID,
 	idpUserName,
-	userID string,
+	userID,
+	linkingUserID string,
 	idpAccessToken *crypto.CryptoValue,
 	idpIDToken string,
 ) (*SucceededEvent, error) {
@@ -96,6 +98,7 @@ func NewSucceededEvent(
 		IDPUserID:      idpUserID,
 		IDPUserName:    idpUserName,
 		UserID:         userID,
+		LinkingUserID:  linkingUserID,
 		IDPAccessToken: idpAccessToken,
 		IDPIDToken:     idpIDToken,
 	}, nil
@@ -0,0 +1,375 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	emailHTTPConfigPrefix                 = "email.http.config."
+	EmailHTTPConfigAddedEventType         = instanceEventTypePrefix + emailHTTPConfigPrefix + "added"
+	EmailHTTPConfigChangedEventType       = instanceEventTypePrefix + emailHTTPConfigPrefix + "changed"
+	EmailHTTPConfigAPIKeyChangedEventType = instanceEventTypePrefix + emailHTTPConfigPrefix + "apikey.changed"
+	EmailHTTPConfigActivatedEventType     = instanceEventTypePrefix + emailHTTPConfigPrefix + "activated"
+	EmailHTTPConfigDeactivatedEventType   = instanceEventTypePrefix + emailHTTPConfigPrefix + "deactivated"
+	EmailHTTPConfigRemovedEventType       = instanceEventTypePrefix + emailHTTPConfigPrefix + "removed"
+)
+
+type EmailHTTPConfigAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                  string                 `json:"id,omitempty"`
+	Preset              domain.EmailHTTPPreset `json:"preset,omitempty"`
+	Endpoint            string                 `json:"endpoint,omitempty"`
+	ContentType         string                 `json:"contentType,omitempty"`
+	BodyTemplate        string                 `json:"bodyTemplate,omitempty"`
+	AuthHeader          string                 `json:"authHeader,omitempty"`
+	APIKey              *crypto.CryptoValue    `json:"apiKey,omitempty"`
+	SuccessStatusCodes  []int32                `json:"successStatusCodes,omitempty"`
+	SuccessBodyContains string                 `json:"successBodyContains,omitempty"`
+	SenderAddress       string                 `json:"senderAddress,omitempty"`
+	SenderName          string                 `json:"senderName,omitempty"`
+}
+
+func NewEmailHTTPConfigAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	preset domain.EmailHTTPPreset,
+	endpoint,
+	contentType,
+	bodyTemplate,
+	authHeader string,
+	apiKey *crypto.CryptoValue,
+	successStatusCodes []int32,
+	successBodyContains,
+	senderAddress,
+	senderName string,
+) *EmailHTTPConfigAddedEvent {
+	return &EmailHTTPConfigAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailHTTPConfigAddedEventType,
+		),
+		ID:                  id,
+		Preset:              preset,
+		Endpoint:            endpoint,
+		ContentType:         contentType,
+		BodyTemplate:        bodyTemplate,
+		AuthHeader:          authHeader,
+		APIKey:              apiKey,
+		SuccessStatusCodes:  successStatusCodes,
+		SuccessBodyContains: successBodyContains,
+		SenderAddress:       senderAddress,
+		SenderName:          senderName,
+	}
+}
+
+func (e *EmailHTTPConfigAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailHTTPConfigAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailHTTPConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	configAdded := &EmailHTTPConfigAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, configAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Eh3ad", "unable to unmarshal email http config added")
+	}
+
+	return configAdded, nil
+}
+
+type EmailHTTPConfigChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                  string                  `json:"id,omitempty"`
+	Preset              *domain.EmailHTTPPreset `json:"preset,omitempty"`
+	Endpoint            *string                 `json:"endpoint,omitempty"`
+	ContentType         *string                 `json:"contentType,omitempty"`
+	BodyTemplate        *string                 `json:"bodyTemplate,omitempty"`
+	AuthHeader          *string                 `json:"authHeader,omitempty"`
+	SuccessStatusCodes  *[]int32                `json:"successStatusCodes,omitempty"`
+	SuccessBodyContains *string                 `json:"successBodyContains,omitempty"`
+	SenderAddress       *string                 `json:"senderAddress,omitempty"`
+	SenderName          *string                 `json:"senderName,omitempty"`
+}
+
+func NewEmailHTTPConfigChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []EmailHTTPConfigChanges,
+) (*EmailHTTPConfigChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IAM-Eh8cg", "Errors.NoChangesFound")
+	}
+	changeEvent := &EmailHTTPConfigChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailHTTPConfigChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type EmailHTTPConfigChanges func(event *EmailHTTPConfigChangedEvent)
+
+func ChangeEmailHTTPConfigPreset(preset domain.EmailHTTPPreset) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.Preset = &preset
+	}
+}
+
+func ChangeEmailHTTPConfigEndpoint(endpoint string) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.Endpoint = &endpoint
+	}
+}
+
+func ChangeEmailHTTPConfigContentType(contentType string) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.ContentType = &contentType
+	}
+}
+
+func ChangeEmailHTTPConfigBodyTemplate(bodyTemplate string) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.BodyTemplate = &bodyTemplate
+	}
+}
+
+func ChangeEmailHTTPConfigAuthHeader(authHeader string) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.AuthHeader = &authHeader
+	}
+}
+
+func ChangeEmailHTTPConfigSuccessStatusCodes(codes []int32) func(event *EmailHTTPConfigChangedEvent) {
+	if codes == nil {
+		// an empty list must be stored as [] instead of null to reset the codes
+		codes = []int32{}
+	}
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.SuccessStatusCodes = &codes
+	}
+}
+
+func ChangeEmailHTTPConfigSuccessBodyContains(contains string) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.SuccessBodyContains = &contains
+	}
+}
+
+func ChangeEmailHTTPConfigSenderAddress(senderAddress string) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.SenderAddress = &senderAddress
+	}
+}
+
+func ChangeEmailHTTPConfigSenderName(senderName string) func(event *EmailHTTPConfigChangedEvent) {
+	return func(e *EmailHTTPConfigChangedEvent) {
+		e.SenderName = &senderName
+	}
+}
+
+func (e *EmailHTTPConfigChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailHTTPConfigChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailHTTPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	configChanged := &EmailHTTPConfigChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, configChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Eh2cm", "unable to unmarshal email http config changed")
+	}
+
+	return configChanged, nil
+}
+
+type EmailHTTPConfigAPIKeyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID     string              `json:"id,omitempty"`
+	APIKey *crypto.CryptoValue `json:"apiKey,omitempty"`
+}
+
+func NewEmailHTTPConfigAPIKeyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	apiKey *crypto.CryptoValue,
+) *EmailHTTPConfigAPIKeyChangedEvent {
+	return &EmailHTTPConfigAPIKeyChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailHTTPConfigAPIKeyChangedEventType,
+		),
+		ID:     id,
+		APIKey: apiKey,
+	}
+}
+
+func (e *EmailHTTPConfigAPIKeyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailHTTPConfigAPIKeyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailHTTPConfigAPIKeyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	apiKeyChanged := &EmailHTTPConfigAPIKeyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, apiKeyChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Eh6ak", "unable to unmarshal email http config api key changed")
+	}
+
+	return apiKeyChanged, nil
+}
+
+type EmailHTTPConfigActivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+}
+
+func NewEmailHTTPConfigActivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *EmailHTTPConfigActivatedEvent {
+	return &EmailHTTPConfigActivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailHTTPConfigActivatedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *EmailHTTPConfigActivatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailHTTPConfigActivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailHTTPConfigActivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	configActivated := &EmailHTTPConfigActivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, configActivated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Eh4av", "unable to unmarshal email http config activated")
+	}
+
+	return configActivated, nil
+}
+
+type EmailHTTPConfigDeactivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+}
+
+func NewEmailHTTPConfigDeactivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *EmailHTTPConfigDeactivatedEvent {
+	return &EmailHTTPConfigDeactivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailHTTPConfigDeactivatedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *EmailHTTPConfigDeactivatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailHTTPConfigDeactivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailHTTPConfigDeactivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	configDeactivated := &EmailHTTPConfigDeactivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, configDeactivated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Eh7dv", "unable to unmarshal email http config deactivated")
+	}
+
+	return configDeactivated, nil
+}
+
+type EmailHTTPConfigRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+}
+
+func NewEmailHTTPConfigRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *EmailHTTPConfigRemovedEvent {
+	return &EmailHTTPConfigRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailHTTPConfigRemovedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *EmailHTTPConfigRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailHTTPConfigRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailHTTPConfigRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	configRemoved := &EmailHTTPConfigRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, configRemoved)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Eh9rm", "unable to unmarshal email http config removed")
+	}
+
+	return configRemoved, nil
+}
@@ -22,6 +22,12 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, SMSConfigActivatedEventType, SMSConfigActivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigDeactivatedEventType, SMSConfigDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigRemovedEventType, SMSConfigRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailHTTPConfigAddedEventType, EmailHTTPConfigAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailHTTPConfigChangedEventType, EmailHTTPConfigChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailHTTPConfigAPIKeyChangedEventType, EmailHTTPConfigAPIKeyChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailHTTPConfigActivatedEventType, EmailHTTPConfigActivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailHTTPConfigDeactivatedEventType, EmailHTTPConfigDeactivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailHTTPConfigRemovedEventType, EmailHTTPConfigRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DebugNotificationProviderFileAddedEventType, DebugNotificationProviderFileAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DebugNotificationProviderFileChangedEventType, DebugNotificationProviderFileChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DebugNotificationProviderFileRemovedEventType, DebugNotificationProviderFileRemovedEventMapper).
@@ -31,6 +37,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, OIDCSettingsAddedEventType, OIDCSettingsAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OIDCSettingsChangedEventType, OIDCSettingsChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SecurityPolicySetEventType, SecurityPolicySetEventMapper).
+		RegisterFilterEventMapper(AggregateType, KeyRotationPolicySetEventType, KeyRotationPolicySetEventMapper).
 		RegisterFilterEventMapper(AggregateType, LabelPolicyAddedEventType, LabelPolicyAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LabelPolicyChangedEventType, LabelPolicyChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LabelPolicyActivatedEventType, LabelPolicyActivatedEventMapper).
@@ -102,6 +109,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, LoginPolicyMultiFactorRemovedEventType, MultiFactorRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateAddedEventType, MailTemplateAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateChangedEventType, MailTemplateChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MailTemplateMessageSetEventType, MailTemplateMessageSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, MailTemplateMessageRemovedEventType, MailTemplateMessageRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextAddedEventType, MailTextAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextChangedEventType, MailTextChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, CustomTextSetEventType, CustomTextSetEventMapper).
@@ -114,5 +123,9 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, InstanceChangedEventType, InstanceChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, InstanceRemovedEventType, InstanceRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyAddedEventType, NotificationPolicyAddedEventMapper).
-		RegisterFilterEventMapper(AggregateType, NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper)
+		RegisterFilterEventMapper(AggregateType, NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserLifecyclePolicyAddedEventType, UserLifecyclePolicyAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserLifecyclePolicyChangedEventType, UserLifecyclePolicyChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserSchemaPolicyAddedEventType, UserSchemaPolicyAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserSchemaPolicyChangedEventType, UserSchemaPolicyChangedEventMapper)
 }
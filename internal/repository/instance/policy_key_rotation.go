@@ -0,0 +1,99 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	keyRotationPolicyPrefix       = "policy.key_rotation."
+	KeyRotationPolicySetEventType = instanceEventTypePrefix + keyRotationPolicyPrefix + "set"
+)
+
+type KeyRotationPolicySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SigningKeyAlgorithm   *string        `json:"signingKeyAlgorithm,omitempty"`
+	SigningKeyLifetime    *time.Duration `json:"signingKeyLifetime,omitempty"`
+	CertificateLifetime   *time.Duration `json:"certificateLifetime,omitempty"`
+	PrePublicationPeriod  *time.Duration `json:"prePublicationPeriod,omitempty"`
+	RetirementGracePeriod *time.Duration `json:"retirementGracePeriod,omitempty"`
+}
+
+func NewKeyRotationPolicySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []KeyRotationPolicyChanges,
+) (*KeyRotationPolicySetEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Aif4u", "Errors.NoChangesFound")
+	}
+	event := &KeyRotationPolicySetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			KeyRotationPolicySetEventType,
+		),
+	}
+	for _, change := range changes {
+		change(event)
+	}
+	return event, nil
+}
+
+type KeyRotationPolicyChanges func(event *KeyRotationPolicySetEvent)
+
+func ChangeKeyRotationPolicySigningKeyAlgorithm(algorithm string) func(event *KeyRotationPolicySetEvent) {
+	return func(e *KeyRotationPolicySetEvent) {
+		e.SigningKeyAlgorithm = &algorithm
+	}
+}
+
+func ChangeKeyRotationPolicySigningKeyLifetime(lifetime time.Duration) func(event *KeyRotationPolicySetEvent) {
+	return func(e *KeyRotationPolicySetEvent) {
+		e.SigningKeyLifetime = &lifetime
+	}
+}
+
+func ChangeKeyRotationPolicyCertificateLifetime(lifetime time.Duration) func(event *KeyRotationPolicySetEvent) {
+	return func(e *KeyRotationPolicySetEvent) {
+		e.CertificateLifetime = &lifetime
+	}
+}
+
+func ChangeKeyRotationPolicyPrePublicationPeriod(period time.Duration) func(event *KeyRotationPolicySetEvent) {
+	return func(e *KeyRotationPolicySetEvent) {
+		e.PrePublicationPeriod = &period
+	}
+}
+
+func ChangeKeyRotationPolicyRetirementGracePeriod(period time.Duration) func(event *KeyRotationPolicySetEvent) {
+	return func(e *KeyRotationPolicySetEvent) {
+		e.RetirementGracePeriod = &period
+	}
+}
+
+func (e *KeyRotationPolicySetEvent) Data() interface{} {
+	return e
+}
+
+func (e *KeyRotationPolicySetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func KeyRotationPolicySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	keyRotationPolicySet := &KeyRotationPolicySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, keyRotationPolicySet)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ohph4", "unable to unmarshal key rotation policy set")
+	}
+
+	return keyRotationPolicySet, nil
+}
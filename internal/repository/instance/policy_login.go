@@ -31,7 +31,10 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowEmailMagicLink bool,
+	riskStepUpThreshold,
+	riskBlockThreshold uint32,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -55,6 +58,9 @@ func NewLoginPolicyAddedEvent(
 			allowDomainDiscovery,
 			disableLoginWithEmail,
 			disableLoginWithPhone,
+			allowEmailMagicLink,
+			riskStepUpThreshold,
+			riskBlockThreshold,
 			passwordlessType,
 			defaultRedirectURI,
 			passwordCheckLifetime,
@@ -9,8 +9,10 @@ import (
 )
 
 var (
-	MailTemplateAddedEventType   = instanceEventTypePrefix + policy.MailTemplatePolicyAddedEventType
-	MailTemplateChangedEventType = instanceEventTypePrefix + policy.MailTemplatePolicyChangedEventType
+	MailTemplateAddedEventType          = instanceEventTypePrefix + policy.MailTemplatePolicyAddedEventType
+	MailTemplateChangedEventType        = instanceEventTypePrefix + policy.MailTemplatePolicyChangedEventType
+	MailTemplateMessageSetEventType     = instanceEventTypePrefix + policy.MailTemplateMessageSetEventType
+	MailTemplateMessageRemovedEventType = instanceEventTypePrefix + policy.MailTemplateMessageRemovedEventType
 )
 
 type MailTemplateAddedEvent struct {
@@ -65,3 +67,61 @@ func MailTemplateChangedEventMapper(event *repository.Event) (eventstore.Event,
 
 	return &MailTemplateChangedEvent{MailTemplateChangedEvent: *e.(*policy.MailTemplateChangedEvent)}, nil
 }
+
+type MailTemplateMessageSetEvent struct {
+	policy.MailTemplateMessageSetEvent
+}
+
+func NewMailTemplateMessageSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType,
+	subject string,
+	html,
+	text []byte,
+) *MailTemplateMessageSetEvent {
+	return &MailTemplateMessageSetEvent{
+		MailTemplateMessageSetEvent: *policy.NewMailTemplateMessageSetEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailTemplateMessageSetEventType),
+			messageType,
+			subject,
+			html,
+			text,
+		),
+	}
+}
+
+func MailTemplateMessageSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailTemplateMessageSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailTemplateMessageSetEvent{MailTemplateMessageSetEvent: *e.(*policy.MailTemplateMessageSetEvent)}, nil
+}
+
+type MailTemplateMessageRemovedEvent struct {
+	policy.MailTemplateMessageRemovedEvent
+}
+
+func NewMailTemplateMessageRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+) *MailTemplateMessageRemovedEvent {
+	return &MailTemplateMessageRemovedEvent{
+		MailTemplateMessageRemovedEvent: *policy.NewMailTemplateMessageRemovedEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailTemplateMessageRemovedEventType),
+			messageType,
+		),
+	}
+}
+
+func MailTemplateMessageRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailTemplateMessageRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailTemplateMessageRemovedEvent{MailTemplateMessageRemovedEvent: *e.(*policy.MailTemplateMessageRemovedEvent)}, nil
+}
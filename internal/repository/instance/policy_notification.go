@@ -20,7 +20,13 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	newDeviceLogin,
+	mfaChange,
+	emailChange,
+	phoneChange,
+	accountLocked,
+	personalAccessTokenAdded bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -28,7 +34,13 @@ func NewNotificationPolicyAddedEvent(
 				ctx,
 				aggregate,
 				NotificationPolicyAddedEventType),
-			passwordChange),
+			passwordChange,
+			newDeviceLogin,
+			mfaChange,
+			emailChange,
+			phoneChange,
+			accountLocked,
+			personalAccessTokenAdded),
 	}
 }
 
@@ -0,0 +1,73 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	UserSchemaPolicyAddedEventType   = instanceEventTypePrefix + policy.UserSchemaPolicyAddedEventType
+	UserSchemaPolicyChangedEventType = instanceEventTypePrefix + policy.UserSchemaPolicyChangedEventType
+)
+
+type UserSchemaPolicyAddedEvent struct {
+	policy.UserSchemaPolicyAddedEvent
+}
+
+func NewUserSchemaPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attributes []*policy.UserSchemaAttribute,
+) *UserSchemaPolicyAddedEvent {
+	return &UserSchemaPolicyAddedEvent{
+		UserSchemaPolicyAddedEvent: *policy.NewUserSchemaPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				UserSchemaPolicyAddedEventType),
+			attributes),
+	}
+}
+
+func UserSchemaPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.UserSchemaPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserSchemaPolicyAddedEvent{UserSchemaPolicyAddedEvent: *e.(*policy.UserSchemaPolicyAddedEvent)}, nil
+}
+
+type UserSchemaPolicyChangedEvent struct {
+	policy.UserSchemaPolicyChangedEvent
+}
+
+func NewUserSchemaPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.UserSchemaPolicyChanges,
+) (*UserSchemaPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewUserSchemaPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserSchemaPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &UserSchemaPolicyChangedEvent{UserSchemaPolicyChangedEvent: *changedEvent}, nil
+}
+
+func UserSchemaPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.UserSchemaPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserSchemaPolicyChangedEvent{UserSchemaPolicyChangedEvent: *e.(*policy.UserSchemaPolicyChangedEvent)}, nil
+}
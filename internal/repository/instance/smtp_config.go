@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
@@ -18,26 +19,37 @@ const (
 	SMTPConfigRemovedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "removed"
 )
 
+// SMTPConfigAddedEvent adds an SMTP configuration to the instance.
+// Configurations added before multiple configurations were supported have no ID,
+// they are identified by the id of the instance.
 type SMTPConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID            string              `json:"id,omitempty"`
+	Priority      uint32              `json:"priority,omitempty"`
 	SenderAddress string              `json:"senderAddress,omitempty"`
 	SenderName    string              `json:"senderName,omitempty"`
 	TLS           bool                `json:"tls,omitempty"`
 	Host          string              `json:"host,omitempty"`
 	User          string              `json:"user,omitempty"`
 	Password      *crypto.CryptoValue `json:"password,omitempty"`
+	AuthType      domain.SMTPAuthType `json:"authType,omitempty"`
+	XOAuth2       *domain.SMTPXOAuth2 `json:"xoauth2,omitempty"`
 }
 
 func NewSMTPConfigAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
+	priority uint32,
 	tls bool,
 	senderAddress,
 	senderName,
 	host,
 	user string,
 	password *crypto.CryptoValue,
+	authType domain.SMTPAuthType,
+	xoauth2 *domain.SMTPXOAuth2,
 ) *SMTPConfigAddedEvent {
 	return &SMTPConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -45,15 +57,24 @@ func NewSMTPConfigAddedEvent(
 			aggregate,
 			SMTPConfigAddedEventType,
 		),
+		ID:            id,
+		Priority:      priority,
 		TLS:           tls,
 		SenderAddress: senderAddress,
 		SenderName:    senderName,
 		Host:          host,
 		User:          user,
 		Password:      password,
+		AuthType:      authType,
+		XOAuth2:       xoauth2,
 	}
 }
 
+// ConfigID returns the id of the configuration, which is the id of the instance for configurations without id
+func (e *SMTPConfigAddedEvent) ConfigID() string {
+	return smtpConfigID(e.ID, e.Aggregate())
+}
+
 func (e *SMTPConfigAddedEvent) Data() interface{} {
 	return e
 }
@@ -77,11 +98,19 @@ func SMTPConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type SMTPConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	FromAddress *string `json:"senderAddress,omitempty"`
-	FromName    *string `json:"senderName,omitempty"`
-	TLS         *bool   `json:"tls,omitempty"`
-	Host        *string `json:"host,omitempty"`
-	User        *string `json:"user,omitempty"`
+	ID          string               `json:"id,omitempty"`
+	Priority    *uint32              `json:"priority,omitempty"`
+	FromAddress *string              `json:"senderAddress,omitempty"`
+	FromName    *string              `json:"senderName,omitempty"`
+	TLS         *bool                `json:"tls,omitempty"`
+	Host        *string              `json:"host,omitempty"`
+	User        *string              `json:"user,omitempty"`
+	AuthType    *domain.SMTPAuthType `json:"authType,omitempty"`
+	XOAuth2     *domain.SMTPXOAuth2  `json:"xoauth2,omitempty"`
+}
+
+func (e *SMTPConfigChangedEvent) ConfigID() string {
+	return smtpConfigID(e.ID, e.Aggregate())
 }
 
 func (e *SMTPConfigChangedEvent) Data() interface{} {
@@ -95,6 +124,7 @@ func (e *SMTPConfigChangedEvent) UniqueConstraints() []*eventstore.EventUniqueCo
 func NewSMTPConfigChangeEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	changes []SMTPConfigChanges,
 ) (*SMTPConfigChangedEvent, error) {
 	if len(changes) == 0 {
@@ -106,6 +136,7 @@ func NewSMTPConfigChangeEvent(
 			aggregate,
 			SMTPConfigChangedEventType,
 		),
+		ID: id,
 	}
 	for _, change := range changes {
 		change(changeEvent)
@@ -115,6 +146,12 @@ func NewSMTPConfigChangeEvent(
 
 type SMTPConfigChanges func(event *SMTPConfigChangedEvent)
 
+func ChangeSMTPConfigPriority(priority uint32) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.Priority = &priority
+	}
+}
+
 func ChangeSMTPConfigTLS(tls bool) func(event *SMTPConfigChangedEvent) {
 	return func(e *SMTPConfigChangedEvent) {
 		e.TLS = &tls
@@ -145,6 +182,18 @@ func ChangeSMTPConfigSMTPUser(smtpUser string) func(event *SMTPConfigChangedEven
 	}
 }
 
+func ChangeSMTPConfigAuthType(authType domain.SMTPAuthType) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.AuthType = &authType
+	}
+}
+
+func ChangeSMTPConfigXOAuth2(xoauth2 *domain.SMTPXOAuth2) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.XOAuth2 = xoauth2
+	}
+}
+
 func SMTPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &SMTPConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -161,12 +210,14 @@ func SMTPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, er
 type SMTPConfigPasswordChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID       string              `json:"id,omitempty"`
 	Password *crypto.CryptoValue `json:"password,omitempty"`
 }
 
 func NewSMTPConfigPasswordChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	password *crypto.CryptoValue,
 ) *SMTPConfigPasswordChangedEvent {
 	return &SMTPConfigPasswordChangedEvent{
@@ -175,10 +226,15 @@ func NewSMTPConfigPasswordChangedEvent(
 			aggregate,
 			SMTPConfigPasswordChangedEventType,
 		),
+		ID:       id,
 		Password: password,
 	}
 }
 
+func (e *SMTPConfigPasswordChangedEvent) ConfigID() string {
+	return smtpConfigID(e.ID, e.Aggregate())
+}
+
 func (e *SMTPConfigPasswordChangedEvent) Data() interface{} {
 	return e
 }
@@ -201,11 +257,14 @@ func SMTPConfigPasswordChangedEventMapper(event *repository.Event) (eventstore.E
 
 type SMTPConfigRemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id,omitempty"`
 }
 
 func NewSMTPConfigRemovedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 ) *SMTPConfigRemovedEvent {
 	return &SMTPConfigRemovedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -213,9 +272,14 @@ func NewSMTPConfigRemovedEvent(
 			aggregate,
 			SMTPConfigRemovedEventType,
 		),
+		ID: id,
 	}
 }
 
+func (e *SMTPConfigRemovedEvent) ConfigID() string {
+	return smtpConfigID(e.ID, e.Aggregate())
+}
+
 func (e *SMTPConfigRemovedEvent) Data() interface{} {
 	return e
 }
@@ -235,3 +299,10 @@ func SMTPConfigRemovedEventMapper(event *repository.Event) (eventstore.Event, er
 
 	return smtpConfigRemoved, nil
 }
+
+func smtpConfigID(id string, aggregate eventstore.Aggregate) string {
+	if id != "" {
+		return id
+	}
+	return aggregate.ID
+}
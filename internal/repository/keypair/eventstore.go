@@ -7,4 +7,6 @@ import (
 func RegisterEventMappers(es *eventstore.Eventstore) {
 	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper)
 	es.RegisterFilterEventMapper(AggregateType, AddedCertificateEventType, AddedCertificateEventMapper)
+	es.RegisterFilterEventMapper(AggregateType, RetiredEventType, RetiredEventMapper)
+	es.RegisterFilterEventMapper(AggregateType, RevokedEventType, RevokedEventMapper)
 }
@@ -0,0 +1,66 @@
+package keypair
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	RetiredEventType = eventTypePrefix + "retired"
+)
+
+// RetiredEvent shortens the lifetime of a key pair (and its certificate) on a manual rotation.
+// The private key is still used until a successor has been pre-published,
+// the public key and certificate are published for the grace period afterwards.
+type RetiredEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	PrivateKeyExpiry  time.Time `json:"privateKeyExpiry"`
+	PublicKeyExpiry   time.Time `json:"publicKeyExpiry"`
+	CertificateExpiry time.Time `json:"certificateExpiry,omitempty"`
+}
+
+func (e *RetiredEvent) Data() interface{} {
+	return e
+}
+
+func (e *RetiredEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRetiredEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	privateKeyExpiration,
+	publicKeyExpiration,
+	certificateExpiration time.Time,
+) *RetiredEvent {
+	return &RetiredEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RetiredEventType,
+		),
+		PrivateKeyExpiry:  privateKeyExpiration,
+		PublicKeyExpiry:   publicKeyExpiration,
+		CertificateExpiry: certificateExpiration,
+	}
+}
+
+func RetiredEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RetiredEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "KEY-Aib5o", "unable to unmarshal key pair retired")
+	}
+
+	return e, nil
+}
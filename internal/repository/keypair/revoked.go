@@ -0,0 +1,45 @@
+package keypair
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	RevokedEventType = eventTypePrefix + "revoked"
+)
+
+// RevokedEvent removes a (compromised) key pair and its certificate immediately,
+// so that it's no longer used for signing nor published for verification
+type RevokedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RevokedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *RevokedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRevokedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *RevokedEvent {
+	return &RevokedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RevokedEventType,
+		),
+	}
+}
+
+func RevokedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &RevokedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
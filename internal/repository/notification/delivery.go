@@ -0,0 +1,224 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	SentEventType            = eventTypePrefix + "sent"
+	FailedEventType          = eventTypePrefix + "failed"
+	StatusChangedEventType   = eventTypePrefix + "status.changed"
+	ResendRequestedEventType = eventTypePrefix + "resend.requested"
+)
+
+// Delivery contains the data describing a notification handed to a provider
+type Delivery struct {
+	UserID                string                  `json:"userId"`
+	Channel               domain.NotificationType `json:"channel"`
+	Recipient             string                  `json:"recipient"`
+	MessageType           string                  `json:"messageType"`
+	TriggeringAggregateID string                  `json:"triggeringAggregateId"`
+	TriggeringSequence    uint64                  `json:"triggeringSequence"`
+	TriggeringEventType   string                  `json:"triggeringEventType"`
+}
+
+func deliveryFromDomain(delivery *domain.NotificationDelivery) Delivery {
+	return Delivery{
+		UserID:                delivery.UserID,
+		Channel:               delivery.Channel,
+		Recipient:             delivery.Recipient,
+		MessageType:           delivery.MessageType,
+		TriggeringAggregateID: delivery.TriggeringAggregateID,
+		TriggeringSequence:    delivery.TriggeringSequence,
+		TriggeringEventType:   delivery.TriggeringEventType,
+	}
+}
+
+type SentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	Delivery
+
+	ProviderMessageID string `json:"providerMessageId,omitempty"`
+}
+
+func NewSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delivery *domain.NotificationDelivery,
+) *SentEvent {
+	return &SentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SentEventType,
+		),
+		Delivery:          deliveryFromDomain(delivery),
+		ProviderMessageID: delivery.ProviderMessageID,
+	}
+}
+
+func (e *SentEvent) Data() interface{} {
+	return e
+}
+
+func (e *SentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func SentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &SentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Gs8dq", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type FailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	Delivery
+
+	Error string `json:"error"`
+}
+
+func NewFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delivery *domain.NotificationDelivery,
+) *FailedEvent {
+	return &FailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			FailedEventType,
+		),
+		Delivery: deliveryFromDomain(delivery),
+		Error:    delivery.Error,
+	}
+}
+
+func (e *FailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *FailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func FailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &FailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-s0Bvk", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type StatusChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Status domain.NotificationDeliveryStatus `json:"status"`
+	Reason string                            `json:"reason,omitempty"`
+}
+
+func NewStatusChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	status domain.NotificationDeliveryStatus,
+	reason string,
+) *StatusChangedEvent {
+	return &StatusChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			StatusChangedEventType,
+		),
+		Status: status,
+		Reason: reason,
+	}
+}
+
+func (e *StatusChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *StatusChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func StatusChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &StatusChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Pw2jf", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// ResendRequestedEvent references the user event of the original notification,
+// which is then handled again by the notification handler
+type ResendRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TriggeringAggregateID string `json:"triggeringAggregateId"`
+	TriggeringSequence    uint64 `json:"triggeringSequence"`
+	TriggeringEventType   string `json:"triggeringEventType"`
+}
+
+func NewResendRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	triggeringAggregateID string,
+	triggeringSequence uint64,
+	triggeringEventType string,
+) *ResendRequestedEvent {
+	return &ResendRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ResendRequestedEventType,
+		),
+		TriggeringAggregateID: triggeringAggregateID,
+		TriggeringSequence:    triggeringSequence,
+		TriggeringEventType:   triggeringEventType,
+	}
+}
+
+func (e *ResendRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ResendRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func ResendRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ResendRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Yd7tn", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,12 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, SentEventType, SentEventMapper).
+		RegisterFilterEventMapper(AggregateType, FailedEventType, FailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, StatusChangedEventType, StatusChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ResendRequestedEventType, ResendRequestedEventMapper)
+}
@@ -0,0 +1,158 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	actionSecretEventTypePrefix    = orgEventTypePrefix + "action.secret."
+	ActionSecretSetEventType       = actionSecretEventTypePrefix + "set"
+	ActionSecretRemovedEventType   = actionSecretEventTypePrefix + "removed"
+	actionKeyValueEventTypePrefix  = orgEventTypePrefix + "action.kv."
+	ActionKeyValueSetEventType     = actionKeyValueEventTypePrefix + "set"
+	ActionKeyValueRemovedEventType = actionKeyValueEventTypePrefix + "removed"
+)
+
+// ActionSecretSetEvent adds or overwrites a secret which can be read by the actions of the organisation.
+// The value is only stored encrypted.
+type ActionSecretSetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Name  string              `json:"name"`
+	Value *crypto.CryptoValue `json:"value"`
+}
+
+func (e *ActionSecretSetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *ActionSecretSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionSecretSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionSecretSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name string,
+	value *crypto.CryptoValue,
+) *ActionSecretSetEvent {
+	return &ActionSecretSetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionSecretSetEventType,
+		),
+		Name:  name,
+		Value: value,
+	}
+}
+
+type ActionSecretRemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Name string `json:"name"`
+}
+
+func (e *ActionSecretRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *ActionSecretRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionSecretRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionSecretRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name string,
+) *ActionSecretRemovedEvent {
+	return &ActionSecretRemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionSecretRemovedEventType,
+		),
+		Name: name,
+	}
+}
+
+// ActionKeyValueSetEvent adds or overwrites an entry of the key value store of the actions of the organisation
+type ActionKeyValueSetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+func (e *ActionKeyValueSetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *ActionKeyValueSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionKeyValueSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionKeyValueSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+	value []byte,
+) *ActionKeyValueSetEvent {
+	return &ActionKeyValueSetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionKeyValueSetEventType,
+		),
+		Key:   key,
+		Value: value,
+	}
+}
+
+type ActionKeyValueRemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Key string `json:"key"`
+}
+
+func (e *ActionKeyValueRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *ActionKeyValueRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionKeyValueRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionKeyValueRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+) *ActionKeyValueRemovedEvent {
+	return &ActionKeyValueRemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionKeyValueRemovedEventType,
+		),
+		Key: key,
+	}
+}
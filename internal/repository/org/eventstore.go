@@ -63,7 +63,13 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, PrivacyPolicyRemovedEventType, PrivacyPolicyRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateAddedEventType, MailTemplateAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateChangedEventType, MailTemplateChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MailTemplateMessageSetEventType, MailTemplateMessageSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, MailTemplateMessageRemovedEventType, MailTemplateMessageRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateRemovedEventType, MailTemplateRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigAddedEventType, SMTPConfigAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigChangedEventType, SMTPConfigChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigPasswordChangedEventType, SMTPConfigPasswordChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigRemovedEventType, SMTPConfigRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextAddedEventType, MailTextAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextChangedEventType, MailTextChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextRemovedEventType, MailTextRemovedEventMapper).
@@ -108,9 +114,19 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataRemovedType, MetadataRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataRemovedAllType, MetadataRemovedAllEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionSecretSetEventType, eventstore.GenericEventMapper[ActionSecretSetEvent]).
+		RegisterFilterEventMapper(AggregateType, ActionSecretRemovedEventType, eventstore.GenericEventMapper[ActionSecretRemovedEvent]).
+		RegisterFilterEventMapper(AggregateType, ActionKeyValueSetEventType, eventstore.GenericEventMapper[ActionKeyValueSetEvent]).
+		RegisterFilterEventMapper(AggregateType, ActionKeyValueRemovedEventType, eventstore.GenericEventMapper[ActionKeyValueRemovedEvent]).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyAddedEventType, NotificationPolicyAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyRemovedEventType, NotificationPolicyRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserLifecyclePolicyAddedEventType, UserLifecyclePolicyAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserLifecyclePolicyChangedEventType, UserLifecyclePolicyChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserLifecyclePolicyRemovedEventType, UserLifecyclePolicyRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserSchemaPolicyAddedEventType, UserSchemaPolicyAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserSchemaPolicyChangedEventType, UserSchemaPolicyChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserSchemaPolicyRemovedEventType, UserSchemaPolicyRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, deviceauth.AddedEventType, eventstore.GenericEventMapper[deviceauth.AddedEvent]).
 		RegisterFilterEventMapper(AggregateType, deviceauth.ApprovedEventType, eventstore.GenericEventMapper[deviceauth.ApprovedEvent]).
 		RegisterFilterEventMapper(AggregateType, deviceauth.CanceledEventType, eventstore.GenericEventMapper[deviceauth.CanceledEvent]).
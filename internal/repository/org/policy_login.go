@@ -32,7 +32,10 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowEmailMagicLink bool,
+	riskStepUpThreshold,
+	riskBlockThreshold uint32,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -56,6 +59,9 @@ func NewLoginPolicyAddedEvent(
 			allowDomainDiscovery,
 			disableLoginWithEmail,
 			disableLoginWithPhone,
+			allowEmailMagicLink,
+			riskStepUpThreshold,
+			riskBlockThreshold,
 			passwordlessType,
 			defaultRedirectURI,
 			passwordCheckLifetime,
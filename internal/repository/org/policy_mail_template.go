@@ -10,9 +10,11 @@ import (
 )
 
 var (
-	MailTemplateAddedEventType   = orgEventTypePrefix + policy.MailTemplatePolicyAddedEventType
-	MailTemplateChangedEventType = orgEventTypePrefix + policy.MailTemplatePolicyChangedEventType
-	MailTemplateRemovedEventType = orgEventTypePrefix + policy.MailTemplatePolicyRemovedEventType
+	MailTemplateAddedEventType          = orgEventTypePrefix + policy.MailTemplatePolicyAddedEventType
+	MailTemplateChangedEventType        = orgEventTypePrefix + policy.MailTemplatePolicyChangedEventType
+	MailTemplateMessageSetEventType     = orgEventTypePrefix + policy.MailTemplateMessageSetEventType
+	MailTemplateMessageRemovedEventType = orgEventTypePrefix + policy.MailTemplateMessageRemovedEventType
+	MailTemplateRemovedEventType        = orgEventTypePrefix + policy.MailTemplatePolicyRemovedEventType
 )
 
 type MailTemplateAddedEvent struct {
@@ -91,3 +93,61 @@ func MailTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event,
 
 	return &MailTemplateRemovedEvent{MailTemplateRemovedEvent: *e.(*policy.MailTemplateRemovedEvent)}, nil
 }
+
+type MailTemplateMessageSetEvent struct {
+	policy.MailTemplateMessageSetEvent
+}
+
+func NewMailTemplateMessageSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType,
+	subject string,
+	html,
+	text []byte,
+) *MailTemplateMessageSetEvent {
+	return &MailTemplateMessageSetEvent{
+		MailTemplateMessageSetEvent: *policy.NewMailTemplateMessageSetEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailTemplateMessageSetEventType),
+			messageType,
+			subject,
+			html,
+			text,
+		),
+	}
+}
+
+func MailTemplateMessageSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailTemplateMessageSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailTemplateMessageSetEvent{MailTemplateMessageSetEvent: *e.(*policy.MailTemplateMessageSetEvent)}, nil
+}
+
+type MailTemplateMessageRemovedEvent struct {
+	policy.MailTemplateMessageRemovedEvent
+}
+
+func NewMailTemplateMessageRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+) *MailTemplateMessageRemovedEvent {
+	return &MailTemplateMessageRemovedEvent{
+		MailTemplateMessageRemovedEvent: *policy.NewMailTemplateMessageRemovedEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailTemplateMessageRemovedEventType),
+			messageType,
+		),
+	}
+}
+
+func MailTemplateMessageRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailTemplateMessageRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailTemplateMessageRemovedEvent{MailTemplateMessageRemovedEvent: *e.(*policy.MailTemplateMessageRemovedEvent)}, nil
+}
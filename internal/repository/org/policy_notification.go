@@ -21,7 +21,13 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	newDeviceLogin,
+	mfaChange,
+	emailChange,
+	phoneChange,
+	accountLocked,
+	personalAccessTokenAdded bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -30,6 +36,12 @@ func NewNotificationPolicyAddedEvent(
 				aggregate,
 				NotificationPolicyAddedEventType),
 			passwordChange,
+			newDeviceLogin,
+			mfaChange,
+			emailChange,
+			phoneChange,
+			accountLocked,
+			personalAccessTokenAdded,
 		),
 	}
 }
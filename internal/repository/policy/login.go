@@ -29,6 +29,9 @@ type LoginPolicyAddedEvent struct {
 	AllowDomainDiscovery       bool                    `json:"allowDomainDiscovery,omitempty"`
 	DisableLoginWithEmail      bool                    `json:"disableLoginWithEmail,omitempty"`
 	DisableLoginWithPhone      bool                    `json:"disableLoginWithPhone,omitempty"`
+	AllowEmailMagicLink        bool                    `json:"allowEmailMagicLink,omitempty"`
+	RiskStepUpThreshold        uint32                  `json:"riskStepUpThreshold,omitempty"`
+	RiskBlockThreshold         uint32                  `json:"riskBlockThreshold,omitempty"`
 	PasswordlessType           domain.PasswordlessType `json:"passwordlessType,omitempty"`
 	DefaultRedirectURI         string                  `json:"defaultRedirectURI,omitempty"`
 	PasswordCheckLifetime      time.Duration           `json:"passwordCheckLifetime,omitempty"`
@@ -56,7 +59,10 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowEmailMagicLink bool,
+	riskStepUpThreshold,
+	riskBlockThreshold uint32,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -83,6 +89,9 @@ func NewLoginPolicyAddedEvent(
 		MultiFactorCheckLifetime:   multiFactorCheckLifetime,
 		DisableLoginWithEmail:      disableLoginWithEmail,
 		DisableLoginWithPhone:      disableLoginWithPhone,
+		AllowEmailMagicLink:        allowEmailMagicLink,
+		RiskStepUpThreshold:        riskStepUpThreshold,
+		RiskBlockThreshold:         riskBlockThreshold,
 	}
 }
 
@@ -111,6 +120,9 @@ type LoginPolicyChangedEvent struct {
 	AllowDomainDiscovery       *bool                    `json:"allowDomainDiscovery,omitempty"`
 	DisableLoginWithEmail      *bool                    `json:"disableLoginWithEmail,omitempty"`
 	DisableLoginWithPhone      *bool                    `json:"disableLoginWithPhone,omitempty"`
+	AllowEmailMagicLink        *bool                    `json:"allowEmailMagicLink,omitempty"`
+	RiskStepUpThreshold        *uint32                  `json:"riskStepUpThreshold,omitempty"`
+	RiskBlockThreshold         *uint32                  `json:"riskBlockThreshold,omitempty"`
 	PasswordlessType           *domain.PasswordlessType `json:"passwordlessType,omitempty"`
 	DefaultRedirectURI         *string                  `json:"defaultRedirectURI,omitempty"`
 	PasswordCheckLifetime      *time.Duration           `json:"passwordCheckLifetime,omitempty"`
@@ -242,6 +254,24 @@ func ChangeDisableLoginWithPhone(DisableLoginWithPhone bool) func(*LoginPolicyCh
 	}
 }
 
+func ChangeAllowEmailMagicLink(allowEmailMagicLink bool) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.AllowEmailMagicLink = &allowEmailMagicLink
+	}
+}
+
+func ChangeRiskStepUpThreshold(riskStepUpThreshold uint32) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.RiskStepUpThreshold = &riskStepUpThreshold
+	}
+}
+
+func ChangeRiskBlockThreshold(riskBlockThreshold uint32) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.RiskBlockThreshold = &riskBlockThreshold
+	}
+}
+
 func LoginPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &LoginPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
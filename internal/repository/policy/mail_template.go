@@ -127,3 +127,92 @@ func MailTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event,
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+const (
+	mailTemplateMessagePrefix           = mailTemplatePolicyPrefix + "message."
+	MailTemplateMessageSetEventType     = mailTemplateMessagePrefix + "set"
+	MailTemplateMessageRemovedEventType = mailTemplateMessagePrefix + "removed"
+)
+
+type MailTemplateMessageSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType,omitempty"`
+	Subject     string `json:"subject,omitempty"`
+	HTML        []byte `json:"html,omitempty"`
+	Text        []byte `json:"text,omitempty"`
+}
+
+func (e *MailTemplateMessageSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *MailTemplateMessageSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMailTemplateMessageSetEvent(
+	base *eventstore.BaseEvent,
+	messageType,
+	subject string,
+	html,
+	text []byte,
+) *MailTemplateMessageSetEvent {
+	return &MailTemplateMessageSetEvent{
+		BaseEvent:   *base,
+		MessageType: messageType,
+		Subject:     subject,
+		HTML:        html,
+		Text:        text,
+	}
+}
+
+func MailTemplateMessageSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MailTemplateMessageSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Wq3nd", "unable to unmarshal mail template message")
+	}
+
+	return e, nil
+}
+
+type MailTemplateMessageRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType,omitempty"`
+}
+
+func (e *MailTemplateMessageRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MailTemplateMessageRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMailTemplateMessageRemovedEvent(
+	base *eventstore.BaseEvent,
+	messageType string,
+) *MailTemplateMessageRemovedEvent {
+	return &MailTemplateMessageRemovedEvent{
+		BaseEvent:   *base,
+		MessageType: messageType,
+	}
+}
+
+func MailTemplateMessageRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MailTemplateMessageRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-g7Tbe", "unable to unmarshal mail template message")
+	}
+
+	return e, nil
+}
@@ -17,7 +17,13 @@ const (
 type NotificationPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange bool `json:"passwordChange,omitempty"`
+	PasswordChange           bool `json:"passwordChange,omitempty"`
+	NewDeviceLogin           bool `json:"newDeviceLogin,omitempty"`
+	MFAChange                bool `json:"mfaChange,omitempty"`
+	EmailChange              bool `json:"emailChange,omitempty"`
+	PhoneChange              bool `json:"phoneChange,omitempty"`
+	AccountLocked            bool `json:"accountLocked,omitempty"`
+	PersonalAccessTokenAdded bool `json:"personalAccessTokenAdded,omitempty"`
 }
 
 func (e *NotificationPolicyAddedEvent) Data() interface{} {
@@ -30,11 +36,23 @@ func (e *NotificationPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUn
 
 func NewNotificationPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	passwordChange bool,
+	passwordChange,
+	newDeviceLogin,
+	mfaChange,
+	emailChange,
+	phoneChange,
+	accountLocked,
+	personalAccessTokenAdded bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
-		BaseEvent:      *base,
-		PasswordChange: passwordChange,
+		BaseEvent:                *base,
+		PasswordChange:           passwordChange,
+		NewDeviceLogin:           newDeviceLogin,
+		MFAChange:                mfaChange,
+		EmailChange:              emailChange,
+		PhoneChange:              phoneChange,
+		AccountLocked:            accountLocked,
+		PersonalAccessTokenAdded: personalAccessTokenAdded,
 	}
 }
 
@@ -54,7 +72,13 @@ func NotificationPolicyAddedEventMapper(event *repository.Event) (eventstore.Eve
 type NotificationPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange *bool `json:"passwordChange,omitempty"`
+	PasswordChange           *bool `json:"passwordChange,omitempty"`
+	NewDeviceLogin           *bool `json:"newDeviceLogin,omitempty"`
+	MFAChange                *bool `json:"mfaChange,omitempty"`
+	EmailChange              *bool `json:"emailChange,omitempty"`
+	PhoneChange              *bool `json:"phoneChange,omitempty"`
+	AccountLocked            *bool `json:"accountLocked,omitempty"`
+	PersonalAccessTokenAdded *bool `json:"personalAccessTokenAdded,omitempty"`
 }
 
 func (e *NotificationPolicyChangedEvent) Data() interface{} {
@@ -89,6 +113,42 @@ func ChangePasswordChange(passwordChange bool) func(*NotificationPolicyChangedEv
 	}
 }
 
+func ChangeNewDeviceLogin(newDeviceLogin bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.NewDeviceLogin = &newDeviceLogin
+	}
+}
+
+func ChangeMFAChange(mfaChange bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MFAChange = &mfaChange
+	}
+}
+
+func ChangeEmailChange(emailChange bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.EmailChange = &emailChange
+	}
+}
+
+func ChangePhoneChange(phoneChange bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.PhoneChange = &phoneChange
+	}
+}
+
+func ChangeAccountLocked(accountLocked bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.AccountLocked = &accountLocked
+	}
+}
+
+func ChangePersonalAccessTokenAdded(personalAccessTokenAdded bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.PersonalAccessTokenAdded = &personalAccessTokenAdded
+	}
+}
+
 func NotificationPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &NotificationPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -0,0 +1,177 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UserLifecyclePolicyAddedEventType   = "policy.user.lifecycle.added"
+	UserLifecyclePolicyChangedEventType = "policy.user.lifecycle.changed"
+	UserLifecyclePolicyRemovedEventType = "policy.user.lifecycle.removed"
+)
+
+type UserLifecyclePolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeactivateAfterDays uint64   `json:"deactivateAfterDays,omitempty"`
+	DeleteAfterDays     uint64   `json:"deleteAfterDays,omitempty"`
+	WarnDaysBefore      uint64   `json:"warnDaysBefore,omitempty"`
+	ExcludeMachineUsers bool     `json:"excludeMachineUsers,omitempty"`
+	ExcludedUserIDs     []string `json:"excludedUserIds,omitempty"`
+	DryRun              bool     `json:"dryRun,omitempty"`
+}
+
+func (e *UserLifecyclePolicyAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserLifecyclePolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserLifecyclePolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	deactivateAfterDays,
+	deleteAfterDays,
+	warnDaysBefore uint64,
+	excludeMachineUsers bool,
+	excludedUserIDs []string,
+	dryRun bool,
+) *UserLifecyclePolicyAddedEvent {
+	return &UserLifecyclePolicyAddedEvent{
+		BaseEvent:           *base,
+		DeactivateAfterDays: deactivateAfterDays,
+		DeleteAfterDays:     deleteAfterDays,
+		WarnDaysBefore:      warnDaysBefore,
+		ExcludeMachineUsers: excludeMachineUsers,
+		ExcludedUserIDs:     excludedUserIDs,
+		DryRun:              dryRun,
+	}
+}
+
+func UserLifecyclePolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserLifecyclePolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Ulc4a", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type UserLifecyclePolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeactivateAfterDays *uint64   `json:"deactivateAfterDays,omitempty"`
+	DeleteAfterDays     *uint64   `json:"deleteAfterDays,omitempty"`
+	WarnDaysBefore      *uint64   `json:"warnDaysBefore,omitempty"`
+	ExcludeMachineUsers *bool     `json:"excludeMachineUsers,omitempty"`
+	ExcludedUserIDs     *[]string `json:"excludedUserIds,omitempty"`
+	DryRun              *bool     `json:"dryRun,omitempty"`
+}
+
+func (e *UserLifecyclePolicyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserLifecyclePolicyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserLifecyclePolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []UserLifecyclePolicyChanges,
+) (*UserLifecyclePolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Ulc8c", "Errors.NoChangesFound")
+	}
+	changeEvent := &UserLifecyclePolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type UserLifecyclePolicyChanges func(*UserLifecyclePolicyChangedEvent)
+
+func ChangeDeactivateAfterDays(deactivateAfterDays uint64) func(*UserLifecyclePolicyChangedEvent) {
+	return func(e *UserLifecyclePolicyChangedEvent) {
+		e.DeactivateAfterDays = &deactivateAfterDays
+	}
+}
+
+func ChangeDeleteAfterDays(deleteAfterDays uint64) func(*UserLifecyclePolicyChangedEvent) {
+	return func(e *UserLifecyclePolicyChangedEvent) {
+		e.DeleteAfterDays = &deleteAfterDays
+	}
+}
+
+func ChangeWarnDaysBefore(warnDaysBefore uint64) func(*UserLifecyclePolicyChangedEvent) {
+	return func(e *UserLifecyclePolicyChangedEvent) {
+		e.WarnDaysBefore = &warnDaysBefore
+	}
+}
+
+func ChangeExcludeMachineUsers(excludeMachineUsers bool) func(*UserLifecyclePolicyChangedEvent) {
+	return func(e *UserLifecyclePolicyChangedEvent) {
+		e.ExcludeMachineUsers = &excludeMachineUsers
+	}
+}
+
+func ChangeExcludedUserIDs(excludedUserIDs []string) func(*UserLifecyclePolicyChangedEvent) {
+	return func(e *UserLifecyclePolicyChangedEvent) {
+		e.ExcludedUserIDs = &excludedUserIDs
+	}
+}
+
+func ChangeDryRun(dryRun bool) func(*UserLifecyclePolicyChangedEvent) {
+	return func(e *UserLifecyclePolicyChangedEvent) {
+		e.DryRun = &dryRun
+	}
+}
+
+func UserLifecyclePolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserLifecyclePolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Ulc9m", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type UserLifecyclePolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *UserLifecyclePolicyRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *UserLifecyclePolicyRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserLifecyclePolicyRemovedEvent(base *eventstore.BaseEvent) *UserLifecyclePolicyRemovedEvent {
+	return &UserLifecyclePolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func UserLifecyclePolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &UserLifecyclePolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
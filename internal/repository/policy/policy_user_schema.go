@@ -0,0 +1,173 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UserSchemaPolicyAddedEventType   = "policy.user.schema.added"
+	UserSchemaPolicyChangedEventType = "policy.user.schema.changed"
+	UserSchemaPolicyRemovedEventType = "policy.user.schema.removed"
+)
+
+type UserSchemaAttribute struct {
+	Name          string                               `json:"name"`
+	DisplayName   string                               `json:"displayName,omitempty"`
+	Type          domain.UserSchemaAttributeType       `json:"type,omitempty"`
+	Required      bool                                 `json:"required,omitempty"`
+	Pattern       string                               `json:"pattern,omitempty"`
+	Unique        bool                                 `json:"unique,omitempty"`
+	Visibility    domain.UserSchemaAttributeVisibility `json:"visibility,omitempty"`
+	InTokenClaims bool                                 `json:"inTokenClaims,omitempty"`
+}
+
+func UserSchemaAttributesFromDomain(attributes []*domain.UserSchemaAttribute) []*UserSchemaAttribute {
+	converted := make([]*UserSchemaAttribute, len(attributes))
+	for i, attribute := range attributes {
+		converted[i] = &UserSchemaAttribute{
+			Name:          attribute.Name,
+			DisplayName:   attribute.DisplayName,
+			Type:          attribute.Type,
+			Required:      attribute.Required,
+			Pattern:       attribute.Pattern,
+			Unique:        attribute.Unique,
+			Visibility:    attribute.Visibility,
+			InTokenClaims: attribute.InTokenClaims,
+		}
+	}
+	return converted
+}
+
+func UserSchemaAttributesToDomain(attributes []*UserSchemaAttribute) []*domain.UserSchemaAttribute {
+	converted := make([]*domain.UserSchemaAttribute, len(attributes))
+	for i, attribute := range attributes {
+		converted[i] = &domain.UserSchemaAttribute{
+			Name:          attribute.Name,
+			DisplayName:   attribute.DisplayName,
+			Type:          attribute.Type,
+			Required:      attribute.Required,
+			Pattern:       attribute.Pattern,
+			Unique:        attribute.Unique,
+			Visibility:    attribute.Visibility,
+			InTokenClaims: attribute.InTokenClaims,
+		}
+	}
+	return converted
+}
+
+type UserSchemaPolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attributes []*UserSchemaAttribute `json:"attributes,omitempty"`
+}
+
+func (e *UserSchemaPolicyAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserSchemaPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserSchemaPolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	attributes []*UserSchemaAttribute,
+) *UserSchemaPolicyAddedEvent {
+	return &UserSchemaPolicyAddedEvent{
+		BaseEvent:  *base,
+		Attributes: attributes,
+	}
+}
+
+func UserSchemaPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserSchemaPolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Usc4a", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type UserSchemaPolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attributes *[]*UserSchemaAttribute `json:"attributes,omitempty"`
+}
+
+func (e *UserSchemaPolicyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserSchemaPolicyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserSchemaPolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []UserSchemaPolicyChanges,
+) (*UserSchemaPolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Usc8c", "Errors.NoChangesFound")
+	}
+	changeEvent := &UserSchemaPolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type UserSchemaPolicyChanges func(*UserSchemaPolicyChangedEvent)
+
+func ChangeUserSchemaAttributes(attributes []*UserSchemaAttribute) func(*UserSchemaPolicyChangedEvent) {
+	return func(e *UserSchemaPolicyChangedEvent) {
+		e.Attributes = &attributes
+	}
+}
+
+func UserSchemaPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserSchemaPolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Usc9m", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type UserSchemaPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *UserSchemaPolicyRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *UserSchemaPolicyRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserSchemaPolicyRemovedEvent(base *eventstore.BaseEvent) *UserSchemaPolicyRemovedEvent {
+	return &UserSchemaPolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func UserSchemaPolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &UserSchemaPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -0,0 +1,171 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	oidcInitialAccessTokenEventTypePrefix = projectEventTypePrefix + "oidc.initial_access_token."
+	OIDCInitialAccessTokenAddedType       = oidcInitialAccessTokenEventTypePrefix + "added"
+	OIDCInitialAccessTokenRemovedType     = oidcInitialAccessTokenEventTypePrefix + "removed"
+
+	OIDCRegistrationAccessTokenAddedType = applicationEventTypePrefix + "oidc.registration_access_token.added"
+)
+
+type OIDCInitialAccessTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID                    string                      `json:"tokenId"`
+	TokenHash                  *crypto.CryptoValue         `json:"tokenHash,omitempty"`
+	Expiration                 time.Time                   `json:"expiration"`
+	AllowedRedirectURIPrefixes []string                    `json:"allowedRedirectUriPrefixes,omitempty"`
+	AllowedGrantTypes          []domain.OIDCGrantType      `json:"allowedGrantTypes,omitempty"`
+	AllowedAuthMethodTypes     []domain.OIDCAuthMethodType `json:"allowedAuthMethodTypes,omitempty"`
+}
+
+func (e *OIDCInitialAccessTokenAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *OIDCInitialAccessTokenAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOIDCInitialAccessTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+	tokenHash *crypto.CryptoValue,
+	expiration time.Time,
+	allowedRedirectURIPrefixes []string,
+	allowedGrantTypes []domain.OIDCGrantType,
+	allowedAuthMethodTypes []domain.OIDCAuthMethodType,
+) *OIDCInitialAccessTokenAddedEvent {
+	return &OIDCInitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCInitialAccessTokenAddedType,
+		),
+		TokenID:                    tokenID,
+		TokenHash:                  tokenHash,
+		Expiration:                 expiration,
+		AllowedRedirectURIPrefixes: allowedRedirectURIPrefixes,
+		AllowedGrantTypes:          allowedGrantTypes,
+		AllowedAuthMethodTypes:     allowedAuthMethodTypes,
+	}
+}
+
+func OIDCInitialAccessTokenAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &OIDCInitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Ohch4", "unable to unmarshal initial access token added")
+	}
+
+	return e, nil
+}
+
+type OIDCInitialAccessTokenRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *OIDCInitialAccessTokenRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *OIDCInitialAccessTokenRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOIDCInitialAccessTokenRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *OIDCInitialAccessTokenRemovedEvent {
+	return &OIDCInitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCInitialAccessTokenRemovedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func OIDCInitialAccessTokenRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &OIDCInitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-eiT4u", "unable to unmarshal initial access token removed")
+	}
+
+	return e, nil
+}
+
+// OIDCRegistrationAccessTokenAddedEvent is pushed when a client was registered
+// (or its registration access token was rotated) by Dynamic Client Registration.
+// Only the latest token of an app is valid.
+type OIDCRegistrationAccessTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID                string              `json:"appId"`
+	TokenID              string              `json:"tokenId"`
+	TokenHash            *crypto.CryptoValue `json:"tokenHash,omitempty"`
+	InitialAccessTokenID string              `json:"initialAccessTokenId"`
+}
+
+func (e *OIDCRegistrationAccessTokenAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *OIDCRegistrationAccessTokenAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOIDCRegistrationAccessTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	tokenID string,
+	tokenHash *crypto.CryptoValue,
+	initialAccessTokenID string,
+) *OIDCRegistrationAccessTokenAddedEvent {
+	return &OIDCRegistrationAccessTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCRegistrationAccessTokenAddedType,
+		),
+		AppID:                appID,
+		TokenID:              tokenID,
+		TokenHash:            tokenHash,
+		InitialAccessTokenID: initialAccessTokenID,
+	}
+}
+
+func OIDCRegistrationAccessTokenAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &OIDCRegistrationAccessTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Aeyu1", "unable to unmarshal registration access token added")
+	}
+
+	return e, nil
+}
@@ -23,24 +23,26 @@ const (
 type OIDCConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                     `json:"appId"`
-	ClientID                 string                     `json:"clientId,omitempty"`
-	ClientSecret             *crypto.CryptoValue        `json:"clientSecret,omitempty"`
-	RedirectUris             []string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   []string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  bool                       `json:"devMode,omitempty"`
-	AccessTokenType          domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	Version                   domain.OIDCVersion              `json:"oidcVersion,omitempty"`
+	AppID                     string                          `json:"appId"`
+	ClientID                  string                          `json:"clientId,omitempty"`
+	ClientSecret              *crypto.CryptoValue             `json:"clientSecret,omitempty"`
+	RedirectUris              []string                        `json:"redirectUris,omitempty"`
+	ResponseTypes             []domain.OIDCResponseType       `json:"responseTypes,omitempty"`
+	GrantTypes                []domain.OIDCGrantType          `json:"grantTypes,omitempty"`
+	ApplicationType           domain.OIDCApplicationType      `json:"applicationType,omitempty"`
+	AuthMethodType            domain.OIDCAuthMethodType       `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris    []string                        `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                   bool                            `json:"devMode,omitempty"`
+	AccessTokenType           domain.OIDCTokenType            `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion  bool                            `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion      bool                            `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion  bool                            `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                 time.Duration                   `json:"clockSkew,omitempty"`
+	AdditionalOrigins         []string                        `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage  bool                            `json:"skipNativeAppSuccessPage,omitempty"`
+	RefreshTokenRotation      domain.OIDCRefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
+	RefreshTokenReuseInterval time.Duration                   `json:"refreshTokenReuseInterval,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Data() interface{} {
@@ -72,6 +74,8 @@ func NewOIDCConfigAddedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	refreshTokenRotation domain.OIDCRefreshTokenRotation,
+	refreshTokenReuseInterval time.Duration,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -79,24 +83,26 @@ func NewOIDCConfigAddedEvent(
 			aggregate,
 			OIDCConfigAddedType,
 		),
-		Version:                  version,
-		AppID:                    appID,
-		ClientID:                 clientID,
-		ClientSecret:             clientSecret,
-		RedirectUris:             redirectUris,
-		ResponseTypes:            responseTypes,
-		GrantTypes:               grantTypes,
-		ApplicationType:          applicationType,
-		AuthMethodType:           authMethodType,
-		PostLogoutRedirectUris:   postLogoutRedirectUris,
-		DevMode:                  devMode,
-		AccessTokenType:          accessTokenType,
-		AccessTokenRoleAssertion: accessTokenRoleAssertion,
-		IDTokenRoleAssertion:     idTokenRoleAssertion,
-		IDTokenUserinfoAssertion: idTokenUserinfoAssertion,
-		ClockSkew:                clockSkew,
-		AdditionalOrigins:        additionalOrigins,
-		SkipNativeAppSuccessPage: skipNativeAppSuccessPage,
+		Version:                   version,
+		AppID:                     appID,
+		ClientID:                  clientID,
+		ClientSecret:              clientSecret,
+		RedirectUris:              redirectUris,
+		ResponseTypes:             responseTypes,
+		GrantTypes:                grantTypes,
+		ApplicationType:           applicationType,
+		AuthMethodType:            authMethodType,
+		PostLogoutRedirectUris:    postLogoutRedirectUris,
+		DevMode:                   devMode,
+		AccessTokenType:           accessTokenType,
+		AccessTokenRoleAssertion:  accessTokenRoleAssertion,
+		IDTokenRoleAssertion:      idTokenRoleAssertion,
+		IDTokenUserinfoAssertion:  idTokenUserinfoAssertion,
+		ClockSkew:                 clockSkew,
+		AdditionalOrigins:         additionalOrigins,
+		SkipNativeAppSuccessPage:  skipNativeAppSuccessPage,
+		RefreshTokenRotation:      refreshTokenRotation,
+		RefreshTokenReuseInterval: refreshTokenReuseInterval,
 	}
 }
 
@@ -182,7 +188,13 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
-	return e.SkipNativeAppSuccessPage == c.SkipNativeAppSuccessPage
+	if e.SkipNativeAppSuccessPage != c.SkipNativeAppSuccessPage {
+		return false
+	}
+	if e.RefreshTokenRotation != c.RefreshTokenRotation {
+		return false
+	}
+	return e.RefreshTokenReuseInterval == c.RefreshTokenReuseInterval
 }
 
 func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
@@ -201,22 +213,24 @@ func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type OIDCConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  *domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                      `json:"appId"`
-	RedirectUris             *[]string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            *[]domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               *[]domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          *domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           *domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   *[]string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  *bool                       `json:"devMode,omitempty"`
-	AccessTokenType          *domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion *bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     *bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	Version                   *domain.OIDCVersion              `json:"oidcVersion,omitempty"`
+	AppID                     string                           `json:"appId"`
+	RedirectUris              *[]string                        `json:"redirectUris,omitempty"`
+	ResponseTypes             *[]domain.OIDCResponseType       `json:"responseTypes,omitempty"`
+	GrantTypes                *[]domain.OIDCGrantType          `json:"grantTypes,omitempty"`
+	ApplicationType           *domain.OIDCApplicationType      `json:"applicationType,omitempty"`
+	AuthMethodType            *domain.OIDCAuthMethodType       `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris    *[]string                        `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                   *bool                            `json:"devMode,omitempty"`
+	AccessTokenType           *domain.OIDCTokenType            `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion  *bool                            `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion      *bool                            `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion  *bool                            `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                 *time.Duration                   `json:"clockSkew,omitempty"`
+	AdditionalOrigins         *[]string                        `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage  *bool                            `json:"skipNativeAppSuccessPage,omitempty"`
+	RefreshTokenRotation      *domain.OIDCRefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
+	RefreshTokenReuseInterval *time.Duration                   `json:"refreshTokenReuseInterval,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Data() interface{} {
@@ -343,6 +357,18 @@ func ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage bool) func(event *O
 	}
 }
 
+func ChangeRefreshTokenRotation(refreshTokenRotation domain.OIDCRefreshTokenRotation) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenRotation = &refreshTokenRotation
+	}
+}
+
+func ChangeRefreshTokenReuseInterval(refreshTokenReuseInterval time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenReuseInterval = &refreshTokenReuseInterval
+	}
+}
+
 func OIDCConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -12,15 +12,17 @@ import (
 )
 
 const (
-	sessionEventPrefix    = "session."
-	AddedType             = sessionEventPrefix + "added"
-	UserCheckedType       = sessionEventPrefix + "user.checked"
-	PasswordCheckedType   = sessionEventPrefix + "password.checked"
-	PasskeyChallengedType = sessionEventPrefix + "passkey.challenged"
-	PasskeyCheckedType    = sessionEventPrefix + "passkey.checked"
-	TokenSetType          = sessionEventPrefix + "token.set"
-	MetadataSetType       = sessionEventPrefix + "metadata.set"
-	TerminateType         = sessionEventPrefix + "terminated"
+	sessionEventPrefix      = "session."
+	AddedType               = sessionEventPrefix + "added"
+	UserCheckedType         = sessionEventPrefix + "user.checked"
+	PasswordCheckedType     = sessionEventPrefix + "password.checked"
+	PasskeyChallengedType   = sessionEventPrefix + "passkey.challenged"
+	PasskeyCheckedType      = sessionEventPrefix + "passkey.checked"
+	MagicLinkCheckedType    = sessionEventPrefix + "magiclink.checked"
+	RecoveryCodeCheckedType = sessionEventPrefix + "recoverycode.checked"
+	TokenSetType            = sessionEventPrefix + "token.set"
+	MetadataSetType         = sessionEventPrefix + "metadata.set"
+	TerminateType           = sessionEventPrefix + "terminated"
 )
 
 type AddedEvent struct {
@@ -216,6 +218,72 @@ func NewPasskeyCheckedEvent(
 	}
 }
 
+type MagicLinkCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+func (e *MagicLinkCheckedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MagicLinkCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *MagicLinkCheckedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewMagicLinkCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	checkedAt time.Time,
+) *MagicLinkCheckedEvent {
+	return &MagicLinkCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MagicLinkCheckedType,
+		),
+		CheckedAt: checkedAt,
+	}
+}
+
+type RecoveryCodeCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+func (e *RecoveryCodeCheckedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RecoveryCodeCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *RecoveryCodeCheckedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewRecoveryCodeCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	checkedAt time.Time,
+) *RecoveryCodeCheckedEvent {
+	return &RecoveryCodeCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RecoveryCodeCheckedType,
+		),
+		CheckedAt: checkedAt,
+	}
+}
+
 type TokenSetEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -5,6 +5,7 @@ import (
 )
 
 func RegisterEventMappers(es *eventstore.Eventstore) {
+	registerPIIInterceptors(es)
 	es.RegisterFilterEventMapper(AggregateType, UserV1AddedType, HumanAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserV1RegisteredType, HumanRegisteredEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserV1InitialCodeAddedType, HumanInitialCodeAddedEventMapper).
@@ -87,6 +88,14 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPRemovedType, HumanOTPRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckSucceededType, HumanOTPCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckFailedType, HumanOTPCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodesAddedType, HumanRecoveryCodesAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodesRemovedType, HumanRecoveryCodesRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeCheckSucceededType, HumanRecoveryCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeCheckFailedType, HumanRecoveryCodeCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeUsedSentType, HumanRecoveryCodeUsedSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanSecurityNotificationSentType, HumanSecurityNotificationSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserLifecycleWarningAddedType, UserLifecycleWarningAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserLifecycleWarningSentType, UserLifecycleWarningSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
@@ -106,9 +115,14 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanPasswordlessInitCodeSentType, HumanPasswordlessInitCodeSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordlessInitCodeCheckFailedType, HumanPasswordlessInitCodeCodeCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordlessInitCodeCheckSucceededType, HumanPasswordlessInitCodeCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCodeAddedType, HumanMagicLinkCodeAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCodeSentType, HumanMagicLinkCodeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCodeCheckSucceededType, HumanMagicLinkCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCodeCheckFailedType, HumanMagicLinkCodeCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenAddedType, HumanRefreshTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRenewedType, HumanRefreshTokenRenewedEventEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRemovedType, HumanRefreshTokenRemovedEventEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenReuseDetectedType, HumanRefreshTokenReuseDetectedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineAddedEventType, MachineAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineChangedEventType, MachineChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineKeyAddedEventType, MachineKeyAddedEventMapper).
@@ -0,0 +1,204 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	magicLinkEventPrefix                 = humanEventPrefix + "magiclink."
+	humanMagicLinkCodePrefix             = magicLinkEventPrefix + "code."
+	HumanMagicLinkCodeAddedType          = humanMagicLinkCodePrefix + "added"
+	HumanMagicLinkCodeSentType           = humanMagicLinkCodePrefix + "sent"
+	HumanMagicLinkCodeCheckSucceededType = humanMagicLinkCodePrefix + "check.succeeded"
+	HumanMagicLinkCodeCheckFailedType    = humanMagicLinkCodePrefix + "check.failed"
+)
+
+type HumanMagicLinkCodeAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID            string              `json:"id"`
+	Code          *crypto.CryptoValue `json:"code"`
+	Expiry        time.Duration       `json:"expiry"`
+	URLTemplate   string              `json:"url_template,omitempty"`
+	CodeReturned  bool                `json:"code_returned,omitempty"`
+	AuthRequestID string              `json:"auth_request_id,omitempty"`
+}
+
+func (e *HumanMagicLinkCodeAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCodeAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCodeAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	urlTmpl string,
+	codeReturned bool,
+	authRequestID string,
+) *HumanMagicLinkCodeAddedEvent {
+	return &HumanMagicLinkCodeAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCodeAddedType,
+		),
+		ID:            id,
+		Code:          code,
+		Expiry:        expiry,
+		URLTemplate:   urlTmpl,
+		CodeReturned:  codeReturned,
+		AuthRequestID: authRequestID,
+	}
+}
+
+func HumanMagicLinkCodeAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	codeAdded := &HumanMagicLinkCodeAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, codeAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Eiph7", "unable to unmarshal human magic link code added")
+	}
+	return codeAdded, nil
+}
+
+type HumanMagicLinkCodeSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+}
+
+func (e *HumanMagicLinkCodeSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCodeSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCodeSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *HumanMagicLinkCodeSentEvent {
+	return &HumanMagicLinkCodeSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCodeSentType,
+		),
+		ID: id,
+	}
+}
+
+func HumanMagicLinkCodeSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	codeSent := &HumanMagicLinkCodeSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, codeSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-ohK3u", "unable to unmarshal human magic link code sent")
+	}
+	return codeSent, nil
+}
+
+// HumanMagicLinkCodeCheckSucceededEvent marks the code as used.
+// The embedded [AuthRequestInfo] is only set if the link was used in the login UI.
+type HumanMagicLinkCodeCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CodeID string `json:"codeID"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkCodeCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCodeCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCodeCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeID string,
+	info *AuthRequestInfo,
+) *HumanMagicLinkCodeCheckSucceededEvent {
+	return &HumanMagicLinkCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCodeCheckSucceededType,
+		),
+		CodeID:          codeID,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkCodeCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkSucceeded := &HumanMagicLinkCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkSucceeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ieb4a", "unable to unmarshal human magic link code check succeeded")
+	}
+	return checkSucceeded, nil
+}
+
+type HumanMagicLinkCodeCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CodeID string `json:"codeID"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkCodeCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCodeCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCodeCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeID string,
+	info *AuthRequestInfo,
+) *HumanMagicLinkCodeCheckFailedEvent {
+	return &HumanMagicLinkCodeCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCodeCheckFailedType,
+		),
+		CodeID:          codeID,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkCodeCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkFailed := &HumanMagicLinkCodeCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkFailed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ooy6u", "unable to unmarshal human magic link code check failed")
+	}
+	return checkFailed, nil
+}
@@ -0,0 +1,234 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	recoveryCodesEventPrefix            = mfaEventPrefix + "recoverycodes."
+	HumanRecoveryCodesAddedType         = recoveryCodesEventPrefix + "added"
+	HumanRecoveryCodesRemovedType       = recoveryCodesEventPrefix + "removed"
+	HumanRecoveryCodeCheckSucceededType = recoveryCodesEventPrefix + "check.succeeded"
+	HumanRecoveryCodeCheckFailedType    = recoveryCodesEventPrefix + "check.failed"
+	HumanRecoveryCodeUsedSentType       = recoveryCodesEventPrefix + "used.sent"
+
+	UniqueRecoveryCodeUsed = "recovery_code_used"
+)
+
+// NewAddRecoveryCodeUsedUniqueConstraint ensures that a recovery code can only be used once,
+// even if it's checked by concurrent requests.
+// The codes are random, so their hash identifies the code of the set.
+func NewAddRecoveryCodeUsedUniqueConstraint(userID string, code *crypto.CryptoValue) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRecoveryCodeUsed,
+		userID+":"+base64.RawStdEncoding.EncodeToString(code.Crypted),
+		"Errors.User.MFA.RecoveryCodes.InvalidCode")
+}
+
+// HumanRecoveryCodesAddedEvent sets the hashed recovery codes of the user,
+// replacing any previous set
+type HumanRecoveryCodesAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Codes []*crypto.CryptoValue `json:"codes,omitempty"`
+}
+
+func (e *HumanRecoveryCodesAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodesAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodesAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codes []*crypto.CryptoValue,
+) *HumanRecoveryCodesAddedEvent {
+	return &HumanRecoveryCodesAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodesAddedType,
+		),
+		Codes: codes,
+	}
+}
+
+func HumanRecoveryCodesAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	codesAdded := &HumanRecoveryCodesAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, codesAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Jf8wq", "unable to unmarshal human recovery codes added")
+	}
+	return codesAdded, nil
+}
+
+type HumanRecoveryCodesRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanRecoveryCodesRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *HumanRecoveryCodesRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodesRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanRecoveryCodesRemovedEvent {
+	return &HumanRecoveryCodesRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodesRemovedType,
+		),
+	}
+}
+
+func HumanRecoveryCodesRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &HumanRecoveryCodesRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+// HumanRecoveryCodeCheckSucceededEvent consumes the code with the index of the current set
+type HumanRecoveryCodeCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Index int `json:"index"`
+	*AuthRequestInfo
+
+	code *crypto.CryptoValue
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddRecoveryCodeUsedUniqueConstraint(e.Aggregate().ID, e.code)}
+}
+
+func NewHumanRecoveryCodeCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	index int,
+	code *crypto.CryptoValue,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckSucceededEvent {
+	return &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeCheckSucceededType,
+		),
+		Index:           index,
+		AuthRequestInfo: info,
+		code:            code,
+	}
+}
+
+func HumanRecoveryCodeCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkSucceeded := &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkSucceeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Wq2ds", "unable to unmarshal human recovery code check succeeded")
+	}
+	return checkSucceeded, nil
+}
+
+type HumanRecoveryCodeCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckFailedEvent {
+	return &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRecoveryCodeCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkFailed := &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkFailed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-p0Kxs", "unable to unmarshal human recovery code check failed")
+	}
+	return checkFailed, nil
+}
+
+// HumanRecoveryCodeUsedSentEvent marks the notification about the used code with the index as sent
+type HumanRecoveryCodeUsedSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Index int `json:"index"`
+}
+
+func (e *HumanRecoveryCodeUsedSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeUsedSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeUsedSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	index int,
+) *HumanRecoveryCodeUsedSentEvent {
+	return &HumanRecoveryCodeUsedSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeUsedSentType,
+		),
+		Index: index,
+	}
+}
+
+func HumanRecoveryCodeUsedSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sent := &HumanRecoveryCodeUsedSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ze3ob", "unable to unmarshal human recovery code used sent")
+	}
+	return sent, nil
+}
@@ -16,6 +16,8 @@ const (
 	HumanRefreshTokenAddedType   = refreshTokenEventPrefix + "added"
 	HumanRefreshTokenRenewedType = refreshTokenEventPrefix + "renewed"
 	HumanRefreshTokenRemovedType = refreshTokenEventPrefix + "removed"
+
+	HumanRefreshTokenReuseDetectedType = refreshTokenEventPrefix + "reuse.detected"
 )
 
 type HumanRefreshTokenAddedEvent struct {
@@ -31,6 +33,7 @@ type HumanRefreshTokenAddedEvent struct {
 	IdleExpiration        time.Duration `json:"idleExpiration"`
 	Expiration            time.Duration `json:"expiration"`
 	PreferredLanguage     string        `json:"preferredLanguage"`
+	ConfirmationJKT       string        `json:"cnfJkt,omitempty"`
 }
 
 func (e *HumanRefreshTokenAddedEvent) Data() interface{} {
@@ -58,6 +61,7 @@ func NewHumanRefreshTokenAddedEvent(
 	authTime time.Time,
 	idleExpiration,
 	expiration time.Duration,
+	confirmationJKT string,
 ) *HumanRefreshTokenAddedEvent {
 	return &HumanRefreshTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -75,6 +79,7 @@ func NewHumanRefreshTokenAddedEvent(
 		IdleExpiration:        idleExpiration,
 		Expiration:            expiration,
 		PreferredLanguage:     preferredLanguage,
+		ConfirmationJKT:       confirmationJKT,
 	}
 }
 
@@ -185,3 +190,57 @@ func HumanRefreshTokenRemovedEventEventMapper(event *repository.Event) (eventsto
 
 	return tokenAdded, nil
 }
+
+// HumanRefreshTokenReuseDetectedEvent is pushed if an already rotated refresh token was used again.
+// The TokenID stays the same on every renewal and therefore identifies the whole token family,
+// which is revoked by this event.
+type HumanRefreshTokenReuseDetectedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID     string `json:"tokenId"`
+	ClientID    string `json:"clientId,omitempty"`
+	UserAgentID string `json:"userAgentId,omitempty"`
+}
+
+func (e *HumanRefreshTokenReuseDetectedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRefreshTokenReuseDetectedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *HumanRefreshTokenReuseDetectedEvent) Assets() []*eventstore.Asset {
+	return nil
+}
+
+func NewHumanRefreshTokenReuseDetectedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	clientID,
+	userAgentID string,
+) *HumanRefreshTokenReuseDetectedEvent {
+	return &HumanRefreshTokenReuseDetectedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRefreshTokenReuseDetectedType,
+		),
+		TokenID:     tokenID,
+		ClientID:    clientID,
+		UserAgentID: userAgentID,
+	}
+}
+
+func HumanRefreshTokenReuseDetectedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	reuseDetected := &HumanRefreshTokenReuseDetectedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, reuseDetected)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-ohR3e", "unable to unmarshal refresh token reuse detected")
+	}
+
+	return reuseDetected, nil
+}
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	HumanSecurityNotificationSentType = humanEventPrefix + "security.notification.sent"
+)
+
+// HumanSecurityNotificationSentEvent marks the security notification
+// of the message type about the event with the sequence as sent
+type HumanSecurityNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType   string `json:"messageType"`
+	EventSequence uint64 `json:"eventSequence"`
+}
+
+func (e *HumanSecurityNotificationSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanSecurityNotificationSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanSecurityNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	eventSequence uint64,
+) *HumanSecurityNotificationSentEvent {
+	return &HumanSecurityNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanSecurityNotificationSentType,
+		),
+		MessageType:   messageType,
+		EventSequence: eventSequence,
+	}
+}
+
+func HumanSecurityNotificationSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sent := &HumanSecurityNotificationSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Sn4ts", "unable to unmarshal human security notification sent")
+	}
+	return sent, nil
+}
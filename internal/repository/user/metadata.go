@@ -2,7 +2,10 @@ package user
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
 
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/repository/metadata"
@@ -12,10 +15,49 @@ const (
 	MetadataSetType        = userEventTypePrefix + metadata.SetEventType
 	MetadataRemovedType    = userEventTypePrefix + metadata.RemovedEventType
 	MetadataRemovedAllType = userEventTypePrefix + metadata.RemovedAllEventType
+
+	UniqueSchemaAttribute = "user_schema_attribute"
 )
 
+func NewAddSchemaAttributeUniqueConstraint(resourceOwner, key string, value []byte) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueSchemaAttribute,
+		schemaAttributeUniqueField(resourceOwner, key, value),
+		"Errors.UserSchemaPolicy.AttributeValueNotUnique")
+}
+
+func NewRemoveSchemaAttributeUniqueConstraint(resourceOwner, key string, value []byte) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueSchemaAttribute,
+		schemaAttributeUniqueField(resourceOwner, key, value))
+}
+
+func schemaAttributeUniqueField(resourceOwner, key string, value []byte) string {
+	return resourceOwner + ":" + key + ":" + string(value)
+}
+
 type MetadataSetEvent struct {
 	metadata.SetEvent
+
+	// Unique is set if the value is reserved in the organisation because of the user schema
+	Unique bool `json:"unique,omitempty"`
+
+	releaseValue []byte
+}
+
+func (e *MetadataSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *MetadataSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	var constraints []*eventstore.EventUniqueConstraint
+	if e.releaseValue != nil {
+		constraints = append(constraints, NewRemoveSchemaAttributeUniqueConstraint(e.Aggregate().ResourceOwner, e.Key, e.releaseValue))
+	}
+	if e.Unique {
+		constraints = append(constraints, NewAddSchemaAttributeUniqueConstraint(e.Aggregate().ResourceOwner, e.Key, e.Value))
+	}
+	return constraints
 }
 
 func NewMetadataSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, key string, value []byte) *MetadataSetEvent {
@@ -30,17 +72,43 @@ func NewMetadataSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, k
 	}
 }
 
+// NewSchemaMetadataSetEvent sets the value of an attribute of the user schema.
+// Unique values are reserved in the organisation, a previously reserved value is passed as releaseValue.
+func NewSchemaMetadataSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, key string, value []byte, unique bool, releaseValue []byte) *MetadataSetEvent {
+	event := NewMetadataSetEvent(ctx, aggregate, key, value)
+	event.Unique = unique
+	event.releaseValue = releaseValue
+	return event
+}
+
 func MetadataSetEventMapper(event *repository.Event) (eventstore.Event, error) {
-	e, err := metadata.SetEventMapper(event)
+	e := &MetadataSetEvent{
+		SetEvent: metadata.SetEvent{
+			BaseEvent: *eventstore.BaseEventFromRepo(event),
+		},
+	}
+
+	err := json.Unmarshal(event.Data, e)
 	if err != nil {
-		return nil, err
+		return nil, errors.ThrowInternal(err, "USER-Mdt3s", "unable to unmarshal metadata set")
 	}
 
-	return &MetadataSetEvent{SetEvent: *e.(*metadata.SetEvent)}, nil
+	return e, nil
 }
 
 type MetadataRemovedEvent struct {
 	metadata.RemovedEvent
+
+	releaseValue []byte
+}
+
+func (e *MetadataRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	if e.releaseValue == nil {
+		return nil
+	}
+	return []*eventstore.EventUniqueConstraint{
+		NewRemoveSchemaAttributeUniqueConstraint(e.Aggregate().ResourceOwner, e.Key, e.releaseValue),
+	}
 }
 
 func NewMetadataRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, key string) *MetadataRemovedEvent {
@@ -54,6 +122,14 @@ func NewMetadataRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregat
 	}
 }
 
+// NewSchemaMetadataRemovedEvent removes the value of an attribute of the user schema
+// and releases the reserved value if it was unique
+func NewSchemaMetadataRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, key string, releaseValue []byte) *MetadataRemovedEvent {
+	event := NewMetadataRemovedEvent(ctx, aggregate, key)
+	event.releaseValue = releaseValue
+	return event
+}
+
 func MetadataRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e, err := metadata.RemovedEventMapper(event)
 	if err != nil {
@@ -65,6 +141,21 @@ func MetadataRemovedEventMapper(event *repository.Event) (eventstore.Event, erro
 
 type MetadataRemovedAllEvent struct {
 	metadata.RemovedAllEvent
+
+	releaseValues map[string][]byte
+}
+
+func (e *MetadataRemovedAllEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	keys := make([]string, 0, len(e.releaseValues))
+	for key := range e.releaseValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	constraints := make([]*eventstore.EventUniqueConstraint, 0, len(keys))
+	for _, key := range keys {
+		constraints = append(constraints, NewRemoveSchemaAttributeUniqueConstraint(e.Aggregate().ResourceOwner, key, e.releaseValues[key]))
+	}
+	return constraints
 }
 
 func NewMetadataRemovedAllEvent(ctx context.Context, aggregate *eventstore.Aggregate) *MetadataRemovedAllEvent {
@@ -78,6 +169,14 @@ func NewMetadataRemovedAllEvent(ctx context.Context, aggregate *eventstore.Aggre
 	}
 }
 
+// NewSchemaMetadataRemovedAllEvent removes all metadata of the user
+// and releases the reserved values of the unique attributes of the user schema
+func NewSchemaMetadataRemovedAllEvent(ctx context.Context, aggregate *eventstore.Aggregate, releaseValues map[string][]byte) *MetadataRemovedAllEvent {
+	event := NewMetadataRemovedAllEvent(ctx, aggregate)
+	event.releaseValues = releaseValues
+	return event
+}
+
 func MetadataRemovedAllEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e, err := metadata.RemovedAllEventMapper(event)
 	if err != nil {
@@ -0,0 +1,156 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+// piiPrefix marks the values of the payload which are encrypted by the data encryption key of the user
+const piiPrefix = "pii:"
+
+var (
+	profilePIIFields = []string{"firstName", "lastName", "nickName", "displayName"}
+	addressPIIFields = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	humanPIIFields   = append(append(append([]string{}, profilePIIFields...), "email", "phone"), addressPIIFields...)
+
+	// piiFields are the keys of the personal data in the payload of the user events.
+	// The user name is not encrypted as it's required to release the unique constraint on removal.
+	piiFields = map[eventstore.EventType][]string{
+		UserV1AddedType:          humanPIIFields,
+		UserV1RegisteredType:     humanPIIFields,
+		HumanAddedType:           humanPIIFields,
+		HumanRegisteredType:      humanPIIFields,
+		UserV1ProfileChangedType: profilePIIFields,
+		HumanProfileChangedType:  profilePIIFields,
+		UserV1AddressChangedType: addressPIIFields,
+		HumanAddressChangedType:  addressPIIFields,
+		UserV1EmailChangedType:   {"email"},
+		HumanEmailChangedType:    {"email"},
+		UserV1PhoneChangedType:   {"phone"},
+		HumanPhoneChangedType:    {"phone"},
+	}
+)
+
+// PIIEventTypes returns the types of the events which contain personal data
+func PIIEventTypes() []eventstore.EventType {
+	types := make([]eventstore.EventType, 0, len(piiFields))
+	for typ := range piiFields {
+		types = append(types, typ)
+	}
+	return types
+}
+
+// EncryptPIIPayload encrypts the personal data in the payload of the user event,
+// values which are already encrypted are left as is
+func EncryptPIIPayload(ctx context.Context, keys eventstore.PersonalDataKeys, instanceID, userID string, typ eventstore.EventType, payload []byte) ([]byte, error) {
+	return mapPIIPayload(typ, payload, func(value string) (string, error) {
+		if strings.HasPrefix(value, piiPrefix) {
+			return value, nil
+		}
+		alg, err := keys.Key(ctx, instanceID, userID, true)
+		if err != nil {
+			return "", err
+		}
+		encrypted, err := alg.Encrypt([]byte(value))
+		if err != nil {
+			return "", errors.ThrowInternal(err, "USER-Pq3lx", "unable to encrypt personal data")
+		}
+		return piiPrefix + base64.RawStdEncoding.EncodeToString(encrypted), nil
+	})
+}
+
+// RemovePIIPayload empties the personal data in the payload of the user event,
+// e.g. for removed users whose key is already destroyed
+func RemovePIIPayload(typ eventstore.EventType, payload []byte) ([]byte, error) {
+	return mapPIIPayload(typ, payload, func(string) (string, error) {
+		return "", nil
+	})
+}
+
+// decryptPIIPayload decrypts the personal data in the payload of the user event,
+// if the key of the user was destroyed the values are emptied
+func decryptPIIPayload(ctx context.Context, keys eventstore.PersonalDataKeys, instanceID, userID string, typ eventstore.EventType, payload []byte) ([]byte, error) {
+	return mapPIIPayload(typ, payload, func(value string) (string, error) {
+		if !strings.HasPrefix(value, piiPrefix) {
+			return value, nil
+		}
+		alg, err := keys.Key(ctx, instanceID, userID, false)
+		if err != nil || alg == nil {
+			return "", err
+		}
+		encrypted, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, piiPrefix))
+		if err != nil {
+			return "", errors.ThrowInternal(err, "USER-Xb8rw", "unable to decode personal data")
+		}
+		return alg.DecryptString(encrypted, alg.EncryptionKeyID())
+	})
+}
+
+func mapPIIPayload(typ eventstore.EventType, payload []byte, mapValue func(string) (string, error)) ([]byte, error) {
+	fields, ok := piiFields[typ]
+	if !ok || len(payload) == 0 {
+		return payload, nil
+	}
+	data := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Mf9sd", "unable to unmarshal payload")
+	}
+	var changed bool
+	for _, field := range fields {
+		raw, ok := data[field]
+		if !ok {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil || value == "" {
+			continue
+		}
+		mapped, err := mapValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if mapped == value {
+			continue
+		}
+		if data[field], err = json.Marshal(mapped); err != nil {
+			return nil, errors.ThrowInternal(err, "USER-Kd7bf", "unable to marshal personal data")
+		}
+		changed = true
+	}
+	if !changed {
+		return payload, nil
+	}
+	mapped, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Lw2hz", "unable to marshal payload")
+	}
+	return mapped, nil
+}
+
+// registerPIIInterceptors encrypts the personal data of the pushed events
+// and decrypts it before the filtered events are mapped,
+// if the eventstore is configured with keys
+func registerPIIInterceptors(es *eventstore.Eventstore) {
+	keys := es.PersonalDataKeys()
+	if keys == nil {
+		return
+	}
+	encrypt := func(ctx context.Context, event *repository.Event) (err error) {
+		event.Data, err = EncryptPIIPayload(ctx, keys, event.InstanceID, event.AggregateID, eventstore.EventType(event.Type), event.Data)
+		return err
+	}
+	decrypt := func(ctx context.Context, event *repository.Event) (err error) {
+		event.Data, err = decryptPIIPayload(ctx, keys, event.InstanceID, event.AggregateID, eventstore.EventType(event.Type), event.Data)
+		return err
+	}
+	for typ := range piiFields {
+		es.RegisterPushEventInterceptor(typ, encrypt).
+			RegisterFilterEventInterceptor(typ, decrypt)
+	}
+}
@@ -50,10 +50,16 @@ func NewRemoveUsernameUniqueConstraint(userName, resourceOwner string, userLogin
 
 type UserLockedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	// ByLockoutPolicy is set if the user was locked because of too many failed checks
+	ByLockoutPolicy bool `json:"byLockoutPolicy,omitempty"`
 }
 
 func (e *UserLockedEvent) Data() interface{} {
-	return nil
+	if !e.ByLockoutPolicy {
+		return nil
+	}
+	return e
 }
 
 func (e *UserLockedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
@@ -70,10 +76,25 @@ func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *U
 	}
 }
 
+// NewUserLockedByLockoutPolicyEvent locks the user after the maximum attempts of the lockout policy are reached
+func NewUserLockedByLockoutPolicyEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserLockedEvent {
+	e := NewUserLockedEvent(ctx, aggregate)
+	e.ByLockoutPolicy = true
+	return e
+}
+
 func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
-	return &UserLockedEvent{
+	e := &UserLockedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	if len(event.Data) == 0 {
+		return e, nil
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Lk7bp", "unable to unmarshal user locked")
+	}
+	return e, nil
 }
 
 type UserUnlockedEvent struct {
@@ -211,14 +232,16 @@ func UserRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
 type UserTokenAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	TokenID           string    `json:"tokenId"`
-	ApplicationID     string    `json:"applicationId"`
-	UserAgentID       string    `json:"userAgentId"`
-	RefreshTokenID    string    `json:"refreshTokenID,omitempty"`
-	Audience          []string  `json:"audience"`
-	Scopes            []string  `json:"scopes"`
-	Expiration        time.Time `json:"expiration"`
-	PreferredLanguage string    `json:"preferredLanguage"`
+	TokenID             string    `json:"tokenId"`
+	ApplicationID       string    `json:"applicationId"`
+	UserAgentID         string    `json:"userAgentId"`
+	RefreshTokenID      string    `json:"refreshTokenID,omitempty"`
+	Audience            []string  `json:"audience"`
+	Scopes              []string  `json:"scopes"`
+	Expiration          time.Time `json:"expiration"`
+	PreferredLanguage   string    `json:"preferredLanguage"`
+	ConfirmationJKT     string    `json:"cnfJkt,omitempty"`
+	ConfirmationX5TS256 string    `json:"cnfX5tS256,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Data() interface{} {
@@ -240,6 +263,8 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	confirmationJKT,
+	confirmationX5TS256 string,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -247,14 +272,16 @@ func NewUserTokenAddedEvent(
 			aggregate,
 			UserTokenAddedType,
 		),
-		TokenID:           tokenID,
-		ApplicationID:     applicationID,
-		UserAgentID:       userAgentID,
-		RefreshTokenID:    refreshTokenID,
-		Audience:          audience,
-		Scopes:            scopes,
-		Expiration:        expiration,
-		PreferredLanguage: preferredLanguage,
+		TokenID:             tokenID,
+		ApplicationID:       applicationID,
+		UserAgentID:         userAgentID,
+		RefreshTokenID:      refreshTokenID,
+		Audience:            audience,
+		Scopes:              scopes,
+		Expiration:          expiration,
+		PreferredLanguage:   preferredLanguage,
+		ConfirmationJKT:     confirmationJKT,
+		ConfirmationX5TS256: confirmationX5TS256,
 	}
 }
 
@@ -0,0 +1,94 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	lifecycleEventPrefix          = userEventTypePrefix + "lifecycle."
+	UserLifecycleWarningAddedType = lifecycleEventPrefix + "warning.added"
+	UserLifecycleWarningSentType  = lifecycleEventPrefix + "warning.sent"
+)
+
+// UserLifecycleWarningAddedEvent is pushed by the user lifecycle job
+// before the user is deactivated or deleted because of the user lifecycle policy
+type UserLifecycleWarningAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Action  domain.UserLifecycleAction `json:"action"`
+	DueDate time.Time                  `json:"dueDate"`
+}
+
+func (e *UserLifecycleWarningAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserLifecycleWarningAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserLifecycleWarningAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	action domain.UserLifecycleAction,
+	dueDate time.Time,
+) *UserLifecycleWarningAddedEvent {
+	return &UserLifecycleWarningAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserLifecycleWarningAddedType,
+		),
+		Action:  action,
+		DueDate: dueDate,
+	}
+}
+
+func UserLifecycleWarningAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	warning := &UserLifecycleWarningAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, warning)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Lw3ad", "unable to unmarshal user lifecycle warning added")
+	}
+	return warning, nil
+}
+
+type UserLifecycleWarningSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *UserLifecycleWarningSentEvent) Data() interface{} {
+	return nil
+}
+
+func (e *UserLifecycleWarningSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserLifecycleWarningSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *UserLifecycleWarningSentEvent {
+	return &UserLifecycleWarningSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserLifecycleWarningSentType,
+		),
+	}
+}
+
+func UserLifecycleWarningSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &UserLifecycleWarningSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -0,0 +1,16 @@
+package userbulkjob
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, StartedEventType, StartedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RowImportedEventType, RowImportedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RowFailedEventType, RowFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, DoneEventType, DoneEventMapper).
+		RegisterFilterEventMapper(AggregateType, FailedEventType, FailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, CancelledEventType, CancelledEventMapper).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper)
+}
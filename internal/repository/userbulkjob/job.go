@@ -0,0 +1,381 @@
+package userbulkjob
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	AddedEventType       = eventTypePrefix + "added"
+	StartedEventType     = eventTypePrefix + "started"
+	RowImportedEventType = eventTypePrefix + "row.imported"
+	RowFailedEventType   = eventTypePrefix + "row.failed"
+	DoneEventType        = eventTypePrefix + "done"
+	FailedEventType      = eventTypePrefix + "failed"
+	CancelledEventType   = eventTypePrefix + "cancelled"
+	RemovedEventType     = eventTypePrefix + "removed"
+)
+
+type ExportFilter struct {
+	Type     domain.UserType  `json:"type,omitempty"`
+	State    domain.UserState `json:"state,omitempty"`
+	UserName string           `json:"userName,omitempty"`
+	Email    string           `json:"email,omitempty"`
+}
+
+func ExportFilterFromDomain(filter *domain.UserBulkExportFilter) *ExportFilter {
+	if filter == nil {
+		return nil
+	}
+	return &ExportFilter{
+		Type:     filter.Type,
+		State:    filter.State,
+		UserName: filter.UserName,
+		Email:    filter.Email,
+	}
+}
+
+func (f *ExportFilter) ToDomain() *domain.UserBulkExportFilter {
+	if f == nil {
+		return nil
+	}
+	return &domain.UserBulkExportFilter{
+		Type:     f.Type,
+		State:    f.State,
+		UserName: f.UserName,
+		Email:    f.Email,
+	}
+}
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	JobType domain.UserBulkJobType   `json:"jobType"`
+	Format  domain.UserBulkJobFormat `json:"format"`
+	// FileName is the uploaded file of an import
+	FileName string        `json:"fileName,omitempty"`
+	Total    uint64        `json:"total,omitempty"`
+	Filter   *ExportFilter `json:"filter,omitempty"`
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	jobType domain.UserBulkJobType,
+	format domain.UserBulkJobFormat,
+	fileName string,
+	total uint64,
+	filter *ExportFilter,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedEventType,
+		),
+		JobType:  jobType,
+		Format:   format,
+		FileName: fileName,
+		Total:    total,
+		Filter:   filter,
+	}
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USERBULK-Ubj1m", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type StartedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func NewStartedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *StartedEvent {
+	return &StartedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			StartedEventType,
+		),
+	}
+}
+
+func (e *StartedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *StartedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func StartedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &StartedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+// RowImportedEvent is pushed together with the events of the imported user,
+// so a resumed import continues after the last imported row
+type RowImportedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Row    uint64 `json:"row"`
+	UserID string `json:"userId"`
+}
+
+func NewRowImportedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	row uint64,
+	userID string,
+) *RowImportedEvent {
+	return &RowImportedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RowImportedEventType,
+		),
+		Row:    row,
+		UserID: userID,
+	}
+}
+
+func (e *RowImportedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RowImportedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func RowImportedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RowImportedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USERBULK-Ubj2m", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// RowFailedEvent reports a row which could not be imported
+type RowFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Row    uint64 `json:"row"`
+	UserID string `json:"userId,omitempty"`
+	// ErrorID and Error are the id and the message (key) of the error which prevented the import
+	ErrorID string `json:"errorId,omitempty"`
+	Error   string `json:"error"`
+}
+
+func NewRowFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	row uint64,
+	userID,
+	errorID,
+	message string,
+) *RowFailedEvent {
+	return &RowFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RowFailedEventType,
+		),
+		Row:     row,
+		UserID:  userID,
+		ErrorID: errorID,
+		Error:   message,
+	}
+}
+
+func (e *RowFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RowFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func RowFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RowFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USERBULK-Ubj3m", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type DoneEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// FileName is the created file of an export
+	FileName string `json:"fileName,omitempty"`
+	// Total is the amount of exported users
+	Total uint64 `json:"total,omitempty"`
+}
+
+func NewDoneEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	fileName string,
+	total uint64,
+) *DoneEvent {
+	return &DoneEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DoneEventType,
+		),
+		FileName: fileName,
+		Total:    total,
+	}
+}
+
+func (e *DoneEvent) Data() interface{} {
+	return e
+}
+
+func (e *DoneEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func DoneEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DoneEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USERBULK-Ubj4m", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type FailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	reason string,
+) *FailedEvent {
+	return &FailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			FailedEventType,
+		),
+		Reason: reason,
+	}
+}
+
+func (e *FailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *FailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func FailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &FailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USERBULK-Ubj5m", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type CancelledEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func NewCancelledEvent(ctx context.Context, aggregate *eventstore.Aggregate) *CancelledEvent {
+	return &CancelledEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CancelledEventType,
+		),
+	}
+}
+
+func (e *CancelledEvent) Data() interface{} {
+	return nil
+}
+
+func (e *CancelledEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func CancelledEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &CancelledEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func NewRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+	}
+}
+
+func (e *RemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func RemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
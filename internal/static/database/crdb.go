@@ -68,11 +68,12 @@ func (c *crdbStorage) PutObject(ctx context.Context, instanceID, location, resou
 		LastModified: updatedAt,
 		Location:     location,
 		ContentType:  contentType,
+		ObjectType:   objectType,
 	}, nil
 }
 
 func (c *crdbStorage) GetObject(ctx context.Context, instanceID, resourceOwner, name string) ([]byte, func() (*static.Asset, error), error) {
-	query, args, err := squirrel.Select(AssetColData, AssetColContentType, AssetColHash, AssetColUpdatedAt).
+	query, args, err := squirrel.Select(AssetColData, AssetColContentType, AssetColHash, AssetColUpdatedAt, AssetColType).
 		From(assetsTable).
 		Where(squirrel.Eq{
 			AssetColInstanceID:    instanceID,
@@ -85,6 +86,7 @@ func (c *crdbStorage) GetObject(ctx context.Context, instanceID, resourceOwner,
 		return nil, nil, caos_errors.ThrowInternal(err, "DATAB-GE3hz", "Errors.Internal")
 	}
 	var data []byte
+	var objectType sql.NullString
 	asset := &static.Asset{
 		InstanceID:    instanceID,
 		ResourceOwner: resourceOwner,
@@ -96,6 +98,7 @@ func (c *crdbStorage) GetObject(ctx context.Context, instanceID, resourceOwner,
 			&asset.ContentType,
 			&asset.Hash,
 			&asset.LastModified,
+			&objectType,
 		)
 	if err != nil {
 		if errs.Is(err, sql.ErrNoRows) {
@@ -104,6 +107,7 @@ func (c *crdbStorage) GetObject(ctx context.Context, instanceID, resourceOwner,
 		return nil, nil, caos_errors.ThrowInternal(err, "DATAB-Sfgb3", "Errors.Assets.Object.GetFailed")
 	}
 	asset.Size = int64(len(data))
+	asset.ObjectType = static.ObjectTypeFromString(objectType.String)
 	return data,
 		func() (*static.Asset, error) {
 			return asset, nil
@@ -112,7 +116,7 @@ func (c *crdbStorage) GetObject(ctx context.Context, instanceID, resourceOwner,
 }
 
 func (c *crdbStorage) GetObjectInfo(ctx context.Context, instanceID, resourceOwner, name string) (*static.Asset, error) {
-	query, args, err := squirrel.Select(AssetColContentType, AssetColLocation, "length("+AssetColData+")", AssetColHash, AssetColUpdatedAt).
+	query, args, err := squirrel.Select(AssetColContentType, AssetColLocation, "length("+AssetColData+")", AssetColHash, AssetColUpdatedAt, AssetColType).
 		From(assetsTable).
 		Where(squirrel.Eq{
 			AssetColInstanceID:    instanceID,
@@ -124,6 +128,7 @@ func (c *crdbStorage) GetObjectInfo(ctx context.Context, instanceID, resourceOwn
 	if err != nil {
 		return nil, caos_errors.ThrowInternal(err, "DATAB-rggt2", "Errors.Internal")
 	}
+	var objectType sql.NullString
 	asset := &static.Asset{
 		InstanceID:    instanceID,
 		ResourceOwner: resourceOwner,
@@ -136,10 +141,12 @@ func (c *crdbStorage) GetObjectInfo(ctx context.Context, instanceID, resourceOwn
 			&asset.Size,
 			&asset.Hash,
 			&asset.LastModified,
+			&objectType,
 		)
 	if err != nil {
 		return nil, caos_errors.ThrowInternal(err, "DATAB-Dbh2s", "Errors.Internal")
 	}
+	asset.ObjectType = static.ObjectTypeFromString(objectType.String)
 	return asset, nil
 }
 
@@ -18,6 +18,9 @@ import (
 
 var _ static.Storage = (*Minio)(nil)
 
+// objectTypeMetadata is the user metadata of the objects containing their static.ObjectType
+const objectTypeMetadata = "Object-Type"
+
 type Minio struct {
 	Client       *minio.Client
 	Location     string
@@ -32,7 +35,10 @@ func (m *Minio) PutObject(ctx context.Context, instanceID, location, resourceOwn
 	}
 	bucketName := m.prefixBucketName(instanceID)
 	objectName := fmt.Sprintf("%s/%s", resourceOwner, name)
-	info, err := m.Client.PutObject(ctx, bucketName, objectName, object, objectSize, minio.PutObjectOptions{ContentType: contentType})
+	info, err := m.Client.PutObject(ctx, bucketName, objectName, object, objectSize, minio.PutObjectOptions{
+		ContentType:  contentType,
+		UserMetadata: map[string]string{objectTypeMetadata: objectType.String()},
+	})
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "MINIO-590sw", "Errors.Assets.Object.PutFailed")
 	}
@@ -45,6 +51,7 @@ func (m *Minio) PutObject(ctx context.Context, instanceID, location, resourceOwn
 		LastModified:  info.LastModified,
 		Location:      info.Location,
 		ContentType:   contentType,
+		ObjectType:    objectType,
 	}, nil
 }
 
@@ -179,6 +186,7 @@ func (m *Minio) objectToAssetInfo(bucketName string, resourceOwner string, objec
 		Size:          object.Size,
 		LastModified:  object.LastModified,
 		ContentType:   object.ContentType,
+		ObjectType:    static.ObjectTypeFromString(object.UserMetadata[objectTypeMetadata]),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"io"
+	"strconv"
 	"time"
 )
 
@@ -24,6 +25,7 @@ type ObjectType int32
 const (
 	ObjectTypeUserAvatar ObjectType = iota
 	ObjectTypeStyling
+	ObjectTypeUserBulkJob
 )
 
 func (o ObjectType) String() string {
@@ -32,11 +34,29 @@ func (o ObjectType) String() string {
 		return "0"
 	case ObjectTypeStyling:
 		return "1"
+	case ObjectTypeUserBulkJob:
+		return "2"
 	default:
 		return ""
 	}
 }
 
+// ObjectTypeFromString returns the type of a stored object,
+// objects stored before their type was recorded are assets of the user avatar or styling
+func ObjectTypeFromString(objectType string) ObjectType {
+	t, err := strconv.Atoi(objectType)
+	if err != nil {
+		return ObjectTypeUserAvatar
+	}
+	return ObjectType(t)
+}
+
+// Public returns true if objects of the type are served by the assets API,
+// other objects are only readable through the API of their resource
+func (o ObjectType) Public() bool {
+	return o == ObjectTypeUserAvatar || o == ObjectTypeStyling
+}
+
 type Asset struct {
 	InstanceID    string
 	ResourceOwner string
@@ -46,6 +66,7 @@ type Asset struct {
 	LastModified  time.Time
 	Location      string
 	ContentType   string
+	ObjectType    ObjectType
 }
 
 func (a *Asset) VersionedName() string {
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errors "github.com/zitadel/zitadel/internal/errors"
 
@@ -8,20 +9,30 @@ import (
 )
 
 type TokenView struct {
-	ID                string
-	CreationDate      time.Time
-	ChangeDate        time.Time
-	ResourceOwner     string
-	UserID            string
-	ApplicationID     string
-	UserAgentID       string
-	Audience          []string
-	Expiration        time.Time
-	Scopes            []string
-	Sequence          uint64
-	PreferredLanguage string
-	RefreshTokenID    string
-	IsPAT             bool
+	ID                  string
+	CreationDate        time.Time
+	ChangeDate          time.Time
+	ResourceOwner       string
+	UserID              string
+	ApplicationID       string
+	UserAgentID         string
+	Audience            []string
+	Expiration          time.Time
+	Scopes              []string
+	Sequence            uint64
+	PreferredLanguage   string
+	RefreshTokenID      string
+	IsPAT               bool
+	ConfirmationJKT     string
+	ConfirmationX5TS256 string
+}
+
+// Confirmation returns the keys the token is bound to
+func (t *TokenView) Confirmation() *authz.TokenConfirmation {
+	return &authz.TokenConfirmation{
+		JKT:     t.ConfirmationJKT,
+		X5TS256: t.ConfirmationX5TS256,
+	}
 }
 
 type TokenSearchRequest struct {
@@ -49,6 +49,7 @@ type HumanView struct {
 	Region                   string
 	StreetAddress            string
 	OTPState                 MFAState
+	RecoveryCodesState       MFAState
 	U2FTokens                []*WebAuthNView
 	PasswordlessTokens       []*WebAuthNView
 	MFAMaxSetUp              domain.MFALevel
@@ -192,6 +193,10 @@ func (u *UserView) MFATypesAllowed(level domain.MFALevel, policy *domain.LoginPo
 				}
 			}
 		}
+		// recovery codes are only a fallback for the other second factors
+		if len(types) > 0 && u.RecoveryCodesState == MFAStateReady {
+			types = append(types, domain.MFATypeRecoveryCode)
+		}
 		//PLANNED: add sms
 	}
 	return types, required
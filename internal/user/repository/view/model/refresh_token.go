@@ -87,6 +87,7 @@ func (t *RefreshTokenView) AppendEventIfMyRefreshToken(event *es_models.Event) (
 			return err
 		}
 	case user_repo.HumanRefreshTokenRemovedType,
+		user_repo.HumanRefreshTokenReuseDetectedType,
 		user_repo.UserRemovedType,
 		user_repo.UserDeactivatedType,
 		user_repo.UserLockedType:
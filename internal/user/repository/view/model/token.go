@@ -26,40 +26,44 @@ const (
 )
 
 type TokenView struct {
-	ID                string               `json:"tokenId" gorm:"column:id;primary_key"`
-	CreationDate      time.Time            `json:"-" gorm:"column:creation_date"`
-	ChangeDate        time.Time            `json:"-" gorm:"column:change_date"`
-	ResourceOwner     string               `json:"-" gorm:"column:resource_owner"`
-	UserID            string               `json:"-" gorm:"column:user_id"`
-	ApplicationID     string               `json:"applicationId" gorm:"column:application_id"`
-	UserAgentID       string               `json:"userAgentId" gorm:"column:user_agent_id"`
-	Audience          database.StringArray `json:"audience" gorm:"column:audience"`
-	Scopes            database.StringArray `json:"scopes" gorm:"column:scopes"`
-	Expiration        time.Time            `json:"expiration" gorm:"column:expiration"`
-	Sequence          uint64               `json:"-" gorm:"column:sequence"`
-	PreferredLanguage string               `json:"preferredLanguage" gorm:"column:preferred_language"`
-	RefreshTokenID    string               `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
-	IsPAT             bool                 `json:"-" gorm:"is_pat"`
-	Deactivated       bool                 `json:"-" gorm:"-"`
-	InstanceID        string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
+	ID                  string               `json:"tokenId" gorm:"column:id;primary_key"`
+	CreationDate        time.Time            `json:"-" gorm:"column:creation_date"`
+	ChangeDate          time.Time            `json:"-" gorm:"column:change_date"`
+	ResourceOwner       string               `json:"-" gorm:"column:resource_owner"`
+	UserID              string               `json:"-" gorm:"column:user_id"`
+	ApplicationID       string               `json:"applicationId" gorm:"column:application_id"`
+	UserAgentID         string               `json:"userAgentId" gorm:"column:user_agent_id"`
+	Audience            database.StringArray `json:"audience" gorm:"column:audience"`
+	Scopes              database.StringArray `json:"scopes" gorm:"column:scopes"`
+	Expiration          time.Time            `json:"expiration" gorm:"column:expiration"`
+	Sequence            uint64               `json:"-" gorm:"column:sequence"`
+	PreferredLanguage   string               `json:"preferredLanguage" gorm:"column:preferred_language"`
+	RefreshTokenID      string               `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
+	IsPAT               bool                 `json:"-" gorm:"is_pat"`
+	ConfirmationJKT     string               `json:"cnfJkt,omitempty" gorm:"column:cnf_jkt"`
+	ConfirmationX5TS256 string               `json:"cnfX5tS256,omitempty" gorm:"column:cnf_x5t_s256"`
+	Deactivated         bool                 `json:"-" gorm:"-"`
+	InstanceID          string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
 
 func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 	return &usr_model.TokenView{
-		ID:                token.ID,
-		CreationDate:      token.CreationDate,
-		ChangeDate:        token.ChangeDate,
-		ResourceOwner:     token.ResourceOwner,
-		UserID:            token.UserID,
-		ApplicationID:     token.ApplicationID,
-		UserAgentID:       token.UserAgentID,
-		Audience:          token.Audience,
-		Scopes:            token.Scopes,
-		Expiration:        token.Expiration,
-		Sequence:          token.Sequence,
-		PreferredLanguage: token.PreferredLanguage,
-		RefreshTokenID:    token.RefreshTokenID,
-		IsPAT:             token.IsPAT,
+		ID:                  token.ID,
+		CreationDate:        token.CreationDate,
+		ChangeDate:          token.ChangeDate,
+		ResourceOwner:       token.ResourceOwner,
+		UserID:              token.UserID,
+		ApplicationID:       token.ApplicationID,
+		UserAgentID:         token.UserAgentID,
+		Audience:            token.Audience,
+		Scopes:              token.Scopes,
+		Expiration:          token.Expiration,
+		Sequence:            token.Sequence,
+		PreferredLanguage:   token.PreferredLanguage,
+		RefreshTokenID:      token.RefreshTokenID,
+		IsPAT:               token.IsPAT,
+		ConfirmationJKT:     token.ConfirmationJKT,
+		ConfirmationX5TS256: token.ConfirmationX5TS256,
 	}
 }
 
@@ -72,7 +76,8 @@ func (t *TokenView) AppendEventIfMyToken(event *es_models.Event) (err error) {
 		err = view.setData(event)
 	case user_repo.UserTokenRemovedType:
 		return t.appendTokenRemoved(event)
-	case user_repo.HumanRefreshTokenRemovedType:
+	case user_repo.HumanRefreshTokenRemovedType,
+		user_repo.HumanRefreshTokenReuseDetectedType:
 		return t.appendRefreshTokenRemoved(event)
 	case user_repo.UserV1SignedOutType,
 		user_repo.HumanSignedOutType:
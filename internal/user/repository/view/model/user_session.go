@@ -38,6 +38,7 @@ type UserSessionView struct {
 	SelectedIDPConfigID          string    `json:"selectedIDPConfigID" gorm:"column:selected_idp_config_id"`
 	PasswordVerification         time.Time `json:"-" gorm:"column:password_verification"`
 	PasswordlessVerification     time.Time `json:"-" gorm:"column:passwordless_verification"`
+	MagicLinkVerification        time.Time `json:"-" gorm:"column:magic_link_verification"`
 	ExternalLoginVerification    time.Time `json:"-" gorm:"column:external_login_verification"`
 	SecondFactorVerification     time.Time `json:"-" gorm:"column:second_factor_verification"`
 	SecondFactorVerificationType int32     `json:"-" gorm:"column:second_factor_verification_type"`
@@ -71,6 +72,7 @@ func UserSessionToModel(userSession *UserSessionView) *model.UserSessionView {
 		SelectedIDPConfigID:          userSession.SelectedIDPConfigID,
 		PasswordVerification:         userSession.PasswordVerification,
 		PasswordlessVerification:     userSession.PasswordlessVerification,
+		MagicLinkVerification:        userSession.MagicLinkVerification,
 		ExternalLoginVerification:    userSession.ExternalLoginVerification,
 		SecondFactorVerification:     userSession.SecondFactorVerification,
 		SecondFactorVerificationType: domain.MFAType(userSession.SecondFactorVerificationType),
@@ -114,6 +116,11 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		user.HumanPasswordlessTokenRemovedType:
 		v.PasswordlessVerification = time.Time{}
 		v.MultiFactorVerification = time.Time{}
+	case user.HumanMagicLinkCodeCheckSucceededType:
+		v.MagicLinkVerification = event.CreationDate
+		v.State = int32(domain.UserSessionStateActive)
+	case user.HumanMagicLinkCodeCheckFailedType:
+		v.MagicLinkVerification = time.Time{}
 	case user.UserV1PasswordCheckFailedType,
 		user.HumanPasswordCheckFailedType:
 		v.PasswordVerification = time.Time{}
@@ -144,7 +151,8 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		user.HumanMFAOTPCheckFailedType,
 		user.HumanMFAOTPRemovedType,
 		user.HumanU2FTokenCheckFailedType,
-		user.HumanU2FTokenRemovedType:
+		user.HumanU2FTokenRemovedType,
+		user.HumanRecoveryCodeCheckFailedType:
 		v.SecondFactorVerification = time.Time{}
 	case user.HumanU2FTokenVerifiedType:
 		data := new(es_model.WebAuthNVerify)
@@ -157,12 +165,15 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		}
 	case user.HumanU2FTokenCheckSucceededType:
 		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeU2F)
+	case user.HumanRecoveryCodeCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeRecoveryCode)
 	case user.UserV1SignedOutType,
 		user.HumanSignedOutType,
 		user.UserLockedType,
 		user.UserDeactivatedType:
 		v.PasswordlessVerification = time.Time{}
 		v.PasswordVerification = time.Time{}
+		v.MagicLinkVerification = time.Time{}
 		v.SecondFactorVerification = time.Time{}
 		v.SecondFactorVerificationType = int32(domain.MFALevelNotSetUp)
 		v.MultiFactorVerification = time.Time{}
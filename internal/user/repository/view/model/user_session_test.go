@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	es_model "github.com/zitadel/zitadel/internal/user/repository/eventsourcing/model"
@@ -59,6 +60,22 @@ func TestAppendEvent(t *testing.T) {
 			},
 			result: &UserSessionView{ChangeDate: now(), PasswordVerification: time.Time{}},
 		},
+		{
+			name: "append human magic link code check succeeded event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanMagicLinkCodeCheckSucceededType)},
+				userView: &UserSessionView{},
+			},
+			result: &UserSessionView{ChangeDate: now(), MagicLinkVerification: now()},
+		},
+		{
+			name: "append human magic link code check failed event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanMagicLinkCodeCheckFailedType)},
+				userView: &UserSessionView{MagicLinkVerification: now()},
+			},
+			result: &UserSessionView{ChangeDate: now(), MagicLinkVerification: time.Time{}},
+		},
 		{
 			name: "append user password changed event",
 			args: args{
@@ -176,6 +193,22 @@ func TestAppendEvent(t *testing.T) {
 			},
 			result: &UserSessionView{ChangeDate: now(), SecondFactorVerification: time.Time{}},
 		},
+		{
+			name: "append human recovery code check succeeded event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanRecoveryCodeCheckSucceededType)},
+				userView: &UserSessionView{},
+			},
+			result: &UserSessionView{ChangeDate: now(), SecondFactorVerification: now(), SecondFactorVerificationType: int32(domain.MFATypeRecoveryCode)},
+		},
+		{
+			name: "append human recovery code check failed event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanRecoveryCodeCheckFailedType)},
+				userView: &UserSessionView{SecondFactorVerification: now()},
+			},
+			result: &UserSessionView{ChangeDate: now(), SecondFactorVerification: time.Time{}},
+		},
 		{
 			name: "append user otp removed event",
 			args: args{
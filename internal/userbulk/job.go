@@ -0,0 +1,188 @@
+package userbulk
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/instancejob"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// BulkJobUserID is used as editor of the events created by the job
+	BulkJobUserID = "USER-BULK-JOB"
+
+	lockName = "user_bulk_jobs"
+
+	exportPageSize = 1000
+)
+
+type Config struct {
+	Enabled bool
+	// Interval defines how often the pending jobs are checked
+	Interval time.Duration
+	// LockDuration defines how long an instance is locked by a single run
+	LockDuration time.Duration
+}
+
+// job processes the queued and running user bulk imports and exports.
+// Jobs which were interrupted (e.g. by a restart) are resumed by the next run.
+type job struct {
+	commands *command.Commands
+	queries  *query.Queries
+}
+
+func Start(ctx context.Context, config Config, db *database.DB, es *eventstore.Eventstore, commands *command.Commands, queries *query.Queries) {
+	if !config.Enabled {
+		return
+	}
+	j := &job{
+		commands: commands,
+		queries:  queries,
+	}
+	instancejob.NewScheduler(lockName, config.Interval, config.LockDuration, db, es, j.runInstance).Start(ctx)
+}
+
+// runInstance processes all pending jobs of the instance
+func (j *job) runInstance(ctx context.Context, instanceID string) error {
+	jobs, err := j.queries.PendingUserBulkJobs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bulkJob := range jobs.Jobs {
+		err = j.process(authz.SetCtxData(ctx, authz.CtxData{UserID: BulkJobUserID, OrgID: bulkJob.ResourceOwner}), bulkJob)
+		logging.WithFields("instance", instanceID, "org", bulkJob.ResourceOwner, "job", bulkJob.ID).OnError(err).Warn("user bulk: unable to process job")
+	}
+	return nil
+}
+
+func (j *job) process(ctx context.Context, bulkJob *query.UserBulkJob) error {
+	switch bulkJob.Type {
+	case domain.UserBulkJobTypeImport:
+		return j.commands.ProcessUserBulkImportJob(ctx, bulkJob.ID, bulkJob.ResourceOwner)
+	case domain.UserBulkJobTypeExport:
+		return j.export(ctx, bulkJob)
+	}
+	return nil
+}
+
+// export writes the users matching the filter of the job into a file.
+// An interrupted export is started again from the beginning.
+func (j *job) export(ctx context.Context, bulkJob *query.UserBulkJob) error {
+	if err := j.commands.StartUserBulkJob(ctx, bulkJob.ID, bulkJob.ResourceOwner); err != nil {
+		return err
+	}
+	rows, err := j.exportRows(ctx, bulkJob.ResourceOwner, bulkJob.Filter)
+	if err != nil {
+		return err
+	}
+	file, err := domain.WriteUserBulkExportFile(bulkJob.Format, rows)
+	if err != nil {
+		return j.commands.FailUserBulkJob(ctx, bulkJob.ID, bulkJob.ResourceOwner, "Errors.UserBulkJob.FileInvalid")
+	}
+	_, err = j.commands.CompleteUserBulkExportJob(ctx, bulkJob.ID, bulkJob.ResourceOwner, file, uint64(len(rows)))
+	return err
+}
+
+func (j *job) exportRows(ctx context.Context, orgID string, filter *domain.UserBulkExportFilter) ([]*domain.UserBulkExportRow, error) {
+	queries, err := exportSearchQueries(orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*domain.UserBulkExportRow, 0)
+	for offset := uint64(0); ; offset += exportPageSize {
+		users, err := j.queries.SearchUsers(ctx, &query.UserSearchQueries{
+			SearchRequest: query.SearchRequest{
+				Offset:        offset,
+				Limit:         exportPageSize,
+				SortingColumn: query.UserIDCol,
+				Asc:           true,
+			},
+			Queries: queries,
+		}, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users.Users {
+			rows = append(rows, userToExportRow(user))
+		}
+		if len(users.Users) < exportPageSize {
+			return rows, nil
+		}
+	}
+}
+
+func exportSearchQueries(orgID string, filter *domain.UserBulkExportFilter) ([]query.SearchQuery, error) {
+	resourceOwnerQuery, err := query.NewUserResourceOwnerSearchQuery(orgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	queries := []query.SearchQuery{resourceOwnerQuery}
+	if filter == nil {
+		return queries, nil
+	}
+	if filter.Type != domain.UserTypeUnspecified {
+		typeQuery, err := query.NewUserTypeSearchQuery(int32(filter.Type))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, typeQuery)
+	}
+	if filter.State != domain.UserStateUnspecified {
+		stateQuery, err := query.NewUserStateSearchQuery(int32(filter.State))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, stateQuery)
+	}
+	if filter.UserName != "" {
+		userNameQuery, err := query.NewUserUsernameSearchQuery(filter.UserName, query.TextContainsIgnoreCase)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, userNameQuery)
+	}
+	if filter.Email != "" {
+		emailQuery, err := query.NewUserEmailSearchQuery(filter.Email, query.TextContainsIgnoreCase)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, emailQuery)
+	}
+	return queries, nil
+}
+
+func userToExportRow(user *query.User) *domain.UserBulkExportRow {
+	row := &domain.UserBulkExportRow{
+		UserID:   user.ID,
+		UserName: user.Username,
+		Type:     user.Type.Name(),
+		State:    user.State.Name(),
+	}
+	if user.Human != nil {
+		row.FirstName = user.Human.FirstName
+		row.LastName = user.Human.LastName
+		row.NickName = user.Human.NickName
+		row.DisplayName = user.Human.DisplayName
+		if user.Human.PreferredLanguage != language.Und {
+			row.PreferredLanguage = user.Human.PreferredLanguage.String()
+		}
+		row.Gender = user.Human.Gender.Name()
+		row.Email = string(user.Human.Email)
+		row.EmailVerified = user.Human.IsEmailVerified
+		row.Phone = string(user.Human.Phone)
+		row.PhoneVerified = user.Human.IsPhoneVerified
+	}
+	if user.Machine != nil {
+		row.DisplayName = user.Machine.Name
+	}
+	return row
+}
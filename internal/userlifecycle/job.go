@@ -0,0 +1,189 @@
+package userlifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/instancejob"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// LifecycleUserID is used as editor of the events created by the job
+	LifecycleUserID = "USER-LIFECYCLE"
+
+	lockName = "user_lifecycle"
+)
+
+type Config struct {
+	Enabled bool
+	// Interval defines how often the users are checked
+	Interval time.Duration
+	// LockDuration defines how long an instance is locked by a single run
+	LockDuration time.Duration
+}
+
+// job deactivates and deletes inactive users based on the user lifecycle policy of their organisation
+type job struct {
+	commands lifecycleCommands
+	queries  lifecycleQueries
+}
+
+type lifecycleCommands interface {
+	AddUserLifecycleWarning(ctx context.Context, orgID, userID string, action domain.UserLifecycleAction, dueDate time.Time) error
+	DeactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	RemoveUser(ctx context.Context, userID, resourceOwner string, cascadingUserMemberships []*command.CascadingMembership, cascadingGrantIDs ...string) (*domain.ObjectDetails, error)
+}
+
+type lifecycleQueries interface {
+	UserActivityResourceOwners(ctx context.Context) ([]string, error)
+	UserLifecycleReport(ctx context.Context, orgID string, now time.Time) (*query.UserLifecycleReport, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk, withOwnerRemoved bool) (*query.UserGrants, error)
+	Memberships(ctx context.Context, queries *query.MembershipSearchQuery, withOwnerRemoved bool) (*query.Memberships, error)
+}
+
+func Start(ctx context.Context, config Config, db *database.DB, es *eventstore.Eventstore, commands *command.Commands, queries *query.Queries) {
+	if !config.Enabled {
+		return
+	}
+	j := &job{
+		commands: commands,
+		queries:  queries,
+	}
+	instancejob.NewScheduler(lockName, config.Interval, config.LockDuration, db, es, j.runInstance).Start(ctx)
+}
+
+// runInstance processes all organisations of the instance
+func (j *job) runInstance(ctx context.Context, instanceID string) error {
+	orgIDs, err := j.queries.UserActivityResourceOwners(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, orgID := range orgIDs {
+		err = j.runOrg(ctx, orgID, now)
+		logging.WithFields("instance", instanceID, "org", orgID).OnError(err).Warn("user lifecycle: unable to process organisation")
+	}
+	return nil
+}
+
+func (j *job) runOrg(ctx context.Context, orgID string, now time.Time) error {
+	report, err := j.queries.UserLifecycleReport(ctx, orgID, now)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: LifecycleUserID, OrgID: orgID})
+	for _, candidate := range report.Candidates {
+		if report.Policy.DryRun {
+			logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "org", orgID, "user", candidate.UserID, "action", candidate.Action, "due", candidate.DueDate).
+				Info("user lifecycle: dry run")
+			continue
+		}
+		err = j.execute(ctx, candidate)
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "org", orgID, "user", candidate.UserID, "action", candidate.Action).
+			OnError(err).Warn("user lifecycle: unable to execute action")
+	}
+	return nil
+}
+
+func (j *job) execute(ctx context.Context, candidate *query.UserLifecycleCandidate) (err error) {
+	switch candidate.Action {
+	case domain.UserLifecycleActionWarnDeactivation,
+		domain.UserLifecycleActionWarnDeletion:
+		return j.commands.AddUserLifecycleWarning(ctx, candidate.ResourceOwner, candidate.UserID, candidate.Action, candidate.DueDate)
+	case domain.UserLifecycleActionDeactivate:
+		_, err = j.commands.DeactivateUser(ctx, candidate.UserID, candidate.ResourceOwner)
+		return err
+	case domain.UserLifecycleActionDelete:
+		memberships, grants, err := j.removeUserDependencies(ctx, candidate.UserID)
+		if err != nil {
+			return err
+		}
+		_, err = j.commands.RemoveUser(ctx, candidate.UserID, candidate.ResourceOwner, memberships, grants...)
+		return err
+	}
+	return nil
+}
+
+func (j *job) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
+	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := j.queries.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userGrantUserQuery},
+	}, true, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	membershipsUserQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := j.queries.Memberships(ctx, &query.MembershipSearchQuery{
+		Queries: []query.SearchQuery{membershipsUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cascadingMemberships(memberships.Memberships), userGrantsToIDs(grants.UserGrants), nil
+}
+
+func cascadingMemberships(memberships []*query.Membership) []*command.CascadingMembership {
+	cascades := make([]*command.CascadingMembership, len(memberships))
+	for i, membership := range memberships {
+		cascades[i] = &command.CascadingMembership{
+			UserID:        membership.UserID,
+			ResourceOwner: membership.ResourceOwner,
+			IAM:           cascadingIAMMembership(membership.IAM),
+			Org:           cascadingOrgMembership(membership.Org),
+			Project:       cascadingProjectMembership(membership.Project),
+			ProjectGrant:  cascadingProjectGrantMembership(membership.ProjectGrant),
+		}
+	}
+	return cascades
+}
+
+func cascadingIAMMembership(membership *query.IAMMembership) *command.CascadingIAMMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingIAMMembership{IAMID: membership.IAMID}
+}
+func cascadingOrgMembership(membership *query.OrgMembership) *command.CascadingOrgMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingOrgMembership{OrgID: membership.OrgID}
+}
+func cascadingProjectMembership(membership *query.ProjectMembership) *command.CascadingProjectMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingProjectMembership{ProjectID: membership.ProjectID}
+}
+func cascadingProjectGrantMembership(membership *query.ProjectGrantMembership) *command.CascadingProjectGrantMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingProjectGrantMembership{ProjectID: membership.ProjectID, GrantID: membership.GrantID}
+}
+
+func userGrantsToIDs(userGrants []*query.UserGrant) []string {
+	converted := make([]string, len(userGrants))
+	for i, grant := range userGrants {
+		converted[i] = grant.ID
+	}
+	return converted
+}
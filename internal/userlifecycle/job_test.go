@@ -0,0 +1,252 @@
+package userlifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type executedAction struct {
+	action        domain.UserLifecycleAction
+	userID        string
+	resourceOwner string
+	dueDate       time.Time
+	memberships   []*command.CascadingMembership
+	grantIDs      []string
+}
+
+type mockCommands struct {
+	err      error
+	executed []*executedAction
+}
+
+func (m *mockCommands) AddUserLifecycleWarning(_ context.Context, orgID, userID string, action domain.UserLifecycleAction, dueDate time.Time) error {
+	m.executed = append(m.executed, &executedAction{action: action, userID: userID, resourceOwner: orgID, dueDate: dueDate})
+	return m.err
+}
+
+func (m *mockCommands) DeactivateUser(_ context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	m.executed = append(m.executed, &executedAction{action: domain.UserLifecycleActionDeactivate, userID: userID, resourceOwner: resourceOwner})
+	return &domain.ObjectDetails{ResourceOwner: resourceOwner}, m.err
+}
+
+func (m *mockCommands) RemoveUser(_ context.Context, userID, resourceOwner string, memberships []*command.CascadingMembership, grantIDs ...string) (*domain.ObjectDetails, error) {
+	m.executed = append(m.executed, &executedAction{action: domain.UserLifecycleActionDelete, userID: userID, resourceOwner: resourceOwner, memberships: memberships, grantIDs: grantIDs})
+	return &domain.ObjectDetails{ResourceOwner: resourceOwner}, m.err
+}
+
+type mockQueries struct {
+	report         *query.UserLifecycleReport
+	reportErr      error
+	grants         []*query.UserGrant
+	memberships    []*query.Membership
+	membershipsErr error
+}
+
+func (m *mockQueries) UserActivityResourceOwners(context.Context) ([]string, error) {
+	return []string{"org1"}, nil
+}
+
+func (m *mockQueries) UserLifecycleReport(context.Context, string, time.Time) (*query.UserLifecycleReport, error) {
+	return m.report, m.reportErr
+}
+
+func (m *mockQueries) UserGrants(context.Context, *query.UserGrantsQueries, bool, bool) (*query.UserGrants, error) {
+	return &query.UserGrants{UserGrants: m.grants}, nil
+}
+
+func (m *mockQueries) Memberships(context.Context, *query.MembershipSearchQuery, bool) (*query.Memberships, error) {
+	return &query.Memberships{Memberships: m.memberships}, m.membershipsErr
+}
+
+func TestJob_runOrg(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	due := now.Add(7 * 24 * time.Hour)
+	type fields struct {
+		commands *mockCommands
+		queries  *mockQueries
+	}
+	type res struct {
+		err      func(error) bool
+		executed []*executedAction
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "no policy, nothing executed",
+			fields: fields{
+				commands: &mockCommands{},
+				queries: &mockQueries{
+					reportErr: errors.ThrowNotFound(nil, "QUERY-Ulc1n", "Errors.UserLifecyclePolicy.NotFound"),
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "report failed, error",
+			fields: fields{
+				commands: &mockCommands{},
+				queries: &mockQueries{
+					reportErr: errors.ThrowInternal(nil, "QUERY-Ulc2i", "Errors.Internal"),
+				},
+			},
+			res: res{
+				err: errors.IsInternal,
+			},
+		},
+		{
+			name: "dry run, nothing executed",
+			fields: fields{
+				commands: &mockCommands{},
+				queries: &mockQueries{
+					report: &query.UserLifecycleReport{
+						Policy: &query.UserLifecyclePolicy{DeactivateAfterDays: 90, DryRun: true},
+						Candidates: []*query.UserLifecycleCandidate{
+							{UserID: "user1", ResourceOwner: "org1", Action: domain.UserLifecycleActionDeactivate, DueDate: now},
+							{UserID: "user2", ResourceOwner: "org1", Action: domain.UserLifecycleActionDelete, DueDate: now},
+						},
+					},
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "warnings, warnings added",
+			fields: fields{
+				commands: &mockCommands{},
+				queries: &mockQueries{
+					report: &query.UserLifecycleReport{
+						Policy: &query.UserLifecyclePolicy{DeactivateAfterDays: 90, DeleteAfterDays: 30, WarnDaysBefore: 7},
+						Candidates: []*query.UserLifecycleCandidate{
+							{UserID: "user1", ResourceOwner: "org1", Action: domain.UserLifecycleActionWarnDeactivation, DueDate: due},
+							{UserID: "user2", ResourceOwner: "org1", Action: domain.UserLifecycleActionWarnDeletion, DueDate: due},
+						},
+					},
+				},
+			},
+			res: res{
+				executed: []*executedAction{
+					{action: domain.UserLifecycleActionWarnDeactivation, userID: "user1", resourceOwner: "org1", dueDate: due},
+					{action: domain.UserLifecycleActionWarnDeletion, userID: "user2", resourceOwner: "org1", dueDate: due},
+				},
+			},
+		},
+		{
+			name: "deactivate, user deactivated",
+			fields: fields{
+				commands: &mockCommands{},
+				queries: &mockQueries{
+					report: &query.UserLifecycleReport{
+						Policy: &query.UserLifecyclePolicy{DeactivateAfterDays: 90},
+						Candidates: []*query.UserLifecycleCandidate{
+							{UserID: "user1", ResourceOwner: "org1", Action: domain.UserLifecycleActionDeactivate, DueDate: now},
+						},
+					},
+				},
+			},
+			res: res{
+				executed: []*executedAction{
+					{action: domain.UserLifecycleActionDeactivate, userID: "user1", resourceOwner: "org1"},
+				},
+			},
+		},
+		{
+			name: "delete, user removed with memberships and grants",
+			fields: fields{
+				commands: &mockCommands{},
+				queries: &mockQueries{
+					report: &query.UserLifecycleReport{
+						Policy: &query.UserLifecyclePolicy{DeleteAfterDays: 30},
+						Candidates: []*query.UserLifecycleCandidate{
+							{UserID: "user1", ResourceOwner: "org1", Action: domain.UserLifecycleActionDelete, DueDate: now},
+						},
+					},
+					grants: []*query.UserGrant{{ID: "grant1"}, {ID: "grant2"}},
+					memberships: []*query.Membership{
+						{UserID: "user1", ResourceOwner: "org1", Org: &query.OrgMembership{OrgID: "org1"}},
+						{UserID: "user1", ResourceOwner: "org2", Project: &query.ProjectMembership{ProjectID: "project1"}},
+					},
+				},
+			},
+			res: res{
+				executed: []*executedAction{
+					{
+						action:        domain.UserLifecycleActionDelete,
+						userID:        "user1",
+						resourceOwner: "org1",
+						memberships: []*command.CascadingMembership{
+							{UserID: "user1", ResourceOwner: "org1", Org: &command.CascadingOrgMembership{OrgID: "org1"}},
+							{UserID: "user1", ResourceOwner: "org2", Project: &command.CascadingProjectMembership{ProjectID: "project1"}},
+						},
+						grantIDs: []string{"grant1", "grant2"},
+					},
+				},
+			},
+		},
+		{
+			name: "delete, memberships failed, user not removed",
+			fields: fields{
+				commands: &mockCommands{},
+				queries: &mockQueries{
+					report: &query.UserLifecycleReport{
+						Policy: &query.UserLifecyclePolicy{DeleteAfterDays: 30},
+						Candidates: []*query.UserLifecycleCandidate{
+							{UserID: "user1", ResourceOwner: "org1", Action: domain.UserLifecycleActionDelete, DueDate: now},
+						},
+					},
+					membershipsErr: errors.ThrowInternal(nil, "QUERY-Ulc3m", "Errors.Internal"),
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "action failed, remaining users processed",
+			fields: fields{
+				commands: &mockCommands{
+					err: errors.ThrowPreconditionFailed(nil, "COMMAND-Ulc4f", "Errors.User.AlreadyInactive"),
+				},
+				queries: &mockQueries{
+					report: &query.UserLifecycleReport{
+						Policy: &query.UserLifecyclePolicy{DeactivateAfterDays: 90},
+						Candidates: []*query.UserLifecycleCandidate{
+							{UserID: "user1", ResourceOwner: "org1", Action: domain.UserLifecycleActionDeactivate, DueDate: now},
+							{UserID: "user2", ResourceOwner: "org1", Action: domain.UserLifecycleActionDeactivate, DueDate: now},
+						},
+					},
+				},
+			},
+			res: res{
+				executed: []*executedAction{
+					{action: domain.UserLifecycleActionDeactivate, userID: "user1", resourceOwner: "org1"},
+					{action: domain.UserLifecycleActionDeactivate, userID: "user2", resourceOwner: "org1"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &job{
+				commands: tt.fields.commands,
+				queries:  tt.fields.queries,
+			}
+			err := j.runOrg(context.Background(), "org1", now)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.executed, tt.fields.commands.executed)
+		})
+	}
+}